@@ -29,23 +29,86 @@ type Client interface {
 	Error() error
 	Flush(context.Context) error
 	Echo(context.Context, string) (*types.ResponseEcho, error)
+
+	// CheckTxBatch executes CheckTx for every request and returns the
+	// responses in the same order. Applications implementing
+	// types.CheckTxBatchApplication receive all requests in a single call;
+	// otherwise the client falls back to one CheckTx per request, and the
+	// failure of individual requests is reported as a CheckTxBatchError
+	// alongside the responses of the others.
+	CheckTxBatch(context.Context, []*types.RequestCheckTx) ([]*types.ResponseCheckTx, error)
 }
 
 //----------------------------------------
 
 // NewClient returns a new ABCI client of the specified transport type.
-// It returns an error if the transport is not "socket" or "grpc"
+// It returns an error if the transport is not "socket", "grpc" or
+// "grpc-stream"
 func NewClient(logger log.Logger, addr, transport string, mustConnect bool) (Client, error) {
 	switch transport {
 	case "socket":
 		return NewSocketClient(logger, addr, mustConnect), nil
 	case "grpc":
 		return NewGRPCClient(logger, addr, mustConnect), nil
+	case "grpc-stream":
+		return NewGRPCStreamClient(logger, addr, mustConnect), nil
 	default:
 		return nil, fmt.Errorf("unknown abci transport %s", transport)
 	}
 }
 
+// CheckTxBatchError is returned by CheckTxBatch when some, but not
+// necessarily all, of the requests in a batch failed. Errors holds one entry
+// per request, nil for the requests that succeeded; the responses of failed
+// requests are nil.
+type CheckTxBatchError struct {
+	Errors []error
+}
+
+func (e CheckTxBatchError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d CheckTx requests failed, first error: %v", failed, len(e.Errors), first)
+}
+
+// checkTxSequential executes CheckTx for each request in turn. It is the
+// fallback used by clients that cannot batch requests. A failed request does
+// not prevent the remaining ones from being checked; its error is recorded in
+// a CheckTxBatchError instead.
+func checkTxSequential(
+	ctx context.Context,
+	app types.Application,
+	reqs []*types.RequestCheckTx,
+) ([]*types.ResponseCheckTx, error) {
+	var (
+		out  = make([]*types.ResponseCheckTx, len(reqs))
+		errs []error
+	)
+	for i, req := range reqs {
+		res, err := app.CheckTx(ctx, req)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(reqs))
+			}
+			errs[i] = err
+			continue
+		}
+		out[i] = res
+	}
+	if errs != nil {
+		return out, CheckTxBatchError{Errors: errs}
+	}
+	return out, nil
+}
+
 type requestAndResponse struct {
 	*types.Request
 	*types.Response
@@ -1,9 +1,10 @@
 // Package abciclient provides an ABCI implementation in Go.
 //
-// There are 3 clients available:
+// There are 4 clients available:
 //		1. socket (unix or TCP)
 //		2. local (in memory)
 //		3. gRPC
+//		4. gRPC stream
 //
 // ## Socket client
 //
@@ -17,4 +18,9 @@
 // ## gRPC client
 //
 // The client waits for all calls to complete.
+//
+// ## gRPC stream client
+//
+// The client behaves as the socket client, but exchanges messages over a
+// single gRPC bidirectional stream.
 package abciclient
@@ -134,6 +134,10 @@ func (cli *grpcClient) CheckTx(ctx context.Context, params *types.RequestCheckTx
 	return cli.client.CheckTx(ctx, types.ToRequestCheckTx(params).GetCheckTx(), grpc.WaitForReady(true))
 }
 
+func (cli *grpcClient) CheckTxBatch(ctx context.Context, reqs []*types.RequestCheckTx) ([]*types.ResponseCheckTx, error) {
+	return checkTxSequential(ctx, cli, reqs)
+}
+
 func (cli *grpcClient) Query(ctx context.Context, params *types.RequestQuery) (*types.ResponseQuery, error) {
 	return cli.client.Query(ctx, types.ToRequestQuery(params).GetQuery(), grpc.WaitForReady(true))
 }
@@ -0,0 +1,84 @@
+package abciclient
+
+import (
+	"container/list"
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// NewGRPCStreamClient creates a client which exchanges the messages of the
+// socket protocol over a gRPC bidirectional stream, to benefit from the flow
+// control of HTTP/2. It has the same ordering guarantees as the socket client:
+// requests are sent in the order they are queued and responses are matched to
+// them in that order, so Flush returns once all prior requests are answered.
+// If mustConnect is true, the client will return an error upon start if it
+// fails to connect.
+func NewGRPCStreamClient(logger log.Logger, addr string, mustConnect bool) Client {
+	cli := &socketClient{
+		logger:      logger,
+		reqQueue:    make(chan *requestAndResponse),
+		mustConnect: mustConnect,
+		addr:        addr,
+		reqSent:     list.New(),
+	}
+	cli.connect = cli.connectGRPCStream
+	cli.BaseService = *service.NewBaseService(logger, "grpcStreamClient", cli)
+	return cli
+}
+
+// grpcStreamConn exchanges messages over a gRPC bidirectional stream. gRPC
+// frames each message, and sends it as soon as the stream's flow control
+// allows.
+type grpcStreamConn struct {
+	conn   *grpc.ClientConn
+	stream types.ABCIStream_StreamClient
+	cancel context.CancelFunc
+}
+
+func (cli *socketClient) connectGRPCStream(ctx context.Context) (messageConn, error) {
+	conn, err := grpc.Dial(cli.addr,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialerFunc),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(types.MaxStreamMessageSize),
+			grpc.MaxCallSendMsgSize(types.MaxStreamMessageSize),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stream lives as long as the client, unless the connection is closed.
+	sctx, cancel := context.WithCancel(ctx)
+	stream, err := types.NewABCIStreamClient(conn).Stream(sctx, grpc.WaitForReady(!cli.mustConnect))
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, err
+	}
+	return &grpcStreamConn{
+		conn:   conn,
+		stream: stream,
+		cancel: cancel,
+	}, nil
+}
+
+func (c *grpcStreamConn) WriteRequest(req *types.Request) error {
+	return c.stream.Send(req)
+}
+
+func (c *grpcStreamConn) Flush() error { return nil }
+
+func (c *grpcStreamConn) ReadResponse() (*types.Response, error) {
+	return c.stream.Recv()
+}
+
+func (c *grpcStreamConn) Close() error {
+	c.cancel()
+	return c.conn.Close()
+}
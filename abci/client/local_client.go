@@ -2,6 +2,7 @@ package abciclient
 
 import (
 	"context"
+	"fmt"
 
 	types "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -38,3 +39,22 @@ func (*localClient) Flush(context.Context) error   { return nil }
 func (*localClient) Echo(_ context.Context, msg string) (*types.ResponseEcho, error) {
 	return &types.ResponseEcho{Message: msg}, nil
 }
+
+// CheckTxBatch hands all requests to the application in one call if it
+// implements types.CheckTxBatchApplication, and checks them one by one
+// otherwise.
+func (cli *localClient) CheckTxBatch(ctx context.Context, reqs []*types.RequestCheckTx) ([]*types.ResponseCheckTx, error) {
+	app, ok := cli.Application.(types.CheckTxBatchApplication)
+	if !ok {
+		return checkTxSequential(ctx, cli.Application, reqs)
+	}
+
+	res, err := app.CheckTxBatch(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != len(reqs) {
+		return nil, fmt.Errorf("application returned %d CheckTx responses for %d requests", len(res), len(reqs))
+	}
+	return res, nil
+}
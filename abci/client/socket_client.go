@@ -6,7 +6,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net"
 	"sync"
 	"time"
@@ -25,7 +24,8 @@ type socketClient struct {
 
 	addr        string
 	mustConnect bool
-	conn        net.Conn
+	connect     func(context.Context) (messageConn, error)
+	conn        messageConn
 
 	reqQueue chan *requestAndResponse
 
@@ -47,28 +47,69 @@ func NewSocketClient(logger log.Logger, addr string, mustConnect bool) Client {
 		addr:        addr,
 		reqSent:     list.New(),
 	}
+	cli.connect = cli.connectSocket
 	cli.BaseService = *service.NewBaseService(logger, "socketClient", cli)
 	return cli
 }
 
+// messageConn is a connection over which the client exchanges ABCI messages
+// with the server, which replies to requests in the order it receives them.
+type messageConn interface {
+	WriteRequest(*types.Request) error
+	Flush() error
+	ReadResponse() (*types.Response, error)
+	Close() error
+}
+
+// socketConn exchanges length-delimited messages over a socket.
+type socketConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+func (cli *socketClient) connectSocket(context.Context) (messageConn, error) {
+	conn, err := tmnet.Connect(cli.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &socketConn{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		w:    bufio.NewWriter(conn),
+	}, nil
+}
+
+func (c *socketConn) WriteRequest(req *types.Request) error {
+	return types.WriteMessage(req, c.w)
+}
+
+func (c *socketConn) Flush() error { return c.w.Flush() }
+
+func (c *socketConn) ReadResponse() (*types.Response, error) {
+	res := &types.Response{}
+	if err := types.ReadMessage(c.r, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *socketConn) Close() error { return c.conn.Close() }
+
 // OnStart implements Service by connecting to the server and spawning reading
 // and writing goroutines.
 func (cli *socketClient) OnStart(ctx context.Context) error {
-	var (
-		err  error
-		conn net.Conn
-	)
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 
 	for {
-		conn, err = tmnet.Connect(cli.addr)
+		conn, err := cli.connect(ctx)
 		if err != nil {
 			if cli.mustConnect {
 				return err
 			}
-			cli.logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying after %vs...",
-				cli.addr, dialRetryIntervalSeconds), "err", err)
+			cli.logger.Error(fmt.Sprintf("abci.%s failed to connect to %v.  Retrying after %vs...",
+				cli, cli.addr, dialRetryIntervalSeconds), "err", err)
 
 			timer.Reset(time.Second * dialRetryIntervalSeconds)
 			select {
@@ -105,8 +146,7 @@ func (cli *socketClient) Error() error {
 
 //----------------------------------------
 
-func (cli *socketClient) sendRequestsRoutine(ctx context.Context, conn io.Writer) {
-	bw := bufio.NewWriter(conn)
+func (cli *socketClient) sendRequestsRoutine(ctx context.Context, conn messageConn) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -117,12 +157,12 @@ func (cli *socketClient) sendRequestsRoutine(ctx context.Context, conn io.Writer
 			// unsolicited reply.
 			cli.trackRequest(reqres)
 
-			if err := types.WriteMessage(reqres.Request, bw); err != nil {
+			if err := conn.WriteRequest(reqres.Request); err != nil {
 				cli.stopForError(fmt.Errorf("write to buffer: %w", err))
 				return
 			}
 
-			if err := bw.Flush(); err != nil {
+			if err := conn.Flush(); err != nil {
 				cli.stopForError(fmt.Errorf("flush buffer: %w", err))
 				return
 			}
@@ -130,15 +170,14 @@ func (cli *socketClient) sendRequestsRoutine(ctx context.Context, conn io.Writer
 	}
 }
 
-func (cli *socketClient) recvResponseRoutine(ctx context.Context, conn io.Reader) {
-	r := bufio.NewReader(conn)
+func (cli *socketClient) recvResponseRoutine(ctx context.Context, conn messageConn) {
 	for {
 		if ctx.Err() != nil {
 			return
 		}
-		res := &types.Response{}
 
-		if err := types.ReadMessage(r, res); err != nil {
+		res, err := conn.ReadResponse()
+		if err != nil {
 			cli.stopForError(fmt.Errorf("read message: %w", err))
 			return
 		}
@@ -265,6 +304,40 @@ func (cli *socketClient) CheckTx(ctx context.Context, req *types.RequestCheckTx)
 	return res.GetCheckTx(), nil
 }
 
+// CheckTxBatch queues all requests on the connection before waiting for any
+// response, so the application receives them back to back rather than one
+// round trip at a time. Responses arrive in request order.
+func (cli *socketClient) CheckTxBatch(ctx context.Context, reqs []*types.RequestCheckTx) ([]*types.ResponseCheckTx, error) {
+	if !cli.IsRunning() {
+		return nil, errors.New("client has stopped")
+	}
+
+	pending := make([]*requestAndResponse, 0, len(reqs))
+	for _, req := range reqs {
+		reqres := makeReqRes(types.ToRequestCheckTx(req))
+		select {
+		case cli.reqQueue <- reqres:
+			pending = append(pending, reqres)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("can't queue req: %w", ctx.Err())
+		}
+	}
+
+	out := make([]*types.ResponseCheckTx, 0, len(pending))
+	for _, reqres := range pending {
+		select {
+		case <-reqres.signal:
+			if err := cli.Error(); err != nil {
+				return nil, err
+			}
+			out = append(out, reqres.Response.GetCheckTx())
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return out, nil
+}
+
 func (cli *socketClient) Query(ctx context.Context, req *types.RequestQuery) (*types.ResponseQuery, error) {
 	res, err := cli.doRequest(ctx, types.ToRequestQuery(req))
 	if err != nil {
@@ -418,6 +491,6 @@ func (cli *socketClient) stopForError(err error) {
 	cli.err = err
 	cli.mtx.Unlock()
 
-	cli.logger.Info("Stopping abci.socketClient", "reason", err)
+	cli.logger.Info(fmt.Sprintf("Stopping abci.%s", cli), "reason", err)
 	cli.Stop()
 }
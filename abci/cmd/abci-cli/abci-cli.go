@@ -111,7 +111,7 @@ func addGlobalFlags(cmd *cobra.Command) {
 		"",
 		"tcp://0.0.0.0:26658",
 		"address of application socket")
-	cmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket or grpc")
+	cmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket, grpc or grpc-stream")
 	cmd.PersistentFlags().BoolVarP(&flagVerbose,
 		"verbose",
 		"v",
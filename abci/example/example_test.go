@@ -55,6 +55,62 @@ func TestGRPC(t *testing.T) {
 	testGRPCSync(ctx, t, logger, types.NewBaseApplication())
 }
 
+// checkTxDataApp returns the checked tx as the data of the response.
+type checkTxDataApp struct {
+	*kvstore.Application
+}
+
+func (*checkTxDataApp) CheckTx(_ context.Context, req *types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	return &types.ResponseCheckTx{Code: code.CodeTypeOK, Data: req.Tx}, nil
+}
+
+func TestGRPCStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+
+	socketFile := fmt.Sprintf("/tmp/test-%08x.sock", rand.Int31n(1<<30))
+	defer os.Remove(socketFile)
+	socket := fmt.Sprintf("unix://%v", socketFile)
+
+	server := abciserver.NewGRPCStreamServer(logger.With("module", "abci-server"), socket, &checkTxDataApp{kvstore.NewApplication()})
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(server.Wait)
+
+	client := abciclient.NewGRPCStreamClient(logger.With("module", "abci-client"), socket, true)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	res, err := client.Echo(ctx, "hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", res.Message)
+
+	// responses are returned in request order
+	reqs := make([]*types.RequestCheckTx, 1000)
+	for i := range reqs {
+		reqs[i] = &types.RequestCheckTx{Tx: []byte(fmt.Sprintf("key%d=value%d", i, i))}
+	}
+	checkRes, err := client.CheckTxBatch(ctx, reqs)
+	require.NoError(t, err)
+	require.Len(t, checkRes, len(reqs))
+	for i, res := range checkRes {
+		require.Equal(t, code.CodeTypeOK, res.Code)
+		require.Equal(t, reqs[i].Tx, res.Data)
+	}
+
+	const numDeliverTxs = 100000
+	rfb := &types.RequestFinalizeBlock{Txs: make([][]byte, numDeliverTxs)}
+	for counter := 0; counter < numDeliverTxs; counter++ {
+		rfb.Txs[counter] = []byte("test")
+	}
+	finalizeRes, err := client.FinalizeBlock(ctx, rfb)
+	require.NoError(t, err)
+	require.Len(t, finalizeRes.TxResults, numDeliverTxs)
+
+	require.NoError(t, client.Flush(ctx))
+}
+
 func testBulk(ctx context.Context, t *testing.T, logger log.Logger, app types.Application) {
 	t.Helper()
 
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// GRPCStreamServer serves the socket protocol over gRPC bidirectional streams:
+// the requests of each stream are executed in the order they are received, and
+// answered in that order.
+type GRPCStreamServer struct {
+	service.BaseService
+	logger log.Logger
+
+	proto  string
+	addr   string
+	server *grpc.Server
+
+	app types.Application
+}
+
+// NewGRPCStreamServer returns a new gRPC streaming ABCI server
+func NewGRPCStreamServer(logger log.Logger, protoAddr string, app types.Application) service.Service {
+	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
+	s := &GRPCStreamServer{
+		logger: logger,
+		proto:  proto,
+		addr:   addr,
+		app:    app,
+	}
+	s.BaseService = *service.NewBaseService(logger, "ABCIServer", s)
+	return s
+}
+
+// OnStart starts the gRPC service.
+func (s *GRPCStreamServer) OnStart(ctx context.Context) error {
+	ln, err := net.Listen(s.proto, s.addr)
+	if err != nil {
+		return err
+	}
+
+	s.server = grpc.NewServer(
+		grpc.MaxRecvMsgSize(types.MaxStreamMessageSize),
+		grpc.MaxSendMsgSize(types.MaxStreamMessageSize),
+	)
+	types.RegisterABCIStreamServer(s.server, s)
+
+	s.logger.Info("Listening", "proto", s.proto, "addr", s.addr)
+	go func() {
+		go func() {
+			<-ctx.Done()
+			s.server.GracefulStop()
+		}()
+
+		if err := s.server.Serve(ln); err != nil {
+			s.logger.Error("error serving gRPC server", "err", err)
+		}
+	}()
+	return nil
+}
+
+// OnStop stops the gRPC server.
+func (s *GRPCStreamServer) OnStop() { s.server.Stop() }
+
+// Stream implements types.ABCIStreamServer.
+func (s *GRPCStreamServer) Stream(stream types.ABCIStream_StreamServer) (err error) {
+	defer func() {
+		// make sure to recover from any app-related panics to allow proper stream cleanup
+		if r := recover(); r != nil {
+			const size = 64 << 10
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+			err = fmt.Errorf("recovered from panic: %v\n%s", r, buf)
+		}
+		if err != nil {
+			s.logger.Error("Stream error", "err", err)
+		}
+	}()
+
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			s.logger.Info("Stream was closed by client")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading message: %w", err)
+		}
+
+		res, err := processRequest(ctx, s.app, req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(res); err != nil {
+			return fmt.Errorf("error writing message: %w", err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ Package server is used to start a new ABCI server.
 
 It contains two server implementation:
  * gRPC server
+ * gRPC streaming server
  * socket server
 
 */
@@ -24,6 +25,8 @@ func NewServer(logger log.Logger, protoAddr, transport string, app types.Applica
 		s = NewSocketServer(logger, protoAddr, app)
 	case "grpc":
 		s = NewGRPCServer(logger, protoAddr, app)
+	case "grpc-stream":
+		s = NewGRPCStreamServer(logger, protoAddr, app)
 	default:
 		err = fmt.Errorf("unknown server type %s", transport)
 	}
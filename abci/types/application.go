@@ -36,6 +36,15 @@ type Application interface {
 	LoadLatest(context.Context, *RequestLoadLatest) (*ResponseLoadLatest, error)
 }
 
+// CheckTxBatchApplication is an optional interface an Application can
+// implement to advertise that it validates several transactions in a single
+// call. The responses must be returned in the same order as the requests.
+type CheckTxBatchApplication interface {
+	Application
+
+	CheckTxBatch(context.Context, []*RequestCheckTx) ([]*ResponseCheckTx, error)
+}
+
 //-------------------------------------------------------
 // BaseApplication is a base form of Application
 
@@ -10,6 +10,10 @@ import (
 
 const (
 	maxMsgSize = 104857600 // 100MB
+
+	// MaxStreamMessageSize is the maximum size of a message exchanged over an
+	// ABCIStream, which is the same as over a socket connection.
+	MaxStreamMessageSize = maxMsgSize
 )
 
 // WriteMessage writes a varint length-delimited protobuf message.
@@ -58,6 +58,43 @@ func (CheckTxType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_252557cfdd89a31a, []int{0}
 }
 
+// EventAttributeType is a hint of how the indexer compares the value of an
+// event attribute in range queries. Values without a type are compared as
+// numbers if they look like one.
+type EventAttributeType int32
+
+const (
+	EventAttributeType_UNSPECIFIED EventAttributeType = 0
+	EventAttributeType_STRING      EventAttributeType = 1
+	EventAttributeType_INT         EventAttributeType = 2
+	EventAttributeType_DECIMAL     EventAttributeType = 3
+	EventAttributeType_TIME        EventAttributeType = 4
+)
+
+var EventAttributeType_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "STRING",
+	2: "INT",
+	3: "DECIMAL",
+	4: "TIME",
+}
+
+var EventAttributeType_value = map[string]int32{
+	"UNSPECIFIED": 0,
+	"STRING":      1,
+	"INT":         2,
+	"DECIMAL":     3,
+	"TIME":        4,
+}
+
+func (x EventAttributeType) String() string {
+	return proto.EnumName(EventAttributeType_name, int32(x))
+}
+
+func (EventAttributeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{1}
+}
+
 type MisbehaviorType int32
 
 const (
@@ -83,7 +120,7 @@ func (x MisbehaviorType) String() string {
 }
 
 func (MisbehaviorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{1}
+	return fileDescriptor_252557cfdd89a31a, []int{2}
 }
 
 type ResponseOfferSnapshot_Result int32
@@ -2784,6 +2821,10 @@ type ResponseCheckTx struct {
 	Codespace string `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
 	Sender    string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
 	Priority  int64  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	// sequence of the transaction for its sender, e.g. the account nonce. When
+	// transaction replacement is enabled in the mempool, a transaction replaces
+	// a pending one with the same sender and sequence if its priority is higher.
+	Sequence uint64 `protobuf:"varint,12,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -2868,6 +2909,13 @@ func (m *ResponseCheckTx) GetPriority() int64 {
 	return 0
 }
 
+func (m *ResponseCheckTx) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -3836,9 +3884,10 @@ func (m *Event) GetAttributes() []EventAttribute {
 
 // EventAttribute is a single key-value pair, associated with an event.
 type EventAttribute struct {
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Index bool   `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Key   []byte             `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte             `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Index bool               `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Type  EventAttributeType `protobuf:"varint,4,opt,name=type,proto3,enum=tendermint.abci.EventAttributeType" json:"type,omitempty"`
 }
 
 func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
@@ -3895,6 +3944,13 @@ func (m *EventAttribute) GetIndex() bool {
 	return false
 }
 
+func (m *EventAttribute) GetType() EventAttributeType {
+	if m != nil {
+		return m.Type
+	}
+	return EventAttributeType_UNSPECIFIED
+}
+
 // ExecTxResult contains results of executing one individual transaction.
 //
 // * Its structure is equivalent to #ResponseDeliverTx which will be deprecated/deleted
@@ -4632,11 +4688,13 @@ func (m *Evidence) GetTotalVotingPower() int64 {
 }
 
 type Snapshot struct {
-	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
-	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
-	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Height     uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format     uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks     uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash       []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata   []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	BaseHeight uint64 `protobuf:"varint,6,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
+	BaseHash   []byte `protobuf:"bytes,7,opt,name=base_hash,json=baseHash,proto3" json:"base_hash,omitempty"`
 }
 
 func (m *Snapshot) Reset()         { *m = Snapshot{} }
@@ -4707,8 +4765,23 @@ func (m *Snapshot) GetMetadata() []byte {
 	return nil
 }
 
+func (m *Snapshot) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+func (m *Snapshot) GetBaseHash() []byte {
+	if m != nil {
+		return m.BaseHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("tendermint.abci.EventAttributeType", EventAttributeType_name, EventAttributeType_value)
 	proto.RegisterEnum("tendermint.abci.MisbehaviorType", MisbehaviorType_name, MisbehaviorType_value)
 	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
 	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
@@ -4780,248 +4853,257 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 3856 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0x4b, 0x73, 0x1b, 0xd9,
-	0x75, 0x46, 0xe3, 0x8d, 0x83, 0x57, 0xf3, 0x92, 0xa2, 0x20, 0x68, 0x86, 0xe4, 0xf4, 0x94, 0x66,
-	0x24, 0xcd, 0x98, 0x74, 0xa8, 0x48, 0x96, 0xa2, 0x71, 0x26, 0x24, 0x04, 0x1a, 0x94, 0x28, 0x92,
-	0xd3, 0x04, 0x39, 0x99, 0x24, 0x56, 0xbb, 0x09, 0x5c, 0x02, 0x6d, 0x01, 0xe8, 0x76, 0x77, 0x83,
-	0x03, 0xce, 0x2a, 0x95, 0xc4, 0x1b, 0x67, 0x33, 0xcb, 0x2c, 0xe2, 0x5d, 0xfc, 0x07, 0xb2, 0x48,
-	0x65, 0x95, 0x55, 0x2a, 0xe5, 0x85, 0x17, 0x5e, 0xa4, 0x52, 0x59, 0x39, 0xa9, 0x99, 0x9d, 0xff,
-	0x40, 0x76, 0x71, 0xea, 0x3e, 0xfa, 0x05, 0x74, 0xe3, 0x21, 0xa9, 0x5c, 0x35, 0x95, 0xd9, 0xf5,
-	0xbd, 0x7d, 0xce, 0xb9, 0xaf, 0xf3, 0xba, 0xdf, 0xe9, 0x86, 0x9b, 0x36, 0x1e, 0xb4, 0xb1, 0xd9,
-	0xd7, 0x06, 0xf6, 0x96, 0x7a, 0xde, 0xd2, 0xb6, 0xec, 0x2b, 0x03, 0x5b, 0x9b, 0x86, 0xa9, 0xdb,
-	0x3a, 0x2a, 0x7b, 0x2f, 0x37, 0xc9, 0xcb, 0xea, 0xdb, 0x3e, 0xea, 0x96, 0x79, 0x65, 0xd8, 0xfa,
-	0x96, 0x61, 0xea, 0xfa, 0x05, 0xa3, 0xaf, 0xbe, 0x35, 0xf9, 0xfa, 0x25, 0xbe, 0xe2, 0xd2, 0x02,
-	0xcc, 0x74, 0x94, 0x2d, 0x43, 0x35, 0xd5, 0xbe, 0x15, 0xc2, 0xcc, 0x5e, 0xfb, 0xa6, 0x52, 0x5d,
-	0xef, 0xe8, 0x7a, 0xa7, 0x87, 0xb7, 0x68, 0xeb, 0x7c, 0x78, 0xb1, 0x65, 0x6b, 0x7d, 0x6c, 0xd9,
-	0x6a, 0xdf, 0xe0, 0x04, 0x2b, 0x1d, 0xbd, 0xa3, 0xd3, 0xc7, 0x2d, 0xf2, 0xc4, 0x7a, 0xa5, 0x5f,
-	0xe5, 0x21, 0x23, 0xe3, 0x9f, 0x0c, 0xb1, 0x65, 0xa3, 0x6d, 0x48, 0xe2, 0x56, 0x57, 0xaf, 0x08,
-	0x1b, 0xc2, 0xed, 0xfc, 0xf6, 0x5b, 0x9b, 0x63, 0x8b, 0xdb, 0xe4, 0x74, 0xf5, 0x56, 0x57, 0x6f,
-	0xc4, 0x64, 0x4a, 0x8b, 0xee, 0x43, 0xea, 0xa2, 0x37, 0xb4, 0xba, 0x95, 0x38, 0x65, 0x7a, 0x3b,
-	0x8a, 0x69, 0x8f, 0x10, 0x35, 0x62, 0x32, 0xa3, 0x26, 0x43, 0x69, 0x83, 0x0b, 0xbd, 0x92, 0x98,
-	0x3e, 0xd4, 0xfe, 0xe0, 0x82, 0x0e, 0x45, 0x68, 0xd1, 0x2e, 0x80, 0x36, 0xd0, 0x6c, 0xa5, 0xd5,
-	0x55, 0xb5, 0x41, 0x25, 0x49, 0x39, 0xdf, 0x89, 0xe6, 0xd4, 0xec, 0x1a, 0x21, 0x6c, 0xc4, 0xe4,
-	0x9c, 0xe6, 0x34, 0xc8, 0x74, 0x7f, 0x32, 0xc4, 0xe6, 0x55, 0x25, 0x35, 0x7d, 0xba, 0x9f, 0x10,
-	0x22, 0x32, 0x5d, 0x4a, 0x8d, 0x3e, 0x82, 0x6c, 0xab, 0x8b, 0x5b, 0x2f, 0x15, 0x7b, 0x54, 0xc9,
-	0x50, 0xce, 0xf5, 0x28, 0xce, 0x1a, 0xa1, 0x6b, 0x8e, 0x1a, 0x31, 0x39, 0xd3, 0x62, 0x8f, 0xe8,
-	0x21, 0xa4, 0x5b, 0x7a, 0xbf, 0xaf, 0xd9, 0x15, 0xa0, 0xbc, 0x6b, 0x91, 0xbc, 0x94, 0xaa, 0x11,
-	0x93, 0x39, 0x3d, 0x3a, 0x84, 0x52, 0x4f, 0xb3, 0x6c, 0xc5, 0x1a, 0xa8, 0x86, 0xd5, 0xd5, 0x6d,
-	0xab, 0x92, 0xa7, 0x12, 0x6e, 0x45, 0x49, 0x38, 0xd0, 0x2c, 0xfb, 0xc4, 0x21, 0x6e, 0xc4, 0xe4,
-	0x62, 0xcf, 0xdf, 0x41, 0xe4, 0xe9, 0x17, 0x17, 0xd8, 0x74, 0x05, 0x56, 0x0a, 0xd3, 0xe5, 0x1d,
-	0x11, 0x6a, 0x87, 0x9f, 0xc8, 0xd3, 0xfd, 0x1d, 0xe8, 0xcf, 0x61, 0xb9, 0xa7, 0xab, 0x6d, 0x57,
-	0x9c, 0xd2, 0xea, 0x0e, 0x07, 0x2f, 0x2b, 0x45, 0x2a, 0xf4, 0x4e, 0xe4, 0x24, 0x75, 0xb5, 0xed,
-	0x88, 0xa8, 0x11, 0x86, 0x46, 0x4c, 0x5e, 0xea, 0x8d, 0x77, 0xa2, 0x17, 0xb0, 0xa2, 0x1a, 0x46,
-	0xef, 0x6a, 0x5c, 0x7a, 0x89, 0x4a, 0xbf, 0x1b, 0x25, 0x7d, 0x87, 0xf0, 0x8c, 0x8b, 0x47, 0xea,
-	0x44, 0x2f, 0x6a, 0x82, 0x68, 0x98, 0xd8, 0x50, 0x4d, 0xac, 0x18, 0xa6, 0x6e, 0xe8, 0x96, 0xda,
-	0xab, 0x94, 0xa9, 0xec, 0xf7, 0xa3, 0x64, 0x1f, 0x33, 0xfa, 0x63, 0x4e, 0xde, 0x88, 0xc9, 0x65,
-	0x23, 0xd8, 0xc5, 0xa4, 0xea, 0x2d, 0x6c, 0x59, 0x9e, 0x54, 0x71, 0x96, 0x54, 0x4a, 0x1f, 0x94,
-	0x1a, 0xe8, 0x42, 0x75, 0xc8, 0xe3, 0x11, 0x61, 0x57, 0x2e, 0x75, 0x1b, 0x57, 0x96, 0xa8, 0x40,
-	0x29, 0xd2, 0x42, 0x29, 0xe9, 0x99, 0x6e, 0xe3, 0x46, 0x4c, 0x06, 0xec, 0xb6, 0x90, 0x0a, 0xd7,
-	0x2e, 0xb1, 0xa9, 0x5d, 0x5c, 0x51, 0x31, 0x0a, 0x7d, 0x63, 0x69, 0xfa, 0xa0, 0x82, 0xa8, 0xc0,
-	0x0f, 0xa2, 0x04, 0x9e, 0x51, 0x26, 0x22, 0xa2, 0xee, 0xb0, 0x34, 0x62, 0xf2, 0xf2, 0xe5, 0x64,
-	0x37, 0x51, 0xb1, 0x0b, 0x6d, 0xa0, 0xf6, 0xb4, 0x2f, 0xb0, 0x72, 0xde, 0xd3, 0x5b, 0x2f, 0x2b,
-	0xcb, 0xd3, 0x55, 0x6c, 0x8f, 0x53, 0xef, 0x12, 0x62, 0xa2, 0x62, 0x17, 0xfe, 0x0e, 0xb2, 0xf2,
-	0x73, 0xdc, 0xd1, 0x06, 0x5c, 0xd8, 0xca, 0xf4, 0x95, 0xef, 0x12, 0x52, 0x47, 0x12, 0x9c, 0xbb,
-	0x2d, 0xe2, 0x3c, 0xda, 0xb8, 0xa7, 0x5d, 0x62, 0x93, 0xd8, 0xf0, 0xb5, 0xe9, 0xce, 0xe3, 0x09,
-	0xa3, 0xa4, 0x56, 0x9c, 0x6b, 0x3b, 0x0d, 0xf4, 0x31, 0xe4, 0xc8, 0x09, 0xb0, 0x89, 0xac, 0x52,
-	0x11, 0x1b, 0x91, 0x47, 0x30, 0x68, 0x3b, 0xd3, 0xc8, 0x62, 0xfe, 0x4c, 0xd6, 0x42, 0xcd, 0xa5,
-	0xa7, 0xda, 0xd8, 0xb2, 0x2b, 0xd7, 0xa7, 0xaf, 0x85, 0x98, 0xc9, 0x01, 0xa5, 0x24, 0x6b, 0xe9,
-	0xb9, 0xad, 0xdd, 0x0c, 0xa4, 0x2e, 0xd5, 0xde, 0x10, 0x3f, 0x4d, 0x66, 0xd3, 0x62, 0xe6, 0x69,
-	0x32, 0x9b, 0x15, 0x73, 0x4f, 0x93, 0xd9, 0x9c, 0x08, 0xd2, 0xfb, 0x90, 0xf7, 0x79, 0x69, 0x54,
-	0x81, 0x4c, 0x1f, 0x5b, 0x96, 0xda, 0xc1, 0xd4, 0xa9, 0xe7, 0x64, 0xa7, 0x29, 0x95, 0xa0, 0xe0,
-	0xf7, 0xcc, 0xd2, 0x97, 0x82, 0xcb, 0x49, 0x9c, 0x2e, 0xe1, 0xbc, 0xc4, 0x26, 0xd5, 0x0d, 0xce,
-	0xc9, 0x9b, 0xe8, 0x5d, 0x28, 0xd2, 0x1d, 0x50, 0x9c, 0xf7, 0xc4, 0xf3, 0x27, 0xe5, 0x02, 0xed,
-	0x3c, 0xe3, 0x44, 0xeb, 0x90, 0x37, 0xb6, 0x0d, 0x97, 0x24, 0x41, 0x49, 0xc0, 0xd8, 0x36, 0x1c,
-	0x82, 0x77, 0xa0, 0x40, 0xd6, 0xea, 0x52, 0x24, 0xe9, 0x20, 0x79, 0xd2, 0xc7, 0x49, 0xa4, 0x5f,
-	0xc5, 0x41, 0x1c, 0xf7, 0xe6, 0xe8, 0x21, 0x24, 0x49, 0x60, 0xe3, 0x31, 0xaa, 0xba, 0xc9, 0xa2,
-	0xde, 0xa6, 0x13, 0xf5, 0x36, 0x9b, 0x4e, 0xd4, 0xdb, 0xcd, 0xfe, 0xf2, 0x37, 0xeb, 0xb1, 0x2f,
-	0xff, 0x6b, 0x5d, 0x90, 0x29, 0x07, 0xba, 0x41, 0x7c, 0xb8, 0xaa, 0x0d, 0x14, 0xad, 0x4d, 0xa7,
-	0x9c, 0x23, 0x0e, 0x5a, 0xd5, 0x06, 0xfb, 0x6d, 0x74, 0x00, 0x62, 0x4b, 0x1f, 0x58, 0x78, 0x60,
-	0x0d, 0x2d, 0x85, 0xc5, 0x5c, 0x1e, 0x99, 0x02, 0x2a, 0xc2, 0xc2, 0x6d, 0xcd, 0xa1, 0x3c, 0xa6,
-	0x84, 0x72, 0xb9, 0x15, 0xec, 0x40, 0x7b, 0x00, 0x97, 0x6a, 0x4f, 0x6b, 0xab, 0xb6, 0x6e, 0x5a,
-	0x95, 0xe4, 0x46, 0x22, 0x54, 0x4f, 0xce, 0x1c, 0x92, 0x53, 0xa3, 0xad, 0xda, 0x78, 0x37, 0x49,
-	0xa6, 0x2b, 0xfb, 0x38, 0xd1, 0x7b, 0x50, 0x56, 0x0d, 0x43, 0xb1, 0x6c, 0xd5, 0xc6, 0xca, 0xf9,
-	0x95, 0x8d, 0x2d, 0x1a, 0xb5, 0x0a, 0x72, 0x51, 0x35, 0x8c, 0x13, 0xd2, 0xbb, 0x4b, 0x3a, 0xd1,
-	0x2d, 0x28, 0x91, 0x00, 0xa7, 0xa9, 0x3d, 0xa5, 0x8b, 0xb5, 0x4e, 0xd7, 0xae, 0xa4, 0x37, 0x84,
-	0xdb, 0x09, 0xb9, 0xc8, 0x7b, 0x1b, 0xb4, 0x53, 0x6a, 0xbb, 0x27, 0x4e, 0x83, 0x1b, 0x42, 0x90,
-	0x6c, 0xab, 0xb6, 0x4a, 0x77, 0xb2, 0x20, 0xd3, 0x67, 0xd2, 0x67, 0xa8, 0x76, 0x97, 0xef, 0x0f,
-	0x7d, 0x46, 0xab, 0x90, 0xe6, 0x62, 0x13, 0x54, 0x2c, 0x6f, 0xa1, 0x15, 0x48, 0x19, 0xa6, 0x7e,
-	0x89, 0xe9, 0xd1, 0x65, 0x65, 0xd6, 0x90, 0x64, 0x28, 0x05, 0x03, 0x21, 0x2a, 0x41, 0xdc, 0x1e,
-	0xf1, 0x51, 0xe2, 0xf6, 0x08, 0x7d, 0x17, 0x92, 0x64, 0x23, 0xe9, 0x18, 0xa5, 0x90, 0xd0, 0xcf,
-	0xf9, 0x9a, 0x57, 0x06, 0x96, 0x29, 0xa5, 0x54, 0x86, 0x62, 0x20, 0x40, 0x4a, 0xab, 0xb0, 0x12,
-	0x16, 0xef, 0xa4, 0xae, 0xdb, 0x1f, 0x88, 0x5b, 0xe8, 0x3e, 0x64, 0xdd, 0x80, 0xc7, 0x14, 0xe7,
-	0xc6, 0xc4, 0xb0, 0x0e, 0xb1, 0xec, 0x92, 0x12, 0x8d, 0x21, 0x07, 0xd0, 0x55, 0x79, 0x7a, 0x53,
-	0x90, 0x33, 0xaa, 0x61, 0x34, 0x54, 0xab, 0x2b, 0xfd, 0x08, 0x2a, 0x51, 0xc1, 0xcc, 0xb7, 0x61,
-	0x02, 0x55, 0x7b, 0x67, 0xc3, 0x56, 0x21, 0x7d, 0xa1, 0x9b, 0x7d, 0xd5, 0xa6, 0xc2, 0x8a, 0x32,
-	0x6f, 0x91, 0x8d, 0x64, 0x81, 0x2d, 0x41, 0xbb, 0x59, 0x43, 0x52, 0xe0, 0x46, 0x64, 0x40, 0x23,
-	0x2c, 0xda, 0xa0, 0x8d, 0xd9, 0xb6, 0x16, 0x65, 0xd6, 0xf0, 0x04, 0xb1, 0xc9, 0xb2, 0x06, 0x19,
-	0xd6, 0xa2, 0x6b, 0xa5, 0xf2, 0x73, 0x32, 0x6f, 0x49, 0xff, 0x96, 0x86, 0xd5, 0xf0, 0xb0, 0x86,
-	0x36, 0xa0, 0xd0, 0x57, 0x47, 0x8a, 0x3d, 0xe2, 0x6a, 0x27, 0xd0, 0x83, 0x87, 0xbe, 0x3a, 0x6a,
-	0x8e, 0x98, 0xce, 0x89, 0x90, 0xb0, 0x47, 0x56, 0x25, 0xbe, 0x91, 0xb8, 0x5d, 0x90, 0xc9, 0x23,
-	0x3a, 0x85, 0xa5, 0x9e, 0xde, 0x52, 0x7b, 0x4a, 0x4f, 0xb5, 0x6c, 0x85, 0xe7, 0x3b, 0xcc, 0x88,
-	0xde, 0x9d, 0xd8, 0x6c, 0x16, 0xa0, 0x70, 0x9b, 0x9d, 0x27, 0x71, 0x38, 0x5c, 0xff, 0xcb, 0x54,
-	0xc6, 0x81, 0xea, 0x1c, 0x35, 0x3a, 0x85, 0x95, 0xf3, 0xab, 0x2f, 0xd4, 0x81, 0xad, 0x0d, 0xb0,
-	0x32, 0x61, 0x56, 0x93, 0xda, 0xf3, 0x5c, 0xb3, 0xce, 0x71, 0x57, 0xbd, 0xd4, 0x74, 0x93, 0x8b,
-	0x5c, 0x76, 0xf9, 0xcf, 0x3c, 0xdb, 0xf2, 0xce, 0x28, 0x15, 0x50, 0x6a, 0xc7, 0xbd, 0xa4, 0x17,
-	0x76, 0x2f, 0xdf, 0x85, 0x95, 0x01, 0x1e, 0xd9, 0xbe, 0x39, 0x32, 0xc5, 0xc9, 0xd0, 0xb3, 0x40,
-	0xe4, 0x9d, 0x37, 0x3e, 0xd1, 0x21, 0x74, 0x87, 0x66, 0x0a, 0x86, 0x6e, 0x61, 0x53, 0x51, 0xdb,
-	0x6d, 0x13, 0x5b, 0x56, 0x25, 0x4b, 0xa9, 0xcb, 0x4e, 0xff, 0x0e, 0xeb, 0x0e, 0x68, 0x62, 0x2e,
-	0xa0, 0x89, 0xe8, 0x7d, 0x28, 0x8f, 0x0f, 0x09, 0x94, 0xa2, 0x74, 0x19, 0x1c, 0xee, 0x16, 0x94,
-	0x3c, 0x27, 0x47, 0xe9, 0xf2, 0xcc, 0x9b, 0xb8, 0xbd, 0x94, 0xec, 0x26, 0xe4, 0x88, 0x2b, 0x60,
-	0x14, 0x05, 0x4a, 0x91, 0x25, 0x1d, 0xf4, 0xe5, 0xbb, 0x50, 0xc4, 0x97, 0x5a, 0x1b, 0x0f, 0x5a,
-	0x98, 0x11, 0x14, 0x29, 0x41, 0xc1, 0xe9, 0xa4, 0x44, 0xef, 0x41, 0x99, 0xea, 0x00, 0x8b, 0x12,
-	0x94, 0xac, 0xc4, 0x46, 0x22, 0xdd, 0x2c, 0x2a, 0x12, 0xba, 0x87, 0x70, 0xc3, 0x47, 0x67, 0xa8,
-	0xa6, 0xad, 0x58, 0xd8, 0x56, 0x6c, 0xdd, 0xe6, 0x89, 0x58, 0x42, 0xbe, 0xe6, 0x72, 0x1c, 0xab,
-	0xa6, 0x7d, 0x82, 0xed, 0x26, 0x79, 0x89, 0x1e, 0x40, 0x25, 0x8c, 0x93, 0x0e, 0x25, 0xd2, 0xa1,
-	0x56, 0xc6, 0x19, 0xe9, 0x88, 0xb7, 0x41, 0xf4, 0x69, 0x27, 0xa3, 0x5f, 0x62, 0x9b, 0xd5, 0x73,
-	0x55, 0x8e, 0x52, 0xde, 0x85, 0x25, 0x4a, 0x69, 0x62, 0x6b, 0xd8, 0xb3, 0xf9, 0x7e, 0x21, 0x76,
-	0x38, 0xe4, 0x85, 0xcc, 0xfa, 0xa9, 0x2f, 0xf8, 0x27, 0xbf, 0x21, 0x05, 0xd3, 0x36, 0x6e, 0x26,
-	0x82, 0x67, 0x26, 0x27, 0xb0, 0xc2, 0x0f, 0xb7, 0x1d, 0xb0, 0x14, 0x76, 0x7d, 0xba, 0x39, 0xe9,
-	0x0d, 0xc7, 0x2d, 0x04, 0x39, 0xec, 0x73, 0x18, 0x49, 0xe2, 0xf5, 0x8c, 0x04, 0x41, 0x92, 0xae,
-	0x3b, 0xc9, 0x22, 0x04, 0x79, 0xfe, 0x26, 0x1b, 0x0e, 0xcc, 0x34, 0x9c, 0xfc, 0x9c, 0x86, 0x53,
-	0x98, 0x69, 0x38, 0xc5, 0x59, 0x86, 0x53, 0x9a, 0xcf, 0x70, 0xca, 0x0b, 0x1b, 0x8e, 0xf8, 0xaa,
-	0x86, 0xb3, 0xb4, 0xa0, 0xe1, 0xa0, 0xf9, 0x0d, 0x67, 0x39, 0xdc, 0x70, 0x3e, 0x86, 0xa5, 0x89,
-	0x0b, 0x8b, 0xab, 0x74, 0x42, 0xa8, 0xd2, 0xc5, 0xfd, 0x4a, 0x27, 0xfd, 0xbd, 0x00, 0xd5, 0xe8,
-	0x1b, 0x4a, 0xa8, 0xa8, 0x0f, 0x60, 0xc9, 0x3d, 0x5e, 0x57, 0x79, 0x58, 0xbc, 0x14, 0xdd, 0x17,
-	0x8e, 0xf6, 0x44, 0xa5, 0x3e, 0xb7, 0xa0, 0x34, 0x76, 0x7f, 0x62, 0x26, 0x52, 0xbc, 0xf4, 0x8f,
-	0x2f, 0xfd, 0x63, 0xda, 0xcd, 0x47, 0x02, 0x97, 0x9c, 0x10, 0xb7, 0xf0, 0x09, 0x2c, 0xb7, 0x71,
-	0x4b, 0x6b, 0xbf, 0xaa, 0x57, 0x58, 0xe2, 0xdc, 0xdf, 0x3a, 0x85, 0x6f, 0x9d, 0xc2, 0x37, 0xdb,
-	0x29, 0xfc, 0x4d, 0xdc, 0xf5, 0x0a, 0xde, 0x65, 0x3e, 0xd4, 0x94, 0x1f, 0x10, 0xad, 0x53, 0x49,
-	0x62, 0xcb, 0xcc, 0xa4, 0x32, 0x79, 0x57, 0x6b, 0xd0, 0xf7, 0x5c, 0x9d, 0x39, 0x35, 0x3a, 0x0a,
-	0xce, 0xdb, 0x87, 0x43, 0x4e, 0x82, 0x7a, 0x9e, 0x3d, 0xf9, 0x8c, 0xcd, 0xb7, 0x3c, 0x7a, 0x57,
-	0x96, 0xa7, 0xe6, 0xa8, 0x93, 0x57, 0x8d, 0x3a, 0x3f, 0xdf, 0x29, 0x66, 0x26, 0xd5, 0xdd, 0xbb,
-	0xaf, 0x0b, 0x46, 0x4c, 0xdc, 0xa4, 0xde, 0x81, 0x82, 0xa5, 0x75, 0x14, 0x8a, 0xc2, 0x68, 0x98,
-	0xdd, 0x6a, 0xb3, 0x72, 0xde, 0xd2, 0x3a, 0x67, 0xbc, 0x4b, 0xba, 0x03, 0xe5, 0x31, 0x40, 0x62,
-	0xec, 0x7a, 0xe2, 0x39, 0xd3, 0x65, 0x77, 0xdf, 0x3d, 0xe0, 0x41, 0xfa, 0x45, 0x01, 0xb2, 0x32,
-	0xb6, 0x0c, 0xa2, 0xd4, 0x68, 0x17, 0x72, 0x78, 0xd4, 0xc2, 0x86, 0xed, 0xa0, 0x02, 0xe1, 0xe0,
-	0x05, 0xa3, 0xae, 0x3b, 0x94, 0x8d, 0x98, 0xec, 0xb1, 0xa1, 0x7b, 0x1c, 0x63, 0x8e, 0x86, 0x8b,
-	0x39, 0xbb, 0x1f, 0x64, 0x7e, 0xe0, 0x80, 0xcc, 0x89, 0x48, 0xfc, 0x94, 0x71, 0x8d, 0xa1, 0xcc,
-	0xf7, 0x38, 0xca, 0x9c, 0x9c, 0x31, 0x58, 0x00, 0x66, 0xae, 0x05, 0x60, 0xe6, 0xd4, 0x8c, 0x65,
-	0x46, 0xe0, 0xcc, 0x0f, 0x1c, 0x9c, 0x39, 0x3d, 0x63, 0xc6, 0x63, 0x40, 0xf3, 0xf7, 0x7d, 0x40,
-	0x73, 0x36, 0x12, 0x61, 0x62, 0xac, 0x21, 0x48, 0xf3, 0x23, 0x17, 0x69, 0xce, 0x47, 0xa2, 0xd4,
-	0x9c, 0x79, 0x1c, 0x6a, 0x3e, 0x9a, 0x80, 0x9a, 0x19, 0x34, 0xfc, 0x5e, 0xa4, 0x88, 0x19, 0x58,
-	0xf3, 0xd1, 0x04, 0xd6, 0x5c, 0x9c, 0x21, 0x70, 0x06, 0xd8, 0xfc, 0x17, 0xe1, 0x60, 0x73, 0x34,
-	0x1c, 0xcc, 0xa7, 0x39, 0x1f, 0xda, 0xac, 0x44, 0xa0, 0xcd, 0xe5, 0x48, 0x64, 0x94, 0x89, 0x9f,
-	0x1b, 0x6e, 0x3e, 0x0d, 0x81, 0x9b, 0x19, 0x30, 0x7c, 0x3b, 0x52, 0xf8, 0x1c, 0x78, 0xf3, 0x69,
-	0x08, 0xde, 0xbc, 0x34, 0x53, 0xec, 0x4c, 0xc0, 0x79, 0x2f, 0x08, 0x38, 0xa3, 0x88, 0x8b, 0xbc,
-	0x67, 0xed, 0x11, 0x88, 0xf3, 0x79, 0x14, 0xe2, 0xcc, 0x50, 0xe1, 0x0f, 0x23, 0x25, 0x2e, 0x00,
-	0x39, 0x1f, 0x4d, 0x40, 0xce, 0x2b, 0x33, 0x34, 0x6d, 0x06, 0xe6, 0xbc, 0x17, 0xc4, 0x9c, 0xaf,
-	0xcd, 0x58, 0x7c, 0x24, 0xe8, 0x5c, 0x0b, 0x80, 0xce, 0xab, 0x33, 0x5c, 0x49, 0x04, 0xea, 0xfc,
-	0x27, 0x7e, 0xd4, 0xf9, 0x7a, 0x24, 0x70, 0xcd, 0xcf, 0x21, 0x0c, 0x76, 0xde, 0x0b, 0xc2, 0xce,
-	0x95, 0x19, 0xcb, 0x99, 0x07, 0x77, 0xce, 0x88, 0x59, 0x86, 0x38, 0x3f, 0x4d, 0x66, 0x41, 0xcc,
-	0x4b, 0x77, 0x48, 0xf0, 0x18, 0x73, 0xfc, 0x68, 0x05, 0x52, 0xd8, 0x34, 0x75, 0x93, 0x23, 0xc8,
-	0xac, 0x21, 0xdd, 0x86, 0x82, 0xdf, 0xc9, 0x4f, 0xc1, 0xa8, 0x29, 0xee, 0xe7, 0x73, 0xec, 0xd2,
-	0x3f, 0x0b, 0x1e, 0x2f, 0x8d, 0xbc, 0x7e, 0x0c, 0x33, 0xc7, 0x31, 0x4c, 0x1f, 0x72, 0x1d, 0x0f,
-	0x22, 0xd7, 0xeb, 0x90, 0x27, 0x79, 0xdf, 0x18, 0x28, 0xad, 0x1a, 0x2e, 0x28, 0xed, 0xe4, 0x29,
-	0x3c, 0xd7, 0x62, 0x51, 0x32, 0x49, 0xa3, 0x64, 0xd9, 0xcb, 0xb6, 0x58, 0x6e, 0xfb, 0x1d, 0x58,
-	0xf6, 0xd1, 0xba, 0xf9, 0x24, 0x43, 0x68, 0x45, 0x97, 0x7a, 0x87, 0x03, 0x86, 0xff, 0x2a, 0x78,
-	0x3b, 0xe4, 0xa1, 0xd9, 0x61, 0xc0, 0xb3, 0xf0, 0x86, 0x80, 0xe7, 0xf8, 0x2b, 0x03, 0xcf, 0xfe,
-	0xfc, 0x38, 0x11, 0xc4, 0x3d, 0xff, 0x47, 0xf0, 0xce, 0xc4, 0x85, 0x91, 0x5b, 0x7a, 0x1b, 0x73,
-	0x24, 0x92, 0x3e, 0x93, 0xfb, 0x4d, 0x4f, 0xef, 0x70, 0xbc, 0x91, 0x3c, 0x12, 0x2a, 0x37, 0x12,
-	0xe7, 0x78, 0xa0, 0x75, 0x41, 0x4c, 0x76, 0x69, 0xe0, 0x20, 0xa6, 0x08, 0x89, 0x97, 0x98, 0xc5,
-	0xcd, 0x82, 0x4c, 0x1e, 0x09, 0x1d, 0x55, 0x3b, 0x9e, 0xfc, 0xb3, 0x06, 0x7a, 0x08, 0x39, 0x5a,
-	0x59, 0x57, 0x74, 0xc3, 0xe2, 0xa1, 0x32, 0x70, 0x4f, 0x62, 0xe5, 0xf5, 0xcd, 0x63, 0x42, 0x73,
-	0x64, 0x58, 0x72, 0xd6, 0xe0, 0x4f, 0xbe, 0x04, 0x28, 0x17, 0xb8, 0xad, 0xbc, 0x05, 0x39, 0x32,
-	0x7b, 0xcb, 0x50, 0x5b, 0x98, 0xde, 0x0b, 0x72, 0xb2, 0xd7, 0x21, 0xbd, 0x00, 0x34, 0x69, 0xef,
-	0xa8, 0x01, 0x69, 0x7c, 0x89, 0x07, 0x36, 0xbb, 0xcc, 0xe5, 0xb7, 0x57, 0x43, 0x92, 0x3d, 0x3c,
-	0xb0, 0x77, 0x2b, 0x64, 0x93, 0x7f, 0xfb, 0x9b, 0x75, 0x91, 0x51, 0x7f, 0xa8, 0xf7, 0x35, 0x1b,
-	0xf7, 0x0d, 0xfb, 0x4a, 0xe6, 0xfc, 0xd2, 0xbf, 0x0b, 0x24, 0x55, 0x0b, 0x44, 0xf6, 0xd0, 0xbd,
-	0x75, 0x54, 0x3e, 0xee, 0x83, 0xed, 0x27, 0xf7, 0xfb, 0x6d, 0x80, 0x8e, 0x6a, 0x29, 0x9f, 0xab,
-	0x03, 0x1b, 0xb7, 0xf9, 0x06, 0xe7, 0x3a, 0xaa, 0xf5, 0x29, 0xed, 0x08, 0x2e, 0x35, 0x3b, 0xb6,
-	0x54, 0x1f, 0x62, 0x9c, 0xf3, 0x23, 0xc6, 0xa8, 0x0a, 0x59, 0xc3, 0xd4, 0x74, 0x53, 0xb3, 0xaf,
-	0xe8, 0xfe, 0x24, 0x64, 0xb7, 0xfd, 0x34, 0x99, 0x4d, 0x8a, 0x29, 0xb7, 0x20, 0xc5, 0xdc, 0x43,
-	0x5e, 0x2c, 0x48, 0x3f, 0x8d, 0x7b, 0x7a, 0xef, 0x65, 0xb2, 0xaf, 0xbe, 0xb0, 0x30, 0x45, 0x5a,
-	0x0b, 0x59, 0xac, 0xaf, 0x87, 0xcc, 0x9b, 0xb4, 0x86, 0x16, 0x6e, 0xf3, 0xd2, 0x88, 0xdb, 0xf6,
-	0x1d, 0x60, 0xe6, 0xf5, 0x0e, 0x70, 0xfa, 0x9e, 0x4a, 0x7f, 0x4b, 0x8b, 0x59, 0x41, 0x27, 0x8d,
-	0x4e, 0xfc, 0x60, 0xc4, 0x90, 0x9a, 0xa3, 0xa3, 0x48, 0xf3, 0xda, 0xad, 0x07, 0x5a, 0xb0, 0x6e,
-	0x0b, 0xfd, 0x29, 0x5c, 0x1f, 0xf3, 0x29, 0xae, 0xe8, 0x78, 0x44, 0x46, 0x39, 0xee, 0x59, 0xae,
-	0x05, 0x3d, 0x8b, 0x23, 0xd9, 0xdb, 0xab, 0xc4, 0x6b, 0x2a, 0xfb, 0x7d, 0x28, 0x05, 0x13, 0x51,
-	0x72, 0x17, 0x36, 0xb1, 0xad, 0x6a, 0x03, 0x25, 0x80, 0xb8, 0x14, 0x58, 0x27, 0x2f, 0x61, 0x1d,
-	0xc3, 0xb5, 0xd0, 0xe4, 0x13, 0x7d, 0x0f, 0x72, 0x5e, 0xde, 0x2a, 0x44, 0x5c, 0xbb, 0xdc, 0x0a,
-	0x8f, 0x47, 0x2b, 0xfd, 0x8b, 0xe0, 0x89, 0x0c, 0xd6, 0x8c, 0xea, 0x90, 0x66, 0xd7, 0x55, 0xaa,
-	0xa4, 0xa5, 0xed, 0xef, 0xcc, 0x97, 0xb6, 0x6e, 0xb2, 0xbb, 0xac, 0xcc, 0x99, 0xa5, 0x17, 0x90,
-	0x66, 0x3d, 0x28, 0x0f, 0x99, 0xd3, 0xc3, 0x67, 0x87, 0x47, 0x9f, 0x1e, 0x8a, 0x31, 0x04, 0x90,
-	0xde, 0xa9, 0xd5, 0xea, 0xc7, 0x4d, 0x51, 0x40, 0x39, 0x48, 0xed, 0xec, 0x1e, 0xc9, 0x4d, 0x31,
-	0x4e, 0xba, 0xe5, 0xfa, 0xd3, 0x7a, 0xad, 0x29, 0x26, 0xd0, 0x12, 0x14, 0xd9, 0xb3, 0xb2, 0x77,
-	0x24, 0x3f, 0xdf, 0x69, 0x8a, 0x49, 0x5f, 0xd7, 0x49, 0xfd, 0xf0, 0x49, 0x5d, 0x16, 0x53, 0xd2,
-	0x1f, 0xc0, 0x8d, 0xc8, 0x44, 0xd7, 0x2b, 0x08, 0x09, 0xbe, 0x82, 0x90, 0xf4, 0x77, 0x71, 0xa8,
-	0x46, 0x67, 0xaf, 0xe8, 0xe9, 0xd8, 0xc2, 0xb7, 0x17, 0x48, 0x7d, 0xc7, 0x56, 0x8f, 0x6e, 0x41,
-	0xc9, 0xc4, 0x17, 0xd8, 0x6e, 0x75, 0x59, 0x36, 0xcd, 0xa2, 0x52, 0x51, 0x2e, 0xf2, 0x5e, 0xca,
-	0x64, 0x31, 0xb2, 0x1f, 0xe3, 0x96, 0xad, 0x30, 0x4f, 0xc3, 0x14, 0x2c, 0x47, 0xc8, 0x48, 0xef,
-	0x09, 0xeb, 0x94, 0x7e, 0xb4, 0xd0, 0x5e, 0xe6, 0x20, 0x25, 0xd7, 0x9b, 0xf2, 0x67, 0x62, 0x02,
-	0x21, 0x28, 0xd1, 0x47, 0xe5, 0xe4, 0x70, 0xe7, 0xf8, 0xa4, 0x71, 0x44, 0xf6, 0x72, 0x19, 0xca,
-	0xce, 0x5e, 0x3a, 0x9d, 0x29, 0xe9, 0x3f, 0xe2, 0x70, 0x3d, 0x22, 0xf7, 0x46, 0x0f, 0x01, 0xec,
-	0x91, 0x62, 0xe2, 0x96, 0x6e, 0xb6, 0xa3, 0x95, 0xac, 0x39, 0x92, 0x29, 0x85, 0x9c, 0xb3, 0xf9,
-	0x93, 0x35, 0xa5, 0x8e, 0x88, 0x3e, 0xe2, 0x42, 0x29, 0xfe, 0xc1, 0xcd, 0xea, 0xed, 0x90, 0x72,
-	0x19, 0x6e, 0x11, 0xc1, 0x74, 0x6f, 0xa9, 0x60, 0x4a, 0x8f, 0x9e, 0x87, 0xf9, 0x8f, 0x39, 0x0b,
-	0xce, 0x21, 0x9e, 0xe3, 0xb3, 0x68, 0xcf, 0x91, 0x9a, 0x37, 0x29, 0x09, 0x77, 0x1d, 0xd2, 0x3f,
-	0x24, 0xfc, 0x1b, 0x1b, 0xbc, 0x6a, 0x1c, 0x41, 0xda, 0xb2, 0x55, 0x7b, 0x68, 0x71, 0x85, 0xfb,
-	0xde, 0xbc, 0xf7, 0x96, 0x4d, 0xe7, 0xe1, 0x84, 0xb2, 0xcb, 0x5c, 0xcc, 0xb7, 0xfb, 0x4d, 0x1d,
-	0x6c, 0x70, 0x73, 0xa2, 0x4d, 0xc6, 0xf3, 0x39, 0x71, 0xe9, 0xb1, 0x97, 0xe4, 0xf8, 0x20, 0xf9,
-	0x49, 0xb8, 0x5b, 0x08, 0x83, 0xbb, 0x7f, 0x21, 0xc0, 0xcd, 0x29, 0xb7, 0x37, 0xf4, 0xc9, 0xd8,
-	0x39, 0x3f, 0x5a, 0xe4, 0xee, 0xb7, 0xc9, 0xfa, 0x82, 0x27, 0x2d, 0xdd, 0x83, 0x82, 0xbf, 0x7f,
-	0xbe, 0x45, 0xfe, 0x36, 0xee, 0xf9, 0xfc, 0x20, 0x2e, 0xff, 0xc6, 0xb2, 0xb9, 0x31, 0x3d, 0x8b,
-	0x2f, 0xa8, 0x67, 0xa1, 0x79, 0x41, 0xe2, 0x35, 0xf3, 0x82, 0x29, 0xda, 0x96, 0x7c, 0x3d, 0x6d,
-	0x0b, 0x18, 0x5c, 0x2a, 0x78, 0x61, 0x58, 0xf1, 0x34, 0xca, 0x07, 0x2b, 0x7e, 0x06, 0xe0, 0xc3,
-	0x4f, 0x57, 0x20, 0x65, 0xea, 0xc3, 0x41, 0x9b, 0xea, 0x45, 0x4a, 0x66, 0x0d, 0x74, 0x1f, 0x52,
-	0x44, 0xbf, 0x9c, 0xdd, 0x9b, 0x74, 0xb5, 0x44, 0x3f, 0x7c, 0xa8, 0x2c, 0xa3, 0x96, 0x7e, 0x08,
-	0xa5, 0x20, 0x68, 0xfb, 0x66, 0xc5, 0x6b, 0x80, 0x26, 0x3f, 0x5e, 0x88, 0x18, 0xe2, 0xfb, 0xc1,
-	0x21, 0xde, 0x89, 0xfc, 0x0c, 0x22, 0x7c, 0xa8, 0x2f, 0x20, 0x45, 0xd5, 0x8d, 0xe4, 0xbc, 0xf4,
-	0x8b, 0x19, 0x7e, 0xcb, 0x25, 0xcf, 0xe8, 0x87, 0x00, 0xaa, 0x6d, 0x9b, 0xda, 0xf9, 0xd0, 0x1b,
-	0x60, 0x3d, 0x5c, 0x5d, 0x77, 0x1c, 0xba, 0xdd, 0xb7, 0xb8, 0xde, 0xae, 0x78, 0xac, 0x3e, 0xdd,
-	0xf5, 0x09, 0x94, 0x0e, 0xa1, 0x14, 0xe4, 0x75, 0xee, 0x65, 0x42, 0xc8, 0xbd, 0x2c, 0xee, 0xbf,
-	0x97, 0xb9, 0xb7, 0xba, 0x04, 0xfb, 0x2c, 0x88, 0x36, 0xa4, 0xff, 0x15, 0xa0, 0xe0, 0xd7, 0xf6,
-	0x37, 0x7c, 0x03, 0x98, 0x71, 0xdd, 0xb9, 0x31, 0x71, 0x01, 0xc8, 0x74, 0x54, 0xeb, 0xf4, 0xf7,
-	0x99, 0xff, 0xff, 0x54, 0x80, 0xac, 0xbb, 0xf8, 0x08, 0x08, 0xde, 0xdb, 0xbb, 0xb8, 0xff, 0xb3,
-	0x1e, 0x06, 0xfb, 0x27, 0x5c, 0xd8, 0xff, 0xb1, 0x9b, 0xa0, 0x45, 0xe1, 0xda, 0xfe, 0x9d, 0x76,
-	0x8a, 0x1f, 0x3c, 0x1f, 0x35, 0xd9, 0x34, 0x48, 0x62, 0x82, 0xfe, 0x08, 0xd2, 0x6a, 0xcb, 0x05,
-	0xf3, 0x4b, 0x21, 0xd0, 0x94, 0x43, 0xba, 0xd9, 0x1c, 0xed, 0x50, 0x4a, 0x99, 0x73, 0xf0, 0x49,
-	0xc5, 0x9d, 0x49, 0x49, 0x55, 0x22, 0x77, 0xc7, 0x79, 0x07, 0xa7, 0x87, 0xcf, 0x8f, 0x9e, 0xec,
-	0xef, 0xed, 0xd7, 0x9f, 0x88, 0x31, 0xa9, 0x06, 0x79, 0xa7, 0x78, 0xa4, 0xf6, 0x2d, 0x74, 0x13,
-	0x72, 0x7d, 0x35, 0xf8, 0x69, 0x51, 0xb6, 0xaf, 0xf2, 0x0f, 0x8b, 0xae, 0x43, 0x86, 0xbc, 0xec,
-	0xa8, 0x96, 0x53, 0xeb, 0xed, 0xab, 0xa3, 0x1f, 0xa8, 0x96, 0xf4, 0x3b, 0x01, 0xca, 0x63, 0xee,
-	0x08, 0x6d, 0x43, 0x8a, 0xc1, 0x62, 0x51, 0x5f, 0xac, 0xfb, 0x86, 0x95, 0x19, 0x29, 0xfa, 0x08,
-	0xb2, 0x4e, 0x7d, 0x2d, 0xec, 0x3e, 0xc4, 0xfc, 0x9e, 0x53, 0xa1, 0xe1, 0xac, 0x2e, 0x07, 0xfa,
-	0x18, 0x72, 0xae, 0x63, 0x8d, 0xfe, 0x44, 0xd0, 0x75, 0xc9, 0x9c, 0xdf, 0xe3, 0x41, 0x8f, 0x3c,
-	0x74, 0x2a, 0x39, 0x09, 0xd1, 0x73, 0x76, 0x46, 0xc0, 0x99, 0x1d, 0x7a, 0xe9, 0x31, 0xe4, 0x5c,
-	0xc1, 0xa8, 0x02, 0x19, 0xa7, 0xca, 0x29, 0x70, 0x8f, 0xcb, 0xab, 0x9b, 0x2b, 0x90, 0x32, 0xf4,
-	0xcf, 0xf9, 0xe7, 0x5e, 0x09, 0x99, 0x35, 0xa4, 0x36, 0x94, 0xc7, 0x02, 0x05, 0x7a, 0x0c, 0x19,
-	0x63, 0x78, 0xae, 0x38, 0x56, 0x3d, 0xb6, 0x7f, 0x0e, 0x7e, 0x32, 0x3c, 0xef, 0x69, 0xad, 0x67,
-	0xf8, 0xca, 0xd1, 0x23, 0x63, 0x78, 0xfe, 0x8c, 0x19, 0x3f, 0x1b, 0x25, 0xee, 0x1f, 0xe5, 0x12,
-	0xb2, 0x8e, 0x2f, 0x43, 0x7f, 0xec, 0xdf, 0x2a, 0xe7, 0x73, 0xcd, 0xc8, 0xe0, 0xc5, 0xc5, 0xfb,
-	0x76, 0xea, 0x2e, 0x2c, 0x59, 0x5a, 0x67, 0xe0, 0x54, 0xc4, 0xd9, 0x41, 0xb3, 0x12, 0x57, 0x99,
-	0xbd, 0x38, 0x70, 0x40, 0x36, 0x92, 0x7a, 0x88, 0xe3, 0xce, 0xf4, 0xf7, 0x39, 0x81, 0x90, 0x14,
-	0x29, 0x11, 0x96, 0x22, 0xfd, 0x75, 0x1c, 0xf2, 0xbe, 0x3a, 0x3b, 0xfa, 0x43, 0x9f, 0x67, 0x2f,
-	0x85, 0xc4, 0x76, 0x1f, 0xad, 0xf7, 0x3d, 0x64, 0x70, 0x61, 0xf1, 0xc5, 0x17, 0x16, 0xf5, 0x59,
-	0x83, 0x53, 0xae, 0x4f, 0x2e, 0x5c, 0xae, 0xff, 0x10, 0x10, 0x2d, 0x34, 0x2b, 0x97, 0xba, 0xad,
-	0x0d, 0x3a, 0x0a, 0x53, 0x0d, 0xe6, 0x87, 0x45, 0xfa, 0xe6, 0x8c, 0xbe, 0x38, 0xa6, 0x5a, 0xf2,
-	0x97, 0x71, 0xc8, 0x3a, 0x16, 0xf6, 0xff, 0x74, 0x0b, 0xfe, 0x4a, 0x80, 0xac, 0x0b, 0x35, 0x2c,
-	0xfa, 0xc1, 0xe8, 0x2a, 0xa4, 0xf9, 0x6d, 0x9a, 0x7d, 0x31, 0xca, 0x5b, 0xa1, 0x9f, 0x66, 0x54,
-	0x21, 0xdb, 0xc7, 0xb6, 0x4a, 0xe3, 0x2a, 0x4b, 0xcd, 0xdc, 0xf6, 0xdd, 0x47, 0x90, 0xf7, 0x7d,
-	0x6c, 0x4b, 0x42, 0xed, 0x61, 0xfd, 0x53, 0x31, 0x56, 0xcd, 0xfc, 0xec, 0xe7, 0x1b, 0x89, 0x43,
-	0xfc, 0x39, 0x71, 0x32, 0x72, 0xbd, 0xd6, 0xa8, 0xd7, 0x9e, 0x89, 0x42, 0x35, 0xff, 0xb3, 0x9f,
-	0x6f, 0x64, 0x64, 0x4c, 0x4b, 0x8d, 0x77, 0x9f, 0x41, 0x79, 0xec, 0x60, 0x82, 0xb9, 0x37, 0x82,
-	0xd2, 0x93, 0xd3, 0xe3, 0x83, 0xfd, 0xda, 0x4e, 0xb3, 0xae, 0x9c, 0x1d, 0x35, 0xeb, 0xa2, 0x80,
-	0xae, 0xc3, 0xf2, 0xc1, 0xfe, 0x0f, 0x1a, 0x4d, 0xa5, 0x76, 0xb0, 0x5f, 0x3f, 0x6c, 0x2a, 0x3b,
-	0xcd, 0xe6, 0x4e, 0xed, 0x99, 0x18, 0xdf, 0xfe, 0x5d, 0x1e, 0xca, 0x3b, 0xbb, 0xb5, 0xfd, 0x1d,
-	0xc3, 0xe8, 0x69, 0x2d, 0x95, 0xc6, 0x90, 0x1a, 0x24, 0x69, 0x75, 0x60, 0xea, 0x5f, 0x48, 0xd5,
-	0xe9, 0xf5, 0x63, 0xb4, 0x07, 0x29, 0x5a, 0x38, 0x40, 0xd3, 0x7f, 0x4b, 0xaa, 0xce, 0x28, 0x28,
-	0x93, 0xc9, 0x50, 0x8f, 0x32, 0xf5, 0x3f, 0xa5, 0xea, 0xf4, 0xfa, 0x32, 0x3a, 0x80, 0x8c, 0x83,
-	0xeb, 0xce, 0xfa, 0x79, 0xa8, 0x3a, 0xb3, 0xe8, 0x4b, 0x96, 0xc6, 0xf0, 0xf7, 0xe9, 0xbf, 0x30,
-	0x55, 0x67, 0x54, 0x9e, 0xd1, 0x3e, 0xa4, 0x39, 0x02, 0x37, 0xe3, 0xaf, 0xa4, 0xea, 0xac, 0x5a,
-	0x32, 0x92, 0x21, 0xe7, 0x55, 0x36, 0x66, 0xff, 0x98, 0x55, 0x9d, 0xa3, 0xa8, 0x8e, 0x5e, 0x40,
-	0x31, 0x88, 0xf4, 0xcd, 0xf7, 0xe7, 0x53, 0x75, 0xce, 0xaa, 0x35, 0x91, 0x1f, 0x84, 0xfd, 0xe6,
-	0xfb, 0x13, 0xaa, 0x3a, 0x67, 0x11, 0x1b, 0xfd, 0x18, 0x96, 0x26, 0x61, 0xb9, 0xf9, 0x7f, 0x8c,
-	0xaa, 0x2e, 0x50, 0xd6, 0x46, 0x7d, 0x40, 0x21, 0x70, 0xde, 0x02, 0xff, 0x49, 0x55, 0x17, 0xa9,
-	0x72, 0xa3, 0x36, 0x94, 0xc7, 0x21, 0xb2, 0x79, 0xff, 0x9b, 0xaa, 0xce, 0x5d, 0xf1, 0x66, 0xa3,
-	0x04, 0xf1, 0xa2, 0x79, 0xff, 0xa3, 0xaa, 0xce, 0x5d, 0x00, 0x47, 0xa7, 0x00, 0x3e, 0xbc, 0x63,
-	0x8e, 0xff, 0xaa, 0xaa, 0xf3, 0x94, 0xc2, 0x91, 0x01, 0xcb, 0x61, 0x40, 0xc8, 0x22, 0xbf, 0x59,
-	0x55, 0x17, 0xaa, 0x90, 0x13, 0x7d, 0x0e, 0x42, 0x1a, 0xf3, 0xfd, 0x76, 0x55, 0x9d, 0xb3, 0x54,
-	0x4e, 0x36, 0xca, 0xbb, 0xc6, 0xa3, 0x39, 0x7e, 0x5d, 0xaa, 0xce, 0x53, 0x67, 0xde, 0xad, 0xff,
-	0xf2, 0xab, 0x35, 0xe1, 0xd7, 0x5f, 0xad, 0x09, 0xff, 0xfd, 0xd5, 0x9a, 0xf0, 0xe5, 0xd7, 0x6b,
-	0xb1, 0x5f, 0x7f, 0xbd, 0x16, 0xfb, 0xcf, 0xaf, 0xd7, 0x62, 0x7f, 0xf6, 0x41, 0x47, 0xb3, 0xbb,
-	0xc3, 0xf3, 0xcd, 0x96, 0xde, 0xdf, 0xf2, 0xff, 0xff, 0x1a, 0xf6, 0x53, 0xee, 0x79, 0x9a, 0xc6,
-	0xe9, 0x7b, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0x0d, 0x29, 0x84, 0xba, 0xb4, 0x3b, 0x00, 0x00,
+	// 3985 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0xcd, 0x93, 0xe3, 0xd6,
+	0x71, 0x27, 0xf8, 0xcd, 0xe6, 0x17, 0xf8, 0x86, 0xbb, 0xcb, 0xe5, 0x4a, 0xbb, 0x2b, 0x6c, 0x49,
+	0x5a, 0xad, 0xe4, 0x59, 0x65, 0x14, 0x49, 0xab, 0xc8, 0x8e, 0x33, 0xc3, 0xe5, 0x88, 0xb3, 0x3b,
+	0x3b, 0x33, 0xc2, 0x70, 0x56, 0x51, 0x12, 0x0b, 0x06, 0xc9, 0x37, 0x24, 0xbc, 0x24, 0x01, 0x03,
+	0xe0, 0x88, 0xa3, 0x53, 0xaa, 0x12, 0x57, 0xaa, 0x9c, 0x8b, 0x8e, 0x39, 0xc4, 0xb7, 0xf8, 0x1f,
+	0xc8, 0x21, 0x95, 0x53, 0x2a, 0x87, 0x54, 0xca, 0x07, 0x1f, 0x7c, 0x4a, 0xe5, 0xe4, 0xa4, 0xa4,
+	0x9b, 0x4f, 0xb9, 0xe5, 0x16, 0xa7, 0xde, 0x17, 0x08, 0x90, 0x00, 0x3f, 0xb4, 0x2a, 0x57, 0xa9,
+	0xac, 0x1b, 0x5e, 0xa3, 0xbb, 0xdf, 0x07, 0xba, 0xfb, 0xf5, 0xfb, 0xf5, 0x03, 0xdc, 0x70, 0xf1,
+	0xb8, 0x87, 0xed, 0x91, 0x31, 0x76, 0xef, 0xeb, 0x9d, 0xae, 0x71, 0xdf, 0xbd, 0xb4, 0xb0, 0xb3,
+	0x6d, 0xd9, 0xa6, 0x6b, 0xa2, 0xf2, 0xec, 0xe5, 0x36, 0x79, 0x59, 0x7f, 0xd1, 0xc7, 0xdd, 0xb5,
+	0x2f, 0x2d, 0xd7, 0xbc, 0x6f, 0xd9, 0xa6, 0x79, 0xce, 0xf8, 0xeb, 0x2f, 0x2c, 0xbe, 0x7e, 0x86,
+	0x2f, 0xb9, 0xb6, 0x80, 0x30, 0xed, 0xe5, 0xbe, 0xa5, 0xdb, 0xfa, 0xc8, 0x09, 0x11, 0x66, 0xaf,
+	0x7d, 0x43, 0xa9, 0xdf, 0xea, 0x9b, 0x66, 0x7f, 0x88, 0xef, 0xd3, 0x56, 0x67, 0x72, 0x7e, 0xdf,
+	0x35, 0x46, 0xd8, 0x71, 0xf5, 0x91, 0xc5, 0x19, 0xaa, 0x7d, 0xb3, 0x6f, 0xd2, 0xc7, 0xfb, 0xe4,
+	0x89, 0x51, 0x95, 0x5f, 0xe6, 0x21, 0xa3, 0xe2, 0x1f, 0x4f, 0xb0, 0xe3, 0xa2, 0x1d, 0x48, 0xe2,
+	0xee, 0xc0, 0xac, 0x49, 0xb7, 0xa5, 0xbb, 0xf9, 0x9d, 0x17, 0xb6, 0xe7, 0x26, 0xb7, 0xcd, 0xf9,
+	0x9a, 0xdd, 0x81, 0xd9, 0x8a, 0xa9, 0x94, 0x17, 0xbd, 0x0d, 0xa9, 0xf3, 0xe1, 0xc4, 0x19, 0xd4,
+	0xe2, 0x54, 0xe8, 0xc5, 0x28, 0xa1, 0x7d, 0xc2, 0xd4, 0x8a, 0xa9, 0x8c, 0x9b, 0x74, 0x65, 0x8c,
+	0xcf, 0xcd, 0x5a, 0x62, 0x79, 0x57, 0x07, 0xe3, 0x73, 0xda, 0x15, 0xe1, 0x45, 0x7b, 0x00, 0xc6,
+	0xd8, 0x70, 0xb5, 0xee, 0x40, 0x37, 0xc6, 0xb5, 0x24, 0x95, 0x7c, 0x29, 0x5a, 0xd2, 0x70, 0x1b,
+	0x84, 0xb1, 0x15, 0x53, 0x73, 0x86, 0x68, 0x90, 0xe1, 0xfe, 0x78, 0x82, 0xed, 0xcb, 0x5a, 0x6a,
+	0xf9, 0x70, 0x3f, 0x24, 0x4c, 0x64, 0xb8, 0x94, 0x1b, 0x7d, 0x17, 0xb2, 0xdd, 0x01, 0xee, 0x3e,
+	0xd3, 0xdc, 0x69, 0x2d, 0x43, 0x25, 0x6f, 0x45, 0x49, 0x36, 0x08, 0x5f, 0x7b, 0xda, 0x8a, 0xa9,
+	0x99, 0x2e, 0x7b, 0x44, 0x0f, 0x20, 0xdd, 0x35, 0x47, 0x23, 0xc3, 0xad, 0x01, 0x95, 0xbd, 0x19,
+	0x29, 0x4b, 0xb9, 0x5a, 0x31, 0x95, 0xf3, 0xa3, 0x23, 0x28, 0x0d, 0x0d, 0xc7, 0xd5, 0x9c, 0xb1,
+	0x6e, 0x39, 0x03, 0xd3, 0x75, 0x6a, 0x79, 0xaa, 0xe1, 0xe5, 0x28, 0x0d, 0x87, 0x86, 0xe3, 0x9e,
+	0x0a, 0xe6, 0x56, 0x4c, 0x2d, 0x0e, 0xfd, 0x04, 0xa2, 0xcf, 0x3c, 0x3f, 0xc7, 0xb6, 0xa7, 0xb0,
+	0x56, 0x58, 0xae, 0xef, 0x98, 0x70, 0x0b, 0x79, 0xa2, 0xcf, 0xf4, 0x13, 0xd0, 0x9f, 0xc3, 0xd6,
+	0xd0, 0xd4, 0x7b, 0x9e, 0x3a, 0xad, 0x3b, 0x98, 0x8c, 0x9f, 0xd5, 0x8a, 0x54, 0xe9, 0x6b, 0x91,
+	0x83, 0x34, 0xf5, 0x9e, 0x50, 0xd1, 0x20, 0x02, 0xad, 0x98, 0x5a, 0x19, 0xce, 0x13, 0xd1, 0x27,
+	0x50, 0xd5, 0x2d, 0x6b, 0x78, 0x39, 0xaf, 0xbd, 0x44, 0xb5, 0xdf, 0x8b, 0xd2, 0xbe, 0x4b, 0x64,
+	0xe6, 0xd5, 0x23, 0x7d, 0x81, 0x8a, 0xda, 0x20, 0x5b, 0x36, 0xb6, 0x74, 0x1b, 0x6b, 0x96, 0x6d,
+	0x5a, 0xa6, 0xa3, 0x0f, 0x6b, 0x65, 0xaa, 0xfb, 0xd5, 0x28, 0xdd, 0x27, 0x8c, 0xff, 0x84, 0xb3,
+	0xb7, 0x62, 0x6a, 0xd9, 0x0a, 0x92, 0x98, 0x56, 0xb3, 0x8b, 0x1d, 0x67, 0xa6, 0x55, 0x5e, 0xa5,
+	0x95, 0xf2, 0x07, 0xb5, 0x06, 0x48, 0xa8, 0x09, 0x79, 0x3c, 0x25, 0xe2, 0xda, 0x85, 0xe9, 0xe2,
+	0x5a, 0x85, 0x2a, 0x54, 0x22, 0x3d, 0x94, 0xb2, 0x3e, 0x35, 0x5d, 0xdc, 0x8a, 0xa9, 0x80, 0xbd,
+	0x16, 0xd2, 0xe1, 0xca, 0x05, 0xb6, 0x8d, 0xf3, 0x4b, 0xaa, 0x46, 0xa3, 0x6f, 0x1c, 0xc3, 0x1c,
+	0xd7, 0x10, 0x55, 0xf8, 0x7a, 0x94, 0xc2, 0xa7, 0x54, 0x88, 0xa8, 0x68, 0x0a, 0x91, 0x56, 0x4c,
+	0xdd, 0xba, 0x58, 0x24, 0x13, 0x13, 0x3b, 0x37, 0xc6, 0xfa, 0xd0, 0xf8, 0x0c, 0x6b, 0x9d, 0xa1,
+	0xd9, 0x7d, 0x56, 0xdb, 0x5a, 0x6e, 0x62, 0xfb, 0x9c, 0x7b, 0x8f, 0x30, 0x13, 0x13, 0x3b, 0xf7,
+	0x13, 0xc8, 0xcc, 0x3b, 0xb8, 0x6f, 0x8c, 0xb9, 0xb2, 0xea, 0xf2, 0x99, 0xef, 0x11, 0x56, 0xa1,
+	0x09, 0x3a, 0x5e, 0x8b, 0x04, 0x8f, 0x1e, 0x1e, 0x1a, 0x17, 0xd8, 0x26, 0x3e, 0x7c, 0x65, 0x79,
+	0xf0, 0x78, 0xc8, 0x38, 0xa9, 0x17, 0xe7, 0x7a, 0xa2, 0x81, 0xbe, 0x0f, 0x39, 0xf2, 0x05, 0xd8,
+	0x40, 0xae, 0x52, 0x15, 0xb7, 0x23, 0x3f, 0xc1, 0xb8, 0x27, 0x86, 0x91, 0xc5, 0xe3, 0x9e, 0x37,
+	0x17, 0xea, 0x2e, 0x43, 0xdd, 0xc5, 0x8e, 0x5b, 0xbb, 0xb6, 0x7c, 0x2e, 0xc4, 0x4d, 0x0e, 0x29,
+	0x27, 0x99, 0xcb, 0xd0, 0x6b, 0xed, 0x65, 0x20, 0x75, 0xa1, 0x0f, 0x27, 0xf8, 0x51, 0x32, 0x9b,
+	0x96, 0x33, 0x8f, 0x92, 0xd9, 0xac, 0x9c, 0x7b, 0x94, 0xcc, 0xe6, 0x64, 0x50, 0x5e, 0x85, 0xbc,
+	0x2f, 0x4a, 0xa3, 0x1a, 0x64, 0x46, 0xd8, 0x71, 0xf4, 0x3e, 0xa6, 0x41, 0x3d, 0xa7, 0x8a, 0xa6,
+	0x52, 0x82, 0x82, 0x3f, 0x32, 0x2b, 0x9f, 0x4b, 0x90, 0xf7, 0x05, 0x5d, 0x22, 0x79, 0x81, 0x6d,
+	0x6a, 0x1b, 0x5c, 0x92, 0x37, 0xd1, 0x1d, 0x28, 0xd2, 0x15, 0xd0, 0xc4, 0x7b, 0x12, 0xf9, 0x93,
+	0x6a, 0x81, 0x12, 0x9f, 0x72, 0xa6, 0x5b, 0x90, 0xb7, 0x76, 0x2c, 0x8f, 0x25, 0x41, 0x59, 0xc0,
+	0xda, 0xb1, 0x04, 0xc3, 0x4b, 0x50, 0x20, 0x73, 0xf5, 0x38, 0x92, 0xb4, 0x93, 0x3c, 0xa1, 0x71,
+	0x16, 0xe5, 0x97, 0x71, 0x90, 0xe7, 0xa3, 0x39, 0x7a, 0x00, 0x49, 0xb2, 0xb1, 0xf1, 0x3d, 0xaa,
+	0xbe, 0xcd, 0x76, 0xbd, 0x6d, 0xb1, 0xeb, 0x6d, 0xb7, 0xc5, 0xae, 0xb7, 0x97, 0xfd, 0xc5, 0xaf,
+	0x6f, 0xc5, 0x3e, 0xff, 0xaf, 0x5b, 0x92, 0x4a, 0x25, 0xd0, 0x75, 0x12, 0xc3, 0x75, 0x63, 0xac,
+	0x19, 0x3d, 0x3a, 0xe4, 0x1c, 0x09, 0xd0, 0xba, 0x31, 0x3e, 0xe8, 0xa1, 0x43, 0x90, 0xbb, 0xe6,
+	0xd8, 0xc1, 0x63, 0x67, 0xe2, 0x68, 0x6c, 0xcf, 0xad, 0x25, 0x16, 0x4d, 0x84, 0x6d, 0xb7, 0x0d,
+	0xc1, 0x79, 0x42, 0x19, 0xd5, 0x72, 0x37, 0x48, 0x40, 0xfb, 0x00, 0x17, 0xfa, 0xd0, 0xe8, 0xe9,
+	0xae, 0x69, 0x3b, 0xb5, 0xe4, 0xed, 0x44, 0xa8, 0x9d, 0x3c, 0x15, 0x2c, 0x67, 0x56, 0x4f, 0x77,
+	0xf1, 0x5e, 0x92, 0x0c, 0x57, 0xf5, 0x49, 0xa2, 0x57, 0xa0, 0xac, 0x5b, 0x96, 0xe6, 0xb8, 0xba,
+	0x8b, 0xb5, 0xce, 0xa5, 0x8b, 0x1d, 0xba, 0x6b, 0x15, 0xd4, 0xa2, 0x6e, 0x59, 0xa7, 0x84, 0xba,
+	0x47, 0x88, 0xe8, 0x65, 0x28, 0x91, 0x0d, 0xce, 0xd0, 0x87, 0xda, 0x00, 0x1b, 0xfd, 0x81, 0x5b,
+	0x4b, 0xdf, 0x96, 0xee, 0x26, 0xd4, 0x22, 0xa7, 0xb6, 0x28, 0x51, 0xe9, 0x41, 0xc1, 0xbf, 0xb9,
+	0x21, 0x04, 0xc9, 0x9e, 0xee, 0xea, 0x74, 0x25, 0x0b, 0x2a, 0x7d, 0x26, 0x34, 0x4b, 0x77, 0x07,
+	0x7c, 0x7d, 0xe8, 0x33, 0xba, 0x0a, 0x69, 0xae, 0x36, 0x41, 0xd5, 0xf2, 0x16, 0xaa, 0x42, 0xca,
+	0xb2, 0xcd, 0x0b, 0x4c, 0x3f, 0x5d, 0x56, 0x65, 0x0d, 0x45, 0x85, 0x52, 0x70, 0x23, 0x44, 0x25,
+	0x88, 0xbb, 0x53, 0xde, 0x4b, 0xdc, 0x9d, 0xa2, 0x37, 0x21, 0x49, 0x16, 0x92, 0xf6, 0x51, 0x0a,
+	0xd9, 0xfa, 0xb9, 0x5c, 0xfb, 0xd2, 0xc2, 0x2a, 0xe5, 0x54, 0xca, 0x50, 0x0c, 0x6c, 0x90, 0xca,
+	0x55, 0xa8, 0x86, 0xed, 0x77, 0xca, 0x00, 0xaa, 0x61, 0xfb, 0x16, 0x7a, 0x1b, 0xb2, 0xde, 0x86,
+	0xc7, 0x0c, 0xe7, 0xfa, 0x42, 0xb7, 0x82, 0x59, 0xf5, 0x58, 0x89, 0xc5, 0x90, 0x0f, 0x30, 0xd0,
+	0x79, 0x7a, 0x53, 0x50, 0x33, 0xba, 0x65, 0xb5, 0x74, 0x67, 0xa0, 0xfc, 0x10, 0x6a, 0x51, 0x9b,
+	0x99, 0x6f, 0xc1, 0x24, 0x6a, 0xf6, 0xbc, 0x45, 0xe8, 0xe7, 0xa6, 0x3d, 0xd2, 0x5d, 0xaa, 0xac,
+	0xa8, 0xf2, 0x16, 0x59, 0x48, 0xb6, 0xb1, 0x25, 0x28, 0x99, 0x35, 0x14, 0x0d, 0xae, 0x47, 0x6e,
+	0x68, 0x44, 0xc4, 0x18, 0xf7, 0x30, 0x5b, 0xd6, 0xa2, 0xca, 0x1a, 0x33, 0x45, 0x6c, 0xb0, 0xac,
+	0x41, 0xba, 0x75, 0xe8, 0x5c, 0xa9, 0xfe, 0x9c, 0xca, 0x5b, 0xca, 0xbf, 0xa7, 0xe1, 0x6a, 0xf8,
+	0xb6, 0x86, 0x6e, 0x43, 0x61, 0xa4, 0x4f, 0x35, 0x77, 0xca, 0xcd, 0x4e, 0xa2, 0x1f, 0x1e, 0x46,
+	0xfa, 0xb4, 0x3d, 0x65, 0x36, 0x27, 0x43, 0xc2, 0x9d, 0x3a, 0xb5, 0xf8, 0xed, 0xc4, 0xdd, 0x82,
+	0x4a, 0x1e, 0xd1, 0x19, 0x54, 0x86, 0x66, 0x57, 0x1f, 0x6a, 0x43, 0xdd, 0x71, 0x35, 0x9e, 0xef,
+	0x30, 0x27, 0xba, 0xb3, 0xb0, 0xd8, 0x6c, 0x83, 0xc2, 0x3d, 0xf6, 0x3d, 0x49, 0xc0, 0xe1, 0xf6,
+	0x5f, 0xa6, 0x3a, 0x0e, 0x75, 0xf1, 0xa9, 0xd1, 0x19, 0x54, 0x3b, 0x97, 0x9f, 0xe9, 0x63, 0xd7,
+	0x18, 0x63, 0x6d, 0xc1, 0xad, 0x16, 0xad, 0xe7, 0x89, 0xe1, 0x74, 0xf0, 0x40, 0xbf, 0x30, 0x4c,
+	0x9b, 0xab, 0xdc, 0xf2, 0xe4, 0x9f, 0xce, 0x7c, 0x6b, 0xf6, 0x8d, 0x52, 0x01, 0xa3, 0x16, 0xe1,
+	0x25, 0xbd, 0x71, 0x78, 0x79, 0x13, 0xaa, 0x63, 0x3c, 0x75, 0x7d, 0x63, 0x64, 0x86, 0x93, 0xa1,
+	0xdf, 0x02, 0x91, 0x77, 0xb3, 0xfe, 0x89, 0x0d, 0xa1, 0xd7, 0x68, 0xa6, 0x60, 0x99, 0x0e, 0xb6,
+	0x35, 0xbd, 0xd7, 0xb3, 0xb1, 0xe3, 0xd4, 0xb2, 0x94, 0xbb, 0x2c, 0xe8, 0xbb, 0x8c, 0x1c, 0xb0,
+	0xc4, 0x5c, 0xc0, 0x12, 0xd1, 0xab, 0x50, 0x9e, 0xef, 0x12, 0x28, 0x47, 0xe9, 0x22, 0xd8, 0xdd,
+	0xcb, 0x50, 0x9a, 0x05, 0x39, 0xca, 0x97, 0x67, 0xd1, 0xc4, 0xa3, 0x52, 0xb6, 0x1b, 0x90, 0x23,
+	0xa1, 0x80, 0x71, 0x14, 0x28, 0x47, 0x96, 0x10, 0xe8, 0xcb, 0x3b, 0x50, 0xc4, 0x17, 0x46, 0x0f,
+	0x8f, 0xbb, 0x98, 0x31, 0x14, 0x29, 0x43, 0x41, 0x10, 0x29, 0xd3, 0x2b, 0x50, 0xa6, 0x36, 0xc0,
+	0x76, 0x09, 0xca, 0x56, 0x62, 0x3d, 0x11, 0x32, 0xdb, 0x15, 0x09, 0xdf, 0x03, 0xb8, 0xee, 0xe3,
+	0xb3, 0x74, 0xdb, 0xd5, 0x1c, 0xec, 0x6a, 0xae, 0xe9, 0xf2, 0x44, 0x2c, 0xa1, 0x5e, 0xf1, 0x24,
+	0x4e, 0x74, 0xdb, 0x3d, 0xc5, 0x6e, 0x9b, 0xbc, 0x44, 0xef, 0x40, 0x2d, 0x4c, 0x92, 0x76, 0x25,
+	0xd3, 0xae, 0xaa, 0xf3, 0x82, 0xb4, 0xc7, 0xbb, 0x20, 0xfb, 0xac, 0x93, 0xf1, 0x57, 0xd8, 0x62,
+	0x0d, 0x3d, 0x93, 0xa3, 0x9c, 0xf7, 0xa0, 0x42, 0x39, 0x6d, 0xec, 0x4c, 0x86, 0x2e, 0x5f, 0x2f,
+	0xc4, 0x3e, 0x0e, 0x79, 0xa1, 0x32, 0x3a, 0x8d, 0x05, 0xff, 0xe4, 0x77, 0xa4, 0x60, 0xda, 0xc6,
+	0xdd, 0x44, 0x9a, 0xb9, 0xc9, 0x29, 0x54, 0xf9, 0xc7, 0xed, 0x05, 0x3c, 0x85, 0x1d, 0x9f, 0x6e,
+	0x2c, 0x46, 0xc3, 0x79, 0x0f, 0x41, 0x42, 0x7c, 0x0d, 0x27, 0x49, 0x3c, 0x9f, 0x93, 0x20, 0x48,
+	0xd2, 0x79, 0x27, 0xd9, 0x0e, 0x41, 0x9e, 0xbf, 0xc9, 0x8e, 0x03, 0x2b, 0x1d, 0x27, 0xbf, 0xa6,
+	0xe3, 0x14, 0x56, 0x3a, 0x4e, 0x71, 0x95, 0xe3, 0x94, 0xd6, 0x73, 0x9c, 0xf2, 0xc6, 0x8e, 0x23,
+	0x7f, 0x55, 0xc7, 0xa9, 0x6c, 0xe8, 0x38, 0x68, 0x7d, 0xc7, 0xd9, 0x0a, 0x77, 0x9c, 0xef, 0x43,
+	0x65, 0xe1, 0xc0, 0xe2, 0x19, 0x9d, 0x14, 0x6a, 0x74, 0x71, 0xbf, 0xd1, 0x29, 0x7f, 0x2f, 0x41,
+	0x3d, 0xfa, 0x84, 0x12, 0xaa, 0xea, 0x75, 0xa8, 0x78, 0x9f, 0xd7, 0x33, 0x1e, 0xb6, 0x5f, 0xca,
+	0xde, 0x0b, 0x61, 0x3d, 0x51, 0xa9, 0xcf, 0xcb, 0x50, 0x9a, 0x3b, 0x3f, 0x31, 0x17, 0x29, 0x5e,
+	0xf8, 0xfb, 0x57, 0xfe, 0x31, 0x0d, 0xd5, 0xb0, 0x43, 0x4e, 0x48, 0x58, 0xf8, 0x10, 0xb6, 0x7a,
+	0xb8, 0x6b, 0xf4, 0xbe, 0x6a, 0x54, 0xa8, 0x70, 0xe9, 0x6f, 0x83, 0xc2, 0xb7, 0x41, 0xe1, 0x9b,
+	0x1d, 0x14, 0xfe, 0x3a, 0x0e, 0x95, 0x85, 0xc3, 0x7c, 0xa8, 0x2b, 0xbf, 0x43, 0xac, 0x4e, 0x27,
+	0x89, 0x2d, 0x73, 0x93, 0xda, 0xe2, 0x59, 0xad, 0x45, 0xdf, 0x73, 0x73, 0xe6, 0xdc, 0xe8, 0x38,
+	0x38, 0x6e, 0x1f, 0x0e, 0xb9, 0x08, 0xea, 0xcd, 0xfc, 0xc9, 0xe7, 0x6c, 0xa5, 0x61, 0x80, 0x8a,
+	0xd4, 0xa5, 0x39, 0xea, 0xe2, 0x51, 0xa3, 0xc9, 0xbf, 0xef, 0x12, 0x37, 0x53, 0x9a, 0x20, 0xcf,
+	0x83, 0x11, 0x0b, 0x27, 0xa9, 0x97, 0xa0, 0xe0, 0x18, 0x7d, 0x8d, 0xa2, 0x30, 0x06, 0x66, 0xa7,
+	0xda, 0xac, 0x9a, 0x77, 0x8c, 0xfe, 0x53, 0x4e, 0x52, 0x5e, 0x83, 0xf2, 0x1c, 0x20, 0x31, 0x77,
+	0x3c, 0x99, 0x05, 0xd3, 0x2d, 0xa8, 0xf8, 0x8e, 0x34, 0x0c, 0x6a, 0x50, 0x7e, 0x5e, 0x80, 0xac,
+	0x8a, 0x1d, 0x8b, 0x18, 0x35, 0xda, 0x83, 0x1c, 0x9e, 0x76, 0xb1, 0xe5, 0x0a, 0x54, 0x20, 0x1c,
+	0xbc, 0x60, 0xdc, 0x4d, 0xc1, 0x49, 0x30, 0x14, 0x4f, 0x0c, 0xbd, 0xc5, 0x31, 0xe6, 0x68, 0xb8,
+	0x98, 0x8b, 0xfb, 0x41, 0xe6, 0x77, 0x04, 0xc8, 0x9c, 0x88, 0xc4, 0x4f, 0x99, 0xd4, 0x1c, 0xca,
+	0xfc, 0x16, 0x47, 0x99, 0x93, 0x2b, 0x3a, 0x0b, 0xc0, 0xcc, 0x8d, 0x00, 0xcc, 0x9c, 0x5a, 0x31,
+	0xcd, 0x08, 0x9c, 0xf9, 0x1d, 0x81, 0x33, 0xa7, 0x57, 0x8c, 0x78, 0x0e, 0x68, 0xfe, 0x9e, 0x0f,
+	0x68, 0xce, 0x46, 0x22, 0x4c, 0x4c, 0x34, 0x04, 0x69, 0x7e, 0xcf, 0x43, 0x9a, 0xf3, 0x91, 0x28,
+	0x35, 0x17, 0x9e, 0x87, 0x9a, 0x8f, 0x17, 0xa0, 0x66, 0x06, 0x0d, 0xbf, 0x12, 0xa9, 0x62, 0x05,
+	0xd6, 0x7c, 0xbc, 0x80, 0x35, 0x17, 0x57, 0x28, 0x5c, 0x01, 0x36, 0xff, 0x45, 0x38, 0xd8, 0x1c,
+	0x0d, 0x07, 0xf3, 0x61, 0xae, 0x87, 0x36, 0x6b, 0x11, 0x68, 0x73, 0x39, 0x12, 0x19, 0x65, 0xea,
+	0xd7, 0x86, 0x9b, 0xcf, 0x42, 0xe0, 0x66, 0x06, 0x0c, 0xdf, 0x8d, 0x54, 0xbe, 0x06, 0xde, 0x7c,
+	0x16, 0x82, 0x37, 0x57, 0x56, 0xaa, 0x5d, 0x09, 0x38, 0xef, 0x07, 0x01, 0x67, 0x14, 0x71, 0x90,
+	0x9f, 0x79, 0x7b, 0x04, 0xe2, 0xdc, 0x89, 0x42, 0x9c, 0x19, 0x2a, 0xfc, 0x46, 0xa4, 0xc6, 0x0d,
+	0x20, 0xe7, 0xe3, 0x05, 0xc8, 0xb9, 0xba, 0xc2, 0xd2, 0x56, 0x60, 0xce, 0xfb, 0x41, 0xcc, 0xf9,
+	0xca, 0x8a, 0xc9, 0x47, 0x82, 0xce, 0x8d, 0x00, 0xe8, 0x7c, 0x75, 0x45, 0x28, 0x89, 0x40, 0x9d,
+	0xff, 0xc4, 0x8f, 0x3a, 0x5f, 0x8b, 0x04, 0xae, 0xf9, 0x77, 0x08, 0x83, 0x9d, 0xf7, 0x83, 0xb0,
+	0x73, 0x6d, 0xc5, 0x74, 0xd6, 0xc1, 0x9d, 0x33, 0x72, 0x96, 0x21, 0xce, 0x8f, 0x92, 0x59, 0x90,
+	0xf3, 0xca, 0x6b, 0x50, 0x11, 0xe2, 0x5e, 0xe0, 0x27, 0x78, 0x14, 0xb6, 0x6d, 0xd3, 0xe6, 0x08,
+	0x32, 0x6b, 0x28, 0x77, 0xa1, 0xe0, 0xb1, 0x2e, 0xc7, 0xa8, 0x29, 0xee, 0xe7, 0x0b, 0xec, 0xca,
+	0x3f, 0x4b, 0x50, 0xf0, 0xc7, 0xec, 0x00, 0x86, 0x99, 0xe3, 0x18, 0xa6, 0x0f, 0xb9, 0x8e, 0x07,
+	0x91, 0xeb, 0x5b, 0x90, 0x27, 0x79, 0xdf, 0x1c, 0x28, 0xad, 0x5b, 0x1e, 0x28, 0x2d, 0xf2, 0x14,
+	0x9e, 0x6b, 0xb1, 0x5d, 0x32, 0x49, 0x77, 0xc9, 0xf2, 0x2c, 0xdb, 0xa2, 0x64, 0xf4, 0x1d, 0xd8,
+	0xf2, 0xf1, 0x7a, 0xf9, 0x24, 0x43, 0x68, 0x65, 0x8f, 0x7b, 0x97, 0x03, 0x86, 0xff, 0x26, 0x41,
+	0x65, 0x61, 0xcf, 0x08, 0x05, 0x9e, 0xa5, 0xaf, 0x09, 0x78, 0x8e, 0x7f, 0x65, 0xe0, 0xd9, 0x9f,
+	0x1f, 0x27, 0x82, 0xb8, 0xe7, 0xff, 0x4a, 0x50, 0x0c, 0x6c, 0x5d, 0xe4, 0x13, 0x74, 0xcd, 0x1e,
+	0xe6, 0x48, 0x24, 0x7d, 0x26, 0xe7, 0x9b, 0xa1, 0xd9, 0xe7, 0x78, 0x23, 0x79, 0x24, 0x5c, 0xde,
+	0x4e, 0x9c, 0xe3, 0x1b, 0xad, 0x07, 0x62, 0xb2, 0x43, 0x03, 0x6b, 0x10, 0xd9, 0x67, 0x98, 0xed,
+	0x9b, 0x05, 0x95, 0x3c, 0xa2, 0x2a, 0x37, 0x3b, 0x9e, 0xfc, 0xb3, 0x06, 0x7a, 0x00, 0x39, 0x5a,
+	0x59, 0xd7, 0x4c, 0xcb, 0xa9, 0x65, 0x17, 0xcf, 0x49, 0xac, 0xbc, 0xbe, 0x7d, 0x42, 0x78, 0x8e,
+	0x2d, 0x47, 0xcd, 0x5a, 0xfc, 0xc9, 0x97, 0x00, 0xe5, 0x02, 0xa7, 0x95, 0x17, 0x20, 0x47, 0x46,
+	0xef, 0x58, 0x7a, 0x17, 0xd3, 0x73, 0x41, 0x4e, 0x9d, 0x11, 0x94, 0x4f, 0x00, 0x2d, 0xfa, 0x3b,
+	0x6a, 0x41, 0x1a, 0x5f, 0xe0, 0xb1, 0xcb, 0x0e, 0x73, 0xf9, 0x9d, 0xab, 0x21, 0xc9, 0x1e, 0x1e,
+	0xbb, 0x7b, 0x35, 0xb2, 0xc8, 0xbf, 0xf9, 0xf5, 0x2d, 0x99, 0x71, 0xbf, 0x61, 0x8e, 0x0c, 0x17,
+	0x8f, 0x2c, 0xf7, 0x52, 0xe5, 0xf2, 0xca, 0xff, 0x48, 0x50, 0x16, 0x1d, 0x08, 0xe8, 0x3c, 0x6c,
+	0x6d, 0x85, 0xc9, 0xc7, 0x7d, 0xb0, 0xfd, 0xe2, 0x7a, 0xbf, 0x08, 0xd0, 0xd7, 0x1d, 0xed, 0x53,
+	0x7d, 0xec, 0xe2, 0x1e, 0x5f, 0xe0, 0x5c, 0x5f, 0x77, 0x3e, 0xa2, 0x84, 0xe0, 0x54, 0xb3, 0x73,
+	0x53, 0xf5, 0x21, 0xc6, 0x39, 0x3f, 0x62, 0x8c, 0xea, 0x90, 0xb5, 0x6c, 0xc3, 0xb4, 0x0d, 0xf7,
+	0x92, 0xae, 0x4f, 0x42, 0xf5, 0xda, 0xe4, 0x9d, 0x43, 0xb2, 0xc7, 0x71, 0x17, 0xd3, 0xc4, 0x21,
+	0xa9, 0x7a, 0xed, 0x47, 0xc9, 0x6c, 0x52, 0x4e, 0x79, 0xc5, 0x2a, 0x16, 0x3a, 0xf2, 0x72, 0x41,
+	0xf9, 0x49, 0x1c, 0x2a, 0x0b, 0xc1, 0xef, 0x39, 0x26, 0x1d, 0x66, 0x64, 0x37, 0x43, 0x16, 0xc2,
+	0x47, 0x21, 0xe3, 0x26, 0xad, 0x89, 0x83, 0x7b, 0xbc, 0x6c, 0xe2, 0xb5, 0x7d, 0x1f, 0x37, 0xf3,
+	0x7c, 0x1f, 0x77, 0xf9, 0x7a, 0x2b, 0x7f, 0x4b, 0x0b, 0x5d, 0xc1, 0x00, 0x8e, 0x4e, 0xfd, 0x40,
+	0xc5, 0x84, 0xba, 0xaa, 0x30, 0xb2, 0x75, 0x7d, 0x5a, 0xbe, 0x08, 0x92, 0x1d, 0xf4, 0xa7, 0x70,
+	0x6d, 0x2e, 0xde, 0x78, 0xaa, 0xe3, 0x11, 0xd9, 0xe6, 0x7c, 0xd4, 0xb9, 0x12, 0x8c, 0x3a, 0x42,
+	0xf3, 0x6c, 0xad, 0x12, 0xcf, 0xe9, 0x08, 0x6f, 0x43, 0x49, 0x2c, 0x06, 0x47, 0x32, 0xee, 0x40,
+	0xd1, 0xc6, 0x2e, 0x29, 0xdd, 0x05, 0xd0, 0x98, 0x02, 0x23, 0xf2, 0xf2, 0xd6, 0x09, 0x5c, 0x09,
+	0x4d, 0x4c, 0xd1, 0xbb, 0x90, 0x9b, 0xe5, 0xb4, 0x52, 0xc4, 0x91, 0x4c, 0xb0, 0xab, 0x33, 0x5e,
+	0xe5, 0x5f, 0x24, 0xb8, 0x12, 0x9a, 0x9a, 0xa2, 0x26, 0xa4, 0xd9, 0x51, 0x96, 0x1a, 0x69, 0x69,
+	0xe7, 0x3b, 0xeb, 0xa5, 0xb4, 0xdb, 0xec, 0x9c, 0xab, 0x72, 0x61, 0xe5, 0x13, 0x48, 0x33, 0x0a,
+	0xca, 0x43, 0xe6, 0xec, 0xe8, 0xf1, 0xd1, 0xf1, 0x47, 0x47, 0x72, 0x0c, 0x01, 0xa4, 0x77, 0x1b,
+	0x8d, 0xe6, 0x49, 0x5b, 0x96, 0x50, 0x0e, 0x52, 0xbb, 0x7b, 0xc7, 0x6a, 0x5b, 0x8e, 0x13, 0xb2,
+	0xda, 0x7c, 0xd4, 0x6c, 0xb4, 0xe5, 0x04, 0xaa, 0x40, 0x91, 0x3d, 0x6b, 0xfb, 0xc7, 0xea, 0x93,
+	0xdd, 0xb6, 0x9c, 0xf4, 0x91, 0x4e, 0x9b, 0x47, 0x0f, 0x9b, 0xaa, 0x9c, 0x52, 0xfe, 0x00, 0xae,
+	0x8b, 0x71, 0x2c, 0x56, 0xa9, 0xbc, 0x62, 0x91, 0xe4, 0x2b, 0x16, 0x29, 0x7f, 0x17, 0x87, 0xba,
+	0x90, 0x09, 0xa9, 0x3b, 0x3d, 0x9a, 0x9b, 0xf8, 0xce, 0x06, 0x69, 0xf1, 0xdc, 0xec, 0x09, 0x82,
+	0x62, 0xe3, 0x73, 0xec, 0x76, 0x07, 0x2c, 0xd3, 0x66, 0x3b, 0x56, 0x51, 0x2d, 0x72, 0x2a, 0x15,
+	0x72, 0x18, 0xdb, 0x8f, 0x70, 0xd7, 0xd5, 0x58, 0x14, 0x62, 0x06, 0x96, 0x53, 0x8b, 0x8c, 0x7a,
+	0xca, 0x88, 0xca, 0x0f, 0x37, 0x5a, 0xcb, 0x1c, 0xa4, 0xd4, 0x66, 0x5b, 0xfd, 0x58, 0x4e, 0x20,
+	0x04, 0x25, 0xfa, 0xa8, 0x9d, 0x1e, 0xed, 0x9e, 0x9c, 0xb6, 0x8e, 0xc9, 0x5a, 0x6e, 0x41, 0x59,
+	0xac, 0xa5, 0x20, 0xa6, 0x94, 0xff, 0x88, 0xc3, 0xb5, 0x88, 0xbc, 0x1c, 0x3d, 0x00, 0x70, 0xa7,
+	0x9a, 0x8d, 0xbb, 0xa6, 0xdd, 0x8b, 0x36, 0xb2, 0xf6, 0x54, 0xa5, 0x1c, 0x6a, 0xce, 0xe5, 0x4f,
+	0xce, 0x92, 0x1a, 0x23, 0xfa, 0x2e, 0x57, 0x4a, 0x66, 0x25, 0xdc, 0xea, 0xc5, 0x90, 0x52, 0x1a,
+	0xee, 0x12, 0xc5, 0x74, 0x6d, 0x73, 0x2e, 0x7f, 0x72, 0xd0, 0x93, 0xb0, 0xf8, 0xb1, 0x66, 0x31,
+	0x3a, 0x24, 0x72, 0x7c, 0x1c, 0x1d, 0x39, 0x52, 0xeb, 0x26, 0x2c, 0xe1, 0xa1, 0x43, 0xf9, 0x87,
+	0x84, 0x7f, 0x61, 0x83, 0xc7, 0x90, 0x63, 0x48, 0x3b, 0xae, 0xee, 0x4e, 0x1c, 0x6e, 0x70, 0xef,
+	0xae, 0x7b, 0xa6, 0xd9, 0x16, 0x0f, 0xa7, 0x54, 0x5c, 0xe5, 0x6a, 0xbe, 0x5d, 0x6f, 0x1a, 0x60,
+	0x83, 0x8b, 0x13, 0xed, 0x32, 0xb3, 0x98, 0x13, 0x57, 0xde, 0x9f, 0x25, 0x40, 0x3e, 0xb8, 0x7e,
+	0x11, 0x0a, 0x97, 0xc2, 0xa0, 0xf0, 0x9f, 0x4b, 0x70, 0x63, 0xc9, 0xc9, 0x0e, 0x7d, 0x38, 0xf7,
+	0x9d, 0xdf, 0xdb, 0xe4, 0x5c, 0xb8, 0xcd, 0x68, 0xc1, 0x2f, 0xad, 0xbc, 0x05, 0x05, 0x3f, 0x7d,
+	0xbd, 0x49, 0xfe, 0x26, 0x0e, 0x57, 0x42, 0x0f, 0x89, 0x5f, 0x5f, 0xa6, 0x37, 0x67, 0x67, 0xf1,
+	0x0d, 0xed, 0x2c, 0x34, 0x2f, 0x48, 0x3c, 0x67, 0x5e, 0xb0, 0xc4, 0xda, 0x92, 0xcf, 0x67, 0x6d,
+	0x01, 0x87, 0x4b, 0x05, 0x0f, 0x13, 0x55, 0x40, 0xfe, 0xfd, 0x89, 0x43, 0x8e, 0x1f, 0x03, 0xf8,
+	0xb0, 0xd5, 0x2a, 0xa4, 0x6c, 0x73, 0x32, 0xee, 0x51, 0xbb, 0x48, 0xa9, 0xac, 0x41, 0xae, 0x71,
+	0x12, 0xfb, 0x12, 0xab, 0xb7, 0x18, 0x6a, 0x89, 0x7d, 0xf8, 0x10, 0x5b, 0xc6, 0xad, 0xfc, 0x00,
+	0x4a, 0x41, 0x40, 0xf7, 0xeb, 0x55, 0x6f, 0x00, 0x5a, 0xbc, 0xd8, 0x10, 0xd1, 0xc5, 0xf7, 0x82,
+	0x5d, 0xbc, 0x14, 0x79, 0x45, 0x22, 0xbc, 0xab, 0xcf, 0x20, 0x45, 0xcd, 0x8d, 0xe4, 0xbc, 0xf4,
+	0x36, 0x0d, 0x3f, 0x01, 0x93, 0x67, 0xf4, 0x03, 0x00, 0xdd, 0x75, 0x6d, 0xa3, 0x33, 0x99, 0x75,
+	0x70, 0x2b, 0xdc, 0x5c, 0x77, 0x05, 0xdf, 0xde, 0x0b, 0xdc, 0x6e, 0xab, 0x33, 0x51, 0x9f, 0xed,
+	0xfa, 0x14, 0x2a, 0x7f, 0x23, 0x41, 0x29, 0x28, 0x2c, 0x0e, 0x6d, 0x52, 0xc8, 0xa1, 0x2d, 0xee,
+	0x3f, 0xb4, 0x79, 0x47, 0xbe, 0x04, 0xbb, 0x33, 0x44, 0x1b, 0xe8, 0x5d, 0x3e, 0x87, 0x24, 0x75,
+	0xfd, 0x3b, 0x2b, 0x46, 0xea, 0xbb, 0x18, 0xf4, 0x7f, 0x12, 0x14, 0xfc, 0x7e, 0xf2, 0x35, 0x9f,
+	0x1d, 0x56, 0x1c, 0xa2, 0xae, 0x2f, 0x1c, 0x1d, 0x32, 0x7d, 0xdd, 0x39, 0xfb, 0x5d, 0x9e, 0x1c,
+	0x7e, 0x22, 0x41, 0xd6, 0x9b, 0x7c, 0x04, 0xb0, 0x3f, 0x5b, 0xf4, 0xb8, 0xff, 0xb2, 0x10, 0x2b,
+	0x26, 0x24, 0xbc, 0x62, 0xc2, 0xfb, 0x5e, 0x6a, 0x17, 0x85, 0x96, 0xfb, 0x57, 0x5a, 0x94, 0x54,
+	0x78, 0x26, 0x6b, 0xb3, 0x61, 0x90, 0x94, 0x06, 0xfd, 0x11, 0xa4, 0xf5, 0xae, 0x57, 0x22, 0x28,
+	0x85, 0x00, 0x5e, 0x82, 0x75, 0xbb, 0x3d, 0xdd, 0xa5, 0x9c, 0x2a, 0x97, 0xe0, 0x83, 0x8a, 0x8b,
+	0x41, 0x29, 0x75, 0xc8, 0x0a, 0x1e, 0x54, 0x02, 0x38, 0x3b, 0x7a, 0x72, 0xfc, 0xf0, 0x60, 0xff,
+	0xa0, 0xf9, 0x50, 0x8e, 0x29, 0x0d, 0xc8, 0x8b, 0x92, 0x14, 0x01, 0x3f, 0x6e, 0x40, 0x6e, 0xa4,
+	0x07, 0x2f, 0x2c, 0x65, 0x47, 0x3a, 0xbf, 0xae, 0x74, 0x0d, 0x32, 0xe4, 0x65, 0x5f, 0x77, 0x44,
+	0x05, 0x79, 0xa4, 0x4f, 0x3f, 0xd0, 0x1d, 0xe5, 0xb7, 0x12, 0x94, 0xe7, 0x02, 0x19, 0xda, 0x81,
+	0x14, 0x03, 0xdb, 0xa2, 0xee, 0xc1, 0xfb, 0xba, 0x55, 0x19, 0x2b, 0xb9, 0x20, 0x2e, 0xaa, 0x76,
+	0x61, 0x27, 0x29, 0x16, 0x31, 0x45, 0xdd, 0x87, 0x8b, 0x7a, 0x12, 0xe4, 0x62, 0xa9, 0x17, 0x92,
+	0xa3, 0x2f, 0x1e, 0x7a, 0xc1, 0x9c, 0xcb, 0xcf, 0x64, 0xd0, 0x7b, 0x33, 0xcc, 0x2b, 0xb9, 0x08,
+	0xfc, 0x73, 0x71, 0xc6, 0xc0, 0x85, 0x05, 0xbf, 0xf2, 0x3e, 0xe4, 0x3c, 0xc5, 0x04, 0x3b, 0x13,
+	0xb5, 0x53, 0x89, 0xc7, 0x6a, 0xd6, 0xa4, 0xb7, 0xfd, 0xcc, 0x4f, 0xf9, 0x25, 0xb2, 0x84, 0xca,
+	0x1a, 0x4a, 0x0f, 0xca, 0x73, 0x5b, 0x0c, 0x7a, 0x1f, 0x32, 0xd6, 0xa4, 0xa3, 0x89, 0x70, 0x30,
+	0xb7, 0x7e, 0x02, 0x95, 0x99, 0x74, 0x86, 0x46, 0xf7, 0x31, 0xbe, 0x14, 0x76, 0x64, 0x4d, 0x3a,
+	0x8f, 0x59, 0xd4, 0x60, 0xbd, 0xc4, 0xfd, 0xbd, 0x5c, 0x40, 0x56, 0x44, 0x41, 0xf4, 0xc7, 0xfe,
+	0xa5, 0x12, 0x97, 0x40, 0x23, 0xb7, 0x3d, 0xae, 0xde, 0xb7, 0x52, 0xf7, 0xa0, 0xe2, 0x18, 0xfd,
+	0xb1, 0xa8, 0xb3, 0xb3, 0x0f, 0xcd, 0x0a, 0x67, 0x65, 0xf6, 0xe2, 0x50, 0x40, 0x77, 0x24, 0x69,
+	0x91, 0xe7, 0xc3, 0xf0, 0xef, 0x72, 0x00, 0x21, 0xc9, 0x55, 0x22, 0x2c, 0xb9, 0xfa, 0xab, 0x38,
+	0xe4, 0x7d, 0xd5, 0x7b, 0xf4, 0x87, 0xbe, 0x3d, 0xa1, 0x14, 0x92, 0x15, 0xf8, 0x78, 0x67, 0xc1,
+	0x34, 0x38, 0xb1, 0xf8, 0xe6, 0x13, 0x8b, 0xba, 0x2c, 0x21, 0x2e, 0x01, 0x24, 0x37, 0xbe, 0x04,
+	0xf0, 0x06, 0x20, 0x5a, 0xbe, 0x26, 0xa5, 0x03, 0x63, 0xdc, 0xd7, 0x98, 0x69, 0xb0, 0x38, 0x2c,
+	0xd3, 0x37, 0x4f, 0xe9, 0x8b, 0x13, 0x6a, 0x25, 0x7f, 0x19, 0x87, 0xac, 0xf0, 0xb0, 0xdf, 0xd3,
+	0x25, 0xf8, 0x57, 0x09, 0xb2, 0x1e, 0x48, 0xb1, 0xe9, 0x35, 0xd4, 0xab, 0x90, 0xe6, 0xe7, 0x70,
+	0x76, 0x0f, 0x95, 0xb7, 0x42, 0x2f, 0x7c, 0xd4, 0x21, 0x3b, 0xc2, 0xae, 0x4e, 0xf7, 0x55, 0x96,
+	0xd4, 0x79, 0x6d, 0x82, 0xb2, 0x77, 0x74, 0x07, 0xfb, 0xef, 0x22, 0x27, 0x55, 0x20, 0x24, 0x8e,
+	0x9c, 0xdf, 0x80, 0x1c, 0x63, 0x98, 0x5d, 0xe8, 0xc8, 0xd2, 0xd7, 0xba, 0x33, 0xb8, 0xf7, 0x1e,
+	0xe4, 0x7d, 0x17, 0x80, 0xc9, 0x46, 0x7d, 0xd4, 0xfc, 0x48, 0x8e, 0xd5, 0x33, 0x3f, 0xfd, 0xd9,
+	0xed, 0xc4, 0x11, 0xfe, 0x94, 0x84, 0x28, 0xb5, 0xd9, 0x68, 0x35, 0x1b, 0x8f, 0x65, 0xa9, 0x9e,
+	0xff, 0xe9, 0xcf, 0x6e, 0x67, 0x54, 0x4c, 0xcb, 0x9f, 0xf7, 0x3e, 0x04, 0xb4, 0x98, 0x29, 0xa0,
+	0x32, 0xe4, 0xcf, 0x8e, 0x4e, 0x4f, 0x9a, 0x0d, 0xbe, 0x6f, 0x90, 0x74, 0xff, 0xb4, 0xad, 0x1e,
+	0x1c, 0x7d, 0x20, 0x4b, 0x28, 0x03, 0x89, 0x83, 0x23, 0x02, 0x08, 0xe4, 0x21, 0xf3, 0xb0, 0xd9,
+	0x38, 0x78, 0xb2, 0x7b, 0x28, 0x27, 0x50, 0x16, 0x92, 0xed, 0x83, 0x27, 0x4d, 0x39, 0x79, 0xef,
+	0x31, 0x94, 0xe7, 0x2c, 0x25, 0x78, 0x8c, 0x40, 0x50, 0x7a, 0x78, 0x76, 0x72, 0x78, 0xd0, 0xd8,
+	0x6d, 0x37, 0xb5, 0xa7, 0xc7, 0xed, 0xa6, 0x2c, 0xa1, 0x6b, 0xb0, 0x75, 0x78, 0xf0, 0x41, 0xab,
+	0xad, 0x35, 0x0e, 0x0f, 0x9a, 0x47, 0x6d, 0x6d, 0xb7, 0xdd, 0xde, 0x6d, 0x3c, 0x96, 0xe3, 0x3b,
+	0xbf, 0xcd, 0x43, 0x79, 0x77, 0xaf, 0x71, 0x40, 0xa0, 0x11, 0xa3, 0xab, 0xd3, 0x4d, 0xad, 0x01,
+	0x49, 0x5a, 0x04, 0x59, 0xfa, 0xb3, 0x55, 0x7d, 0x79, 0x99, 0x1c, 0xed, 0x43, 0x8a, 0xd6, 0x47,
+	0xd0, 0xf2, 0xbf, 0xaf, 0xea, 0x2b, 0xea, 0xe6, 0x64, 0x30, 0x34, 0xc4, 0x2d, 0xfd, 0x1d, 0xab,
+	0xbe, 0xbc, 0x8c, 0x8e, 0x0e, 0x21, 0x23, 0xe0, 0xeb, 0x55, 0xff, 0x48, 0xd5, 0x57, 0xd6, 0xb6,
+	0xc9, 0xd4, 0x58, 0x99, 0x61, 0xf9, 0x9f, 0x5a, 0xf5, 0x15, 0x05, 0x76, 0x74, 0x00, 0x69, 0x0e,
+	0x26, 0xae, 0xf8, 0xf9, 0xaa, 0xbe, 0xaa, 0x64, 0x8e, 0x54, 0xc8, 0xcd, 0x0a, 0x38, 0xab, 0xff,
+	0x3f, 0xab, 0xaf, 0x71, 0x77, 0x00, 0x7d, 0x02, 0xc5, 0x20, 0x68, 0xb9, 0xde, 0x0f, 0x5e, 0xf5,
+	0x35, 0x8b, 0xf3, 0x44, 0x7f, 0x10, 0xc1, 0x5c, 0xef, 0x87, 0xaf, 0xfa, 0x9a, 0xb5, 0x7a, 0xf4,
+	0x23, 0xa8, 0x2c, 0x22, 0x8c, 0xeb, 0xff, 0xff, 0x55, 0xdf, 0xa0, 0x7a, 0x8f, 0x46, 0x80, 0x42,
+	0x90, 0xc9, 0x0d, 0x7e, 0x07, 0xab, 0x6f, 0x52, 0xcc, 0x47, 0x3d, 0x28, 0xcf, 0xa3, 0x7d, 0xeb,
+	0xfe, 0x1e, 0x56, 0x5f, 0xbb, 0xb0, 0xcf, 0x7a, 0x09, 0x42, 0x5f, 0xeb, 0xfe, 0x2e, 0x56, 0x5f,
+	0xbb, 0xce, 0x8f, 0xce, 0x00, 0x7c, 0xd0, 0xcd, 0x1a, 0xbf, 0x8f, 0xd5, 0xd7, 0xa9, 0xf8, 0x23,
+	0x0b, 0xb6, 0xc2, 0x30, 0x9d, 0x4d, 0xfe, 0x26, 0xab, 0x6f, 0x74, 0x11, 0x80, 0xd8, 0x73, 0x10,
+	0x9d, 0x59, 0xef, 0xef, 0xb2, 0xfa, 0x9a, 0x37, 0x02, 0xc8, 0x42, 0xcd, 0x10, 0x09, 0xb4, 0xc6,
+	0x1f, 0x5a, 0xf5, 0x75, 0xca, 0xe9, 0x3b, 0xc7, 0x00, 0x64, 0x03, 0x38, 0x75, 0x6d, 0xac, 0x8f,
+	0xd0, 0x2e, 0xa4, 0xf9, 0x53, 0x2d, 0xaa, 0x83, 0xfa, 0xf5, 0x48, 0xb5, 0x77, 0xa5, 0x37, 0xa5,
+	0xbd, 0xe6, 0x2f, 0xbe, 0xb8, 0x29, 0xfd, 0xea, 0x8b, 0x9b, 0xd2, 0x7f, 0x7f, 0x71, 0x53, 0xfa,
+	0xfc, 0xcb, 0x9b, 0xb1, 0x5f, 0x7d, 0x79, 0x33, 0xf6, 0x9f, 0x5f, 0xde, 0x8c, 0xfd, 0xd9, 0xeb,
+	0x7d, 0xc3, 0x1d, 0x4c, 0x3a, 0xdb, 0x5d, 0x73, 0x74, 0xdf, 0xff, 0xdf, 0x70, 0xd8, 0xcf, 0xcc,
+	0x9d, 0x34, 0xcd, 0x44, 0xde, 0xfa, 0xff, 0x01, 0x00, 0x06, 0x8c, 0x78, 0xe2, 0xec, 0x3c, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5680,6 +5762,110 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	Metadata: "tendermint/abci/types.proto",
 }
 
+// ABCIStreamClient is the client API for ABCIStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ABCIStreamClient interface {
+	Stream(ctx context.Context, opts ...grpc.CallOption) (ABCIStream_StreamClient, error)
+}
+
+type aBCIStreamClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewABCIStreamClient(cc *grpc.ClientConn) ABCIStreamClient {
+	return &aBCIStreamClient{cc}
+}
+
+func (c *aBCIStreamClient) Stream(ctx context.Context, opts ...grpc.CallOption) (ABCIStream_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ABCIStream_serviceDesc.Streams[0], "/tendermint.abci.ABCIStream/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aBCIStreamStreamClient{stream}
+	return x, nil
+}
+
+type ABCIStream_StreamClient interface {
+	Send(*Request) error
+	Recv() (*Response, error)
+	grpc.ClientStream
+}
+
+type aBCIStreamStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aBCIStreamStreamClient) Send(m *Request) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aBCIStreamStreamClient) Recv() (*Response, error) {
+	m := new(Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ABCIStreamServer is the server API for ABCIStream service.
+type ABCIStreamServer interface {
+	Stream(ABCIStream_StreamServer) error
+}
+
+// UnimplementedABCIStreamServer can be embedded to have forward compatible implementations.
+type UnimplementedABCIStreamServer struct {
+}
+
+func (*UnimplementedABCIStreamServer) Stream(srv ABCIStream_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+
+func RegisterABCIStreamServer(s *grpc.Server, srv ABCIStreamServer) {
+	s.RegisterService(&_ABCIStream_serviceDesc, srv)
+}
+
+func _ABCIStream_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ABCIStreamServer).Stream(&aBCIStreamStreamServer{stream})
+}
+
+type ABCIStream_StreamServer interface {
+	Send(*Response) error
+	Recv() (*Request, error)
+	grpc.ServerStream
+}
+
+type aBCIStreamStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aBCIStreamStreamServer) Send(m *Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aBCIStreamStreamServer) Recv() (*Request, error) {
+	m := new(Request)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ABCIStream_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.abci.ABCIStream",
+	HandlerType: (*ABCIStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _ABCIStream_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "tendermint/abci/types.proto",
+}
+
 func (m *Request) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -8138,6 +8324,11 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.Priority != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
 		i--
@@ -9019,6 +9210,11 @@ func (m *EventAttribute) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Index {
 		i--
 		if m.Index {
@@ -9613,6 +9809,18 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.BaseHash) > 0 {
+		i -= len(m.BaseHash)
+		copy(dAtA[i:], m.BaseHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.BaseHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Metadata) > 0 {
 		i -= len(m.Metadata)
 		copy(dAtA[i:], m.Metadata)
@@ -10870,6 +11078,9 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if m.Priority != 0 {
 		n += 1 + sovTypes(uint64(m.Priority))
 	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -11241,6 +11452,9 @@ func (m *EventAttribute) Size() (n int) {
 	if m.Index {
 		n += 2
 	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
 	return n
 }
 
@@ -11490,6 +11704,13 @@ func (m *Snapshot) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
+	l = len(m.BaseHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -17987,6 +18208,25 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -20312,6 +20552,25 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Index = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EventAttributeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -22089,6 +22348,59 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BaseHash = append(m.BaseHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BaseHash == nil {
+				m.BaseHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,425 @@
+package commands
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// archiveVersion is the version of the archive format written by ExportState.
+const archiveVersion = 1
+
+// archiveBatchSize is the number of records written per batch by ImportState.
+const archiveBatchSize = 1000
+
+// maxArchiveRecordSize bounds the size of keys, values and the manifest read
+// from an archive.
+const maxArchiveRecordSize = 256 << 20
+
+var (
+	exportEvidence bool = false
+	importForce    bool = false
+)
+
+// ArchiveManifest describes the content of a node state archive.
+type ArchiveManifest struct {
+	Version           int       `json:"version"`
+	TendermintVersion string    `json:"tendermint_version"`
+	CreatedAt         time.Time `json:"created_at"`
+	ChainID           string    `json:"chain_id"`
+	// BaseHeight and Height are the lowest and highest heights in the block store.
+	BaseHeight int64 `json:"base_height"`
+	Height     int64 `json:"height"`
+	// StateHeight is the last block height of the state store.
+	StateHeight int64 `json:"state_height"`
+	// Databases lists the databases in the archive, in order.
+	Databases []string `json:"databases"`
+}
+
+// MakeExportCommand constructs a command that exports the node state to an
+// archive.
+func MakeExportCommand(conf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "export the block store and state store to an archive",
+		Long: `
+Exports the block store and the state store, and optionally the evidence
+store, to a versioned archive that can be restored on another machine with the
+import command. The archive records the chain ID and the heights of the stores,
+and is independent of the database backend. The databases are opened read-only.
+The node must be stopped.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			manifest, err := ExportState(conf, f, exportEvidence)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(args[0])
+				return fmt.Errorf("failed to export state: %w", err)
+			}
+
+			fmt.Printf("Exported chain %s, blocks %d-%d, state height %d to %s\n",
+				manifest.ChainID, manifest.BaseHeight, manifest.Height, manifest.StateHeight, args[0])
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&exportEvidence, "evidence", false, "include the evidence store")
+
+	return cmd
+}
+
+// MakeImportCommand constructs a command that imports the node state from an
+// archive.
+func MakeImportCommand(conf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "import the block store and state store from an archive",
+		Long: `
+Restores an archive created by the export command. The chain ID of the archive
+must match the one of the genesis file, and the imported stores are checked
+against the heights recorded in the archive. Import refuses to overwrite
+existing stores, unless --force is set, in which case they are replaced once
+the archive has been imported and verified.
+The node must be stopped.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			manifest, err := ImportState(conf, f, importForce)
+			if err != nil {
+				return fmt.Errorf("failed to import state: %w", err)
+			}
+
+			fmt.Printf("Imported chain %s, blocks %d-%d, state height %d\n",
+				manifest.ChainID, manifest.BaseHeight, manifest.Height, manifest.StateHeight)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&importForce, "force", false, "overwrite existing stores")
+
+	return cmd
+}
+
+// ExportState writes the block store, the state store and, if includeEvidence
+// is set, the evidence store to w.
+//
+// The archive is gzip-compressed. It starts with the JSON-encoded manifest,
+// followed by the key-value pairs of each database listed in the manifest.
+// Every manifest, key and value is prefixed by its uvarint-encoded length,
+// and the records of each database are terminated by an empty key.
+func ExportState(conf *config.Config, w io.Writer, includeEvidence bool) (*ArchiveManifest, error) {
+	dbNames := []string{"blockstore", "state"}
+	if includeEvidence {
+		dbNames = append(dbNames, "evidence")
+	}
+
+	dbs := make([]dbm.DB, 0, len(dbNames))
+	defer func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}()
+	for _, name := range dbNames {
+		db, err := config.ReadOnlyDBProvider(&config.DBContext{ID: name, Config: conf})
+		if err != nil {
+			return nil, fmt.Errorf("opening %s database: %w", name, err)
+		}
+		dbs = append(dbs, db)
+	}
+
+	blockStore := store.NewBlockStore(dbs[0])
+	st, err := state.NewStore(dbs[1]).Load()
+	if err != nil {
+		return nil, err
+	}
+	if st.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+
+	manifest := &ArchiveManifest{
+		Version:           archiveVersion,
+		TendermintVersion: version.TMVersion,
+		CreatedAt:         time.Now().UTC(),
+		ChainID:           st.ChainID,
+		BaseHeight:        blockStore.Base(),
+		Height:            blockStore.Height(),
+		StateHeight:       st.LastBlockHeight,
+		Databases:         dbNames,
+	}
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	bz, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeArchiveRecord(bw, bz); err != nil {
+		return nil, err
+	}
+	for i, db := range dbs {
+		if err := exportDB(bw, db); err != nil {
+			return nil, fmt.Errorf("exporting %s database: %w", dbNames[i], err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ImportState restores an archive written by ExportState. Existing stores are
+// replaced if force is set, otherwise ImportState fails if any of them exists.
+// The archive is imported into a temporary directory under the database
+// directory and verified before it replaces any existing store, so that a
+// corrupt archive leaves the node data untouched.
+func ImportState(conf *config.Config, r io.Reader, force bool) (*ArchiveManifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	br := bufio.NewReader(zr)
+
+	bz, err := readArchiveRecord(br)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	manifest := &ArchiveManifest{}
+	if err := json.Unmarshal(bz, manifest); err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	if manifest.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d, expected %d", manifest.Version, archiveVersion)
+	}
+
+	genDoc, err := types.GenesisDocFromFile(conf.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	if manifest.ChainID != genDoc.ChainID {
+		return nil, fmt.Errorf("archive is for chain %q, but the genesis file is for chain %q",
+			manifest.ChainID, genDoc.ChainID)
+	}
+
+	for _, name := range manifest.Databases {
+		switch name {
+		case "blockstore", "state", "evidence":
+		default:
+			return nil, fmt.Errorf("unknown database %q in archive", name)
+		}
+		path := filepath.Join(conf.DBDir(), name+".db")
+		if _, err := os.Stat(path); err == nil && !force {
+			return nil, fmt.Errorf("%s already exists; use --force to overwrite it", path)
+		}
+	}
+
+	if err := tmos.EnsureDir(conf.DBDir(), 0700); err != nil {
+		return nil, err
+	}
+	tmpDir, err := os.MkdirTemp(conf.DBDir(), "import-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := importDBs(conf, tmpDir, br, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, replaceDBs(conf.DBDir(), tmpDir, manifest.Databases)
+}
+
+// importDBs imports the databases of the archive read from br into dir, and
+// verifies them against manifest.
+func importDBs(conf *config.Config, dir string, br *bufio.Reader, manifest *ArchiveManifest) error {
+	tmpConf := *conf
+	tmpConf.DBPath = dir
+
+	dbs := make(map[string]dbm.DB, len(manifest.Databases))
+	defer func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}()
+	for _, name := range manifest.Databases {
+		db, err := config.DefaultDBProvider(&config.DBContext{ID: name, Config: &tmpConf})
+		if err != nil {
+			return fmt.Errorf("opening %s database: %w", name, err)
+		}
+		dbs[name] = db
+		if err := importDB(br, db); err != nil {
+			return fmt.Errorf("importing %s database: %w", name, err)
+		}
+	}
+	if _, err := br.ReadByte(); err != io.EOF {
+		return errors.New("unexpected data at the end of the archive")
+	}
+
+	return verifyImportedState(manifest, dbs["blockstore"], dbs["state"])
+}
+
+// replaceDBs moves the databases named names from srcDir to dstDir. Existing
+// databases in dstDir are moved to srcDir, and are put back if any of the new
+// ones can't be moved into place.
+func replaceDBs(dstDir, srcDir string, names []string) (err error) {
+	type move struct{ from, to string }
+	var done []move
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			_ = os.Rename(done[i].to, done[i].from)
+		}
+	}()
+
+	rename := func(from, to string) error {
+		if err := os.Rename(from, to); err != nil {
+			return err
+		}
+		done = append(done, move{from: from, to: to})
+		return nil
+	}
+	for _, name := range names {
+		path := filepath.Join(dstDir, name+".db")
+		if _, err := os.Stat(path); err == nil {
+			if err := rename(path, filepath.Join(srcDir, name+".old.db")); err != nil {
+				return err
+			}
+		}
+		if err := rename(filepath.Join(srcDir, name+".db"), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyImportedState checks the imported stores against the manifest.
+func verifyImportedState(manifest *ArchiveManifest, blockStoreDB, stateDB dbm.DB) error {
+	if blockStoreDB == nil || stateDB == nil {
+		return errors.New("archive is missing the block store or the state store")
+	}
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	if base, height := blockStore.Base(), blockStore.Height(); base != manifest.BaseHeight || height != manifest.Height {
+		return fmt.Errorf("imported block store has blocks %d-%d, expected %d-%d",
+			base, height, manifest.BaseHeight, manifest.Height)
+	}
+	st, err := state.NewStore(stateDB).Load()
+	if err != nil {
+		return err
+	}
+	if st.ChainID != manifest.ChainID || st.LastBlockHeight != manifest.StateHeight {
+		return fmt.Errorf("imported state is for chain %q at height %d, expected chain %q at height %d",
+			st.ChainID, st.LastBlockHeight, manifest.ChainID, manifest.StateHeight)
+	}
+	return nil
+}
+
+func exportDB(w io.Writer, db dbm.DB) error {
+	iter, err := db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if err := writeArchiveRecord(w, iter.Key()); err != nil {
+			return err
+		}
+		if err := writeArchiveRecord(w, iter.Value()); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return writeArchiveRecord(w, nil)
+}
+
+func importDB(r *bufio.Reader, db dbm.DB) error {
+	batch := db.NewBatch()
+	defer func() { _ = batch.Close() }()
+
+	for n := 1; ; n++ {
+		key, err := readArchiveRecord(r)
+		if err != nil {
+			return err
+		}
+		if len(key) == 0 {
+			return batch.WriteSync()
+		}
+		value, err := readArchiveRecord(r)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(key, value); err != nil {
+			return err
+		}
+
+		if n%archiveBatchSize == 0 {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			_ = batch.Close()
+			batch = db.NewBatch()
+		}
+	}
+}
+
+func writeArchiveRecord(w io.Writer, bz []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(bz)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(bz)
+	return err
+}
+
+func readArchiveRecord(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if size > maxArchiveRecordSize {
+		return nil, fmt.Errorf("record of %d bytes exceeds the maximum of %d bytes", size, maxArchiveRecordSize)
+	}
+	bz := make([]byte, size)
+	if _, err := io.ReadFull(r, bz); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
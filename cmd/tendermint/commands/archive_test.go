@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newArchiveTestConfig(t *testing.T, chainID string) *config.Config {
+	t.Helper()
+
+	cfg, err := config.ResetTestRootWithChainID(t.TempDir(), t.Name(), chainID)
+	require.NoError(t, err)
+	cfg.DBBackend = "goleveldb"
+	return cfg
+}
+
+// saveGenesisState saves the genesis state of cfg in its state store, with an
+// extra key in the block store.
+func saveGenesisState(t *testing.T, cfg *config.Config) sm.State {
+	t.Helper()
+
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	st, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	stateDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
+	require.NoError(t, err)
+	require.NoError(t, sm.NewStore(stateDB).Save(st))
+	require.NoError(t, stateDB.Close())
+
+	blockStoreDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	require.NoError(t, err)
+	require.NoError(t, blockStoreDB.Set([]byte("extra"), []byte("value")))
+	require.NoError(t, blockStoreDB.Close())
+	return st
+}
+
+func TestExportImportState(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+	st := saveGenesisState(t, src)
+
+	var archive bytes.Buffer
+	manifest, err := ExportState(src, &archive, false)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", manifest.ChainID)
+	assert.Equal(t, st.LastBlockHeight, manifest.StateHeight)
+	assert.Equal(t, []string{"blockstore", "state"}, manifest.Databases)
+
+	dst := newArchiveTestConfig(t, "archive")
+	imported, err := ImportState(dst, bytes.NewReader(archive.Bytes()), false)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.ChainID, imported.ChainID)
+
+	stateDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: dst})
+	require.NoError(t, err)
+	loaded, err := sm.NewStore(stateDB).Load()
+	require.NoError(t, err)
+	assert.Equal(t, st.Validators.Hash(), loaded.Validators.Hash())
+	require.NoError(t, stateDB.Close())
+
+	blockStoreDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: dst})
+	require.NoError(t, err)
+	value, err := blockStoreDB.Get([]byte("extra"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, blockStoreDB.Close())
+
+	// The stores now exist, and are only overwritten if forced.
+	_, err = ImportState(dst, bytes.NewReader(archive.Bytes()), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	_, err = ImportState(dst, bytes.NewReader(archive.Bytes()), true)
+	require.NoError(t, err)
+}
+
+func TestExportStateEvidence(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+	saveGenesisState(t, src)
+
+	evidenceDB, err := config.DefaultDBProvider(&config.DBContext{ID: "evidence", Config: src})
+	require.NoError(t, err)
+	require.NoError(t, evidenceDB.Close())
+
+	var archive bytes.Buffer
+	manifest, err := ExportState(src, &archive, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blockstore", "state", "evidence"}, manifest.Databases)
+
+	_, err = ImportState(newArchiveTestConfig(t, "archive"), &archive, false)
+	require.NoError(t, err)
+}
+
+func TestImportStateChainIDMismatch(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+	saveGenesisState(t, src)
+
+	var archive bytes.Buffer
+	_, err := ExportState(src, &archive, false)
+	require.NoError(t, err)
+
+	_, err = ImportState(newArchiveTestConfig(t, "other"), &archive, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "genesis file is for chain")
+}
+
+func TestImportStateTruncated(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+	saveGenesisState(t, src)
+
+	var archive bytes.Buffer
+	_, err := ExportState(src, &archive, false)
+	require.NoError(t, err)
+
+	truncated := archive.Bytes()[:archive.Len()/2]
+	_, err = ImportState(newArchiveTestConfig(t, "archive"), bytes.NewReader(truncated), false)
+	require.Error(t, err)
+}
+
+func TestImportStateForceKeepsStoresOnError(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+	saveGenesisState(t, src)
+
+	var archive bytes.Buffer
+	_, err := ExportState(src, &archive, false)
+	require.NoError(t, err)
+
+	dst := newArchiveTestConfig(t, "archive")
+	st := saveGenesisState(t, dst)
+
+	// A truncated archive fails to import, even if forced, without touching
+	// the existing stores or leaving temporary files behind.
+	truncated := archive.Bytes()[:archive.Len()/2]
+	_, err = ImportState(dst, bytes.NewReader(truncated), true)
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dst.DBDir())
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"blockstore.db", "state.db", "priv_validator_state.json"}, names)
+
+	stateDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: dst})
+	require.NoError(t, err)
+	loaded, err := sm.NewStore(stateDB).Load()
+	require.NoError(t, err)
+	assert.Equal(t, st.Validators.Hash(), loaded.Validators.Hash())
+	require.NoError(t, stateDB.Close())
+}
+
+func TestExportStateEmpty(t *testing.T) {
+	src := newArchiveTestConfig(t, "archive")
+
+	_, err := ExportState(src, &bytes.Buffer{}, false)
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@ const (
 	flagNodeRPCAddr = "rpc-laddr"
 	flagProfAddr    = "pprof-laddr"
 	flagFrequency   = "frequency"
+	flagLogFile     = "log-file"
 )
 
 func GetDebugCommand(logger log.Logger) *cobra.Command {
@@ -23,8 +23,13 @@ func getDumpCmd(logger log.Logger) *cobra.Command {
 		Short: "Continuously poll a Tendermint process and dump debugging data into a single location",
 		Long: `Continuously poll a Tendermint process and dump debugging data into a single
 location at a specified frequency. At each frequency interval, an archived and compressed
-file will contain node debugging information including the goroutine and heap profiles
-if enabled.`,
+file will contain node debugging information including the node's status, consensus
+state, configuration with credentials redacted, the tail of its log file and the
+goroutine and heap profiles if enabled. With a frequency of 0, a single archive is
+dumped, e.g. to attach to a bug report.
+
+Example:
+$ tendermint debug dump /path/to/out --frequency 0 --log-file /var/log/tendermint.log`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outDir := args[0]
@@ -36,10 +41,6 @@ if enabled.`,
 				return fmt.Errorf("flag %q not defined: %w", flagFrequency, err)
 			}
 
-			if frequency == 0 {
-				return errors.New("frequency must be positive")
-			}
-
 			nodeRPCAddr, err := cmd.Flags().GetString(flagNodeRPCAddr)
 			if err != nil {
 				return fmt.Errorf("flag %q not defined: %w", flagNodeRPCAddr, err)
@@ -50,6 +51,11 @@ if enabled.`,
 				return fmt.Errorf("flag %q not defined: %w", flagProfAddr, err)
 			}
 
+			logFile, err := cmd.Flags().GetString(flagLogFile)
+			if err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagLogFile, err)
+			}
+
 			if _, err := os.Stat(outDir); os.IsNotExist(err) {
 				if err := os.Mkdir(outDir, os.ModePerm); err != nil {
 					return fmt.Errorf("failed to create output directory: %w", err)
@@ -70,10 +76,15 @@ if enabled.`,
 
 			dumpArgs := dumpDebugDataArgs{
 				conf:     conf,
+				home:     home,
 				outDir:   outDir,
 				profAddr: profAddr,
+				logFile:  logFile,
 			}
 			dumpDebugData(ctx, logger, rpc, dumpArgs)
+			if frequency == 0 {
+				return nil
+			}
 
 			ticker := time.NewTicker(time.Duration(frequency) * time.Second)
 			for range ticker.C {
@@ -86,7 +97,7 @@ if enabled.`,
 	cmd.Flags().Uint(
 		flagFrequency,
 		30,
-		"the frequency (seconds) in which to poll, aggregate and dump Tendermint debug data",
+		"the frequency (seconds) in which to poll, aggregate and dump Tendermint debug data; 0 dumps it once",
 	)
 
 	cmd.Flags().String(
@@ -95,14 +106,22 @@ if enabled.`,
 		"the profiling server address (<host>:<port>)",
 	)
 
+	cmd.Flags().String(
+		flagLogFile,
+		"",
+		"the file the node logs to, whose tail is included in the dumps",
+	)
+
 	return cmd
 
 }
 
 type dumpDebugDataArgs struct {
 	conf     *config.Config
+	home     string
 	outDir   string
 	profAddr string
+	logFile  string
 }
 
 func dumpDebugData(ctx context.Context, logger log.Logger, rpc *rpchttp.HTTP, args dumpDebugDataArgs) {
@@ -139,6 +158,20 @@ func dumpDebugData(ctx context.Context, logger log.Logger, rpc *rpchttp.HTTP, ar
 		return
 	}
 
+	logger.Info("copying node configuration...")
+	if err := copyConfig(args.home, tmpDir); err != nil {
+		logger.Error("failed to copy node configuration", "error", err)
+		return
+	}
+
+	if args.logFile != "" {
+		logger.Info("copying node logs...")
+		if err := copyLogTail(args.logFile, tmpDir, maxLogTailBytes); err != nil {
+			logger.Error("failed to copy node logs", "error", err)
+			return
+		}
+	}
+
 	if args.profAddr != "" {
 		logger.Info("getting node goroutine profile...")
 		if err := dumpProfile(tmpDir, args.profAddr, "goroutine", 2); err != nil {
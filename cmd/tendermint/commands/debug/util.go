@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -8,6 +9,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/tendermint/tendermint/config"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
@@ -55,13 +58,99 @@ func copyWAL(conf *config.Config, dir string) error {
 	return copyFile(walPath, filepath.Join(dir, walFile))
 }
 
-// copyConfig copies the Tendermint node's config file. It returns an error if
-// the config file cannot be read or copied.
+// copyConfig copies the Tendermint node's config file, with its credentials
+// redacted. It returns an error if the config file cannot be read or written.
 func copyConfig(home, dir string) error {
 	configFile := "config.toml"
 	configPath := filepath.Join(home, "config", configFile)
 
-	return copyFile(configPath, filepath.Join(dir, configFile))
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, configFile), redactConfig(data), 0600)
+}
+
+var (
+	// configSecretKeys are the config keys whose values may contain
+	// credentials, in addition to the keys naming a password, secret or token.
+	configSecretKeys = map[string]bool{
+		"psql-conn": true,
+	}
+
+	// urlUserinfoRegexp matches the userinfo of URLs, which may contain a
+	// password, e.g. in genesis-url or rpc-servers.
+	urlUserinfoRegexp = regexp.MustCompile(`(\w+://)[^/@\s"',]+@`)
+)
+
+// redactConfig returns the TOML config data with the values that may contain
+// credentials replaced, keeping the rest of the config as is. The config only
+// holds the paths to the node and validator keys, which are never dumped.
+func redactConfig(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		key, value, ok := strings.Cut(line, "=")
+		if ok && !strings.HasPrefix(strings.TrimSpace(key), "#") && isSecretConfigKey(strings.TrimSpace(key)) {
+			if value = strings.TrimSpace(value); value != `""` && value != "" {
+				lines[i] = key + `= "<redacted>"`
+			}
+			continue
+		}
+		lines[i] = urlUserinfoRegexp.ReplaceAllString(line, "${1}<redacted>@")
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func isSecretConfigKey(key string) bool {
+	if configSecretKeys[key] {
+		return true
+	}
+	for _, word := range []string{"password", "secret", "token"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxLogTailBytes is how much of the end of the node's log file is dumped.
+const maxLogTailBytes = 10 * 1024 * 1024
+
+// copyLogTail copies at most the last maxBytes of the node's log file, from the
+// start of a line. It returns an error if the log file cannot be read or
+// copied.
+func copyLogTail(logFile, dir string, maxBytes int64) error {
+	src, err := os.Open(logFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(src)
+	if offset := info.Size() - maxBytes; offset > 0 {
+		if _, err := src.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		// skip the partial line at the offset
+		if _, err := reader.ReadString('\n'); err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	dest, err := os.Create(filepath.Join(dir, filepath.Base(logFile)))
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+
+	_, err = io.Copy(dest, reader)
+	return err
 }
 
 func dumpProfile(dir, addr, profile string, debug int) error {
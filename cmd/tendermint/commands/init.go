@@ -35,7 +35,7 @@ func MakeInitFilesCommand(conf *config.Config, logger log.Logger) *cobra.Command
 	}
 
 	cmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+		"Key type to generate privval file with. Options: ed25519, secp256k1, bls12381")
 
 	return cmd
 }
@@ -85,6 +85,8 @@ func initFilesWithConfig(ctx context.Context, conf *config.Config, logger log.Lo
 	genFile := conf.GenesisFile()
 	if tmos.FileExists(genFile) {
 		logger.Info("Found genesis file", "path", genFile)
+	} else if conf.GenesisURL != "" {
+		logger.Info("Genesis file will be fetched on start", "url", conf.GenesisURL)
 	} else {
 
 		genDoc := types.GenesisDoc{
@@ -92,9 +94,10 @@ func initFilesWithConfig(ctx context.Context, conf *config.Config, logger log.Lo
 			GenesisTime:     tmtime.Now(),
 			ConsensusParams: types.DefaultConsensusParams(),
 		}
-		if keyType == "secp256k1" {
+		switch keyType {
+		case types.ABCIPubKeyTypeSecp256k1, types.ABCIPubKeyTypeBls12381:
 			genDoc.ConsensusParams.Validator = types.ValidatorParams{
-				PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
+				PubKeyTypes: []string{keyType},
 			}
 		}
 
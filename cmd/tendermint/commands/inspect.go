@@ -24,6 +24,8 @@ func MakeInspectCommand(conf *config.Config, logger log.Logger) *cobra.Command {
 	tendermint process. Tendermint will not start up while in this inconsistent state. 
 	The inspect command can be used to query the block and state store using Tendermint
 	RPC calls to debug issues of inconsistent state.
+
+	The databases are opened read-only, and neither consensus nor p2p are started.
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, syscall.SIGINT)
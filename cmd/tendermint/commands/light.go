@@ -15,6 +15,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/light"
@@ -40,6 +41,7 @@ func MakeLightCommand(conf *config.Config, logger log.Logger) *cobra.Command {
 		trustedHash    []byte
 		trustLevelStr  string
 		blacklistTTL   time.Duration
+		merkleHash     string
 
 		logLevel  string
 		logFormat string
@@ -100,6 +102,10 @@ for applications built w/ Cosmos SDK).
 			chainID = args[0]
 			logger.Info("Creating client...", "chainID", chainID)
 
+			if err := merkle.SetHashFunction(merkleHash); err != nil {
+				return err
+			}
+
 			var witnessesAddrs []string
 			if witnessAddrsJoined != "" {
 				witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
@@ -169,6 +175,16 @@ for applications built w/ Cosmos SDK).
 			cfg := rpcserver.DefaultConfig()
 			cfg.MaxBodyBytes = conf.RPC.MaxBodyBytes
 			cfg.MaxHeaderBytes = conf.RPC.MaxHeaderBytes
+			cfg.ReadTimeout = conf.RPC.ReadTimeout
+			cfg.WriteTimeout = conf.RPC.WriteTimeout
+			cfg.IdleTimeout = conf.RPC.IdleTimeout
+			if cfg.RateLimits, cfg.DefaultRateLimit, err = conf.RPC.ParseRateLimits(); err != nil {
+				return err
+			}
+			cfg.RateLimitPerClient = conf.RPC.RateLimitPerClient
+			if cfg.UnixSocketPermissions, err = conf.RPC.UnixSocketFileMode(); err != nil {
+				return err
+			}
 			cfg.MaxOpenConnections = maxOpenConnections
 			// If necessary adjust global WriteTimeout to ensure it's greater than
 			// TimeoutBroadcastTxCommit.
@@ -229,6 +245,9 @@ for applications built w/ Cosmos SDK).
 	cmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	cmd.Flags().StringVar(&merkleHash, "merkle-hash-function", merkle.HashFunctionSHA256,
+		"the merkle hash function of the chain, as set in its genesis file (sha256|blake2b-256)",
+	)
 
 	return cmd
 
@@ -43,7 +43,9 @@ you can run this command when the event store backend dropped/disconnected or yo
 replace the backend. The default start-height is 0, meaning the tooling will start
 reindex from the base block height(inclusive); and the default end-height is 0, meaning
 the tooling will reindex until the latest block height(inclusive). User can omit
-either or both arguments.
+either or both arguments. Reindexing also backfills the app hash index used by the
+block_by_app_hash RPC endpoint and the block.proposer index for blocks indexed
+before they existed.
 	`,
 		Example: `
 	tendermint reindex-event
@@ -119,7 +121,10 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 			if conn == "" {
 				return nil, errors.New("the psql connection settings cannot be empty")
 			}
-			es, err := psql.NewEventSink(conn, cfg.ChainID())
+			es, err := psql.NewEventSink(conn, cfg.ChainID(),
+				psql.WithSchema(cfg.TxIndex.PsqlSchema),
+				psql.WithTablePrefix(cfg.TxIndex.PsqlTablePrefix),
+			)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/proxy"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeReplayVerifyCommand constructs a command that replays the stored blocks
+// against an app and reports the first height where the app hash diverges.
+func MakeReplayVerifyCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	var endHeight int64
+
+	cmd := &cobra.Command{
+		Use:   "replay-verify",
+		Short: "replay stored blocks against an app and verify the app hashes it computes",
+		Long: `
+Re-executes the blocks of the block store against the app given by --proxy-app,
+and compares the app hash the app computes for each block with the one recorded
+by the chain, to detect nondeterminism, e.g. after an app upgrade. Blocks are
+replayed from the height following the app's last block height, so the app
+should be started from a copy of its data at a lower height, or from scratch, in
+which case it is initialized from the genesis file. The command stops at the
+first diverging height. The state and block stores are only read, and the node
+must be stopped.
+`,
+		Example: `
+	tendermint replay-verify --proxy-app tcp://127.0.0.1:26658
+	tendermint replay-verify --proxy-app tcp://127.0.0.1:26658 --end-height 1000
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := ReplayVerify(cmd.Context(), conf, logger, endHeight)
+			if err != nil {
+				return fmt.Errorf("failed to verify replay: %w", err)
+			}
+
+			if res.Mismatch != nil {
+				return fmt.Errorf("app hash diverged after block %d: recorded %X, computed %X",
+					res.Mismatch.Height, res.Mismatch.Recorded, res.Mismatch.Computed)
+			}
+			fmt.Printf("Replayed %d blocks from height %d to %d, all app hashes match\n",
+				res.Replayed, res.StartHeight, res.EndHeight)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&endHeight, "end-height", 0,
+		"the last height to replay (defaults to the latest height)")
+	cmd.Flags().String(
+		"proxy-app",
+		conf.ProxyApp,
+		"proxy app address, or one of: 'kvstore',"+
+			" 'persistent_kvstore', 'e2e' or 'noop' for local testing.")
+	cmd.Flags().String("abci", conf.ABCI, "specify abci transport (socket | grpc | grpc-stream)")
+	return cmd
+}
+
+// ReplayVerify connects to the app of the config and replays the stored blocks
+// it hasn't executed yet up to endHeight, initializing the app from the genesis
+// file first if needed. See state.VerifyReplay.
+func ReplayVerify(ctx context.Context, conf *config.Config, logger log.Logger, endHeight int64) (*state.ReplayVerifyResult, error) {
+	// Block hashes depend on the Merkle hash function of the chain.
+	genDoc, err := types.GenesisDocFromFile(conf.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	if err := merkle.SetHashFunction(genDoc.MerkleHashFunction); err != nil {
+		return nil, err
+	}
+
+	blockStore, stateStore, err := loadStateAndBlockStore(conf)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	appClient, closer, err := proxy.ClientFactory(logger, conf.ProxyApp, conf.ABCI, conf.DBDir())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = closer.Close() }()
+
+	if err := appClient.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to the app: %w", err)
+	}
+	defer appClient.Stop()
+
+	info, err := appClient.Info(ctx, &proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the app: %w", err)
+	}
+	appHeight, appHash := info.LastBlockHeight, info.LastBlockAppHash
+
+	if appHeight == 0 {
+		if appHash, err = initChain(ctx, appClient, genDoc); err != nil {
+			return nil, fmt.Errorf("failed to initialize the app: %w", err)
+		}
+	}
+
+	return state.VerifyReplay(ctx, logger, appClient, stateStore, blockStore, appHeight, appHash, endHeight)
+}
+
+// initChain initializes the app from the genesis doc, like the handshake of a
+// new node, and returns the app hash it computed.
+func initChain(ctx context.Context, appClient abci.Application, genDoc *types.GenesisDoc) ([]byte, error) {
+	validators := make([]*types.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = types.NewValidator(val.PubKey, val.Power)
+	}
+	pbParams := genDoc.ConsensusParams.ToProto()
+	res, err := appClient.InitChain(ctx, &abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		InitialHeight:   genDoc.InitialHeight,
+		ConsensusParams: &pbParams,
+		Validators:      types.TM2PB.ValidatorUpdates(types.NewValidatorSet(validators)),
+		AppStateBytes:   genDoc.AppState,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.AppHash, nil
+}
@@ -0,0 +1,43 @@
+package commands_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/cmd/tendermint/commands"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReplayVerifyMerkleHashFunction(t *testing.T) {
+	// The Merkle hash function can only be set once per process, so the
+	// replay runs in a child process to leave the other tests on the default.
+	if os.Getenv("TM_TEST_REPLAY_VERIFY_CHILD") == "" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestReplayVerifyMerkleHashFunction$")
+		cmd.Env = append(os.Environ(), "TM_TEST_REPLAY_VERIFY_CHILD=1")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return
+	}
+
+	cfg, err := rpctest.CreateConfig(t, t.Name())
+	require.NoError(t, err)
+	cfg.ProxyApp = "noop"
+
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	genDoc.MerkleHashFunction = merkle.HashFunctionBlake2b
+	require.NoError(t, genDoc.SaveAs(cfg.GenesisFile()))
+
+	// There are no stores to replay, but the hash function of the genesis
+	// file is set before they are opened.
+	_, err = commands.ReplayVerify(context.Background(), cfg, log.NewNopLogger(), 0)
+	require.Error(t, err)
+	require.Equal(t, merkle.HashFunctionBlake2b, merkle.GetHashFunction())
+}
@@ -61,10 +61,10 @@ Only use in testing. This can cause the node to double sign`,
 	}
 
 	resetSignerCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Signer key type. Options: ed25519, secp256k1")
+		"Signer key type. Options: ed25519, secp256k1, bls12381")
 
 	resetAllCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Signer key type. Options: ed25519, secp256k1")
+		"Signer key type. Options: ed25519, secp256k1, bls12381")
 
 	resetCmd.AddCommand(resetBlocksCmd)
 	resetCmd.AddCommand(resetPeersCmd)
@@ -196,7 +196,7 @@ Only use in testing. This can cause the node to double sign`,
 	}
 
 	resetAllCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Signer key type. Options: ed25519, secp256k1")
+		"Signer key type. Options: ed25519, secp256k1, bls12381")
 
 	return resetAllCmd
 }
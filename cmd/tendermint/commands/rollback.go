@@ -9,48 +9,79 @@ import (
 	"github.com/tendermint/tendermint/internal/state"
 )
 
-var removeBlock bool = false
+var (
+	removeBlock      bool  = false
+	rollbackHeight   int64 = 0
+	dryRun           bool  = false
+	skipPrivValReset bool  = false
+)
 
 func MakeRollbackStateCommand(conf *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rollback",
-		Short: "rollback tendermint state by one height",
+		Short: "rollback tendermint state by one or more heights",
 		Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when Tendermint has persisted an incorrect app hash and is thus unable to make
-progress. Rollback overwrites a state at height n with the state at height n - 1.
-The application should also roll back to height n - 1. No blocks are removed, so upon
-restarting Tendermint the transactions in block n will be re-executed against the
-application.
+progress. Rollback overwrites a state at height n with the state at height n - 1,
+or with the state at the height given by --height. The application should also roll
+back to the same height. No blocks are removed, so upon restarting Tendermint the
+transactions in the rolled back blocks will be re-executed against the application.
+
+If the block store is ahead of the state, e.g. after a crash, the blocks that were
+never applied to the state are discarded instead, which requires --hard if there is
+more than one.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			height, hash, err := RollbackState(conf, removeBlock)
+			if dryRun {
+				current, rolledBack, err := PreviewRollbackState(conf, rollbackHeight)
+				if err != nil {
+					return fmt.Errorf("failed to preview rollback: %w", err)
+				}
+				printRollbackPreview(current, rolledBack)
+				return nil
+			}
+
+			resetPrivVal := removeBlock && !skipPrivValReset
+			res, err := RollbackState(conf, removeBlock, resetPrivVal, rollbackHeight)
 			if err != nil {
 				return fmt.Errorf("failed to rollback state: %w", err)
 			}
 
-			if removeBlock {
-				fmt.Printf("Rolled back both state and block to height %d and hash %X\n", height, hash)
+			if len(res.RolledBackHeights) > 0 {
+				fmt.Printf("Rolled back %d heights: %v\n", len(res.RolledBackHeights), res.RolledBackHeights)
+			}
+			if res.RemovedBlock {
+				fmt.Printf("Rolled back both state and block to height %d and hash %X\n", res.Height, res.AppHash)
 			} else {
-				fmt.Printf("Rolled back state to height %d and hash %X\n", height, hash)
+				fmt.Printf("Rolled back state to height %d and hash %X\n", res.Height, res.AppHash)
 			}
+			fmt.Printf("Validators hash %X\n", res.ValidatorsHash)
 			return nil
 		},
 	}
 	cmd.Flags().BoolVar(&removeBlock, "hard", false, "remove last block as well as state")
+	cmd.Flags().Int64Var(&rollbackHeight, "height", 0,
+		"height to roll back to (defaults to one below the current height)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"print the state the node would be rolled back to without changing anything")
+	cmd.Flags().BoolVar(&skipPrivValReset, "skip-priv-val-reset", false,
+		"do not reset the private validator's last sign state when used with --hard")
 
 	return cmd
 }
 
 // RollbackState takes the state at the current height n and overwrites it with the state
-// at height n - 1. Note state here refers to tendermint state not application state.
-// Returns the latest state height and app hash alongside an error if there was one.
-func RollbackState(config *config.Config, removeBlock bool) (int64, []byte, error) {
+// at targetHeight, or at height n - 1 if targetHeight is zero. Note state here refers to
+// tendermint state not application state. If resetPrivVal is true the private validator's
+// last sign state is reset as well.
+// Returns a description of the resulting state or an error if there was one.
+func RollbackState(config *config.Config, removeBlock, resetPrivVal bool, targetHeight int64) (*state.RollbackResult, error) {
 	// use the parsed config to load the block and state store
 	blockStore, stateStore, err := loadStateAndBlockStore(config)
 	fmt.Printf("Current blockStore height=%d hash=%X\n", blockStore.Height(), blockStore.LoadSeenCommit().Hash())
 	if err != nil {
-		return -1, nil, err
+		return nil, err
 	}
 
 	defer func() {
@@ -59,6 +90,38 @@ func RollbackState(config *config.Config, removeBlock bool) (int64, []byte, erro
 	}()
 
 	// rollback the last state
-	height, hash, err := state.Rollback(blockStore, stateStore, removeBlock, config.PrivValidator)
-	return height, hash, err
+	return state.Rollback(blockStore, stateStore, removeBlock, resetPrivVal, config.PrivValidator, targetHeight)
+}
+
+// PreviewRollbackState returns the current state along with the state that
+// RollbackState would produce for targetHeight, without modifying any stores.
+func PreviewRollbackState(config *config.Config, targetHeight int64) (state.State, state.State, error) {
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return state.State{}, state.State{}, err
+	}
+
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	current, err := stateStore.Load()
+	if err != nil {
+		return state.State{}, state.State{}, err
+	}
+
+	rolledBack, err := state.RollbackPreview(blockStore, stateStore, targetHeight)
+	if err != nil {
+		return state.State{}, state.State{}, err
+	}
+	return current, rolledBack, nil
+}
+
+func printRollbackPreview(current, rolledBack state.State) {
+	fmt.Printf("%-16s %-64s %-64s\n", "", "current", "after rollback")
+	fmt.Printf("%-16s %-64d %-64d\n", "height", current.LastBlockHeight, rolledBack.LastBlockHeight)
+	fmt.Printf("%-16s %-64X %-64X\n", "app hash", current.AppHash, rolledBack.AppHash)
+	fmt.Printf("%-16s %-64X %-64X\n", "results hash", current.LastResultsHash, rolledBack.LastResultsHash)
+	fmt.Printf("%-16s %-64X %-64X\n", "validators hash", current.Validators.Hash(), rolledBack.Validators.Hash())
 }
@@ -43,8 +43,9 @@ func TestRollbackIntegration(t *testing.T) {
 	t.Run("Rollback", func(t *testing.T) {
 		time.Sleep(time.Second)
 		require.NoError(t, app.Rollback())
-		height, _, err = commands.RollbackState(cfg, false)
-		require.NoError(t, err, "%d", height)
+		res, err := commands.RollbackState(cfg, false, false, 0)
+		require.NoError(t, err)
+		height = res.Height
 	})
 	t.Run("Restart", func(t *testing.T) {
 		require.True(t, height > 0, "%d", height)
@@ -51,7 +51,11 @@ func RootCommand(conf *config.Config, logger log.Logger) *cobra.Command {
 			}
 			*conf = *pconf
 			config.EnsureRoot(conf.RootDir)
-			if err := log.OverrideWithNewLogger(logger, conf.LogFormat, conf.LogLevel); err != nil {
+			logFields, err := conf.StaticLogFields()
+			if err != nil {
+				return err
+			}
+			if err := log.OverrideWithNewLoggerWithFields(logger, conf.LogFormat, conf.LogLevel, logFields); err != nil {
 				return err
 			}
 			if warning := pconf.DeprecatedFieldWarning(); warning != nil {
@@ -177,6 +177,30 @@ func TestRootConfig(t *testing.T) {
 	}
 }
 
+func TestRootConfigRPCListeners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defaultRoot := t.TempDir()
+	conf := clearConfig(t, defaultRoot)
+
+	// write a config file with additional RPC listeners, which are arrays of
+	// tables
+	written := cfg.DefaultConfig()
+	written.RPC.Listeners = []cfg.RPCListenerConfig{
+		{ListenAddress: "tcp://127.0.0.1:26658", Unsafe: true, AuthToken: "secret"},
+		{ListenAddress: "tcp://0.0.0.0:26659", CORSAllowedOrigins: []string{"*"}, CORSAllowedMethods: []string{"GET"}, DeniedMethods: []string{"broadcast_tx_commit"}},
+	}
+	require.NoError(t, tmos.EnsureDir(filepath.Join(defaultRoot, "config"), 0700))
+	require.NoError(t, cfg.WriteConfigFile(defaultRoot, written))
+
+	require.NoError(t, testSetup(ctx, t, conf, nil, nil))
+	require.Equal(t, []cfg.RPCListenerConfig{
+		{ListenAddress: "tcp://127.0.0.1:26658", Unsafe: true, CORSAllowedOrigins: []string{}, CORSAllowedMethods: []string{}, CORSAllowedHeaders: []string{}, AuthToken: "secret", AllowedMethods: []string{}, DeniedMethods: []string{}},
+		{ListenAddress: "tcp://0.0.0.0:26659", CORSAllowedOrigins: []string{"*"}, CORSAllowedMethods: []string{"GET"}, CORSAllowedHeaders: []string{}, AllowedMethods: []string{}, DeniedMethods: []string{"broadcast_tx_commit"}},
+	}, conf.RPC.Listeners)
+}
+
 // WriteConfigVals writes a toml file with the given values.
 // It returns an error if writing was impossible.
 func WriteConfigVals(dir string, vals map[string]string) error {
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	keepOldNodeKey bool          = false
+	gracePeriod    time.Duration = 0
+)
+
+// MakeRotateNodeKeyCommand constructs a command that replaces the node key
+// with a newly generated one.
+func MakeRotateNodeKeyCommand(conf *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-node-key",
+		Short: "replace the node key with a new one",
+		Long: `
+Generates a new node key, replacing the existing one, and prints the new node ID.
+The node must be stopped, and authenticates with the new key only once it is
+restarted. Since the node ID changes, the addresses of this node configured
+elsewhere, e.g. in the persistent-peers of other nodes, must be updated, and
+peers treat it as a new peer with no reputation.
+
+With --grace-period, the old key signs a record of the rotation, valid for the
+given period, which the node sends to its peers when connecting. Until it
+expires, peers dialing the old node ID accept the connection and dial the node
+with its new node ID from then on. Peers must support key rotations, and their
+configuration must still be updated before the grace period ends.
+
+With --keep-old, the old key is kept next to the new one, with an .old suffix,
+so that the rotation can be reverted by moving it back.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if gracePeriod < 0 {
+				return errors.New("the grace period can't be negative")
+			}
+			oldID, newID, err := RotateNodeKey(conf, keepOldNodeKey, gracePeriod)
+			if err != nil {
+				return fmt.Errorf("failed to rotate node key: %w", err)
+			}
+
+			fmt.Printf("Rotated node key from %s to %s\n", oldID, newID)
+			if gracePeriod > 0 {
+				fmt.Printf("Peers may dial the old node ID for %s\n", gracePeriod)
+			}
+			if keepOldNodeKey {
+				fmt.Printf("Kept the old node key in %s\n", oldNodeKeyFile(conf))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&keepOldNodeKey, "keep-old", false, "keep the old node key in a backup file")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", 0,
+		"period during which peers dialing the old node ID can still connect, e.g. 72h")
+
+	return cmd
+}
+
+// RotateNodeKey replaces the node key of conf with a newly generated one, and
+// returns the IDs of the old and new keys. If keepOld is set, the old key is
+// first copied to a file with an .old suffix, which must not exist. If
+// gracePeriod is positive, the rotation is recorded, signed by the old key, so
+// that the node can prove it to peers dialing the old node ID until the grace
+// period ends.
+func RotateNodeKey(conf *config.Config, keepOld bool, gracePeriod time.Duration) (types.NodeID, types.NodeID, error) {
+	oldKey, err := types.LoadNodeKey(conf.NodeKeyFile())
+	if err != nil {
+		return "", "", err
+	}
+
+	if keepOld {
+		path := oldNodeKeyFile(conf)
+		if _, err := os.Stat(path); err == nil {
+			return "", "", fmt.Errorf("%s already exists", path)
+		}
+		if err := oldKey.SaveAs(path); err != nil {
+			return "", "", fmt.Errorf("saving old node key: %w", err)
+		}
+	}
+
+	newKey := types.GenNodeKey()
+
+	// If saving the new key fails, the rotation is ignored by the node since
+	// it is for another node ID.
+	if gracePeriod > 0 {
+		rotation, err := types.NewNodeKeyRotation(oldKey, newKey.ID, time.Now().Add(gracePeriod))
+		if err != nil {
+			return "", "", err
+		}
+		if err := rotation.SaveAs(conf.NodeKeyRotationFile()); err != nil {
+			return "", "", fmt.Errorf("saving node key rotation: %w", err)
+		}
+	} else if err := os.Remove(conf.NodeKeyRotationFile()); err != nil && !os.IsNotExist(err) {
+		return "", "", fmt.Errorf("removing previous node key rotation: %w", err)
+	}
+
+	if err := newKey.SaveAs(conf.NodeKeyFile()); err != nil {
+		return "", "", fmt.Errorf("saving new node key: %w", err)
+	}
+	return oldKey.ID, newKey.ID, nil
+}
+
+func oldNodeKeyFile(conf *config.Config) string {
+	return conf.NodeKeyFile() + ".old"
+}
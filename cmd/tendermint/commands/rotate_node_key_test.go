@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRotateNodeKey(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), t.Name())
+	require.NoError(t, err)
+	initialID, err := cfg.LoadOrGenNodeKeyID()
+	require.NoError(t, err)
+
+	oldID, newID, err := RotateNodeKey(cfg, false, 0)
+	require.NoError(t, err)
+	assert.Equal(t, initialID, oldID)
+	assert.NotEqual(t, oldID, newID)
+	assert.NoFileExists(t, oldNodeKeyFile(cfg))
+
+	loadedID, err := cfg.LoadNodeKeyID()
+	require.NoError(t, err)
+	assert.Equal(t, newID, loadedID)
+
+	// the old key is kept if requested, but never overwritten
+	oldID, newID, err = RotateNodeKey(cfg, true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, loadedID, oldID)
+	oldKey, err := types.LoadNodeKey(oldNodeKeyFile(cfg))
+	require.NoError(t, err)
+	assert.Equal(t, oldID, oldKey.ID)
+
+	_, _, err = RotateNodeKey(cfg, true, 0)
+	require.Error(t, err)
+	loadedID, err = cfg.LoadNodeKeyID()
+	require.NoError(t, err)
+	assert.Equal(t, newID, loadedID)
+}
+
+func TestRotateNodeKeyGracePeriod(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), t.Name())
+	require.NoError(t, err)
+	_, err = cfg.LoadOrGenNodeKeyID()
+	require.NoError(t, err)
+
+	oldID, newID, err := RotateNodeKey(cfg, false, time.Hour)
+	require.NoError(t, err)
+
+	rotation, err := types.LoadNodeKeyRotation(cfg.NodeKeyRotationFile())
+	require.NoError(t, err)
+	require.NoError(t, rotation.ValidateBasic())
+	assert.Equal(t, oldID, rotation.PreviousNodeID())
+	assert.Equal(t, newID, rotation.NodeID)
+	assert.False(t, rotation.IsExpired(time.Now()))
+	assert.True(t, rotation.IsExpired(time.Now().Add(time.Hour)))
+
+	// a rotation without grace period removes the previous one
+	_, _, err = RotateNodeKey(cfg, false, 0)
+	require.NoError(t, err)
+	assert.NoFileExists(t, cfg.NodeKeyRotationFile())
+}
+
+func TestRotateNodeKeyMissing(t *testing.T) {
+	cfg := config.TestConfig().SetRoot(t.TempDir())
+
+	_, _, err := RotateNodeKey(cfg, false, 0)
+	require.Error(t, err)
+}
@@ -12,12 +12,17 @@ import (
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 )
 
 var (
 	genesisHash []byte
 )
 
+// HaltExitCode is the exit code of a node which halted at the configured halt
+// height.
+const HaltExitCode = 3
+
 // AddNodeFlags exposes some common configuration options from conf in the flag
 // set for cmd. This is a convenience for commands embedding a Tendermint node.
 func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
@@ -40,9 +45,15 @@ func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
 		"genesis-hash",
 		[]byte{},
 		"optional SHA-256 hash of the genesis file")
+	cmd.Flags().String("genesis-url", conf.GenesisURL,
+		"HTTPS URL to fetch the genesis file from if it doesn't exist locally")
+	cmd.Flags().String("genesis-sha256", conf.GenesisSHA256,
+		"hex-encoded SHA-256 hash of the genesis file fetched from genesis-url")
 	cmd.Flags().Int64("consensus.double-sign-check-height", conf.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
+	cmd.Flags().Bool("consensus.allow-app-version-upgrade", conf.Consensus.AllowAppVersionUpgrade,
+		"start even if the app reports a different version than the consensus params")
 
 	// abci flags
 	cmd.Flags().String(
@@ -50,7 +61,7 @@ func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
 		conf.ProxyApp,
 		"proxy app address, or one of: 'kvstore',"+
 			" 'persistent_kvstore', 'e2e' or 'noop' for local testing.")
-	cmd.Flags().String("abci", conf.ABCI, "specify abci transport (socket | grpc)")
+	cmd.Flags().String("abci", conf.ABCI, "specify abci transport (socket | grpc | grpc-stream)")
 
 	// rpc flags
 	cmd.Flags().String("rpc.laddr", conf.RPC.ListenAddress, "RPC listen address. Port required")
@@ -82,6 +93,10 @@ func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
 		"consensus.gossip-tx-key-only",
 		conf.Consensus.GossipTransactionKeyOnly,
 		"set this to false to gossip entire data rather than just the key")
+	cmd.Flags().Int64(
+		"consensus.halt-height",
+		conf.Consensus.HaltHeight,
+		"halt and exit once the block at this height is committed (0 disables it)")
 
 	addDBFlags(cmd, conf)
 }
@@ -123,6 +138,11 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider, conf *cfg.Config, logger lo
 
 			logger.Info("started node", "chain", conf.ChainID())
 
+			var haltCh <-chan struct{}
+			if h, ok := n.(interface{ Halted() <-chan struct{} }); ok {
+				haltCh = h.Halted()
+			}
+
 			for {
 				select {
 				case <-ctx.Done():
@@ -131,6 +151,11 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider, conf *cfg.Config, logger lo
 					logger.Info("Received signal to restart node.")
 					n.Stop()
 					os.Exit(1)
+				case <-haltCh:
+					logger.Info("halted at the configured halt height; stopping node",
+						"halt_height", conf.Consensus.HaltHeight, "exit_code", HaltExitCode)
+					n.Stop()
+					os.Exit(HaltExitCode)
 				}
 			}
 		},
@@ -144,6 +169,11 @@ func checkGenesisHash(config *cfg.Config) error {
 	if len(genesisHash) == 0 || config.Genesis == "" {
 		return nil
 	}
+	// The genesis file is yet to be fetched, and verified against
+	// genesis-sha256 instead.
+	if config.GenesisURL != "" && !tmos.FileExists(config.GenesisFile()) {
+		return nil
+	}
 
 	// Calculate SHA-256 hash of the genesis file.
 	f, err := os.Open(config.GenesisFile())
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeStoreVerifyCommand constructs a command that verifies the integrity of
+// the block and state stores and reports the first inconsistency.
+func MakeStoreVerifyCommand(conf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "store-verify",
+		Short: "verify the integrity of the block and state stores",
+		Long: `
+Walks the blocks of the block store from its base to its latest height, and
+checks that each block is well formed, that its parts match the part set hash
+of its block ID, and that its commit is signed by the validator set recorded in
+the state store for its height. It then checks that the heights of the state
+and block stores line up. The command stops at the first inconsistency and
+reports its height and reason. The databases are opened read-only.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := StoreVerify(conf)
+			if err != nil {
+				return fmt.Errorf("failed to verify the stores: %w", err)
+			}
+
+			if res.Inconsistency != nil {
+				return fmt.Errorf("inconsistency at height %d: %s",
+					res.Inconsistency.Height, res.Inconsistency.Reason)
+			}
+			fmt.Printf("Verified %d blocks from height %d to %d, the stores are consistent\n",
+				res.Verified, res.Base, res.Height)
+			return nil
+		},
+	}
+}
+
+// StoreVerify opens the block and state stores of the config read-only and
+// verifies them. See state.VerifyStores.
+func StoreVerify(conf *config.Config) (*state.StoreVerifyResult, error) {
+	// Block hashes depend on the Merkle hash function of the chain.
+	genDoc, err := types.GenesisDocFromFile(conf.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	if err := merkle.SetHashFunction(genDoc.MerkleHashFunction); err != nil {
+		return nil, err
+	}
+
+	blockStoreDB, err := config.ReadOnlyDBProvider(&config.DBContext{ID: "blockstore", Config: conf})
+	if err != nil {
+		return nil, fmt.Errorf("opening blockstore database: %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := config.ReadOnlyDBProvider(&config.DBContext{ID: "state", Config: conf})
+	if err != nil {
+		return nil, fmt.Errorf("opening state database: %w", err)
+	}
+	defer stateDB.Close()
+
+	return state.VerifyStores(state.NewStore(stateDB), store.NewBlockStore(blockStoreDB))
+}
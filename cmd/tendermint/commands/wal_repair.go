@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MakeWALRepairCommand constructs a command that truncates the consensus WAL
+// at its first corrupted record.
+func MakeWALRepairCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "wal-repair",
+		Short: "truncate the consensus WAL at its first corrupted record",
+		Long: `
+Reads the consensus write-ahead log, validating the checksum and length prefix of
+every record, and truncates it at the first corrupted record. This recovers nodes
+that are unable to start because of a torn final write. The original WAL is kept
+alongside the repaired one with a .CORRUPTED suffix. The node must be stopped.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			walFile := conf.Consensus.WalFile()
+			res, err := RepairWAL(walFile)
+			if err != nil {
+				return fmt.Errorf("failed to repair WAL %s: %w", walFile, err)
+			}
+
+			if res.Corrupted {
+				logger.Info("repaired WAL", "file", walFile, "messages", res.Messages,
+					"backup", walFile+".CORRUPTED")
+			} else {
+				logger.Info("WAL is not corrupted; nothing to repair", "file", walFile, "messages", res.Messages)
+			}
+			fmt.Printf("Last good message height=%d round=%d\n", res.Height, res.Round)
+			return nil
+		},
+	}
+}
+
+// RepairWAL truncates the WAL file at its first corrupted record. If the WAL
+// is corrupted the original file is moved to walFile.CORRUPTED and replaced by
+// the repaired one, otherwise it is left untouched.
+func RepairWAL(walFile string) (*consensus.WALRepairResult, error) {
+	repairedFile := walFile + ".REPAIRED"
+	res, err := consensus.RepairWAL(walFile, repairedFile)
+	if err != nil {
+		_ = os.Remove(repairedFile)
+		return nil, err
+	}
+
+	if !res.Corrupted {
+		return res, os.Remove(repairedFile)
+	}
+
+	if err := os.Rename(walFile, walFile+".CORRUPTED"); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(repairedFile, walFile); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
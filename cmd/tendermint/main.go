@@ -36,11 +36,14 @@ func main() {
 		commands.MakeLightCommand(conf, logger),
 		commands.MakeReplayCommand(conf, logger),
 		commands.MakeReplayConsoleCommand(conf, logger),
+		commands.MakeReplayVerifyCommand(conf, logger),
+		commands.MakeStoreVerifyCommand(conf),
 		commands.MakeResetCommand(conf, logger),
 		commands.MakeUnsafeResetAllCommand(conf, logger),
 		commands.MakeShowValidatorCommand(conf, logger),
 		commands.MakeTestnetFilesCommand(conf, logger),
 		commands.MakeShowNodeIDCommand(conf),
+		commands.MakeRotateNodeKeyCommand(conf),
 		commands.GenNodeKeyCmd,
 		commands.VersionCmd,
 		commands.MakeInspectCommand(conf, logger),
@@ -49,6 +52,9 @@ func main() {
 		debug.GetDebugCommand(logger),
 		commands.NewCompletionCmd(rcmd, true),
 		commands.MakeCompactDBCommand(conf, logger),
+		commands.MakeWALRepairCommand(conf, logger),
+		commands.MakeExportCommand(conf),
+		commands.MakeImportCommand(conf),
 	)
 
 	// NOTE:
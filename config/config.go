@@ -1,12 +1,17 @@
 package config
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +33,14 @@ const (
 	ModeFull      = "full"
 	ModeValidator = "validator"
 	ModeSeed      = "seed"
+
+	// WALFlushInterval flushes the consensus WAL periodically.
+	WALFlushInterval = "interval"
+	// WALFlushEveryMessage flushes the consensus WAL after every message.
+	WALFlushEveryMessage = "every-message"
+	// WALFlushMessages flushes the consensus WAL after a number of messages,
+	// and periodically.
+	WALFlushMessages = "messages"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -68,7 +81,9 @@ type Config struct {
 	P2P             *P2PConfig             `mapstructure:"p2p"`
 	Mempool         *MempoolConfig         `mapstructure:"mempool"`
 	StateSync       *StateSyncConfig       `mapstructure:"statesync"`
+	BlockSync       *BlockSyncConfig       `mapstructure:"blocksync"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
+	Evidence        *EvidenceConfig        `mapstructure:"evidence"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx-index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 	PrivValidator   *PrivValidatorConfig   `mapstructure:"priv-validator"`
@@ -84,7 +99,9 @@ func DefaultConfig() *Config {
 		P2P:             DefaultP2PConfig(),
 		Mempool:         DefaultMempoolConfig(),
 		StateSync:       DefaultStateSyncConfig(),
+		BlockSync:       DefaultBlockSyncConfig(),
 		Consensus:       DefaultConsensusConfig(),
+		Evidence:        DefaultEvidenceConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
@@ -108,7 +125,9 @@ func TestConfig() *Config {
 		P2P:             TestP2PConfig(),
 		Mempool:         TestMempoolConfig(),
 		StateSync:       TestStateSyncConfig(),
+		BlockSync:       TestBlockSyncConfig(),
 		Consensus:       TestConsensusConfig(),
+		Evidence:        TestEvidenceConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
@@ -143,9 +162,18 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.StateSync.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [statesync] section: %w", err)
 	}
+	if err := cfg.BlockSync.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [blocksync] section: %w", err)
+	}
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.Evidence.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [evidence] section: %w", err)
+	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx-index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -214,19 +242,39 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db-dir"`
 
+	// Codec used to compress block parts in the block store: none | snappy | zstd
+	// Changing the codec only affects newly saved blocks; existing blocks are
+	// always loaded with the codec they were written with.
+	BlockCompression string `mapstructure:"block-compression"`
+
+	// Number of block metas (headers and block IDs) the block store keeps in
+	// memory, evicting the least recently used ones. 0 disables the cache.
+	BlockMetaCacheSize int `mapstructure:"block-meta-cache-size"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log-level"`
 
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log-format"`
 
+	// Static fields added to every log line, as key=value pairs
+	LogFields []string `mapstructure:"log-fields"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis-file"`
 
+	// HTTPS URL to fetch the genesis file from, if it doesn't exist locally.
+	// The fetched file is verified against GenesisSHA256 and saved to the
+	// genesis file path, so that it is only fetched once.
+	GenesisURL string `mapstructure:"genesis-url"`
+
+	// Hex-encoded SHA-256 hash of the genesis file fetched from GenesisURL.
+	GenesisSHA256 string `mapstructure:"genesis-sha256"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
-	// Mechanism to connect to the ABCI application: socket | grpc
+	// Mechanism to connect to the ABCI application: socket | grpc | grpc-stream
 	ABCI string `mapstructure:"abci"`
 
 	// If true, query the ABCI app on connecting to a new peer
@@ -250,6 +298,9 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers: false,
 		DBBackend:   "goleveldb",
 		DBPath:      "data",
+
+		BlockCompression:   "none",
+		BlockMetaCacheSize: 1000,
 	}
 }
 
@@ -277,6 +328,12 @@ func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// NodeKeyRotationFile returns the full path to the file recording the last
+// rotation of the node key, next to the node_key.json file
+func (cfg BaseConfig) NodeKeyRotationFile() string {
+	return cfg.NodeKeyFile() + ".rotation"
+}
+
 // LoadNodeKey loads NodeKey located in filePath.
 func (cfg BaseConfig) LoadNodeKeyID() (types.NodeID, error) {
 	jsonBytes, err := os.ReadFile(cfg.NodeKeyFile())
@@ -312,6 +369,19 @@ func (cfg BaseConfig) LoadOrGenNodeKeyID() (types.NodeID, error) {
 	return nodeKey.ID, nil
 }
 
+// StaticLogFields returns the LogFields as a map from keys to values.
+func (cfg BaseConfig) StaticLogFields() (map[string]string, error) {
+	fields := make(map[string]string, len(cfg.LogFields))
+	for _, field := range cfg.LogFields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a key=value pair", field)
+		}
+		fields[key] = value
+	}
+	return fields, log.ValidateStaticFields(fields)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -326,6 +396,10 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errors.New("unknown log format (must be 'plain', 'text' or 'json')")
 	}
 
+	if _, err := cfg.StaticLogFields(); err != nil {
+		return fmt.Errorf("log-fields: %w", err)
+	}
+
 	switch cfg.Mode {
 	case ModeFull, ModeValidator, ModeSeed:
 	case "":
@@ -335,9 +409,41 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
 
+	switch cfg.BlockCompression {
+	case "", "none", "snappy", "zstd":
+	default:
+		return fmt.Errorf("unknown block-compression: %v (must be 'none', 'snappy' or 'zstd')", cfg.BlockCompression)
+	}
+
+	if cfg.BlockMetaCacheSize < 0 {
+		return errors.New("block-meta-cache-size can't be negative")
+	}
+
+	if cfg.GenesisURL != "" {
+		if !strings.HasPrefix(cfg.GenesisURL, "https://") {
+			return errors.New("genesis-url must be an https:// URL")
+		}
+		if _, err := cfg.GenesisHash(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// GenesisHash returns the decoded GenesisSHA256. It returns an error if it
+// isn't a valid SHA-256 hash.
+func (cfg BaseConfig) GenesisHash() ([]byte, error) {
+	hash, err := hex.DecodeString(cfg.GenesisSHA256)
+	if err != nil {
+		return nil, fmt.Errorf("genesis-sha256: %w", err)
+	}
+	if len(hash) != sha256.Size {
+		return nil, fmt.Errorf("genesis-sha256 must be a hex-encoded SHA-256 hash of %d bytes", sha256.Size)
+	}
+	return hash, nil
+}
+
 //-----------------------------------------------------------------------------
 // PrivValidatorConfig
 
@@ -351,6 +457,10 @@ type PrivValidatorConfig struct {
 	// Path to the JSON file containing the last sign state of a validator
 	State string `mapstructure:"state-file"`
 
+	// Path to a lock file acquired on startup, preventing two processes
+	// from signing with the same key. Empty disables the lock.
+	Lock string `mapstructure:"lock-file"`
+
 	// TCP or UNIX socket address for Tendermint to listen on for
 	// connections from an external PrivValidator process
 	ListenAddr string `mapstructure:"laddr"`
@@ -400,6 +510,15 @@ func (cfg *PrivValidatorConfig) StateFile() string {
 	return rootify(cfg.State, cfg.RootDir)
 }
 
+// LockFile returns the full path to the signer lock file, or an empty string
+// if the lock is disabled.
+func (cfg *PrivValidatorConfig) LockFile() string {
+	if cfg.Lock == "" {
+		return ""
+	}
+	return rootify(cfg.Lock, cfg.RootDir)
+}
+
 func (cfg *PrivValidatorConfig) AreSecurityOptionsPresent() bool {
 	switch {
 	case cfg.RootCA == "":
@@ -423,6 +542,12 @@ type RPCConfig struct {
 	// TCP or UNIX socket address for the RPC server to listen on
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Permissions of the socket file when listening on a UNIX socket, as an
+	// octal string, e.g. "0660" to also let the processes of the group of the
+	// node connect. A stale socket file is replaced on startup, and the file is
+	// removed on shutdown.
+	UnixSocketPermissions string `mapstructure:"unix-socket-permissions"`
+
 	// A list of origins a cross-domain request can be executed from.
 	// If the special '*' value is present in the list, all origins will be allowed.
 	// An origin may contain a wildcard (*) to replace 0 or more characters (i.e.: http://*.domain.com).
@@ -438,6 +563,18 @@ type RPCConfig struct {
 	// Activate unsafe RPC commands like /dial-persistent-peers and /unsafe-flush-mempool
 	Unsafe bool `mapstructure:"unsafe"`
 
+	// If not empty, requests to the listen addresses above must carry an
+	// "Authorization: Bearer <auth-token>" header.
+	AuthToken string `mapstructure:"auth-token"`
+
+	// If not empty, only these RPC methods can be called on the listen
+	// addresses above. The other methods return a "Method not allowed" error.
+	AllowedMethods []string `mapstructure:"allowed-methods"`
+
+	// RPC methods which can't be called on the listen addresses above, e.g.
+	// "broadcast_tx_commit". They return a "Method not allowed" error.
+	DeniedMethods []string `mapstructure:"denied-methods"`
+
 	// Maximum number of simultaneous connections (including WebSocket).
 	// If you want to accept a larger number than the default, make sure
 	// you increase your OS limits.
@@ -483,9 +620,26 @@ type RPCConfig struct {
 	// up to 2000, choose a value > 2000.
 	EventLogMaxItems int `mapstructure:"event-log-max-items"`
 
+	// Maximum duration for reading an entire request, including its body.
+	// 0 - unlimited.
+	ReadTimeout time.Duration `mapstructure:"read-timeout"`
+
+	// Maximum duration for writing a response, from the end of the request
+	// read. It applies to all endpoints, so it should be a safety valve rather
+	// than a resource control. 0 - unlimited.
+	// WebSocket connections set their own read and write deadlines for each
+	// message once upgraded, so the read and write timeouts only bound their
+	// upgrade request.
+	WriteTimeout time.Duration `mapstructure:"write-timeout"`
+
+	// Maximum duration to wait for the next request on a keep-alive
+	// connection. 0 - the read timeout is used.
+	IdleTimeout time.Duration `mapstructure:"idle-timeout"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
-	// WARNING: Using a value larger than 10s will result in increasing the
-	// global HTTP write timeout, which applies to all connections and endpoints.
+	// WARNING: Using a value larger than the write timeout will result in
+	// increasing the global HTTP write timeout, which applies to all
+	// connections and endpoints.
 	// See https://github.com/tendermint/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout-broadcast-tx-commit"`
 
@@ -495,6 +649,20 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Token bucket rate limits of RPC methods, as "<method>=<qps>:<burst>"
+	// strings, e.g. "block_search=2:10" allows 2 calls per second on average,
+	// in bursts of up to 10 calls. Calls over the limit get a 429 Too Many
+	// Requests response with a Retry-After header, while calls made over a
+	// websocket connection get a JSON-RPC error.
+	RateLimits []string `mapstructure:"rate-limits"`
+
+	// Rate limit shared by the methods without their own rate limit, as a
+	// "<qps>:<burst>" string. Empty - unlimited.
+	DefaultRateLimit string `mapstructure:"default-rate-limit"`
+
+	// If true, the rate limits apply to each client IP address separately.
+	RateLimitPerClient bool `mapstructure:"rate-limit-per-client"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -518,17 +686,59 @@ type RPCConfig struct {
 
 	// Lag threshold determines the threshold for whether the /lag_status endpoint returns OK or not
 	LagThreshold int64 `mapstructure:"lag-threshold"`
+
+	// Additional listen addresses, each with its own unsafe, CORS and auth
+	// settings. The other settings of the RPC config apply to all of them.
+	Listeners []RPCListenerConfig `mapstructure:"listeners"`
+}
+
+// RPCListenerConfig defines an additional address for the RPC server to
+// listen on, with its own access settings.
+type RPCListenerConfig struct {
+	// TCP or UNIX socket address to listen on.
+	ListenAddress string `mapstructure:"laddr"`
+
+	// Activate unsafe RPC commands on this address.
+	Unsafe bool `mapstructure:"unsafe"`
+
+	// A list of origins a cross-domain request can be executed from. Empty
+	// disables cors support on this address.
+	CORSAllowedOrigins []string `mapstructure:"cors-allowed-origins"`
+
+	// A list of methods the client is allowed to use with cross-domain
+	// requests. Empty - the cors-allowed-methods of the RPC config.
+	CORSAllowedMethods []string `mapstructure:"cors-allowed-methods"`
+
+	// A list of non simple headers the client is allowed to use with
+	// cross-domain requests. Empty - the cors-allowed-headers of the RPC
+	// config.
+	CORSAllowedHeaders []string `mapstructure:"cors-allowed-headers"`
+
+	// If not empty, requests to this address must carry an
+	// "Authorization: Bearer <auth-token>" header.
+	AuthToken string `mapstructure:"auth-token"`
+
+	// If not empty, only these RPC methods can be called on this address.
+	AllowedMethods []string `mapstructure:"allowed-methods"`
+
+	// RPC methods which can't be called on this address.
+	DeniedMethods []string `mapstructure:"denied-methods"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
 func DefaultRPCConfig() *RPCConfig {
 	return &RPCConfig{
-		ListenAddress:      "tcp://127.0.0.1:26657",
+		ListenAddress:         "tcp://127.0.0.1:26657",
+		UnixSocketPermissions: "0600",
+
 		CORSAllowedOrigins: []string{},
 		CORSAllowedMethods: []string{http.MethodHead, http.MethodGet, http.MethodPost},
 		CORSAllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
 
 		Unsafe:             false,
+		AuthToken:          "",
+		AllowedMethods:     []string{},
+		DeniedMethods:      []string{},
 		MaxOpenConnections: 900,
 
 		// Settings for event subscription.
@@ -538,11 +748,19 @@ func DefaultRPCConfig() *RPCConfig {
 		EventLogWindowSize:           30 * time.Second,
 		EventLogMaxItems:             0,
 
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 0, // unlimited, so as not to cut off long queries
+		IdleTimeout:  0, // same as the read timeout
+
 		TimeoutBroadcastTxCommit: 10 * time.Second,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		RateLimits:         []string{},
+		DefaultRateLimit:   "",
+		RateLimitPerClient: false,
+
 		TLSCertFile:  "",
 		TLSKeyFile:   "",
 		LagThreshold: 300,
@@ -576,6 +794,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.EventLogMaxItems < 0 {
 		return errors.New("event-log-max-items must not be negative")
 	}
+	if cfg.ReadTimeout < 0 {
+		return errors.New("read-timeout can't be negative")
+	}
+	if cfg.WriteTimeout < 0 {
+		return errors.New("write-timeout can't be negative")
+	}
+	if cfg.IdleTimeout < 0 {
+		return errors.New("idle-timeout can't be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout-broadcast-tx-commit can't be negative")
 	}
@@ -588,14 +815,154 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.LagThreshold < 0 {
 		return errors.New("lag-threshold can't be negative")
 	}
+	if _, _, err := cfg.ParseRateLimits(); err != nil {
+		return err
+	}
+	if _, err := cfg.UnixSocketFileMode(); err != nil {
+		return err
+	}
+	if err := validateMethodNames(cfg.AllowedMethods, cfg.DeniedMethods); err != nil {
+		return err
+	}
+	for i, listener := range cfg.Listeners {
+		if strings.TrimSpace(listener.ListenAddress) == "" {
+			return fmt.Errorf("listeners[%d]: laddr can't be empty", i)
+		}
+		if err := validateMethodNames(listener.AllowedMethods, listener.DeniedMethods); err != nil {
+			return fmt.Errorf("listeners[%d]: %w", i, err)
+		}
+	}
 	return nil
 }
 
+// validateMethodNames checks that the allowed and denied RPC method names
+// aren't empty. Whether the methods exist is only known to the RPC server.
+func validateMethodNames(allowed, denied []string) error {
+	for _, name := range allowed {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("allowed-methods can't contain an empty method name")
+		}
+	}
+	for _, name := range denied {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("denied-methods can't contain an empty method name")
+		}
+	}
+	return nil
+}
+
+// AllListeners returns the settings of every address the RPC server listens
+// on: the comma separated addresses of ListenAddress, which share the
+// settings of the RPC config, followed by the additional Listeners. The CORS
+// methods and headers of the additional listeners default to the ones of the
+// RPC config, but not their allowed and denied methods.
+func (cfg *RPCConfig) AllListeners() []RPCListenerConfig {
+	var listeners []RPCListenerConfig
+	for _, addr := range strings.Split(cfg.ListenAddress, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		listeners = append(listeners, RPCListenerConfig{
+			ListenAddress:      addr,
+			Unsafe:             cfg.Unsafe,
+			CORSAllowedOrigins: cfg.CORSAllowedOrigins,
+			CORSAllowedMethods: cfg.CORSAllowedMethods,
+			CORSAllowedHeaders: cfg.CORSAllowedHeaders,
+			AuthToken:          cfg.AuthToken,
+			AllowedMethods:     cfg.AllowedMethods,
+			DeniedMethods:      cfg.DeniedMethods,
+		})
+	}
+	for _, listener := range cfg.Listeners {
+		listener.ListenAddress = strings.TrimSpace(listener.ListenAddress)
+		if len(listener.CORSAllowedMethods) == 0 {
+			listener.CORSAllowedMethods = cfg.CORSAllowedMethods
+		}
+		if len(listener.CORSAllowedHeaders) == 0 {
+			listener.CORSAllowedHeaders = cfg.CORSAllowedHeaders
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// UnixSocketFileMode returns the UnixSocketPermissions as a file mode. An
+// empty string is a zero mode, which leaves the permissions to the umask.
+func (cfg *RPCConfig) UnixSocketFileMode() (os.FileMode, error) {
+	if cfg.UnixSocketPermissions == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(cfg.UnixSocketPermissions, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("unix-socket-permissions: %q is not an octal permission mode", cfg.UnixSocketPermissions)
+	}
+	return os.FileMode(mode), nil
+}
+
+// RateLimit is a token bucket rate limit: requests are allowed at QPS per
+// second on average, in bursts of up to Burst requests. A zero QPS means no
+// limit.
+type RateLimit struct {
+	QPS   float64
+	Burst int
+}
+
+// ParseRateLimit parses a rate limit of the form "<qps>:<burst>", e.g. "2.5:10".
+func ParseRateLimit(s string) (RateLimit, error) {
+	qps, burst, ok := strings.Cut(s, ":")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("rate limit %q is not of the form <qps>:<burst>", s)
+	}
+	var (
+		limit RateLimit
+		err   error
+	)
+	if limit.QPS, err = strconv.ParseFloat(qps, 64); err != nil || limit.QPS <= 0 || math.IsInf(limit.QPS, 0) {
+		return RateLimit{}, fmt.Errorf("rate limit %q: qps must be a positive number", s)
+	}
+	if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst < 1 {
+		return RateLimit{}, fmt.Errorf("rate limit %q: burst must be a positive integer", s)
+	}
+	return limit, nil
+}
+
+// ParseRateLimits returns the RateLimits as a map from method names to rate
+// limits, and the DefaultRateLimit.
+func (cfg *RPCConfig) ParseRateLimits() (map[string]RateLimit, RateLimit, error) {
+	limits := make(map[string]RateLimit, len(cfg.RateLimits))
+	for _, entry := range cfg.RateLimits {
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || method == "" {
+			return nil, RateLimit{}, fmt.Errorf("rate-limits: %q is not a <method>=<qps>:<burst> pair", entry)
+		}
+		limit, err := ParseRateLimit(value)
+		if err != nil {
+			return nil, RateLimit{}, fmt.Errorf("rate-limits: %w", err)
+		}
+		limits[method] = limit
+	}
+
+	var defaultLimit RateLimit
+	if cfg.DefaultRateLimit != "" {
+		var err error
+		if defaultLimit, err = ParseRateLimit(cfg.DefaultRateLimit); err != nil {
+			return nil, RateLimit{}, fmt.Errorf("default-rate-limit: %w", err)
+		}
+	}
+	return limits, defaultLimit, nil
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
 }
 
+// IsCorsEnabled returns true if cross-origin resource sharing is enabled on
+// the listener.
+func (cfg RPCListenerConfig) IsCorsEnabled() bool {
+	return len(cfg.CORSAllowedOrigins) != 0
+}
+
 func (cfg RPCConfig) KeyFile() string {
 	path := cfg.TLSKeyFile
 	if filepath.IsAbs(path) {
@@ -626,6 +993,12 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Transport used for peer connections. Options are "mconn" (MConn over
+	// TCP) and "quic" (MConn over QUIC, one stream per channel), with the
+	// default being "mconn". Peers must be addressed with the matching protocol, e.g.
+	// quic://<id>@<host>:<port>.
+	Transport string `mapstructure:"transport"`
+
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external-address"`
 
@@ -644,6 +1017,17 @@ type P2PConfig struct { //nolint: maligned
 	// outbound).
 	MaxConnections uint16 `mapstructure:"max-connections"`
 
+	// MaxInboundConnections and MaxOutboundConnections limit the number of
+	// inbound and outbound connections, not counting persistent and
+	// unconditional peers. 0 means no limit other than MaxConnections.
+	MaxInboundConnections  uint16 `mapstructure:"max-inbound-connections"`
+	MaxOutboundConnections uint16 `mapstructure:"max-outbound-connections"`
+
+	// ReservedConnections is the number of the MaxConnections slots which
+	// only persistent and unconditional peers may use, so that other peers
+	// can't take all of them.
+	ReservedConnections uint16 `mapstructure:"reserved-connections"`
+
 	// MaxIncomingConnectionAttempts rate limits the number of incoming connection
 	// attempts per IP address.
 	MaxIncomingConnectionAttempts uint `mapstructure:"max-incoming-connection-attempts"`
@@ -674,6 +1058,14 @@ type P2PConfig struct { //nolint: maligned
 	HandshakeTimeout time.Duration `mapstructure:"handshake-timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial-timeout"`
 
+	// Timeout for the secret connection key exchange and authentication,
+	// which is the first part of the handshake.
+	AuthTimeout time.Duration `mapstructure:"auth-timeout"`
+
+	// Time spent on shutdown sending the messages already queued for peers,
+	// before closing the connections. 0 drops them.
+	DrainTimeout time.Duration `mapstructure:"drain-timeout"`
+
 	// Testing params.
 	// Force dial to fail
 	TestDialFail bool `mapstructure:"test-dial-fail"`
@@ -685,12 +1077,36 @@ type P2PConfig struct { //nolint: maligned
 
 	// List of node IDs, to which a connection will be (re)established ignoring any existing limits
 	UnconditionalPeerIDs string `mapstructure:"unconditional-peer-ids"`
+
+	// Time it takes for a peer's score to move one point back toward the
+	// default score. 0 disables decay.
+	PeerScoreDecayInterval time.Duration `mapstructure:"peer-score-decay-interval"`
+
+	// Score at or below which a misbehaving peer is disconnected and
+	// temporarily banned.
+	PeerBanThreshold int64 `mapstructure:"peer-ban-threshold"`
+
+	// Duration of a peer's first ban. Ban durations double each time the same
+	// peer is banned, up to PeerMaxBanDuration.
+	PeerMinBanDuration time.Duration `mapstructure:"peer-min-ban-duration"`
+
+	// Maximum duration of a peer ban. 0 disables bans.
+	PeerMaxBanDuration time.Duration `mapstructure:"peer-max-ban-duration"`
+
+	// Comma separated list of IP ranges in CIDR notation. If set, only peers
+	// with an IP address in one of these ranges are dialed or accepted.
+	AllowedCIDRs string `mapstructure:"allowed-cidrs"`
+
+	// Comma separated list of IP ranges in CIDR notation from which peers are
+	// neither dialed nor accepted. Unconditional peers bypass it.
+	DeniedCIDRs string `mapstructure:"denied-cidrs"`
 }
 
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                 "tcp://0.0.0.0:26656",
+		Transport:                     "mconn",
 		ExternalAddress:               "",
 		UPNP:                          false,
 		MaxConnections:                64,
@@ -708,14 +1124,25 @@ func DefaultP2PConfig() *P2PConfig {
 		AllowDuplicateIP:        false,
 		HandshakeTimeout:        20 * time.Second,
 		DialTimeout:             3 * time.Second,
+		AuthTimeout:             20 * time.Second,
+		DrainTimeout:            time.Second,
 		TestDialFail:            false,
 		QueueType:               "simple-priority",
+		PeerScoreDecayInterval:  0,
+		PeerBanThreshold:        0,
+		PeerMinBanDuration:      time.Minute,
+		PeerMaxBanDuration:      0,
 	}
 }
 
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case "mconn", "quic":
+	default:
+		return fmt.Errorf("unknown transport: %v", cfg.Transport)
+	}
 	if cfg.FlushThrottleTimeout < 0 {
 		return errors.New("flush-throttle-timeout can't be negative")
 	}
@@ -728,9 +1155,84 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv-rate can't be negative")
 	}
+	if cfg.HandshakeTimeout < 0 {
+		return errors.New("handshake-timeout can't be negative")
+	}
+	if cfg.AuthTimeout < 0 {
+		return errors.New("auth-timeout can't be negative")
+	}
+	if cfg.DrainTimeout < 0 {
+		return errors.New("drain-timeout can't be negative")
+	}
+	if cfg.PeerScoreDecayInterval < 0 {
+		return errors.New("peer-score-decay-interval can't be negative")
+	}
+	if cfg.PeerMinBanDuration < 0 {
+		return errors.New("peer-min-ban-duration can't be negative")
+	}
+	if cfg.PeerMaxBanDuration < 0 {
+		return errors.New("peer-max-ban-duration can't be negative")
+	}
+	if cfg.PeerMaxBanDuration > 0 {
+		if cfg.PeerMinBanDuration == 0 {
+			return errors.New("peer-min-ban-duration must be set when bans are enabled")
+		}
+		if cfg.PeerMinBanDuration > cfg.PeerMaxBanDuration {
+			return errors.New("peer-min-ban-duration can't exceed peer-max-ban-duration")
+		}
+		// Peers start with a score of 10, so a threshold of 9 or more would
+		// ban a peer on its first bad report.
+		if cfg.PeerBanThreshold >= 9 {
+			return errors.New("peer-ban-threshold must be less than 9")
+		}
+	}
+	if cfg.MaxConnections > 0 {
+		if cfg.MaxInboundConnections > cfg.MaxConnections {
+			return errors.New("max-inbound-connections can't exceed max-connections")
+		}
+		if cfg.MaxOutboundConnections > cfg.MaxConnections {
+			return errors.New("max-outbound-connections can't exceed max-connections")
+		}
+		if cfg.ReservedConnections > cfg.MaxConnections {
+			return errors.New("reserved-connections can't exceed max-connections")
+		}
+	}
+	if _, err := parseCIDRs(cfg.AllowedCIDRs); err != nil {
+		return fmt.Errorf("invalid allowed-cidrs: %w", err)
+	}
+	if _, err := parseCIDRs(cfg.DeniedCIDRs); err != nil {
+		return fmt.Errorf("invalid denied-cidrs: %w", err)
+	}
 	return nil
 }
 
+// AllowedIPNets returns the IP ranges of AllowedCIDRs.
+func (cfg *P2PConfig) AllowedIPNets() ([]*net.IPNet, error) {
+	return parseCIDRs(cfg.AllowedCIDRs)
+}
+
+// DeniedIPNets returns the IP ranges of DeniedCIDRs.
+func (cfg *P2PConfig) DeniedIPNets() ([]*net.IPNet, error) {
+	return parseCIDRs(cfg.DeniedCIDRs)
+}
+
+// parseCIDRs parses a comma separated list of IP ranges in CIDR notation.
+func parseCIDRs(s string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	for _, cidr := range strings.Split(s, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
 // TestP2PConfig returns a configuration for testing the peer-to-peer layer
 func TestP2PConfig() *P2PConfig {
 	cfg := DefaultP2PConfig()
@@ -799,6 +1301,34 @@ type MempoolConfig struct {
 	// blacklist the peer.
 	CheckTxErrorBlacklistEnabled bool `mapstructure:"check-tx-error-blacklist-enabled"`
 	CheckTxErrorThreshold        int  `mapstructure:"check-tx-error-threshold"`
+
+	// MinGasPrice, if non-zero, defines the minimum gas price a transaction
+	// must pay to enter the mempool. The gas price is the priority returned by
+	// CheckTx divided by its gas wanted, or the priority alone if no gas is
+	// wanted. Transactions below it are rejected right after CheckTx, with an
+	// ErrTxBelowMinGasPrice error.
+	MinGasPrice float64 `mapstructure:"min-gas-price"`
+
+	// TxReplacement enables replacing a pending transaction by a new one with
+	// the same sender and sequence, as returned by CheckTx, and a higher
+	// priority. A sender may then have one pending transaction per sequence.
+	TxReplacement bool `mapstructure:"tx-replacement"`
+
+	// MaxRecheckTxs, if non-zero, bounds the number of transactions rechecked
+	// synchronously after a block is committed. The remaining transactions are
+	// rechecked in the background, and are still reaped for a proposal until
+	// then, but flagged as pending a recheck.
+	MaxRecheckTxs int `mapstructure:"max-recheck-txs"`
+
+	// WalPath is the directory of the mempool write-ahead log, which persists
+	// the accepted transactions so that the pending ones are checked again and
+	// added back to the mempool when the node restarts. An empty path disables
+	// the WAL.
+	WalPath string `mapstructure:"wal-dir"`
+
+	// MaxWalSizeBytes bounds the size of the mempool WAL. Transactions accepted
+	// while it is full are not persisted.
+	MaxWalSizeBytes int64 `mapstructure:"max-wal-size-bytes"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool.
@@ -816,6 +1346,10 @@ func DefaultMempoolConfig() *MempoolConfig {
 		TxNotifyThreshold:            0,
 		CheckTxErrorBlacklistEnabled: false,
 		CheckTxErrorThreshold:        0,
+		MinGasPrice:                  0,
+		TxReplacement:                false,
+		MaxRecheckTxs:                0,
+		MaxWalSizeBytes:              2 * 1024 * 1024 * 1024, // 2GB
 	}
 }
 
@@ -853,10 +1387,29 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CheckTxErrorThreshold < 0 {
 		return errors.New("check-tx-error-threshold can't be negative")
 	}
+	if cfg.MinGasPrice < 0 {
+		return errors.New("min-gas-price can't be negative")
+	}
+	if cfg.MaxRecheckTxs < 0 {
+		return errors.New("max-recheck-txs can't be negative")
+	}
+	if cfg.WalEnabled() && cfg.MaxWalSizeBytes <= 0 {
+		return errors.New("max-wal-size-bytes must be positive when the WAL is enabled")
+	}
 
 	return nil
 }
 
+// WalDir returns the full path to the mempool's write-ahead log
+func (cfg *MempoolConfig) WalDir() string {
+	return rootify(cfg.WalPath, cfg.RootDir)
+}
+
+// WalEnabled returns true if the WAL is enabled.
+func (cfg *MempoolConfig) WalEnabled() bool {
+	return cfg.WalPath != ""
+}
+
 //-----------------------------------------------------------------------------
 // StateSyncConfig
 
@@ -908,6 +1461,8 @@ type StateSyncConfig struct {
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk-request-timeout"`
 
 	// The number of concurrent chunk and block fetchers to run (default: 4).
+	// Chunks are fetched from different peers where possible, and each chunk fetcher
+	// buffers at most 4 chunks ahead of the chunk being restored.
 	Fetchers int32 `mapstructure:"fetchers"`
 
 	// Timeout before considering light block verification failed
@@ -915,6 +1470,14 @@ type StateSyncConfig struct {
 
 	// Time before which a blacklisted witness can not be added back as a provider
 	BlacklistTTL time.Duration `mapstructure:"blacklist-ttl"`
+
+	// The maximum size in bytes of the header of a light block received from
+	// a peer or an RPC server. 0 means no limit.
+	MaxLightBlockHeaderBytes int64 `mapstructure:"max-light-block-header-bytes"`
+
+	// The maximum size in bytes of a light block, including its commit and
+	// validator set, received from a peer or an RPC server. 0 means no limit.
+	MaxLightBlockBytes int64 `mapstructure:"max-light-block-bytes"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -929,14 +1492,25 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 // DefaultStateSyncConfig returns a default configuration for the state sync service
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:             168 * time.Hour,
-		DiscoveryTime:           15 * time.Second,
-		ChunkRequestTimeout:     15 * time.Second,
-		Fetchers:                4,
-		BackfillBlocks:          0,
-		BackfillDuration:        0 * time.Second,
-		VerifyLightBlockTimeout: 60 * time.Second,
-		BlacklistTTL:            5 * time.Minute,
+		TrustPeriod:              168 * time.Hour,
+		DiscoveryTime:            15 * time.Second,
+		ChunkRequestTimeout:      15 * time.Second,
+		Fetchers:                 4,
+		BackfillBlocks:           0,
+		BackfillDuration:         0 * time.Second,
+		VerifyLightBlockTimeout:  60 * time.Second,
+		BlacklistTTL:             5 * time.Minute,
+		MaxLightBlockHeaderBytes: types.DefaultMaxLightBlockHeaderBytes,
+		MaxLightBlockBytes:       types.DefaultMaxLightBlockBytes,
+	}
+}
+
+// LightBlockSizeLimits returns the size limits of the light blocks fetched
+// during state sync.
+func (cfg *StateSyncConfig) LightBlockSizeLimits() types.LightBlockSizeLimits {
+	return types.LightBlockSizeLimits{
+		MaxHeaderBytes: cfg.MaxLightBlockHeaderBytes,
+		MaxBytes:       cfg.MaxLightBlockBytes,
 	}
 }
 
@@ -1002,6 +1576,76 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		return errors.New("fetchers is required")
 	}
 
+	if err := cfg.LightBlockSizeLimits().ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid light block size limits: %w", err)
+	}
+
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// BlockSyncConfig
+
+// BlockSyncConfig defines the configuration for the Tendermint block sync service
+type BlockSyncConfig struct {
+	// The maximum number of block requests in flight to a single peer. With
+	// adaptive requests, this is the upper bound of the per-peer limit.
+	MaxRequestsPerPeer int `mapstructure:"max-requests-per-peer"`
+
+	// The maximum number of blocks requested ahead of the latest synced
+	// block, across all peers.
+	MaxPendingRequests int `mapstructure:"max-pending-requests"`
+
+	// If true, the number of requests in flight to each peer is adjusted to
+	// its observed latency: peers responding slower than average get fewer
+	// requests, and faster ones get more, up to max-requests-per-peer.
+	AdaptiveRequests bool `mapstructure:"adaptive-requests"`
+
+	// WARNING: this reduces the security of block sync, only use it when
+	// syncing from peers under your control.
+	//
+	// If positive, the commits of the blocks below this height are only fully
+	// verified every trusted-verify-interval blocks. The commits of the blocks
+	// in between are only checked structurally, without verifying their
+	// signatures, which a malicious peer can forge. Blocks from the trusted
+	// height on are fully verified. 0 fully verifies every block.
+	TrustedHeight int64 `mapstructure:"trusted-height"`
+
+	// The interval, in blocks, at which commits are fully verified below
+	// trusted-height.
+	TrustedVerifyInterval int64 `mapstructure:"trusted-verify-interval"`
+}
+
+// DefaultBlockSyncConfig returns a default configuration for the block sync service
+func DefaultBlockSyncConfig() *BlockSyncConfig {
+	return &BlockSyncConfig{
+		MaxRequestsPerPeer:    20,
+		MaxPendingRequests:    600,
+		AdaptiveRequests:      false,
+		TrustedHeight:         0,
+		TrustedVerifyInterval: 100,
+	}
+}
+
+// TestBlockSyncConfig returns a default configuration for the block sync service
+func TestBlockSyncConfig() *BlockSyncConfig {
+	return DefaultBlockSyncConfig()
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *BlockSyncConfig) ValidateBasic() error {
+	if cfg.MaxRequestsPerPeer <= 0 {
+		return errors.New("max-requests-per-peer must be positive")
+	}
+	if cfg.MaxPendingRequests <= 0 {
+		return errors.New("max-pending-requests must be positive")
+	}
+	if cfg.TrustedHeight < 0 {
+		return errors.New("trusted-height can't be negative")
+	}
+	if cfg.TrustedHeight > 0 && cfg.TrustedVerifyInterval <= 0 {
+		return errors.New("trusted-verify-interval must be positive")
+	}
 	return nil
 }
 
@@ -1015,9 +1659,26 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal-file"`
 	walFile string // overrides WalPath if set
 
+	// WalFlushPolicy determines when the messages written to the WAL are
+	// flushed and synced to disk: one of WALFlushInterval,
+	// WALFlushEveryMessage or WALFlushMessages. Whatever the policy, the WAL
+	// is flushed before signing a proposal or vote, and when a height ends.
+	WalFlushPolicy string `mapstructure:"wal-flush-policy"`
+	// WalFlushInterval is the period of the WAL flushes. It bounds the
+	// durability window of all policies.
+	WalFlushInterval time.Duration `mapstructure:"wal-flush-interval"`
+	// WalFlushMessages is the number of messages after which the WAL is
+	// flushed with the WALFlushMessages policy.
+	WalFlushMessages int `mapstructure:"wal-flush-messages"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create-empty-blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create-empty-blocks-interval"`
+	// MaxConsecutiveEmptyBlocks caps the number of consecutive empty blocks
+	// created because of CreateEmptyBlocks or CreateEmptyBlocksInterval. Once
+	// reached, the node waits for transactions before proposing. 0 means no
+	// limit.
+	MaxConsecutiveEmptyBlocks int64 `mapstructure:"max-consecutive-empty-blocks"`
 	// Send transaction hash only
 	GossipTransactionKeyOnly bool `mapstructure:"gossip-tx-key-only"`
 
@@ -1025,8 +1686,63 @@ type ConsensusConfig struct {
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer-gossip-sleep-duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
+	// UnresponsivePeerTimeout is how long a peer may leave the vote set
+	// queries sent to it unanswered before it is disconnected, without being
+	// banned. 0 disables the disconnection.
+	UnresponsivePeerTimeout time.Duration `mapstructure:"unresponsive-peer-timeout"`
+
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
 
+	// AllowAppVersionUpgrade lets the node start when the app reports a
+	// different version than the app version of the consensus params, only
+	// logging the mismatch. It is meant for intentional upgrades, done by
+	// every validator.
+	AllowAppVersionUpgrade bool `mapstructure:"allow-app-version-upgrade"`
+
+	// ErasureCodedGossip enables gossiping erasure-coded parity parts of the
+	// proposal blocks, in addition to their parts, to the peers that enable it
+	// as well. A peer can reconstruct a block from any sufficient subset of its
+	// parts and parity parts.
+	ErasureCodedGossip bool `mapstructure:"erasure-coded-gossip"`
+
+	// DeterministicTxOrder makes the proposer order the transactions of its
+	// proposals by a seed derived from the previous block hash, instead of
+	// in mempool priority order. The set of reaped transactions is unchanged.
+	DeterministicTxOrder bool `mapstructure:"deterministic-tx-order"`
+
+	// VoteTimeoutPerValidator is added to the prevote and precommit timeouts
+	// for each validator in the validator set, so that they grow with the time
+	// votes take to propagate on large networks. 0 disables the scaling.
+	VoteTimeoutPerValidator time.Duration `mapstructure:"vote-timeout-per-validator"`
+	// MaxVoteTimeoutScaling caps the time added to the prevote and precommit
+	// timeouts by VoteTimeoutPerValidator. 0 means no cap.
+	MaxVoteTimeoutScaling time.Duration `mapstructure:"max-vote-timeout-scaling"`
+
+	// CommitOnThreshold makes the node move on to the next height as soon as
+	// it commits a block with +2/3 of the precommits, instead of waiting out
+	// the commit timeout for the precommits of the remaining validators. This
+	// lowers the block latency, but the next block then carries the
+	// signatures of fewer validators, so that the stragglers are recorded as
+	// absent from the commit, e.g. missing their rewards.
+	CommitOnThreshold bool `mapstructure:"commit-on-threshold"`
+	// CommitGracePeriod is how long a node with CommitOnThreshold still waits
+	// for more precommits after committing a block, for a fuller commit. It
+	// moves on earlier once every validator precommitted. 0 doesn't wait.
+	CommitGracePeriod time.Duration `mapstructure:"commit-grace-period"`
+
+	// ClockSkewTolerance is added to the Precision synchrony parameter when
+	// checking the timestamps of proposals against the local clock, so that a
+	// validator with imperfect clock synchronization doesn't prevote nil on
+	// timely proposals. It doesn't change which blocks are valid.
+	ClockSkewTolerance time.Duration `mapstructure:"clock-skew-tolerance"`
+
+	// HaltHeight makes the node halt once it committed the block at this
+	// height, e.g. for a coordinated upgrade: consensus stops without signing
+	// anything for the next height, and the node exits with code 3.
+	// A node restarted at or past the halt height halts right away. 0 disables
+	// it.
+	HaltHeight int64 `mapstructure:"halt-height"`
+
 	// TODO: The following fields are all temporary overrides that should exist only
 	// for the duration of the v0.36 release. The below fields should be completely
 	// removed in the v0.37 release of Tendermint.
@@ -1079,10 +1795,14 @@ type ConsensusConfig struct {
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		WalFlushPolicy:              WALFlushInterval,
+		WalFlushInterval:            2 * time.Second,
+		WalFlushMessages:            100,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
+		UnresponsivePeerTimeout:     0,
 		DoubleSignCheckHeight:       int64(0),
 		// Sei Configurations
 		GossipTransactionKeyOnly: true,
@@ -1100,9 +1820,9 @@ func TestConsensusConfig() *ConsensusConfig {
 	return cfg
 }
 
-// WaitForTxs returns true if the consensus should wait for transactions before entering the propose step
+// WaitForTxs returns true if the consensus may wait for transactions before entering the propose step
 func (cfg *ConsensusConfig) WaitForTxs() bool {
-	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
+	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0 || cfg.MaxConsecutiveEmptyBlocks > 0
 }
 
 // WalFile returns the full path to the write-ahead log file
@@ -1121,6 +1841,17 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
+	switch cfg.WalFlushPolicy {
+	case WALFlushInterval, WALFlushEveryMessage, WALFlushMessages:
+	default:
+		return fmt.Errorf("unknown wal-flush-policy %q", cfg.WalFlushPolicy)
+	}
+	if cfg.WalFlushInterval <= 0 {
+		return errors.New("wal-flush-interval must be positive")
+	}
+	if cfg.WalFlushMessages <= 0 {
+		return errors.New("wal-flush-messages must be positive")
+	}
 	if cfg.UnsafeProposeTimeoutOverride < 0 {
 		return errors.New("unsafe-propose-timeout-override can't be negative")
 	}
@@ -1136,15 +1867,39 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.UnsafeCommitTimeoutOverride < 0 {
 		return errors.New("unsafe-commit-timeout-override can't be negative")
 	}
+	if cfg.VoteTimeoutPerValidator < 0 {
+		return errors.New("vote-timeout-per-validator can't be negative")
+	}
+	if cfg.MaxVoteTimeoutScaling < 0 {
+		return errors.New("max-vote-timeout-scaling can't be negative")
+	}
+	if cfg.CommitGracePeriod < 0 {
+		return errors.New("commit-grace-period can't be negative")
+	}
+	if cfg.CommitGracePeriod > 0 && !cfg.CommitOnThreshold {
+		return errors.New("commit-grace-period requires commit-on-threshold")
+	}
+	if cfg.ClockSkewTolerance < 0 {
+		return errors.New("clock-skew-tolerance can't be negative")
+	}
+	if cfg.HaltHeight < 0 {
+		return errors.New("halt-height can't be negative")
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create-empty-blocks-interval can't be negative")
 	}
+	if cfg.MaxConsecutiveEmptyBlocks < 0 {
+		return errors.New("max-consecutive-empty-blocks can't be negative")
+	}
 	if cfg.PeerGossipSleepDuration < 0 {
 		return errors.New("peer-gossip-sleep-duration can't be negative")
 	}
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer-query-maj23-sleep-duration can't be negative")
 	}
+	if cfg.UnresponsivePeerTimeout < 0 {
+		return errors.New("unresponsive-peer-timeout can't be negative")
+	}
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
@@ -1189,6 +1944,41 @@ func (cfg *ConsensusConfig) DeprecatedFieldWarning() error {
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// EvidenceConfig
+
+// EvidenceConfig defines the configuration for the evidence pool.
+type EvidenceConfig struct {
+	// How long evidence is kept by the local evidence pool, measured from the
+	// time of the evidence. Evidence that is older than the consensus evidence
+	// params allow is no longer broadcast or proposed, but remains queryable
+	// until the retention passes. A retention that does not exceed the
+	// consensus params' max age duration keeps nothing past expiry.
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// DefaultEvidenceConfig returns a default configuration for the evidence pool.
+func DefaultEvidenceConfig() *EvidenceConfig {
+	return &EvidenceConfig{
+		Retention: 0,
+	}
+}
+
+// TestEvidenceConfig returns a configuration for the evidence pool used for
+// testing.
+func TestEvidenceConfig() *EvidenceConfig {
+	return DefaultEvidenceConfig()
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *EvidenceConfig) ValidateBasic() error {
+	if cfg.Retention < 0 {
+		return errors.New("retention can't be negative")
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // TxIndexConfig
 // Remember that Event has the following structure:
@@ -1215,6 +2005,24 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The PostgreSQL schema the indexer relations are located in. If empty,
+	// the default schema of the connection (usually "public") is used.
+	PsqlSchema string `mapstructure:"psql-schema"`
+
+	// The prefix of the names of the indexer relations, e.g. "chain1_". Along
+	// with PsqlSchema, this allows several chains to share a database.
+	PsqlTablePrefix string `mapstructure:"psql-table-prefix"`
+
+	// The maximum number of index keys the kv indexer scans for a tx_search
+	// query. When it's reached, the search stops and the results found so far
+	// are returned, flagged as truncated. 0 means no limit.
+	MaxScannedKeys int `mapstructure:"max-scanned-keys"`
+
+	// The maximum number of transactions the kv indexer returns for a
+	// tx_search query, before pagination. When it's reached, the results are
+	// flagged as truncated. 0 means no limit.
+	MaxSearchResults int `mapstructure:"max-search-results"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1227,6 +2035,35 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{Indexer: []string{"kv"}}
 }
 
+// psqlIdentifierPattern matches the identifiers accepted for the psql schema
+// and table prefix. They are used to build queries, so they are restricted to
+// plain lowercase identifiers.
+var psqlIdentifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.PsqlSchema != "" {
+		if len(cfg.PsqlSchema) > 63 || !psqlIdentifierPattern.MatchString(cfg.PsqlSchema) {
+			return errors.New("psql-schema must be at most 63 lowercase letters, digits or underscores, not starting with a digit")
+		}
+	}
+	if cfg.PsqlTablePrefix != "" {
+		// The longest relation name is 23 characters, and PostgreSQL
+		// identifiers are limited to 63.
+		if len(cfg.PsqlTablePrefix) > 40 || !psqlIdentifierPattern.MatchString(cfg.PsqlTablePrefix) {
+			return errors.New("psql-table-prefix must be at most 40 lowercase letters, digits or underscores, not starting with a digit")
+		}
+	}
+	if cfg.MaxScannedKeys < 0 {
+		return errors.New("max-scanned-keys can't be negative")
+	}
+	if cfg.MaxSearchResults < 0 {
+		return errors.New("max-search-results can't be negative")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -1246,21 +2083,54 @@ type InstrumentationConfig struct {
 	// 0 - unlimited.
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
 
-	// Instrumentation namespace.
+	// Instrumentation namespace, prefixed to the name of every metric.
 	Namespace string `mapstructure:"namespace"`
+
+	// Constant labels set on every metric, in addition to the chain_id label,
+	// as "key=value" pairs, e.g. to tell apart the metrics of several nodes
+	// scraped by the same Prometheus instance.
+	ConstLabels []string `mapstructure:"const-labels"`
+
+	// Directory, relative to the home directory, heap profiles are written
+	// to when the memory usage crosses one of the thresholds below.
+	HeapProfileDir string `mapstructure:"heap-profile-dir"`
+	// Resident set size, in bytes, above which a heap profile is captured.
+	// Only supported on Linux. 0 disables it.
+	HeapProfileRSSThreshold int64 `mapstructure:"heap-profile-rss-threshold"`
+	// Heap in use, in bytes, above which a heap profile is captured. 0
+	// disables it.
+	HeapProfileHeapThreshold int64 `mapstructure:"heap-profile-heap-threshold"`
+	// Minimum time between two heap profile captures, so that capturing
+	// doesn't worsen a memory problem.
+	HeapProfileMinInterval time.Duration `mapstructure:"heap-profile-min-interval"`
+	// Maximum number of heap profiles kept, the oldest ones being removed.
+	HeapProfileRetention int `mapstructure:"heap-profile-retention"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
 // reporting.
 func DefaultInstrumentationConfig() *InstrumentationConfig {
 	return &InstrumentationConfig{
-		Prometheus:           false,
-		PrometheusListenAddr: ":26660",
-		MaxOpenConnections:   3,
-		Namespace:            "tendermint",
+		Prometheus:             false,
+		PrometheusListenAddr:   ":26660",
+		MaxOpenConnections:     3,
+		Namespace:              "tendermint",
+		HeapProfileDir:         filepath.Join(defaultDataDir, "heap-profiles"),
+		HeapProfileMinInterval: 10 * time.Minute,
+		HeapProfileRetention:   10,
 	}
 }
 
+// MetricsLabelChainID is the label set to the chain ID on every metric.
+const MetricsLabelChainID = "chain_id"
+
+var (
+	// metricNamespacePattern matches the namespaces accepted by Prometheus.
+	metricNamespacePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	// metricLabelPattern matches the label names accepted by Prometheus.
+	metricLabelPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
 // TestInstrumentationConfig returns a default configuration for metrics
 // reporting.
 func TestInstrumentationConfig() *InstrumentationConfig {
@@ -1273,9 +2143,64 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max-open-connections can't be negative")
 	}
+	if cfg.Namespace != "" && !metricNamespacePattern.MatchString(cfg.Namespace) {
+		return fmt.Errorf("invalid namespace %q: only letters, digits, underscores and colons are allowed, not starting with a digit", cfg.Namespace)
+	}
+	if _, err := cfg.ConstLabelsAndValues(); err != nil {
+		return err
+	}
+	if cfg.HeapProfileRSSThreshold < 0 {
+		return errors.New("heap-profile-rss-threshold can't be negative")
+	}
+	if cfg.HeapProfileHeapThreshold < 0 {
+		return errors.New("heap-profile-heap-threshold can't be negative")
+	}
+	if cfg.HeapProfilingEnabled() {
+		if cfg.HeapProfileDir == "" {
+			return errors.New("heap-profile-dir can't be empty")
+		}
+		if cfg.HeapProfileMinInterval <= 0 {
+			return errors.New("heap-profile-min-interval must be positive")
+		}
+		if cfg.HeapProfileRetention <= 0 {
+			return errors.New("heap-profile-retention must be positive")
+		}
+	}
 	return nil
 }
 
+// ConstLabelsAndValues parses the "key=value" pairs of ConstLabels into a list
+// of alternating label names and values. It returns an error if a pair is
+// malformed, if a label name isn't accepted by Prometheus or is reserved, or
+// if a label is set twice.
+func (cfg *InstrumentationConfig) ConstLabelsAndValues() ([]string, error) {
+	labelsAndValues := make([]string, 0, 2*len(cfg.ConstLabels))
+	seen := make(map[string]bool, len(cfg.ConstLabels))
+	for _, pair := range cfg.ConstLabels {
+		label, value, ok := strings.Cut(pair, "=")
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("invalid const label %q: must be key=value", pair)
+		case !metricLabelPattern.MatchString(label) || strings.HasPrefix(label, "__"):
+			return nil, fmt.Errorf("invalid const label name %q: only letters, digits and underscores are allowed, "+
+				"not starting with a digit or two underscores", label)
+		case label == MetricsLabelChainID:
+			return nil, fmt.Errorf("const label %q is reserved", label)
+		case seen[label]:
+			return nil, fmt.Errorf("duplicate const label %q", label)
+		}
+		seen[label] = true
+		labelsAndValues = append(labelsAndValues, label, value)
+	}
+	return labelsAndValues, nil
+}
+
+// HeapProfilingEnabled returns true if heap profiles are captured when the
+// memory usage crosses a threshold.
+func (cfg *InstrumentationConfig) HeapProfilingEnabled() bool {
+	return cfg.HeapProfileRSSThreshold > 0 || cfg.HeapProfileHeapThreshold > 0
+}
+
 type DBSyncConfig struct {
 	// When true, the node will try to import DB files that overwrite its
 	// application DB. Note that it will NOT automatically detect whether
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,6 +58,34 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = "json"
+
+	cfg.LogFields = []string{"chain_id=test-chain", "node_id="}
+	fields, err := cfg.StaticLogFields()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"chain_id": "test-chain", "node_id": ""}, fields)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.LogFields = []string{"chain_id"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFields = []string{"height=1"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFields = nil
+
+	cfg.GenesisURL = "https://example.com/genesis.json"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GenesisSHA256 = "abcd"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GenesisSHA256 = strings.Repeat("ab", 32)
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.GenesisURL = "http://example.com/genesis.json"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GenesisURL = ""
+
+	cfg.BlockMetaCacheSize = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.BlockMetaCacheSize = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -66,6 +96,9 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"ReadTimeout",
+		"WriteTimeout",
+		"IdleTimeout",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
@@ -77,6 +110,91 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.RateLimits = []string{"block_search=2:10", "tx_search=0.5:1"}
+	cfg.DefaultRateLimit = "100:200"
+	limits, defaultLimit, err := cfg.ParseRateLimits()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RateLimit{
+		"block_search": {QPS: 2, Burst: 10},
+		"tx_search":    {QPS: 0.5, Burst: 1},
+	}, limits)
+	assert.Equal(t, RateLimit{QPS: 100, Burst: 200}, defaultLimit)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, limit := range []string{"block_search", "=2:10", "block_search=2", "block_search=0:10", "block_search=2:0"} {
+		cfg.RateLimits = []string{limit}
+		assert.Error(t, cfg.ValidateBasic(), limit)
+	}
+	cfg.RateLimits = nil
+	cfg.DefaultRateLimit = "-1:10"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DefaultRateLimit = ""
+
+	cfg.UnixSocketPermissions = "0660"
+	mode, err := cfg.UnixSocketFileMode()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), mode)
+	for _, perm := range []string{"0960", "rw", "01777"} {
+		cfg.UnixSocketPermissions = perm
+		assert.Error(t, cfg.ValidateBasic(), perm)
+	}
+	cfg.UnixSocketPermissions = "0600"
+
+	cfg.Listeners = []RPCListenerConfig{{ListenAddress: " "}}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Listeners = []RPCListenerConfig{{ListenAddress: "tcp://127.0.0.1:26658", DeniedMethods: []string{""}}}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Listeners = nil
+	cfg.AllowedMethods = []string{"status", " "}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AllowedMethods = nil
+}
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := ParseRateLimit("2.5:10")
+	require.NoError(t, err)
+	assert.Equal(t, RateLimit{QPS: 2.5, Burst: 10}, limit)
+
+	for _, s := range []string{"", "2", "2:", ":10", "0:10", "-1:10", "inf:10", "2:0", "2:1.5"} {
+		_, err := ParseRateLimit(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRPCConfigAllListeners(t *testing.T) {
+	cfg := DefaultRPCConfig()
+	cfg.ListenAddress = "tcp://0.0.0.0:26657, unix:///tmp/rpc.sock"
+	cfg.CORSAllowedOrigins = []string{"*"}
+	cfg.AuthToken = "secret"
+	cfg.DeniedMethods = []string{"broadcast_tx_commit"}
+	cfg.Listeners = []RPCListenerConfig{
+		{ListenAddress: "tcp://127.0.0.1:26658", Unsafe: true},
+		{ListenAddress: "tcp://0.0.0.0:26659", CORSAllowedOrigins: []string{"https://example.com"}, CORSAllowedMethods: []string{"GET"}},
+	}
+	require.NoError(t, cfg.ValidateBasic())
+
+	listeners := cfg.AllListeners()
+	require.Len(t, listeners, 4)
+	for _, listener := range listeners[:2] {
+		assert.True(t, listener.IsCorsEnabled())
+		assert.False(t, listener.Unsafe)
+		assert.Equal(t, "secret", listener.AuthToken)
+		assert.Equal(t, []string{"broadcast_tx_commit"}, listener.DeniedMethods)
+	}
+	assert.Equal(t, "tcp://0.0.0.0:26657", listeners[0].ListenAddress)
+	assert.Equal(t, "unix:///tmp/rpc.sock", listeners[1].ListenAddress)
+
+	assert.Equal(t, RPCListenerConfig{
+		ListenAddress:      "tcp://127.0.0.1:26658",
+		Unsafe:             true,
+		CORSAllowedMethods: cfg.CORSAllowedMethods,
+		CORSAllowedHeaders: cfg.CORSAllowedHeaders,
+	}, listeners[2])
+	assert.False(t, listeners[2].IsCorsEnabled())
+	assert.Equal(t, []string{"GET"}, listeners[3].CORSAllowedMethods)
+	assert.Equal(t, cfg.CORSAllowedHeaders, listeners[3].CORSAllowedHeaders)
+	assert.Empty(t, listeners[3].AuthToken)
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -88,6 +206,7 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"MaxRecheckTxs",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -95,11 +214,57 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.WalPath = "data/mempool.wal"
+	cfg.MaxWalSizeBytes = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxWalSizeBytes = 1024
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.Enable = true
+	cfg.UseP2P = true
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0A"
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.MaxLightBlockHeaderBytes = 0
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.MaxLightBlockHeaderBytes = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.MaxLightBlockHeaderBytes = 0
+	cfg.MaxLightBlockBytes = -1
+	require.Error(t, cfg.ValidateBasic())
+}
+
+func TestBlockSyncConfigValidateBasic(t *testing.T) {
+	cfg := TestBlockSyncConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	fieldsToTest := []string{
+		"MaxRequestsPerPeer",
+		"MaxPendingRequests",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(1)
+	}
+
+	// the verify interval is only checked when a trusted height is set
+	cfg.TrustedVerifyInterval = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TrustedHeight = 1000
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TrustedVerifyInterval = 10
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TrustedHeight = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -107,21 +272,42 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		modify    func(*ConsensusConfig)
 		expectErr bool
 	}{
-		"UnsafeProposeTimeoutOverride":               {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutOverride = time.Second }, false},
-		"UnsafeProposeTimeoutOverride negative":      {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutOverride = -1 }, true},
-		"UnsafeProposeTimeoutDeltaOverride":          {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutDeltaOverride = time.Second }, false},
-		"UnsafeProposeTimeoutDeltaOverride negative": {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutDeltaOverride = -1 }, true},
-		"UnsafePrevoteTimeoutOverride":               {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutOverride = time.Second }, false},
-		"UnsafePrevoteTimeoutOverride negative":      {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutOverride = -1 }, true},
-		"UnsafePrevoteTimeoutDeltaOverride":          {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutDeltaOverride = time.Second }, false},
-		"UnsafePrevoteTimeoutDeltaOverride negative": {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutDeltaOverride = -1 }, true},
-		"UnsafeCommitTimeoutOverride":                {func(c *ConsensusConfig) { c.UnsafeCommitTimeoutOverride = time.Second }, false},
-		"UnsafeCommitTimeoutOverride negative":       {func(c *ConsensusConfig) { c.UnsafeCommitTimeoutOverride = -1 }, true},
-		"PeerGossipSleepDuration":                    {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = time.Second }, false},
-		"PeerGossipSleepDuration negative":           {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
-		"PeerQueryMaj23SleepDuration":                {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
-		"PeerQueryMaj23SleepDuration negative":       {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
-		"DoubleSignCheckHeight negative":             {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"UnsafeProposeTimeoutOverride":                {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutOverride = time.Second }, false},
+		"UnsafeProposeTimeoutOverride negative":       {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutOverride = -1 }, true},
+		"UnsafeProposeTimeoutDeltaOverride":           {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutDeltaOverride = time.Second }, false},
+		"UnsafeProposeTimeoutDeltaOverride negative":  {func(c *ConsensusConfig) { c.UnsafeProposeTimeoutDeltaOverride = -1 }, true},
+		"UnsafePrevoteTimeoutOverride":                {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutOverride = time.Second }, false},
+		"UnsafePrevoteTimeoutOverride negative":       {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutOverride = -1 }, true},
+		"UnsafePrevoteTimeoutDeltaOverride":           {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutDeltaOverride = time.Second }, false},
+		"UnsafePrevoteTimeoutDeltaOverride negative":  {func(c *ConsensusConfig) { c.UnsafeVoteTimeoutDeltaOverride = -1 }, true},
+		"UnsafeCommitTimeoutOverride":                 {func(c *ConsensusConfig) { c.UnsafeCommitTimeoutOverride = time.Second }, false},
+		"UnsafeCommitTimeoutOverride negative":        {func(c *ConsensusConfig) { c.UnsafeCommitTimeoutOverride = -1 }, true},
+		"PeerGossipSleepDuration":                     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = time.Second }, false},
+		"PeerGossipSleepDuration negative":            {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
+		"PeerQueryMaj23SleepDuration":                 {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
+		"PeerQueryMaj23SleepDuration negative":        {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
+		"UnresponsivePeerTimeout":                     {func(c *ConsensusConfig) { c.UnresponsivePeerTimeout = 0 }, false},
+		"UnresponsivePeerTimeout negative":            {func(c *ConsensusConfig) { c.UnresponsivePeerTimeout = -1 }, true},
+		"DoubleSignCheckHeight negative":              {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"MaxConsecutiveEmptyBlocks":                   {func(c *ConsensusConfig) { c.MaxConsecutiveEmptyBlocks = 10 }, false},
+		"MaxConsecutiveEmptyBlocks negative":          {func(c *ConsensusConfig) { c.MaxConsecutiveEmptyBlocks = -1 }, true},
+		"VoteTimeoutPerValidator":                     {func(c *ConsensusConfig) { c.VoteTimeoutPerValidator = time.Millisecond }, false},
+		"VoteTimeoutPerValidator negative":            {func(c *ConsensusConfig) { c.VoteTimeoutPerValidator = -1 }, true},
+		"MaxVoteTimeoutScaling":                       {func(c *ConsensusConfig) { c.MaxVoteTimeoutScaling = time.Second }, false},
+		"MaxVoteTimeoutScaling negative":              {func(c *ConsensusConfig) { c.MaxVoteTimeoutScaling = -1 }, true},
+		"CommitOnThreshold":                           {func(c *ConsensusConfig) { c.CommitOnThreshold = true }, false},
+		"CommitGracePeriod":                           {func(c *ConsensusConfig) { c.CommitOnThreshold, c.CommitGracePeriod = true, time.Millisecond }, false},
+		"CommitGracePeriod negative":                  {func(c *ConsensusConfig) { c.CommitOnThreshold, c.CommitGracePeriod = true, -1 }, true},
+		"CommitGracePeriod without CommitOnThreshold": {func(c *ConsensusConfig) { c.CommitGracePeriod = time.Millisecond }, true},
+		"ClockSkewTolerance":                          {func(c *ConsensusConfig) { c.ClockSkewTolerance = time.Second }, false},
+		"ClockSkewTolerance negative":                 {func(c *ConsensusConfig) { c.ClockSkewTolerance = -1 }, true},
+		"HaltHeight":                                  {func(c *ConsensusConfig) { c.HaltHeight = 10 }, false},
+		"HaltHeight negative":                         {func(c *ConsensusConfig) { c.HaltHeight = -1 }, true},
+		"WalFlushPolicy every-message":                {func(c *ConsensusConfig) { c.WalFlushPolicy = WALFlushEveryMessage }, false},
+		"WalFlushPolicy messages":                     {func(c *ConsensusConfig) { c.WalFlushPolicy = WALFlushMessages }, false},
+		"WalFlushPolicy unknown":                      {func(c *ConsensusConfig) { c.WalFlushPolicy = "never" }, true},
+		"WalFlushInterval zero":                       {func(c *ConsensusConfig) { c.WalFlushInterval = 0 }, true},
+		"WalFlushMessages zero":                       {func(c *ConsensusConfig) { c.WalFlushMessages = 0 }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -146,6 +332,82 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxOpenConnections = 3
+
+	cfg.Namespace = "0tendermint"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Namespace = ""
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Namespace = "sei_node"
+
+	cfg.ConstLabels = []string{"node=validator-1", "region=eu-west", "empty="}
+	assert.NoError(t, cfg.ValidateBasic())
+	labelsAndValues, err := cfg.ConstLabelsAndValues()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node", "validator-1", "region", "eu-west", "empty", ""}, labelsAndValues)
+	for _, labels := range [][]string{
+		{"node"},
+		{"node=a", "node=b"},
+		{"chain_id=test"},
+		{"0node=a"},
+		{"__node=a"},
+		{"no-de=a"},
+	} {
+		cfg.ConstLabels = labels
+		assert.Error(t, cfg.ValidateBasic(), labels)
+	}
+	cfg.ConstLabels = nil
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.HeapProfileRSSThreshold = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.HeapProfileRSSThreshold = 0
+	cfg.HeapProfileHeapThreshold = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// the heap profile settings are only checked when a threshold is set
+	cfg.HeapProfileHeapThreshold = 0
+	cfg.HeapProfileMinInterval = 0
+	cfg.HeapProfileRetention = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.False(t, cfg.HeapProfilingEnabled())
+
+	cfg.HeapProfileHeapThreshold = 1 << 30
+	assert.True(t, cfg.HeapProfilingEnabled())
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.HeapProfileMinInterval = time.Minute
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.HeapProfileRetention = 1
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.HeapProfileDir = ""
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PsqlSchema = "chain_1"
+	cfg.PsqlTablePrefix = "chain1_"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, schema := range []string{"Chain1", "1chain", "chain; DROP TABLE blocks", `chain"`, strings.Repeat("a", 64)} {
+		cfg.PsqlSchema = schema
+		assert.Error(t, cfg.ValidateBasic(), schema)
+	}
+	cfg.PsqlSchema = ""
+
+	for _, prefix := range []string{"Chain1_", "chain-1_", "x; --", strings.Repeat("a", 41)} {
+		cfg.PsqlTablePrefix = prefix
+		assert.Error(t, cfg.ValidateBasic(), prefix)
+	}
+	cfg.PsqlTablePrefix = ""
+
+	cfg.MaxScannedKeys = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxScannedKeys = 0
+	cfg.MaxSearchResults = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -157,6 +419,10 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"DrainTimeout",
+		"PeerScoreDecayInterval",
+		"PeerMinBanDuration",
+		"PeerMaxBanDuration",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -164,4 +430,46 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	for _, fieldName := range []string{
+		"MaxInboundConnections",
+		"MaxOutboundConnections",
+		"ReservedConnections",
+	} {
+		field := reflect.ValueOf(cfg).Elem().FieldByName(fieldName)
+		field.SetUint(uint64(cfg.MaxConnections))
+		assert.NoError(t, cfg.ValidateBasic())
+		field.SetUint(uint64(cfg.MaxConnections) + 1)
+		assert.Error(t, cfg.ValidateBasic())
+		field.SetUint(0)
+	}
+
+	// bans are disabled by default; enabling them requires a sane threshold
+	assert.Zero(t, cfg.PeerMaxBanDuration)
+	cfg.PeerMinBanDuration = time.Minute
+	cfg.PeerMaxBanDuration = time.Hour
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PeerBanThreshold = 9
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PeerBanThreshold = 0
+	cfg.PeerMinBanDuration = 2 * time.Hour
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigCIDRs(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.AllowedCIDRs = "10.0.0.0/8, 2001:db8::/32"
+	cfg.DeniedCIDRs = "10.1.0.0/16"
+	require.NoError(t, cfg.ValidateBasic())
+
+	allowed, err := cfg.AllowedIPNets()
+	require.NoError(t, err)
+	require.Len(t, allowed, 2)
+	assert.Equal(t, "2001:db8::/32", allowed[1].String())
+	denied, err := cfg.DeniedIPNets()
+	require.NoError(t, err)
+	require.Len(t, denied, 1)
+
+	cfg.DeniedCIDRs = "10.1.0.0"
+	assert.Error(t, cfg.ValidateBasic())
 }
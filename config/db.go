@@ -2,7 +2,9 @@ package config
 
 import (
 	"context"
+	"errors"
 
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -27,3 +29,46 @@ func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
 	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
 }
+
+// ErrReadOnlyDB is returned when writing to a database opened by
+// ReadOnlyDBProvider.
+var ErrReadOnlyDB = errors.New("database is opened read-only")
+
+// ReadOnlyDBProvider returns a read-only database using the DBBackend and
+// DBDir specified in the Config. The goleveldb backend is opened in its
+// read-only mode, which only takes a shared lock on the database, and fails if
+// the database doesn't exist. Other backends are opened normally, with writes
+// rejected.
+func ReadOnlyDBProvider(ctx *DBContext) (dbm.DB, error) {
+	dbType := dbm.BackendType(ctx.Config.DBBackend)
+	if dbType == dbm.GoLevelDBBackend {
+		return dbm.NewGoLevelDBWithOpts(ctx.ID, ctx.Config.DBDir(), &opt.Options{
+			ReadOnly:       true,
+			ErrorIfMissing: true,
+		})
+	}
+	db, err := dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	if err != nil {
+		return nil, err
+	}
+	return readOnlyDB{db}, nil
+}
+
+// readOnlyDB wraps a database, rejecting writes.
+type readOnlyDB struct {
+	dbm.DB
+}
+
+func (readOnlyDB) Set([]byte, []byte) error     { return ErrReadOnlyDB }
+func (readOnlyDB) SetSync([]byte, []byte) error { return ErrReadOnlyDB }
+func (readOnlyDB) Delete([]byte) error          { return ErrReadOnlyDB }
+func (readOnlyDB) DeleteSync([]byte) error      { return ErrReadOnlyDB }
+func (db readOnlyDB) NewBatch() dbm.Batch       { return readOnlyBatch{db.DB.NewBatch()} }
+
+// readOnlyBatch wraps a batch, rejecting writes.
+type readOnlyBatch struct {
+	dbm.Batch
+}
+
+func (readOnlyBatch) Write() error     { return ErrReadOnlyDB }
+func (readOnlyBatch) WriteSync() error { return ErrReadOnlyDB }
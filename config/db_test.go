@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestReadOnlyDBProvider(t *testing.T) {
+	cfg := TestConfig()
+	cfg.RootDir = t.TempDir()
+	cfg.DBBackend = string(dbm.GoLevelDBBackend)
+	dbCtx := &DBContext{ID: "test", Config: cfg}
+
+	_, err := ReadOnlyDBProvider(dbCtx)
+	require.Error(t, err, "a missing database should not be created")
+
+	db, err := DefaultDBProvider(dbCtx)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	// Several read-only databases can be opened at once.
+	for i := 0; i < 2; i++ {
+		db, err := ReadOnlyDBProvider(dbCtx)
+		require.NoError(t, err)
+		defer db.Close()
+
+		value, err := db.Get([]byte("key"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), value)
+		assert.Error(t, db.Set([]byte("key"), []byte("other")))
+	}
+}
+
+func TestReadOnlyDBProviderMemDB(t *testing.T) {
+	cfg := TestConfig()
+	cfg.DBBackend = string(dbm.MemDBBackend)
+
+	db, err := ReadOnlyDBProvider(&DBContext{ID: "test", Config: cfg})
+	require.NoError(t, err)
+	assert.ErrorIs(t, db.Set([]byte("key"), []byte("value")), ErrReadOnlyDB)
+	assert.ErrorIs(t, db.Delete([]byte("key")), ErrReadOnlyDB)
+
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("key"), []byte("value")))
+	assert.ErrorIs(t, batch.Write(), ErrReadOnlyDB)
+	require.NoError(t, batch.Close())
+}
@@ -127,21 +127,43 @@ db-backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db-dir = "{{ js .BaseConfig.DBPath }}"
 
+# Codec used to compress block parts in the block store: none | snappy | zstd
+# Changing the codec only affects newly saved blocks; existing blocks are
+# always loaded with the codec they were written with.
+block-compression = "{{ .BaseConfig.BlockCompression }}"
+
+# Number of block metas (headers and block IDs) the block store keeps in
+# memory, evicting the least recently used ones. 0 disables the cache.
+block-meta-cache-size = {{ .BaseConfig.BlockMetaCacheSize }}
+
 # Output level for logging, including package level options
 log-level = "{{ .BaseConfig.LogLevel }}"
 
 # Output format: 'plain' (colored text) or 'json'
 log-format = "{{ .BaseConfig.LogFormat }}"
 
+# Static fields added to every log line, as "key=value" pairs, e.g.
+# ["chain_id=my-chain", "node_id=..."]. The level, time, message, module,
+# height and round keys are reserved.
+log-fields = [{{ range $i, $e := .BaseConfig.LogFields }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
 ##### additional base config options #####
 
 # Path to the JSON file containing the initial validator set and other meta data
 genesis-file = "{{ js .BaseConfig.Genesis }}"
 
+# HTTPS URL to fetch the genesis file from when it doesn't exist locally. The
+# fetched file must match genesis-sha256, and is saved to genesis-file so that
+# it isn't fetched again on restarts.
+genesis-url = "{{ .BaseConfig.GenesisURL }}"
+
+# Hex-encoded SHA-256 hash of the genesis file fetched from genesis-url.
+genesis-sha256 = "{{ .BaseConfig.GenesisSHA256 }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
-# Mechanism to connect to the ABCI application: socket | grpc
+# Mechanism to connect to the ABCI application: socket | grpc | grpc-stream
 abci = "{{ .BaseConfig.ABCI }}"
 
 # If true, query the ABCI app on connecting to a new peer
@@ -160,6 +182,14 @@ key-file = "{{ js .PrivValidator.Key }}"
 # Path to the JSON file containing the last sign state of a validator
 state-file = "{{ js .PrivValidator.State }}"
 
+# Path to a lock file the validator acquires on startup, preventing a
+# second process from signing with the same key. The file holds a lease renewed
+# while the node runs, and can be placed on storage shared by several hosts if
+# it supports file locks. The lease is updated under a lock on a ".guard" file
+# created next to it. The lock is released on shutdown, and becomes stale shortly after a crash.
+# Leave empty to disable.
+lock-file = "{{ js .PrivValidator.Lock }}"
+
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
 # when the listenAddr is prefixed with grpc instead of tcp it will use the gRPC Client
@@ -188,6 +218,12 @@ root-ca-file = "{{ js .PrivValidator.RootCA }}"
 # TCP or UNIX socket address for the RPC server to listen on
 laddr = "{{ .RPC.ListenAddress }}"
 
+# Permissions of the socket file when listening on a UNIX socket, e.g.
+# unix:///path/to/rpc.sock, as an octal string. For example, "0660" also lets
+# the processes of the group of the node connect. A stale socket file is
+# replaced on startup, and the file is removed on shutdown.
+unix-socket-permissions = "{{ .RPC.UnixSocketPermissions }}"
+
 # A list of origins a cross-domain request can be executed from
 # Default value '[]' disables cors support
 # Use '["*"]' to allow any origin
@@ -202,6 +238,18 @@ cors-allowed-headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}
 # Activate unsafe RPC commands like /dial-seeds and /unsafe-flush-mempool
 unsafe = {{ .RPC.Unsafe }}
 
+# If not empty, requests to laddr must carry an "Authorization: Bearer <token>"
+# header with this token. Other requests get a 401 Unauthorized response.
+auth-token = "{{ .RPC.AuthToken }}"
+
+# If not empty, only these RPC methods can be called on laddr, e.g.
+# ["health", "status", "block", "tx"]. Other methods return a
+# "Method not allowed" error.
+allowed-methods = [{{ range $i, $e := .RPC.AllowedMethods }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# RPC methods which can't be called on laddr, e.g. ["broadcast_tx_commit"].
+denied-methods = [{{ range $i, $e := .RPC.DeniedMethods }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
 # Maximum number of simultaneous connections (including WebSocket).
 # If you want to accept a larger number than the default, make sure
 # you increase your OS limits.
@@ -247,9 +295,25 @@ event-log-window-size = "{{ .RPC.EventLogWindowSize }}"
 # up to 2000, choose a value > 2000.
 event-log-max-items = {{ .RPC.EventLogMaxItems }}
 
+# Maximum duration for reading an entire request, including its body.
+# 0 - unlimited.
+read-timeout = "{{ .RPC.ReadTimeout }}"
+
+# Maximum duration for writing a response, from the end of the request read.
+# It applies to all endpoints, so it should be a safety valve rather than a
+# resource control. 0 - unlimited.
+# WebSocket connections set their own read and write deadlines for each message
+# once upgraded, so the read and write timeouts only bound their upgrade request.
+write-timeout = "{{ .RPC.WriteTimeout }}"
+
+# Maximum duration to wait for the next request on a keep-alive connection.
+# 0 - the read timeout is used.
+idle-timeout = "{{ .RPC.IdleTimeout }}"
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
-# WARNING: Using a value larger than 10s will result in increasing the
-# global HTTP write timeout, which applies to all connections and endpoints.
+# WARNING: Using a value larger than the write timeout will result in
+# increasing the global HTTP write timeout, which applies to all connections
+# and endpoints.
 # See https://github.com/tendermint/tendermint/issues/3435
 timeout-broadcast-tx-commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
@@ -259,6 +323,20 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Token bucket rate limits of RPC methods, as "<method>=<qps>:<burst>" strings.
+# For example, "block_search=2:10" allows 2 calls per second on average, in
+# bursts of up to 10 calls. Calls over the limit get a 429 Too Many Requests
+# response with a Retry-After header, while calls made over a websocket
+# connection get a JSON-RPC error.
+rate-limits = [{{ range $i, $e := .RPC.RateLimits }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# Rate limit shared by the methods without their own rate limit, as a
+# "<qps>:<burst>" string. Empty - unlimited.
+default-rate-limit = "{{ .RPC.DefaultRateLimit }}"
+
+# If true, the rate limits apply to each client IP address separately.
+rate-limit-per-client = {{ .RPC.RateLimitPerClient }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -277,6 +355,32 @@ tls-key-file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
+# Additional addresses for the RPC server to listen on, each with its own
+# unsafe, cors, auth-token, allowed-methods and denied-methods settings, e.g.
+# to serve the unsafe commands on localhost only and a restricted set of
+# methods publicly. The cors-allowed-methods and cors-allowed-headers default
+# to the ones above, and the other settings above apply to all listeners,
+# each listener having its own connection limit, while the rate limits are
+# shared by all listeners.
+#
+# [[rpc.listeners]]
+# laddr = "tcp://0.0.0.0:26658"
+# unsafe = false
+# cors-allowed-origins = ["*"]
+# auth-token = ""
+# allowed-methods = []
+# denied-methods = ["broadcast_tx_commit", "broadcast_tx_sync", "broadcast_tx_async"]
+{{ range .RPC.Listeners }}
+[[rpc.listeners]]
+laddr = "{{ .ListenAddress }}"
+unsafe = {{ .Unsafe }}
+cors-allowed-origins = [{{ range $i, $e := .CORSAllowedOrigins }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+cors-allowed-methods = [{{ range $i, $e := .CORSAllowedMethods }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+cors-allowed-headers = [{{ range $i, $e := .CORSAllowedHeaders }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+auth-token = "{{ .AuthToken }}"
+allowed-methods = [{{ range $i, $e := .AllowedMethods }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+denied-methods = [{{ range $i, $e := .DeniedMethods }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+{{ end }}
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -288,6 +392,15 @@ queue-type = "{{ .P2P.QueueType }}"
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Transport used for peer connections. Options are:
+#   1) "mconn" (default) - the MConn protocol over TCP
+#   2) "quic" - the MConn protocol over QUIC (UDP), one stream per channel
+# Peers are authenticated the same way with either transport, but must be
+# addressed with the matching protocol, e.g. quic://<id>@<host>:<port>.
+# With "quic", a lost packet only delays the channel it belongs to, but
+# channel priorities are not supported.
+transport = "{{ .P2P.Transport }}"
+
 # Address to advertise to peers for them to dial
 # If empty, will use the same port as the laddr,
 # and will introspect on the listener or use UPnP
@@ -309,6 +422,15 @@ upnp = {{ .P2P.UPNP }}
 # Maximum number of connections (inbound and outbound).
 max-connections = {{ .P2P.MaxConnections }}
 
+# Maximum number of inbound and outbound connections, not counting persistent
+# and unconditional peers. 0 means no limit other than max-connections.
+max-inbound-connections = {{ .P2P.MaxInboundConnections }}
+max-outbound-connections = {{ .P2P.MaxOutboundConnections }}
+
+# Number of the max-connections slots which only persistent and unconditional
+# peers may use, so that other peers can't take all of them.
+reserved-connections = {{ .P2P.ReservedConnections }}
+
 # Rate limits the number of incoming connection attempts per IP address.
 max-incoming-connection-attempts = {{ .P2P.MaxIncomingConnectionAttempts }}
 
@@ -326,6 +448,16 @@ allow-duplicate-ip = {{ .P2P.AllowDuplicateIP }}
 handshake-timeout = "{{ .P2P.HandshakeTimeout }}"
 dial-timeout = "{{ .P2P.DialTimeout }}"
 
+# Timeout for the secret connection key exchange and authentication, which is
+# the first part of the handshake. Consider raising this along with
+# handshake-timeout for peers behind high-latency links.
+auth-timeout = "{{ .P2P.AuthTimeout }}"
+
+# Time spent on shutdown sending the messages already queued for peers, before
+# closing the connections. No new messages are sent meanwhile. 0 drops the
+# queued messages.
+drain-timeout = "{{ .P2P.DrainTimeout }}"
+
 # Time to wait before flushing messages out on the connection
 # TODO: Remove once MConnConnection is removed.
 flush-throttle-timeout = "{{ .P2P.FlushThrottleTimeout }}"
@@ -345,6 +477,33 @@ recv-rate = {{ .P2P.RecvRate }}
 # List of node IDs, to which a connection will be (re)established ignoring any existing limits
 unconditional-peer-ids = "{{ .P2P.UnconditionalPeerIDs }}"
 
+# Time it takes for a peer's score to move one point back toward the default
+# score, so that past misbehavior is gradually forgotten. 0 disables decay.
+peer-score-decay-interval = "{{ .P2P.PeerScoreDecayInterval }}"
+
+# Score at or below which a misbehaving peer is disconnected and temporarily
+# banned. Peers start with a score of 10 which drops each time they misbehave,
+# so the threshold must be below 9 for a single bad report to never ban a peer.
+# Persistent and unconditional peers are never banned.
+peer-ban-threshold = {{ .P2P.PeerBanThreshold }}
+
+# Duration of a peer's first ban. Each subsequent ban of the same peer lasts
+# twice as long, up to peer-max-ban-duration.
+peer-min-ban-duration = "{{ .P2P.PeerMinBanDuration }}"
+
+# Maximum duration of a peer ban. 0 disables bans.
+peer-max-ban-duration = "{{ .P2P.PeerMaxBanDuration }}"
+
+# Comma separated list of IP ranges in CIDR notation, e.g.
+# "10.0.0.0/8,2001:db8::/32". If set, only peers with an IP address in one of
+# these ranges are dialed or accepted.
+allowed-cidrs = "{{ .P2P.AllowedCIDRs }}"
+
+# Comma separated list of IP ranges in CIDR notation from which peers are
+# neither dialed nor accepted. Unconditional peers bypass it; inbound
+# connections are checked against it after the handshake.
+denied-cidrs = "{{ .P2P.DeniedCIDRs }}"
+
 
 #######################################################
 ###          Mempool Configuration Option          ###
@@ -405,6 +564,35 @@ check-tx-error-blacklist-enabled = {{ .Mempool.CheckTxErrorBlacklistEnabled }}
 
 check-tx-error-threshold = {{ .Mempool.CheckTxErrorThreshold }}
 
+# min-gas-price, if non-zero, defines the minimum gas price a transaction must
+# pay to enter the mempool. The gas price is the priority returned by CheckTx
+# divided by the gas wanted, or the priority alone if no gas is wanted.
+# Transactions below it are rejected with an error right after CheckTx, and are
+# not requested again from peers while they remain in the cache.
+min-gas-price = {{ .Mempool.MinGasPrice }}
+
+# tx-replacement enables replacing a pending transaction by a new one with the
+# same sender and sequence, as returned by CheckTx, and a higher priority. A
+# sender may then have one pending transaction per sequence.
+tx-replacement = {{ .Mempool.TxReplacement }}
+
+# max-recheck-txs, if non-zero, bounds the number of transactions rechecked
+# synchronously after a block is committed, so that a large mempool doesn't
+# stall block production. The remaining transactions are rechecked in the
+# background. Until then, they can still be reaped for a proposal, and are
+# flagged as pending a recheck by the mempool_entries RPC.
+max-recheck-txs = {{ .Mempool.MaxRecheckTxs }}
+
+# Directory of the mempool write-ahead log, e.g. "data/mempool.wal". The WAL
+# persists the accepted transactions, so that the pending ones are checked
+# again and added back to the mempool when the node restarts. Leave empty to
+# disable the WAL.
+wal-dir = "{{ js .Mempool.WalPath }}"
+
+# Maximum size of the mempool WAL, in bytes. Transactions accepted while it is
+# full are not persisted.
+max-wal-size-bytes = {{ .Mempool.MaxWalSizeBytes }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -456,12 +644,54 @@ temp-dir = "{{ .StateSync.TempDir }}"
 chunk-request-timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 
 # The number of concurrent chunk and block fetchers to run (default: 4).
+# Chunks are fetched from different peers where possible, and each chunk fetcher
+# buffers at most 4 chunks ahead of the chunk being restored.
 fetchers = "{{ .StateSync.Fetchers }}"
 
 verify-light-block-timeout = "{{ .StateSync.VerifyLightBlockTimeout }}"
 
 blacklist-ttl = "{{ .StateSync.BlacklistTTL }}"
 
+# The maximum size in bytes of the header of a light block received from a
+# peer or an RPC server. Larger light blocks are rejected. 0 means no limit.
+max-light-block-header-bytes = {{ .StateSync.MaxLightBlockHeaderBytes }}
+
+# The maximum size in bytes of a light block, including its commit and
+# validator set, received from a peer or an RPC server. 0 means no limit.
+max-light-block-bytes = {{ .StateSync.MaxLightBlockBytes }}
+
+#######################################################
+###         Block Sync Configuration Options        ###
+#######################################################
+[blocksync]
+
+# The maximum number of block requests in flight to a single peer. With
+# adaptive requests, this is the upper bound of the per-peer limit.
+max-requests-per-peer = {{ .BlockSync.MaxRequestsPerPeer }}
+
+# The maximum number of blocks requested ahead of the latest synced block,
+# across all peers.
+max-pending-requests = {{ .BlockSync.MaxPendingRequests }}
+
+# If true, the number of requests in flight to each peer is adjusted to its
+# observed latency: peers responding slower than average get fewer requests,
+# and faster ones get more, up to max-requests-per-peer.
+adaptive-requests = {{ .BlockSync.AdaptiveRequests }}
+
+# WARNING: this reduces the security of block sync, only use it when syncing
+# from peers under your control.
+#
+# If positive, the commits of the blocks below this height are only fully
+# verified every trusted-verify-interval blocks. The commits of the blocks in
+# between are only checked structurally, without verifying their signatures,
+# which a malicious peer can forge. Blocks from the trusted height on are fully
+# verified. 0 fully verifies every block.
+trusted-height = {{ .BlockSync.TrustedHeight }}
+
+# The interval, in blocks, at which commits are fully verified below
+# trusted-height.
+trusted-verify-interval = {{ .BlockSync.TrustedVerifyInterval }}
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -469,16 +699,47 @@ blacklist-ttl = "{{ .StateSync.BlacklistTTL }}"
 
 wal-file = "{{ js .Consensus.WalPath }}"
 
+# When the messages written to the WAL are flushed and synced to disk:
+#   - "interval": every wal-flush-interval
+#   - "every-message": after every message
+#   - "messages": every wal-flush-messages messages, and every
+#     wal-flush-interval
+# Whatever the policy, the WAL is flushed before the node signs a proposal or
+# a vote, so that it never signs based on messages that could be lost in a
+# crash: after a restart, it replays the WAL and recomputes the same votes,
+# which the private validator accepts, rather than conflicting ones, which it
+# refuses to sign. The messages received since the last flush are lost in a
+# crash and fetched from peers again. Batching flushes reduces disk I/O at the
+# cost of this durability window, without weakening double-sign protection as
+# long as the storage honours fsync. On storage that may lose synced data, no
+# policy prevents the node from double signing after a crash.
+wal-flush-policy = "{{ .Consensus.WalFlushPolicy }}"
+wal-flush-interval = "{{ .Consensus.WalFlushInterval }}"
+wal-flush-messages = {{ .Consensus.WalFlushMessages }}
+
 # How many blocks to look back to check existence of the node's consensus votes before joining consensus
 # When non-zero, the node will panic upon restart
 # if the same consensus key was used to sign {double-sign-check-height} last blocks.
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# If true, the node starts even if the app reports a different version than
+# the app version of the consensus params, and only logs the mismatch. Only
+# set this for an intentional upgrade of the app version, done by every
+# validator.
+allow-app-version-upgrade = {{ .Consensus.AllowAppVersionUpgrade }}
+
 # EmptyBlocks mode and possible interval between empty blocks
 create-empty-blocks = {{ .Consensus.CreateEmptyBlocks }}
 create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 
+# Maximum number of consecutive empty blocks, 0 for no limit. Once this many
+# empty blocks in a row were committed, the node stops creating empty blocks,
+# as it would with create-empty-blocks or create-empty-blocks-interval, and
+# waits for transactions. Blocks required to commit a new app hash are always
+# created.
+max-consecutive-empty-blocks = {{ .Consensus.MaxConsecutiveEmptyBlocks }}
+
 # Only gossip hashes, not the actual data
 gossip-tx-key-only = "{{ .Consensus.GossipTransactionKeyOnly }}"
 
@@ -486,6 +747,53 @@ gossip-tx-key-only = "{{ .Consensus.GossipTransactionKeyOnly }}"
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# How long a peer may leave the vote set queries sent to it unanswered before it
+# is disconnected, without being banned. 0 disables the disconnection.
+unresponsive-peer-timeout = "{{ .Consensus.UnresponsivePeerTimeout }}"
+
+# If true, erasure-coded parity parts of the proposal blocks are gossiped, in
+# addition to their parts, to the peers that enable it as well. Peers can then
+# reconstruct a block from any sufficient subset of its parts and parity parts,
+# instead of waiting for every one of its parts.
+erasure-coded-gossip = {{ .Consensus.ErasureCodedGossip }}
+
+# If true, the transactions of a proposal are ordered by a seed derived from
+# the previous block hash instead of by mempool priority. Reaping still picks
+# the same transactions, so the block size and gas limits are respected.
+# NOTE: the application must accept transactions in any order.
+deterministic-tx-order = {{ .Consensus.DeterministicTxOrder }}
+
+# Time added to the prevote and precommit timeouts for each validator in the
+# validator set, so that they grow with the time votes take to propagate on
+# large networks. 0 keeps the timeouts fixed.
+vote-timeout-per-validator = "{{ .Consensus.VoteTimeoutPerValidator }}"
+
+# Maximum time added to the prevote and precommit timeouts by
+# vote-timeout-per-validator. 0 means no cap.
+max-vote-timeout-scaling = "{{ .Consensus.MaxVoteTimeoutScaling }}"
+
+# Move on to the next height as soon as a block is committed with +2/3 of the
+# precommits, instead of waiting out the commit timeout for the precommits of
+# the remaining validators. This lowers the block latency, but the validators
+# missing from the commit are recorded as absent from it.
+commit-on-threshold = {{ .Consensus.CommitOnThreshold }}
+
+# How long a node with commit-on-threshold still waits for more precommits
+# after committing a block, for a fuller commit. It moves on earlier once every
+# validator precommitted. 0 doesn't wait.
+commit-grace-period = "{{ .Consensus.CommitGracePeriod }}"
+
+# Tolerance added to the precision synchrony consensus parameter when checking
+# the timestamps of proposals against the local clock, for validators with
+# imperfect clock synchronization. It doesn't change which blocks are valid.
+clock-skew-tolerance = "{{ .Consensus.ClockSkewTolerance }}"
+
+# Halt once the block at this height is committed, e.g. for a coordinated
+# upgrade. Consensus stops without signing anything for the next height, and
+# the node exits with code 3. A node restarted at or past the halt height halts
+# right away. 0 disables it.
+halt-height = {{ .Consensus.HaltHeight }}
+
 ### Unsafe Timeout Overrides ###
 
 # These fields provide temporary overrides for the Timeout consensus parameters.
@@ -530,6 +838,19 @@ unsafe-commit-timeout-override = "{{ .Consensus.UnsafeCommitTimeoutOverride }}"
 # as soon as the node has gathered votes from all of the validators on the network.
 # unsafe-bypass-commit-timeout-override = {{ .Consensus.UnsafeBypassCommitTimeoutOverride }}
 
+#######################################################
+###         Evidence Configuration Options          ###
+#######################################################
+[evidence]
+
+# How long evidence is kept by the local evidence pool, measured from the time
+# of the evidence. Evidence older than the consensus evidence params allow is
+# no longer broadcast or proposed, but remains queryable through the
+# expired_evidence RPC method until the retention passes.
+# A retention that does not exceed the max age duration of the consensus
+# evidence params keeps nothing past expiry.
+retention = "{{ .Evidence.Retention }}"
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -552,6 +873,22 @@ indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The PostgreSQL schema and the prefix of the table names used by the psql
+# indexer. Setting them allows several chains to share a database. They may only
+# contain lowercase letters, digits and underscores. If the tables don't exist,
+# they are created on startup; existing tables are left untouched.
+psql-schema = "{{ .TxIndex.PsqlSchema }}"
+psql-table-prefix = "{{ .TxIndex.PsqlTablePrefix }}"
+
+# Limits on the work done by the kv indexer for a tx_search query, which
+# protect the node against queries matching a large part of the index. When a
+# limit is reached, the results found so far are returned with "truncated" set
+# and the indexer_tx_search_scan_limit_hits metric counts the queries that
+# reached max-scanned-keys. Past max-search-results, the matches of the lowest
+# heights are kept. 0 means no limit.
+max-scanned-keys = {{ .TxIndex.MaxScannedKeys }}
+max-search-results = {{ .TxIndex.MaxSearchResults }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -571,9 +908,32 @@ prometheus-listen-addr = "{{ .Instrumentation.PrometheusListenAddr }}"
 # 0 - unlimited.
 max-open-connections = {{ .Instrumentation.MaxOpenConnections }}
 
-# Instrumentation namespace
+# Instrumentation namespace, prefixed to the name of every metric.
 namespace = "{{ .Instrumentation.Namespace }}"
 
+# Constant labels set on every metric, in addition to the chain_id label, as
+# "key=value" pairs, e.g. to tell apart the metrics of several nodes scraped by
+# the same Prometheus instance: ["node=validator-1", "region=eu-west"].
+const-labels = [{{ range .Instrumentation.ConstLabels }}{{ printf "%q, " . }}{{end}}]
+
+# Directory, relative to the home directory, heap profiles are written to when
+# the memory usage crosses one of the thresholds below.
+heap-profile-dir = "{{ js .Instrumentation.HeapProfileDir }}"
+
+# Resident set size, in bytes, above which a heap profile is captured. Only
+# supported on Linux. 0 disables it.
+heap-profile-rss-threshold = {{ .Instrumentation.HeapProfileRSSThreshold }}
+
+# Heap in use, in bytes, above which a heap profile is captured. 0 disables it.
+heap-profile-heap-threshold = {{ .Instrumentation.HeapProfileHeapThreshold }}
+
+# Minimum time between two heap profile captures, so that capturing doesn't
+# worsen a memory problem.
+heap-profile-min-interval = "{{ .Instrumentation.HeapProfileMinInterval }}"
+
+# Maximum number of heap profiles kept, the oldest ones being removed.
+heap-profile-retention = {{ .Instrumentation.HeapProfileRetention }}
+
 #######################################################
 ###       SelfRemediation Configuration Options     ###
 #######################################################
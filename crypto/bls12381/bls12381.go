@@ -0,0 +1,308 @@
+// Package bls12381 implements BLS signatures over the BLS12-381 curve, with
+// public keys in G1 and signatures in G2.
+//
+// It uses the message augmentation scheme of the IETF BLS signature draft: the
+// signer's public key is prepended to every message before it is hashed, so
+// signatures of any messages, including identical ones, can be aggregated
+// without proofs of possession of the keys.
+package bls12381
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bls "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+const (
+	PrivKeyName = "tendermint/PrivKeyBls12381"
+	PubKeyName  = "tendermint/PubKeyBls12381"
+	// PrivKeySize is the size, in bytes, of private keys: a big-endian scalar.
+	PrivKeySize = 32
+	// PubKeySize is the size, in bytes, of public keys: a compressed G1 point.
+	PubKeySize = bls.SizeOfG1AffineCompressed
+	// SignatureSize is the size, in bytes, of signatures: a compressed G2 point.
+	SignatureSize = bls.SizeOfG2AffineCompressed
+
+	KeyType = "bls12381"
+)
+
+// dst is the domain separation tag of the message augmentation ciphersuite.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+
+func init() {
+	tmjson.RegisterType(PubKey{}, PubKeyName)
+	tmjson.RegisterType(PrivKey{}, PrivKeyName)
+
+	jsontypes.MustRegister(PubKey{})
+	jsontypes.MustRegister(PrivKey{})
+}
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKey{}
+
+// PrivKey implements crypto.PrivKey.
+type PrivKey []byte
+
+// TypeTag satisfies the jsontypes.Tagged interface.
+func (PrivKey) TypeTag() string { return PrivKeyName }
+
+// Bytes returns the privkey byte format.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign produces a signature on the provided message.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+	h, err := hashToG2(publicKey(sk), msg)
+	if err != nil {
+		return nil, err
+	}
+	var sig bls.G2Affine
+	sig.ScalarMultiplication(h, sk)
+	sigBytes := sig.Bytes()
+	return sigBytes[:], nil
+}
+
+// PubKey gets the corresponding public key from the private key.
+//
+// Panics if the private key is invalid.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+	return publicKey(sk)
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBLS, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBLS[:]) == 1
+	}
+	return false
+}
+
+func (privKey PrivKey) Type() string {
+	return KeyType
+}
+
+// scalar returns the private key as a scalar, which must be in [1, r).
+func (privKey PrivKey) scalar() (*big.Int, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, fmt.Errorf("bls12381: private key must be %d bytes, got %d", PrivKeySize, len(privKey))
+	}
+	sk := new(big.Int).SetBytes(privKey)
+	if sk.Sign() == 0 || sk.Cmp(fr.Modulus()) >= 0 {
+		return nil, errors.New("bls12381: private key is out of range")
+	}
+	return sk, nil
+}
+
+// GenPrivKey generates a new BLS12-381 private key from OS randomness.
+func GenPrivKey() PrivKey {
+	return genPrivKey(rand.Reader)
+}
+
+// genPrivKey generates a new BLS12-381 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKey {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		panic(err)
+	}
+	return keyGen(ikm)
+}
+
+// GenPrivKeyFromSecret hashes the secret with SHA2, and derives the private
+// key from that 32 byte output.
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeyFromSecret(secret []byte) PrivKey {
+	seed := sha256.Sum256(secret)
+	return keyGen(seed[:])
+}
+
+// keyGen derives a private key from the input keying material, which must be
+// at least 32 bytes, as specified by the KeyGen procedure of the IETF BLS
+// signature draft.
+func keyGen(ikm []byte) PrivKey {
+	const l = 48 // ceil((3 * ceil(log2(r))) / 16)
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		prk := hkdf.Extract(sha256.New, append(append([]byte{}, ikm...), 0), salt)
+		okm := make([]byte, l)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte{0, l}), okm); err != nil {
+			panic(err)
+		}
+		sk.SetBytes(okm).Mod(sk, fr.Modulus())
+	}
+	return PrivKey(sk.FillBytes(make([]byte, PrivKeySize)))
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey for BLS12-381 signatures.
+type PubKey []byte
+
+// TypeTag satisfies the jsontypes.Tagged interface.
+func (PubKey) TypeTag() string { return PubKeyName }
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.AddressHash(pubKey)
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+// VerifySignature verifies that sig is the signature of msg by pubKey.
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return VerifyAggregateSignature([]PubKey{pubKey}, [][]byte{msg}, sig)
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12381{%X}", []byte(pubKey))
+}
+
+func (pubKey PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBLS, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBLS[:])
+	}
+	return false
+}
+
+// point decodes the public key, which must be a point of G1 other than the
+// identity.
+func (pubKey PubKey) point() (*bls.G1Affine, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("bls12381: public key must be %d bytes, got %d", PubKeySize, len(pubKey))
+	}
+	var p bls.G1Affine
+	if _, err := p.SetBytes(pubKey); err != nil {
+		return nil, fmt.Errorf("bls12381: invalid public key: %w", err)
+	}
+	if p.IsInfinity() {
+		return nil, errors.New("bls12381: public key is the identity")
+	}
+	return &p, nil
+}
+
+func publicKey(sk *big.Int) PubKey {
+	var p bls.G1Affine
+	p.ScalarMultiplicationBase(sk)
+	pkBytes := p.Bytes()
+	return PubKey(pkBytes[:])
+}
+
+//-------------------------------------
+
+// AggregateSignatures combines signatures into a single signature, which
+// verifies with VerifyAggregateSignature against the public keys and messages
+// of all of them.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls12381: no signatures to aggregate")
+	}
+	var agg bls.G2Jac
+	for i, sig := range sigs {
+		p, err := signaturePoint(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature #%d: %w", i, err)
+		}
+		agg.AddMixed(p)
+	}
+	var aggAffine bls.G2Affine
+	aggAffine.FromJacobian(&agg)
+	aggBytes := aggAffine.Bytes()
+	return aggBytes[:], nil
+}
+
+// VerifyAggregateSignature verifies that sig is the aggregate of the
+// signatures of msgs[i] by pubKeys[i], for all i. The same public key or
+// message may appear more than once.
+func VerifyAggregateSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+	sigPoint, err := signaturePoint(sig)
+	if err != nil {
+		return false
+	}
+
+	// e(g1, sig) == ∏ e(pk_i, H(pk_i || msg_i)) is checked as
+	// e(-g1, sig) * ∏ e(pk_i, H(pk_i || msg_i)) == 1.
+	_, _, g1, _ := bls.Generators()
+	p := make([]bls.G1Affine, 1, len(pubKeys)+1)
+	q := make([]bls.G2Affine, 1, len(pubKeys)+1)
+	p[0].Neg(&g1)
+	q[0] = *sigPoint
+	for i, pubKey := range pubKeys {
+		pkPoint, err := pubKey.point()
+		if err != nil {
+			return false
+		}
+		h, err := hashToG2(pubKey, msgs[i])
+		if err != nil {
+			return false
+		}
+		p = append(p, *pkPoint)
+		q = append(q, *h)
+	}
+	ok, err := bls.PairingCheck(p, q)
+	return err == nil && ok
+}
+
+// signaturePoint decodes a signature, which must be a point of G2.
+func signaturePoint(sig []byte) (*bls.G2Affine, error) {
+	if len(sig) != SignatureSize {
+		return nil, fmt.Errorf("bls12381: signature must be %d bytes, got %d", SignatureSize, len(sig))
+	}
+	var p bls.G2Affine
+	if _, err := p.SetBytes(sig); err != nil {
+		return nil, fmt.Errorf("bls12381: invalid signature: %w", err)
+	}
+	return &p, nil
+}
+
+// hashToG2 hashes the message augmented with the public key of its signer.
+func hashToG2(pubKey PubKey, msg []byte) (*bls.G2Affine, error) {
+	augmented := make([]byte, 0, len(pubKey)+len(msg))
+	augmented = append(append(augmented, pubKey...), msg...)
+	h, err := bls.HashToG2(augmented, dst)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
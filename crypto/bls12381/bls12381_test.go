@@ -0,0 +1,85 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
+)
+
+func TestSignAndValidateBLS12381(t *testing.T) {
+	privKey := bls12381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12381.SignatureSize)
+
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(msg[1:], sig))
+	assert.False(t, bls12381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	assert.Equal(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("secret")))
+	assert.NotEqual(t, privKey, bls12381.GenPrivKeyFromSecret([]byte("other secret")))
+}
+
+func TestInvalidKeys(t *testing.T) {
+	msg := []byte("message")
+	_, err := bls12381.PrivKey(make([]byte, bls12381.PrivKeySize)).Sign(msg)
+	assert.Error(t, err)
+	_, err = bls12381.PrivKey([]byte{1}).Sign(msg)
+	assert.Error(t, err)
+
+	sig, err := bls12381.GenPrivKey().Sign(msg)
+	require.NoError(t, err)
+	// the compressed encoding of the identity of G1
+	identity := make(bls12381.PubKey, bls12381.PubKeySize)
+	identity[0] = 0xc0
+	assert.False(t, identity.VerifySignature(msg, sig))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	var (
+		pubKeys []bls12381.PubKey
+		msgs    [][]byte
+		sigs    [][]byte
+	)
+	for i := 0; i < 4; i++ {
+		privKey := bls12381.GenPrivKey()
+		// the first two validators sign the same message
+		msg := []byte{byte(i / 2 * 2)}
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, privKey.PubKey().(bls12381.PubKey))
+		msgs = append(msgs, msg)
+		sigs = append(sigs, sig)
+	}
+
+	agg, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	assert.True(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, agg))
+
+	// a missing signer, a wrong message or a wrong signer fail verification
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys[1:], msgs[1:], agg))
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, append([][]byte{{9}}, msgs[1:]...), agg))
+	assert.False(t, bls12381.VerifyAggregateSignature(
+		append([]bls12381.PubKey{pubKeys[1]}, pubKeys[1:]...), msgs, agg))
+	assert.False(t, bls12381.VerifyAggregateSignature(nil, nil, agg))
+
+	_, err = bls12381.AggregateSignatures(nil)
+	assert.Error(t, err)
+	_, err = bls12381.AggregateSignatures([][]byte{sigs[0][1:]})
+	assert.Error(t, err)
+}
@@ -1,28 +1,117 @@
 package merkle
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"hash"
+	"sync"
+	"sync/atomic"
 
-	"github.com/tendermint/tendermint/crypto"
+	"golang.org/x/crypto/blake2b"
 )
 
+// Hash functions of the Merkle trees. Both produce 32-byte digests.
+const (
+	HashFunctionSHA256  = "sha256"
+	HashFunctionBlake2b = "blake2b-256"
+)
+
+var hashFunctions = map[string]func() hash.Hash{
+	HashFunctionSHA256: sha256.New,
+	HashFunctionBlake2b: func() hash.Hash {
+		h, _ := blake2b.New256(nil) // only errors on keys over 64 bytes
+		return h
+	},
+}
+
+type hashFunction struct {
+	name string
+	new  func() hash.Hash
+}
+
+var (
+	currentHashFunction atomic.Pointer[hashFunction]
+
+	hashFunctionMtx sync.Mutex
+	hashFunctionSet bool
+)
+
+func init() {
+	currentHashFunction.Store(&hashFunction{HashFunctionSHA256, sha256.New})
+}
+
+// ValidateHashFunction returns an error if name isn't the name of a hash
+// function of the Merkle trees. An empty name stands for the default,
+// HashFunctionSHA256.
+func ValidateHashFunction(name string) error {
+	if _, ok := hashFunctions[name]; !ok && name != "" {
+		return fmt.Errorf("unknown merkle hash function %q", name)
+	}
+	return nil
+}
+
+// SetHashFunction selects the hash function of all the Merkle trees computed
+// and verified from then on, including the ones of block headers, block data
+// and block parts. It is consensus critical: all the nodes of a chain must use
+// the same one, as set in the genesis file, and it must be set before any tree
+// is computed. An empty name selects the default, HashFunctionSHA256.
+//
+// The hash function can only be set once per process: setting it again to
+// another hash function returns an error.
+func SetHashFunction(name string) error {
+	if err := ValidateHashFunction(name); err != nil {
+		return err
+	}
+	if name == "" {
+		name = HashFunctionSHA256
+	}
+
+	hashFunctionMtx.Lock()
+	defer hashFunctionMtx.Unlock()
+
+	if hashFunctionSet {
+		if current := GetHashFunction(); current != name {
+			return fmt.Errorf("merkle hash function is already set to %s, can't set it to %s", current, name)
+		}
+		return nil
+	}
+	currentHashFunction.Store(&hashFunction{name, hashFunctions[name]})
+	hashFunctionSet = true
+	return nil
+}
+
+// GetHashFunction returns the name of the hash function of the Merkle trees.
+func GetHashFunction() string {
+	return currentHashFunction.Load().name
+}
+
+func newHash() hash.Hash {
+	return currentHashFunction.Load().new()
+}
+
+func checksum(data []byte) []byte {
+	h := newHash()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
 // TODO: make these have a large predefined capacity
 var (
 	leafPrefix  = []byte{0}
 	innerPrefix = []byte{1}
 )
 
-// returns tmhash(<empty>)
+// returns hash(<empty>)
 func emptyHash() []byte {
-	return crypto.Checksum([]byte{})
+	return checksum([]byte{})
 }
 
-// returns tmhash(0x00 || leaf)
+// returns hash(0x00 || leaf)
 func leafHash(leaf []byte) []byte {
-	return crypto.Checksum(append(leafPrefix, leaf...))
+	return checksum(append(leafPrefix, leaf...))
 }
 
-// returns tmhash(0x00 || leaf)
+// returns hash(0x00 || leaf)
 func leafHashOpt(s hash.Hash, leaf []byte) []byte {
 	s.Reset()
 	s.Write(leafPrefix)
@@ -30,13 +119,13 @@ func leafHashOpt(s hash.Hash, leaf []byte) []byte {
 	return s.Sum(nil)
 }
 
-// returns tmhash(0x01 || left || right)
+// returns hash(0x01 || left || right)
 func innerHash(left []byte, right []byte) []byte {
 	data := make([]byte, len(innerPrefix)+len(left)+len(right))
 	n := copy(data, innerPrefix)
 	n += copy(data[n:], left)
 	copy(data[n:], right)
-	return crypto.Checksum(data)[:]
+	return checksum(data)
 }
 
 func innerHashOpt(s hash.Hash, left []byte, right []byte) []byte {
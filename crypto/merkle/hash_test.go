@@ -0,0 +1,108 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The vectors were computed independently, e.g. for blake2b-256 with Python's
+// hashlib.blake2b(data, digest_size=32).
+var hashFunctionVectors = map[string]struct {
+	emptyTree, emptyLeaf, leaf, node string
+	trees                            []string // of treeVectorItems[:2], [:3] and [:5]
+}{
+	HashFunctionSHA256: {
+		emptyTree: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		emptyLeaf: "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		leaf:      "395aa064aa4c29f7010acfe3f25db9485bbd4b91897b6ad7ad547639252b4d56",
+		node:      "aa217fe888e47007fa15edab33c2b492a722cb106c64667fc2b044444de66bbb",
+		trees: []string{
+			"b137985ff484fb600db93107c77b0365c80d78f5b429ded0fd97361d077999eb",
+			"36642e73c2540ab121e3a6bf9545b0a24982cd830eb13d3cd19de3ce6c021ec1",
+			"fe14a5426fbd70c0fa73f52342afed0da0bd23c4838662ccf6b88a3070ead97b",
+		},
+	},
+	HashFunctionBlake2b: {
+		emptyTree: "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8",
+		emptyLeaf: "03170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314",
+		leaf:      "76ad9a1dbf9de24cf6eb6caa7367663fd059b30b158516221ac5a9dae37d3a93",
+		node:      "1f3a1bd7b4b02b7f27f867cd82a5a631cbd354278b3f09d41bb8be73dcdf0af8",
+		trees: []string{
+			"ee616625a590167bc4b3dc703ab4f3f2ddecbee6b9d05fee9281f02046e6082e",
+			"17321db51c1ef3ec1f77e271aa300b4e5c6091708bcba37e46025774a26142ee",
+			"57d36622e3f900dadd327fb108b62e282cb8f65225ff24749df78d09176c1d0d",
+		},
+	},
+}
+
+var treeVectorItems = [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e")}
+
+// resetHashFunction unsets the hash function, which can then be set again.
+func resetHashFunction() {
+	hashFunctionMtx.Lock()
+	defer hashFunctionMtx.Unlock()
+
+	currentHashFunction.Store(&hashFunction{HashFunctionSHA256, sha256.New})
+	hashFunctionSet = false
+}
+
+func TestHashFunctions(t *testing.T) {
+	t.Cleanup(func() { resetHashFunction() })
+
+	for name, vectors := range hashFunctionVectors {
+		name, vectors := name, vectors
+		t.Run(name, func(t *testing.T) {
+			resetHashFunction()
+			require.NoError(t, SetHashFunction(name))
+			assert.Equal(t, name, GetHashFunction())
+
+			hexHash := func(h []byte) string { return hex.EncodeToString(h) }
+			assert.Equal(t, vectors.emptyTree, hexHash(HashFromByteSlices(nil)))
+			assert.Equal(t, vectors.emptyLeaf, hexHash(HashFromByteSlices([][]byte{{}})))
+			assert.Equal(t, vectors.leaf, hexHash(HashFromByteSlices([][]byte{[]byte("L123456")})))
+			assert.Equal(t, vectors.node, hexHash(innerHash([]byte("N123"), []byte("N456"))))
+
+			for i, want := range vectors.trees {
+				items := treeVectorItems[:[]int{2, 3, 5}[i]]
+				assert.Equal(t, want, hexHash(HashFromByteSlices(items)))
+				assert.Equal(t, want, hexHash(HashFromByteSlicesIterative(items)))
+
+				root, proofs := ProofsFromByteSlices(items)
+				assert.Equal(t, want, hexHash(root))
+				for j, proof := range proofs {
+					require.NoError(t, proof.ValidateBasic())
+					assert.NoError(t, proof.Verify(root, items[j]))
+				}
+			}
+		})
+	}
+
+	// Proofs don't verify under another hash function.
+	resetHashFunction()
+	require.NoError(t, SetHashFunction(HashFunctionBlake2b))
+	root, proofs := ProofsFromByteSlices(treeVectorItems)
+	resetHashFunction()
+	require.NoError(t, SetHashFunction(HashFunctionSHA256))
+	assert.Error(t, proofs[0].Verify(root, treeVectorItems[0]))
+}
+
+func TestSetHashFunctionOnce(t *testing.T) {
+	resetHashFunction()
+	t.Cleanup(func() { resetHashFunction() })
+
+	assert.Error(t, SetHashFunction("md5"))
+	require.NoError(t, SetHashFunction(""))
+	assert.Equal(t, HashFunctionSHA256, GetHashFunction())
+
+	// setting the same hash function again is a no-op
+	require.NoError(t, SetHashFunction(HashFunctionSHA256))
+	require.NoError(t, SetHashFunction(""))
+
+	// it can't be changed once set
+	assert.Error(t, SetHashFunction(HashFunctionBlake2b))
+	assert.Equal(t, HashFunctionSHA256, GetHashFunction())
+}
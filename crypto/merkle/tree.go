@@ -1,7 +1,6 @@
 package merkle
 
 import (
-	"crypto/sha256"
 	"hash"
 	"math/bits"
 )
@@ -9,7 +8,7 @@ import (
 // HashFromByteSlices computes a Merkle tree where the leaves are the byte slice,
 // in the provided order. It follows RFC-6962.
 func HashFromByteSlices(items [][]byte) []byte {
-	return hashFromByteSlices(sha256.New(), items)
+	return hashFromByteSlices(newHash(), items)
 }
 
 func hashFromByteSlices(sha hash.Hash, items [][]byte) []byte {
@@ -67,7 +66,7 @@ func hashFromByteSlices(sha hash.Hash, items [][]byte) []byte {
 // implementation for so little benefit.
 func HashFromByteSlicesIterative(input [][]byte) []byte {
 	items := make([][]byte, len(input))
-	sha := sha256.New()
+	sha := newHash()
 	for i, leaf := range input {
 		items[i] = leafHash(leaf)
 	}
@@ -12,6 +12,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/libs/flowrate"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
@@ -31,12 +33,15 @@ eg, L = latency = 0.1s
 */
 
 const (
-	requestInterval           = 2 * time.Millisecond
-	inactiveSleepInterval     = 1 * time.Second
-	maxTotalRequesters        = 600
-	maxPeerErrBuffer          = 1000
-	maxPendingRequests        = maxTotalRequesters
-	maxPendingRequestsPerPeer = 20
+	requestInterval       = 2 * time.Millisecond
+	inactiveSleepInterval = 1 * time.Second
+	maxPeerErrBuffer      = 1000
+
+	// Smoothing factors of the request latency moving averages, of each peer
+	// and of the pool. The pool average moves slower, so that it serves as
+	// the baseline peers are compared against.
+	peerLatencyAlpha = 0.2
+	poolLatencyAlpha = 0.05
 
 	// Minimum recv rate to ensure we're receiving blocks from a peer fast
 	// enough. If a peer is not sending us data at at least that rate, we
@@ -92,6 +97,12 @@ type BlockPool struct {
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
 
+	maxPendingRequests int32
+	maxRequestsPerPeer int32
+	adaptiveRequests   bool
+	avgLatency         time.Duration // moving average of the request latency
+	metrics            *consensus.Metrics
+
 	startHeight               int64
 	lastHundredBlockTimeStamp time.Time
 	lastSyncRate              float64
@@ -106,18 +117,24 @@ func NewBlockPool(
 	requestsCh chan<- BlockRequest,
 	errorsCh chan<- peerError,
 	peerManager *p2p.PeerManager,
+	cfg *config.BlockSyncConfig,
+	metrics *consensus.Metrics,
 ) *BlockPool {
 	bp := &BlockPool{
-		logger:       logger,
-		peers:        make(map[types.NodeID]*bpPeer),
-		requesters:   make(map[int64]*bpRequester),
-		height:       start,
-		startHeight:  start,
-		numPending:   0,
-		requestsCh:   requestsCh,
-		errorsCh:     errorsCh,
-		lastSyncRate: 0,
-		peerManager:  peerManager,
+		logger:             logger,
+		peers:              make(map[types.NodeID]*bpPeer),
+		requesters:         make(map[int64]*bpRequester),
+		height:             start,
+		startHeight:        start,
+		numPending:         0,
+		requestsCh:         requestsCh,
+		errorsCh:           errorsCh,
+		lastSyncRate:       0,
+		peerManager:        peerManager,
+		maxPendingRequests: int32(cfg.MaxPendingRequests),
+		maxRequestsPerPeer: int32(cfg.MaxRequestsPerPeer),
+		adaptiveRequests:   cfg.AdaptiveRequests,
+		metrics:            metrics,
 	}
 	bp.BaseService = *service.NewBaseService(logger, "BlockPool", bp)
 	return bp
@@ -152,7 +169,7 @@ func (pool *BlockPool) makeRequestersRoutine(ctx context.Context) {
 		}
 
 		_, numPending, lenRequesters := pool.GetStatus()
-		if numPending >= maxPendingRequests || lenRequesters >= maxTotalRequesters {
+		if numPending >= pool.maxPendingRequests || lenRequesters >= int(pool.maxPendingRequests) {
 			// This is preferable to using a timer because the request interval
 			// is so small. Larger request intervals may necessitate using a
 			// timer/ticker.
@@ -312,11 +329,13 @@ func (pool *BlockPool) AddBlock(peerID types.NodeID, block *types.Block, extComm
 		return fmt.Errorf("peer sent us a block we didn't expect (peer: %s, current height: %d, block height: %d)", peerID, pool.height, block.Height)
 	}
 
+	requestedAt := requester.getRequestedAt()
 	if requester.setBlock(block, extCommit, peerID) {
 		atomic.AddInt32(&pool.numPending, -1)
 		peer := pool.peers[peerID]
 		if peer != nil {
 			peer.decrPending(blockSize)
+			pool.updatePeerLatency(peer, time.Since(requestedAt))
 		}
 	} else {
 		err := errors.New("requester is different or block already exists")
@@ -327,6 +346,37 @@ func (pool *BlockPool) AddBlock(peerID types.NodeID, block *types.Block, extComm
 	return nil
 }
 
+// updatePeerLatency records the latency of a request served by the peer. With
+// adaptive requests, the peer's limit of requests in flight is then halved if
+// its latency is more than twice the pool average, and increased by one if it
+// is below the average.
+func (pool *BlockPool) updatePeerLatency(peer *bpPeer, latency time.Duration) {
+	pool.metrics.BlockSyncRequestLatency.With("peer_id", string(peer.id)).Observe(latency.Seconds())
+
+	peer.latency = movingAverage(peer.latency, latency, peerLatencyAlpha)
+	pool.avgLatency = movingAverage(pool.avgLatency, latency, poolLatencyAlpha)
+	if !pool.adaptiveRequests {
+		return
+	}
+
+	switch {
+	case peer.latency > 2*pool.avgLatency:
+		peer.maxPending /= 2
+		if peer.maxPending < 1 {
+			peer.maxPending = 1
+		}
+	case peer.latency < pool.avgLatency && peer.maxPending < pool.maxRequestsPerPeer:
+		peer.maxPending++
+	}
+}
+
+func movingAverage(avg, sample time.Duration, alpha float64) time.Duration {
+	if avg == 0 {
+		return sample
+	}
+	return time.Duration((1-alpha)*float64(avg) + alpha*float64(sample))
+}
+
 // MaxPeerHeight returns the highest reported height.
 func (pool *BlockPool) MaxPeerHeight() int64 {
 	pool.mtx.RLock()
@@ -358,6 +408,7 @@ func (pool *BlockPool) SetPeerRange(peerID types.NodeID, base int64, height int6
 			base:       base,
 			height:     height,
 			numPending: 0,
+			maxPending: pool.maxRequestsPerPeer,
 			logger:     pool.logger.With("peer", peerID),
 			startAt:    time.Now(),
 		}
@@ -455,7 +506,7 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 			pool.removePeer(peer.id)
 			continue
 		}
-		if peer.numPending >= maxPendingRequestsPerPeer {
+		if peer.numPending >= peer.maxPending {
 			continue
 		}
 		if height < peer.base || height > peer.height {
@@ -547,6 +598,8 @@ func (pool *BlockPool) getLastSyncRate() float64 {
 type bpPeer struct {
 	didTimeout  bool
 	numPending  int32
+	maxPending  int32         // limit of requests in flight
+	latency     time.Duration // moving average of the request latency
 	height      int64
 	base        int64
 	pool        *BlockPool
@@ -612,10 +665,11 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan types.NodeID // redo may send multitime, add peerId to identify repeat
 
-	mtx       sync.Mutex
-	peerID    types.NodeID
-	block     *types.Block
-	extCommit *types.ExtendedCommit
+	mtx         sync.Mutex
+	peerID      types.NodeID
+	requestedAt time.Time
+	block       *types.Block
+	extCommit   *types.ExtendedCommit
 }
 
 func newBPRequester(logger log.Logger, pool *BlockPool, height int64) *bpRequester {
@@ -678,6 +732,12 @@ func (bpr *bpRequester) getPeerID() types.NodeID {
 	return bpr.peerID
 }
 
+func (bpr *bpRequester) getRequestedAt() time.Time {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return bpr.requestedAt
+}
+
 // This is called from the requestRoutine, upon redo().
 func (bpr *bpRequester) reset() {
 	bpr.mtx.Lock()
@@ -730,6 +790,7 @@ OUTER_LOOP:
 		}
 		bpr.mtx.Lock()
 		bpr.peerID = peer.id
+		bpr.requestedAt = time.Now()
 		bpr.mtx.Unlock()
 
 		// Send request and wait.
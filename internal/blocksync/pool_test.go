@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/types"
 )
@@ -119,7 +121,7 @@ func TestBlockPoolBasic(t *testing.T) {
 	peers := makePeers(10, start+1, 1000)
 	errorsCh := make(chan peerError, 1000)
 	requestsCh := make(chan BlockRequest, 1000)
-	pool := NewBlockPool(log.NewNopLogger(), start, requestsCh, errorsCh, makePeerManager(peers))
+	pool := NewBlockPool(log.NewNopLogger(), start, requestsCh, errorsCh, makePeerManager(peers), config.TestBlockSyncConfig(), consensus.NopMetrics())
 
 	if err := pool.Start(ctx); err != nil {
 		t.Error(err)
@@ -177,7 +179,7 @@ func TestBlockPoolTimeout(t *testing.T) {
 	peers := makePeers(10, start+1, 1000)
 	errorsCh := make(chan peerError, 1000)
 	requestsCh := make(chan BlockRequest, 1000)
-	pool := NewBlockPool(logger, start, requestsCh, errorsCh, makePeerManager(peers))
+	pool := NewBlockPool(logger, start, requestsCh, errorsCh, makePeerManager(peers), config.TestBlockSyncConfig(), consensus.NopMetrics())
 	err := pool.Start(ctx)
 	if err != nil {
 		t.Error(err)
@@ -245,7 +247,7 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 	requestsCh := make(chan BlockRequest)
 	errorsCh := make(chan peerError)
 
-	pool := NewBlockPool(log.NewNopLogger(), 1, requestsCh, errorsCh, makePeerManager(peers))
+	pool := NewBlockPool(log.NewNopLogger(), 1, requestsCh, errorsCh, makePeerManager(peers), config.TestBlockSyncConfig(), consensus.NopMetrics())
 	err := pool.Start(ctx)
 	require.NoError(t, err)
 	t.Cleanup(func() { cancel(); pool.Wait() })
@@ -283,7 +285,7 @@ func TestSortedPeers(t *testing.T) {
 
 	requestsCh := make(chan BlockRequest)
 	errorsCh := make(chan peerError)
-	pool := NewBlockPool(log.NewNopLogger(), 1, requestsCh, errorsCh, makePeerManager(peers))
+	pool := NewBlockPool(log.NewNopLogger(), 1, requestsCh, errorsCh, makePeerManager(peers), config.TestBlockSyncConfig(), consensus.NopMetrics())
 	// add peers
 	for peerID, peer := range peers {
 		pool.SetPeerRange(peerID, peer.base, peer.height)
@@ -291,3 +293,42 @@ func TestSortedPeers(t *testing.T) {
 	// Peers should be sorted by score via peerManager
 	assert.Equal(t, []types.NodeID{peerIdC, peerIdA, peerIdB}, pool.getSortedPeers(pool.peers))
 }
+
+func TestBlockPoolAdaptiveRequests(t *testing.T) {
+	peers := makePeers(2, 1, 1000)
+	cfg := config.TestBlockSyncConfig()
+	cfg.AdaptiveRequests = true
+	pool := NewBlockPool(log.NewNopLogger(), 1, make(chan BlockRequest), make(chan peerError),
+		makePeerManager(peers), cfg, consensus.NopMetrics())
+
+	var fast, slow *bpPeer
+	for _, peer := range peers {
+		pool.SetPeerRange(peer.id, peer.base, peer.height)
+		if fast == nil {
+			fast = pool.peers[peer.id]
+		} else {
+			slow = pool.peers[peer.id]
+		}
+	}
+	maxRequests := int32(cfg.MaxRequestsPerPeer)
+	assert.Equal(t, maxRequests, fast.maxPending)
+	assert.Equal(t, maxRequests, slow.maxPending)
+
+	for i := 0; i < 20; i++ {
+		pool.updatePeerLatency(fast, 10*time.Millisecond)
+	}
+	for i := 0; i < 5; i++ {
+		pool.updatePeerLatency(slow, time.Second)
+	}
+	assert.Equal(t, maxRequests, fast.maxPending)
+	assert.Less(t, slow.maxPending, maxRequests)
+	assert.GreaterOrEqual(t, slow.maxPending, int32(1))
+
+	// The slow peer regains requests once it responds faster than average.
+	limit := slow.maxPending
+	for i := 0; i < 50; i++ {
+		pool.updatePeerLatency(slow, time.Millisecond)
+	}
+	assert.Greater(t, slow.maxPending, limit)
+	assert.LessOrEqual(t, slow.maxPending, maxRequests)
+}
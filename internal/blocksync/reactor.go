@@ -88,6 +88,7 @@ type Reactor struct {
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
 
+	config   *config.BlockSyncConfig
 	metrics  *consensus.Metrics
 	eventBus *eventbus.EventBus
 
@@ -96,6 +97,10 @@ type Reactor struct {
 	restartCh                 chan struct{}
 	blocksBehindThreshold     uint64
 	blocksBehindCheckInterval time.Duration
+
+	// haltHeight is the height after which no blocks are applied, see
+	// ConsensusConfig.HaltHeight. 0 disables it.
+	haltHeight int64
 }
 
 // NewReactor returns new reactor instance.
@@ -111,7 +116,9 @@ func NewReactor(
 	metrics *consensus.Metrics,
 	eventBus *eventbus.EventBus,
 	restartCh chan struct{},
+	cfg *config.BlockSyncConfig,
 	selfRemediationConfig *config.SelfRemediationConfig,
+	haltHeight int64,
 ) *Reactor {
 	r := &Reactor{
 		logger:                    logger,
@@ -122,11 +129,13 @@ func NewReactor(
 		blockSync:                 newAtomicBool(blockSync),
 		peerEvents:                peerEvents,
 		peerManager:               peerManager,
+		config:                    cfg,
 		metrics:                   metrics,
 		eventBus:                  eventBus,
 		restartCh:                 restartCh,
 		blocksBehindThreshold:     selfRemediationConfig.BlocksBehindThreshold,
 		blocksBehindCheckInterval: time.Duration(selfRemediationConfig.BlocksBehindCheckIntervalSeconds) * time.Second,
+		haltHeight:                haltHeight,
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "BlockSync", r)
@@ -160,9 +169,9 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 		startHeight = state.InitialHeight
 	}
 
-	requestsCh := make(chan BlockRequest, maxTotalRequesters)
+	requestsCh := make(chan BlockRequest, r.config.MaxPendingRequests)
 	errorsCh := make(chan peerError, maxPeerErrBuffer) // NOTE: The capacity should be larger than the peer count.
-	r.pool = NewBlockPool(r.logger, startHeight, requestsCh, errorsCh, r.peerManager)
+	r.pool = NewBlockPool(r.logger, startHeight, requestsCh, errorsCh, r.peerManager, r.config, r.metrics)
 	r.requestsCh = requestsCh
 	r.errorsCh = errorsCh
 
@@ -206,7 +215,7 @@ func (r *Reactor) respondToPeer(ctx context.Context, msg *bcproto.BlockRequest,
 		return fmt.Errorf("loading state: %w", err)
 	}
 	var extCommit *types.ExtendedCommit
-	if state.ConsensusParams.ABCI.VoteExtensionsEnabled(msg.Height) {
+	if state.ConsensusParams.ExtendedCommitsRequired(msg.Height) {
 		extCommit = r.store.LoadBlockExtendedCommit(msg.Height)
 		if extCommit == nil {
 			return fmt.Errorf("found block in store with no extended commit: %v", block)
@@ -497,6 +506,12 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 	defer trySyncTicker.Stop()
 	defer switchToConsensusTicker.Stop()
 
+	if r.config.TrustedHeight > state.LastBlockHeight {
+		r.logger.Info("WARNING: trusted block sync: commit signatures are only verified periodically below the trusted height",
+			"trusted_height", r.config.TrustedHeight,
+			"verify_interval", r.config.TrustedVerifyInterval)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -532,7 +547,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			// if we already had extensions for the initial height.
 			// If any of these conditions is not met, we continue the loop, looking
 			// for extensions.
-			case state.ConsensusParams.ABCI.VoteExtensionsEnabled(state.LastBlockHeight) &&
+			case state.ConsensusParams.ExtendedCommitsRequired(state.LastBlockHeight) &&
 				(blocksSynced == 0 && !initialCommitHasExtensions):
 				r.logger.Info(
 					"no extended commit yet",
@@ -544,6 +559,10 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 				)
 				continue
 
+			case r.reachedHaltHeight(state):
+				// consensus halts right away when it's started at the halt height
+				r.logger.Info("reached halt height; switching to consensus reactor", "height", height, "halt_height", r.haltHeight)
+
 			case r.pool.IsCaughtUp():
 				r.logger.Info("switching to consensus reactor", "height", height)
 
@@ -589,10 +608,15 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			//
 			// TODO: Uncouple from request routine.
 
+			// don't apply blocks past the halt height
+			if r.reachedHaltHeight(state) {
+				continue
+			}
+
 			// see if there are any blocks to sync
 			first, second, extCommit := r.pool.PeekTwoBlocks()
 			if first != nil && extCommit == nil &&
-				state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height) {
+				state.ConsensusParams.ExtendedCommitsRequired(first.Height) {
 				// See https://github.com/tendermint/tendermint/pull/8433#discussion_r866790631
 				panic(fmt.Errorf("peeked first block without extended commit at height %d - possible node store corruption", first.Height))
 			} else if first == nil || second == nil {
@@ -604,7 +628,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			// try again quickly next loop
 			didProcessCh <- struct{}{}
 
-			firstParts, err := first.MakePartSet(types.BlockPartSizeBytes)
+			firstParts, err := first.MakePartSet(state.ConsensusParams.Block.PartSize())
 			if err != nil {
 				r.logger.Error("failed to make ",
 					"height", first.Height,
@@ -623,11 +647,19 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
 			// TODO(sergio): Should we also validate against the extended commit?
-			err = state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
-
-			if err == nil {
-				// validate the block before we persist it
-				err = r.blockExec.ValidateBlock(ctx, state, first)
+			if r.skipCommitVerification(first.Height) {
+				// below the trusted height, the signatures are only verified
+				// every TrustedVerifyInterval blocks
+				err = types.VerifyCommitStructure(state.Validators, firstID, first.Height, second.LastCommit)
+				if err == nil {
+					err = r.blockExec.ValidateBlockTrusted(ctx, state, first)
+				}
+			} else {
+				err = state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+				if err == nil {
+					// validate the block before we persist it
+					err = r.blockExec.ValidateBlock(ctx, state, first)
+				}
 			}
 			if err == nil && state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height) {
 				// if vote extensions were required at this height, ensure they exist.
@@ -668,9 +700,14 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			r.pool.PopRequest()
 
 			// TODO: batch saves so we do not persist to disk every block
-			if state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height) {
+			switch {
+			case state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height):
 				r.store.SaveBlockWithExtendedCommit(first, firstParts, extCommit)
-			} else {
+			case state.ConsensusParams.Validator.AggregateCommitsEnabled(first.Height):
+				// The LastCommit of the second block is aggregated, so the
+				// individual signatures are only in extCommit.
+				r.store.SaveBlockWithAggregatedCommit(first, firstParts, extCommit)
+			default:
 				// We use LastCommit here instead of extCommit. extCommit is not
 				// guaranteed to be populated by the peer if extensions are not enabled.
 				// Currently, the peer should provide an extCommit even if the vote extension data are absent
@@ -704,6 +741,20 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 	}
 }
 
+// skipCommitVerification returns true if the signatures of the commits for
+// the block at height, and of its LastCommit, are not verified. See
+// BlockSyncConfig.TrustedHeight.
+func (r *Reactor) skipCommitVerification(height int64) bool {
+	return r.config.TrustedHeight > 0 && height < r.config.TrustedHeight &&
+		height%r.config.TrustedVerifyInterval != 0
+}
+
+// reachedHaltHeight returns true if the block at the halt height has been
+// applied.
+func (r *Reactor) reachedHaltHeight(state sm.State) bool {
+	return r.haltHeight > 0 && state.LastBlockHeight >= r.haltHeight
+}
+
 func (r *Reactor) GetMaxPeerBlockHeight() int64 {
 	return r.pool.MaxPeerHeight()
 }
@@ -164,7 +164,9 @@ func makeReactor(
 		consensus.NopMetrics(),
 		nil, // eventbus, can be nil
 		restartChan,
+		config.TestBlockSyncConfig(),
 		selfRemediationConfig,
+		0, // haltHeight
 	)
 }
 
@@ -344,6 +346,29 @@ func TestReactor_SyncTime(t *testing.T) {
 	)
 }
 
+func TestReactor_SkipCommitVerification(t *testing.T) {
+	cfg := config.TestBlockSyncConfig()
+	r := &Reactor{config: cfg}
+
+	// every commit is verified by default
+	for height := int64(1); height <= 300; height++ {
+		require.False(t, r.skipCommitVerification(height))
+	}
+
+	// below the trusted height, only every TrustedVerifyInterval commit is
+	// verified
+	cfg.TrustedHeight = 250
+	cfg.TrustedVerifyInterval = 100
+	require.True(t, r.skipCommitVerification(1))
+	require.True(t, r.skipCommitVerification(99))
+	require.False(t, r.skipCommitVerification(100))
+	require.True(t, r.skipCommitVerification(101))
+	require.False(t, r.skipCommitVerification(200))
+	require.True(t, r.skipCommitVerification(249))
+	require.False(t, r.skipCommitVerification(250))
+	require.False(t, r.skipCommitVerification(251))
+}
+
 type MockBlockStore struct {
 	mock.Mock
 	sm.BlockStore
@@ -86,6 +86,32 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	ensureNewEventOnChannel(t, newBlockCh)   // until the CreateEmptyBlocksInterval has passed
 }
 
+func TestMempoolMaxConsecutiveEmptyBlocks(t *testing.T) {
+	baseConfig := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, err := ResetConfig(t.TempDir(), "consensus_mempool_txs_available_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(config.RootDir) })
+
+	config.Consensus.CreateEmptyBlocksInterval = ensureTimeout / 2
+	config.Consensus.MaxConsecutiveEmptyBlocks = 2
+	state, privVals := makeGenesisState(ctx, t, baseConfig, genesisStateArgs{
+		Validators: 1,
+		Power:      10,
+		Params:     factory.ConsensusParams()})
+	cs := newStateWithConfig(ctx, t, log.NewNopLogger(), config, state, privVals[0], NewCounterApplication())
+	assertMempool(t, cs.txNotifier).EnableTxsAvailable()
+	newBlockCh := subscribe(ctx, t, cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(ctx, cs, cs.roundState.Height(), cs.roundState.Round())
+
+	ensureNewEventOnChannel(t, newBlockCh)   // first block gets committed
+	ensureNewEventOnChannel(t, newBlockCh)   // then an empty block after the interval
+	ensureNoNewEventOnChannel(t, newBlockCh) // but no more once the limit is reached
+	ensureNoNewEventOnChannel(t, newBlockCh)
+}
+
 func TestMempoolProgressInHigherRound(t *testing.T) {
 	baseConfig := configSetup(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -132,12 +132,38 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "state_syncing",
 			Help:      "Whether or not a node is state syncing. 1 if yes, 0 if no.",
 		}, labels).With(labelsAndValues...),
+		BlockSyncRequestLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_sync_request_latency",
+			Help:      "Time in seconds between requesting a block from a peer and receiving it, during block sync.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 10, 8),
+		}, append(labels, "peer_id")).With(labelsAndValues...),
 		BlockParts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "block_parts",
 			Help:      "Number of block parts transmitted by each peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		ReconstructedBlockParts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reconstructed_block_parts",
+			Help:      "Number of block parts reconstructed from parity parts.",
+		}, labels).With(labelsAndValues...),
+		PeersWithUnansweredQueries: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peers_with_unanswered_queries",
+			Help:      "Number of peers with unanswered vote set queries.",
+		}, labels).With(labelsAndValues...),
+		UnresponsivePeersEvicted: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "unresponsive_peers_evicted",
+			Help:      "Number of peers evicted for leaving vote set queries unanswered for longer than the unresponsive peer timeout.",
+		}, labels).With(labelsAndValues...),
 		StepDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -294,7 +320,11 @@ func NopMetrics() *Metrics {
 		CommittedHeight:               discard.NewGauge(),
 		BlockSyncing:                  discard.NewGauge(),
 		StateSyncing:                  discard.NewGauge(),
+		BlockSyncRequestLatency:       discard.NewHistogram(),
 		BlockParts:                    discard.NewCounter(),
+		ReconstructedBlockParts:       discard.NewCounter(),
+		PeersWithUnansweredQueries:    discard.NewGauge(),
+		UnresponsivePeersEvicted:      discard.NewCounter(),
 		StepDuration:                  discard.NewHistogram(),
 		BlockGossipReceiveLatency:     discard.NewHistogram(),
 		BlockGossipPartsReceived:      discard.NewCounter(),
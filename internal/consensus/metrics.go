@@ -65,9 +65,20 @@ type Metrics struct {
 	BlockSyncing metrics.Gauge
 	// Whether or not a node is state syncing. 1 if yes, 0 if no.
 	StateSyncing metrics.Gauge
+	// Time in seconds between requesting a block from a peer and receiving
+	// it, during block sync.
+	BlockSyncRequestLatency metrics.Histogram `metrics_labels:"peer_id" metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 10, 8"`
 
 	// Number of block parts transmitted by each peer.
 	BlockParts metrics.Counter `metrics_labels:"peer_id"`
+	// Number of block parts reconstructed from parity parts.
+	ReconstructedBlockParts metrics.Counter
+
+	// Number of peers with unanswered vote set queries.
+	PeersWithUnansweredQueries metrics.Gauge
+	// Number of peers evicted for leaving vote set queries unanswered for
+	// longer than the unresponsive peer timeout.
+	UnresponsivePeersEvicted metrics.Counter
 
 	// Histogram of durations for each step in the consensus protocol.
 	StepDuration metrics.Histogram `metrics_labels:"step" metrics_buckettype:"exprange" metrics_bucketsizes:"0.1, 100, 8"`
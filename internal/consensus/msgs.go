@@ -32,6 +32,7 @@ func init() {
 	jsontypes.MustRegister(&HasVoteMessage{})
 	jsontypes.MustRegister(&VoteSetMaj23Message{})
 	jsontypes.MustRegister(&VoteSetBitsMessage{})
+	jsontypes.MustRegister(&ParityPartMessage{})
 }
 
 // NewRoundStepMessage is sent for every step taken in the ConsensusState.
@@ -213,6 +214,42 @@ func (m *BlockPartMessage) String() string {
 	return fmt.Sprintf("[BlockPart H:%v R:%v P:%v]", m.Height, m.Round, m.Part)
 }
 
+// ParityPartMessage is sent, in addition to block parts, to peers supporting
+// erasure-coded block gossip.
+type ParityPartMessage struct {
+	Height        int64 `json:",string"`
+	Round         int32
+	PartSetHeader types.PartSetHeader
+	Part          *types.ParityPart
+}
+
+func (*ParityPartMessage) TypeTag() string { return "tendermint/ParityPart" }
+
+// ValidateBasic performs basic validation.
+func (m *ParityPartMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.PartSetHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong PartSetHeader: %w", err)
+	}
+	if m.Part.Index >= types.ParityPartsTotal(m.PartSetHeader.Total) {
+		return fmt.Errorf("parity part index %d out of range for %d parts", m.Part.Index, m.PartSetHeader.Total)
+	}
+	if err := m.Part.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Part: %w", err)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *ParityPartMessage) String() string {
+	return fmt.Sprintf("[ParityPart H:%v R:%v PSH:%v P:%v]", m.Height, m.Round, m.PartSetHeader, m.Part)
+}
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -456,6 +493,22 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 			Sum: vsb,
 		}
 
+	case *ParityPartMessage:
+		part, err := msg.Part.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_ParityPart{
+				ParityPart: &tmcons.ParityPart{
+					Height:        msg.Height,
+					Round:         msg.Round,
+					PartSetHeader: msg.PartSetHeader.ToProto(),
+					Part:          *part,
+				},
+			},
+		}
+
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -580,6 +633,21 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *tmcons.Message_ParityPart:
+		psh, err := types.PartSetHeaderFromProto(&msg.ParityPart.PartSetHeader)
+		if err != nil {
+			return nil, fmt.Errorf("parity part msg to proto error: %w", err)
+		}
+		part, err := types.ParityPartFromProto(&msg.ParityPart.Part)
+		if err != nil {
+			return nil, fmt.Errorf("parity part msg to proto error: %w", err)
+		}
+		pb = &ParityPartMessage{
+			Height:        msg.ParityPart.Height,
+			Round:         msg.ParityPart.Round,
+			PartSetHeader: *psh,
+			Part:          part,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -713,6 +713,40 @@ func TestBlockPartMessageValidateBasic(t *testing.T) {
 	assert.Equal(t, true, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
 }
 
+func TestParityPartMessageValidateBasic(t *testing.T) {
+	psh := types.PartSetHeader{Total: 4, Hash: crypto.Checksum([]byte("parts"))}
+	testCases := []struct {
+		testName      string
+		messageHeight int64
+		messageRound  int32
+		messageHeader types.PartSetHeader
+		messagePart   *types.ParityPart
+		expectErr     bool
+	}{
+		{"Valid Message", 0, 0, psh, &types.ParityPart{Index: 1, Size: 100, Bytes: []byte("parity")}, false},
+		{"Invalid Height", -1, 0, psh, &types.ParityPart{Index: 1, Size: 100, Bytes: []byte("parity")}, true},
+		{"Invalid Round", 0, -1, psh, &types.ParityPart{Index: 1, Size: 100, Bytes: []byte("parity")}, true},
+		{"Invalid Index", 0, 0, psh, &types.ParityPart{Index: 2, Size: 100, Bytes: []byte("parity")}, true},
+		{"Not Erasure-Coded", 0, 0, types.PartSetHeader{Total: 1, Hash: psh.Hash},
+			&types.ParityPart{Index: 0, Size: 100, Bytes: []byte("parity")}, true},
+		{"Empty Part", 0, 0, psh, &types.ParityPart{Index: 1, Size: 100}, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			message := ParityPartMessage{
+				Height:        tc.messageHeight,
+				Round:         tc.messageRound,
+				PartSetHeader: tc.messageHeader,
+				Part:          tc.messagePart,
+			}
+
+			assert.Equal(t, tc.expectErr, message.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestHasVoteMessageValidateBasic(t *testing.T) {
 	const (
 		validSignedMsgType   tmproto.SignedMsgType = 0x01
@@ -0,0 +1,104 @@
+package consensus
+
+import (
+	"sync"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/types"
+)
+
+// parityPartSet collects the parity parts received for the proposal block of
+// the current round, until they are enough to reconstruct its missing parts.
+type parityPartSet struct {
+	mtx     sync.Mutex
+	header  types.PartSetHeader
+	parts   map[uint32]*types.ParityPart
+	senders map[uint32]types.NodeID
+	// done is set once the missing parts were reconstructed, so that the parity
+	// parts received afterwards are ignored. Until then, a failed attempt is
+	// retried with each parity part received, so that invalid ones are
+	// eventually left out.
+	done bool
+}
+
+// add adds a parity part of partSet received from a peer. Once the parts of
+// partSet and the parity parts received are enough, it reconstructs and
+// returns the parts missing from partSet, along with the peers that sent
+// parity parts not matching the reconstructed block.
+func (s *parityPartSet) add(partSet *types.PartSet, part *types.ParityPart, from types.NodeID) ([]*types.Part, []types.NodeID, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if header := partSet.Header(); !s.header.Equals(header) {
+		s.header = header
+		s.parts = make(map[uint32]*types.ParityPart)
+		s.senders = make(map[uint32]types.NodeID)
+		s.done = false
+	}
+	if s.done || partSet.IsComplete() {
+		return nil, nil, nil
+	}
+	if _, ok := s.parts[part.Index]; ok {
+		return nil, nil, nil
+	}
+	s.parts[part.Index] = part
+	s.senders[part.Index] = from
+	if partSet.Count()+uint32(len(s.parts)) < partSet.Total() {
+		return nil, nil, nil
+	}
+
+	parity := make([]*types.ParityPart, 0, len(s.parts))
+	for _, part := range s.parts {
+		parity = append(parity, part)
+	}
+	parts, invalid, err := partSet.ReconstructParts(parity)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.done = true
+
+	faulty := make([]types.NodeID, 0, len(invalid))
+	for _, part := range invalid {
+		faulty = append(faulty, s.senders[part.Index])
+	}
+	return parts, faulty, nil
+}
+
+// pickPartToSend picks a part of the proposal block the peer lacks, at
+// random. For peers supporting erasure-coded gossip, the parity parts the peer
+// lacks are picked from as well, as long as it doesn't have as many parts and
+// parity parts as the block has parts, after which it needs specific parts.
+func pickPartToSend(partSet *types.PartSet, prs *cstypes.PeerRoundState, parity bool) (index int, isParity bool, ok bool) {
+	missing := partSet.BitArray().Sub(prs.ProposalBlockParts.Copy())
+	total := int(partSet.Total())
+	parityTotal := int(types.ParityPartsTotal(partSet.Total()))
+	if !parity || parityTotal == 0 || !partSet.IsComplete() ||
+		countTrue(prs.ProposalBlockParts)+countTrue(prs.ProposalParityParts) >= total {
+		index, ok = missing.PickRandom()
+		return index, false, ok
+	}
+
+	candidates := bits.NewBitArray(total + parityTotal)
+	for i := 0; i < total; i++ {
+		candidates.SetIndex(i, missing.GetIndex(i))
+	}
+	for i := 0; i < parityTotal; i++ {
+		candidates.SetIndex(total+i, !prs.ProposalParityParts.GetIndex(i))
+	}
+	index, ok = candidates.PickRandom()
+	if ok && index >= total {
+		return index - total, true, true
+	}
+	return index, false, ok
+}
+
+func countTrue(bA *bits.BitArray) int {
+	n := 0
+	for i := 0; i < bA.Size(); i++ {
+		if bA.GetIndex(i) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/bits"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestParityPartSetAdd(t *testing.T) {
+	const partSize = 512
+	peerID := types.NodeID(strings.Repeat("a", 40))
+	partSet := types.NewPartSetFromData(tmrand.Bytes(partSize*6), partSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 3)
+
+	partial := types.NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 2, 5} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	var s parityPartSet
+	parts, _, err := s.add(partial, parity[0], peerID)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+	// Duplicates don't count.
+	parts, _, err = s.add(partial, parity[0], peerID)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+	parts, _, err = s.add(partial, parity[2], peerID)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+
+	parts, faulty, err := s.add(partial, parity[1], peerID)
+	require.NoError(t, err)
+	assert.Empty(t, faulty)
+	require.Len(t, parts, 3)
+	for _, part := range parts {
+		added, err := partial.AddPart(part)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	assert.True(t, partial.IsComplete())
+
+	// Parity parts received after the reconstruction are ignored.
+	parts, _, err = s.add(partial, parity[1], peerID)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+
+	// A new part set resets the parity parts collected.
+	other := types.NewPartSetFromData(tmrand.Bytes(partSize*2), partSize)
+	otherParity, err := other.ParityParts()
+	require.NoError(t, err)
+	otherPartial := types.NewPartSetFromHeader(other.Header())
+	_, err = otherPartial.AddPart(other.GetPart(1))
+	require.NoError(t, err)
+	parts, _, err = s.add(otherPartial, otherParity[0], peerID)
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	assert.Equal(t, other.GetPart(0).Bytes, parts[0].Bytes)
+}
+
+func TestParityPartSetAddInvalid(t *testing.T) {
+	const partSize = 512
+	goodPeer := types.NodeID(strings.Repeat("a", 40))
+	badPeer := types.NodeID(strings.Repeat("b", 40))
+
+	partSet := types.NewPartSetFromData(tmrand.Bytes(partSize*6), partSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 3)
+
+	partial := types.NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 1, 2, 3} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	corrupted := *parity[0]
+	corrupted.Bytes = tmrand.Bytes(partSize)
+
+	var s parityPartSet
+	parts, _, err := s.add(partial, &corrupted, badPeer)
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+	// The first attempt fails, but isn't the last one.
+	_, _, err = s.add(partial, parity[1], goodPeer)
+	require.ErrorIs(t, err, types.ErrPartSetInvalidParity)
+
+	// Another parity part allows leaving out the invalid one, whose sender is
+	// reported.
+	parts, faulty, err := s.add(partial, parity[2], goodPeer)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, []types.NodeID{badPeer}, faulty)
+	for _, part := range parts {
+		added, err := partial.AddPart(part)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	assert.True(t, partial.IsComplete())
+}
+
+func TestPickPartToSend(t *testing.T) {
+	const partSize = 512
+	partSet := types.NewPartSetFromData(tmrand.Bytes(partSize*4), partSize)
+	prs := &cstypes.PeerRoundState{
+		ProposalBlockParts:  bits.NewBitArray(4),
+		ProposalParityParts: bits.NewBitArray(2),
+	}
+
+	// Peers not supporting parity parts only get block parts.
+	for i := 0; i < 20; i++ {
+		_, isParity, ok := pickPartToSend(partSet, prs, false)
+		require.True(t, ok)
+		assert.False(t, isParity)
+	}
+
+	prs.ProposalBlockParts.SetIndex(0, true)
+	prs.ProposalBlockParts.SetIndex(1, true)
+	prs.ProposalParityParts.SetIndex(0, true)
+	sent := map[bool]map[int]bool{false: {}, true: {}}
+	for i := 0; i < 100; i++ {
+		index, isParity, ok := pickPartToSend(partSet, prs, true)
+		require.True(t, ok)
+		sent[isParity][index] = true
+	}
+	assert.Equal(t, map[bool]map[int]bool{false: {2: true, 3: true}, true: {1: true}}, sent)
+
+	// Once the peer has as many parts as the block, it needs block parts.
+	prs.ProposalParityParts.SetIndex(1, true)
+	for i := 0; i < 20; i++ {
+		index, isParity, ok := pickPartToSend(partSet, prs, true)
+		require.True(t, ok)
+		assert.False(t, isParity)
+		assert.Contains(t, []int{2, 3}, index)
+	}
+}
@@ -72,6 +72,9 @@ type pbtsTestConfiguration struct {
 	// The setting to use for the TimeoutPropose configuration parameter.
 	timeoutPropose time.Duration
 
+	// The setting to use for the ClockSkewTolerance configuration parameter.
+	clockSkewTolerance time.Duration
+
 	// The genesis time
 	genesisTime time.Time
 
@@ -116,6 +119,7 @@ func newPBTSTestHarness(ctx context.Context, t *testing.T, tc pbtsTestConfigurat
 		Validators: validators,
 	})
 	cs := newState(ctx, t, log.NewNopLogger(), state, privVals[0], kvstore.NewApplication())
+	cs.config.ClockSkewTolerance = tc.clockSkewTolerance
 	vss := make([]*validatorStub, validators)
 	for i := 0; i < validators; i++ {
 		vss[i] = newValidatorStub(privVals[i], int32(i))
@@ -478,6 +482,29 @@ func TestTooFarInThePastProposal(t *testing.T) {
 	require.Nil(t, results.height2.prevote.BlockID.Hash)
 }
 
+func TestTooFarInThePastProposalWithinClockSkewTolerance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// localtime > proposedBlockTime + MsgDelay + Precision, but
+	// localtime <= proposedBlockTime + MsgDelay + Precision + ClockSkewTolerance
+	cfg := pbtsTestConfiguration{
+		synchronyParams: types.SynchronyParams{
+			Precision:    1 * time.Millisecond,
+			MessageDelay: 10 * time.Millisecond,
+		},
+		timeoutPropose:                    50 * time.Millisecond,
+		clockSkewTolerance:                5 * time.Millisecond,
+		height2ProposedBlockOffset:        15 * time.Millisecond,
+		height2ProposalTimeDeliveryOffset: 27 * time.Millisecond,
+	}
+
+	pbtsTest := newPBTSTestHarness(ctx, t, cfg)
+	results := pbtsTest.run(ctx, t)
+
+	require.NotNil(t, results.height2.prevote.BlockID.Hash)
+}
+
 func TestTooFarInTheFutureProposal(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
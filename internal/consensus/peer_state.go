@@ -47,6 +47,12 @@ type PeerState struct {
 	running bool
 	PRS     cstypes.PeerRoundState `json:"round_state"`
 	Stats   *peerStateStats        `json:"stats"`
+
+	// parity is set if the peer supports erasure-coded block gossip.
+	parity bool
+	// queriedAt is the time of the oldest VoteSetMaj23 query sent to the peer
+	// that it hasn't answered with a VoteSetBits, or zero if there is none.
+	queriedAt time.Time
 }
 
 // NewPeerState returns a new PeerState for the given node ID.
@@ -159,6 +165,61 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetHasParityPart sets the given parity part index as known for the peer.
+func (ps *PeerState) SetHasParityPart(height int64, round int32, header types.PartSetHeader, index int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.PRS.Height != height || ps.PRS.Round != round || !ps.PRS.ProposalBlockPartSetHeader.Equals(header) {
+		return
+	}
+
+	if ps.PRS.ProposalParityParts == nil {
+		ps.PRS.ProposalParityParts = bits.NewBitArray(int(types.ParityPartsTotal(header.Total)))
+	}
+	ps.PRS.ProposalParityParts.SetIndex(index, true)
+}
+
+// SetSupportsParityParts sets whether the peer supports erasure-coded block
+// gossip.
+func (ps *PeerState) SetSupportsParityParts(v bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.parity = v
+}
+
+// SupportsParityParts returns true if parity parts may be sent to the peer.
+func (ps *PeerState) SupportsParityParts() bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.parity
+}
+
+// SetQueried records that a VoteSetMaj23 query was sent to the peer at the
+// given time, unless an earlier query is still unanswered.
+func (ps *PeerState) SetQueried(now time.Time) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.queriedAt.IsZero() {
+		ps.queriedAt = now
+	}
+}
+
+// UnansweredFor returns for how long the oldest VoteSetMaj23 query sent to
+// the peer has been left unanswered, or 0 if there is none.
+func (ps *PeerState) UnansweredFor(now time.Time) time.Duration {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	if ps.queriedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(ps.queriedAt)
+}
+
 // PickVoteToSend picks a vote to send to the peer. It will return true if a
 // vote was picked.
 //
@@ -412,10 +473,16 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 	ps.PRS.Step = msg.Step
 	ps.PRS.StartTime = startTime
 
+	// the peer only answers queries for its current height
+	if psHeight != msg.Height {
+		ps.queriedAt = time.Time{}
+	}
+
 	if psHeight != msg.Height || psRound != msg.Round {
 		ps.PRS.Proposal = false
 		ps.PRS.ProposalBlockPartSetHeader = types.PartSetHeader{}
 		ps.PRS.ProposalBlockParts = nil
+		ps.PRS.ProposalParityParts = nil
 		ps.PRS.ProposalPOLRound = -1
 		ps.PRS.ProposalPOL = nil
 
@@ -461,6 +528,9 @@ func (ps *PeerState) ApplyNewValidBlockMessage(msg *NewValidBlockMessage) {
 		return
 	}
 
+	if !ps.PRS.ProposalBlockPartSetHeader.Equals(msg.BlockPartSetHeader) {
+		ps.PRS.ProposalParityParts = nil
+	}
 	ps.PRS.ProposalBlockPartSetHeader = msg.BlockPartSetHeader
 	ps.PRS.ProposalBlockParts = msg.BlockParts
 }
@@ -503,6 +573,8 @@ func (ps *PeerState) ApplyVoteSetBitsMessage(msg *VoteSetBitsMessage, ourVotes *
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
+	ps.queriedAt = time.Time{}
+
 	votes := ps.getVoteBitArray(msg.Height, msg.Round, msg.Type)
 	if votes != nil {
 		if ourVotes == nil {
@@ -2,9 +2,11 @@ package consensus
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -99,3 +101,28 @@ func TestApplyHasVoteMessage(t *testing.T) {
 
 	// skip test cases like v & v3 in TestSetHasVote due to the same path
 }
+
+func TestPeerStateUnansweredQueries(t *testing.T) {
+	now := time.Now()
+
+	ps := peerStateSetup(5, 0, 1)
+	require.Zero(t, ps.UnansweredFor(now))
+
+	// the oldest unanswered query counts
+	ps.SetQueried(now)
+	ps.SetQueried(now.Add(time.Minute))
+	require.Equal(t, 2*time.Minute, ps.UnansweredFor(now.Add(2*time.Minute)))
+
+	// a response answers the queries
+	ps.ApplyVoteSetBitsMessage(&VoteSetBitsMessage{Height: 5, Type: tmproto.PrevoteType}, nil)
+	require.Zero(t, ps.UnansweredFor(now.Add(3*time.Minute)))
+
+	// a new round at the same height doesn't drop the queries
+	ps.SetQueried(now)
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: 5, Round: 1, Step: cstypes.RoundStepNewRound})
+	require.Equal(t, time.Minute, ps.UnansweredFor(now.Add(time.Minute)))
+
+	// the peer doesn't answer queries for a previous height
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: 6, Step: cstypes.RoundStepNewHeight})
+	require.Zero(t, ps.UnansweredFor(now.Add(time.Minute)))
+}
@@ -54,6 +54,21 @@ func GetDataChannelDescriptor() *p2p.ChannelDescriptor {
 	}
 }
 
+// GetParityChannelDescriptor returns the descriptor of the channel parity
+// parts are gossiped on. Nodes open it only if erasure-coded gossip is enabled,
+// so that peers know whether they support it.
+func GetParityChannelDescriptor() *p2p.ChannelDescriptor {
+	return &p2p.ChannelDescriptor{
+		ID:                  ParityChannel,
+		MessageType:         new(tmcons.Message),
+		Priority:            12,
+		SendQueueCapacity:   64,
+		RecvBufferCapacity:  512,
+		RecvMessageCapacity: maxMsgSize,
+		Name:                "parity",
+	}
+}
+
 func GetVoteChannelDescriptor() *p2p.ChannelDescriptor {
 	return &p2p.ChannelDescriptor{
 		ID:                  VoteChannel,
@@ -83,6 +98,7 @@ const (
 	DataChannel        = p2p.ChannelID(0x21)
 	VoteChannel        = p2p.ChannelID(0x22)
 	VoteSetBitsChannel = p2p.ChannelID(0x23)
+	ParityChannel      = p2p.ChannelID(0x24)
 
 	maxMsgSize = 4194304 // 4MB; NOTE: keep larger than types.PartSet sizes.
 
@@ -90,8 +106,19 @@ const (
 	votesToContributeToBecomeGoodPeer  = 10000
 
 	listenerIDConsensus = "consensus-reactor"
+
+	// unresponsivePeerCheckInterval is the interval at which peers are checked
+	// for unanswered queries.
+	unresponsivePeerCheckInterval = time.Second
 )
 
+var errPeerUnresponsive = errors.New("peer left vote set queries unanswered")
+
+// PeerEvictor disconnects peers without affecting their score.
+type PeerEvictor interface {
+	Errored(types.NodeID, error)
+}
+
 // NOTE: Temporary interface for switching to block sync, we should get rid of v0.
 // See: https://github.com/tendermint/tendermint/issues/4595
 type BlockSyncReactor interface {
@@ -132,9 +159,11 @@ type Reactor struct {
 	rs          *cstypes.RoundState
 	readySignal chan struct{} // closed when the node is ready to start consensus
 
-	peerEvents p2p.PeerEventSubscriber
+	peerEvents  p2p.PeerEventSubscriber
+	peerEvictor PeerEvictor
 
 	channels *channelBundle
+	parity   parityPartSet
 }
 
 // NewReactor returns a reference to a new consensus reactor, which implements
@@ -145,6 +174,7 @@ func NewReactor(
 	logger log.Logger,
 	cs *State,
 	peerEvents p2p.PeerEventSubscriber,
+	peerEvictor PeerEvictor,
 	eventBus *eventbus.EventBus,
 	waitSync bool,
 	metrics *Metrics,
@@ -158,6 +188,7 @@ func NewReactor(
 		eventBus:    eventBus,
 		Metrics:     metrics,
 		peerEvents:  peerEvents,
+		peerEvictor: peerEvictor,
 		readySignal: make(chan struct{}),
 		channels:    &channelBundle{},
 	}
@@ -175,6 +206,7 @@ type channelBundle struct {
 	data   *p2p.Channel
 	vote   *p2p.Channel
 	votSet *p2p.Channel
+	parity *p2p.Channel
 }
 
 func (r *Reactor) SetStateChannel(ch *p2p.Channel) {
@@ -193,6 +225,12 @@ func (r *Reactor) SetVoteSetChannel(ch *p2p.Channel) {
 	r.channels.votSet = ch
 }
 
+// SetParityChannel enables erasure-coded block gossip with the peers that
+// open the channel as well.
+func (r *Reactor) SetParityChannel(ch *p2p.Channel) {
+	r.channels.parity = ch
+}
+
 // OnStart starts separate go routines for each p2p Channel and listens for
 // envelopes on each. In addition, it also listens for peer updates and handles
 // messages on that p2p channel accordingly. The caller must be sure to execute
@@ -224,7 +262,11 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	go r.processDataCh(ctx, *r.channels)
 	go r.processVoteCh(ctx, *r.channels)
 	go r.processVoteSetBitsCh(ctx, *r.channels)
+	if r.channels.parity != nil {
+		go r.processParityCh(ctx, *r.channels)
+	}
 	go r.processPeerUpdates(ctx, peerUpdates, *r.channels)
+	go r.unresponsivePeersRoutine(ctx)
 
 	return nil
 }
@@ -505,7 +547,7 @@ func (r *Reactor) gossipDataForCatchup(ctx context.Context, rs *cstypes.RoundSta
 	time.Sleep(r.state.config.PeerGossipSleepDuration)
 }
 
-func (r *Reactor) gossipDataRoutine(ctx context.Context, ps *PeerState, dataCh *p2p.Channel) {
+func (r *Reactor) gossipDataRoutine(ctx context.Context, ps *PeerState, dataCh, parityCh *p2p.Channel) {
 	logger := r.logger.With("peer", ps.peerID)
 
 	timer := time.NewTimer(0)
@@ -530,7 +572,15 @@ OUTER_LOOP:
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+			index, isParity, ok := pickPartToSend(rs.ProposalBlockParts, prs, ps.SupportsParityParts())
+			if ok && isParity {
+				if err := r.sendParityPart(ctx, rs, ps, index, parityCh); err != nil {
+					logger.Error("failed to send parity part", "err", err)
+					return
+				}
+				continue OUTER_LOOP
+			}
+			if ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				partProto, err := part.ToProto()
 				if err != nil {
@@ -638,6 +688,36 @@ OUTER_LOOP:
 	}
 }
 
+// sendParityPart sends the parity part of the proposal block at index to the
+// peer.
+func (r *Reactor) sendParityPart(ctx context.Context, rs *cstypes.RoundState, ps *PeerState, index int, parityCh *p2p.Channel) error {
+	parity, err := rs.ProposalBlockParts.ParityParts()
+	if err != nil {
+		return err
+	}
+	partProto, err := parity[index].ToProto()
+	if err != nil {
+		return err
+	}
+
+	header := rs.ProposalBlockParts.Header()
+	r.logger.Debug("sending parity part", "height", rs.Height, "round", rs.Round, "index", index, "peer", ps.peerID)
+	if err := parityCh.Send(ctx, p2p.Envelope{
+		To: ps.peerID,
+		Message: &tmcons.ParityPart{
+			Height:        rs.Height,
+			Round:         rs.Round,
+			PartSetHeader: header.ToProto(),
+			Part:          *partProto,
+		},
+	}); err != nil {
+		return err
+	}
+
+	ps.SetHasParityPart(rs.Height, rs.Round, header, index)
+	return nil
+}
+
 // pickSendVote picks a vote and sends it to the peer. It will return true if
 // there is a vote to send and false otherwise.
 func (r *Reactor) pickSendVote(ctx context.Context, ps *PeerState, votes types.VoteSetReader, voteCh *p2p.Channel) (bool, error) {
@@ -787,7 +867,7 @@ func (r *Reactor) gossipVotesRoutine(ctx context.Context, ps *PeerState, voteCh
 			// signatures for prs.Height.
 			var ec *types.ExtendedCommit
 			r.state.mtx.RLock()
-			if r.state.state.ConsensusParams.ABCI.VoteExtensionsEnabled(prs.Height) {
+			if r.state.state.ConsensusParams.ExtendedCommitsRequired(prs.Height) {
 				ec = r.state.blockStore.LoadBlockExtendedCommit(prs.Height)
 			} else {
 				ec = r.state.blockStore.LoadBlockCommit(prs.Height).WrappedExtendedCommit()
@@ -851,14 +931,11 @@ func (r *Reactor) queryMaj23Routine(ctx context.Context, ps *PeerState, stateCh
 
 				// maybe send Height/Round/Prevotes
 				if maj23, ok := rs.Votes.Prevotes(prs.Round).TwoThirdsMajority(); ok {
-					if err := stateCh.Send(ctx, p2p.Envelope{
-						To: ps.peerID,
-						Message: &tmcons.VoteSetMaj23{
-							Height:  prs.Height,
-							Round:   prs.Round,
-							Type:    tmproto.PrevoteType,
-							BlockID: maj23.ToProto(),
-						},
+					if err := r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{
+						Height:  prs.Height,
+						Round:   prs.Round,
+						Type:    tmproto.PrevoteType,
+						BlockID: maj23.ToProto(),
 					}); err != nil {
 						cancel()
 					}
@@ -872,14 +949,11 @@ func (r *Reactor) queryMaj23Routine(ctx context.Context, ps *PeerState, stateCh
 
 					// maybe send Height/Round/ProposalPOL
 					if maj23, ok := rs.Votes.Prevotes(prs.ProposalPOLRound).TwoThirdsMajority(); ok {
-						if err := stateCh.Send(ctx, p2p.Envelope{
-							To: ps.peerID,
-							Message: &tmcons.VoteSetMaj23{
-								Height:  prs.Height,
-								Round:   prs.ProposalPOLRound,
-								Type:    tmproto.PrevoteType,
-								BlockID: maj23.ToProto(),
-							},
+						if err := r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{
+							Height:  prs.Height,
+							Round:   prs.ProposalPOLRound,
+							Type:    tmproto.PrevoteType,
+							BlockID: maj23.ToProto(),
 						}); err != nil {
 							cancel()
 						}
@@ -893,14 +967,11 @@ func (r *Reactor) queryMaj23Routine(ctx context.Context, ps *PeerState, stateCh
 
 				// maybe send Height/Round/Precommits
 				if maj23, ok := rs.Votes.Precommits(prs.Round).TwoThirdsMajority(); ok {
-					if err := stateCh.Send(ctx, p2p.Envelope{
-						To: ps.peerID,
-						Message: &tmcons.VoteSetMaj23{
-							Height:  prs.Height,
-							Round:   prs.Round,
-							Type:    tmproto.PrecommitType,
-							BlockID: maj23.ToProto(),
-						},
+					if err := r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{
+						Height:  prs.Height,
+						Round:   prs.Round,
+						Type:    tmproto.PrecommitType,
+						BlockID: maj23.ToProto(),
 					}); err != nil {
 						cancel()
 					}
@@ -918,14 +989,11 @@ func (r *Reactor) queryMaj23Routine(ctx context.Context, ps *PeerState, stateCh
 				if prs.Height <= r.state.blockStore.Height() && prs.Height >= r.state.blockStore.Base() {
 					// maybe send Height/CatchupCommitRound/CatchupCommit
 					if commit := r.state.LoadCommit(prs.Height); commit != nil {
-						if err := stateCh.Send(ctx, p2p.Envelope{
-							To: ps.peerID,
-							Message: &tmcons.VoteSetMaj23{
-								Height:  prs.Height,
-								Round:   commit.Round,
-								Type:    tmproto.PrecommitType,
-								BlockID: commit.BlockID.ToProto(),
-							},
+						if err := r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{
+							Height:  prs.Height,
+							Round:   commit.Round,
+							Type:    tmproto.PrecommitType,
+							BlockID: commit.BlockID.ToProto(),
 						}); err != nil {
 							cancel()
 						}
@@ -971,6 +1039,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 			ps = NewPeerState(r.logger, peerUpdate.NodeID)
 			r.peers[peerUpdate.NodeID] = ps
 		}
+		ps.SetSupportsParityParts(chans.parity != nil && peerUpdate.Channels.Contains(ParityChannel))
 
 		if !ps.IsRunning() {
 			// Set the peer state's closer to signal to all spawned goroutines to exit
@@ -992,7 +1061,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 					return
 				}
 				// start goroutines for this peer
-				go r.gossipDataRoutine(ctx, ps, chans.data)
+				go r.gossipDataRoutine(ctx, ps, chans.data, chans.parity)
 				go r.gossipVotesRoutine(ctx, ps, chans.vote)
 				go r.queryMaj23Routine(ctx, ps, chans.state)
 
@@ -1143,6 +1212,15 @@ func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope,
 	case *tmcons.BlockPart:
 		bpMsg := msgI.(*BlockPartMessage)
 
+		// All parts but the last must be exactly the part size set by the
+		// consensus params for the height.
+		part := bpMsg.Part
+		if size, ok := r.state.blockPartSizeAt(bpMsg.Height); ok &&
+			int64(part.Index) < part.Proof.Total-1 && len(part.Bytes) != int(size) {
+			return fmt.Errorf("block part %d at height %d has %d bytes, expected %d",
+				part.Index, bpMsg.Height, len(part.Bytes), size)
+		}
+
 		ps.SetHasProposalBlockPart(bpMsg.Height, bpMsg.Round, int(bpMsg.Part.Index))
 		r.Metrics.BlockParts.With("peer_id", string(envelope.From)).Add(1)
 		select {
@@ -1159,6 +1237,77 @@ func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope,
 	return nil
 }
 
+// handleParityMessage handles envelopes sent from peers on the ParityChannel.
+// The parity parts of the current proposal block are collected until they are
+// enough to reconstruct its missing parts, which are then processed like the
+// block parts received from the peer that sent the last parity part. The peers
+// that sent parity parts not matching the reconstructed block are reported on
+// parityCh. If we fail to find the peer state for the envelope sender, we
+// perform a no-op and return.
+func (r *Reactor) handleParityMessage(ctx context.Context, envelope *p2p.Envelope, msgI Message, parityCh *p2p.Channel) error {
+	logger := r.logger.With("peer", envelope.From, "ch_id", "ParityChannel")
+
+	ps, ok := r.GetPeerState(envelope.From)
+	if !ok || ps == nil {
+		r.logger.Debug("failed to find peer state")
+		return nil
+	}
+
+	if r.WaitSync() {
+		logger.Debug("ignoring message received during sync", "msg", fmt.Sprintf("%T", msgI))
+		return nil
+	}
+
+	switch msg := envelope.Message.(type) {
+	case *tmcons.ParityPart:
+		ppMsg := msgI.(*ParityPartMessage)
+		ps.SetHasParityPart(ppMsg.Height, ppMsg.Round, ppMsg.PartSetHeader, int(ppMsg.Part.Index))
+
+		r.state.mtx.RLock()
+		height, partSet := r.state.roundState.Height(), r.state.roundState.ProposalBlockParts()
+		r.state.mtx.RUnlock()
+
+		// Like block parts, parity parts can't be used before the proposal.
+		if height != ppMsg.Height || !partSet.HasHeader(ppMsg.PartSetHeader) {
+			return nil
+		}
+
+		parts, faulty, err := r.parity.add(partSet, ppMsg.Part, envelope.From)
+		if err != nil {
+			// The faulty parity parts can't be told apart yet, so the
+			// reconstruction is retried as more parity parts are received.
+			logger.Debug("failed to reconstruct block parts from parity parts",
+				"height", ppMsg.Height, "round", ppMsg.Round, "err", err)
+			return nil
+		}
+		for _, peerID := range faulty {
+			if err := parityCh.SendError(ctx, p2p.PeerError{
+				NodeID: peerID,
+				Err:    fmt.Errorf("invalid parity part for block %v", ppMsg.PartSetHeader.Hash),
+			}); err != nil {
+				return err
+			}
+		}
+		if len(parts) > 0 {
+			logger.Debug("reconstructed block parts from parity parts",
+				"height", ppMsg.Height, "round", ppMsg.Round, "parts", len(parts))
+			r.Metrics.ReconstructedBlockParts.Add(float64(len(parts)))
+		}
+		for _, part := range parts {
+			select {
+			case r.state.peerMsgQueue <- msgInfo{&BlockPartMessage{ppMsg.Height, ppMsg.Round, part}, envelope.From, tmtime.Now()}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+	default:
+		return fmt.Errorf("received unknown message on ParityChannel: %T", msg)
+	}
+
+	return nil
+}
+
 // handleVoteMessage handles envelopes sent from peers on the VoteChannel. If we
 // fail to find the peer state for the envelope sender, we perform a no-op and
 // return. This can happen when we process the envelope after the peer is
@@ -1179,11 +1328,21 @@ func (r *Reactor) handleVoteMessage(ctx context.Context, envelope *p2p.Envelope,
 
 	switch msg := envelope.Message.(type) {
 	case *tmcons.Vote:
+		vMsg := msgI.(*VoteMessage)
+
 		r.state.mtx.RLock()
 		height, valSize, lastCommitSize := r.state.roundState.Height(), r.state.roundState.Validators().Size(), r.state.roundState.LastCommit().Size()
+		extensionsEnabled := r.state.state.ConsensusParams.ABCI.VoteExtensionsEnabled(vMsg.Vote.Height)
 		r.state.mtx.RUnlock()
 
-		vMsg := msgI.(*VoteMessage)
+		// Non-nil precommits must carry an extension signature, even for an
+		// empty extension, when extensions are enabled. The signature itself
+		// is verified before the vote is counted.
+		if extensionsEnabled {
+			if err := vMsg.Vote.EnsureExtension(); err != nil {
+				return fmt.Errorf("invalid vote %v: %w", vMsg.Vote, err)
+			}
+		}
 
 		ps.EnsureVoteBitArrays(height, valSize)
 		ps.EnsureVoteBitArrays(height-1, lastCommitSize)
@@ -1303,6 +1462,8 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, cha
 		err = r.handleVoteMessage(ctx, envelope, msgI)
 	case VoteSetBitsChannel:
 		err = r.handleVoteSetBitsMessage(ctx, envelope, msgI)
+	case ParityChannel:
+		err = r.handleParityMessage(ctx, envelope, msgI, chans.parity)
 	default:
 		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", envelope.ChannelID, envelope)
 	}
@@ -1352,6 +1513,27 @@ func (r *Reactor) processDataCh(ctx context.Context, chans channelBundle) {
 	}
 }
 
+// processParityCh initiates a blocking process where we listen for and handle
+// envelopes on the ParityChannel. Any error encountered during message
+// execution will result in a PeerError being sent on the ParityChannel. When
+// the reactor is stopped, we will catch the signal and close the p2p Channel
+// gracefully.
+func (r *Reactor) processParityCh(ctx context.Context, chans channelBundle) {
+	iter := chans.parity.Receive(ctx)
+	for iter.Next(ctx) {
+		envelope := iter.Envelope()
+		if err := r.handleMessage(ctx, envelope, chans); err != nil {
+			r.logger.Error("failed to process message", "ch_id", envelope.ChannelID, "envelope", envelope, "err", err)
+			if serr := chans.parity.SendError(ctx, p2p.PeerError{
+				NodeID: envelope.From,
+				Err:    err,
+			}); serr != nil {
+				return
+			}
+		}
+	}
+}
+
 // processVoteCh initiates a blocking process where we listen for and handle
 // envelopes on the VoteChannel. Any error encountered during message
 // execution will result in a PeerError being sent on the VoteChannel. When
@@ -1451,6 +1633,77 @@ func (r *Reactor) peerStatsRoutine(ctx context.Context, peerUpdates *p2p.PeerUpd
 	}
 }
 
+// sendVoteSetMaj23 sends a VoteSetMaj23 query to the peer, and records it as
+// unanswered until the peer responds with a VoteSetBits.
+func (r *Reactor) sendVoteSetMaj23(ctx context.Context, ps *PeerState, stateCh *p2p.Channel, msg *tmcons.VoteSetMaj23) error {
+	if err := stateCh.Send(ctx, p2p.Envelope{
+		To:      ps.peerID,
+		Message: msg,
+	}); err != nil {
+		return err
+	}
+	// the responses are ignored while we're syncing
+	if !r.WaitSync() {
+		ps.SetQueried(time.Now())
+	}
+	return nil
+}
+
+// unresponsivePeersRoutine disconnects the peers that leave the VoteSetMaj23
+// queries sent to them unanswered for longer than the UnresponsivePeerTimeout.
+// The peers are evicted without being reported as bad, so they aren't banned
+// and can reconnect.
+func (r *Reactor) unresponsivePeersRoutine(ctx context.Context) {
+	timeout := r.state.config.UnresponsivePeerTimeout
+	if timeout == 0 || r.peerEvictor == nil {
+		return
+	}
+
+	ticker := time.NewTicker(unresponsivePeerCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, peerID := range r.unresponsivePeers(now, timeout) {
+				r.logger.Info("evicting unresponsive peer", "peer", peerID, "timeout", timeout)
+				r.Metrics.UnresponsivePeersEvicted.Add(1)
+				r.peerEvictor.Errored(peerID, errPeerUnresponsive)
+			}
+		}
+	}
+}
+
+// unresponsivePeers returns the peers that have left a VoteSetMaj23 query
+// unanswered for longer than timeout.
+func (r *Reactor) unresponsivePeers(now time.Time, timeout time.Duration) []types.NodeID {
+	if r.WaitSync() {
+		return nil
+	}
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var (
+		pending      int
+		unresponsive []types.NodeID
+	)
+	for peerID, ps := range r.peers {
+		d := ps.UnansweredFor(now)
+		if d > 0 {
+			pending++
+		}
+		if d > timeout {
+			unresponsive = append(unresponsive, peerID)
+		}
+	}
+	r.Metrics.PeersWithUnansweredQueries.Set(float64(pending))
+
+	return unresponsive
+}
+
 func (r *Reactor) GetConsensusState() *State {
 	return r.state
 }
@@ -94,6 +94,7 @@ func setup(
 			state.logger.With("node", nodeID),
 			state,
 			func(ctx context.Context) *p2p.PeerUpdates { return node.MakePeerUpdates(ctx, t) },
+			node.PeerManager,
 			state.eventBus,
 			true,
 			NopMetrics(),
@@ -694,6 +695,7 @@ func TestSwitchToConsensusVoteExtensions(t *testing.T) {
 				log.NewNopLogger(),
 				cs,
 				nil,
+				nil,
 				cs.eventBus,
 				true,
 				NopMetrics(),
@@ -1029,3 +1031,33 @@ func TestReactorValidatorSetChanges(t *testing.T) {
 		)
 	}
 }
+
+func TestReactorUnresponsivePeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &Reactor{
+		readySignal: make(chan struct{}),
+		Metrics:     NopMetrics(),
+		peers: map[types.NodeID]*PeerState{
+			"answering":   NewPeerState(log.NewNopLogger(), "answering"),
+			"unanswering": NewPeerState(log.NewNopLogger(), "unanswering"),
+		},
+	}
+	stateCh := p2p.NewChannel(StateChannel, nil, make(chan p2p.Envelope, 3), nil)
+	for _, ps := range r.peers {
+		require.NoError(t, r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{}))
+	}
+	r.peers["answering"].ApplyVoteSetBitsMessage(&VoteSetBitsMessage{}, nil)
+
+	now := time.Now()
+	require.Empty(t, r.unresponsivePeers(now, time.Minute))
+	require.Equal(t, []types.NodeID{"unanswering"}, r.unresponsivePeers(now.Add(2*time.Minute), time.Minute))
+
+	// queries sent while we're syncing aren't tracked
+	r.waitSync = true
+	require.Empty(t, r.unresponsivePeers(now.Add(time.Hour), time.Minute))
+	ps := NewPeerState(log.NewNopLogger(), "syncing")
+	require.NoError(t, r.sendVoteSetMaj23(ctx, ps, stateCh, &tmcons.VoteSetMaj23{}))
+	require.Zero(t, ps.UnansweredFor(now.Add(time.Hour)))
+}
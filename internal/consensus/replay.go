@@ -210,9 +210,20 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	allowAppVersionUpgrade bool
+
 	nBlocks int // number of blocks applied to the state
 }
 
+// HandshakerOption sets an optional parameter on the Handshaker.
+type HandshakerOption func(*Handshaker)
+
+// HandshakerAllowAppVersionUpgrade lets the handshake adopt the version the app
+// reports when it doesn't match the version of the state, instead of failing.
+func HandshakerAllowAppVersionUpgrade(allow bool) HandshakerOption {
+	return func(h *Handshaker) { h.allowAppVersionUpgrade = allow }
+}
+
 func NewHandshaker(
 	logger log.Logger,
 	stateStore sm.Store,
@@ -220,8 +231,9 @@ func NewHandshaker(
 	store sm.BlockStore,
 	eventBus *eventbus.EventBus,
 	genDoc *types.GenesisDoc,
+	options ...HandshakerOption,
 ) *Handshaker {
-	return &Handshaker{
+	h := &Handshaker{
 		stateStore:   stateStore,
 		initialState: state,
 		store:        store,
@@ -229,6 +241,10 @@ func NewHandshaker(
 		genDoc:       genDoc,
 		logger:       logger,
 	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
 }
 
 // NBlocks returns the number of blocks applied to the state.
@@ -238,6 +254,9 @@ func (h *Handshaker) NBlocks() int {
 
 // TODO: retry the handshake/replay if it fails ?
 func (h *Handshaker) Handshake(ctx context.Context, appClient abciclient.Client) error {
+	if err := h.checkMerkleHashFunction(); err != nil {
+		return err
+	}
 
 	// Handshake is done via ABCI Info on the query conn.
 	res, err := appClient.Info(ctx, &proxy.RequestInfo)
@@ -262,6 +281,8 @@ func (h *Handshaker) Handshake(ctx context.Context, appClient abciclient.Client)
 	// Only set the version if there is no existing state.
 	if h.initialState.LastBlockHeight == 0 {
 		h.initialState.Version.Consensus.App = res.AppVersion
+	} else if err := h.checkAppVersion(res.AppVersion, blockHeight); err != nil {
+		return err
 	}
 
 	// Replay blocks up to the latest in the blockstore.
@@ -278,6 +299,46 @@ func (h *Handshaker) Handshake(ctx context.Context, appClient abciclient.Client)
 	return nil
 }
 
+// checkMerkleHashFunction verifies that the Merkle hash function in use is the
+// one of the genesis doc, and that the latest block still hashes to its block
+// ID with it, which it doesn't if the genesis doc's was changed.
+func (h *Handshaker) checkMerkleHashFunction() error {
+	hashFunction := merkle.GetHashFunction()
+	if want := h.genDoc.MerkleHashFunction; want != hashFunction && (want != "" || hashFunction != merkle.HashFunctionSHA256) {
+		return fmt.Errorf("the merkle hash function in use is %s, but the genesis doc sets %q", hashFunction, want)
+	}
+
+	if h.store.Height() == 0 {
+		return nil
+	}
+	meta := h.store.LoadBlockMeta(h.store.Height())
+	if meta != nil && !bytes.Equal(meta.Header.Hash(), meta.BlockID.Hash) {
+		return fmt.Errorf("the block at height %d does not hash to its block ID %v with the %s merkle hash function, "+
+			"which can't be changed once the chain started", meta.Header.Height, meta.BlockID.Hash, hashFunction)
+	}
+	return nil
+}
+
+// checkAppVersion verifies that the version reported by an app at the height of
+// the state matches the app version of the consensus params. If the app is
+// behind, its version may legitimately be older, and is left to the replay. A
+// zero app version in the params, the default, means that the chain doesn't
+// track it, and isn't checked. With allowAppVersionUpgrade set, a mismatch is
+// only logged, and the state is left as is.
+func (h *Handshaker) checkAppVersion(appVersion uint64, appBlockHeight int64) error {
+	paramsVersion := h.initialState.ConsensusParams.Version.AppVersion
+	if paramsVersion == 0 || appVersion == paramsVersion || appBlockHeight != h.initialState.LastBlockHeight {
+		return nil
+	}
+	if !h.allowAppVersionUpgrade {
+		return sm.ErrAppVersionMismatch{ParamsVersion: paramsVersion, AppVersion: appVersion}
+	}
+
+	h.logger.Error("App version does not match the consensus params, allowed by allow-app-version-upgrade",
+		"params_version", paramsVersion, "app_version", appVersion, "height", appBlockHeight)
+	return nil
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/proxy"
 	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
@@ -320,6 +321,9 @@ func newConsensusStateForReplay(
 	if err != nil {
 		return nil, err
 	}
+	if err := merkle.SetHashFunction(gdoc.MerkleHashFunction); err != nil {
+		return nil, err
+	}
 
 	state, err := sm.MakeGenesisState(gdoc)
 	if err != nil {
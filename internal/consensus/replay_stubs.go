@@ -33,9 +33,11 @@ func (emptyMempool) Size() int                 { return 0 }
 func (emptyMempool) CheckTx(context.Context, types.Tx, func(*abci.ResponseCheckTx), mempool.TxInfo) error {
 	return nil
 }
-func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
-func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
-func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error    { return nil }
+func (emptyMempool) RemoveTxByHash(types.TxKey, bool) error   { return nil }
+func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs  { return types.Txs{} }
+func (emptyMempool) ReapMaxTxs(n int) types.Txs               { return types.Txs{} }
+func (emptyMempool) ReapMaxTxEntries(n int) []mempool.TxEntry { return nil }
 func (emptyMempool) Update(
 	_ context.Context,
 	_ int64,
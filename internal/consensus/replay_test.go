@@ -24,6 +24,7 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/proxy"
@@ -1037,6 +1038,114 @@ func (app *badApp) FinalizeBlock(_ context.Context, _ *abci.RequestFinalizeBlock
 	panic("either allHashesAreWrong or onlyLastHashIsWrong must be set")
 }
 
+func TestHandshakeAppVersionMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := ResetConfig(t.TempDir(), "handshake_test_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(cfg.RootDir) })
+	privVal, err := privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	const appVersion = 0x1
+	pubKey, err := privVal.GetPubKey(ctx)
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(t, cfg, pubKey, appVersion)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	state.LastValidators = state.Validators.Copy()
+	store.chain = sf.MakeBlocks(ctx, t, 3, &state, privVal)
+
+	logger := log.NewNopLogger()
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	// The app is at the height of the state, with an upgraded version.
+	app := &versionApp{height: state.LastBlockHeight, appHash: state.AppHash, version: appVersion + 1}
+	client := abciclient.NewLocalClient(logger, app)
+	proxyApp := proxy.New(client, logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+	t.Cleanup(func() { cancel(); proxyApp.Wait() })
+
+	h := NewHandshaker(logger, stateStore, state, store, eventBus, genDoc)
+	err = h.Handshake(ctx, proxyApp)
+	var mismatch sm.ErrAppVersionMismatch
+	require.True(t, errors.As(err, &mismatch), "unexpected error: %v", err)
+	assert.EqualValues(t, appVersion, mismatch.ParamsVersion)
+	assert.EqualValues(t, appVersion+1, mismatch.AppVersion)
+
+	// With the override, the mismatch is let through, and the stored state is
+	// left unchanged.
+	h = NewHandshaker(logger, stateStore, state, store, eventBus, genDoc,
+		HandshakerAllowAppVersionUpgrade(true))
+	require.NoError(t, h.Handshake(ctx, proxyApp))
+	saved, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state.Version, saved.Version)
+	assert.Equal(t, state.ConsensusParams, saved.ConsensusParams)
+}
+
+func TestHandshakeMerkleHashFunctionMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := ResetConfig(t.TempDir(), "handshake_test_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(cfg.RootDir) })
+	privVal, err := privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(ctx)
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(t, cfg, pubKey, 0x1)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	state.LastValidators = state.Validators.Copy()
+	store.chain = sf.MakeBlocks(ctx, t, 3, &state, privVal)
+	logger := log.NewNopLogger()
+
+	// The genesis doc sets another hash function than the one in use.
+	genDoc.MerkleHashFunction = merkle.HashFunctionBlake2b
+	h := NewHandshaker(logger, stateStore, state, store, nil, genDoc)
+	require.Error(t, h.checkMerkleHashFunction())
+
+	genDoc.MerkleHashFunction = ""
+	h = NewHandshaker(logger, stateStore, state, store, nil, genDoc)
+	require.NoError(t, h.checkMerkleHashFunction())
+
+	// The blocks were hashed with another hash function than the genesis
+	// doc's.
+	meta := *store.LoadBlockMeta(store.Height())
+	meta.BlockID.Hash = tmrand.Bytes(crypto.HashSize)
+	h = NewHandshaker(logger, stateStore, state, fixedMetaBlockStore{store, &meta}, nil, genDoc)
+	require.Error(t, h.checkMerkleHashFunction())
+}
+
+// fixedMetaBlockStore returns a fixed meta for all heights.
+type fixedMetaBlockStore struct {
+	*mockBlockStore
+	meta *types.BlockMeta
+}
+
+func (bs fixedMetaBlockStore) LoadBlockMeta(int64) *types.BlockMeta { return bs.meta }
+
+// versionApp reports a fixed height, app hash and version.
+type versionApp struct {
+	abci.BaseApplication
+	height  int64
+	appHash []byte
+	version uint64
+}
+
+func (app *versionApp) Info(_ context.Context, _ *abci.RequestInfo) (*abci.ResponseInfo, error) {
+	return &abci.ResponseInfo{
+		LastBlockHeight:  app.height,
+		LastBlockAppHash: app.appHash,
+		AppVersion:       app.version,
+	}, nil
+}
+
 //--------------------------
 // utils for making blocks
 
@@ -1162,6 +1271,7 @@ func stateAndStore(
 	state, err := sm.MakeGenesisStateFromFile(cfg.GenesisFile())
 	require.NoError(t, err)
 	state.Version.Consensus.App = appVersion
+	state.ConsensusParams.Version.AppVersion = appVersion
 	store := newMockBlockStore(t, cfg, state.ConsensusParams)
 	require.NoError(t, stateStore.Save(state))
 
@@ -1212,6 +1322,8 @@ func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 func (bs *mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
 func (bs *mockBlockStore) SaveBlockWithExtendedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit) {
 }
+func (bs *mockBlockStore) SaveBlockWithAggregatedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit) {
+}
 func (bs *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
 
@@ -7,11 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"runtime/debug"
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -145,9 +145,22 @@ type State struct {
 	mtx        sync.RWMutex
 	roundState cstypes.SafeRoundState
 	state      sm.State // State until height-1.
+	// size of the parts of the block being decided, read by the reactor
+	// without taking mtx
+	blockPartSize atomic.Pointer[heightPartSize]
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
+	// paused is set while the node doesn't sign votes and proposals
+	paused bool
+	// halted is set once the block at the halt height is committed, and
+	// haltCh is closed then
+	halted bool
+	haltCh chan struct{}
+	// number of consecutive empty blocks up to emptyBlocksHeight, see
+	// emptyBlocksCapped
+	emptyBlocks       int64
+	emptyBlocksHeight int64
 
 	// state changes may be triggered by: msgs from peers,
 	// msgs from ourself, or by timeouts
@@ -172,6 +185,10 @@ type State struct {
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
+	// stepTrigger records what woke up the state machine (a timeout, a message
+	// or available txs) and is published with every step transition.
+	stepTrigger string
+
 	// some functions can be overwritten for testing
 	decideProposal func(ctx context.Context, height int64, round int32)
 	doPrevote      func(ctx context.Context, height int64, round int32)
@@ -228,6 +245,7 @@ func NewState(
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(logger),
 		statsMsgQueue:    make(chan msgInfo, msgQueueSize),
+		haltCh:           make(chan struct{}),
 		doWALCatchup:     true,
 		wal:              nilWAL{},
 		evpool:           evpool,
@@ -339,6 +357,68 @@ func (cs *State) GetValidators() (int64, []*types.Validator) {
 	return cs.state.LastBlockHeight, cs.state.Validators.Copy().Validators
 }
 
+// Pause stops the node from signing votes and proposals. The node keeps
+// following consensus, gossiping and committing blocks. Once Pause returns, no
+// vote or proposal is signed until Resume is called.
+func (cs *State) Pause() {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	if !cs.paused {
+		cs.paused = true
+		cs.logger.Info("paused consensus participation", "height", cs.roundState.Height())
+	}
+}
+
+// Resume resumes signing votes and proposals after Pause. The priv validator
+// still refuses to sign votes conflicting with the ones signed before.
+func (cs *State) Resume() {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	if cs.paused {
+		cs.paused = false
+		cs.logger.Info("resumed consensus participation", "height", cs.roundState.Height())
+	}
+}
+
+// IsPaused returns true if consensus participation is paused.
+func (cs *State) IsPaused() bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.paused
+}
+
+// Halted returns a channel which is closed once consensus halted at the
+// configured halt height. By then, the block at the halt height is committed
+// and nothing is signed for the next height.
+func (cs *State) Halted() <-chan struct{} {
+	return cs.haltCh
+}
+
+// checkHaltHeight halts consensus if the block at the configured halt height
+// has been committed, and returns true if consensus is halted. Once halted,
+// consensus doesn't process messages and timeouts anymore, so that it doesn't
+// move to, nor sign anything for, the next height.
+// cs.mtx must be held.
+func (cs *State) checkHaltHeight() bool {
+	if cs.halted {
+		return true
+	}
+	haltHeight := cs.config.HaltHeight
+	if haltHeight <= 0 || cs.state.LastBlockHeight < haltHeight {
+		return false
+	}
+
+	cs.halted = true
+	// the node may exit as soon as haltCh is closed
+	if err := cs.wal.FlushAndSync(); err != nil {
+		cs.logger.Error("failed to flush WAL on halt", "err", err)
+	}
+	close(cs.haltCh)
+	cs.logger.Info("reached halt height; halted consensus",
+		"height", cs.state.LastBlockHeight, "halt_height", haltHeight)
+	return true
+}
+
 // SetPrivValidator sets the private validator account for signing votes. It
 // immediately requests pubkey and caches it.
 func (cs *State) SetPrivValidator(ctx context.Context, priv types.PrivValidator) {
@@ -406,6 +486,12 @@ func (cs *State) OnStart(ctx context.Context) error {
 		return err
 	}
 
+	// A node restarted at or past the halt height halts right away, without
+	// replaying the WAL for, or signing anything at, the next height.
+	cs.mtx.Lock()
+	halted := cs.checkHaltHeight()
+	cs.mtx.Unlock()
+
 	// We may set the WAL in testing before calling Start, so only OpenWAL if its
 	// still the nilWAL.
 	if _, ok := cs.wal.(nilWAL); ok {
@@ -425,7 +511,7 @@ func (cs *State) OnStart(ctx context.Context) error {
 
 	// We may have lost some votes if the process crashed reload from consensus
 	// log to catchup.
-	if cs.doWALCatchup {
+	if cs.doWALCatchup && !halted {
 		repairAttempted := false
 
 	LOOP:
@@ -474,8 +560,10 @@ func (cs *State) OnStart(ctx context.Context) error {
 	}
 
 	// Double Signing Risk Reduction
-	if err := cs.checkDoubleSigningRisk(cs.roundState.Height()); err != nil {
-		return err
+	if !halted {
+		if err := cs.checkDoubleSigningRisk(cs.roundState.Height()); err != nil {
+			return err
+		}
 	}
 
 	// now start the receiveRoutine
@@ -483,6 +571,10 @@ func (cs *State) OnStart(ctx context.Context) error {
 	// start heartbeater
 	go cs.heartbeater(ctx)
 
+	if halted {
+		return nil
+	}
+
 	// schedule the first round!
 	// use GetRoundState so we don't race the receiveRoutine for access
 	cs.scheduleRound0(cs.GetRoundState())
@@ -551,6 +643,15 @@ func (cs *State) OpenWAL(ctx context.Context, walFile string) (WAL, error) {
 		cs.logger.Error("failed to open WAL", "file", walFile, "err", err)
 		return nil, err
 	}
+	switch cs.config.WalFlushPolicy {
+	case config.WALFlushEveryMessage:
+		wal.SetFlushMessages(1)
+	case config.WALFlushMessages:
+		wal.SetFlushMessages(cs.config.WalFlushMessages)
+	}
+	if cs.config.WalFlushInterval > 0 {
+		wal.SetFlushInterval(cs.config.WalFlushInterval)
+	}
 
 	if err := wal.Start(ctx); err != nil {
 		cs.logger.Error("failed to start WAL", "err", err)
@@ -710,10 +811,10 @@ func (cs *State) sendInternalMessage(ctx context.Context, mi msgInfo) {
 // Reconstruct the LastCommit from either SeenCommit or the ExtendedCommit. SeenCommit
 // and ExtendedCommit are saved along with the block. If VoteExtensions are required
 // the method will panic on an absent ExtendedCommit or an ExtendedCommit without
-// extension data.
+// extension data. The ExtendedCommit is also used if the commit is aggregated in
+// the next block, since it keeps the individual signatures.
 func (cs *State) reconstructLastCommit(state sm.State) {
-	extensionsEnabled := cs.state.ConsensusParams.ABCI.VoteExtensionsEnabled(state.LastBlockHeight)
-	if !extensionsEnabled {
+	if !cs.state.ConsensusParams.ExtendedCommitsRequired(state.LastBlockHeight) {
 		votes, err := cs.votesFromSeenCommit(state)
 		if err != nil {
 			panic(fmt.Sprintf("failed to reconstruct last commit; %s", err))
@@ -734,7 +835,12 @@ func (cs *State) votesFromExtendedCommit(state sm.State) (*types.VoteSet, error)
 	if ec == nil {
 		return nil, fmt.Errorf("extended commit for height %v not found", state.LastBlockHeight)
 	}
-	vs := ec.ToExtendedVoteSet(state.ChainID, state.LastValidators)
+	var vs *types.VoteSet
+	if state.ConsensusParams.ABCI.VoteExtensionsEnabled(state.LastBlockHeight) {
+		vs = ec.ToExtendedVoteSet(state.ChainID, state.LastValidators)
+	} else {
+		vs = ec.ToVoteSet(state.ChainID, state.LastValidators)
+	}
 	if !vs.HasTwoThirdsMajority() {
 		return nil, errors.New("extended commit does not have +2/3 majority")
 	}
@@ -794,7 +900,7 @@ func (cs *State) updateToState(state sm.State) {
 				"new_height", state.LastBlockHeight+1,
 				"old_height", cs.state.LastBlockHeight+1,
 			)
-			cs.newStep()
+			cs.newStep("update-to-state")
 			return
 		}
 	}
@@ -866,12 +972,30 @@ func (cs *State) updateToState(state sm.State) {
 	cs.roundState.SetTriggeredTimeoutPrecommit(false)
 
 	cs.state = state
+	cs.blockPartSize.Store(&heightPartSize{height: height, size: state.ConsensusParams.Block.PartSize()})
 
 	// Finally, broadcast RoundState
-	cs.newStep()
+	cs.newStep("new-height")
 }
 
-func (cs *State) newStep() {
+type heightPartSize struct {
+	height int64
+	size   uint32
+}
+
+// blockPartSizeAt returns the size of the parts of the block at height, and
+// false if it isn't known, i.e. if height isn't the height being decided.
+func (cs *State) blockPartSizeAt(height int64) (uint32, bool) {
+	ps := cs.blockPartSize.Load()
+	if ps == nil || ps.height != height {
+		return 0, false
+	}
+	return ps.size, true
+}
+
+// newStep records and publishes the current round step. The reason is the
+// label of the transition that led to it.
+func (cs *State) newStep(reason string) {
 	rs := cs.roundState.RoundStateEvent()
 	if err := cs.wal.Write(rs); err != nil {
 		cs.logger.Error("failed writing to WAL", "err", err)
@@ -887,6 +1011,20 @@ func (cs *State) newStep() {
 
 		roundState := cs.roundState.CopyInternal()
 		cs.evsw.FireEvent(types.EventNewRoundStepValue, roundState)
+
+		cs.publishStepTransition(rs, reason)
+	}
+}
+
+func (cs *State) publishStepTransition(rs types.EventDataRoundState, reason string) {
+	if err := cs.eventBus.PublishEventStepTransition(types.EventDataStepTransition{
+		Height:  rs.Height,
+		Round:   rs.Round,
+		Step:    rs.Step,
+		Trigger: cs.stepTrigger,
+		Reason:  reason,
+	}); err != nil {
+		cs.logger.Error("failed publishing step transition", "err", err)
 	}
 }
 
@@ -1038,6 +1176,11 @@ func (cs *State) gossipTransactionKeyOnly() bool {
 func (cs *State) handleMsg(ctx context.Context, mi msgInfo, fsyncUponCompletion bool) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	if cs.halted {
+		return
+	}
+	cs.stepTrigger = types.StepTriggerMessage
+
 	var (
 		added bool
 		err   error
@@ -1179,6 +1322,10 @@ func (cs *State) handleTimeout(
 	// the timeout will now cause a state transition
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	if cs.halted {
+		return
+	}
+	cs.stepTrigger = types.StepTriggerTimeout
 
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
@@ -1221,9 +1368,10 @@ func (cs *State) handleTimeout(
 func (cs *State) handleTxsAvailable(ctx context.Context) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	cs.stepTrigger = types.StepTriggerTxsAvailable
 
 	// We only need to do this for round 0.
-	if cs.roundState.Round() != 0 {
+	if cs.halted || cs.roundState.Round() != 0 {
 		return
 	}
 
@@ -1264,6 +1412,11 @@ func (cs *State) getTracingCtx(defaultCtx context.Context) context.Context {
 // Enter: +2/3 prevotes any or +2/3 precommits for block or any from (height, round)
 // NOTE: cs.StartTime was already set for height.
 func (cs *State) enterNewRound(ctx context.Context, height int64, round int32, entryLabel string) {
+	// a vote committing the block at the halt height may try to move on right away
+	if cs.halted {
+		return
+	}
+
 	if height > cs.heightBeingTraced {
 		if cs.heightSpan != nil {
 			cs.heightSpan.End()
@@ -1334,16 +1487,20 @@ func (cs *State) enterNewRound(ctx context.Context, height int64, round int32, e
 	if err := cs.eventBus.PublishEventNewRound(cs.roundState.NewRoundEvent()); err != nil {
 		cs.logger.Error("failed publishing new round", "err", err)
 	}
+	cs.publishStepTransition(cs.roundState.RoundStateEvent(), entryLabel)
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
-	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
+	// Empty blocks are not created once MaxConsecutiveEmptyBlocks is reached.
+	if cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height) {
+		emptyBlocksCapped := cs.emptyBlocksCapped(height)
+		if emptyBlocksCapped || !cs.config.CreateEmptyBlocks || cs.config.CreateEmptyBlocksInterval > 0 {
+			if cs.config.CreateEmptyBlocksInterval > 0 && !emptyBlocksCapped {
+				cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+					cstypes.RoundStepNewRound)
+			}
+			return
 		}
-		return
 	}
 
 	span.End()
@@ -1367,6 +1524,31 @@ func (cs *State) needProofBlock(height int64) bool {
 	return !bytes.Equal(cs.state.AppHash, lastBlockMeta.Header.AppHash)
 }
 
+// emptyBlocksCapped returns true if the MaxConsecutiveEmptyBlocks blocks
+// before height are all empty.
+//
+// The count of consecutive empty blocks is kept up to date by finalizeCommit.
+// It is only loaded from the block store when the blocks before height
+// weren't committed by consensus, e.g. after a restart or block sync.
+func (cs *State) emptyBlocksCapped(height int64) bool {
+	limit := cs.config.MaxConsecutiveEmptyBlocks
+	if limit <= 0 {
+		return false
+	}
+	if cs.emptyBlocksHeight != height-1 {
+		cs.emptyBlocks = 0
+		for h := height - 1; h >= cs.state.InitialHeight && cs.emptyBlocks < limit; h-- {
+			blockMeta := cs.blockStore.LoadBlockMeta(h)
+			if blockMeta == nil || blockMeta.NumTxs > 0 {
+				break
+			}
+			cs.emptyBlocks++
+		}
+		cs.emptyBlocksHeight = height - 1
+	}
+	return cs.emptyBlocks >= limit
+}
+
 // Enter (CreateEmptyBlocks): from enterNewRound(height,round)
 // Enter (CreateEmptyBlocks, CreateEmptyBlocksInterval > 0 ):
 //
@@ -1404,7 +1586,7 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32, en
 	defer func() {
 		// Done enterPropose:
 		cs.updateRoundStep(round, cstypes.RoundStepPropose)
-		cs.newStep()
+		cs.newStep(entryLabel)
 
 		// If we have the whole proposal + POL, then goto Prevote now.
 		// else, we'll enterPrevote when the rest of the proposal is received (in AddProposalBlockPart),
@@ -1425,6 +1607,16 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32, en
 		return
 	}
 
+	if cs.paused {
+		logger.Debug("propose step; not proposing since consensus participation is paused")
+		return
+	}
+
+	if cs.halted {
+		logger.Debug("propose step; not proposing since consensus is halted")
+		return
+	}
+
 	if cs.privValidatorPubKey == nil {
 		// If this node is a validator & proposer in the current round, it will
 		// miss the opportunity to create a block.
@@ -1484,17 +1676,19 @@ func (cs *State) defaultDecideProposal(ctx context.Context, height int64, round
 			return
 		}
 		cs.metrics.ProposalCreateCount.Add(1)
-		blockParts, err = block.MakePartSet(types.BlockPartSizeBytes)
+		blockParts, err = block.MakePartSet(cs.state.ConsensusParams.Block.PartSize())
 		if err != nil {
 			cs.logger.Error("unable to create proposal block part set", "error", err)
 			return
 		}
 	}
 
-	// Flush the WAL. Otherwise, we may not recompute the same proposal to sign,
-	// and the privValidator will refuse to sign anything.
+	// Flush the WAL, whatever its flush policy. Otherwise, we may not recompute
+	// the same proposal to sign, and the privValidator will refuse to sign
+	// anything.
 	if err := cs.wal.FlushAndSync(); err != nil {
-		cs.logger.Error("failed flushing WAL to disk")
+		cs.logger.Error("failed flushing WAL to disk, not signing the proposal", "err", err)
+		return
 	}
 
 	// Make proposal
@@ -1610,7 +1804,7 @@ func (cs *State) enterPrevote(ctx context.Context, height int64, round int32, en
 	defer func() {
 		// Done enterPrevote:
 		cs.updateRoundStep(round, cstypes.RoundStepPrevote)
-		cs.newStep()
+		cs.newStep(entryLabel)
 	}()
 
 	logger.Debug("entering prevote step", "current", fmt.Sprintf("%v/%v/%v", cs.roundState.Height(), cs.roundState.Round(), cs.roundState.Step()), "time", time.Now().UnixMilli())
@@ -1622,9 +1816,16 @@ func (cs *State) enterPrevote(ctx context.Context, height int64, round int32, en
 	// (so we have more time to try and collect +2/3 prevotes for a single block)
 }
 
-func (cs *State) proposalIsTimely() bool {
+// synchronyParams returns the synchrony parameters of the state, with the
+// configured clock skew tolerance added to the precision.
+func (cs *State) synchronyParams() types.SynchronyParams {
 	sp := cs.state.ConsensusParams.Synchrony.SynchronyParamsOrDefaults()
-	return cs.roundState.Proposal().IsTimely(cs.roundState.ProposalReceiveTime(), sp, cs.roundState.Round())
+	sp.Precision += cs.config.ClockSkewTolerance
+	return sp
+}
+
+func (cs *State) proposalIsTimely() bool {
+	return cs.roundState.Proposal().IsTimely(cs.roundState.ProposalReceiveTime(), cs.synchronyParams(), cs.roundState.Round())
 }
 
 func (cs *State) defaultDoPrevote(ctx context.Context, height int64, round int32) {
@@ -1687,7 +1888,7 @@ func (cs *State) defaultDoPrevote(ctx context.Context, height int64, round int32
 		return
 	}
 
-	sp := cs.state.ConsensusParams.Synchrony.SynchronyParamsOrDefaults()
+	sp := cs.synchronyParams()
 	if cs.roundState.Proposal().POLRound == -1 && cs.roundState.LockedRound() == -1 && !cs.proposalIsTimely() {
 		logger.Info("prevote step: Proposal is not timely; prevoting nil",
 			"proposed",
@@ -1824,7 +2025,7 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	defer func() {
 		// Done enterPrevoteWait:
 		cs.updateRoundStep(round, cstypes.RoundStepPrevoteWait)
-		cs.newStep()
+		cs.newStep("prevote-two-thirds-any")
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
@@ -1860,7 +2061,7 @@ func (cs *State) enterPrecommit(ctx context.Context, height int64, round int32,
 	defer func() {
 		// Done enterPrecommit:
 		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
-		cs.newStep()
+		cs.newStep(entryLabel)
 	}()
 
 	// check for a polka
@@ -1986,7 +2187,7 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	defer func() {
 		// Done enterPrecommitWait:
 		cs.roundState.SetTriggeredTimeoutPrecommit(true)
-		cs.newStep()
+		cs.newStep("precommit-two-thirds-any")
 	}()
 
 	// wait for some more precommits; enterNewRound
@@ -2019,7 +2220,7 @@ func (cs *State) enterCommit(ctx context.Context, height int64, commitRound int3
 		cs.updateRoundStep(cs.roundState.Round(), cstypes.RoundStepCommit)
 		cs.roundState.SetCommitRound(commitRound)
 		cs.roundState.SetCommitTime(tmtime.Now())
-		cs.newStep()
+		cs.newStep(entryLabel)
 
 		// Maybe finalize immediately.
 		cs.tryFinalizeCommit(spanCtx, height)
@@ -2143,9 +2344,12 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		_, storeBlockSpan := cs.tracer.Start(spanCtx, "cs.state.finalizeCommit.saveblockstore")
 		defer storeBlockSpan.End()
 		seenExtendedCommit := cs.roundState.Votes().Precommits(cs.roundState.CommitRound()).MakeExtendedCommit()
-		if cs.state.ConsensusParams.ABCI.VoteExtensionsEnabled(block.Height) {
+		switch {
+		case cs.state.ConsensusParams.ABCI.VoteExtensionsEnabled(block.Height):
 			cs.blockStore.SaveBlockWithExtendedCommit(block, blockParts, seenExtendedCommit)
-		} else {
+		case cs.state.ConsensusParams.Validator.AggregateCommitsEnabled(block.Height):
+			cs.blockStore.SaveBlockWithAggregatedCommit(block, blockParts, seenExtendedCommit)
+		default:
 			cs.blockStore.SaveBlock(block, blockParts, seenExtendedCommit.ToCommit())
 		}
 		// Calculate consensus time
@@ -2202,6 +2406,15 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 	// must be called before we update state
 	cs.RecordMetrics(height, block)
 
+	if height == cs.emptyBlocksHeight+1 {
+		if len(block.Txs) == 0 {
+			cs.emptyBlocks++
+		} else {
+			cs.emptyBlocks = 0
+		}
+		cs.emptyBlocksHeight = height
+	}
+
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
 
@@ -2210,6 +2423,12 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		logger.Error("failed to get private validator pubkey", "err", err)
 	}
 
+	// Stop at the halt height. The WAL and the state already record the
+	// commit, and round 0 of the next height is never scheduled.
+	if cs.checkHaltHeight() {
+		return
+	}
+
 	// cs.StartTime is already set.
 	// Schedule Round0 to start soon.
 	cs.scheduleRound0(cs.roundState.GetInternalPointer())
@@ -2269,7 +2488,7 @@ func (cs *State) RecordMetrics(height int64, block *types.Block) {
 					"validator_address", val.Address.String(),
 				}
 				cs.metrics.ValidatorPower.With(label...).Set(float64(val.VotingPower))
-				if commitSig.BlockIDFlag == types.BlockIDFlagCommit {
+				if commitSig.ForBlock() {
 					cs.metrics.ValidatorLastSignedHeight.With(label...).Set(float64(height))
 				} else {
 					cs.metrics.ValidatorMissedBlocks.With(label...).Add(float64(1))
@@ -2488,7 +2707,7 @@ func (cs *State) tryCreateProposalBlock(ctx context.Context, height int64, round
 		return false
 	}
 	cs.roundState.SetProposalBlock(block)
-	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+	partSet, err := block.MakePartSet(cs.state.ConsensusParams.Block.PartSize())
 	if err != nil {
 		return false
 	}
@@ -2825,8 +3044,8 @@ func (cs *State) signVote(
 	hash []byte,
 	header types.PartSetHeader,
 ) (*types.Vote, error) {
-	// Flush the WAL. Otherwise, we may not recompute the same vote to sign,
-	// and the privValidator will refuse to sign anything.
+	// Flush the WAL, whatever its flush policy. Otherwise, we may not recompute
+	// the same vote to sign, and the privValidator will refuse to sign anything.
 	if err := cs.wal.FlushAndSync(); err != nil {
 		return nil, err
 	}
@@ -2851,6 +3070,7 @@ func (cs *State) signVote(
 	// If the signedMessageType is for precommit,
 	// use our local precommit Timeout as the max wait time for getting a singed commit. The same goes for prevote.
 	timeout := time.Second
+	extended := false
 	if msgType == tmproto.PrecommitType && !vote.BlockID.IsNil() {
 		timeout = cs.voteTimeout(cs.roundState.Round())
 		// if the signedMessage type is for a non-nil precommit, add
@@ -2861,6 +3081,7 @@ func (cs *State) signVote(
 				return nil, err
 			}
 			vote.Extension = ext
+			extended = true
 		}
 	}
 
@@ -2874,6 +3095,15 @@ func (cs *State) signVote(
 	vote.ExtensionSignature = v.ExtensionSignature
 	vote.Timestamp = v.Timestamp
 
+	// The extension, even if empty, must be signed as well. A signer that
+	// doesn't sign it, e.g. an outdated remote signer, would produce
+	// precommits that are rejected by every peer.
+	if err == nil && extended {
+		if err := vote.VerifyExtension(cs.state.ChainID, cs.privValidatorPubKey); err != nil {
+			return vote, fmt.Errorf("signer did not sign the vote extension: %w", err)
+		}
+	}
+
 	return vote, err
 }
 
@@ -2888,6 +3118,10 @@ func (cs *State) signAddVote(
 		return nil
 	}
 
+	if cs.paused || cs.halted {
+		return nil
+	}
+
 	if cs.privValidatorPubKey == nil {
 		// Vote won't be signed, but it's not critical.
 		cs.logger.Error("signAddVote", "err", errPubKeyIsNotSet)
@@ -2954,7 +3188,7 @@ func (cs *State) checkDoubleSigningRisk(height int64) error {
 			lastCommit := cs.LoadCommit(height - i)
 			if lastCommit != nil {
 				for sigIdx, s := range lastCommit.Signatures {
-					if s.BlockIDFlag == types.BlockIDFlagCommit && bytes.Equal(s.ValidatorAddress, valAddr) {
+					if s.ForBlock() && bytes.Equal(s.ValidatorAddress, valAddr) {
 						cs.logger.Info("found signature from the same key", "sig", s, "idx", sigIdx, "height", height-i)
 						return ErrSignatureFoundInPastBlocks
 					}
@@ -3015,37 +3249,8 @@ func CompareHRS(h1 int64, r1 int32, s1 cstypes.RoundStepType, h2 int64, r2 int32
 // repairWalFile decodes messages from src (until the decoder errors) and
 // writes them to dst.
 func repairWalFile(src, dst string) error {
-	in, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer in.Close()
-
-	out, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	var (
-		dec = NewWALDecoder(in)
-		enc = NewWALEncoder(out)
-	)
-
-	// best-case repair (until first error is encountered)
-	for {
-		msg, err := dec.Decode()
-		if err != nil {
-			break
-		}
-
-		err = enc.Encode(msg)
-		if err != nil {
-			return fmt.Errorf("failed to encode msg: %w", err)
-		}
-	}
-
-	return nil
+	_, err := RepairWAL(src, dst)
+	return err
 }
 
 func (cs *State) proposeTimeout(round int32) time.Duration {
@@ -3075,10 +3280,26 @@ func (cs *State) voteTimeout(round int32) time.Duration {
 	}
 	return time.Duration(
 		v.Nanoseconds()+vd.Nanoseconds()*int64(round),
-	) * time.Nanosecond
+	)*time.Nanosecond + cs.voteTimeoutScaling()
+}
+
+// voteTimeoutScaling returns the time added to the vote timeouts for the size
+// of the current validator set.
+func (cs *State) voteTimeoutScaling() time.Duration {
+	if cs.config.VoteTimeoutPerValidator == 0 || cs.state.Validators == nil {
+		return 0
+	}
+	scaling := cs.config.VoteTimeoutPerValidator * time.Duration(cs.state.Validators.Size())
+	if max := cs.config.MaxVoteTimeoutScaling; max > 0 && scaling > max {
+		return max
+	}
+	return scaling
 }
 
 func (cs *State) commitTime(t time.Time) time.Time {
+	if cs.config.CommitOnThreshold {
+		return t.Add(cs.config.CommitGracePeriod)
+	}
 	c := cs.state.ConsensusParams.Timeout.Commit
 	if cs.config.UnsafeCommitTimeoutOverride != 0 {
 		c = cs.config.UnsafeCommitTimeoutOverride
@@ -3087,6 +3308,11 @@ func (cs *State) commitTime(t time.Time) time.Time {
 }
 
 func (cs *State) bypassCommitTimeout() bool {
+	// The grace period of CommitOnThreshold only waits for the precommits
+	// missing from the commit.
+	if cs.config.CommitOnThreshold {
+		return true
+	}
 	if cs.config.UnsafeBypassCommitTimeoutOverride != nil {
 		return *cs.config.UnsafeBypassCommitTimeoutOverride
 	}
@@ -3095,8 +3321,7 @@ func (cs *State) bypassCommitTimeout() bool {
 
 func (cs *State) calculateProposalTimestampDifferenceMetric() {
 	if cs.roundState.Proposal() != nil && cs.roundState.Proposal().POLRound == -1 {
-		sp := cs.state.ConsensusParams.Synchrony.SynchronyParamsOrDefaults()
-		isTimely := cs.roundState.Proposal().IsTimely(cs.roundState.ProposalReceiveTime(), sp, cs.roundState.Round())
+		isTimely := cs.roundState.Proposal().IsTimely(cs.roundState.ProposalReceiveTime(), cs.synchronyParams(), cs.roundState.Round())
 		cs.metrics.ProposalTimestampDifference.With("is_timely", fmt.Sprintf("%t", isTimely)).
 			Observe(cs.roundState.ProposalReceiveTime().Sub(cs.roundState.Proposal().Timestamp).Seconds())
 	}
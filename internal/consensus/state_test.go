@@ -363,6 +363,56 @@ func TestStateFullRound1(t *testing.T) {
 	validateLastPrecommit(ctx, t, cs, vss[0], propBlock.Hash)
 }
 
+func TestStateStepTransitionEvents(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs, _ := makeState(ctx, t, makeStateArgs{config: config, validators: 1})
+	height, round := cs.roundState.Height(), cs.roundState.Round()
+
+	sub, err := cs.eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: testSubscriber,
+		Query:    types.EventQueryStepTransitionForHeights(height, height),
+		Limit:    20,
+	})
+	require.NoError(t, err)
+
+	startTestRound(ctx, cs, height, round)
+
+	// the NewHeight step may be signaled again when the routines start, so
+	// it is skipped
+	expectedSteps := []cstypes.RoundStepType{
+		cstypes.RoundStepNewRound,
+		cstypes.RoundStepPropose,
+		cstypes.RoundStepPrevote,
+		cstypes.RoundStepPrecommit,
+		cstypes.RoundStepCommit,
+	}
+	for _, step := range expectedSteps {
+		var edt types.EventDataStepTransition
+		for edt.Step == "" || edt.Step == cstypes.RoundStepNewHeight.String() {
+			nextCtx, nextCancel := context.WithTimeout(ctx, ensureTimeout)
+			msg, err := sub.Next(nextCtx)
+			nextCancel()
+			require.NoError(t, err, "waiting for step %v", step)
+
+			var ok bool
+			edt, ok = msg.Data().(types.EventDataStepTransition)
+			require.True(t, ok, "expected a EventDataStepTransition, got %T", msg.Data())
+		}
+		require.Equal(t, height, edt.Height)
+		require.Equal(t, round, edt.Round)
+		require.Equal(t, step.String(), edt.Step)
+
+		// the votes of the only validator drive the round past the proposal
+		if step >= cstypes.RoundStepPrevote {
+			require.Equal(t, types.StepTriggerMessage, edt.Trigger)
+			require.NotEmpty(t, edt.Reason)
+		}
+	}
+}
+
 // nil is proposed, so prevote and precommit nil
 func TestStateFullRoundNil(t *testing.T) {
 	config := configSetup(t)
@@ -381,6 +431,50 @@ func TestStateFullRoundNil(t *testing.T) {
 	ensurePrecommitMatch(t, voteCh, height, round, nil) // precommit
 }
 
+func TestStatePauseResume(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs, _ := makeState(ctx, t, makeStateArgs{config: config, validators: 1})
+
+	cs.Pause()
+	require.True(t, cs.IsPaused())
+	assert.Nil(t, cs.signAddVote(ctx, tmproto.PrevoteType, nil, types.PartSetHeader{}))
+
+	cs.Resume()
+	require.False(t, cs.IsPaused())
+	assert.NotNil(t, cs.signAddVote(ctx, tmproto.PrevoteType, nil, types.PartSetHeader{}))
+}
+
+// the block at the halt height is committed, then consensus halts without
+// moving to, or signing anything for, the next height
+func TestStateHaltHeight(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs, _ := makeState(ctx, t, makeStateArgs{config: config, validators: 1})
+	height, round := cs.roundState.Height(), cs.roundState.Round()
+	cs.config.HaltHeight = height
+
+	newRoundCh := subscribe(ctx, t, cs.eventBus, types.EventQueryNewRound)
+
+	startTestRound(ctx, cs, height, round)
+
+	ensureNewRound(t, newRoundCh, height, round)
+
+	select {
+	case <-cs.Halted():
+	case <-time.After(ensureTimeout):
+		t.Fatal("consensus didn't halt at the halt height")
+	}
+	require.Equal(t, height, cs.GetLastHeight())
+
+	ensureNoNewEventOnChannel(t, newRoundCh)
+	assert.Nil(t, cs.signAddVote(ctx, tmproto.PrevoteType, nil, types.PartSetHeader{}))
+}
+
 // run through propose, prevote, precommit commit with two validators
 // where the first validator has to wait for votes from the second
 func TestStateFullRound2(t *testing.T) {
@@ -2298,6 +2392,66 @@ func TestPrepareProposalReceivesVoteExtensions(t *testing.T) {
 	}
 }
 
+// unsignedExtensionPV is a signer that doesn't sign vote extensions.
+type unsignedExtensionPV struct {
+	types.PrivValidator
+}
+
+func (pv unsignedExtensionPV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	if err := pv.PrivValidator.SignVote(ctx, chainID, vote); err != nil {
+		return err
+	}
+	vote.ExtensionSignature = nil
+	return nil
+}
+
+// TestSignVoteExtensionSignature tests that precommits carry an extension
+// signature even when the extension is empty, and that a signer which doesn't
+// sign the extension is rejected.
+func TestSignVoteExtensionSignature(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := abcimocks.NewApplication(t)
+	m.On("ExtendVote", mock.Anything, mock.Anything).Return(&abci.ResponseExtendVote{}, nil)
+	c := factory.ConsensusParams()
+	c.ABCI.VoteExtensionsEnableHeight = 1
+	cs1, _ := makeState(ctx, t, makeStateArgs{config: config, application: m, validators: 1, consensusParams: c})
+	cs1.state.ConsensusParams.ABCI.VoteExtensionsEnableHeight = 1
+	pubKey, err := cs1.privValidator.GetPubKey(ctx)
+	require.NoError(t, err)
+
+	hash := tmrand.Bytes(crypto.HashSize)
+	header := types.PartSetHeader{Total: 1, Hash: hash}
+
+	// an empty extension is signed as well
+	vote, err := cs1.signVote(ctx, tmproto.PrecommitType, hash, header)
+	require.NoError(t, err)
+	require.Empty(t, vote.Extension)
+	require.NotEmpty(t, vote.ExtensionSignature)
+	require.NoError(t, vote.VerifyVoteAndExtension(config.ChainID(), pubKey))
+
+	cs1.privValidator = unsignedExtensionPV{cs1.privValidator}
+	_, err = cs1.signVote(ctx, tmproto.PrecommitType, hash, header)
+	require.Error(t, err)
+}
+
+func TestVoteTimeoutScaling(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs1, _ := makeState(ctx, t, makeStateArgs{config: config, validators: 4})
+	fixed := cs1.voteTimeout(1)
+
+	cs1.config.VoteTimeoutPerValidator = 100 * time.Millisecond
+	require.Equal(t, fixed+400*time.Millisecond, cs1.voteTimeout(1))
+
+	cs1.config.MaxVoteTimeoutScaling = 250 * time.Millisecond
+	require.Equal(t, fixed+250*time.Millisecond, cs1.voteTimeout(1))
+}
+
 // TestVoteExtensionEnableHeight tests that 'ExtensionRequireHeight' correctly
 // enforces that vote extensions be present in consensus for heights greater than
 // or equal to the configured value.
@@ -2794,6 +2948,51 @@ func TestResetTimeoutPrecommitUponNewHeight(t *testing.T) {
 		"triggeredTimeoutPrecommit should be false at the beginning of each height")
 }
 
+// With CommitOnThreshold, the node moves on to the next height as soon as it
+// commits a block with +2/3 precommits, instead of waiting out the commit
+// timeout for the last validator.
+func TestCommitOnThreshold(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs1, vss := makeState(ctx, t, makeStateArgs{config: config})
+	cs1.state.ConsensusParams.Timeout.Commit = time.Minute
+	cs1.state.ConsensusParams.Timeout.BypassCommitTimeout = false
+	cs1.config.CommitOnThreshold = true
+
+	vs2, vs3 := vss[1], vss[2]
+	height, round := cs1.roundState.Height(), cs1.roundState.Round()
+
+	proposalCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryNewRound)
+	newBlockHeader := subscribe(ctx, t, cs1.eventBus, types.EventQueryNewBlockHeader)
+	pv1, err := cs1.privValidator.GetPubKey(ctx)
+	require.NoError(t, err)
+	voteCh := subscribeToVoter(ctx, t, cs1, pv1.Address())
+
+	startTestRound(ctx, cs1, height, round)
+	ensureNewRound(t, newRoundCh, height, round)
+
+	ensureNewProposal(t, proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	blockID := types.BlockID{
+		Hash:          rs.ProposalBlock.Hash(),
+		PartSetHeader: rs.ProposalBlockParts.Header(),
+	}
+
+	ensurePrevoteMatch(t, voteCh, height, round, blockID.Hash)
+	signAddVotes(ctx, t, cs1, tmproto.PrevoteType, config.ChainID(), blockID, vs2, vs3)
+	ensurePrecommit(t, voteCh, height, round)
+
+	// The fourth validator never precommits.
+	signAddVotes(ctx, t, cs1, tmproto.PrecommitType, config.ChainID(), blockID, vs2, vs3)
+
+	ensureNewBlockHeader(t, newBlockHeader, height, blockID.Hash)
+	ensureNewRound(t, newRoundCh, height+1, 0)
+	assert.False(t, cs1.GetRoundState().LastCommit.HasAll())
+}
+
 //------------------------------------------------------------------------------------------
 // CatchupSuite
 
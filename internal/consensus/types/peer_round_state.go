@@ -24,6 +24,8 @@ type PeerRoundState struct {
 	Proposal                   bool                `json:"proposal"`
 	ProposalBlockPartSetHeader types.PartSetHeader `json:"proposal_block_part_set_header"`
 	ProposalBlockParts         *bits.BitArray      `json:"proposal_block_parts"`
+	// Parity parts of the proposal block peer has, nil until one is known.
+	ProposalParityParts *bits.BitArray `json:"proposal_parity_parts"`
 	// Proposal's POL round. -1 if none.
 	ProposalPOLRound int32 `json:"proposal_pol_round"`
 
@@ -62,6 +64,7 @@ func (prs PeerRoundState) Copy() PeerRoundState {
 		Hash:  hashCopy,
 	}
 	prs.ProposalBlockParts = prs.ProposalBlockParts.Copy()
+	prs.ProposalParityParts = prs.ProposalParityParts.Copy()
 	prs.ProposalPOL = prs.ProposalPOL.Copy()
 	prs.Prevotes = prs.Prevotes.Copy()
 	prs.Precommits = prs.Precommits.Copy()
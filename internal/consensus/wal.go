@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -19,6 +21,7 @@ import (
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -86,12 +89,19 @@ type BaseWAL struct {
 
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
+
+	// flushMessages is the number of messages written after which the WAL is
+	// flushed, or 0 to only flush periodically. unflushed counts the messages
+	// written since the last flush.
+	flushMessages int64
+	unflushed     atomic.Int64
 }
 
 var _ WAL = &BaseWAL{}
 
 // NewWAL returns a new write-ahead logger based on `baseWAL`, which implements
-// WAL. It's flushed and synced to disk every 2s and once when stopped.
+// WAL. It's flushed and synced to disk every 2s, unless set otherwise with
+// SetFlushInterval and SetFlushMessages, and once when stopped.
 func NewWAL(ctx context.Context, logger log.Logger, walFile string, groupOptions ...func(*auto.Group)) (*BaseWAL, error) {
 	err := tmos.EnsureDir(filepath.Dir(walFile), 0700)
 	if err != nil {
@@ -117,6 +127,13 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetFlushMessages makes Write flush and sync the WAL to disk every n messages,
+// in addition to the periodic flushes. 1 flushes every message, and 0, the
+// default, only flushes periodically. It must be called before Start.
+func (wal *BaseWAL) SetFlushMessages(n int) {
+	wal.flushMessages = int64(n)
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -155,6 +172,7 @@ func (wal *BaseWAL) processFlushTicks(ctx context.Context) {
 // FlushAndSync flushes and fsync's the underlying group's data to disk.
 // See auto#FlushAndSync
 func (wal *BaseWAL) FlushAndSync() error {
+	wal.unflushed.Store(0)
 	return wal.group.FlushAndSync()
 }
 
@@ -183,18 +201,33 @@ func (wal *BaseWAL) Wait() {
 
 // Write is called in newStep and for each receive on the
 // peerMsgQueue and the timeoutTicker.
-// NOTE: does not call fsync()
+// NOTE: only calls fsync() once the number of messages set with
+// SetFlushMessages is reached
 func (wal *BaseWAL) Write(msg WALMessage) error {
 	if wal == nil {
 		return nil
 	}
 
+	if err := wal.write(msg); err != nil {
+		return err
+	}
+
+	if wal.flushMessages > 0 && wal.unflushed.Add(1) >= wal.flushMessages {
+		if err := wal.FlushAndSync(); err != nil {
+			wal.logger.Error("failed to flush consensus wal", "err", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (wal *BaseWAL) write(msg WALMessage) error {
 	if err := wal.enc.Encode(&TimedWALMessage{tmtime.Now(), msg}); err != nil {
 		wal.logger.Error("error writing msg to consensus wal. WARNING: recover may not be possible for the current height",
 			"err", err, "msg", msg)
 		return err
 	}
-
 	return nil
 }
 
@@ -206,7 +239,7 @@ func (wal *BaseWAL) WriteSync(msg WALMessage) error {
 		return nil
 	}
 
-	if err := wal.Write(msg); err != nil {
+	if err := wal.write(msg); err != nil {
 		return err
 	}
 
@@ -421,6 +454,85 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 	return tMsgWal, err
 }
 
+// WALRepairResult describes the outcome of RepairWAL.
+type WALRepairResult struct {
+	// Messages is the number of messages written to the repaired WAL.
+	Messages int
+	// Corrupted is true if decoding stopped at a corrupted record rather than
+	// at the end of the WAL.
+	Corrupted bool
+	// Height and Round of the last good message that carries them.
+	Height int64
+	Round  int32
+}
+
+// RepairWAL decodes messages from the WAL file src, validating the checksum
+// and length prefix of each record, and writes every message up to the first
+// corrupted record to dst.
+func RepairWAL(src, dst string) (*WALRepairResult, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	var (
+		dec = NewWALDecoder(in)
+		enc = NewWALEncoder(out)
+		res = &WALRepairResult{}
+	)
+
+	// best-case repair (until first error is encountered)
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			res.Corrupted = true
+			break
+		}
+
+		if err := enc.Encode(msg); err != nil {
+			return nil, fmt.Errorf("failed to encode msg: %w", err)
+		}
+		res.Messages++
+		if height, round, ok := walMessageHeightRound(msg.Msg); ok {
+			res.Height, res.Round = height, round
+		}
+	}
+
+	return res, out.Sync()
+}
+
+// walMessageHeightRound returns the height and round carried by msg, if any.
+func walMessageHeightRound(msg WALMessage) (int64, int32, bool) {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return m.Height, m.Round, true
+	case timeoutInfo:
+		return m.Height, m.Round, true
+	case EndHeightMessage:
+		return m.Height, 0, true
+	case msgInfo:
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			return cm.Proposal.Height, cm.Proposal.Round, true
+		case *BlockPartMessage:
+			return cm.Height, cm.Round, true
+		case *VoteMessage:
+			return cm.Vote.Height, cm.Vote.Round, true
+		}
+	}
+	return 0, 0, false
+}
+
 type nilWAL struct{}
 
 var _ WAL = nilWAL{}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -101,6 +102,58 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestRepairWAL(t *testing.T) {
+	walDir := t.TempDir()
+	src := filepath.Join(walDir, "wal")
+	dst := filepath.Join(walDir, "wal.repaired")
+
+	now := tmtime.Now()
+	msgs := []TimedWALMessage{
+		{Time: now, Msg: EndHeightMessage{1}},
+		{Time: now, Msg: tmtypes.EventDataRoundState{Height: 2, Round: 0, Step: ""}},
+		{Time: now, Msg: timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: types.RoundStepPropose}},
+	}
+
+	b := new(bytes.Buffer)
+	enc := NewWALEncoder(b)
+	for _, msg := range msgs {
+		msg := msg
+		require.NoError(t, enc.Encode(&msg))
+	}
+	require.NoError(t, os.WriteFile(src, b.Bytes(), 0600))
+
+	// an intact WAL is copied as is
+	res, err := RepairWAL(src, dst)
+	require.NoError(t, err)
+	assert.False(t, res.Corrupted)
+	assert.Equal(t, len(msgs), res.Messages)
+	assert.EqualValues(t, 2, res.Height)
+	assert.EqualValues(t, 1, res.Round)
+
+	// simulate a torn final write
+	torn := b.Bytes()[:b.Len()-3]
+	require.NoError(t, os.WriteFile(src, torn, 0600))
+
+	res, err = RepairWAL(src, dst)
+	require.NoError(t, err)
+	assert.True(t, res.Corrupted)
+	assert.Equal(t, len(msgs)-1, res.Messages)
+	assert.EqualValues(t, 2, res.Height)
+	assert.EqualValues(t, 0, res.Round)
+
+	repaired, err := os.Open(dst)
+	require.NoError(t, err)
+	defer repaired.Close()
+	dec := NewWALDecoder(repaired)
+	for i := 0; i < len(msgs)-1; i++ {
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, msgs[i].Msg, decoded.Msg)
+	}
+	_, err = dec.Decode()
+	require.ErrorIs(t, err, io.EOF)
+}
+
 func TestWALWrite(t *testing.T) {
 	walDir := t.TempDir()
 	walFile := filepath.Join(walDir, "wal")
@@ -208,3 +261,33 @@ func TestWALPeriodicSync(t *testing.T) {
 
 	t.Cleanup(leaktest.Check(t))
 }
+
+func TestWALFlushMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	walFile := filepath.Join(t.TempDir(), "wal")
+	wal, err := NewWAL(ctx, log.NewNopLogger(), walFile)
+	require.NoError(t, err)
+	wal.SetFlushMessages(3)
+	require.NoError(t, wal.Start(ctx))
+	t.Cleanup(func() { wal.Stop(); wal.Group().Stop(); wal.Group().Wait(); wal.Wait() })
+
+	// The WAL is flushed every third message.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, wal.Write(EndHeightMessage{int64(i + 1)}))
+		assert.NotZero(t, wal.Group().Buffered())
+	}
+	require.NoError(t, wal.Write(EndHeightMessage{3}))
+	assert.Zero(t, wal.Group().Buffered())
+
+	// Synced writes reset the count.
+	require.NoError(t, wal.Write(EndHeightMessage{4}))
+	require.NoError(t, wal.WriteSync(EndHeightMessage{5}))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, wal.Write(EndHeightMessage{int64(i + 6)}))
+		assert.NotZero(t, wal.Group().Buffered())
+	}
+	require.NoError(t, wal.Write(EndHeightMessage{8}))
+	assert.Zero(t, wal.Group().Buffered())
+}
@@ -163,6 +163,14 @@ func (b *EventBus) PublishEventNewRoundStep(data types.EventDataRoundState) erro
 	return b.Publish(types.EventNewRoundStepValue, data)
 }
 
+func (b *EventBus) PublishEventStepTransition(data types.EventDataStepTransition) error {
+	// add Tendermint-reserved step transition event along with the height and
+	// trigger, so subscribers can filter by height range
+	events := append([]abci.Event{types.EventStepTransition}, data.ABCIEvents()...)
+
+	return b.pubsub.PublishWithEvents(data, events)
+}
+
 func (b *EventBus) PublishEventTimeoutPropose(data types.EventDataRoundState) error {
 	return b.Publish(types.EventTimeoutProposeValue, data)
 }
@@ -293,6 +293,48 @@ func TestEventBusPublishEventNewBlockHeader(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventStepTransition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := eventbus.NewDefault(log.NewNopLogger())
+	err := eventBus.Start(ctx)
+	require.NoError(t, err)
+
+	sub, err := eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: "test",
+		Query:    types.EventQueryStepTransitionForHeights(2, 3),
+		Limit:    4,
+	})
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 4; height++ {
+		require.NoError(t, eventBus.PublishEventStepTransition(types.EventDataStepTransition{
+			Height:  height,
+			Round:   0,
+			Step:    "RoundStepPrevote",
+			Trigger: types.StepTriggerMessage,
+			Reason:  "complete-proposal",
+		}))
+	}
+
+	// only the transitions within the height range are delivered
+	for _, height := range []int64{2, 3} {
+		msg, err := sub.Next(ctx)
+		require.NoError(t, err)
+
+		edt := msg.Data().(types.EventDataStepTransition)
+		assert.Equal(t, height, edt.Height)
+		assert.Equal(t, types.StepTriggerMessage, edt.Trigger)
+		assert.Equal(t, "complete-proposal", edt.Reason)
+	}
+
+	nextCtx, nextCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer nextCancel()
+	_, err = sub.Next(nextCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestEventBusPublishEventEvidenceValidated(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -385,7 +427,7 @@ func TestEventBusPublish(t *testing.T) {
 	err := eventBus.Start(ctx)
 	require.NoError(t, err)
 
-	const numEventsExpected = 14
+	const numEventsExpected = 15
 
 	sub, err := eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
 		ClientID: "test",
@@ -414,6 +456,7 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{}))
 	require.NoError(t, eventBus.PublishEventVote(types.EventDataVote{}))
 	require.NoError(t, eventBus.PublishEventNewRoundStep(types.EventDataRoundState{}))
+	require.NoError(t, eventBus.PublishEventStepTransition(types.EventDataStepTransition{}))
 	require.NoError(t, eventBus.PublishEventTimeoutPropose(types.EventDataRoundState{}))
 	require.NoError(t, eventBus.PublishEventTimeoutWait(types.EventDataRoundState{}))
 	require.NoError(t, eventBus.PublishEventNewRound(types.EventDataNewRound{}))
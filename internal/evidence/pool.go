@@ -24,9 +24,9 @@ import (
 
 // key prefixes
 // NB: Before modifying these, cross-check them with those in
-// * internal/store/store.go    [0..4, 13]
-// * internal/state/store.go    [5..8, 14]
-// * internal/evidence/pool.go  [9..10]
+// * internal/store/store.go    [0..4, 13, 17]
+// * internal/state/store.go    [5..8, 14..15, 18..19]
+// * internal/evidence/pool.go  [9..10, 16]
 // * light/store/db/db.go       [11..12]
 // TODO(sergio): Move all these to their own package.
 // TODO: what about these (they already collide):
@@ -36,6 +36,7 @@ const (
 	// prefixes are unique across all tm db's
 	prefixCommitted = int64(9)
 	prefixPending   = int64(10)
+	prefixExpired   = int64(16)
 )
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
@@ -62,6 +63,10 @@ type Pool struct {
 	pruningHeight int64
 	pruningTime   time.Time
 
+	// how long evidence is retained after it is no longer valid for block
+	// inclusion. Retained evidence is never gossiped or proposed.
+	retention time.Duration
+
 	// Eventbus to emit events when evidence is validated
 	// Not part of the constructor, use SetEventBus to set it
 	// The eventBus must be started in order for event publishing not to block
@@ -70,10 +75,30 @@ type Pool struct {
 	Metrics *Metrics
 }
 
+// PoolOption sets an optional parameter on the Pool.
+type PoolOption func(*Pool)
+
+// WithRetention sets how long evidence is kept in the pool, measured from the
+// time of the evidence, after it has expired according to the consensus
+// evidence params. Expired evidence that is still retained can be listed with
+// ExpiredEvidence, but is no longer broadcast or proposed. A retention that
+// does not exceed the evidence params' max age has no effect.
+func WithRetention(d time.Duration) PoolOption {
+	return func(evpool *Pool) { evpool.retention = d }
+}
+
 // NewPool creates an evidence pool. If using an existing evidence store,
 // it will add all pending evidence to the concurrent list.
-func NewPool(logger log.Logger, evidenceDB dbm.DB, stateStore sm.Store, blockStore BlockStore, metrics *Metrics, eventBus *eventbus.EventBus) *Pool {
-	return &Pool{
+func NewPool(
+	logger log.Logger,
+	evidenceDB dbm.DB,
+	stateStore sm.Store,
+	blockStore BlockStore,
+	metrics *Metrics,
+	eventBus *eventbus.EventBus,
+	options ...PoolOption,
+) *Pool {
+	evpool := &Pool{
 		blockStore:      blockStore,
 		stateDB:         stateStore,
 		logger:          logger,
@@ -83,6 +108,12 @@ func NewPool(logger log.Logger, evidenceDB dbm.DB, stateStore sm.Store, blockSto
 		Metrics:         metrics,
 		eventBus:        eventBus,
 	}
+
+	for _, opt := range options {
+		opt(evpool)
+	}
+
+	return evpool
 }
 
 // PendingEvidence is used primarily as part of block proposal and returns up to
@@ -100,13 +131,22 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	return evidence, size
 }
 
+// ExpiredEvidence returns the evidence that is no longer valid for block
+// inclusion but is still retained by the pool, from oldest to newest.
+func (evpool *Pool) ExpiredEvidence() ([]types.Evidence, error) {
+	evidence, _, err := evpool.listEvidence(prefixExpired, -1)
+	return evidence, err
+}
+
 // Update takes both the new state and the evidence committed at that height and performs
 // the following operations:
 // 1. Take any conflicting votes from consensus and use the state's LastBlockTime to form
 //    DuplicateVoteEvidence and add it to the pool.
 // 2. Update the pool's state which contains evidence params relating to expiry.
 // 3. Moves pending evidence that has now been committed into the committed pool.
-// 4. Removes any expired evidence based on both height and time.
+// 4. Removes any expired evidence based on both height and time, retaining it
+//    out of the pending pool if a retention is configured.
+// 5. Prunes retained evidence that is older than the retention.
 func (evpool *Pool) Update(ctx context.Context, state sm.State, ev types.EvidenceList) {
 	// sanity check
 	if state.LastBlockHeight <= evpool.state.LastBlockHeight {
@@ -138,6 +178,8 @@ func (evpool *Pool) Update(ctx context.Context, state sm.State, ev types.Evidenc
 		state.LastBlockTime.After(evpool.pruningTime) {
 		evpool.pruningHeight, evpool.pruningTime = evpool.removeExpiredPendingEvidence()
 	}
+
+	evpool.pruneRetainedEvidence()
 }
 
 // AddEvidence checks the evidence is valid and adds it to the pool.
@@ -270,6 +312,7 @@ func (evpool *Pool) Start(state sm.State) error {
 	// If pending evidence already in db, in event of prior failure, then check
 	// for expiration, update the size and load it back to the evidenceList.
 	evpool.pruningHeight, evpool.pruningTime = evpool.removeExpiredPendingEvidence()
+	evpool.pruneRetainedEvidence()
 	evList, _, err := evpool.listEvidence(prefixPending, -1)
 	if err != nil {
 		return err
@@ -519,6 +562,13 @@ func (evpool *Pool) batchExpiredPendingEvidence(batch dbm.Batch) (int64, time.Ti
 			continue
 		}
 
+		// keep the evidence out of the pending pool if it is still retained
+		if evpool.isRetained(ev.Time()) {
+			if err := batch.Set(keyExpired(ev), iter.Value()); err != nil {
+				evpool.logger.Error("failed to batch retain expired evidence", "err", err, "ev", ev)
+			}
+		}
+
 		// and add to the map to remove the evidence from the clist
 		blockEvidenceMap[evMapKey(ev)] = struct{}{}
 	}
@@ -526,6 +576,63 @@ func (evpool *Pool) batchExpiredPendingEvidence(batch dbm.Batch) (int64, time.Ti
 	return evpool.State().LastBlockHeight, evpool.State().LastBlockTime, blockEvidenceMap
 }
 
+// isRetained checks whether expired evidence with the given time is still
+// within the retention of the pool.
+func (evpool *Pool) isRetained(time time.Time) bool {
+	return evpool.State().LastBlockTime.Sub(time) <= evpool.retention
+}
+
+// pruneRetainedEvidence removes the expired evidence that is no longer retained.
+func (evpool *Pool) pruneRetainedEvidence() {
+	batch := evpool.evidenceStore.NewBatch()
+	defer batch.Close()
+
+	pruned := evpool.batchUnretainedEvidence(batch)
+	if pruned == 0 {
+		return
+	}
+
+	evpool.logger.Debug("pruning retained evidence",
+		"time", evpool.State().LastBlockTime,
+		"pruned evidence", pruned,
+	)
+
+	if err := batch.WriteSync(); err != nil {
+		evpool.logger.Error("failed to batch delete expired evidence", "err", err)
+	}
+}
+
+func (evpool *Pool) batchUnretainedEvidence(batch dbm.Batch) int {
+	iter, err := dbm.IteratePrefix(evpool.evidenceStore, prefixToBytes(prefixExpired))
+	if err != nil {
+		evpool.logger.Error("failed to iterate over expired evidence", "err", err)
+		return 0
+	}
+	defer iter.Close()
+
+	pruned := 0
+	for ; iter.Valid(); iter.Next() {
+		ev, err := bytesToEv(iter.Value())
+		if err != nil {
+			evpool.logger.Error("failed to transition evidence from protobuf", "err", err, "ev", ev)
+			continue
+		}
+
+		// if true, we have looped through all evidence that is no longer retained
+		if evpool.isRetained(ev.Time()) {
+			return pruned
+		}
+
+		if err := batch.Delete(iter.Key()); err != nil {
+			evpool.logger.Error("failed to batch delete expired evidence", "err", err, "ev", ev)
+			continue
+		}
+		pruned++
+	}
+
+	return pruned
+}
+
 func (evpool *Pool) removeEvidenceFromList(
 	blockEvidenceMap map[string]struct{}) {
 
@@ -663,6 +770,15 @@ func keyCommitted(evidence types.Evidence) []byte {
 	return key
 }
 
+func keyExpired(evidence types.Evidence) []byte {
+	height := evidence.Height()
+	key, err := orderedcode.Append(nil, prefixExpired, height, string(evidence.Hash()))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 func keyPending(evidence types.Evidence) []byte {
 	height := evidence.Height()
 	key, err := orderedcode.Append(nil, prefixPending, height, string(evidence.Hash()))
@@ -281,6 +281,65 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	}
 }
 
+func TestEvidencePoolRetention(t *testing.T) {
+	height := int64(21)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, val, _ := defaultTestPool(ctx, t, height, evidence.WithRetention(30*time.Minute))
+
+	state := pool.State()
+
+	firstEv, err := types.NewMockDuplicateVoteEvidenceWithValidator(ctx,
+		1,
+		defaultEvidenceTime.Add(1*time.Minute),
+		val,
+		evidenceChainID,
+	)
+	require.NoError(t, err)
+
+	secondEv, err := types.NewMockDuplicateVoteEvidenceWithValidator(ctx,
+		2,
+		defaultEvidenceTime.Add(2*time.Minute),
+		val,
+		evidenceChainID,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, pool.AddEvidence(ctx, firstEv))
+	require.NoError(t, pool.AddEvidence(ctx, secondEv))
+
+	expired, err := pool.ExpiredEvidence()
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	// the first evidence expires but is kept by the retention period
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
+	pool.Update(ctx, state, types.EvidenceList{})
+
+	evList, _ := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	require.Equal(t, []types.Evidence{secondEv}, evList)
+	require.Equal(t, uint32(1), pool.Size())
+
+	expired, err = pool.ExpiredEvidence()
+	require.NoError(t, err)
+	require.Equal(t, []types.Evidence{firstEv}, expired)
+
+	// the first evidence falls out of the retention period while the second
+	// one expires and is retained in its place
+	state.LastBlockHeight = height + 3
+	state.LastBlockTime = defaultEvidenceTime.Add(32 * time.Minute)
+	pool.Update(ctx, state, types.EvidenceList{})
+
+	evList, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
+	require.Empty(t, evList)
+
+	expired, err = pool.ExpiredEvidence()
+	require.NoError(t, err)
+	require.Equal(t, []types.Evidence{secondEv}, expired)
+}
+
 func TestVerifyPendingEvidencePasses(t *testing.T) {
 	var height int64 = 1
 
@@ -601,7 +660,12 @@ func makeExtCommit(height int64, valAddr []byte) *types.ExtendedCommit {
 	}
 }
 
-func defaultTestPool(ctx context.Context, t *testing.T, height int64) (*evidence.Pool, types.MockPV, *eventbus.EventBus) {
+func defaultTestPool(
+	ctx context.Context,
+	t *testing.T,
+	height int64,
+	options ...evidence.PoolOption,
+) (*evidence.Pool, types.MockPV, *eventbus.EventBus) {
 	t.Helper()
 	val := types.NewMockPV()
 	valAddress := val.PrivKey.PubKey().Address()
@@ -617,7 +681,7 @@ func defaultTestPool(ctx context.Context, t *testing.T, height int64) (*evidence
 	eventBus := eventbus.NewDefault(logger)
 	require.NoError(t, eventBus.Start(ctx))
 
-	pool := evidence.NewPool(logger, evidenceDB, stateStore, blockStore, evidence.NopMetrics(), eventBus)
+	pool := evidence.NewPool(logger, evidenceDB, stateStore, blockStore, evidence.NopMetrics(), eventBus, options...)
 	startPool(t, pool, stateStore)
 	return pool, val, eventBus
 }
@@ -11,6 +11,7 @@ inconsistent state so the node will not be able to start up again.
 
 The RPC endpoints provided by the Inspector type allow for a node operator to inspect
 the block store and state store to better understand what may have caused the inconsistent state.
+When constructed with NewFromConfig, the Inspector opens the databases read-only.
 
 
 The Inspector type's lifecycle is controlled by a context.Context
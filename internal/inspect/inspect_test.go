@@ -17,8 +17,10 @@ import (
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/inspect"
 	"github.com/tendermint/tendermint/internal/pubsub/query"
+	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	indexermocks "github.com/tendermint/tendermint/internal/state/indexer/mocks"
 	statemocks "github.com/tendermint/tendermint/internal/state/mocks"
@@ -269,6 +271,10 @@ func TestBlockResults(t *testing.T) {
 			},
 		},
 	}, nil)
+	testMaxGas := int64(1000)
+	consensusParams := *types.DefaultConsensusParams()
+	consensusParams.Block.MaxGas = testMaxGas
+	stateStoreMock.On("LoadConsensusParams", testHeight).Return(consensusParams, nil)
 	blockStoreMock := &statemocks.BlockStore{}
 	blockStoreMock.On("Base").Return(int64(0))
 	blockStoreMock.On("Height").Return(testHeight)
@@ -300,6 +306,7 @@ func TestBlockResults(t *testing.T) {
 	res, err := cli.BlockResults(ctx, &testHeight)
 	require.NoError(t, err)
 	require.Equal(t, res.TotalGasUsed, testGasUsed)
+	require.Equal(t, res.MaxBlockGas, testMaxGas)
 
 	cancel()
 	wg.Wait()
@@ -475,7 +482,8 @@ func TestValidators(t *testing.T) {
 		},
 	}
 	stateStoreMock := &statemocks.Store{}
-	stateStoreMock.On("LoadValidators", testHeight).Return(&testValidators, nil)
+	stateStoreMock.On("LoadValidatorsPage", testHeight, 0, 100, []byte(nil)).
+		Return(testValidators.Validators, len(testValidators.Validators), nil)
 
 	blockStoreMock := &statemocks.BlockStore{}
 	blockStoreMock.On("Height").Return(testHeight)
@@ -580,6 +588,95 @@ func TestBlockSearch(t *testing.T) {
 	stateStoreMock.AssertExpectations(t)
 }
 
+func TestStatus(t *testing.T) {
+	testHeight := int64(2)
+	testBlockHash := []byte("block hash")
+	stateStoreMock := &statemocks.Store{}
+	stateStoreMock.On("LoadValidators", testHeight+1).Return(types.NewValidatorSet(nil), nil)
+
+	blockStoreMock := &statemocks.BlockStore{}
+	blockStoreMock.On("Height").Return(testHeight)
+	blockStoreMock.On("LoadBaseMeta").Return(&types.BlockMeta{Header: types.Header{Height: 1}})
+	blockStoreMock.On("LoadBlockMeta", testHeight).Return(&types.BlockMeta{
+		BlockID: types.BlockID{Hash: testBlockHash},
+		Header:  types.Header{Height: testHeight},
+	})
+	eventSinkMock := &indexermocks.EventSink{}
+	eventSinkMock.On("Stop").Return(nil)
+	eventSinkMock.On("Type").Return(indexer.EventSinkType("Mock"))
+
+	rpcConfig := config.TestRPCConfig()
+	l := log.NewNopLogger()
+	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, []indexer.EventSink{eventSinkMock}, l)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		require.NoError(t, d.Run(ctx))
+	}()
+	// FIXME: used to induce context switch.
+	// Determine more deterministic method for prompting a context switch
+	runtime.Gosched()
+	requireConnect(t, rpcConfig.ListenAddress, 20)
+	cli, err := httpclient.New(rpcConfig.ListenAddress)
+	require.NoError(t, err)
+	res, err := cli.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testHeight, res.SyncInfo.LatestBlockHeight)
+	require.Equal(t, int64(1), res.SyncInfo.EarliestBlockHeight)
+	require.Equal(t, testBlockHash, []byte(res.SyncInfo.LatestBlockHash))
+	cancel()
+	wg.Wait()
+
+	blockStoreMock.AssertExpectations(t)
+	stateStoreMock.AssertExpectations(t)
+}
+
+func TestConsensusState(t *testing.T) {
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	testState := sm.State{
+		ChainID:         "test",
+		LastBlockHeight: 4,
+		Validators:      types.NewValidatorSet([]*types.Validator{val}),
+	}
+	stateStoreMock := &statemocks.Store{}
+	stateStoreMock.On("Load").Return(testState, nil)
+
+	blockStoreMock := &statemocks.BlockStore{}
+	eventSinkMock := &indexermocks.EventSink{}
+	eventSinkMock.On("Stop").Return(nil)
+	eventSinkMock.On("Type").Return(indexer.EventSinkType("Mock"))
+
+	rpcConfig := config.TestRPCConfig()
+	l := log.NewNopLogger()
+	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, []indexer.EventSink{eventSinkMock}, l)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		require.NoError(t, d.Run(ctx))
+	}()
+	// FIXME: used to induce context switch.
+	// Determine more deterministic method for prompting a context switch
+	runtime.Gosched()
+	requireConnect(t, rpcConfig.ListenAddress, 20)
+	cli, err := httpclient.New(rpcConfig.ListenAddress)
+	require.NoError(t, err)
+	res, err := cli.ConsensusState(ctx)
+	require.NoError(t, err)
+	require.Contains(t, string(res.RoundState), `"height/round/step":"5/0/1"`)
+	require.Contains(t, string(res.RoundState), val.Address.String())
+	cancel()
+	wg.Wait()
+
+	blockStoreMock.AssertExpectations(t)
+	stateStoreMock.AssertExpectations(t)
+}
+
 func requireConnect(t testing.TB, addr string, retries int) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
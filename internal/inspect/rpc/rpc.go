@@ -38,16 +38,22 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, es []index
 		Logger:     logger,
 	}
 	return core.RoutesMap{
-		"blockchain":       server.NewRPCFunc(env.BlockchainInfo),
-		"consensus_params": server.NewRPCFunc(env.ConsensusParams),
-		"block":            server.NewRPCFunc(env.Block),
-		"block_by_hash":    server.NewRPCFunc(env.BlockByHash),
-		"block_results":    server.NewRPCFunc(env.BlockResults),
-		"commit":           server.NewRPCFunc(env.Commit),
-		"validators":       server.NewRPCFunc(env.Validators),
-		"tx":               server.NewRPCFunc(env.Tx),
-		"tx_search":        server.NewRPCFunc(env.TxSearch),
-		"block_search":     server.NewRPCFunc(env.BlockSearch),
+		"status":            server.NewRPCFunc(env.Status),
+		"consensus_state":   server.NewRPCFunc(env.GetConsensusState),
+		"blockchain":        server.NewRPCFunc(env.BlockchainInfo),
+		"height_eta":        server.NewRPCFunc(env.HeightETA),
+		"consensus_params":  server.NewRPCFunc(env.ConsensusParams),
+		"block":             server.NewRPCFunc(env.Block),
+		"block_by_hash":     server.NewRPCFunc(env.BlockByHash),
+		"block_by_app_hash": server.NewRPCFunc(env.BlockByAppHash),
+		"block_results":     server.NewRPCFunc(env.BlockResults),
+		"commit":            server.NewRPCFunc(env.Commit),
+		"validators":        server.NewRPCFunc(env.Validators),
+		"tx":                server.NewRPCFunc(env.Tx),
+		"tx_events":         server.NewRPCFunc(env.TxEvents),
+		"tx_proof":          server.NewRPCFunc(env.TxProof),
+		"tx_search":         server.NewRPCFunc(env.TxSearch),
+		"block_search":      server.NewRPCFunc(env.BlockSearch),
 	}
 }
 
@@ -92,7 +98,11 @@ func addCORSHandler(rpcConfig *config.RPCConfig, h http.Handler) http.Handler {
 // ListenAndServe listens on the address specified in srv.Addr and handles any
 // incoming requests over HTTP using the Inspector rpc handler specified on the server.
 func (srv *Server) ListenAndServe(ctx context.Context) error {
-	listener, err := server.Listen(srv.Addr, srv.Config.MaxOpenConnections)
+	cfg, err := serverRPCConfig(srv.Config)
+	if err != nil {
+		return err
+	}
+	listener, err := server.Listen(srv.Addr, cfg)
 	if err != nil {
 		return err
 	}
@@ -101,13 +111,17 @@ func (srv *Server) ListenAndServe(ctx context.Context) error {
 		listener.Close()
 	}()
 
-	return server.Serve(ctx, listener, srv.Handler, srv.Logger, serverRPCConfig(srv.Config))
+	return server.Serve(ctx, listener, srv.Handler, srv.Logger, cfg)
 }
 
 // ListenAndServeTLS listens on the address specified in srv.Addr. ListenAndServeTLS handles
 // incoming requests over HTTPS using the Inspector rpc handler specified on the server.
 func (srv *Server) ListenAndServeTLS(ctx context.Context, certFile, keyFile string) error {
-	listener, err := server.Listen(srv.Addr, srv.Config.MaxOpenConnections)
+	cfg, err := serverRPCConfig(srv.Config)
+	if err != nil {
+		return err
+	}
+	listener, err := server.Listen(srv.Addr, cfg)
 	if err != nil {
 		return err
 	}
@@ -115,13 +129,25 @@ func (srv *Server) ListenAndServeTLS(ctx context.Context, certFile, keyFile stri
 		<-ctx.Done()
 		listener.Close()
 	}()
-	return server.ServeTLS(ctx, listener, srv.Handler, certFile, keyFile, srv.Logger, serverRPCConfig(srv.Config))
+	return server.ServeTLS(ctx, listener, srv.Handler, certFile, keyFile, srv.Logger, cfg)
 }
 
-func serverRPCConfig(r *config.RPCConfig) *server.Config {
+func serverRPCConfig(r *config.RPCConfig) (*server.Config, error) {
 	cfg := server.DefaultConfig()
+	cfg.MaxOpenConnections = r.MaxOpenConnections
 	cfg.MaxBodyBytes = r.MaxBodyBytes
 	cfg.MaxHeaderBytes = r.MaxHeaderBytes
+	cfg.ReadTimeout = r.ReadTimeout
+	cfg.WriteTimeout = r.WriteTimeout
+	cfg.IdleTimeout = r.IdleTimeout
+	var err error
+	if cfg.RateLimits, cfg.DefaultRateLimit, err = r.ParseRateLimits(); err != nil {
+		return nil, err
+	}
+	cfg.RateLimitPerClient = r.RateLimitPerClient
+	if cfg.UnixSocketPermissions, err = r.UnixSocketFileMode(); err != nil {
+		return nil, err
+	}
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -129,5 +155,5 @@ func serverRPCConfig(r *config.RPCConfig) *server.Config {
 	if cfg.WriteTimeout > 0 && cfg.WriteTimeout <= r.TimeoutBroadcastTxCommit {
 		cfg.WriteTimeout = r.TimeoutBroadcastTxCommit + 1*time.Second
 	}
-	return cfg
+	return cfg, nil
 }
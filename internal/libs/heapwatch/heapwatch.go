@@ -0,0 +1,215 @@
+// Package heapwatch captures heap profiles when the memory usage of the process
+// crosses a threshold, to catch intermittent memory spikes which are hard to
+// catch by profiling manually.
+package heapwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// profilePrefix and profileSuffix enclose the capture time in the names
+	// of the profiles, which sort in capture order.
+	profilePrefix = "heap-"
+	profileSuffix = ".pprof"
+	timeFormat    = "20060102T150405.000Z"
+
+	// defaultCheckInterval is how often the memory usage is checked.
+	defaultCheckInterval = 5 * time.Second
+
+	// heapObjectsMetric and heapUnusedMetric add up to the bytes of heap
+	// spans in use, like runtime.MemStats.HeapInuse, but are read without
+	// stopping the world.
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+	heapUnusedMetric  = "/memory/classes/heap/unused:bytes"
+)
+
+// Config configures a Watchdog.
+type Config struct {
+	// Dir is the directory profiles are written to.
+	Dir string
+	// RSSThreshold is the resident set size, in bytes, above which a profile
+	// is captured. 0 disables it. RSS is only available on Linux.
+	RSSThreshold int64
+	// HeapThreshold is the heap in use, in bytes, above which a profile is
+	// captured. 0 disables it.
+	HeapThreshold int64
+	// MinInterval is the minimum time between two captures, so that capturing
+	// doesn't worsen a memory problem.
+	MinInterval time.Duration
+	// MaxProfiles is the number of profiles kept in Dir, the oldest ones
+	// being removed.
+	MaxProfiles int
+	// CheckInterval is how often the memory usage is checked. It defaults to
+	// 5 seconds.
+	CheckInterval time.Duration
+}
+
+// Watchdog periodically checks the memory usage of the process and captures
+// a heap profile when it crosses one of the thresholds.
+type Watchdog struct {
+	cfg    Config
+	logger log.Logger
+
+	readRSS     func() (int64, error)
+	readHeap    func() int64
+	lastCapture time.Time
+}
+
+// New returns a Watchdog, which does nothing until Run is called.
+func New(logger log.Logger, cfg Config) *Watchdog {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultCheckInterval
+	}
+	return &Watchdog{
+		cfg:      cfg,
+		logger:   logger,
+		readRSS:  readRSS,
+		readHeap: readHeapInUse,
+	}
+}
+
+// Run checks the memory usage at every check interval until ctx is done.
+func (w *Watchdog) Run(ctx context.Context) {
+	if err := os.MkdirAll(w.cfg.Dir, 0700); err != nil {
+		w.logger.Error("failed to create heap profile directory", "dir", w.cfg.Dir, "err", err)
+		return
+	}
+
+	ticker := time.NewTicker(w.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := w.check(now); err != nil {
+				w.logger.Error("failed to capture heap profile", "err", err)
+			}
+		}
+	}
+}
+
+// check captures a heap profile if the memory usage crosses a threshold and
+// the previous capture is older than the minimum interval.
+func (w *Watchdog) check(now time.Time) error {
+	if !w.lastCapture.IsZero() && now.Sub(w.lastCapture) < w.cfg.MinInterval {
+		return nil
+	}
+
+	var reason []interface{}
+	if w.cfg.HeapThreshold > 0 {
+		if heap := w.readHeap(); heap >= w.cfg.HeapThreshold {
+			reason = []interface{}{"heap_in_use", heap, "threshold", w.cfg.HeapThreshold}
+		}
+	}
+	if reason == nil && w.cfg.RSSThreshold > 0 {
+		rss, err := w.readRSS()
+		if err != nil {
+			// RSS is unavailable on this platform, don't fail every check.
+			w.cfg.RSSThreshold = 0
+			return fmt.Errorf("reading RSS, disabling its threshold: %w", err)
+		}
+		if rss >= w.cfg.RSSThreshold {
+			reason = []interface{}{"rss", rss, "threshold", w.cfg.RSSThreshold}
+		}
+	}
+	if reason == nil {
+		return nil
+	}
+
+	// The capture is rate-limited even if it fails, not to retry a failing
+	// capture at every check.
+	w.lastCapture = now
+	path, err := w.capture(now)
+	if err != nil {
+		return err
+	}
+	w.logger.Info("captured heap profile", append([]interface{}{"path", path}, reason...)...)
+	return w.prune()
+}
+
+// capture writes a heap profile named after the given time.
+func (w *Watchdog) capture(now time.Time) (string, error) {
+	path := filepath.Join(w.cfg.Dir, profilePrefix+now.UTC().Format(timeFormat)+profileSuffix)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	if err := pprof.Lookup("heap").WriteTo(file, 0); err != nil {
+		file.Close()
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// prune removes the oldest profiles beyond the maximum number of profiles.
+func (w *Watchdog) prune() error {
+	entries, err := os.ReadDir(w.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	var profiles []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, profilePrefix) && strings.HasSuffix(name, profileSuffix) {
+			profiles = append(profiles, name)
+		}
+	}
+	if len(profiles) <= w.cfg.MaxProfiles {
+		return nil
+	}
+
+	sort.Strings(profiles)
+	for _, name := range profiles[:len(profiles)-w.cfg.MaxProfiles] {
+		if err := os.Remove(filepath.Join(w.cfg.Dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readHeapInUse returns the bytes of heap spans in use.
+func readHeapInUse() int64 {
+	samples := []metrics.Sample{{Name: heapObjectsMetric}, {Name: heapUnusedMetric}}
+	metrics.Read(samples)
+
+	var inUse int64
+	for _, sample := range samples {
+		if sample.Value.Kind() == metrics.KindUint64 {
+			inUse += int64(sample.Value.Uint64())
+		}
+	}
+	return inUse
+}
+
+// readRSS returns the resident set size of the process, read from procfs.
+func readRSS() (int64, error) {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, err
+	}
+	// statm holds the total program size followed by the resident set size,
+	// in pages.
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0, errors.New("unexpected /proc/self/statm format")
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return pages * int64(os.Getpagesize()), nil
+}
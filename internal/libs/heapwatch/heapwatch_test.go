@@ -0,0 +1,106 @@
+package heapwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func newTestWatchdog(t *testing.T, cfg Config, rss, heap *int64) *Watchdog {
+	t.Helper()
+	cfg.Dir = t.TempDir()
+	w := New(log.NewNopLogger(), cfg)
+	w.readRSS = func() (int64, error) { return *rss, nil }
+	w.readHeap = func() int64 { return *heap }
+	return w
+}
+
+func listProfiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestWatchdogThresholds(t *testing.T) {
+	var rss, heap int64
+	w := newTestWatchdog(t, Config{
+		RSSThreshold:  1000,
+		HeapThreshold: 500,
+		MinInterval:   time.Minute,
+		MaxProfiles:   10,
+	}, &rss, &heap)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Below both thresholds.
+	rss, heap = 999, 499
+	require.NoError(t, w.check(now))
+	require.Empty(t, listProfiles(t, w.cfg.Dir))
+
+	// Crossing the heap threshold captures a profile.
+	heap = 500
+	require.NoError(t, w.check(now))
+	require.Equal(t, []string{"heap-20220101T000000.000Z.pprof"}, listProfiles(t, w.cfg.Dir))
+
+	// Captures are rate-limited.
+	rss = 2000
+	require.NoError(t, w.check(now.Add(59*time.Second)))
+	require.Len(t, listProfiles(t, w.cfg.Dir), 1)
+
+	// Crossing the RSS threshold captures a profile.
+	heap = 0
+	require.NoError(t, w.check(now.Add(time.Minute)))
+	require.Equal(t, []string{
+		"heap-20220101T000000.000Z.pprof",
+		"heap-20220101T000100.000Z.pprof",
+	}, listProfiles(t, w.cfg.Dir))
+}
+
+func TestWatchdogRetention(t *testing.T) {
+	var rss, heap int64 = 0, 100
+	w := newTestWatchdog(t, Config{
+		HeapThreshold: 1,
+		MinInterval:   time.Second,
+		MaxProfiles:   2,
+	}, &rss, &heap)
+	require.NoError(t, os.WriteFile(filepath.Join(w.cfg.Dir, "unrelated.txt"), nil, 0600))
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, w.check(now.Add(time.Duration(i)*time.Second)))
+	}
+	require.Equal(t, []string{
+		"heap-20220101T000002.000Z.pprof",
+		"heap-20220101T000003.000Z.pprof",
+		"unrelated.txt",
+	}, listProfiles(t, w.cfg.Dir))
+}
+
+func TestWatchdogRSSUnavailable(t *testing.T) {
+	var rss, heap int64
+	w := newTestWatchdog(t, Config{RSSThreshold: 1, MinInterval: time.Second, MaxProfiles: 1}, &rss, &heap)
+	w.readRSS = func() (int64, error) { return 0, errors.New("unsupported") }
+
+	require.Error(t, w.check(time.Now()))
+	require.NoError(t, w.check(time.Now()))
+	require.Empty(t, listProfiles(t, w.cfg.Dir))
+}
+
+func TestReadMemoryUsage(t *testing.T) {
+	require.Positive(t, readHeapInUse())
+	if _, err := os.Stat("/proc/self/statm"); err == nil {
+		rss, err := readRSS()
+		require.NoError(t, err)
+		require.Positive(t, rss)
+	}
+}
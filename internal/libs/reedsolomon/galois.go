@@ -0,0 +1,50 @@
+package reedsolomon
+
+// Arithmetic in GF(2^8), with the primitive polynomial x^8+x^4+x^3+x^2+1.
+
+const galPolynomial = 0x11d
+
+var (
+	galExp [510]byte
+	galLog [256]byte
+	// galMulTable holds the products of every pair of field elements.
+	galMulTable [256][256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		galExp[i] = byte(x)
+		galExp[i+255] = byte(x)
+		galLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= galPolynomial
+		}
+	}
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			galMulTable[a][b] = galExp[int(galLog[a])+int(galLog[b])]
+		}
+	}
+}
+
+func galMul(a, b byte) byte {
+	return galMulTable[a][b]
+}
+
+// galInv returns the multiplicative inverse of a, which must not be 0.
+func galInv(a byte) byte {
+	return galExp[255-int(galLog[a])]
+}
+
+// galMulSliceXor adds c times in to out.
+func galMulSliceXor(c byte, in, out []byte) {
+	if c == 0 {
+		return
+	}
+	table := &galMulTable[c]
+	for i, b := range in {
+		out[i] ^= table[b]
+	}
+}
@@ -0,0 +1,205 @@
+// Package reedsolomon implements a systematic Reed-Solomon erasure code over
+// GF(2^8). Data is split into shards of equal size, from which parity shards
+// are computed, and any combination of as many shards as there are data
+// shards is enough to reconstruct the data.
+package reedsolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum total number of data and parity shards.
+const MaxShards = 256
+
+var (
+	// ErrTooFewShards is returned by Reconstruct when less shards than the
+	// number of data shards are available.
+	ErrTooFewShards = errors.New("too few shards to reconstruct the data")
+	// ErrShardSize is returned when shards don't all have the same size.
+	ErrShardSize = errors.New("shards must all have the same size")
+)
+
+// Encoder computes and decodes the parity shards of a fixed number of data
+// shards. It is safe for concurrent use.
+type Encoder struct {
+	dataShards   int
+	parityShards int
+	// parity holds the coefficients of the parity shards, one row of
+	// dataShards coefficients per parity shard.
+	parity [][]byte
+}
+
+// New returns an Encoder for the given numbers of data and parity shards.
+func New(dataShards, parityShards int) (*Encoder, error) {
+	if dataShards <= 0 || parityShards <= 0 {
+		return nil, errors.New("the numbers of data and parity shards must be positive")
+	}
+	if dataShards+parityShards > MaxShards {
+		return nil, fmt.Errorf("too many shards: %d, the maximum is %d", dataShards+parityShards, MaxShards)
+	}
+
+	// The parity rows form a Cauchy matrix, so that any square submatrix of
+	// the identity matrix extended with them is invertible.
+	parity := make([][]byte, parityShards)
+	for i := range parity {
+		parity[i] = make([]byte, dataShards)
+		for j := range parity[i] {
+			parity[i][j] = galInv(byte(dataShards+i) ^ byte(j))
+		}
+	}
+	return &Encoder{
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		parity:       parity,
+	}, nil
+}
+
+// DataShards returns the number of data shards.
+func (e *Encoder) DataShards() int { return e.dataShards }
+
+// ParityShards returns the number of parity shards.
+func (e *Encoder) ParityShards() int { return e.parityShards }
+
+// Encode returns the parity shards of the given data shards, which must all
+// have the same size.
+func (e *Encoder) Encode(data [][]byte) ([][]byte, error) {
+	if len(data) != e.dataShards {
+		return nil, fmt.Errorf("expected %d data shards, got %d", e.dataShards, len(data))
+	}
+	size, err := shardSize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	parity := make([][]byte, e.parityShards)
+	for i := range parity {
+		parity[i] = make([]byte, size)
+		for j, shard := range data {
+			galMulSliceXor(e.parity[i][j], shard, parity[i])
+		}
+	}
+	return parity, nil
+}
+
+// Reconstruct fills in the missing data shards, from the data shards followed
+// by the parity shards, where missing shards are nil. At least as many shards
+// as there are data shards must be available. Missing parity shards are left
+// nil.
+func (e *Encoder) Reconstruct(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return fmt.Errorf("expected %d shards, got %d", e.dataShards+e.parityShards, len(shards))
+	}
+	size, err := shardSize(shards)
+	if err != nil {
+		return err
+	}
+
+	// Pick the first dataShards available shards, and the rows of the
+	// encoding matrix that produced them.
+	missing := 0
+	rows := make([][]byte, 0, e.dataShards)
+	inputs := make([][]byte, 0, e.dataShards)
+	for i, shard := range shards {
+		if shard == nil {
+			if i < e.dataShards {
+				missing++
+			}
+			continue
+		}
+		if len(rows) == e.dataShards {
+			continue
+		}
+		if i < e.dataShards {
+			row := make([]byte, e.dataShards)
+			row[i] = 1
+			rows = append(rows, row)
+		} else {
+			rows = append(rows, e.parity[i-e.dataShards])
+		}
+		inputs = append(inputs, shard)
+	}
+	if missing == 0 {
+		return nil
+	}
+	if len(rows) < e.dataShards {
+		return ErrTooFewShards
+	}
+
+	decode, err := invertMatrix(rows)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < e.dataShards; i++ {
+		if shards[i] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for j, input := range inputs {
+			galMulSliceXor(decode[i][j], input, shard)
+		}
+		shards[i] = shard
+	}
+	return nil
+}
+
+// shardSize returns the size of the non-nil shards, which must be the same.
+func shardSize(shards [][]byte) (int, error) {
+	size := -1
+	for _, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		} else if len(shard) != size {
+			return 0, ErrShardSize
+		}
+	}
+	if size == -1 {
+		return 0, ErrTooFewShards
+	}
+	return size, nil
+}
+
+// invertMatrix returns the inverse of the square matrix m, by Gauss-Jordan
+// elimination. m is left unchanged.
+func invertMatrix(m [][]byte) ([][]byte, error) {
+	n := len(m)
+	// work is m augmented with the identity matrix.
+	work := make([][]byte, n)
+	for i := range work {
+		work[i] = make([]byte, 2*n)
+		copy(work[i], m[i])
+		work[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && work[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("matrix is singular")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		if c := work[col][col]; c != 1 {
+			inv := galInv(c)
+			for j := range work[col] {
+				work[col][j] = galMul(work[col][j], inv)
+			}
+		}
+		for i := 0; i < n; i++ {
+			if i != col && work[i][col] != 0 {
+				galMulSliceXor(work[i][col], work[col], work[i])
+			}
+		}
+	}
+
+	inv := make([][]byte, n)
+	for i := range inv {
+		inv[i] = work[i][n:]
+	}
+	return inv, nil
+}
@@ -0,0 +1,69 @@
+package reedsolomon
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomShards(n, size int) [][]byte {
+	shards := make([][]byte, n)
+	for i := range shards {
+		shards[i] = make([]byte, size)
+		rand.Read(shards[i])
+	}
+	return shards
+}
+
+func TestReconstruct(t *testing.T) {
+	for _, tc := range []struct {
+		data, parity int
+	}{
+		{1, 1},
+		{4, 2},
+		{10, 5},
+		{170, 86},
+	} {
+		enc, err := New(tc.data, tc.parity)
+		require.NoError(t, err)
+
+		data := randomShards(tc.data, 64)
+		parity, err := enc.Encode(data)
+		require.NoError(t, err)
+		require.Len(t, parity, tc.parity)
+
+		// Drop as many random shards as there are parity shards.
+		shards := append(append([][]byte{}, data...), parity...)
+		for _, i := range rand.Perm(len(shards))[:tc.parity] {
+			shards[i] = nil
+		}
+		require.NoError(t, enc.Reconstruct(shards))
+		assert.Equal(t, data, shards[:tc.data])
+	}
+}
+
+func TestReconstructTooFewShards(t *testing.T) {
+	enc, err := New(4, 2)
+	require.NoError(t, err)
+
+	data := randomShards(4, 16)
+	parity, err := enc.Encode(data)
+	require.NoError(t, err)
+
+	shards := [][]byte{data[0], nil, nil, nil, parity[0], parity[1]}
+	assert.ErrorIs(t, enc.Reconstruct(shards), ErrTooFewShards)
+
+	shards = [][]byte{data[0], nil, data[2], data[3][:8], parity[0], parity[1]}
+	assert.ErrorIs(t, enc.Reconstruct(shards), ErrShardSize)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(0, 1)
+	assert.Error(t, err)
+	_, err = New(200, 57)
+	assert.Error(t, err)
+	_, err = New(200, 56)
+	assert.NoError(t, err)
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -82,19 +84,38 @@ type TxMempool struct {
 	// index. i.e. older transactions are first.
 	timestampIndex *WrappedTxList
 
+	// expiryIndex defines an expiry height-based, in ascending order, index of
+	// the transactions that declared an expiry height.
+	expiryIndex *WrappedTxList
+
 	// A read/write lock is used to safe guard updates, insertions and deletions
 	// from the mempool. A read-lock is implicitly acquired when executing CheckTx,
 	// however, a caller must explicitly grab a write-lock via Lock when updating
 	// the mempool via Update().
-	mtx       sync.RWMutex
-	preCheck  PreCheckFunc
-	postCheck PostCheckFunc
+	mtx          sync.RWMutex
+	preCheck     PreCheckFunc
+	postCheck    PostCheckFunc
+	expiryHeight TxExpiryHeightFunc
+
+	// minGasPrice is the gas price below which transactions are dropped after
+	// CheckTx. It is guarded by mtx.
+	minGasPrice float64
+
+	// blacklist holds the keys of the transactions removed by RemoveTxByHash
+	// which CheckTx rejects. It is guarded by mtx.
+	blacklist map[types.TxKey]struct{}
 
 	// NodeID to count of transactions failing CheckTx
 	failedCheckTxCounts    map[types.NodeID]uint64
 	mtxFailedCheckTxCounts sync.RWMutex
 
 	peerManager PeerEvictor
+
+	// wal logs the accepted transactions if the WAL is enabled, once it is
+	// loaded with LoadWAL.
+	wal *txWAL
+	// compactWALCh signals the WAL compaction routine to compact the WAL.
+	compactWALCh chan struct{}
 }
 
 func NewTxMempool(
@@ -121,8 +142,13 @@ func NewTxMempool(
 		timestampIndex: NewWrappedTxList(func(wtx1, wtx2 *WrappedTx) bool {
 			return wtx1.timestamp.After(wtx2.timestamp) || wtx1.timestamp.Equal(wtx2.timestamp)
 		}),
+		expiryIndex: NewWrappedTxList(func(wtx1, wtx2 *WrappedTx) bool {
+			return wtx1.expiryHeight >= wtx2.expiryHeight
+		}),
 		failedCheckTxCounts: map[types.NodeID]uint64{},
+		blacklist:           map[types.TxKey]struct{}{},
 		peerManager:         peerManager,
+		minGasPrice:         cfg.MinGasPrice,
 	}
 
 	if cfg.CacheSize > 0 {
@@ -150,11 +176,43 @@ func WithPostCheck(f PostCheckFunc) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.postCheck = f }
 }
 
+// WithTxExpiryHeight sets a hook that lets transactions declare the last
+// height at which they may be included in a block. It is executed after
+// CheckTx.
+func WithTxExpiryHeight(f TxExpiryHeightFunc) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.expiryHeight = f }
+}
+
+// WithTxComparator sets a tiebreak used to order transactions of equal
+// priority when reaping. Without one, the transaction seen first is reaped
+// first.
+func WithTxComparator(f TxComparatorFunc) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.priorityIndex.compare = f }
+}
+
 // WithMetrics sets the mempool's metrics collector.
 func WithMetrics(metrics *Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// SetMinGasPrice updates the minimum gas price of the mempool. It only applies
+// to transactions checked afterwards; transactions already in the mempool are
+// kept.
+func (txmp *TxMempool) SetMinGasPrice(price float64) {
+	txmp.mtx.Lock()
+	defer txmp.mtx.Unlock()
+
+	txmp.minGasPrice = price
+}
+
+// MinGasPrice returns the current minimum gas price of the mempool.
+func (txmp *TxMempool) MinGasPrice() float64 {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	return txmp.minGasPrice
+}
+
 func (txmp *TxMempool) TxStore() *TxStore {
 	return txmp.txStore
 }
@@ -247,17 +305,8 @@ func (txmp *TxMempool) CheckTx(
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
 
-	if txSize := len(tx); txSize > txmp.config.MaxTxBytes {
-		return types.ErrTxTooLarge{
-			Max:    txmp.config.MaxTxBytes,
-			Actual: txSize,
-		}
-	}
-
-	if txmp.preCheck != nil {
-		if err := txmp.preCheck(tx); err != nil {
-			return types.ErrPreCheck{Reason: err}
-		}
+	if err := txmp.validateTx(tx); err != nil {
+		return err
 	}
 
 	if err := txmp.proxyAppConn.Error(); err != nil {
@@ -303,6 +352,123 @@ func (txmp *TxMempool) CheckTx(
 	return nil
 }
 
+// CheckTxBatch executes CheckTx for a set of transactions received together.
+// Transactions that pass the local checks are sent to the application in a
+// single CheckTxBatch call, which falls back to one CheckTx per transaction
+// when the application does not support batching.
+//
+// It returns one error per transaction, in the order of txs, with the same
+// meaning as the error returned by CheckTx.
+func (txmp *TxMempool) CheckTxBatch(ctx context.Context, txs types.Txs, txInfo TxInfo) []error {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	errs := make([]error, len(txs))
+
+	if err := txmp.proxyAppConn.Error(); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	var (
+		indexes = make([]int, 0, len(txs))
+		reqs    = make([]*abci.RequestCheckTx, 0, len(txs))
+	)
+	for i, tx := range txs {
+		if err := txmp.validateTx(tx); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if !txmp.cache.Push(tx) {
+			txmp.txStore.GetOrSetPeerByTxHash(tx.Key(), txInfo.SenderID)
+			errs[i] = types.ErrTxInCache
+			continue
+		}
+
+		indexes = append(indexes, i)
+		reqs = append(reqs, &abci.RequestCheckTx{Tx: tx})
+	}
+
+	if len(reqs) == 0 {
+		return errs
+	}
+
+	res, err := txmp.proxyAppConn.CheckTxBatch(ctx, reqs)
+	var batchErr abciclient.CheckTxBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		for _, i := range indexes {
+			txmp.cache.Remove(txs[i])
+			errs[i] = err
+		}
+		return errs
+	}
+
+	for j, i := range indexes {
+		if batchErr.Errors != nil && batchErr.Errors[j] != nil {
+			txmp.cache.Remove(txs[i])
+			errs[i] = batchErr.Errors[j]
+			continue
+		}
+
+		wtx := &WrappedTx{
+			tx:        txs[i],
+			hash:      txs[i].Key(),
+			timestamp: time.Now().UTC(),
+			height:    txmp.height,
+		}
+		errs[i] = txmp.addNewTransaction(wtx, res[j], txInfo)
+	}
+
+	return errs
+}
+
+// belowMinGasPrice returns true, along with the gas price, if the gas price of
+// a transaction, derived from its CheckTx response, is below the mempool's
+// minimum. The gas price is the priority divided by the gas wanted, or the
+// priority alone if no gas is wanted.
+//
+// NOTE:
+// - The caller must hold at least a read-lock.
+func (txmp *TxMempool) belowMinGasPrice(res *abci.ResponseCheckTx) (float64, bool) {
+	if txmp.minGasPrice <= 0 {
+		return 0, false
+	}
+
+	price := float64(res.Priority)
+	if res.GasWanted > 0 {
+		price /= float64(res.GasWanted)
+	}
+	return price, price < txmp.minGasPrice
+}
+
+// validateTx performs the checks that do not require the application: the
+// transaction size limit, the blacklist and the optional pre-check.
+func (txmp *TxMempool) validateTx(tx types.Tx) error {
+	if txSize := len(tx); txSize > txmp.config.MaxTxBytes {
+		return types.ErrTxTooLarge{
+			Max:    txmp.config.MaxTxBytes,
+			Actual: txSize,
+		}
+	}
+
+	if len(txmp.blacklist) > 0 {
+		if _, ok := txmp.blacklist[tx.Key()]; ok {
+			return types.ErrTxBlacklisted
+		}
+	}
+
+	if txmp.preCheck != nil {
+		if err := txmp.preCheck(tx); err != nil {
+			return types.ErrPreCheck{Reason: err}
+		}
+	}
+
+	return nil
+}
+
 func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	txmp.Lock()
 	defer txmp.Unlock()
@@ -316,6 +482,40 @@ func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return errors.New("transaction not found")
 }
 
+// RemoveTxByHash removes the transaction with the given hash from the mempool,
+// e.g. to purge a transaction stuck in it. The transaction is also removed from
+// the cache and the WAL, so that it may be submitted again, unless blacklist
+// is true: CheckTx then rejects the transaction with ErrTxBlacklisted, until
+// the node restarts. It returns an error if the transaction isn't in the
+// mempool.
+//
+// It takes the write-lock, so the transaction is either removed before a
+// concurrent reap or recheck, or after it, in which case the recheck ignores it.
+func (txmp *TxMempool) RemoveTxByHash(txKey types.TxKey, blacklist bool) error {
+	txmp.Lock()
+	defer txmp.Unlock()
+
+	wtx := txmp.txStore.GetTxByHash(txKey)
+	if wtx == nil {
+		return errors.New("transaction not found")
+	}
+
+	txmp.removeTx(wtx, true)
+	if blacklist {
+		txmp.blacklist[txKey] = struct{}{}
+	}
+	txmp.metrics.RemovedTxs.With("blacklisted", strconv.FormatBool(blacklist)).Add(1)
+	txmp.metrics.Size.Set(float64(txmp.Size()))
+
+	if txmp.wal != nil {
+		txmp.scheduleWALCompaction()
+	}
+
+	txmp.logger.Info("removed transaction from the mempool",
+		"tx", fmt.Sprintf("%X", txKey), "blacklisted", blacklist)
+	return nil
+}
+
 func (txmp *TxMempool) HasTx(txKey types.TxKey) bool {
 	txmp.Lock()
 	defer txmp.Unlock()
@@ -346,6 +546,7 @@ func (txmp *TxMempool) Flush() {
 
 	txmp.heightIndex.Reset()
 	txmp.timestampIndex.Reset()
+	txmp.expiryIndex.Reset()
 
 	for _, wtx := range txmp.txStore.GetAllTxs() {
 		txmp.removeTx(wtx, false)
@@ -353,6 +554,156 @@ func (txmp *TxMempool) Flush() {
 
 	atomic.SwapInt64(&txmp.sizeBytes, 0)
 	txmp.cache.Reset()
+
+	if txmp.wal != nil {
+		if err := txmp.wal.Compact(nil); err != nil {
+			txmp.logger.Error("failed to flush the WAL", "err", err)
+		}
+	}
+}
+
+// LoadWAL opens the mempool WAL, if it is enabled, and checks the pending
+// transactions it holds again, adding the ones still valid back to the
+// mempool. Transactions accepted afterwards are logged to the WAL.
+func (txmp *TxMempool) LoadWAL(ctx context.Context) error {
+	if !txmp.config.WalEnabled() || txmp.wal != nil {
+		return nil
+	}
+
+	wal, err := openTxWAL(txmp.config.WalDir(), txmp.config.MaxWalSizeBytes)
+	if err != nil {
+		return err
+	}
+	txs, corrupt, err := wal.Load()
+	if err == nil {
+		// drop the committed transactions and the corrupt records, if any
+		err = wal.Compact(txs)
+	}
+	if err != nil {
+		wal.Close()
+		return err
+	}
+	if corrupt {
+		txmp.logger.Error("truncated corrupt mempool WAL", "path", wal.path)
+	}
+
+	// The replayed transactions are already in the WAL, so the WAL is only set
+	// once they are checked.
+	for _, tx := range txs {
+		if err := txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: UnknownPeerID}); err != nil {
+			if ctx.Err() != nil {
+				wal.Close()
+				return ctx.Err()
+			}
+			txmp.logger.Debug("failed to replay transaction from the WAL", "tx", fmt.Sprintf("%X", tx.Hash()), "err", err)
+		}
+	}
+	txmp.logger.Info("replayed mempool WAL", "txs", len(txs), "num_txs", txmp.Size())
+
+	txmp.mtx.Lock()
+	txmp.wal = wal
+	txmp.compactWALCh = make(chan struct{}, 1)
+	go txmp.compactWALRoutine(ctx, txmp.compactWALCh)
+	txmp.mtx.Unlock()
+	return nil
+}
+
+// CloseWAL closes the mempool WAL, if it was loaded.
+func (txmp *TxMempool) CloseWAL() error {
+	txmp.mtx.Lock()
+	defer txmp.mtx.Unlock()
+
+	if txmp.wal == nil {
+		return nil
+	}
+	close(txmp.compactWALCh)
+	txmp.compactWALCh = nil
+	err := txmp.wal.Close()
+	txmp.wal = nil
+	return err
+}
+
+// updateWAL logs the transactions committed in a block to the WAL, and
+// schedules a compaction of the WAL once it is mostly made of records that are
+// no longer needed, or is full.
+//
+// NOTE:
+// - The caller must have a write-lock on the mempool.
+func (txmp *TxMempool) updateWAL(blockTxs types.Txs) {
+	err := txmp.wal.WriteCommitted(blockTxs)
+	if err == nil && !txmp.wal.ShouldCompact(txmp.SizeBytes()) {
+		return
+	}
+	if err != nil && !errors.Is(err, errWALFull) {
+		txmp.logger.Error("failed to log committed transactions to the WAL", "err", err)
+	}
+	txmp.scheduleWALCompaction()
+}
+
+// scheduleWALCompaction signals the WAL compaction routine, unless a
+// compaction is already scheduled.
+//
+// NOTE:
+// - The caller must have a write-lock on the mempool.
+func (txmp *TxMempool) scheduleWALCompaction() {
+	select {
+	case txmp.compactWALCh <- struct{}{}:
+	default:
+	}
+}
+
+// compactWALRoutine compacts the WAL when signaled on compactCh, at most once
+// per walCompactInterval, until compactCh is closed.
+func (txmp *TxMempool) compactWALRoutine(ctx context.Context, compactCh <-chan struct{}) {
+	var last time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-compactCh:
+			if !ok {
+				return
+			}
+		}
+
+		if wait := walCompactInterval - time.Since(last); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		last = time.Now()
+		txmp.compactWAL()
+	}
+}
+
+// compactWAL rewrites the WAL with only the pending transactions. The pending
+// transactions are snapshotted under the mempool write-lock, but the WAL is
+// rewritten without holding it.
+func (txmp *TxMempool) compactWAL() {
+	txmp.mtx.Lock()
+	wal := txmp.wal
+	if wal == nil {
+		txmp.mtx.Unlock()
+		return
+	}
+	wal.StartCompact()
+
+	// keep the pending transactions in the order they were received in
+	wtxs := txmp.txStore.GetAllTxs()
+	txmp.mtx.Unlock()
+
+	sort.Slice(wtxs, func(i, j int) bool { return wtxs[i].timestamp.Before(wtxs[j].timestamp) })
+	txs := make(types.Txs, len(wtxs))
+	for i, wtx := range wtxs {
+		txs[i] = wtx.tx
+	}
+	if err := wal.FinishCompact(txs); err != nil {
+		txmp.logger.Error("failed to compact the WAL", "err", err)
+	}
 }
 
 // ReapMaxBytesMaxGas returns a list of transactions within the provided size
@@ -361,6 +712,8 @@ func (txmp *TxMempool) Flush() {
 // NOTE:
 //   - Transactions returned are not removed from the mempool transaction
 //     store or indexes.
+//   - Transactions whose recheck is still pending in the background are
+//     reaped as well; ReapMaxTxEntries reports them as PendingRecheck.
 func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
@@ -386,8 +739,11 @@ func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	}
 	for txmp.priorityIndex.NumTxs() > 0 {
 		wtx := txmp.priorityIndex.PopTx()
-		txs = append(txs, wtx.tx)
 		wTxs = append(wTxs, wtx)
+		if wtx.expiredAt(txmp.height + 1) {
+			continue
+		}
+		txs = append(txs, wtx.tx)
 		size := types.ComputeProtoSizeForTxs([]types.Tx{wtx.tx})
 
 		// Ensure we have capacity for the transaction with respect to the
@@ -420,6 +776,37 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
 
+	wTxs := txmp.reapMaxWrappedTxs(max)
+	txs := make([]types.Tx, 0, len(wTxs))
+	for _, wtx := range wTxs {
+		txs = append(txs, wtx.tx)
+	}
+	return txs
+}
+
+// ReapMaxTxEntries returns a list of transactions within the provided number
+// of transactions bound, along with the metadata the mempool tracks for each
+// of them. Transactions are returned in the same order as ReapMaxTxs.
+//
+// NOTE:
+//   - Transactions returned are not removed from the mempool transaction
+//     store or indexes.
+func (txmp *TxMempool) ReapMaxTxEntries(max int) []TxEntry {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	wTxs := txmp.reapMaxWrappedTxs(max)
+	entries := make([]TxEntry, 0, len(wTxs))
+	for _, wtx := range wTxs {
+		entries = append(entries, wtx.Entry())
+	}
+	return entries
+}
+
+// reapMaxWrappedTxs returns up to max unexpired transactions in priority
+// order, leaving the priority index unchanged. If max is negative, there is
+// no cap. The caller must hold at least a read-lock.
+func (txmp *TxMempool) reapMaxWrappedTxs(max int) []*WrappedTx {
 	numTxs := txmp.priorityIndex.NumTxs()
 	if max < 0 {
 		max = numTxs
@@ -427,19 +814,22 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 
 	cap := tmmath.MinInt(numTxs, max)
 
-	// wTxs contains a list of *WrappedTx retrieved from the priority queue that
-	// need to be re-enqueued prior to returning.
+	// popped contains a list of *WrappedTx retrieved from the priority queue
+	// that need to be re-enqueued prior to returning.
+	popped := make([]*WrappedTx, 0, cap)
 	wTxs := make([]*WrappedTx, 0, cap)
-	txs := make([]types.Tx, 0, cap)
-	for txmp.priorityIndex.NumTxs() > 0 && len(txs) < max {
+	for txmp.priorityIndex.NumTxs() > 0 && len(wTxs) < max {
 		wtx := txmp.priorityIndex.PopTx()
-		txs = append(txs, wtx.tx)
+		popped = append(popped, wtx)
+		if wtx.expiredAt(txmp.height + 1) {
+			continue
+		}
 		wTxs = append(wTxs, wtx)
 	}
-	for _, wtx := range wTxs {
+	for _, wtx := range popped {
 		txmp.priorityIndex.PushTx(wtx)
 	}
-	return txs
+	return wTxs
 }
 
 // Update iterates over all the transactions provided by the block producer,
@@ -487,6 +877,10 @@ func (txmp *TxMempool) Update(
 
 	txmp.purgeExpiredTxs(blockHeight)
 
+	if txmp.wal != nil {
+		txmp.updateWAL(blockTxs)
+	}
+
 	// If there any uncommitted transactions left in the mempool, we either
 	// initiate re-CheckTx per remaining transaction or notify that remaining
 	// transactions are left.
@@ -499,6 +893,12 @@ func (txmp *TxMempool) Update(
 			)
 			txmp.updateReCheckTxs(ctx)
 		} else {
+			// Without a recheck, transactions still pending from a previous
+			// one are not checked again.
+			for _, wtx := range txmp.txStore.GetAllTxs() {
+				wtx.pendingRecheck = false
+			}
+			txmp.metrics.RecheckBacklog.Set(0)
 			txmp.notifyTxsAvailable()
 		}
 	}
@@ -561,10 +961,48 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, res *abci.ResponseCheck
 		return err
 	}
 
+	if price, below := txmp.belowMinGasPrice(res); below {
+		// The transaction stays in the cache so that peers gossiping it again
+		// do not trigger another CheckTx.
+		txmp.logger.Debug(
+			"rejected transaction below minimum gas price",
+			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"priority", res.Priority,
+			"gas_wanted", res.GasWanted,
+			"min_gas_price", txmp.minGasPrice,
+		)
+		txmp.metrics.LowGasPriceTxs.Add(1)
+		return types.ErrTxBelowMinGasPrice{GasPrice: price, MinGasPrice: txmp.minGasPrice}
+	}
+
 	sender := res.Sender
 	priority := res.Priority
 
-	if len(sender) > 0 {
+	if txmp.expiryHeight != nil {
+		wtx.expiryHeight = txmp.expiryHeight(wtx.tx, res)
+		if wtx.expiredAt(txmp.height + 1) {
+			txmp.logger.Debug(
+				"rejected incoming good transaction; tx expired",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"expiry_height", wtx.expiryHeight,
+				"height", txmp.height,
+			)
+			txmp.metrics.RejectedTxs.Add(1)
+			return nil
+		}
+	}
+
+	var replaced *WrappedTx
+	if txmp.config.TxReplacement {
+		// With transaction replacement a sender may have one pending transaction
+		// per sequence instead of one in total.
+		wtx.replacementKey = replacementKey(sender, res.Sequence)
+		var ok bool
+		if replaced, ok = txmp.replaceableTx(wtx, priority); !ok {
+			txmp.metrics.RejectedTxs.Add(1)
+			return nil
+		}
+	} else if len(sender) > 0 {
 		if wtx := txmp.txStore.GetTxBySender(sender); wtx != nil {
 			txmp.logger.Error(
 				"rejected incoming good transaction; tx already exists for sender",
@@ -614,6 +1052,20 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, res *abci.ResponseCheck
 		}
 	}
 
+	// The replaced transaction is only removed once the new one is admitted, it
+	// may already have been evicted above.
+	if replaced != nil && !replaced.removed {
+		txmp.removeTx(replaced, true)
+		txmp.logger.Debug(
+			"replaced existing good transaction",
+			"old_tx", fmt.Sprintf("%X", replaced.tx.Hash()),
+			"old_priority", replaced.priority,
+			"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"new_priority", priority,
+		)
+		txmp.metrics.ReplacedTxs.Add(1)
+	}
+
 	wtx.gasWanted = res.GasWanted
 	wtx.priority = priority
 	wtx.sender = sender
@@ -624,6 +1076,12 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, res *abci.ResponseCheck
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.Size()))
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 
+	if txmp.wal != nil {
+		if err := txmp.wal.WriteTx(wtx.tx); err != nil && !errors.Is(err, errWALFull) {
+			txmp.logger.Error("failed to log transaction to the WAL", "tx", fmt.Sprintf("%X", wtx.tx.Hash()), "err", err)
+		}
+	}
+
 	txmp.insertTx(wtx)
 	txmp.logger.Debug(
 		"inserted good transaction",
@@ -636,6 +1094,40 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, res *abci.ResponseCheck
 	return nil
 }
 
+// replaceableTx returns the pending transaction with the same replacement key
+// as wtx, if any. It returns false if wtx must be rejected because the pending
+// transaction has an equal or higher priority than the given priority of wtx.
+func (txmp *TxMempool) replaceableTx(wtx *WrappedTx, priority int64) (*WrappedTx, bool) {
+	if len(wtx.replacementKey) == 0 {
+		return nil, true
+	}
+	old := txmp.txStore.GetTxByReplacementKey(wtx.replacementKey)
+	if old == nil {
+		return nil, true
+	}
+	if priority <= old.priority {
+		txmp.logger.Debug(
+			"rejected incoming good transaction; replacement priority too low",
+			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"priority", priority,
+			"existing_tx", fmt.Sprintf("%X", old.tx.Hash()),
+			"existing_priority", old.priority,
+		)
+		return nil, false
+	}
+	return old, true
+}
+
+// replacementKey returns the key under which transactions replace each other,
+// i.e. the sender and sequence returned by CheckTx. Transactions without a
+// sender cannot be replaced.
+func replacementKey(sender string, sequence uint64) string {
+	if len(sender) == 0 {
+		return ""
+	}
+	return sender + "/" + strconv.FormatUint(sequence, 10)
+}
+
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
 // during the recheck phase of a block Update.  It removes any transactions
 // invalidated by the application.
@@ -685,33 +1177,10 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, res *abci.ResponseCheckT
 		wtx = txmp.recheckCursor.Value.(*WrappedTx)
 	}
 
-	// Only evaluate transactions that have not been removed. This can happen
-	// if an existing transaction is evicted during CheckTx and while this
-	// callback is being executed for the same evicted transaction.
-	if !txmp.txStore.IsTxRemoved(wtx.hash) {
-		var err error
-		if txmp.postCheck != nil {
-			err = txmp.postCheck(tx, res)
-		}
-
-		if res.Code == abci.CodeTypeOK && err == nil {
-			wtx.priority = res.Priority
-		} else {
-			txmp.logger.Debug(
-				"existing transaction no longer valid; failed re-CheckTx callback",
-				"priority", wtx.priority,
-				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"err", err,
-				"code", res.Code,
-			)
-
-			if wtx.gossipEl != txmp.recheckCursor {
-				panic("corrupted reCheckTx cursor")
-			}
-
-			txmp.removeTx(wtx, !txmp.config.KeepInvalidTxsInCache)
-		}
+	if !txmp.txStore.IsTxRemoved(wtx.hash) && wtx.gossipEl != txmp.recheckCursor {
+		panic("corrupted reCheckTx cursor")
 	}
+	txmp.applyRecheckResult(wtx, res)
 
 	// move reCheckTx cursor to next element
 	if txmp.recheckCursor == txmp.recheckEnd {
@@ -731,10 +1200,9 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, res *abci.ResponseCheckT
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 }
 
-// updateReCheckTxs updates the recheck cursors using the gossipIndex. For
-// each transaction, it executes CheckTx. The global callback defined on
-// the proxyAppConn will be executed for each transaction after CheckTx is
-// executed.
+// updateReCheckTxs updates the recheck cursors using the gossipIndex. All
+// remaining transactions are rechecked with a single CheckTxBatch call and
+// the responses are handled in gossip order.
 //
 // NOTE:
 // - The caller must have a write-lock when executing updateReCheckTxs.
@@ -751,28 +1219,171 @@ func (txmp *TxMempool) updateReCheckTxs(ctx context.Context) {
 	txmp.recheckCursor = txmp.gossipIndex.Front()
 	txmp.recheckEnd = txmp.gossipIndex.Back()
 
+	var (
+		wtxs []*WrappedTx
+		reqs []*abci.RequestCheckTx
+	)
 	for e := txmp.gossipIndex.Front(); e != nil; e = e.Next() {
 		wtx := e.Value.(*WrappedTx)
 
 		// Only execute CheckTx if the transaction is not marked as removed which
 		// could happen if the transaction was evicted.
 		if !txmp.txStore.IsTxRemoved(wtx.hash) {
-			res, err := txmp.proxyAppConn.CheckTx(ctx, &abci.RequestCheckTx{
+			wtxs = append(wtxs, wtx)
+			reqs = append(reqs, &abci.RequestCheckTx{
 				Tx:   wtx.tx,
 				Type: abci.CheckTxType_Recheck,
 			})
-			if err != nil {
-				// no need in retrying since the tx will be rechecked after the next block
-				txmp.logger.Debug("failed to execute CheckTx during recheck", "err", err, "hash", fmt.Sprintf("%x", wtx.tx.Hash()))
-				continue
+		}
+	}
+
+	// Past the configured cap, transactions are rechecked in the background
+	// and flagged until then.
+	var backlog []*WrappedTx
+	if max := txmp.config.MaxRecheckTxs; max > 0 && len(wtxs) > max {
+		backlog = wtxs[max:]
+		wtxs, reqs = wtxs[:max], reqs[:max]
+		txmp.recheckEnd = wtxs[max-1].gossipEl
+		for _, wtx := range backlog {
+			wtx.pendingRecheck = true
+		}
+	}
+	txmp.metrics.RecheckBacklog.Set(float64(len(backlog)))
+
+	if len(reqs) > 0 {
+		res, err := txmp.proxyAppConn.CheckTxBatch(ctx, reqs)
+		var batchErr abciclient.CheckTxBatchError
+		if err != nil && !errors.As(err, &batchErr) {
+			// no need in retrying since the txs will be rechecked after the next block
+			txmp.logger.Debug("failed to execute CheckTx during recheck", "err", err, "num_txs", len(reqs))
+		} else {
+			for i, wtx := range wtxs {
+				if batchErr.Errors != nil && batchErr.Errors[i] != nil {
+					txmp.logger.Debug(
+						"failed to execute CheckTx during recheck",
+						"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+						"err", batchErr.Errors[i],
+					)
+					continue
+				}
+				txmp.handleRecheckResult(wtx.tx, res[i])
 			}
-			txmp.handleRecheckResult(wtx.tx, res)
 		}
 	}
 
 	if err := txmp.proxyAppConn.Flush(ctx); err != nil {
 		txmp.logger.Error("failed to flush transactions during rechecking", "err", err)
 	}
+
+	if len(backlog) > 0 {
+		txmp.logger.Debug(
+			"deferring re-CheckTx of transactions to the background",
+			"num_txs", len(backlog),
+			"height", txmp.height,
+		)
+		go txmp.recheckBacklog(ctx, txmp.height, backlog)
+	}
+}
+
+// recheckBacklog rechecks the transactions deferred by updateReCheckTxs after
+// the block at the given height, in batches of at most MaxRecheckTxs, taking
+// the mempool write-lock for each batch. A batch that fails is skipped, its
+// transactions staying flagged until the next block. It stops if another
+// block is committed in the meantime, since Update then rechecks the
+// remaining transactions anew.
+func (txmp *TxMempool) recheckBacklog(ctx context.Context, height int64, backlog []*WrappedTx) {
+	for len(backlog) > 0 && ctx.Err() == nil {
+		n := tmmath.MinInt(txmp.config.MaxRecheckTxs, len(backlog))
+		if !txmp.recheckBacklogBatch(ctx, height, backlog[:n]) {
+			return
+		}
+		backlog = backlog[n:]
+		txmp.metrics.RecheckBacklog.Set(float64(len(backlog)))
+	}
+}
+
+// recheckBacklogBatch rechecks a batch of transactions deferred after the
+// block at the given height. It returns false if another block was committed
+// in the meantime.
+func (txmp *TxMempool) recheckBacklogBatch(ctx context.Context, height int64, batch []*WrappedTx) bool {
+	txmp.Lock()
+	defer txmp.Unlock()
+
+	if txmp.height != height {
+		return false
+	}
+
+	var (
+		wtxs []*WrappedTx
+		reqs []*abci.RequestCheckTx
+	)
+	for _, wtx := range batch {
+		if !txmp.txStore.IsTxRemoved(wtx.hash) {
+			wtxs = append(wtxs, wtx)
+			reqs = append(reqs, &abci.RequestCheckTx{
+				Tx:   wtx.tx,
+				Type: abci.CheckTxType_Recheck,
+			})
+		}
+	}
+	if len(reqs) == 0 {
+		return true
+	}
+
+	res, err := txmp.proxyAppConn.CheckTxBatch(ctx, reqs)
+	var batchErr abciclient.CheckTxBatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		// the txs stay flagged, and will be rechecked after the next block
+		txmp.logger.Debug("failed to execute CheckTx during background recheck", "err", err, "num_txs", len(reqs))
+		return true
+	}
+	for i, wtx := range wtxs {
+		if batchErr.Errors != nil && batchErr.Errors[i] != nil {
+			// the tx stays flagged, and will be rechecked after the next block
+			txmp.logger.Debug(
+				"failed to execute CheckTx during background recheck",
+				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"err", batchErr.Errors[i],
+			)
+			continue
+		}
+		txmp.metrics.RecheckTimes.Add(1)
+		txmp.applyRecheckResult(wtx, res[i])
+	}
+	txmp.metrics.Size.Set(float64(txmp.Size()))
+	return true
+}
+
+// applyRecheckResult updates the priority of a rechecked transaction, or
+// removes it from the mempool if it is no longer valid. Transactions removed
+// in the meantime, e.g. evicted during CheckTx, are ignored.
+//
+// NOTE:
+// - The caller must have a write-lock.
+func (txmp *TxMempool) applyRecheckResult(wtx *WrappedTx, res *abci.ResponseCheckTx) {
+	if txmp.txStore.IsTxRemoved(wtx.hash) {
+		return
+	}
+
+	var err error
+	if txmp.postCheck != nil {
+		err = txmp.postCheck(wtx.tx, res)
+	}
+
+	if res.Code == abci.CodeTypeOK && err == nil {
+		wtx.priority = res.Priority
+		wtx.pendingRecheck = false
+		return
+	}
+
+	txmp.logger.Debug(
+		"existing transaction no longer valid; failed re-CheckTx callback",
+		"priority", wtx.priority,
+		"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+		"err", err,
+		"code", res.Code,
+	)
+	txmp.removeTx(wtx, !txmp.config.KeepInvalidTxsInCache)
 }
 
 // canAddTx returns an error if we cannot insert the provided *WrappedTx into
@@ -801,6 +1412,9 @@ func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	txmp.priorityIndex.PushTx(wtx)
 	txmp.heightIndex.Insert(wtx)
 	txmp.timestampIndex.Insert(wtx)
+	if wtx.expiryHeight > 0 {
+		txmp.expiryIndex.Insert(wtx)
+	}
 
 	// Insert the transaction into the gossip index and mark the reference to the
 	// linked-list element, which will be needed at a later point when the
@@ -820,6 +1434,9 @@ func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
 	txmp.priorityIndex.RemoveTx(wtx)
 	txmp.heightIndex.Remove(wtx)
 	txmp.timestampIndex.Remove(wtx)
+	if wtx.expiryHeight > 0 {
+		txmp.expiryIndex.Remove(wtx)
+	}
 
 	// Remove the transaction from the gossip index and cleanup the linked-list
 	// element so it can be garbage collected.
@@ -834,8 +1451,9 @@ func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
 }
 
 // purgeExpiredTxs removes all transactions that have exceeded their respective
-// height- and/or time-based TTLs from their respective indexes. Every expired
-// transaction will be removed from the mempool, but preserved in the cache.
+// height- and/or time-based TTLs, or their own expiry height, from their
+// respective indexes. Every expired transaction will be removed from the
+// mempool, but preserved in the cache.
 //
 // NOTE: purgeExpiredTxs must only be called during TxMempool#Update in which
 // the caller has a write-lock on the mempool and so we can safely iterate over
@@ -878,6 +1496,21 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 		}
 	}
 
+	purgeIdx := -1
+	for i, wtx := range txmp.expiryIndex.txs {
+		if wtx.expiredAt(blockHeight + 1) {
+			expiredTxs[wtx.tx.Key()] = wtx
+			purgeIdx = i
+		} else {
+			// since the index is sorted, we know no other txs can be be purged
+			break
+		}
+	}
+
+	if purgeIdx >= 0 {
+		txmp.expiryIndex.txs = txmp.expiryIndex.txs[purgeIdx+1:]
+	}
+
 	for _, wtx := range expiredTxs {
 		txmp.removeTx(wtx, false)
 	}
@@ -41,6 +41,7 @@ func (app *application) CheckTx(_ context.Context, req *abci.RequestCheckTx) (*a
 	var (
 		priority int64
 		sender   string
+		sequence uint64
 	)
 
 	// infer the priority from the raw transaction value (sender=key=value)
@@ -57,6 +58,18 @@ func (app *application) CheckTx(_ context.Context, req *abci.RequestCheckTx) (*a
 
 		priority = v
 		sender = string(parts[0])
+		// a sender of the form sender/nonce-N returns N as the sequence
+		if i := strings.Index(sender, "/nonce-"); i >= 0 {
+			sequence, err = strconv.ParseUint(sender[i+len("/nonce-"):], 10, 64)
+			if err != nil {
+				return &abci.ResponseCheckTx{
+					Priority:  priority,
+					Code:      100,
+					GasWanted: 1,
+				}, nil
+			}
+			sender = sender[:i]
+		}
 	} else {
 		return &abci.ResponseCheckTx{
 			Priority:  priority,
@@ -68,6 +81,7 @@ func (app *application) CheckTx(_ context.Context, req *abci.RequestCheckTx) (*a
 	return &abci.ResponseCheckTx{
 		Priority:  priority,
 		Sender:    sender,
+		Sequence:  sequence,
 		Code:      code.CodeTypeOK,
 		GasWanted: 1,
 	}, nil
@@ -269,6 +283,51 @@ func TestTxMempool_Flush(t *testing.T) {
 	require.Equal(t, int64(0), txmp.SizeBytes())
 }
 
+func TestTxMempool_WAL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 0)
+	txmp.config.WalPath = "data/mempool"
+	require.NoError(t, txmp.LoadWAL(ctx))
+
+	txs := convertTex(checkTxs(ctx, t, txmp, 100, 0))
+	responses := make([]*abci.ExecTxResult, 50)
+	for i := 0; i < len(responses); i++ {
+		responses[i] = &abci.ExecTxResult{Code: abci.CodeTypeOK}
+	}
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 1, txs[:50], responses, nil, nil, true))
+	txmp.Unlock()
+	require.NoError(t, txmp.CloseWAL())
+
+	// the pending transactions are replayed into a new mempool
+	txmp = NewTxMempool(log.NewNopLogger(), txmp.config, client, NewTestPeerEvictor())
+	require.NoError(t, txmp.LoadWAL(ctx))
+	require.Equal(t, 50, txmp.Size())
+	for _, tx := range txs[:50] {
+		require.False(t, txmp.HasTx(tx.Key()))
+	}
+	for _, tx := range txs[50:] {
+		require.True(t, txmp.HasTx(tx.Key()))
+	}
+
+	// flushing the mempool also empties the WAL
+	txmp.Flush()
+	require.NoError(t, txmp.CloseWAL())
+	txmp = NewTxMempool(log.NewNopLogger(), txmp.config, client, NewTestPeerEvictor())
+	require.NoError(t, txmp.LoadWAL(ctx))
+	require.Zero(t, txmp.Size())
+	require.NoError(t, txmp.CloseWAL())
+}
+
 func TestTxMempool_ReapMaxBytesMaxGas(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -386,6 +445,47 @@ func TestTxMempool_ReapMaxTxs(t *testing.T) {
 	require.Len(t, reapedTxs, len(tTxs)/2)
 }
 
+func TestTxMempool_ReapMaxTxEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 0)
+	tTxs := checkTxs(ctx, t, txmp, 100, 0)
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	txMap := make(map[types.TxKey]testTx)
+	for _, tTx := range tTxs {
+		txMap[tTx.tx.Key()] = tTx
+	}
+
+	// entries are returned in the same order as ReapMaxTxs
+	entries := txmp.ReapMaxTxEntries(-1)
+	require.Len(t, entries, len(tTxs))
+	reapedTxs := txmp.ReapMaxTxs(-1)
+	for i, entry := range entries {
+		require.Equal(t, reapedTxs[i], entry.Tx)
+		require.Equal(t, entry.Tx.Key(), entry.Hash)
+
+		tTx, ok := txMap[entry.Hash]
+		require.True(t, ok)
+		require.Equal(t, tTx.priority, entry.Priority)
+		require.Equal(t, string(bytes.Split(entry.Tx, []byte("="))[0]), entry.Sender)
+		require.Equal(t, int64(1), entry.GasWanted)
+		require.False(t, entry.Timestamp.IsZero())
+	}
+
+	// reaping entries leaves the mempool untouched
+	require.Len(t, txmp.ReapMaxTxEntries(len(tTxs)/2), len(tTxs)/2)
+	require.Equal(t, len(tTxs), txmp.Size())
+	require.Equal(t, int64(5690), txmp.SizeBytes())
+}
+
 func TestTxMempool_CheckTxExceedsMaxSize(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -466,6 +566,445 @@ func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	require.Equal(t, 1, txmp.Size())
 }
 
+// batchApplication wraps application with support for CheckTxBatch and
+// records the size of every batch it receives.
+type batchApplication struct {
+	*application
+
+	batches []int
+}
+
+func (app *batchApplication) CheckTxBatch(ctx context.Context, reqs []*abci.RequestCheckTx) ([]*abci.ResponseCheckTx, error) {
+	app.batches = append(app.batches, len(reqs))
+
+	out := make([]*abci.ResponseCheckTx, len(reqs))
+	for i, req := range reqs {
+		res, err := app.CheckTx(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = res
+	}
+	return out, nil
+}
+
+func TestTxMempool_CheckTxBatch(t *testing.T) {
+	batchApp := &batchApplication{application: &application{Application: kvstore.NewApplication()}}
+
+	testCases := map[string]abci.Application{
+		"batching application": batchApp,
+		"fallback to CheckTx":  &application{Application: kvstore.NewApplication()},
+	}
+
+	for name, app := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+			require.NoError(t, client.Start(ctx))
+			t.Cleanup(client.Wait)
+
+			txmp := setup(t, client, 100)
+
+			txs := types.Txs{
+				types.Tx("sender-0=key0=10"),
+				types.Tx("bad-tx"),
+				types.Tx("sender-0=key0=10"),
+				make(types.Tx, txmp.config.MaxTxBytes+1),
+				types.Tx("sender-1=key1=20"),
+			}
+
+			errs := txmp.CheckTxBatch(ctx, txs, TxInfo{SenderID: 0})
+			require.Len(t, errs, len(txs))
+			require.NoError(t, errs[0])
+			require.NoError(t, errs[1])
+			require.ErrorIs(t, errs[2], types.ErrTxInCache)
+			require.ErrorAs(t, errs[3], &types.ErrTxTooLarge{})
+			require.NoError(t, errs[4])
+
+			require.Equal(t, 2, txmp.Size())
+			require.True(t, txmp.HasTx(txs[0].Key()))
+			require.True(t, txmp.HasTx(txs[4].Key()))
+			require.False(t, txmp.HasTx(txs[1].Key()))
+		})
+	}
+
+	// only the txs that passed the local checks reached the application
+	require.Equal(t, []int{3}, batchApp.batches)
+}
+
+// failingApplication wraps application and fails the CheckTx call of every
+// transaction with the given content.
+type failingApplication struct {
+	*application
+
+	failTx types.Tx
+}
+
+func (app *failingApplication) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	if bytes.Equal(req.Tx, app.failTx) {
+		return nil, errors.New("check tx failed")
+	}
+	return app.application.CheckTx(ctx, req)
+}
+
+func TestTxMempool_CheckTxBatchPartialFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &failingApplication{
+		application: &application{Application: kvstore.NewApplication()},
+		failTx:      types.Tx("sender-1=key1=20"),
+	}
+	client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+
+	txs := types.Txs{
+		types.Tx("sender-0=key0=10"),
+		app.failTx,
+		types.Tx("sender-2=key2=30"),
+	}
+
+	// the failed call is reported for its tx only, and does not prevent the
+	// remaining txs from being checked
+	errs := txmp.CheckTxBatch(ctx, txs, TxInfo{SenderID: 0})
+	require.Len(t, errs, len(txs))
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.NoError(t, errs[2])
+
+	require.Equal(t, 2, txmp.Size())
+	require.False(t, txmp.HasTx(txs[1].Key()))
+
+	// and the failed tx was removed from the cache so it can be retried
+	app.failTx = nil
+	require.NoError(t, txmp.CheckTxBatch(ctx, txs[1:2], TxInfo{SenderID: 0})[0])
+	require.True(t, txmp.HasTx(txs[1].Key()))
+}
+
+func TestTxMempool_RecheckPartialFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &failingApplication{application: &application{Application: kvstore.NewApplication()}}
+	client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+
+	txs := types.Txs{
+		types.Tx("sender-0=key0=10"),
+		types.Tx("sender-1=key1=20"),
+		types.Tx("sender-2=key2=30"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	}
+
+	// the recheck of one tx fails, and another one is no longer valid
+	app.failTx = txs[1]
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.Equal(tx, txs[2]) {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	// the results of the other txs are still applied, and the tx whose recheck
+	// failed is kept
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 1, nil, nil, nil, postCheck, true))
+	txmp.Unlock()
+	require.Equal(t, 2, txmp.Size())
+	require.True(t, txmp.HasTx(txs[0].Key()))
+	require.True(t, txmp.HasTx(txs[1].Key()))
+	require.False(t, txmp.HasTx(txs[2].Key()))
+}
+
+func TestTxMempool_MinGasPrice(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	txmp.SetMinGasPrice(10)
+	require.Equal(t, float64(10), txmp.MinGasPrice())
+
+	// the test application wants 1 gas per tx, so the gas price is the priority
+	lowTx := types.Tx("sender-0=key0=5")
+	highTx := types.Tx("sender-1=key1=50")
+
+	err := txmp.CheckTx(ctx, lowTx, nil, TxInfo{SenderID: 0})
+	require.Equal(t, types.ErrTxBelowMinGasPrice{GasPrice: 5, MinGasPrice: 10}, err)
+	require.NoError(t, txmp.CheckTx(ctx, highTx, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 1, txmp.Size())
+	require.False(t, txmp.HasTx(lowTx.Key()))
+	require.True(t, txmp.HasTx(highTx.Key()))
+
+	// the dropped tx stays in the cache so it is not checked again
+	require.ErrorIs(t, txmp.CheckTx(ctx, lowTx, nil, TxInfo{SenderID: 1}), types.ErrTxInCache)
+
+	// lowering the minimum applies to txs checked afterwards
+	txmp.SetMinGasPrice(1)
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("sender-2=key2=5"), nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+}
+
+func TestTxMempool_RemoveTxByHash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	tx1 := types.Tx("sender-0=key0=5")
+	tx2 := types.Tx("sender-1=key1=5")
+	require.NoError(t, txmp.CheckTx(ctx, tx1, nil, TxInfo{SenderID: 0}))
+	require.NoError(t, txmp.CheckTx(ctx, tx2, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+
+	// the removed tx is also removed from the cache, so it may be resubmitted
+	require.NoError(t, txmp.RemoveTxByHash(tx1.Key(), false))
+	require.Equal(t, 1, txmp.Size())
+	require.False(t, txmp.HasTx(tx1.Key()))
+	require.NoError(t, txmp.CheckTx(ctx, tx1, nil, TxInfo{SenderID: 0}))
+	require.True(t, txmp.HasTx(tx1.Key()))
+
+	// a blacklisted tx is rejected afterwards
+	require.NoError(t, txmp.RemoveTxByHash(tx2.Key(), true))
+	require.False(t, txmp.HasTx(tx2.Key()))
+	require.ErrorIs(t, txmp.CheckTx(ctx, tx2, nil, TxInfo{SenderID: 0}), types.ErrTxBlacklisted)
+	require.Equal(t, 1, txmp.Size())
+
+	require.Error(t, txmp.RemoveTxByHash(tx2.Key(), false))
+}
+
+func TestTxMempool_TxReplacement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+
+	// without replacement only one tx per sender is accepted
+	tx := types.Tx("sender-0/nonce-1=key0=10")
+	require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("sender-0/nonce-2=key0=10"), nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 1, txmp.Size())
+
+	// with replacement a sender has one tx per sequence returned by CheckTx
+	txmp.Flush()
+	txmp.config.TxReplacement = true
+	require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("sender-0/nonce-2=key0=10"), nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+
+	// a replacement with the same priority is rejected
+	sameTx := types.Tx("sender-0/nonce-1=key1=10")
+	require.NoError(t, txmp.CheckTx(ctx, sameTx, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+	require.True(t, txmp.HasTx(tx.Key()))
+	require.False(t, txmp.HasTx(sameTx.Key()))
+
+	// a replacement with a higher priority evicts the pending tx
+	higherTx := types.Tx("sender-0/nonce-1=key2=20")
+	require.NoError(t, txmp.CheckTx(ctx, higherTx, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+	require.False(t, txmp.HasTx(tx.Key()))
+	require.True(t, txmp.HasTx(higherTx.Key()))
+	require.Equal(t, higherTx, txmp.ReapMaxTxs(1)[0])
+
+	// the replaced tx is evicted from the cache, and rejected if received again
+	require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 1}))
+	require.False(t, txmp.HasTx(tx.Key()))
+
+	// the same sequence from another sender does not replace anything
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("sender-1/nonce-1=key3=30"), nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 3, txmp.Size())
+	require.True(t, txmp.HasTx(higherTx.Key()))
+}
+
+func TestTxMempool_TxReplacementMempoolFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	txmp.config.TxReplacement = true
+
+	otherTx := types.Tx("sender-1=key1=50")
+	tx := types.Tx("sender-0/nonce-1=key0=10")
+	require.NoError(t, txmp.CheckTx(ctx, otherTx, nil, TxInfo{SenderID: 0}))
+	require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	txmp.config.MaxTxsBytes = int64(len(otherTx) + len(tx))
+
+	// the replacement does not fit even after evicting the tx it replaces, so
+	// the pending tx is kept
+	largeTx := types.Tx("sender-0/nonce-1=" + strings.Repeat("k", 64) + "=20")
+	require.NoError(t, txmp.CheckTx(ctx, largeTx, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+	require.True(t, txmp.HasTx(tx.Key()))
+	require.False(t, txmp.HasTx(largeTx.Key()))
+
+	// a replacement that fits evicts the pending tx
+	higherTx := types.Tx("sender-0/nonce-1=key2=20")
+	require.NoError(t, txmp.CheckTx(ctx, higherTx, nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 2, txmp.Size())
+	require.False(t, txmp.HasTx(tx.Key()))
+	require.True(t, txmp.HasTx(higherTx.Key()))
+}
+
+func TestTxMempool_MaxRecheckTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	txmp.config.MaxRecheckTxs = 2
+
+	txs := make(types.Txs, 5)
+	for i := range txs {
+		txs[i] = types.Tx(fmt.Sprintf("sender-%d=key%d=%d", i, i, 10*(i+1)))
+		require.NoError(t, txmp.CheckTx(ctx, txs[i], nil, TxInfo{SenderID: 0}))
+	}
+
+	// the highest priority tx becomes invalid, but is past the recheck cap
+	invalidTx := txs[4]
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.Equal(tx, invalidTx) {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	// prevent the background recheck from running by canceling its context
+	updateCtx, updateCancel := context.WithCancel(ctx)
+	txmp.Lock()
+	require.NoError(t, txmp.Update(updateCtx, 1, nil, nil, nil, postCheck, true))
+	updateCancel()
+	txmp.Unlock()
+
+	pending := 0
+	for _, entry := range txmp.ReapMaxTxEntries(-1) {
+		if entry.PendingRecheck {
+			pending++
+		}
+	}
+	require.Equal(t, 3, pending)
+	require.Equal(t, 5, txmp.Size())
+
+	// the txs pending a recheck, including the invalid one, can still be
+	// reaped for a proposal
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, -1), 5)
+
+	// the background recheck of the next block removes the invalid tx
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 2, nil, nil, nil, nil, true))
+	txmp.Unlock()
+	require.Eventually(t, func() bool {
+		return txmp.Size() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, entry := range txmp.ReapMaxTxEntries(-1) {
+		require.False(t, entry.PendingRecheck)
+	}
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, -1), 4)
+}
+
+// failingBatchApplication wraps batchApplication and fails every batch that
+// contains the given transaction.
+type failingBatchApplication struct {
+	*batchApplication
+
+	failTx types.Tx
+}
+
+func (app *failingBatchApplication) CheckTxBatch(ctx context.Context, reqs []*abci.RequestCheckTx) ([]*abci.ResponseCheckTx, error) {
+	for _, req := range reqs {
+		if bytes.Equal(req.Tx, app.failTx) {
+			return nil, errors.New("check tx batch failed")
+		}
+	}
+	return app.batchApplication.CheckTxBatch(ctx, reqs)
+}
+
+func TestTxMempool_MaxRecheckTxsFailedBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &failingBatchApplication{
+		batchApplication: &batchApplication{application: &application{Application: kvstore.NewApplication()}},
+	}
+	client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	txmp.config.MaxRecheckTxs = 1
+
+	txs := types.Txs{
+		types.Tx("sender-0=key0=10"),
+		types.Tx("sender-1=key1=20"),
+		types.Tx("sender-2=key2=30"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	}
+
+	// the background batch of the second tx fails, and the third tx is no
+	// longer valid
+	app.failTx = txs[1]
+	postCheck := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.Equal(tx, txs[2]) {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	// the failed batch doesn't prevent the rest of the backlog from being
+	// rechecked, and its tx stays flagged
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 1, nil, nil, nil, postCheck, true))
+	txmp.Unlock()
+	require.Eventually(t, func() bool {
+		return !txmp.HasTx(txs[2].Key())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	entries := txmp.ReapMaxTxEntries(-1)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		require.Equal(t, entry.Hash == txs[1].Key(), entry.PendingRecheck)
+	}
+}
+
 func TestTxMempool_ConcurrentTxs(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -598,6 +1137,53 @@ func TestTxMempool_ExpiredTxs_NumBlocks(t *testing.T) {
 	require.GreaterOrEqual(t, txmp.heightIndex.Size(), 45)
 }
 
+func TestTxMempool_ExpiredTxs_ExpiryHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	expiryHeights := map[string]int64{
+		"sender-a=key=1": 101,
+		"sender-b=key=2": 102,
+		"sender-c=key=3": 0,
+		"sender-d=key=4": 100,
+	}
+	txmp := setup(t, client, 500, WithTxExpiryHeight(func(tx types.Tx, _ *abci.ResponseCheckTx) int64 {
+		return expiryHeights[string(tx)]
+	}))
+	txmp.height = 100
+
+	for tx := range expiryHeights {
+		require.NoError(t, txmp.CheckTx(ctx, types.Tx(tx), nil, TxInfo{}))
+	}
+
+	// the transaction that expired at the current height is rejected
+	require.Equal(t, 3, txmp.Size())
+	require.Equal(t, 2, txmp.expiryIndex.Size())
+	require.Len(t, txmp.ReapMaxTxs(-1), 3)
+
+	update := func(height int64) {
+		txmp.Lock()
+		require.NoError(t, txmp.Update(ctx, height, nil, nil, nil, nil, false))
+		txmp.Unlock()
+	}
+
+	update(101)
+	require.Equal(t, 2, txmp.Size())
+	require.Equal(t, 1, txmp.expiryIndex.Size())
+	require.ElementsMatch(t, types.Txs{types.Tx("sender-c=key=3"), types.Tx("sender-b=key=2")}, txmp.ReapMaxTxs(-1))
+
+	update(102)
+	require.Equal(t, 1, txmp.Size())
+	require.Equal(t, 0, txmp.expiryIndex.Size())
+	require.Equal(t, types.Txs{types.Tx("sender-c=key=3")}, txmp.ReapMaxBytesMaxGas(-1, -1))
+}
+
 func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -727,4 +1313,4 @@ func TestAppendCheckTxErr(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, len(data), 1)
 	require.Equal(t, data[0]["log"], "sample error msg")
-}
\ No newline at end of file
+}
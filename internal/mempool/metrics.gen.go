@@ -52,16 +52,44 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		LowGasPriceTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "low_gas_price_txs",
+			Help:      "Number of transactions dropped for a gas price below the minimum.",
+		}, labels).With(labelsAndValues...),
+		ReplacedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replaced_txs",
+			Help:      "Number of transactions replaced by a higher priority transaction.",
+		}, labels).With(labelsAndValues...),
+		RemovedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "removed_txs",
+			Help:      "Number of transactions removed by the operator.",
+		}, append(labels, "blacklisted")).With(labelsAndValues...),
+		RecheckBacklog: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_backlog",
+			Help:      "Number of transactions waiting to be rechecked in the background.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:           discard.NewGauge(),
+		TxSizeBytes:    discard.NewHistogram(),
+		FailedTxs:      discard.NewCounter(),
+		RejectedTxs:    discard.NewCounter(),
+		EvictedTxs:     discard.NewCounter(),
+		RecheckTimes:   discard.NewCounter(),
+		LowGasPriceTxs: discard.NewCounter(),
+		ReplacedTxs:    discard.NewCounter(),
+		RemovedTxs:     discard.NewCounter(),
+		RecheckBacklog: discard.NewGauge(),
 	}
 }
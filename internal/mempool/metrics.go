@@ -40,4 +40,28 @@ type Metrics struct {
 
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// LowGasPriceTxs defines the number of transactions that passed CheckTx
+	// but were dropped because their gas price is below the configured
+	// minimum.
+	//metrics:Number of transactions dropped for a gas price below the minimum.
+	LowGasPriceTxs metrics.Counter
+
+	// ReplacedTxs defines the number of transactions that were removed from
+	// the mempool because a transaction with the same replacement key and a
+	// higher priority passed CheckTx.
+	//metrics:Number of transactions replaced by a higher priority transaction.
+	ReplacedTxs metrics.Counter
+
+	// RemovedTxs defines the number of transactions removed from the mempool
+	// by the operator through RemoveTxByHash, labeled by whether they were
+	// blacklisted.
+	//metrics:Number of transactions removed by the operator.
+	RemovedTxs metrics.Counter `metrics_labels:"blacklisted"`
+
+	// RecheckBacklog defines the number of transactions left to be rechecked
+	// in the background after a block, when the number of transactions
+	// rechecked synchronously is capped.
+	//metrics:Number of transactions waiting to be rechecked in the background.
+	RecheckBacklog metrics.Gauge
 }
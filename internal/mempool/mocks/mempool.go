@@ -108,6 +108,22 @@ func (_m *Mempool) ReapMaxBytesMaxGas(maxBytes int64, maxGas int64) types.Txs {
 	return r0
 }
 
+// ReapMaxTxEntries provides a mock function with given fields: max
+func (_m *Mempool) ReapMaxTxEntries(max int) []mempool.TxEntry {
+	ret := _m.Called(max)
+
+	var r0 []mempool.TxEntry
+	if rf, ok := ret.Get(0).(func(int) []mempool.TxEntry); ok {
+		r0 = rf(max)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]mempool.TxEntry)
+		}
+	}
+
+	return r0
+}
+
 // ReapMaxTxs provides a mock function with given fields: max
 func (_m *Mempool) ReapMaxTxs(max int) types.Txs {
 	ret := _m.Called(max)
@@ -138,6 +154,20 @@ func (_m *Mempool) RemoveTxByKey(txKey types.TxKey) error {
 	return r0
 }
 
+// RemoveTxByHash provides a mock function with given fields: txKey, blacklist
+func (_m *Mempool) RemoveTxByHash(txKey types.TxKey, blacklist bool) error {
+	ret := _m.Called(txKey, blacklist)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.TxKey, bool) error); ok {
+		r0 = rf(txKey, blacklist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields:
 func (_m *Mempool) Size() int {
 	ret := _m.Called()
@@ -12,6 +12,9 @@ var _ heap.Interface = (*TxPriorityQueue)(nil)
 type TxPriorityQueue struct {
 	mtx sync.RWMutex
 	txs []*WrappedTx
+
+	// compare, if set, orders transactions of equal priority
+	compare TxComparatorFunc
 }
 
 func NewTxPriorityQueue() *TxPriorityQueue {
@@ -139,9 +142,15 @@ func (pq *TxPriorityQueue) Len() int {
 // Less implements the Heap interface. It returns true if the transaction at
 // position i in the queue is of less priority than the transaction at position j.
 func (pq *TxPriorityQueue) Less(i, j int) bool {
-	// If there exists two transactions with the same priority, consider the one
-	// that we saw the earliest as the higher priority transaction.
+	// If there exists two transactions with the same priority, consult the
+	// comparator if there is one and otherwise consider the one that we saw the
+	// earliest as the higher priority transaction.
 	if pq.txs[i].priority == pq.txs[j].priority {
+		if pq.compare != nil {
+			if c := pq.compare(pq.txs[i].tx, pq.txs[j].tx); c != 0 {
+				return c < 0
+			}
+		}
 		return pq.txs[i].timestamp.Before(pq.txs[j].timestamp)
 	}
 
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestTxPriorityQueue(t *testing.T) {
@@ -58,6 +60,33 @@ func TestTxPriorityQueue(t *testing.T) {
 	require.Equal(t, priorities, gotPriorities)
 }
 
+func TestTxPriorityQueue_Comparator(t *testing.T) {
+	pq := NewTxPriorityQueue()
+	// prefer smaller transactions when priorities are equal
+	pq.compare = func(a, b types.Tx) int {
+		return len(a) - len(b)
+	}
+
+	now := time.Now()
+	pq.PushTx(&WrappedTx{tx: make([]byte, 30), priority: 1, timestamp: now})
+	pq.PushTx(&WrappedTx{tx: make([]byte, 20), priority: 1, timestamp: now.Add(time.Second)})
+	pq.PushTx(&WrappedTx{tx: make([]byte, 10), priority: 1, timestamp: now.Add(2 * time.Second)})
+	pq.PushTx(&WrappedTx{tx: make([]byte, 40), priority: 2, timestamp: now.Add(3 * time.Second)})
+	// equal size falls back to the earliest seen
+	pq.PushTx(&WrappedTx{tx: make([]byte, 20), priority: 1, timestamp: now.Add(-time.Second)})
+
+	var gotSizes []int
+	var gotTimes []time.Time
+	for pq.NumTxs() > 0 {
+		tx := pq.PopTx()
+		gotSizes = append(gotSizes, tx.Size())
+		gotTimes = append(gotTimes, tx.timestamp)
+	}
+
+	require.Equal(t, []int{40, 10, 20, 20, 30}, gotSizes)
+	require.Equal(t, now.Add(-time.Second), gotTimes[2])
+}
+
 func TestTxPriorityQueue_GetEvictableTxs(t *testing.T) {
 	pq := NewTxPriorityQueue()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
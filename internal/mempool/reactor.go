@@ -109,6 +109,9 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	if r.channel == nil {
 		return errors.New("mempool channel is not set")
 	}
+	if err := r.mempool.LoadWAL(ctx); err != nil {
+		return fmt.Errorf("failed to load the mempool WAL: %w", err)
+	}
 	go r.processMempoolCh(ctx, r.channel)
 	go r.processPeerUpdates(ctx, r.peerEvents(ctx), r.channel)
 
@@ -117,12 +120,16 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 
 // OnStop stops the reactor by signaling to all spawned goroutines to exit and
 // blocking until they all exit.
-func (r *Reactor) OnStop() {}
+func (r *Reactor) OnStop() {
+	if err := r.mempool.CloseWAL(); err != nil {
+		r.logger.Error("failed to close the mempool WAL", "err", err)
+	}
+}
 
 // handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
-// For every tx in the message, we execute CheckTx. It returns an error if an
-// empty set of txs are sent in an envelope or if we receive an unexpected
-// message type.
+// The txs in the message are checked together with a single CheckTxBatch. It
+// returns an error if an empty set of txs are sent in an envelope or if we
+// receive an unexpected message type.
 func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelope) error {
 	logger := r.logger.With("peer", envelope.From)
 
@@ -138,8 +145,13 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 			txInfo.SenderNodeID = envelope.From
 		}
 
-		for _, tx := range protoTxs {
-			if err := r.mempool.CheckTx(ctx, types.Tx(tx), nil, txInfo); err != nil {
+		txs := make(types.Txs, len(protoTxs))
+		for i, tx := range protoTxs {
+			txs[i] = types.Tx(tx)
+		}
+
+		for i, err := range r.mempool.CheckTxBatch(ctx, txs, txInfo) {
+			if err != nil {
 				if errors.Is(err, types.ErrTxInCache) {
 					// if the tx is in the cache,
 					// then we've been gossiped a
@@ -159,7 +171,7 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 				}
 
 				logger.Debug("checktx failed for tx",
-					"tx", fmt.Sprintf("%X", types.Tx(tx).Hash()),
+					"tx", fmt.Sprintf("%X", txs[i].Hash()),
 					"err", err)
 			}
 		}
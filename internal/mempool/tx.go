@@ -21,6 +21,22 @@ type TxInfo struct {
 	SenderNodeID types.NodeID
 }
 
+// TxEntry is a read-only snapshot of a transaction in the mempool along with
+// the metadata the mempool tracks for it.
+type TxEntry struct {
+	Tx        types.Tx
+	Hash      types.TxKey
+	Priority  int64
+	Sender    string
+	GasWanted int64
+	Height    int64
+	Timestamp time.Time
+
+	// PendingRecheck is set if the transaction hasn't been rechecked since
+	// the last block, because it was deferred to the background recheck.
+	PendingRecheck bool
+}
+
 // WrappedTx defines a wrapper around a raw transaction with additional metadata
 // that is used for indexing.
 type WrappedTx struct {
@@ -44,6 +60,19 @@ type WrappedTx struct {
 	// the ResponseCheckTx response.
 	sender string
 
+	// replacementKey identifies the transactions that replace each other when
+	// transaction replacement is enabled, i.e. their sender and sequence. It is
+	// empty if the transaction cannot be replaced.
+	replacementKey string
+
+	// expiryHeight is the last height at which the transaction may be included
+	// in a block, or zero if the transaction does not declare one.
+	expiryHeight int64
+
+	// pendingRecheck marks a transaction whose recheck after the last block
+	// was deferred to the background. It is guarded by the mempool lock.
+	pendingRecheck bool
+
 	// timestamp is the time at which the node first received the transaction from
 	// a peer. It is used as a second dimension is prioritizing transactions when
 	// two transactions have the same priority.
@@ -69,6 +98,26 @@ func (wtx *WrappedTx) Size() int {
 	return len(wtx.tx)
 }
 
+// Entry returns a snapshot of the transaction and its metadata.
+func (wtx *WrappedTx) Entry() TxEntry {
+	return TxEntry{
+		Tx:             wtx.tx,
+		Hash:           wtx.hash,
+		Priority:       wtx.priority,
+		Sender:         wtx.sender,
+		GasWanted:      wtx.gasWanted,
+		Height:         wtx.height,
+		Timestamp:      wtx.timestamp,
+		PendingRecheck: wtx.pendingRecheck,
+	}
+}
+
+// expiredAt returns true if the transaction declared an expiry height and can
+// no longer be included in a block at the given height.
+func (wtx *WrappedTx) expiredAt(height int64) bool {
+	return wtx.expiryHeight > 0 && height > wtx.expiryHeight
+}
+
 // TxStore implements a thread-safe mapping of valid transaction(s).
 //
 // NOTE:
@@ -76,15 +125,17 @@ func (wtx *WrappedTx) Size() int {
 //   access is not allowed. Regardless, it is not expected for the mempool to
 //   need mutative access.
 type TxStore struct {
-	mtx       sync.RWMutex
-	hashTxs   map[types.TxKey]*WrappedTx // primary index
-	senderTxs map[string]*WrappedTx      // sender is defined by the ABCI application
+	mtx            sync.RWMutex
+	hashTxs        map[types.TxKey]*WrappedTx // primary index
+	senderTxs      map[string]*WrappedTx      // sender is defined by the ABCI application
+	replacementTxs map[string]*WrappedTx      // replacement key of replaceable transactions
 }
 
 func NewTxStore() *TxStore {
 	return &TxStore{
-		senderTxs: make(map[string]*WrappedTx),
-		hashTxs:   make(map[types.TxKey]*WrappedTx),
+		senderTxs:      make(map[string]*WrappedTx),
+		hashTxs:        make(map[types.TxKey]*WrappedTx),
+		replacementTxs: make(map[string]*WrappedTx),
 	}
 }
 
@@ -120,6 +171,15 @@ func (txs *TxStore) GetTxBySender(sender string) *WrappedTx {
 	return txs.senderTxs[sender]
 }
 
+// GetTxByReplacementKey returns the *WrappedTx that a transaction with the
+// given replacement key would replace, if any.
+func (txs *TxStore) GetTxByReplacementKey(key string) *WrappedTx {
+	txs.mtx.RLock()
+	defer txs.mtx.RUnlock()
+
+	return txs.replacementTxs[key]
+}
+
 // GetTxByHash returns a *WrappedTx by the transaction's hash.
 func (txs *TxStore) GetTxByHash(hash types.TxKey) *WrappedTx {
 	txs.mtx.RLock()
@@ -152,6 +212,9 @@ func (txs *TxStore) SetTx(wtx *WrappedTx) {
 	if len(wtx.sender) > 0 {
 		txs.senderTxs[wtx.sender] = wtx
 	}
+	if len(wtx.replacementKey) > 0 {
+		txs.replacementTxs[wtx.replacementKey] = wtx
+	}
 
 	txs.hashTxs[wtx.tx.Key()] = wtx
 }
@@ -162,9 +225,14 @@ func (txs *TxStore) RemoveTx(wtx *WrappedTx) {
 	txs.mtx.Lock()
 	defer txs.mtx.Unlock()
 
-	if len(wtx.sender) > 0 {
+	// With transaction replacement a sender may have several transactions, so
+	// only drop the indexes that still point to this one.
+	if len(wtx.sender) > 0 && txs.senderTxs[wtx.sender] == wtx {
 		delete(txs.senderTxs, wtx.sender)
 	}
+	if len(wtx.replacementKey) > 0 && txs.replacementTxs[wtx.replacementKey] == wtx {
+		delete(txs.replacementTxs, wtx.replacementKey)
+	}
 
 	delete(txs.hashTxs, wtx.tx.Key())
 	wtx.removed = true
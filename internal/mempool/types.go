@@ -38,6 +38,12 @@ type Mempool interface {
 	// from the mempool.
 	RemoveTxByKey(txKey types.TxKey) error
 
+	// RemoveTxByHash removes a transaction, identified by its hash, from the
+	// mempool and the cache, e.g. to purge a transaction stuck in the
+	// mempool. If blacklist is true, the transaction is rejected by CheckTx
+	// afterwards.
+	RemoveTxByHash(txKey types.TxKey, blacklist bool) error
+
 	HasTx(txKey types.TxKey) bool
 
 	GetTxsForKeys(txKeys []types.TxKey) types.Txs
@@ -55,6 +61,11 @@ type Mempool interface {
 	// (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// ReapMaxTxEntries reaps up to max transactions from the mempool along with
+	// their mempool metadata, in the same order as ReapMaxTxs. If max is
+	// negative, all available transactions are returned.
+	ReapMaxTxEntries(max int) []TxEntry
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely
 	// update.
 	Lock()
@@ -118,6 +129,19 @@ type PreCheckFunc func(types.Tx) error
 // transaction doesn't require more gas than available for the block.
 type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 
+// TxComparatorFunc is an optional tiebreak consulted when two transactions
+// have the same priority. It returns a negative number if a should be reaped
+// before b and a positive number if b should be reaped before a. If it returns
+// zero, the transaction that was seen first is reaped first.
+type TxComparatorFunc func(a, b types.Tx) int
+
+// TxExpiryHeightFunc is an optional hook executed after CheckTx that returns
+// the last height at which a transaction may be included in a block. Once a
+// block at that height has been committed the transaction is dropped from the
+// mempool, even if its TTL has not elapsed. Returning zero means the
+// transaction does not expire by height.
+type TxExpiryHeightFunc func(types.Tx, *abci.ResponseCheckTx) int64
+
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal
 // to the expected maxBytes.
 func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
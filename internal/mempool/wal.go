@@ -0,0 +1,337 @@
+package mempool
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The mempool WAL is a file of records, each made of a header with the CRC32
+// checksum and the length of its data, followed by the data: the record type,
+// and either a transaction accepted by the mempool or the keys of the
+// transactions committed in a block.
+const (
+	walFileName   = "wal"
+	walHeaderSize = 8
+
+	walRecordTx        byte = 1
+	walRecordCommitted byte = 2
+
+	// walCompactInterval is the minimum interval between two compactions of
+	// the WAL in the background.
+	walCompactInterval = 10 * time.Second
+)
+
+var (
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+	errWALFull = errors.New("mempool WAL is full")
+)
+
+// txWAL is a write-ahead log of the transactions accepted by the mempool, so
+// that the pending ones can be replayed when the node restarts.
+//
+// Records are written without syncing the file, so they survive the node
+// crashing or restarting, but not necessarily the machine crashing.
+type txWAL struct {
+	mtx     sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	maxSize int64
+
+	// compactMtx is held for the duration of a compaction, which rewrites the
+	// WAL without holding mtx. The records written in the meantime are kept in
+	// tail, and appended to the compacted WAL.
+	compactMtx sync.Mutex
+	compacting bool
+	tail       [][]byte
+}
+
+// openTxWAL opens the WAL in the given directory, creating it if needed. The
+// WAL doesn't grow past maxSize bytes.
+func openTxWAL(dir string, maxSize int64) (*txWAL, error) {
+	if err := tmos.EnsureDir(dir, 0700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, walFileName)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mempool WAL: %w", err)
+	}
+	return &txWAL{
+		path:    path,
+		file:    file,
+		maxSize: maxSize,
+	}, nil
+}
+
+// Load reads the transactions of the WAL that weren't committed, in the order
+// they were accepted in. A corrupt or truncated record, such as a partial
+// record written as the node crashed, ends the WAL: the WAL is truncated
+// before it, and corrupt is set.
+func (w *txWAL) Load() (txs types.Txs, corrupt bool, err error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+
+	var (
+		r         = bufio.NewReader(w.file)
+		offset    int64
+		committed = make(map[types.TxKey]struct{})
+		seen      = make(map[types.TxKey]struct{})
+	)
+	for {
+		data, err := w.readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			corrupt = true
+			break
+		}
+		offset += walHeaderSize + int64(len(data))
+
+		switch data[0] {
+		case walRecordTx:
+			tx := types.Tx(data[1:])
+			if _, ok := seen[tx.Key()]; !ok {
+				seen[tx.Key()] = struct{}{}
+				txs = append(txs, tx)
+			}
+		case walRecordCommitted:
+			keys := data[1:]
+			for i := 0; i+sha256.Size <= len(keys); i += sha256.Size {
+				var key types.TxKey
+				copy(key[:], keys[i:])
+				committed[key] = struct{}{}
+			}
+		}
+	}
+
+	if corrupt {
+		if err := w.file.Truncate(offset); err != nil {
+			return nil, true, fmt.Errorf("failed to truncate corrupt mempool WAL: %w", err)
+		}
+	}
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		return nil, corrupt, err
+	}
+	w.size = offset
+
+	pending := txs[:0]
+	for _, tx := range txs {
+		if _, ok := committed[tx.Key()]; !ok {
+			pending = append(pending, tx)
+		}
+	}
+	return pending, corrupt, nil
+}
+
+// readRecord reads the data of the next record. It returns io.EOF at the end
+// of the WAL, and another error if the record is truncated or corrupt.
+func (w *txWAL) readRecord(r *bufio.Reader) ([]byte, error) {
+	var header [walHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length == 0 || int64(length) > w.maxSize {
+		return nil, fmt.Errorf("invalid record length %d", length)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
+	}
+	if actual := crc32.Checksum(data, crc32c); actual != crc {
+		return nil, fmt.Errorf("checksums do not match: read %v, actual %v", crc, actual)
+	}
+	switch data[0] {
+	case walRecordTx, walRecordCommitted:
+	default:
+		return nil, fmt.Errorf("unknown record type %d", data[0])
+	}
+	return data, nil
+}
+
+// WriteTx logs a transaction accepted by the mempool. It returns errWALFull if
+// the WAL has no room left for it.
+func (w *txWAL) WriteTx(tx types.Tx) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.write(encodeWALRecord(walRecordTx, tx))
+}
+
+// WriteCommitted logs the keys of transactions committed in a block, which
+// are then no longer replayed. It returns errWALFull if the WAL has no room
+// left for them.
+func (w *txWAL) WriteCommitted(txs types.Txs) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	keys := make([]byte, 0, len(txs)*sha256.Size)
+	for _, tx := range txs {
+		key := tx.Key()
+		keys = append(keys, key[:]...)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.write(encodeWALRecord(walRecordCommitted, keys))
+}
+
+func (w *txWAL) write(record []byte) error {
+	if w.size+int64(len(record)) > w.maxSize {
+		return errWALFull
+	}
+	n, err := w.file.Write(record)
+	w.size += int64(n)
+	if err == nil && w.compacting {
+		w.tail = append(w.tail, record)
+	}
+	return err
+}
+
+// ShouldCompact returns true if the WAL is more than half full, and at least
+// half of it is made of records that are no longer needed, given the size of
+// the pending transactions.
+func (w *txWAL) ShouldCompact(pendingBytes int64) bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.size > w.maxSize/2 && w.size > 2*pendingBytes
+}
+
+// Compact replaces the WAL with one made of the given pending transactions,
+// up to the size of the WAL.
+func (w *txWAL) Compact(txs types.Txs) error {
+	w.StartCompact()
+	return w.FinishCompact(txs)
+}
+
+// StartCompact starts a compaction of the WAL, waiting for the previous one to
+// finish. The records written from then on are kept in the compacted WAL, so
+// the pending transactions passed to FinishCompact only have to include the
+// ones accepted before StartCompact returned.
+func (w *txWAL) StartCompact() {
+	w.compactMtx.Lock()
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.compacting = true
+	w.tail = nil
+}
+
+// FinishCompact replaces the WAL with one made of the given pending
+// transactions, up to the size of the WAL, followed by the records written
+// since StartCompact. The WAL can be written to while it is rewritten.
+func (w *txWAL) FinishCompact(txs types.Txs) error {
+	defer w.compactMtx.Unlock()
+
+	tmpPath := w.path + ".tmp"
+	file, size, err := w.writeCompacted(tmpPath, txs)
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	tail := w.tail
+	w.compacting = false
+	w.tail = nil
+	if err != nil {
+		return fmt.Errorf("failed to compact mempool WAL: %w", err)
+	}
+
+	for _, record := range tail {
+		if size+int64(len(record)) > w.maxSize {
+			break
+		}
+		if _, err := file.Write(record); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to compact mempool WAL: %w", err)
+		}
+		size += int64(len(record))
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to compact mempool WAL: %w", err)
+	}
+
+	w.file.Close()
+	w.file = file
+	w.size = size
+	return nil
+}
+
+// writeCompacted writes the records of the given transactions to a new file
+// at path, up to the size of the WAL.
+func (w *txWAL) writeCompacted(path string, txs types.Txs) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bw := bufio.NewWriter(file)
+	var size int64
+	for _, tx := range txs {
+		record := encodeWALRecord(walRecordTx, tx)
+		if size+int64(len(record)) > w.maxSize {
+			break
+		}
+		if _, err := bw.Write(record); err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+		size += int64(len(record))
+	}
+	if err := bw.Flush(); err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, size, nil
+}
+
+// Close closes the WAL, once the compaction in progress, if any, is finished.
+func (w *txWAL) Close() error {
+	w.compactMtx.Lock()
+	defer w.compactMtx.Unlock()
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.file.Close()
+}
+
+func encodeWALRecord(typ byte, data []byte) []byte {
+	record := make([]byte, walHeaderSize+1+len(data))
+	record[walHeaderSize] = typ
+	copy(record[walHeaderSize+1:], data)
+	binary.BigEndian.PutUint32(record[0:4], crc32.Checksum(record[walHeaderSize:], crc32c))
+	binary.BigEndian.PutUint32(record[4:8], uint32(1+len(data)))
+	return record
+}
@@ -0,0 +1,131 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxWAL(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openTxWAL(dir, 1024)
+	require.NoError(t, err)
+
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c"), types.Tx("d")}
+	for _, tx := range txs {
+		require.NoError(t, wal.WriteTx(tx))
+	}
+	require.NoError(t, wal.WriteTx(txs[0]))
+	require.NoError(t, wal.WriteCommitted(types.Txs{txs[1], types.Tx("x")}))
+	require.NoError(t, wal.Close())
+
+	wal, err = openTxWAL(dir, 1024)
+	require.NoError(t, err)
+	loaded, corrupt, err := wal.Load()
+	require.NoError(t, err)
+	require.False(t, corrupt)
+	require.Equal(t, types.Txs{txs[0], txs[2], txs[3]}, loaded)
+
+	// records are appended after the loaded ones
+	require.NoError(t, wal.WriteTx(types.Tx("e")))
+	loaded, _, err = wal.Load()
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txs[0], txs[2], txs[3], types.Tx("e")}, loaded)
+	require.NoError(t, wal.Close())
+}
+
+func TestTxWALCorruptTail(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openTxWAL(dir, 1024)
+	require.NoError(t, err)
+	require.NoError(t, wal.WriteTx(types.Tx("a")))
+	require.NoError(t, wal.WriteTx(types.Tx("b")))
+	size := wal.size
+	require.NoError(t, wal.WriteTx(types.Tx("c")))
+	require.NoError(t, wal.Close())
+
+	// truncate the last record, as a crash while writing it would
+	path := filepath.Join(dir, walFileName)
+	require.NoError(t, os.Truncate(path, size+5))
+
+	wal, err = openTxWAL(dir, 1024)
+	require.NoError(t, err)
+	loaded, corrupt, err := wal.Load()
+	require.NoError(t, err)
+	require.True(t, corrupt)
+	require.Equal(t, types.Txs{types.Tx("a"), types.Tx("b")}, loaded)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, size, info.Size())
+
+	// corrupt the data of the last record
+	require.NoError(t, wal.WriteTx(types.Tx("c")))
+	require.NoError(t, wal.Close())
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	bz[len(bz)-1] = 'x'
+	require.NoError(t, os.WriteFile(path, bz, 0600))
+
+	wal, err = openTxWAL(dir, 1024)
+	require.NoError(t, err)
+	loaded, corrupt, err = wal.Load()
+	require.NoError(t, err)
+	require.True(t, corrupt)
+	require.Equal(t, types.Txs{types.Tx("a"), types.Tx("b")}, loaded)
+	require.NoError(t, wal.Close())
+}
+
+func TestTxWALMaxSize(t *testing.T) {
+	tx := types.Tx("abcdefghijklmnopqrstuvw")
+	record := int64(len(encodeWALRecord(walRecordTx, tx)))
+
+	dir := t.TempDir()
+	wal, err := openTxWAL(dir, 3*record)
+	require.NoError(t, err)
+	defer wal.Close()
+
+	require.NoError(t, wal.WriteTx(tx))
+	require.False(t, wal.ShouldCompact(record))
+	require.NoError(t, wal.WriteTx(tx))
+	require.NoError(t, wal.WriteTx(tx))
+	require.ErrorIs(t, wal.WriteTx(tx), errWALFull)
+	require.Equal(t, 3*record, wal.size)
+	require.True(t, wal.ShouldCompact(record))
+
+	require.NoError(t, wal.Compact(types.Txs{tx}))
+	require.Equal(t, record, wal.size)
+	require.NoError(t, wal.WriteTx(types.Tx("b")))
+
+	loaded, corrupt, err := wal.Load()
+	require.NoError(t, err)
+	require.False(t, corrupt)
+	require.Equal(t, types.Txs{tx, types.Tx("b")}, loaded)
+}
+
+func TestTxWALCompactKeepsWritesInProgress(t *testing.T) {
+	tx1, tx2, tx3 := types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")
+
+	dir := t.TempDir()
+	wal, err := openTxWAL(dir, 1024)
+	require.NoError(t, err)
+	defer wal.Close()
+
+	require.NoError(t, wal.WriteTx(tx1))
+	require.NoError(t, wal.WriteTx(tx2))
+
+	// the records written while the WAL is compacted are kept
+	wal.StartCompact()
+	require.NoError(t, wal.WriteTx(tx3))
+	require.NoError(t, wal.WriteCommitted(types.Txs{tx2}))
+	require.NoError(t, wal.FinishCompact(types.Txs{tx2}))
+
+	loaded, corrupt, err := wal.Load()
+	require.NoError(t, err)
+	require.False(t, corrupt)
+	require.Equal(t, types.Txs{tx3}, loaded)
+}
@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/tendermint/tendermint/internal/p2p/conn"
+)
+
+// channelPriorities tracks the channel priority overrides of a transport, and
+// applies them to the MConnections of its live connections as well as to the
+// connections established later on.
+type channelPriorities struct {
+	mtx       sync.Mutex
+	overrides map[ChannelID]int
+	conns     map[*conn.MConnection]struct{}
+}
+
+func newChannelPriorities() *channelPriorities {
+	return &channelPriorities{
+		overrides: make(map[ChannelID]int),
+		conns:     make(map[*conn.MConnection]struct{}),
+	}
+}
+
+// set overrides the priority of a channel. A priority of 0 removes the
+// override, restoring the priority of the channel descriptor.
+func (p *channelPriorities) set(chID ChannelID, priority int) error {
+	if priority < 0 {
+		return fmt.Errorf("channel priority must not be negative, got %d", priority)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if priority == 0 {
+		delete(p.overrides, chID)
+	} else {
+		p.overrides[chID] = priority
+	}
+	for mconn := range p.conns {
+		// All connections have the channels of the transport, which the
+		// caller checked chID against.
+		_ = mconn.SetChannelPriority(chID, priority)
+	}
+	return nil
+}
+
+// add applies the overrides to a new MConnection and tracks it until removed.
+func (p *channelPriorities) add(mconn *conn.MConnection) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for chID, priority := range p.overrides {
+		_ = mconn.SetChannelPriority(chID, priority)
+	}
+	p.conns[mconn] = struct{}{}
+}
+
+// remove stops tracking an MConnection.
+func (p *channelPriorities) remove(mconn *conn.MConnection) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.conns, mconn)
+}
+
+// hasChannel returns true if chID is described by one of descs.
+func hasChannel(descs []*ChannelDescriptor, chID ChannelID) bool {
+	for _, desc := range descs {
+		if desc.ID == chID {
+			return true
+		}
+	}
+	return false
+}
+
+// channelSentBytesFunc returns an MConnection sent packet callback recording
+// the bytes sent on each channel.
+func channelSentBytesFunc(metrics *Metrics) func(ChannelID, int) {
+	return func(chID ChannelID, numBytes int) {
+		metrics.ChannelSendBytesTotal.With("ch_id", strconv.Itoa(int(chID))).Add(float64(numBytes))
+	}
+}
@@ -51,6 +51,7 @@ const (
 
 type receiveCbFunc func(ctx context.Context, chID ChannelID, msgBytes []byte)
 type errorCbFunc func(context.Context, interface{})
+type sentCbFunc func(chID ChannelID, numBytes int)
 
 /*
 Each peer has one `MConnection` (multiplex connection) instance.
@@ -61,7 +62,8 @@ several messages along a single channel of communication.
 Each `MConnection` handles message transmission on multiple abstract communication
 `Channel`s.  Each channel has a globally unique byte id.
 The byte id and the relative priorities of each `Channel` are configured upon
-initialization of the connection.
+initialization of the connection. The priorities can be changed while the
+connection runs with SetChannelPriority.
 
 There are two methods for sending messages:
 
@@ -88,6 +90,7 @@ type MConnection struct {
 	channelsIdx   map[ChannelID]*channel
 	onReceive     receiveCbFunc
 	onError       errorCbFunc
+	onSent        sentCbFunc
 	errored       uint32
 	config        MConnConfig
 
@@ -200,6 +203,31 @@ func NewMConnection(
 	return mconn
 }
 
+// OnPacketSent sets a callback called with the channel and size of every
+// packet sent. It must be called before the connection is started.
+func (c *MConnection) OnPacketSent(onSent sentCbFunc) {
+	c.onSent = onSent
+}
+
+// SetChannelPriority changes the priority of a channel, relative to the other
+// channels, for the packets sent from then on. A priority of 0 restores the
+// priority of the channel descriptor. Messages already queued on a channel are
+// still sent in order.
+func (c *MConnection) SetChannelPriority(chID ChannelID, priority int) error {
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		return fmt.Errorf("unknown channel %X", chID)
+	}
+	switch {
+	case priority < 0:
+		return fmt.Errorf("channel priority must not be negative, got %d", priority)
+	case priority == 0:
+		priority = channel.desc.Priority
+	}
+	atomic.StoreInt64(&channel.priority, int64(priority))
+	return nil
+}
+
 // OnStart implements BaseService
 func (c *MConnection) OnStart(ctx context.Context) error {
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
@@ -271,6 +299,31 @@ func (c *MConnection) OnStop() {
 	// we close it @ recvRoutine.
 }
 
+// FlushStop replicates the logic of OnStop. It additionally ensures that the
+// messages queued by successful Send calls are sent and flushed before closing
+// the connection, writing for no longer than the given deadline.
+func (c *MConnection) FlushStop(deadline time.Time) {
+	if c.stopServices() {
+		return
+	}
+
+	// wait until the sendRoutine exits, so we don't race on calling
+	// sendSomePacketMsgs
+	<-c.doneSendRoutine
+
+	// bound the writes to peers which don't read what we send
+	if err := c.conn.SetWriteDeadline(deadline); err == nil {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		for !c.sendSomePacketMsgs(ctx) && ctx.Err() == nil {
+		}
+		c.flush()
+		cancel()
+	}
+
+	c.conn.Close()
+	c.Stop()
+}
+
 func (c *MConnection) String() string {
 	return fmt.Sprintf("MConn{%v}", c.conn.RemoteAddr())
 }
@@ -437,7 +490,7 @@ func (c *MConnection) sendPacketMsg(ctx context.Context) bool {
 			continue
 		}
 		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
+		ratio := float32(atomic.LoadInt64(&channel.recentlySent)) / float32(atomic.LoadInt64(&channel.priority))
 		if ratio < leastRatio {
 			leastRatio = ratio
 			leastChannel = channel
@@ -458,6 +511,9 @@ func (c *MConnection) sendPacketMsg(ctx context.Context) bool {
 		return true
 	}
 	c.sendMonitor.Update(_n)
+	if c.onSent != nil {
+		c.onSent(leastChannel.desc.ID, _n)
+	}
 	c.flushTimer.Set()
 	return false
 }
@@ -645,6 +701,10 @@ type channel struct {
 	// See https://github.com/tendermint/tendermint/issues/7000.
 	recentlySent int64
 
+	// The priority of the channel, which may differ from the descriptor's.
+	// This field must be accessed atomically.
+	priority int64
+
 	conn          *MConnection
 	desc          ChannelDescriptor
 	sendQueue     chan []byte
@@ -665,6 +725,7 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *channel {
 	return &channel{
 		conn:                    conn,
 		desc:                    desc,
+		priority:                int64(desc.Priority),
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
@@ -1,6 +1,7 @@
 package conn
 
 import (
+	"bufio"
 	"context"
 	"encoding/hex"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/internal/libs/timer"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -76,6 +78,10 @@ func TestMConnectionSendFlushStop(t *testing.T) {
 		errCh <- err
 	}()
 
+	// stop the conn - it should flush all conns
+	clientConn.FlushStop(time.Now().Add(3 * time.Second))
+	assert.False(t, clientConn.IsRunning())
+
 	timer := time.NewTimer(3 * time.Second)
 	select {
 	case <-errCh:
@@ -641,6 +647,55 @@ func TestMConnectionChannelOverflow(t *testing.T) {
 
 }
 
+func TestMConnectionSetChannelPriority(t *testing.T) {
+	server, client := net.Pipe()
+	t.Cleanup(closeAll(t, client, server))
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 100},
+		{ID: 0x02, Priority: 10, SendQueueCapacity: 100},
+	}
+	c := NewMConnection(log.NewNopLogger(), client, chDescs, nil, nil, DefaultMConnConfig())
+	c.bufConnWriter = bufio.NewWriter(io.Discard)
+	c.flushTimer = timer.NewThrottleTimer("flush", time.Hour)
+	defer c.flushTimer.Stop()
+
+	sent := map[ChannelID]int{}
+	c.OnPacketSent(func(chID ChannelID, numBytes int) {
+		require.Positive(t, numBytes)
+		sent[chID]++
+	})
+	for i := 0; i < 100; i++ {
+		c.channelsIdx[0x01].sendQueue <- []byte("message")
+		c.channelsIdx[0x02].sendQueue <- []byte("message")
+	}
+	sendPackets := func(n int) {
+		for k := range sent {
+			delete(sent, k)
+		}
+		for i := 0; i < n; i++ {
+			require.False(t, c.sendPacketMsg(context.Background()))
+		}
+	}
+
+	// the channel with the higher priority sends most packets
+	sendPackets(22)
+	require.Equal(t, 2, sent[0x01])
+	require.Equal(t, 20, sent[0x02])
+
+	// the new priority takes effect for the next packets
+	require.NoError(t, c.SetChannelPriority(0x01, 1000))
+	sendPackets(20)
+	require.Greater(t, sent[0x01], 15)
+
+	// and the default priority can be restored
+	require.NoError(t, c.SetChannelPriority(0x01, 0))
+	require.EqualValues(t, 1, c.channelsIdx[0x01].priority)
+
+	require.Error(t, c.SetChannelPriority(0x01, -1))
+	require.Error(t, c.SetChannelPriority(0x03, 1))
+}
+
 func waitAll(waiters ...service.Service) func() {
 	return func() {
 		switch len(waiters) {
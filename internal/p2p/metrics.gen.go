@@ -67,13 +67,37 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Subsystem: MetricsSubsystem,
 			Name:      "router_channel_queue_dropped_msgs",
 			Help:      "The number of messages dropped from a peer's queue for a specific p2p Channel.",
-		}, append(labels, "ch_id")).With(labelsAndValues...),
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
 		PeerQueueMsgSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "peer_queue_msg_size",
 			Help:      "The size of messages sent over a peer's queue for a specific p2p Channel.",
 		}, append(labels, "ch_id")).With(labelsAndValues...),
+		PeerQueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_queue_depth",
+			Help:      "The number of messages waiting in a peer's outbound queue.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerQueueThrottledMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_queue_throttled_msgs",
+			Help:      "The number of messages for a specific p2p Channel that had to wait for room in a peer's outbound queue.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+		PeerDroppedMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_dropped_msgs",
+			Help:      "The number of messages for a specific p2p Channel dropped before reaching a peer's outbound queue.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+		ChannelSendBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_send_bytes_total",
+			Help:      "The number of bytes sent by the connection send scheduler for a specific p2p Channel.",
+		}, append(labels, "ch_id")).With(labelsAndValues...),
 	}
 }
 
@@ -89,5 +113,9 @@ func NopMetrics() *Metrics {
 		RouterChannelQueueSend: discard.NewHistogram(),
 		PeerQueueDroppedMsgs:   discard.NewCounter(),
 		PeerQueueMsgSize:       discard.NewGauge(),
+		PeerQueueDepth:         discard.NewGauge(),
+		PeerQueueThrottledMsgs: discard.NewCounter(),
+		PeerDroppedMsgs:        discard.NewCounter(),
+		ChannelSendBytesTotal:  discard.NewCounter(),
 	}
 }
@@ -55,12 +55,34 @@ type Metrics struct {
 	// PeerQueueDroppedMsgs defines the number of messages dropped from a peer's
 	// queue for a specific flow (i.e. Channel).
 	//metrics:The number of messages dropped from a peer's queue for a specific p2p Channel.
-	PeerQueueDroppedMsgs metrics.Counter `metrics_labels:"ch_id" metrics_name:"router_channel_queue_dropped_msgs"`
+	PeerQueueDroppedMsgs metrics.Counter `metrics_labels:"peer_id, ch_id" metrics_name:"router_channel_queue_dropped_msgs"`
 
 	// PeerQueueMsgSize defines the average size of messages sent over a peer's
 	// queue for a specific flow (i.e. Channel).
 	//metrics:The size of messages sent over a peer's queue for a specific p2p Channel.
 	PeerQueueMsgSize metrics.Gauge `metrics_labels:"ch_id" metric_name:"router_channel_queue_msg_size"`
+
+	// PeerQueueDepth defines the number of messages the router has placed on a
+	// peer's outbound queue that have not yet been sent on the connection.
+	//metrics:The number of messages waiting in a peer's outbound queue.
+	PeerQueueDepth metrics.Gauge `metrics_labels:"peer_id"`
+
+	// PeerQueueThrottledMsgs defines the number of messages for a specific flow
+	// (i.e. Channel) that could not be placed on a peer's outbound queue
+	// straight away because the queue was full.
+	//metrics:The number of messages for a specific p2p Channel that had to wait for room in a peer's outbound queue.
+	PeerQueueThrottledMsgs metrics.Counter `metrics_labels:"peer_id, ch_id"`
+
+	// PeerDroppedMsgs defines the number of messages for a specific flow (i.e.
+	// Channel) that were dropped because the peer disconnected before they
+	// could be placed on its outbound queue.
+	//metrics:The number of messages for a specific p2p Channel dropped before reaching a peer's outbound queue.
+	PeerDroppedMsgs metrics.Counter `metrics_labels:"peer_id, ch_id"`
+
+	// ChannelSendBytesTotal defines the number of bytes of packets sent on a
+	// specific flow (i.e. Channel) by the connection send scheduler.
+	//metrics:The number of bytes sent by the connection send scheduler for a specific p2p Channel.
+	ChannelSendBytesTotal metrics.Counter `metrics_labels:"ch_id"`
 }
 
 type metricsLabelCache struct {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
 	"sort"
 	"strings"
 	"sync"
@@ -123,6 +124,20 @@ type PeerManagerOptions struct {
 	// the connection and evict a lower-scored peer.
 	MaxConnectedUpgrade uint16
 
+	// MaxInbound is the maximum number of connected inbound peers, not
+	// counting persistent and unconditional peers. 0 means no limit.
+	MaxInbound uint16
+
+	// MaxOutbound is the maximum number of connected and dialing outbound
+	// peers, not counting persistent and unconditional peers. 0 means no limit.
+	MaxOutbound uint16
+
+	// ReservedSlots is the number of MaxConnected connection slots reserved
+	// for persistent and unconditional peers: other peers are only connected
+	// while fewer than MaxConnected-ReservedSlots of them are. Requires
+	// MaxConnected.
+	ReservedSlots uint16
+
 	// MinRetryTime is the minimum time to wait between retries. Retry times
 	// double for each retry, up to MaxRetryTime. 0 disables retries.
 	MinRetryTime time.Duration
@@ -143,10 +158,36 @@ type PeerManagerOptions struct {
 	// for testing. A score of 0 is ignored.
 	PeerScores map[types.NodeID]PeerScore
 
+	// ScoreDecayInterval is the time it takes for a peer's mutable score to
+	// move one point back toward DefaultMutableScore, so that past good or bad
+	// behavior is gradually forgotten. 0 disables decay.
+	ScoreDecayInterval time.Duration
+
+	// BanThreshold is the mutable score at or below which a misbehaving peer
+	// is disconnected and banned. Persistent and unconditional peers are never
+	// banned.
+	BanThreshold int64
+
+	// MinBanDuration is the duration of a peer's first ban. Ban durations
+	// double for each subsequent ban of the same peer, up to MaxBanDuration.
+	MinBanDuration time.Duration
+
+	// MaxBanDuration is the maximum duration of a ban. 0 disables bans.
+	MaxBanDuration time.Duration
+
 	// PrivatePeerIDs defines a set of NodeID objects which the PEX reactor will
 	// consider private and never gossip.
 	PrivatePeers map[types.NodeID]struct{}
 
+	// AllowedCIDRs, if non-empty, restricts inbound and outbound connections
+	// to peers with an IP address in one of these ranges.
+	AllowedCIDRs []*net.IPNet
+
+	// DeniedCIDRs rejects inbound and outbound connections to peers with an IP
+	// address in one of these ranges. Unconditional peers bypass it; inbound
+	// connections are only checked against it once the peer is authenticated.
+	DeniedCIDRs []*net.IPNet
+
 	// SelfAddress is the address that will be advertised to peers for them to dial back to us.
 	// If Hostname and Port are unset, Advertise() will include no self-announcement
 	SelfAddress NodeAddress
@@ -178,6 +219,19 @@ func (o *PeerManagerOptions) Validate() error {
 			len(o.PersistentPeers), o.MaxConnected)
 	}
 
+	if o.MaxConnected > 0 && o.MaxInbound > o.MaxConnected {
+		return fmt.Errorf("MaxInbound %v can't exceed MaxConnected %v", o.MaxInbound, o.MaxConnected)
+	}
+
+	if o.MaxConnected > 0 && o.MaxOutbound > o.MaxConnected {
+		return fmt.Errorf("MaxOutbound %v can't exceed MaxConnected %v", o.MaxOutbound, o.MaxConnected)
+	}
+
+	if o.ReservedSlots > 0 && (o.MaxConnected == 0 || o.ReservedSlots > o.MaxConnected) {
+		return fmt.Errorf("ReservedSlots %v requires MaxConnected and can't exceed it, got MaxConnected %v",
+			o.ReservedSlots, o.MaxConnected)
+	}
+
 	if o.MaxPeers > 0 {
 		if o.MaxConnected == 0 || o.MaxConnected+o.MaxConnectedUpgrade > o.MaxPeers {
 			return fmt.Errorf("MaxConnected %v and MaxConnectedUpgrade %v can't exceed MaxPeers %v",
@@ -205,6 +259,20 @@ func (o *PeerManagerOptions) Validate() error {
 		}
 	}
 
+	if o.MaxBanDuration > 0 {
+		if o.MinBanDuration == 0 {
+			return errors.New("can't set MaxBanDuration without MinBanDuration")
+		}
+		if o.MinBanDuration > o.MaxBanDuration {
+			return fmt.Errorf("MinBanDuration %v is greater than MaxBanDuration %v",
+				o.MinBanDuration, o.MaxBanDuration)
+		}
+		if o.BanThreshold >= DefaultMutableScore-1 {
+			return fmt.Errorf("BanThreshold %v would ban peers on their first bad report",
+				o.BanThreshold)
+		}
+	}
+
 	return nil
 }
 
@@ -225,6 +293,12 @@ func (o *PeerManagerOptions) isUnconditional(id types.NodeID) bool {
 	return ok
 }
 
+// isTransient returns true if the peer is neither persistent nor unconditional,
+// i.e. is subject to MaxInbound, MaxOutbound and ReservedSlots.
+func (o *PeerManagerOptions) isTransient(id types.NodeID) bool {
+	return !o.isPersistent(id) && !o.isUnconditional(id)
+}
+
 // optimize optimizes operations by pregenerating lookup structures. It's a
 // separate method instead of memoizing during calls to avoid dealing with
 // concurrency and mutex overhead.
@@ -297,9 +371,11 @@ type PeerManager struct {
 	dialing       map[types.NodeID]bool         // peers being dialed (DialNext → Dialed/DialFail)
 	upgrading     map[types.NodeID]types.NodeID // peers claimed for upgrade (DialNext → Dialed/DialFail)
 	connected     map[types.NodeID]bool         // connected peers (Dialed/Accepted → Disconnected)
+	inbound       map[types.NodeID]bool         // connected inbound peers (Accepted → Disconnected)
 	ready         map[types.NodeID]bool         // ready peers (Ready → Disconnected)
 	evict         map[types.NodeID]bool         // peers scheduled for eviction (Connected → EvictNext)
 	evicting      map[types.NodeID]bool         // peers being evicted (EvictNext → Disconnected)
+	lastDecay     time.Time                     // last time peer scores were decayed
 	metrics       *Metrics
 }
 
@@ -337,10 +413,12 @@ func NewPeerManager(
 		dialing:       map[types.NodeID]bool{},
 		upgrading:     map[types.NodeID]types.NodeID{},
 		connected:     map[types.NodeID]bool{},
+		inbound:       map[types.NodeID]bool{},
 		ready:         map[types.NodeID]bool{},
 		evict:         map[types.NodeID]bool{},
 		evicting:      map[types.NodeID]bool{},
 		subscriptions: map[*PeerUpdates]*PeerUpdates{},
+		lastDecay:     time.Now().UTC(),
 		metrics:       metrics,
 	}
 	if err = peerManager.configurePeers(); err != nil {
@@ -515,8 +593,16 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 		return NodeAddress{}, nil
 	}
 
+	// Only persistent and unconditional peers may be dialed once the
+	// transient peer limits are reached.
+	transientFull := m.checkTransientLimits(false, "") != nil
+
+	m.decayScores()
 	for _, peer := range m.store.Ranked() {
-		if m.dialing[peer.ID] || m.connected[peer.ID] {
+		if m.dialing[peer.ID] || m.connected[peer.ID] || peer.isBanned() {
+			continue
+		}
+		if transientFull && m.options.isTransient(peer.ID) {
 			continue
 		}
 
@@ -630,6 +716,11 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 		dupeConnectionErr := fmt.Errorf("cant dial, peer=%q is already connected", address.NodeID)
 		return dupeConnectionErr
 	}
+	if m.options.isTransient(address.NodeID) {
+		if err := m.checkTransientLimits(false, address.NodeID); err != nil {
+			return err
+		}
+	}
 	if m.options.MaxConnected > 0 && m.NumConnected() >= int(m.options.MaxConnected) {
 		if upgradeFromPeer == "" || m.NumConnected() >=
 			int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
@@ -641,6 +732,9 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	if !ok {
 		return fmt.Errorf("peer %q was removed while dialing", address.NodeID)
 	}
+	if peer.isBanned() {
+		return fmt.Errorf("peer %q is banned until %v", address.NodeID, peer.BannedUntil)
+	}
 	now := time.Now().UTC()
 	peer.LastConnected = now
 	if addressInfo, ok := peer.AddressInfo[address]; ok {
@@ -670,6 +764,35 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	return nil
 }
 
+// CheckIP returns an error if connections with the given peer at the given IP
+// address are rejected by AllowedCIDRs or DeniedCIDRs. It is called by the
+// router when dialing an endpoint, and twice when accepting a connection:
+// before the handshake, with an empty peer ID, and after it. Since
+// unconditional peers bypass DeniedCIDRs, only AllowedCIDRs are checked while
+// the peer ID is empty. A nil IP, e.g. of an in-memory endpoint, is never
+// rejected.
+func (m *PeerManager) CheckIP(peerID types.NodeID, ip net.IP) error {
+	if ip == nil {
+		return nil
+	}
+	if len(m.options.AllowedCIDRs) > 0 && !cidrsContain(m.options.AllowedCIDRs, ip) {
+		return fmt.Errorf("IP %v is not in an allowed range", ip)
+	}
+	if peerID != "" && !m.options.isUnconditional(peerID) && cidrsContain(m.options.DeniedCIDRs, ip) {
+		return fmt.Errorf("IP %v is in a denied range", ip)
+	}
+	return nil
+}
+
+func cidrsContain(cidrs []*net.IPNet, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // Accepted marks an incoming peer connection successfully accepted. If the peer
 // is already connected or we don't allow additional connections then this will
 // return an error.
@@ -698,15 +821,24 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 		dupeConnectionErr := fmt.Errorf("can't accept, peer=%q is already connected", peerID)
 		return dupeConnectionErr
 	}
+	if m.options.isTransient(peerID) {
+		if err := m.checkTransientLimits(true, peerID); err != nil {
+			return err
+		}
+	}
 	if m.options.MaxConnected > 0 &&
 		m.NumConnected() >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
 		return fmt.Errorf("already connected to maximum number of peers")
 	}
 
+	m.decayScores()
 	peer, ok := m.store.Get(peerID)
 	if !ok {
 		peer = m.newPeerInfo(peerID)
 	}
+	if peer.isBanned() {
+		return fmt.Errorf("rejecting connection from banned peer %q until %v", peerID, peer.BannedUntil)
+	}
 
 	// reset this to avoid penalizing peers for their past transgressions
 	for _, addr := range peer.AddressInfo {
@@ -730,6 +862,7 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	}
 
 	m.connected[peerID] = true
+	m.inbound[peerID] = true
 	if upgradeFromPeer != "" {
 		m.evict[upgradeFromPeer] = true
 	}
@@ -798,6 +931,7 @@ func (m *PeerManager) TryEvictNext() (types.NodeID, error) {
 
 	// If we're above capacity (shouldn't really happen), just pick the
 	// lowest-ranked peer to evict.
+	m.decayScores()
 	ranked := m.store.Ranked()
 	for i := len(ranked) - 1; i >= 0; i-- {
 		peer := ranked[i]
@@ -825,6 +959,7 @@ func (m *PeerManager) Disconnected(ctx context.Context, peerID types.NodeID) {
 	ready := m.ready[peerID]
 
 	delete(m.connected, peerID)
+	delete(m.inbound, peerID)
 	delete(m.upgrading, peerID)
 	delete(m.evict, peerID)
 	delete(m.evicting, peerID)
@@ -905,6 +1040,41 @@ func (m *PeerManager) NumConnected() int {
 	return cnt
 }
 
+// checkTransientLimits returns an error if connecting another transient peer,
+// inbound or outbound, would exceed MaxInbound, MaxOutbound or the connection
+// slots not reserved by ReservedSlots. Peers being dialed count as outbound,
+// and the peer being connected, if given, isn't counted. The caller must hold
+// the mutex lock.
+func (m *PeerManager) checkTransientLimits(inbound bool, peerID types.NodeID) error {
+	var numInbound, numOutbound int
+	for id := range m.connected {
+		switch {
+		case id == peerID || !m.options.isTransient(id):
+		case m.inbound[id]:
+			numInbound++
+		default:
+			numOutbound++
+		}
+	}
+	for id := range m.dialing {
+		if id != peerID && !m.connected[id] && m.options.isTransient(id) {
+			numOutbound++
+		}
+	}
+
+	if m.options.ReservedSlots > 0 &&
+		numInbound+numOutbound >= int(m.options.MaxConnected)-int(m.options.ReservedSlots) {
+		return errors.New("remaining connection slots are reserved for persistent and unconditional peers")
+	}
+	if inbound && m.options.MaxInbound > 0 && numInbound >= int(m.options.MaxInbound) {
+		return errors.New("already connected to maximum number of inbound peers")
+	}
+	if !inbound && m.options.MaxOutbound > 0 && numOutbound >= int(m.options.MaxOutbound) {
+		return errors.New("already connected to maximum number of outbound peers")
+	}
+	return nil
+}
+
 // PeerEventSubscriber describes the type of the subscription method, to assist
 // in isolating reactors specific construction and lifecycle from the
 // peer manager.
@@ -966,12 +1136,15 @@ func (m *PeerManager) processPeerEvent(ctx context.Context, pu PeerUpdate) {
 	}
 
 	if _, ok := m.store.peers[pu.NodeID]; !ok {
-		m.store.peers[pu.NodeID] = &peerInfo{}
+		peer := m.newPeerInfo(pu.NodeID)
+		m.store.peers[pu.NodeID] = &peer
 	}
 
+	m.decayScores()
 	switch pu.Status {
 	case PeerStatusBad:
 		m.store.peers[pu.NodeID].MutableScore--
+		m.maybeBan(ctx, pu.NodeID)
 	case PeerStatusGood:
 		m.store.peers[pu.NodeID].MutableScore++
 	}
@@ -979,6 +1152,88 @@ func (m *PeerManager) processPeerEvent(ctx context.Context, pu PeerUpdate) {
 	m.store.ranked = nil
 }
 
+// maybeBan bans a peer if its mutable score has dropped to or below
+// BanThreshold, evicting it if connected. Each ban of the same peer lasts
+// twice as long as the previous one, up to MaxBanDuration. The caller must
+// hold the mutex lock.
+func (m *PeerManager) maybeBan(ctx context.Context, peerID types.NodeID) {
+	peer := m.store.peers[peerID]
+	switch {
+	case m.options.MaxBanDuration == 0:
+		return
+	case peer.MutableScore > m.options.BanThreshold:
+		return
+	case m.options.isPersistent(peerID) || m.options.isUnconditional(peerID):
+		return
+	case peer.isBanned():
+		return
+	}
+
+	duration := m.banDuration(peer.NumBans)
+	peer.NumBans++
+	peer.BannedUntil = time.Now().UTC().Add(duration)
+	m.logger.Info("banning peer", "peer", peerID, "score", peer.MutableScore,
+		"duration", duration, "bans", peer.NumBans)
+
+	if m.connected[peerID] {
+		m.evict[peerID] = true
+		m.evictWaker.Wake()
+	}
+
+	// Wake up DialNext() once the ban has expired, so that we can consider
+	// dialing the peer again.
+	go func() {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			m.dialWaker.Wake()
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// banDuration calculates the duration of a ban using exponential backoff,
+// based on the number of times the peer has previously been banned.
+func (m *PeerManager) banDuration(bans uint32) time.Duration {
+	duration := m.options.MinBanDuration * time.Duration(math.Pow(2, float64(bans)))
+	if duration <= 0 || duration > m.options.MaxBanDuration {
+		duration = m.options.MaxBanDuration
+	}
+	return duration
+}
+
+// decayScores moves the mutable score of every peer one point back toward
+// DefaultMutableScore for each ScoreDecayInterval that has elapsed since the
+// scores were last decayed. The caller must hold the mutex lock.
+func (m *PeerManager) decayScores() {
+	if m.options.ScoreDecayInterval == 0 {
+		return
+	}
+	steps := int64(time.Since(m.lastDecay) / m.options.ScoreDecayInterval)
+	if steps <= 0 {
+		return
+	}
+	m.lastDecay = m.lastDecay.Add(time.Duration(steps) * m.options.ScoreDecayInterval)
+
+	for _, peer := range m.store.peers {
+		switch {
+		case peer.MutableScore > DefaultMutableScore:
+			peer.MutableScore -= steps
+			if peer.MutableScore < DefaultMutableScore {
+				peer.MutableScore = DefaultMutableScore
+			}
+		case peer.MutableScore < DefaultMutableScore:
+			peer.MutableScore += steps
+			if peer.MutableScore > DefaultMutableScore {
+				peer.MutableScore = DefaultMutableScore
+			}
+		}
+	}
+	// Invalidate the cache after scores changed
+	m.store.ranked = nil
+}
+
 // broadcast broadcasts a peer update to all subscriptions. The caller must
 // already hold the mutex lock, to make sure updates are sent in the same order
 // as the PeerManager processes them, but this means subscribers must be
@@ -1031,6 +1286,7 @@ func (m *PeerManager) Scores() map[types.NodeID]PeerScore {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	m.decayScores()
 	scores := map[types.NodeID]PeerScore{}
 	for _, peer := range m.store.Ranked() {
 		scores[peer.ID] = peer.Score()
@@ -1042,6 +1298,7 @@ func (m *PeerManager) Score(id types.NodeID) int {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	m.decayScores()
 	peer, ok := m.store.Get(id)
 	if ok {
 		return int(peer.Score())
@@ -1049,6 +1306,36 @@ func (m *PeerManager) Score(id types.NodeID) int {
 	return -1
 }
 
+// PeerScoreInfo describes how a peer is currently scored.
+type PeerScoreInfo struct {
+	ID           types.NodeID
+	Score        PeerScore
+	MutableScore int64
+	NumBans      uint32
+	BannedUntil  time.Time
+}
+
+// ScoreInfos returns scoring details for all known peers, ordered by score
+// (better peers first). It is mainly used for debugging.
+func (m *PeerManager) ScoreInfos() []PeerScoreInfo {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.decayScores()
+	ranked := m.store.Ranked()
+	infos := make([]PeerScoreInfo, 0, len(ranked))
+	for _, peer := range ranked {
+		infos = append(infos, PeerScoreInfo{
+			ID:           peer.ID,
+			Score:        peer.Score(),
+			MutableScore: peer.MutableScore,
+			NumBans:      peer.NumBans,
+			BannedUntil:  peer.BannedUntil,
+		})
+	}
+	return infos
+}
+
 // Status returns the status for a peer, primarily for testing.
 func (m *PeerManager) Status(id types.NodeID) PeerStatus {
 	m.mtx.Lock()
@@ -1323,14 +1610,18 @@ type peerInfo struct {
 	FixedScore    PeerScore // mainly for tests
 
 	MutableScore int64 // updated by router
+
+	NumBans     uint32    // number of times the peer has been banned
+	BannedUntil time.Time // zero if the peer has never been banned
 }
 
 // peerInfoFromProto converts a Protobuf PeerInfo message to a peerInfo,
 // erroring if the data is invalid.
 func peerInfoFromProto(msg *p2pproto.PeerInfo) (*peerInfo, error) {
 	p := &peerInfo{
-		ID:          types.NodeID(msg.ID),
-		AddressInfo: map[NodeAddress]*peerAddressInfo{},
+		ID:           types.NodeID(msg.ID),
+		AddressInfo:  map[NodeAddress]*peerAddressInfo{},
+		MutableScore: DefaultMutableScore,
 	}
 	if msg.LastConnected != nil {
 		p.LastConnected = *msg.LastConnected
@@ -1412,6 +1703,11 @@ func (p *peerInfo) Score() PeerScore {
 	return PeerScore(score)
 }
 
+// isBanned returns true if the peer is currently banned.
+func (p *peerInfo) isBanned() bool {
+	return time.Now().UTC().Before(p.BannedUntil)
+}
+
 // Validate validates the peer info.
 func (p *peerInfo) Validate() error {
 	if p.ID == "" {
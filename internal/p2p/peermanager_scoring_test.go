@@ -107,3 +107,142 @@ func TestPeerScoring(t *testing.T) {
 		}
 	})
 }
+
+func TestPeerScoreDecay(t *testing.T) {
+	selfKey := ed25519.GenPrivKeyFromSecret([]byte{0xf9, 0x1b, 0x08, 0xaa, 0x38, 0xee, 0x34, 0xdd})
+	selfID := types.NodeIDFromPubKey(selfKey.PubKey())
+
+	peerManager, err := NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), PeerManagerOptions{
+		ScoreDecayInterval: time.Minute,
+	}, NopMetrics())
+	require.NoError(t, err)
+
+	good := types.NodeID(strings.Repeat("a1", 20))
+	bad := types.NodeID(strings.Repeat("b2", 20))
+	for _, id := range []types.NodeID{good, bad} {
+		added, err := peerManager.Add(NodeAddress{NodeID: id, Protocol: "memory"})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: good, Status: PeerStatusGood})
+		peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: bad, Status: PeerStatusBad})
+	}
+	require.EqualValues(t, DefaultMutableScore+5, peerManager.Scores()[good])
+	require.EqualValues(t, DefaultMutableScore-5, peerManager.Scores()[bad])
+
+	// scores drift one point toward the default per elapsed interval
+	peerManager.lastDecay = peerManager.lastDecay.Add(-3 * time.Minute)
+	require.EqualValues(t, DefaultMutableScore+2, peerManager.Scores()[good])
+	require.EqualValues(t, DefaultMutableScore-2, peerManager.Scores()[bad])
+
+	// and never overshoot it
+	peerManager.lastDecay = peerManager.lastDecay.Add(-time.Hour)
+	require.EqualValues(t, DefaultMutableScore, peerManager.Scores()[good])
+	require.EqualValues(t, DefaultMutableScore, peerManager.Scores()[bad])
+}
+
+func TestPeerBan(t *testing.T) {
+	selfKey := ed25519.GenPrivKeyFromSecret([]byte{0xf9, 0x1b, 0x08, 0xaa, 0x38, 0xee, 0x34, 0xdd})
+	selfID := types.NodeIDFromPubKey(selfKey.PubKey())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	persistent := types.NodeID(strings.Repeat("c3", 20))
+	peerManager, err := NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), PeerManagerOptions{
+		PersistentPeers: []types.NodeID{persistent},
+		BanThreshold:    DefaultMutableScore - 2,
+		MinBanDuration:  time.Minute,
+		MaxBanDuration:  3 * time.Minute,
+	}, NopMetrics())
+	require.NoError(t, err)
+
+	id := types.NodeID(strings.Repeat("a1", 20))
+	address := NodeAddress{NodeID: id, Protocol: "memory"}
+	for _, a := range []NodeAddress{address, {NodeID: persistent, Protocol: "memory"}} {
+		added, err := peerManager.Add(a)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	require.NoError(t, peerManager.Accepted(id))
+
+	// a single bad event stays above the threshold
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: id, Status: PeerStatusBad})
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Empty(t, evict)
+
+	// reaching the threshold bans and evicts the peer
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: id, Status: PeerStatusBad})
+	evict, err = peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Equal(t, id, evict)
+	peerManager.Disconnected(ctx, id)
+
+	infos := peerManager.ScoreInfos()
+	var info PeerScoreInfo
+	for _, i := range infos {
+		if i.ID == id {
+			info = i
+		}
+	}
+	require.EqualValues(t, 1, info.NumBans)
+	require.WithinDuration(t, time.Now().Add(time.Minute), info.BannedUntil, 5*time.Second)
+
+	// banned peers are neither dialed nor accepted
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.NotEqual(t, address, dial)
+	require.Error(t, peerManager.Accepted(id))
+
+	// once the ban expires, the next ban lasts twice as long
+	peerManager.store.peers[id].BannedUntil = time.Now().Add(-time.Second)
+	require.NoError(t, peerManager.Accepted(id))
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: id, Status: PeerStatusBad})
+	require.EqualValues(t, 2, peerManager.store.peers[id].NumBans)
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), peerManager.store.peers[id].BannedUntil, 5*time.Second)
+
+	// and is capped at MaxBanDuration
+	require.Equal(t, 3*time.Minute, peerManager.banDuration(2))
+
+	// persistent peers are never banned
+	for i := 0; i < 10; i++ {
+		peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: persistent, Status: PeerStatusBad})
+	}
+	require.Zero(t, peerManager.store.peers[persistent].NumBans)
+}
+
+func TestPeerBanUnknownPeer(t *testing.T) {
+	selfKey := ed25519.GenPrivKeyFromSecret([]byte{0xf9, 0x1b, 0x08, 0xaa, 0x38, 0xee, 0x34, 0xdd})
+	selfID := types.NodeIDFromPubKey(selfKey.PubKey())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a threshold that would ban on the first report is rejected
+	_, err := NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), PeerManagerOptions{
+		BanThreshold:   DefaultMutableScore - 1,
+		MinBanDuration: time.Minute,
+		MaxBanDuration: time.Hour,
+	}, NopMetrics())
+	require.Error(t, err)
+
+	peerManager, err := NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), PeerManagerOptions{
+		MinBanDuration: time.Minute,
+		MaxBanDuration: time.Hour,
+	}, NopMetrics())
+	require.NoError(t, err)
+
+	// peers we have never heard of start at the default score, so a single
+	// bad report does not ban them
+	id := types.NodeID(strings.Repeat("d4", 20))
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: id, Status: PeerStatusBad})
+	require.EqualValues(t, DefaultMutableScore-1, peerManager.store.peers[id].MutableScore)
+	require.Zero(t, peerManager.store.peers[id].NumBans)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -73,6 +74,34 @@ func TestPeerManagerOptions_Validate(t *testing.T) {
 			MaxConnectedUpgrade: 1,
 		}, true},
 
+		// MaxInbound, MaxOutbound and ReservedSlots
+		"MaxInbound without MaxConnected": {p2p.PeerManagerOptions{
+			MaxInbound: 3,
+		}, true},
+		"MaxInbound above MaxConnected": {p2p.PeerManagerOptions{
+			MaxInbound:   3,
+			MaxConnected: 2,
+		}, false},
+		"MaxOutbound at MaxConnected": {p2p.PeerManagerOptions{
+			MaxOutbound:  2,
+			MaxConnected: 2,
+		}, true},
+		"MaxOutbound above MaxConnected": {p2p.PeerManagerOptions{
+			MaxOutbound:  3,
+			MaxConnected: 2,
+		}, false},
+		"ReservedSlots without MaxConnected": {p2p.PeerManagerOptions{
+			ReservedSlots: 1,
+		}, false},
+		"ReservedSlots at MaxConnected": {p2p.PeerManagerOptions{
+			ReservedSlots: 2,
+			MaxConnected:  2,
+		}, true},
+		"ReservedSlots above MaxConnected": {p2p.PeerManagerOptions{
+			ReservedSlots: 3,
+			MaxConnected:  2,
+		}, false},
+
 		// MaxRetryTime
 		"MaxRetryTime below MinRetryTime": {p2p.PeerManagerOptions{
 			MinRetryTime: 7 * time.Second,
@@ -98,6 +127,19 @@ func TestPeerManagerOptions_Validate(t *testing.T) {
 		"MaxRetryTimePersistent without MinRetryTime": {p2p.PeerManagerOptions{
 			MaxRetryTimePersistent: 5 * time.Second,
 		}, false},
+
+		// MaxBanDuration
+		"MaxBanDuration below MinBanDuration": {p2p.PeerManagerOptions{
+			MinBanDuration: 7 * time.Second,
+			MaxBanDuration: 5 * time.Second,
+		}, false},
+		"MaxBanDuration at MinBanDuration": {p2p.PeerManagerOptions{
+			MinBanDuration: 5 * time.Second,
+			MaxBanDuration: 5 * time.Second,
+		}, true},
+		"MaxBanDuration without MinBanDuration": {p2p.PeerManagerOptions{
+			MaxBanDuration: 5 * time.Second,
+		}, false},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -177,7 +219,8 @@ func TestNewPeerManager_Persistence(t *testing.T) {
 
 	// Creating a new peer manager with the same database should retain the
 	// peers, but they should have updated scores from the new PersistentPeers
-	// configuration.
+	// configuration. Scores aren't persisted, so reloaded peers start at the
+	// default score like newly added ones.
 	peerManager, err = p2p.NewPeerManager(log.NewNopLogger(), selfID, db, p2p.PeerManagerOptions{
 		PersistentPeers: []types.NodeID{bID},
 		PeerScores:      map[types.NodeID]p2p.PeerScore{cID: 1},
@@ -188,7 +231,7 @@ func TestNewPeerManager_Persistence(t *testing.T) {
 	require.ElementsMatch(t, bAddresses, peerManager.Addresses(bID))
 	require.ElementsMatch(t, cAddresses, peerManager.Addresses(cID))
 	require.Equal(t, map[types.NodeID]p2p.PeerScore{
-		aID: 0,
+		aID: 10,
 		bID: p2p.PeerScorePersistent,
 		cID: 1,
 	}, peerManager.Scores())
@@ -197,7 +240,7 @@ func TestNewPeerManager_Persistence(t *testing.T) {
 	ctx, _ := context.WithCancel(context.Background())
 	peerManager.DialFailed(ctx, bAddresses[0])
 	require.Equal(t, map[types.NodeID]p2p.PeerScore{
-		aID: 0,
+		aID: 10,
 		bID: p2p.PeerScorePersistent - 1,
 		cID: 1,
 	}, peerManager.Scores())
@@ -247,7 +290,8 @@ func TestNewPeerManager_Unconditional(t *testing.T) {
 
 	// Creating a new peer manager with the same database should retain the
 	// peers, but they should have updated scores from the new PersistentPeers
-	// configuration.
+	// configuration. Scores aren't persisted, so reloaded peers start at the
+	// default score like newly added ones.
 	peerManager, err = p2p.NewPeerManager(log.NewNopLogger(), selfID, db, p2p.PeerManagerOptions{
 		UnconditionalPeers: []types.NodeID{bID},
 		PeerScores:         map[types.NodeID]p2p.PeerScore{cID: 1},
@@ -258,7 +302,7 @@ func TestNewPeerManager_Unconditional(t *testing.T) {
 	require.ElementsMatch(t, bAddresses, peerManager.Addresses(bID))
 	require.ElementsMatch(t, cAddresses, peerManager.Addresses(cID))
 	require.Equal(t, map[types.NodeID]p2p.PeerScore{
-		aID: 0,
+		aID: 10,
 		bID: p2p.PeerScoreUnconditional,
 		cID: 1,
 	}, peerManager.Scores())
@@ -1154,6 +1198,93 @@ func TestPeerManager_Dialed_UpgradeNoEvict(t *testing.T) {
 	require.Zero(t, evict)
 }
 
+func TestPeerManager_CheckIP(t *testing.T) {
+	mustParseCIDRs := func(cidrs ...string) []*net.IPNet {
+		ipNets := make([]*net.IPNet, 0, len(cidrs))
+		for _, cidr := range cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			require.NoError(t, err)
+			ipNets = append(ipNets, ipNet)
+		}
+		return ipNets
+	}
+
+	aID := types.NodeID(strings.Repeat("a", 40))
+	bID := types.NodeID(strings.Repeat("b", 40))
+
+	testcases := map[string]struct {
+		options p2p.PeerManagerOptions
+		peerID  types.NodeID
+		ip      string
+		ok      bool
+	}{
+		"no ranges": {p2p.PeerManagerOptions{}, aID, "192.168.0.1", true},
+		"denied IPv4": {p2p.PeerManagerOptions{
+			DeniedCIDRs: mustParseCIDRs("192.168.0.0/16"),
+		}, aID, "192.168.1.1", false},
+		"not denied IPv4": {p2p.PeerManagerOptions{
+			DeniedCIDRs: mustParseCIDRs("192.168.0.0/16"),
+		}, aID, "10.0.0.1", true},
+		"denied IPv6": {p2p.PeerManagerOptions{
+			DeniedCIDRs: mustParseCIDRs("2001:db8::/32"),
+		}, aID, "2001:db8::1", false},
+		"not denied IPv6": {p2p.PeerManagerOptions{
+			DeniedCIDRs: mustParseCIDRs("2001:db8::/32"),
+		}, aID, "2001:db9::1", true},
+		"denied IPv4-mapped IPv6": {p2p.PeerManagerOptions{
+			DeniedCIDRs: mustParseCIDRs("192.168.0.0/16"),
+		}, aID, "::ffff:192.168.1.1", false},
+		"allowed IPv4": {p2p.PeerManagerOptions{
+			AllowedCIDRs: mustParseCIDRs("10.0.0.0/8", "2001:db8::/32"),
+		}, aID, "10.1.2.3", true},
+		"allowed IPv6": {p2p.PeerManagerOptions{
+			AllowedCIDRs: mustParseCIDRs("10.0.0.0/8", "2001:db8::/32"),
+		}, aID, "2001:db8::1", true},
+		"not allowed": {p2p.PeerManagerOptions{
+			AllowedCIDRs: mustParseCIDRs("10.0.0.0/8", "2001:db8::/32"),
+		}, aID, "fe80::1", false},
+		"allowed but denied": {p2p.PeerManagerOptions{
+			AllowedCIDRs: mustParseCIDRs("10.0.0.0/8"),
+			DeniedCIDRs:  mustParseCIDRs("10.1.0.0/16"),
+		}, aID, "10.1.0.1", false},
+		"unconditional bypasses denied": {p2p.PeerManagerOptions{
+			DeniedCIDRs:        mustParseCIDRs("2001:db8::/32"),
+			UnconditionalPeers: []types.NodeID{aID},
+		}, aID, "2001:db8::1", true},
+		"unknown peer isn't checked against denied": {p2p.PeerManagerOptions{
+			DeniedCIDRs:        mustParseCIDRs("2001:db8::/32"),
+			UnconditionalPeers: []types.NodeID{aID},
+		}, "", "2001:db8::1", true},
+		"unknown peer is checked against allowed": {p2p.PeerManagerOptions{
+			AllowedCIDRs: mustParseCIDRs("10.0.0.0/8"),
+		}, "", "192.168.0.1", false},
+		"unconditional of another peer": {p2p.PeerManagerOptions{
+			DeniedCIDRs:        mustParseCIDRs("2001:db8::/32"),
+			UnconditionalPeers: []types.NodeID{aID},
+		}, bID, "2001:db8::1", false},
+		"unconditional doesn't bypass allowed": {p2p.PeerManagerOptions{
+			AllowedCIDRs:       mustParseCIDRs("10.0.0.0/8"),
+			UnconditionalPeers: []types.NodeID{aID},
+		}, aID, "192.168.0.1", false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			peerManager, err := p2p.NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), tc.options, p2p.NopMetrics())
+			require.NoError(t, err)
+
+			err = peerManager.CheckIP(tc.peerID, net.ParseIP(tc.ip))
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			// endpoints without an IP are never filtered
+			require.NoError(t, peerManager.CheckIP(tc.peerID, nil))
+		})
+	}
+}
+
 func TestPeerManager_Accepted(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
@@ -1229,6 +1360,127 @@ func TestPeerManager_Accepted_MaxConnected(t *testing.T) {
 	require.Error(t, peerManager.Accepted(c.NodeID))
 }
 
+func TestPeerManager_MaxInboundOutbound(t *testing.T) {
+	peers := make([]p2p.NodeAddress, 8)
+	for i := range peers {
+		peers[i] = p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat(string(rune('0'+i)), 40))}
+	}
+	persistent := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("f", 40))}
+
+	peerManager, err := p2p.NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{persistent.NodeID},
+		MaxConnected:    10,
+		MaxInbound:      2,
+		MaxOutbound:     2,
+	}, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	// Two peers connect to us, the third is rejected.
+	require.NoError(t, peerManager.Accepted(peers[0].NodeID))
+	require.NoError(t, peerManager.Accepted(peers[1].NodeID))
+	require.Error(t, peerManager.Accepted(peers[2].NodeID))
+
+	// The persistent peer doesn't count toward the limit.
+	require.NoError(t, peerManager.Accepted(persistent.NodeID))
+
+	// We dial two peers, and no more.
+	for _, peer := range peers[2:] {
+		added, err := peerManager.Add(peer)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	var dialed []p2p.NodeAddress
+	for i := 0; i < 2; i++ {
+		dial, err := peerManager.TryDialNext()
+		require.NoError(t, err)
+		require.NotZero(t, dial)
+		dialed = append(dialed, dial)
+	}
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Zero(t, dial)
+	for _, dial := range dialed {
+		require.NoError(t, peerManager.Dialed(dial))
+	}
+
+	// The limits are independent: an outbound peer leaving doesn't make room
+	// for an inbound one, and the other way around.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inbound := []types.NodeID{peers[0].NodeID, peers[1].NodeID}
+	for i := 0; i < 5; i++ {
+		peerManager.Disconnected(ctx, dialed[0].NodeID)
+		require.Error(t, peerManager.Accepted(dialed[0].NodeID))
+		dial, err := peerManager.TryDialNext()
+		require.NoError(t, err)
+		require.NotZero(t, dial)
+		require.NoError(t, peerManager.Dialed(dial))
+		dialed = append(dialed[1:], dial)
+
+		peerManager.Disconnected(ctx, inbound[0])
+		dial, err = peerManager.TryDialNext()
+		require.NoError(t, err)
+		require.Zero(t, dial)
+		require.NoError(t, peerManager.Accepted(inbound[0]))
+		inbound = append(inbound[1:], inbound[0])
+	}
+}
+
+func TestPeerManager_ReservedSlots(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+	p := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("f", 40))}
+
+	peerManager, err := p2p.NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{p.NodeID},
+		MaxConnected:    3,
+		ReservedSlots:   1,
+	}, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	// a and b take the unreserved slots.
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	added, err := peerManager.Add(b)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, dial)
+	require.NoError(t, peerManager.Dialed(b))
+
+	// c can't take the reserved slot, neither inbound nor outbound, while the
+	// persistent peer can.
+	require.Error(t, peerManager.Accepted(c.NodeID))
+	for _, peer := range []p2p.NodeAddress{c, p} {
+		added, err = peerManager.Add(peer)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, p, dial)
+	require.NoError(t, peerManager.Dialed(p))
+
+	// The reserved slot stays free for the persistent peer while it
+	// reconnects, whatever the other peers do.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		peerManager.Disconnected(ctx, p.NodeID)
+		require.Error(t, peerManager.Accepted(c.NodeID))
+		require.NoError(t, peerManager.Accepted(p.NodeID))
+
+		// a leaving makes room for c, but not for a third transient peer.
+		peerManager.Disconnected(ctx, a.NodeID)
+		require.NoError(t, peerManager.Accepted(c.NodeID))
+		require.Error(t, peerManager.Accepted(a.NodeID))
+		a, c = c, a
+	}
+}
+
 func TestPeerManager_Accepted_MaxConnectedUpgrade(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
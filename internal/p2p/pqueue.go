@@ -207,7 +207,10 @@ func (s *pqScheduler) process(ctx context.Context) {
 								canEnqueue = true
 							} else {
 								pqEnvTmpChIDStr := strconv.Itoa(int(pqEnvTmp.envelope.ChannelID))
-								s.metrics.PeerQueueDroppedMsgs.With("ch_id", pqEnvTmpChIDStr).Add(1)
+								s.metrics.PeerQueueDroppedMsgs.With(
+									"peer_id", string(pqEnvTmp.envelope.To),
+									"ch_id", pqEnvTmpChIDStr).Add(1)
+								s.metrics.PeerQueueDepth.With("peer_id", string(pqEnvTmp.envelope.To)).Add(-1)
 								s.logger.Debug(
 									"dropped envelope",
 									"ch_id", pqEnvTmpChIDStr,
@@ -237,7 +240,10 @@ func (s *pqScheduler) process(ctx context.Context) {
 				} else {
 					// There is not sufficient capacity to drop lower priority Envelopes,
 					// so we drop the incoming Envelope.
-					s.metrics.PeerQueueDroppedMsgs.With("ch_id", chIDStr).Add(1)
+					s.metrics.PeerQueueDroppedMsgs.With(
+						"peer_id", string(pqEnv.envelope.To),
+						"ch_id", chIDStr).Add(1)
+					s.metrics.PeerQueueDepth.With("peer_id", string(pqEnv.envelope.To)).Add(-1)
 					s.logger.Debug(
 						"dropped envelope",
 						"ch_id", chIDStr,
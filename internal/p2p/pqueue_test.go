@@ -2,10 +2,13 @@ package p2p
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -45,3 +48,61 @@ func TestCloseWhileDequeueFull(t *testing.T) {
 		t.Fatal("pqueue failed to close")
 	}
 }
+
+// labeledValues records the value of a counter or gauge for each set of
+// label values.
+type labeledValues struct {
+	values map[string]float64
+	lvs    []string
+}
+
+func newLabeledValues() *labeledValues {
+	return &labeledValues{values: map[string]float64{}}
+}
+
+func (v *labeledValues) With(labelValues ...string) metrics.Gauge {
+	return &labeledValues{values: v.values, lvs: append(append([]string{}, v.lvs...), labelValues...)}
+}
+
+func (v *labeledValues) Set(value float64) { v.values[strings.Join(v.lvs, ",")] = value }
+func (v *labeledValues) Add(delta float64) { v.values[strings.Join(v.lvs, ",")] += delta }
+
+func (v *labeledValues) get(labelValues ...string) float64 {
+	return v.values[strings.Join(labelValues, ",")]
+}
+
+type labeledCounter struct{ *labeledValues }
+
+func (c labeledCounter) With(labelValues ...string) metrics.Counter {
+	return labeledCounter{c.labeledValues.With(labelValues...).(*labeledValues)}
+}
+
+func TestPQSchedulerDropMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	depth, dropped := newLabeledValues(), newLabeledValues()
+	m := NopMetrics()
+	m.PeerQueueDepth = depth
+	m.PeerQueueDroppedMsgs = labeledCounter{dropped}
+
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1}}
+	pqueue := newPQScheduler(log.NewNopLogger(), m, newMetricsLabelCache(), chDescs, 1, 1, 4)
+	go pqueue.process(ctx)
+	defer pqueue.close()
+
+	// the router counts the envelope in the queue depth once enqueued, and
+	// the scheduler drops it since it exceeds the capacity
+	depth.With("peer_id", "peer").Add(1)
+	pqueue.enqueue() <- Envelope{
+		To:        "peer",
+		ChannelID: 0x01,
+		Message:   &testMessage{Value: "foo"}, // 5 bytes
+	}
+
+	// once the next envelope is dequeued, the first one has been processed
+	pqueue.enqueue() <- Envelope{To: "other", ChannelID: 0x01, Message: &testMessage{}}
+	<-pqueue.dequeue()
+	require.Equal(t, float64(1), dropped.get("peer_id", "peer", "ch_id", "1"))
+	require.Zero(t, depth.get("peer_id", "peer"))
+}
@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"net"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -73,6 +74,11 @@ type RouterOptions struct {
 	// are used to dial peers. This defaults to the value of
 	// runtime.NumCPU.
 	NumConcurrentDials func() int
+
+	// DrainTimeout bounds the time the router spends sending the messages
+	// already queued for peers when it stops, before closing the
+	// connections. 0 disables draining, dropping queued messages.
+	DrainTimeout time.Duration
 }
 
 const (
@@ -104,6 +110,10 @@ func (o *RouterOptions) Validate() error {
 		o.MaxIncomingConnectionAttempts = 100
 	}
 
+	if o.DrainTimeout < 0 {
+		return fmt.Errorf("drain timeout must not be negative [%s]", o.DrainTimeout)
+	}
+
 	return nil
 }
 
@@ -178,6 +188,16 @@ type Router struct {
 	chDescsToBeAdded []chDescAdderWithCallback
 
 	dynamicIDFilterer func(context.Context, types.NodeID) error
+
+	// draining is closed when the router stops routing outbound messages and
+	// flushes the peer queues, which must be done by drainDeadline. The
+	// senders are the routePeer calls which started before then, and
+	// cancelPeers ends the peer connections, which outlive the router context
+	// when draining is enabled.
+	draining      chan struct{}
+	drainDeadline time.Time
+	senders       sync.WaitGroup
+	cancelPeers   context.CancelFunc
 }
 
 type chDescAdderWithCallback struct {
@@ -224,6 +244,7 @@ func NewRouter(
 		peerQueues:        map[types.NodeID]queue{},
 		peerChannels:      make(map[types.NodeID]ChannelIDSet),
 		dynamicIDFilterer: dynamicIDFilterer,
+		draining:          make(chan struct{}),
 	}
 
 	router.BaseService = service.NewBaseService(logger, "router", router)
@@ -334,6 +355,13 @@ func (r *Router) routeChannel(
 				continue
 			}
 
+			// The queued messages are being flushed before stopping, and
+			// must not be followed by new ones, e.g. votes.
+			if r.isDraining() {
+				r.logger.Debug("router is draining, dropping message", "channel", chID)
+				continue
+			}
+
 			// Mark the envelope with the channel ID to allow sendPeer() to pass
 			// it on to Transport.SendMessage().
 			envelope.ChannelID = chID
@@ -350,17 +378,17 @@ func (r *Router) routeChannel(
 			}
 
 			// collect peer queues to pass the message via
-			var queues []queue
+			var queues map[types.NodeID]queue
 			if envelope.Broadcast {
 				r.peerMtx.RLock()
 
-				queues = make([]queue, 0, len(r.peerQueues))
+				queues = make(map[types.NodeID]queue, len(r.peerQueues))
 				for nodeID, q := range r.peerQueues {
 					peerChs := r.peerChannels[nodeID]
 
 					// check whether the peer is receiving on that channel
 					if _, ok := peerChs[chID]; ok {
-						queues = append(queues, q)
+						queues[nodeID] = q
 					}
 				}
 
@@ -391,19 +419,37 @@ func (r *Router) routeChannel(
 					continue
 				}
 
-				queues = []queue{q}
+				queues = map[types.NodeID]queue{envelope.To: q}
 			}
 
 			// send message to peers
-			for _, q := range queues {
+			chIDStr := strconv.Itoa(int(chID))
+			for peerID, q := range queues {
 				start := time.Now().UTC()
 
+				// address broadcasts to each peer, so that the queue can
+				// attribute its metrics to it
+				envelope.To = peerID
+
+				// try a non-blocking send first, so that peers whose queue is
+				// full can be told apart from the ones keeping up
 				select {
 				case q.enqueue() <- envelope:
 					r.metrics.RouterPeerQueueSend.Observe(time.Since(start).Seconds())
+					r.metrics.PeerQueueDepth.With("peer_id", string(peerID)).Add(1)
+					continue
+				default:
+					r.metrics.PeerQueueThrottledMsgs.With("peer_id", string(peerID), "ch_id", chIDStr).Add(1)
+				}
+
+				select {
+				case q.enqueue() <- envelope:
+					r.metrics.RouterPeerQueueSend.Observe(time.Since(start).Seconds())
+					r.metrics.PeerQueueDepth.With("peer_id", string(peerID)).Add(1)
 
 				case <-q.closed():
-					r.logger.Debug("dropping message for unconnected peer", "peer", envelope.To, "channel", chID)
+					r.metrics.PeerDroppedMsgs.With("peer_id", string(peerID), "ch_id", chIDStr).Add(1)
+					r.logger.Debug("dropping message for unconnected peer", "peer", peerID, "channel", chID)
 
 				case <-ctx.Done():
 					return
@@ -509,7 +555,16 @@ func (r *Router) acceptPeers(ctx context.Context, transport Transport) {
 			continue
 		}
 
+		// The allowed IP ranges are checked before the handshake. The denied
+		// ranges are checked once the peer ID is known, since unconditional
+		// peers bypass them.
 		incomingIP := conn.RemoteEndpoint().IP
+		if err := r.peerManager.CheckIP("", incomingIP); err != nil {
+			closeErr := conn.Close()
+			r.logger.Debug("peer filtered by IP range", "ip", incomingIP.String(), "err", err, "close_err", closeErr)
+			continue
+		}
+
 		if err := r.connTracker.AddConn(incomingIP); err != nil {
 			closeErr := conn.Close()
 			r.logger.Error("rate limiting incoming peer",
@@ -562,6 +617,10 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 		r.logger.Error("peer handshake failed", "endpoint", conn, "err", err)
 		return
 	}
+	if err := r.peerManager.CheckIP(peerInfo.NodeID, incomingIP); err != nil {
+		r.logger.Debug("peer filtered by IP range", "node", peerInfo.NodeID, "ip", incomingIP.String(), "err", err)
+		return
+	}
 	if err := r.filterPeersID(ctx, peerInfo.NodeID); err != nil {
 		r.logger.Debug("peer filtered by node ID", "node", peerInfo.NodeID, "err", err)
 		return
@@ -663,6 +722,22 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	}
 
+	if peerInfo.NodeID != address.NodeID {
+		// The peer rotated its node key: dial it with its new node ID from
+		// now on.
+		r.logger.Info("peer rotated its node key", "peer", address.NodeID, "new_peer", peerInfo.NodeID)
+		newAddress := address
+		newAddress.NodeID = peerInfo.NodeID
+		if _, err := r.peerManager.Add(newAddress); err != nil {
+			r.logger.Error("failed to add rotated peer address", "peer", newAddress, "err", err)
+		}
+		if err = r.peerManager.DialFailed(ctx, address); err != nil {
+			r.logger.Error("failed to report dial failure", "peer", address, "err", err)
+		}
+		conn.Close()
+		return
+	}
+
 	if err := r.runWithPeerMutex(func() error { return r.peerManager.Dialed(address) }); err != nil {
 		// If peer is trying to reconnect, fail it and let it reconnect
 		if strings.Contains(err.Error(), "is already connected") {
@@ -718,6 +793,11 @@ func (r *Router) dialPeer(ctx context.Context, address NodeAddress) (Connection,
 	}
 
 	for _, endpoint := range endpoints {
+		if err := r.peerManager.CheckIP(address.NodeID, endpoint.IP); err != nil {
+			r.logger.Debug("skipping filtered endpoint", "peer", address.NodeID, "endpoint", endpoint, "err", err)
+			continue
+		}
+
 		dialCtx := ctx
 		if r.options.DialTimeout > 0 {
 			var cancel context.CancelFunc
@@ -744,7 +824,9 @@ func (r *Router) dialPeer(ctx context.Context, address NodeAddress) (Connection,
 }
 
 // handshakePeer handshakes with a peer, validating the peer's information. If
-// expectID is given, we check that the peer's info matches it.
+// expectID is given, we check that the peer's info matches it, or that the
+// peer rotated its node key from it and is still in the grace period of the
+// rotation.
 func (r *Router) handshakePeer(
 	ctx context.Context,
 	conn Connection,
@@ -779,8 +861,11 @@ func (r *Router) handshakePeer(
 			peerInfo.NodeID, types.NodeIDFromPubKey(peerKey))
 	}
 	if expectID != "" && expectID != peerInfo.NodeID {
-		return peerInfo, fmt.Errorf("expected to connect with peer %q, got %q",
-			expectID, peerInfo.NodeID)
+		rotation := peerInfo.KeyRotation
+		if rotation == nil || rotation.PreviousNodeID() != expectID || rotation.IsExpired(time.Now()) {
+			return peerInfo, fmt.Errorf("expected to connect with peer %q, got %q",
+				expectID, peerInfo.NodeID)
+		}
 	}
 
 	if err := nodeInfo.CompatibleWith(peerInfo); err != nil {
@@ -803,6 +888,13 @@ func (r *Router) runWithPeerMutex(fn func() error) error {
 // channels. It will close the given connection and send queue when done, or if
 // they are closed elsewhere it will cause this method to shut down and return.
 func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connection, channels ChannelIDSet) {
+	r.peerMtx.Lock()
+	if !r.isDraining() {
+		r.senders.Add(1)
+		defer r.senders.Done()
+	}
+	r.peerMtx.Unlock()
+
 	r.metrics.Peers.Add(1)
 	r.peerManager.Ready(ctx, peerID, channels)
 
@@ -817,6 +909,7 @@ func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connec
 
 		r.peerManager.Disconnected(ctx, peerID)
 		r.metrics.Peers.Add(-1)
+		r.metrics.PeerQueueDepth.With("peer_id", string(peerID)).Set(0)
 	}()
 
 	r.logger.Info("peer connected", "peer", peerID, "endpoint", conn)
@@ -843,7 +936,11 @@ func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connec
 	case <-ctx.Done():
 	}
 
-	_ = conn.Close()
+	if fc, ok := conn.(flushCloser); ok && r.isDraining() {
+		_ = fc.FlushClose(r.drainDeadline)
+	} else {
+		_ = conn.Close()
+	}
 	sendQueue.close()
 
 	select {
@@ -930,23 +1027,12 @@ func (r *Router) sendPeer(ctx context.Context, peerID types.NodeID, conn Connect
 		select {
 		case envelope := <-peerQueue.dequeue():
 			r.metrics.RouterPeerQueueRecv.Observe(time.Since(start).Seconds())
-			if envelope.Message == nil {
-				r.logger.Error("dropping nil message", "peer", peerID)
-				continue
-			}
-
-			bz, err := proto.Marshal(envelope.Message)
-			if err != nil {
-				r.logger.Error("failed to marshal message", "peer", peerID, "err", err)
-				continue
-			}
-
-			if err = conn.SendMessage(ctx, envelope.ChannelID, bz); err != nil {
-				r.logger.Error("failed to send message", "peer", peerID, "err", err)
+			if err := r.sendEnvelope(ctx, peerID, conn, envelope); err != nil {
 				return err
 			}
 
-			r.logger.Debug("sent message", "peer", envelope.To, "message", envelope.Message)
+		case <-r.draining:
+			return r.drainPeer(ctx, peerID, conn, peerQueue)
 
 		case <-peerQueue.closed():
 			return nil
@@ -957,6 +1043,46 @@ func (r *Router) sendPeer(ctx context.Context, peerID types.NodeID, conn Connect
 	}
 }
 
+// drainPeer sends the messages left in a peer queue once the router is
+// draining, and returns when the queue is empty.
+func (r *Router) drainPeer(ctx context.Context, peerID types.NodeID, conn Connection, peerQueue queue) error {
+	for {
+		select {
+		case envelope := <-peerQueue.dequeue():
+			if err := r.sendEnvelope(ctx, peerID, conn, envelope); err != nil {
+				return err
+			}
+
+		default:
+			return nil
+		}
+	}
+}
+
+// sendEnvelope marshals a dequeued message and passes it to the peer
+// connection. Only connection errors are returned.
+func (r *Router) sendEnvelope(ctx context.Context, peerID types.NodeID, conn Connection, envelope Envelope) error {
+	r.metrics.PeerQueueDepth.With("peer_id", string(peerID)).Add(-1)
+	if envelope.Message == nil {
+		r.logger.Error("dropping nil message", "peer", peerID)
+		return nil
+	}
+
+	bz, err := proto.Marshal(envelope.Message)
+	if err != nil {
+		r.logger.Error("failed to marshal message", "peer", peerID, "err", err)
+		return nil
+	}
+
+	if err = conn.SendMessage(ctx, envelope.ChannelID, bz); err != nil {
+		r.logger.Error("failed to send message", "peer", peerID, "err", err)
+		return err
+	}
+
+	r.logger.Debug("sent message", "peer", envelope.To, "message", envelope.Message)
+	return nil
+}
+
 // evictPeers evicts connected peers as requested by the peer manager.
 func (r *Router) evictPeers(ctx context.Context) {
 	for {
@@ -999,6 +1125,19 @@ func (r *Router) AddChDescToBeAdded(chDesc *ChannelDescriptor, callback func(*Ch
 	})
 }
 
+// SetChannelPriority overrides the send priority of a channel on all current
+// and future peer connections, if the transport supports it. A priority of 0
+// restores the priority of the channel descriptor.
+func (r *Router) SetChannelPriority(chID ChannelID, priority int) error {
+	t, ok := r.transport.(interface {
+		SetChannelPriority(ChannelID, int) error
+	})
+	if !ok {
+		return fmt.Errorf("transport %v does not support channel priorities", r.transport)
+	}
+	return t.SetChannelPriority(chID, priority)
+}
+
 // OnStart implements service.Service.
 func (r *Router) OnStart(ctx context.Context) error {
 	if err := r.setupQueueFactory(ctx); err != nil {
@@ -1017,9 +1156,17 @@ func (r *Router) OnStart(ctx context.Context) error {
 		}
 	}
 
-	go r.dialPeers(ctx)
+	// With draining enabled, the peer connections outlive ctx until OnStop
+	// has flushed their queues.
+	peerCtx := ctx
+	r.cancelPeers = func() {}
+	if r.options.DrainTimeout > 0 {
+		peerCtx, r.cancelPeers = context.WithCancel(context.WithoutCancel(ctx))
+	}
+
+	go r.dialPeers(peerCtx)
 	go r.evictPeers(ctx)
-	go r.acceptPeers(ctx, r.transport)
+	go r.acceptPeers(peerCtx, r.transport)
 
 	return nil
 }
@@ -1036,6 +1183,11 @@ func (r *Router) OnStop() {
 		r.logger.Error("failed to close transport", "err", err)
 	}
 
+	if r.options.DrainTimeout > 0 {
+		r.drainPeers()
+	}
+	r.cancelPeers()
+
 	// Collect all remaining queues, and wait for them to close.
 	queues := []queue{}
 
@@ -1057,6 +1209,46 @@ func (r *Router) OnStop() {
 	}
 }
 
+// flushCloser is implemented by connections which buffer the messages passed
+// to SendMessage, and can send them before closing.
+type flushCloser interface {
+	FlushClose(deadline time.Time) error
+}
+
+// drainPeers stops routing outbound messages, and waits for the messages
+// already queued for each peer to be sent and the peer connections to be
+// closed, for no longer than the drain timeout.
+func (r *Router) drainPeers() {
+	r.peerMtx.Lock()
+	r.drainDeadline = time.Now().Add(r.options.DrainTimeout)
+	close(r.draining)
+	r.peerMtx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.senders.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(r.options.DrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		r.logger.Info("drained peer queues")
+	case <-timer.C:
+		r.logger.Error("timed out draining peer queues", "timeout", r.options.DrainTimeout)
+	}
+}
+
+func (r *Router) isDraining() bool {
+	select {
+	case <-r.draining:
+		return true
+	default:
+		return false
+	}
+}
+
 type ChannelIDSet map[ChannelID]struct{}
 
 func (cs ChannelIDSet) Contains(id ChannelID) bool {
@@ -662,6 +662,95 @@ func TestRouter_DialPeers(t *testing.T) {
 	}
 }
 
+func TestRouter_DialPeers_KeyRotation(t *testing.T) {
+	newKey := types.GenNodeKey()
+	newInfo := peerInfo.Copy()
+	newInfo.NodeID = newKey.ID
+	newInfo.Moniker = string(newKey.ID)
+
+	testcases := map[string]struct {
+		expires time.Time
+		rotated bool
+	}{
+		"in grace period": {time.Now().Add(time.Hour), true},
+		"expired":         {time.Now().Add(-time.Hour), false},
+	}
+
+	bctx, bcancel := context.WithCancel(context.Background())
+	defer bcancel()
+
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(leaktest.Check(t))
+			ctx, cancel := context.WithCancel(bctx)
+			defer cancel()
+
+			rotation, err := types.NewNodeKeyRotation(types.NodeKey{ID: peerID, PrivKey: peerKey}, newKey.ID, tc.expires)
+			require.NoError(t, err)
+			rotatedInfo := newInfo.Copy()
+			rotatedInfo.KeyRotation = rotation
+
+			address := p2p.NodeAddress{Protocol: "mock", NodeID: peerID}
+			endpoint := &p2p.Endpoint{Protocol: "mock", Path: string(peerID)}
+
+			// Set up a mock transport that handshakes with the rotated key.
+			connCtx, connCancel := context.WithCancel(context.Background())
+			defer connCancel()
+			mockConnection := &mocks.Connection{}
+			mockConnection.On("String").Maybe().Return("mock")
+			mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
+				Return(rotatedInfo, newKey.PubKey(), nil)
+			mockConnection.On("Close").Run(func(_ mock.Arguments) { connCancel() }).Return(nil)
+
+			mockTransport := &mocks.Transport{}
+			mockTransport.On("String").Maybe().Return("mock")
+			mockTransport.On("Close").Return(nil).Maybe()
+			mockTransport.On("Listen", mock.Anything).Return(nil)
+			mockTransport.On("Accept", mock.Anything).Maybe().Return(nil, io.EOF)
+			mockTransport.On("Dial", mock.Anything, endpoint).Once().Return(mockConnection, nil)
+			mockTransport.On("Dial", mock.Anything, mock.Anything).Maybe().Return(nil, io.EOF)
+
+			peerManager, err := p2p.NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{}, p2p.NopMetrics())
+			require.NoError(t, err)
+			added, err := peerManager.Add(address)
+			require.NoError(t, err)
+			require.True(t, added)
+
+			router, err := p2p.NewRouter(
+				log.NewNopLogger(),
+				p2p.NopMetrics(),
+				selfKey,
+				peerManager,
+				func() *types.NodeInfo { return &selfInfo },
+				mockTransport,
+				nil,
+				nil,
+				p2p.RouterOptions{},
+			)
+			require.NoError(t, err)
+			require.NoError(t, router.Start(ctx))
+
+			// The connection is closed either way, but the peer is only
+			// dialed with its new node ID during the grace period.
+			select {
+			case <-connCtx.Done():
+			case <-time.After(time.Second):
+				require.Fail(t, "connection not closed")
+			}
+			router.Stop()
+
+			if tc.rotated {
+				require.Equal(t, []p2p.NodeAddress{{Protocol: "mock", NodeID: newKey.ID}},
+					peerManager.Addresses(newKey.ID))
+			} else {
+				require.Empty(t, peerManager.Addresses(newKey.ID))
+			}
+			mockConnection.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRouter_DialPeers_Parallel(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
@@ -1014,3 +1103,106 @@ func TestRouter_Channel_FilterByID(t *testing.T) {
 
 	require.Equal(t, 0, len(peerManager.Peers()))
 }
+
+func TestRouter_DrainOnStop(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Block sends until the router stops, to queue messages up.
+	unblock := make(chan struct{})
+	var sentMtx sync.Mutex
+	sent := []string{}
+
+	connCtx, connCancel := context.WithCancel(context.Background())
+	mockConnection := &mocks.Connection{}
+	mockConnection.On("String").Maybe().Return("mock")
+	mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
+		Return(peerInfo, peerKey.PubKey(), nil)
+	mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{})
+	mockConnection.On("Close").Run(func(_ mock.Arguments) { connCancel() }).Return(nil)
+	mockConnection.On("ReceiveMessage", mock.Anything).
+		Run(func(_ mock.Arguments) { <-connCtx.Done() }).
+		Return(chID, nil, io.EOF)
+	mockConnection.On("SendMessage", mock.Anything, chID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-unblock
+			msg := &p2ptest.Message{}
+			require.NoError(t, proto.Unmarshal(args.Get(2).([]byte), msg))
+			sentMtx.Lock()
+			sent = append(sent, msg.Value)
+			sentMtx.Unlock()
+		}).
+		Return(nil)
+
+	mockTransport := &mocks.Transport{}
+	mockTransport.On("AddChannelDescriptors", mock.Anything).Return()
+	mockTransport.On("String").Maybe().Return("mock")
+	mockTransport.On("Close").Return(nil)
+	mockTransport.On("Accept", mock.Anything).Once().Return(mockConnection, nil)
+	mockTransport.On("Accept", mock.Anything).Maybe().Return(nil, io.EOF)
+	mockTransport.On("Listen", mock.Anything).Return(nil)
+
+	peerManager, err := p2p.NewPeerManager(log.NewNopLogger(), selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{}, p2p.NopMetrics())
+	require.NoError(t, err)
+
+	sub := peerManager.Subscribe(ctx)
+
+	router, err := p2p.NewRouter(
+		log.NewNopLogger(),
+		p2p.NopMetrics(),
+		selfKey,
+		peerManager,
+		func() *types.NodeInfo { return &selfInfo },
+		mockTransport,
+		nil,
+		nil,
+		p2p.RouterOptions{DrainTimeout: 5 * time.Second},
+	)
+	require.NoError(t, err)
+	require.NoError(t, router.Start(ctx))
+
+	p2ptest.RequireUpdate(t, sub, p2p.PeerUpdate{
+		NodeID: peerInfo.NodeID,
+		Status: p2p.PeerStatusUp,
+	})
+
+	channel, err := router.OpenChannel(ctx, chDesc)
+	require.NoError(t, err)
+
+	queued := []string{"a", "b", "c"}
+	for _, value := range queued {
+		p2ptest.RequireSend(ctx, t, channel, p2p.Envelope{
+			To:      peerID,
+			Message: &p2ptest.Message{Value: value},
+		})
+	}
+	time.Sleep(50 * time.Millisecond) // let the router queue them
+
+	stopped := make(chan struct{})
+	go func() {
+		router.Stop()
+		close(stopped)
+	}()
+	time.Sleep(50 * time.Millisecond) // let the router start draining
+
+	// Messages sent while draining are dropped.
+	p2ptest.RequireSend(ctx, t, channel, p2p.Envelope{
+		To:      peerID,
+		Message: &p2ptest.Message{Value: "new"},
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	close(unblock)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "router did not stop")
+	}
+
+	sentMtx.Lock()
+	require.Equal(t, queued, sent)
+	sentMtx.Unlock()
+	mockConnection.AssertCalled(t, "Close")
+	mockTransport.AssertExpectations(t)
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/net/netutil"
 
@@ -35,6 +36,17 @@ type MConnTransportOptions struct {
 	// Router, since it will need to do e.g. rate limiting and such as well.
 	// But it might also make sense to have per-transport limits.
 	MaxAcceptedConnections uint32
+
+	// HandshakeTimeout is the timeout for handshaking with a peer, i.e.
+	// authentication and the NodeInfo exchange. 0 means no timeout.
+	HandshakeTimeout time.Duration
+
+	// AuthTimeout is the timeout for the SecretConnection key exchange and
+	// authentication. 0 means no timeout.
+	AuthTimeout time.Duration
+
+	// Metrics records the bytes sent on each channel. nil means no metrics.
+	Metrics *Metrics
 }
 
 // MConnTransport is a Transport implementation using the current multiplexed
@@ -44,6 +56,7 @@ type MConnTransport struct {
 	options      MConnTransportOptions
 	mConnConfig  conn.MConnConfig
 	channelDescs []*ChannelDescriptor
+	priorities   *channelPriorities
 
 	closeOnce sync.Once
 	doneCh    chan struct{}
@@ -59,12 +72,16 @@ func NewMConnTransport(
 	channelDescs []*ChannelDescriptor,
 	options MConnTransportOptions,
 ) *MConnTransport {
+	if options.Metrics == nil {
+		options.Metrics = NopMetrics()
+	}
 	return &MConnTransport{
 		logger:       logger,
 		options:      options,
 		mConnConfig:  mConnConfig,
 		doneCh:       make(chan struct{}),
 		channelDescs: channelDescs,
+		priorities:   newChannelPriorities(),
 	}
 }
 
@@ -164,7 +181,7 @@ func (m *MConnTransport) Accept(ctx context.Context) (Connection, error) {
 	case err := <-errCh:
 		return nil, err
 	case tcpConn := <-conCh:
-		return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+		return m.newConnection(tcpConn), nil
 	}
 
 }
@@ -190,7 +207,7 @@ func (m *MConnTransport) Dial(ctx context.Context, endpoint *Endpoint) (Connecti
 		}
 	}
 
-	return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+	return m.newConnection(tcpConn), nil
 }
 
 // Close implements Transport.
@@ -216,6 +233,26 @@ func (m *MConnTransport) AddChannelDescriptors(channelDesc []*ChannelDescriptor)
 	m.channelDescs = append(m.channelDescs, channelDesc...)
 }
 
+// SetChannelPriority overrides the send priority of a channel on all current
+// and future connections. A priority of 0 restores the priority of the channel
+// descriptor.
+func (m *MConnTransport) SetChannelPriority(chID ChannelID, priority int) error {
+	if !hasChannel(m.channelDescs, chID) {
+		return fmt.Errorf("unknown channel %X", chID)
+	}
+	return m.priorities.set(chID, priority)
+}
+
+// newConnection creates a new connection using the transport's options.
+func (m *MConnTransport) newConnection(tcpConn net.Conn) *mConnConnection {
+	c := newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs)
+	c.handshakeTimeout = m.options.HandshakeTimeout
+	c.authTimeout = m.options.AuthTimeout
+	c.priorities = m.priorities
+	c.onSent = channelSentBytesFunc(m.options.Metrics)
+	return c
+}
+
 // validateEndpoint validates an endpoint.
 func (m *MConnTransport) validateEndpoint(endpoint *Endpoint) error {
 	if err := endpoint.Validate(); err != nil {
@@ -235,14 +272,19 @@ func (m *MConnTransport) validateEndpoint(endpoint *Endpoint) error {
 
 // mConnConnection implements Connection for MConnTransport.
 type mConnConnection struct {
-	logger       log.Logger
-	conn         net.Conn
-	mConnConfig  conn.MConnConfig
-	channelDescs []*ChannelDescriptor
-	receiveCh    chan mConnMessage
-	errorCh      chan error
-	doneCh       chan struct{}
-	closeOnce    sync.Once
+	logger           log.Logger
+	conn             net.Conn
+	protocol         Protocol
+	mConnConfig      conn.MConnConfig
+	handshakeTimeout time.Duration
+	authTimeout      time.Duration
+	channelDescs     []*ChannelDescriptor
+	priorities       *channelPriorities   // may be nil
+	onSent           func(ChannelID, int) // may be nil
+	receiveCh        chan mConnMessage
+	errorCh          chan error
+	doneCh           chan struct{}
+	closeOnce        sync.Once
 
 	mconn *conn.MConnection // set during Handshake()
 }
@@ -263,6 +305,7 @@ func newMConnConnection(
 	return &mConnConnection{
 		logger:       logger,
 		conn:         conn,
+		protocol:     MConnProtocol,
 		mConnConfig:  mConnConfig,
 		channelDescs: channelDescs,
 		receiveCh:    make(chan mConnMessage),
@@ -283,6 +326,11 @@ func (c *mConnConnection) Handshake(
 		peerKey  crypto.PubKey
 		errCh    = make(chan error, 1)
 	)
+	if c.handshakeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.handshakeTimeout)
+		defer cancel()
+	}
 	// To handle context cancellation, we need to do the handshake in a
 	// goroutine and abort the blocking network calls by closing the connection
 	// when the context is canceled.
@@ -314,6 +362,12 @@ func (c *mConnConnection) Handshake(
 			return types.NodeInfo{}, nil, err
 		}
 		c.mconn = mconn
+		if c.onSent != nil {
+			c.mconn.OnPacketSent(c.onSent)
+		}
+		if c.priorities != nil {
+			c.priorities.add(c.mconn)
+		}
 		if err = c.mconn.Start(ctx); err != nil {
 			return types.NodeInfo{}, nil, err
 		}
@@ -333,10 +387,20 @@ func (c *mConnConnection) handshake(
 		return nil, types.NodeInfo{}, nil, errors.New("connection is already handshaked")
 	}
 
+	if c.authTimeout > 0 {
+		if err := c.conn.SetDeadline(time.Now().Add(c.authTimeout)); err != nil {
+			return nil, types.NodeInfo{}, nil, err
+		}
+	}
 	secretConn, err := conn.MakeSecretConnection(c.conn, privKey)
 	if err != nil {
 		return nil, types.NodeInfo{}, nil, err
 	}
+	if c.authTimeout > 0 {
+		if err := c.conn.SetDeadline(time.Time{}); err != nil {
+			return nil, types.NodeInfo{}, nil, err
+		}
+	}
 
 	wg := &sync.WaitGroup{}
 	var pbPeerInfo p2pproto.NodeInfo
@@ -455,34 +519,49 @@ func (c *mConnConnection) ReceiveMessage(ctx context.Context) (ChannelID, []byte
 
 // LocalEndpoint implements Connection.
 func (c *mConnConnection) LocalEndpoint() Endpoint {
-	endpoint := Endpoint{
-		Protocol: MConnProtocol,
-	}
-	if addr, ok := c.conn.LocalAddr().(*net.TCPAddr); ok {
-		endpoint.IP = addr.IP
-		endpoint.Port = uint16(addr.Port)
-	}
-	return endpoint
+	return c.endpoint(c.conn.LocalAddr())
 }
 
 // RemoteEndpoint implements Connection.
 func (c *mConnConnection) RemoteEndpoint() Endpoint {
+	return c.endpoint(c.conn.RemoteAddr())
+}
+
+// endpoint converts a network address of the underlying connection into an
+// Endpoint for the connection's protocol.
+func (c *mConnConnection) endpoint(addr net.Addr) Endpoint {
 	endpoint := Endpoint{
-		Protocol: MConnProtocol,
+		Protocol: c.protocol,
 	}
-	if addr, ok := c.conn.RemoteAddr().(*net.TCPAddr); ok {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		endpoint.IP = addr.IP
+		endpoint.Port = uint16(addr.Port)
+	case *net.UDPAddr:
 		endpoint.IP = addr.IP
 		endpoint.Port = uint16(addr.Port)
 	}
 	return endpoint
 }
 
+// FlushClose closes the connection, after sending the messages buffered by
+// the MConnection for no longer than the given deadline.
+func (c *mConnConnection) FlushClose(deadline time.Time) error {
+	if c.mconn != nil && c.mconn.IsRunning() {
+		c.mconn.FlushStop(deadline)
+	}
+	return c.Close()
+}
+
 // Close implements Connection.
 func (c *mConnConnection) Close() error {
 	var err error
 	c.closeOnce.Do(func() {
 		defer close(c.doneCh)
 
+		if c.mconn != nil && c.priorities != nil {
+			c.priorities.remove(c.mconn)
+		}
 		if c.mconn != nil && c.mconn.IsRunning() {
 			c.mconn.Stop()
 		} else {
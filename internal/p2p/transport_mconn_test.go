@@ -10,9 +10,11 @@ import (
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Transports are mainly tested by common tests in transport_test.go, we
@@ -128,6 +130,69 @@ func TestMConnTransport_AcceptMaxAcceptedConnections(t *testing.T) {
 	require.Equal(t, dial3.LocalEndpoint(), accept3.RemoteEndpoint())
 }
 
+func TestMConnTransport_HandshakeTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const handshakeDelay = 500 * time.Millisecond
+
+	testcases := []struct {
+		name    string
+		options p2p.MConnTransportOptions
+		ok      bool
+	}{
+		{"no timeouts", p2p.MConnTransportOptions{}, true},
+		{"handshake timeout exceeded", p2p.MConnTransportOptions{HandshakeTimeout: 100 * time.Millisecond}, false},
+		{"auth timeout exceeded", p2p.MConnTransportOptions{AuthTimeout: 100 * time.Millisecond}, false},
+		{"timeouts raised", p2p.MConnTransportOptions{HandshakeTimeout: 5 * time.Second, AuthTimeout: 5 * time.Second}, true},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			a := p2p.NewMConnTransport(
+				log.NewNopLogger(),
+				conn.DefaultMConnConfig(),
+				[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+				tc.options,
+			)
+			err := a.Listen(&p2p.Endpoint{
+				Protocol: p2p.MConnProtocol,
+				IP:       net.IPv4(127, 0, 0, 1),
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = a.Close() })
+			b := testTransports["mconn"](t)
+
+			ba, ab := dialAccept(ctx, t, b, a)
+
+			// The accepting side handshakes with a slow peer, which only
+			// starts its handshake after a delay.
+			errCh := make(chan error, 1)
+			go func() {
+				aKey := ed25519.GenPrivKey()
+				aInfo := types.NodeInfo{NodeID: types.NodeIDFromPubKey(aKey.PubKey())}
+				_, _, err := ab.Handshake(ctx, aInfo, aKey)
+				errCh <- err
+			}()
+
+			time.Sleep(handshakeDelay)
+			bKey := ed25519.GenPrivKey()
+			bInfo := types.NodeInfo{NodeID: types.NodeIDFromPubKey(bKey.PubKey())}
+			bCtx, bCancel := context.WithTimeout(ctx, 5*time.Second)
+			defer bCancel()
+			_, _, bErr := ba.Handshake(bCtx, bInfo, bKey)
+
+			err = <-errCh
+			if tc.ok {
+				require.NoError(t, err)
+				require.NoError(t, bErr)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestMConnTransport_Listen(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -228,3 +293,20 @@ func TestMConnTransport_Listen(t *testing.T) {
 		})
 	}
 }
+
+func TestMConnTransport_SetChannelPriority(t *testing.T) {
+	transport := p2p.NewMConnTransport(
+		log.NewNopLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+		p2p.MConnTransportOptions{},
+	)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	require.NoError(t, transport.SetChannelPriority(chID, 10))
+	require.NoError(t, transport.SetChannelPriority(chID, 0))
+	require.Error(t, transport.SetChannelPriority(chID, -1))
+	require.Error(t, transport.SetChannelPriority(chID+1, 10))
+}
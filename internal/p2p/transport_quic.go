@@ -0,0 +1,861 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/p2p/conn"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	QUICProtocol Protocol = "quic"
+
+	// quicALPN is the TLS application protocol negotiated for QUIC connections.
+	quicALPN = "tendermint-p2p"
+
+	// quicKeepAlivePeriod is the interval at which QUIC keep-alives are sent.
+	// MConn pings are too infrequent to keep QUIC connections from timing out
+	// when idle.
+	quicKeepAlivePeriod = 15 * time.Second
+)
+
+// QUICTransportOptions sets options for QUICTransport.
+type QUICTransportOptions struct {
+	// MaxAcceptedConnections is the maximum number of simultaneous accepted
+	// (incoming) connections. Beyond this, new connections are closed
+	// immediately. 0 means unlimited.
+	MaxAcceptedConnections uint32
+
+	// HandshakeTimeout is the timeout for handshaking with a peer, i.e.
+	// authentication and the NodeInfo exchange. 0 means no timeout.
+	HandshakeTimeout time.Duration
+
+	// AuthTimeout is the timeout for the SecretConnection key exchange and
+	// authentication. 0 means no timeout.
+	AuthTimeout time.Duration
+
+	// Metrics records the bytes sent on each channel. nil means no metrics.
+	Metrics *Metrics
+}
+
+// QUICTransport is a Transport implementation that runs the MConn protocol
+// over QUIC, with a separate bidirectional stream for each channel.
+//
+// Since channels don't share a stream, packet loss only delays the channel it
+// occurred on, avoiding head-of-line blocking between channels. For the same
+// reason, the transport does not support channel priorities: bandwidth is
+// shared between channels by QUIC flow control instead.
+//
+// QUIC mandates TLS, but the TLS certificates used here are ephemeral and
+// self-signed, and are not verified. Peers are authenticated exactly as with
+// MConnTransport: by the SecretConnection and NodeInfo handshake performed
+// over a control stream, so peer identity is still derived from the node key.
+// Every channel stream is authenticated by a SecretConnection as well.
+type QUICTransport struct {
+	logger       log.Logger
+	options      QUICTransportOptions
+	mConnConfig  conn.MConnConfig
+	channelDescs []*ChannelDescriptor
+	tlsConfig    *tls.Config
+
+	closeOnce sync.Once
+	doneCh    chan struct{}
+	transport *quic.Transport
+	listener  *quic.Listener
+	acceptSem chan struct{}
+
+	// The listening socket is shared by all accepted and dialed connections,
+	// so it is only closed once the transport is closed and all connections
+	// using it have been closed as well.
+	mtx          sync.Mutex
+	numConns     int
+	closed       bool
+	socketClosed bool
+}
+
+// NewQUICTransport sets up a new QUIC transport.
+func NewQUICTransport(
+	logger log.Logger,
+	mConnConfig conn.MConnConfig,
+	channelDescs []*ChannelDescriptor,
+	options QUICTransportOptions,
+) (*QUICTransport, error) {
+	tlsConfig, err := newQUICTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QUIC TLS config: %w", err)
+	}
+	var acceptSem chan struct{}
+	if options.MaxAcceptedConnections > 0 {
+		acceptSem = make(chan struct{}, options.MaxAcceptedConnections)
+	}
+	if options.Metrics == nil {
+		options.Metrics = NopMetrics()
+	}
+	return &QUICTransport{
+		logger:       logger,
+		options:      options,
+		mConnConfig:  mConnConfig,
+		channelDescs: channelDescs,
+		tlsConfig:    tlsConfig,
+		doneCh:       make(chan struct{}),
+		acceptSem:    acceptSem,
+	}, nil
+}
+
+// String implements Transport.
+func (q *QUICTransport) String() string {
+	return string(QUICProtocol)
+}
+
+// Protocols implements Transport.
+func (q *QUICTransport) Protocols() []Protocol {
+	return []Protocol{QUICProtocol}
+}
+
+// Endpoint implements Transport.
+func (q *QUICTransport) Endpoint() (*Endpoint, error) {
+	if q.listener == nil {
+		return nil, errors.New("listener not defined")
+	}
+	select {
+	case <-q.doneCh:
+		return nil, errors.New("transport closed")
+	default:
+	}
+
+	endpoint := &Endpoint{
+		Protocol: QUICProtocol,
+	}
+	if addr, ok := q.listener.Addr().(*net.UDPAddr); ok {
+		endpoint.IP = addr.IP
+		endpoint.Port = uint16(addr.Port)
+	}
+	return endpoint, nil
+}
+
+// Listen asynchronously listens for inbound connections on the given endpoint.
+// It must be called exactly once before calling Accept(), and the caller must
+// call Close() to shut down the listener. Outbound connections are dialed
+// from the same UDP socket where possible.
+func (q *QUICTransport) Listen(endpoint *Endpoint) error {
+	if q.listener != nil {
+		return errors.New("transport is already listening")
+	}
+	if err := q.validateEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: endpoint.IP, Port: int(endpoint.Port)})
+	if err != nil {
+		return err
+	}
+	transport := &quic.Transport{Conn: udpConn}
+	listener, err := transport.Listen(q.tlsConfig, q.quicConfig())
+	if err != nil {
+		_ = transport.Close()
+		_ = udpConn.Close()
+		return err
+	}
+	q.transport = transport
+	q.listener = listener
+
+	return nil
+}
+
+// Accept implements Transport.
+func (q *QUICTransport) Accept(ctx context.Context) (Connection, error) {
+	if q.listener == nil {
+		return nil, errors.New("transport is not listening")
+	}
+
+	for {
+		qconn, err := q.listener.Accept(ctx)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil, io.EOF
+			case <-q.doneCh:
+				return nil, io.EOF
+			default:
+				return nil, err
+			}
+		}
+
+		if q.acceptSem != nil {
+			select {
+			case q.acceptSem <- struct{}{}:
+				go func() {
+					<-qconn.Context().Done()
+					<-q.acceptSem
+				}()
+			default:
+				q.logger.Debug("rejecting QUIC connection, too many accepted connections",
+					"remote", qconn.RemoteAddr())
+				_ = qconn.CloseWithError(0, "too many connections")
+				continue
+			}
+		}
+
+		q.trackConn(qconn)
+		return q.newConnection(qconn, false), nil
+	}
+}
+
+// Dial implements Transport.
+func (q *QUICTransport) Dial(ctx context.Context, endpoint *Endpoint) (Connection, error) {
+	if err := q.validateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	if endpoint.Port == 0 {
+		endpoint.Port = 26657
+	}
+
+	addr := &net.UDPAddr{IP: endpoint.IP, Port: int(endpoint.Port)}
+	var (
+		qconn quic.Connection
+		err   error
+	)
+	if q.canDialFromListener(addr) {
+		if addr.IP.IsUnspecified() {
+			// The unspecified address refers to the local host, in whichever
+			// address family we are listening on.
+			addr.IP = net.IPv6zero
+			if q.transport.Conn.LocalAddr().(*net.UDPAddr).IP.To4() != nil {
+				addr.IP = net.IPv4zero
+			}
+		}
+		qconn, err = q.transport.Dial(ctx, addr, q.tlsConfig, q.quicConfig())
+		if err == nil {
+			q.trackConn(qconn)
+		}
+	} else {
+		qconn, err = quic.DialAddr(ctx, addr.String(), q.tlsConfig, q.quicConfig())
+	}
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			return nil, err
+		}
+	}
+
+	return q.newConnection(qconn, true), nil
+}
+
+// Close implements Transport.
+func (q *QUICTransport) Close() error {
+	var err error
+	q.closeOnce.Do(func() {
+		close(q.doneCh)
+		if q.listener != nil {
+			err = q.listener.Close()
+		}
+
+		q.mtx.Lock()
+		defer q.mtx.Unlock()
+		q.closed = true
+		q.maybeCloseSocket()
+	})
+	return err
+}
+
+// trackConn registers a connection using the listening socket, and releases
+// it once the connection is closed.
+func (q *QUICTransport) trackConn(qconn quic.Connection) {
+	q.mtx.Lock()
+	q.numConns++
+	q.mtx.Unlock()
+
+	go func() {
+		<-qconn.Context().Done()
+		q.mtx.Lock()
+		defer q.mtx.Unlock()
+		q.numConns--
+		q.maybeCloseSocket()
+	}()
+}
+
+// maybeCloseSocket closes the listening socket if the transport is closed and
+// no connections are using it. The caller must hold q.mtx.
+func (q *QUICTransport) maybeCloseSocket() {
+	if !q.closed || q.numConns > 0 || q.transport == nil || q.socketClosed {
+		return
+	}
+	q.socketClosed = true
+	if err := q.transport.Close(); err != nil {
+		q.logger.Debug("failed to close QUIC socket", "err", err)
+	}
+}
+
+// AddChannelDescriptors implements Transport.
+func (q *QUICTransport) AddChannelDescriptors(channelDesc []*ChannelDescriptor) {
+	q.channelDescs = append(q.channelDescs, channelDesc...)
+}
+
+// canDialFromListener returns true if addr can be dialed from the listening
+// socket, i.e. the transport is listening on an address of the same family.
+// The unspecified address can always be dialed.
+func (q *QUICTransport) canDialFromListener(addr *net.UDPAddr) bool {
+	if q.transport == nil {
+		return false
+	}
+	select {
+	case <-q.doneCh:
+		return false
+	default:
+	}
+	local, ok := q.transport.Conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	return addr.IP.IsUnspecified() || (local.IP.To4() != nil) == (addr.IP.To4() != nil)
+}
+
+// quicConfig returns the QUIC configuration for connections.
+func (q *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		MaxIncomingStreams:    quicMaxStreams,
+		MaxIncomingUniStreams: -1,
+		KeepAlivePeriod:       quicKeepAlivePeriod,
+	}
+}
+
+// validateEndpoint validates an endpoint.
+func (q *QUICTransport) validateEndpoint(endpoint *Endpoint) error {
+	if err := endpoint.Validate(); err != nil {
+		return err
+	}
+	if endpoint.Protocol != QUICProtocol {
+		return fmt.Errorf("unsupported protocol %q", endpoint.Protocol)
+	}
+	if len(endpoint.IP) == 0 {
+		return errors.New("endpoint has no IP address")
+	}
+	if endpoint.Path != "" {
+		return fmt.Errorf("endpoints with path not supported (got %q)", endpoint.Path)
+	}
+	return nil
+}
+
+// newQUICTLSConfig generates a TLS config with an ephemeral self-signed
+// certificate. Certificates are not verified, since peers are authenticated
+// by the SecretConnection handshake.
+func newQUICTLSConfig() (*tls.Config, error) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, pubKey, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certDER},
+			PrivateKey:  privKey,
+		}},
+		NextProtos:         []string{quicALPN},
+		InsecureSkipVerify: true, //nolint:gosec // peers are authenticated by SecretConnection
+		MinVersion:         tls.VersionTLS13,
+	}, nil
+}
+
+// Each QUIC stream starts with a header identifying what it carries: either
+// the control stream, which carries the connection handshake, or the stream
+// of a channel, followed by the channel ID.
+const (
+	quicStreamControl byte = iota
+	quicStreamChannel
+)
+
+// quicMaxStreams is the maximum number of streams a peer may open on a
+// connection: the control stream and one stream per 1-byte channel ID.
+const quicMaxStreams = 1 + math.MaxUint8 + 1
+
+// quicConnection implements Connection for QUICTransport. Each channel is
+// carried by its own bidirectional stream, running an MConnection with only
+// that channel, so that packet loss on one channel does not delay the others.
+//
+// The dialing side opens a control stream, over which the SecretConnection and
+// NodeInfo handshake is performed, and then one stream for each of its
+// channels. Every channel stream is authenticated by its own SecretConnection
+// handshake, and must be authenticated by the same key as the control stream.
+type quicConnection struct {
+	logger    log.Logger
+	transport *QUICTransport
+	conn      quic.Connection
+	dialed    bool
+
+	receiveCh chan mConnMessage
+	errorCh   chan error
+	doneCh    chan struct{}
+	closeOnce sync.Once
+
+	mtx       sync.Mutex
+	control   *mConnConnection
+	channels  map[ChannelID]*mConnConnection
+	channelCh chan struct{} // closed and replaced when a channel is added
+}
+
+// newConnection creates a new connection for a QUIC connection, which was
+// either dialed or accepted by the transport.
+func (q *QUICTransport) newConnection(qconn quic.Connection, dialed bool) *quicConnection {
+	return &quicConnection{
+		logger:    q.logger,
+		transport: q,
+		conn:      qconn,
+		dialed:    dialed,
+		receiveCh: make(chan mConnMessage),
+		errorCh:   make(chan error, 1),
+		doneCh:    make(chan struct{}),
+		channels:  map[ChannelID]*mConnConnection{},
+		channelCh: make(chan struct{}),
+	}
+}
+
+// Handshake implements Connection.
+func (c *quicConnection) Handshake(
+	ctx context.Context,
+	nodeInfo types.NodeInfo,
+	privKey crypto.PrivKey,
+) (types.NodeInfo, crypto.PubKey, error) {
+	if timeout := c.transport.options.HandshakeTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	peerInfo, peerKey, err := c.handshake(ctx, nodeInfo, privKey)
+	if err != nil {
+		_ = c.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return types.NodeInfo{}, nil, ctxErr
+		}
+		return types.NodeInfo{}, nil, err
+	}
+	return peerInfo, peerKey, nil
+}
+
+// handshake is a helper for Handshake. It handshakes over the control stream,
+// and then either opens the streams of all channels, or starts accepting them.
+func (c *quicConnection) handshake(
+	ctx context.Context,
+	nodeInfo types.NodeInfo,
+	privKey crypto.PrivKey,
+) (types.NodeInfo, crypto.PubKey, error) {
+	c.mtx.Lock()
+	handshaked := c.control != nil
+	c.mtx.Unlock()
+	if handshaked {
+		return types.NodeInfo{}, nil, errors.New("connection is already handshaked")
+	}
+
+	var (
+		stream quic.Stream
+		err    error
+	)
+	if c.dialed {
+		stream, err = c.openStream(ctx, quicStreamControl, 0)
+	} else {
+		stream, err = c.conn.AcceptStream(ctx)
+		if err == nil {
+			var kind byte
+			if kind, _, err = readQUICStreamHeader(ctx, stream); err == nil && kind != quicStreamControl {
+				err = fmt.Errorf("expected control stream, got stream of type %d", kind)
+			}
+		}
+	}
+	if err != nil {
+		return types.NodeInfo{}, nil, quicError(err)
+	}
+
+	control := c.newStreamConnection(stream, nil)
+	peerInfo, peerKey, err := control.Handshake(ctx, nodeInfo, privKey)
+	if err != nil {
+		_ = control.Close()
+		return types.NodeInfo{}, nil, err
+	}
+	c.mtx.Lock()
+	c.control = control
+	c.mtx.Unlock()
+	c.watch(control)
+
+	if !c.dialed {
+		go c.acceptChannels(nodeInfo, privKey, peerKey)
+		return peerInfo, peerKey, nil
+	}
+
+	descs := c.transport.channelDescs
+	errCh := make(chan error, len(descs))
+	for _, desc := range descs {
+		go func(desc *ChannelDescriptor) {
+			stream, err := c.openStream(ctx, quicStreamChannel, desc.ID)
+			if err == nil {
+				err = c.addChannel(ctx, stream, desc, nodeInfo, privKey, peerKey)
+			}
+			errCh <- err
+		}(desc)
+	}
+	for range descs {
+		if err := <-errCh; err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+	}
+	return peerInfo, peerKey, nil
+}
+
+// acceptChannels accepts the channel streams opened by the dialing side, until
+// the connection is closed.
+func (c *quicConnection) acceptChannels(
+	nodeInfo types.NodeInfo,
+	privKey crypto.PrivKey,
+	peerKey crypto.PubKey,
+) {
+	ctx := c.conn.Context()
+	for {
+		stream, err := c.conn.AcceptStream(ctx)
+		if err != nil {
+			return
+		}
+		go func() {
+			// The stream handshake can't be bound by a context, since the
+			// MConnection is stopped when its start context is canceled.
+			if timeout := c.transport.options.HandshakeTimeout; timeout > 0 {
+				timer := time.AfterFunc(timeout, func() { resetQUICStream(stream) })
+				defer timer.Stop()
+			}
+			kind, chID, err := readQUICStreamHeader(ctx, stream)
+			if err == nil && kind != quicStreamChannel {
+				err = fmt.Errorf("expected channel stream, got stream of type %d", kind)
+			}
+			if err == nil {
+				err = c.addChannel(ctx, stream, c.channelDesc(chID), nodeInfo, privKey, peerKey)
+			}
+			if err != nil {
+				c.logger.Debug("rejected QUIC stream", "peer", c.RemoteEndpoint(), "err", err)
+				resetQUICStream(stream)
+			}
+		}()
+	}
+}
+
+// addChannel handshakes over the stream of a channel, and starts using it to
+// send and receive the channel's messages.
+func (c *quicConnection) addChannel(
+	ctx context.Context,
+	stream quic.Stream,
+	desc *ChannelDescriptor,
+	nodeInfo types.NodeInfo,
+	privKey crypto.PrivKey,
+	peerKey crypto.PubKey,
+) error {
+	c.mtx.Lock()
+	_, exists := c.channels[desc.ID]
+	c.mtx.Unlock()
+	if exists {
+		return fmt.Errorf("duplicate stream for channel %X", desc.ID)
+	}
+
+	sub := c.newStreamConnection(stream, []*ChannelDescriptor{desc})
+	_, key, err := sub.Handshake(ctx, nodeInfo, privKey)
+	if err != nil {
+		_ = sub.Close()
+		return fmt.Errorf("channel %X handshake: %w", desc.ID, err)
+	}
+	if !key.Equals(peerKey) {
+		_ = sub.Close()
+		return fmt.Errorf("channel %X stream was authenticated by a different key", desc.ID)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	select {
+	case <-c.doneCh:
+		_ = sub.Close()
+		return io.EOF
+	default:
+	}
+	if _, ok := c.channels[desc.ID]; ok {
+		_ = sub.Close()
+		return fmt.Errorf("duplicate stream for channel %X", desc.ID)
+	}
+	c.channels[desc.ID] = sub
+	close(c.channelCh)
+	c.channelCh = make(chan struct{})
+	c.watch(sub)
+	return nil
+}
+
+// channelDesc returns the descriptor of a channel. A peer may open streams for
+// channels we don't have, which we accept with a default descriptor so that
+// the peer doesn't fail its handshake; the peer won't send on them, since we
+// don't advertise them.
+func (c *quicConnection) channelDesc(chID ChannelID) *ChannelDescriptor {
+	for _, desc := range c.transport.channelDescs {
+		if desc.ID == chID {
+			return desc
+		}
+	}
+	return &ChannelDescriptor{ID: chID, Priority: 1}
+}
+
+// newStreamConnection creates an MConnection connection over a stream, which
+// passes its received messages to the connection.
+func (c *quicConnection) newStreamConnection(
+	stream quic.Stream,
+	channelDescs []*ChannelDescriptor,
+) *mConnConnection {
+	q := c.transport
+	sub := newMConnConnection(c.logger, &quicStream{Stream: stream, conn: c.conn}, q.mConnConfig, channelDescs)
+	sub.protocol = QUICProtocol
+	sub.authTimeout = q.options.AuthTimeout
+	sub.onSent = channelSentBytesFunc(q.options.Metrics)
+	sub.receiveCh = c.receiveCh
+	return sub
+}
+
+// watch closes the connection when the MConnection of one of its streams
+// fails, passing on its error.
+func (c *quicConnection) watch(sub *mConnConnection) {
+	go func() {
+		select {
+		case err := <-sub.errorCh:
+			select {
+			case c.errorCh <- err:
+			default:
+			}
+			_ = c.Close()
+		case <-c.doneCh:
+		}
+	}()
+}
+
+// openStream opens a new stream and writes its header.
+func (c *quicConnection) openStream(ctx context.Context, kind byte, chID ChannelID) (quic.Stream, error) {
+	stream, err := c.conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The stream isn't visible to the peer until data is sent on it, so the
+	// header also announces the stream.
+	if _, err := stream.Write([]byte{kind, byte(chID)}); err != nil {
+		resetQUICStream(stream)
+		return nil, err
+	}
+	return stream, nil
+}
+
+// readQUICStreamHeader reads the header of an accepted stream.
+func readQUICStreamHeader(ctx context.Context, stream quic.Stream) (byte, ChannelID, error) {
+	stop := context.AfterFunc(ctx, func() { stream.CancelRead(0) })
+	defer stop()
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(stream, header); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return 0, 0, ctxErr
+		}
+		return 0, 0, err
+	}
+	return header[0], ChannelID(header[1]), nil
+}
+
+// resetQUICStream aborts both directions of a stream.
+func resetQUICStream(stream quic.Stream) {
+	stream.CancelRead(0)
+	stream.CancelWrite(0)
+}
+
+// streamConnections returns the MConnection connections of all streams. The
+// caller must hold c.mtx.
+func (c *quicConnection) streamConnections() []*mConnConnection {
+	subs := make([]*mConnConnection, 0, len(c.channels)+1)
+	if c.control != nil {
+		subs = append(subs, c.control)
+	}
+	for _, sub := range c.channels {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// String displays connection information.
+func (c *quicConnection) String() string {
+	return c.RemoteEndpoint().String()
+}
+
+// SendMessage implements Connection. On the accepting side, it waits for the
+// peer to open the stream of the channel if it hasn't been yet.
+func (c *quicConnection) SendMessage(ctx context.Context, chID ChannelID, msg []byte) error {
+	if chID > math.MaxUint8 {
+		return fmt.Errorf("QUIC connections only support 1-byte channel IDs (got %v)", chID)
+	}
+	for {
+		select {
+		case err := <-c.errorCh:
+			return err
+		case <-c.doneCh:
+			return io.EOF
+		case <-ctx.Done():
+			return io.EOF
+		default:
+		}
+
+		c.mtx.Lock()
+		sub, ok := c.channels[chID]
+		channelCh := c.channelCh
+		c.mtx.Unlock()
+		if ok {
+			return sub.SendMessage(ctx, chID, msg)
+		}
+		if c.dialed {
+			return fmt.Errorf("unknown channel %X", chID)
+		}
+
+		select {
+		case <-channelCh:
+		case <-c.doneCh:
+			return io.EOF
+		case <-ctx.Done():
+			return io.EOF
+		}
+	}
+}
+
+// ReceiveMessage implements Connection.
+func (c *quicConnection) ReceiveMessage(ctx context.Context) (ChannelID, []byte, error) {
+	select {
+	case err := <-c.errorCh:
+		return 0, nil, err
+	case <-c.doneCh:
+		return 0, nil, io.EOF
+	case <-ctx.Done():
+		return 0, nil, io.EOF
+	case msg := <-c.receiveCh:
+		return msg.channelID, msg.payload, nil
+	}
+}
+
+// LocalEndpoint implements Connection.
+func (c *quicConnection) LocalEndpoint() Endpoint {
+	return quicEndpoint(c.conn.LocalAddr())
+}
+
+// RemoteEndpoint implements Connection.
+func (c *quicConnection) RemoteEndpoint() Endpoint {
+	return quicEndpoint(c.conn.RemoteAddr())
+}
+
+// quicEndpoint converts the address of a QUIC connection into an Endpoint.
+func quicEndpoint(addr net.Addr) Endpoint {
+	endpoint := Endpoint{
+		Protocol: QUICProtocol,
+	}
+	if addr, ok := addr.(*net.UDPAddr); ok {
+		endpoint.IP = addr.IP
+		endpoint.Port = uint16(addr.Port)
+	}
+	return endpoint
+}
+
+// FlushClose closes the connection, after sending the messages buffered by
+// the MConnections of its streams for no longer than the given deadline.
+func (c *quicConnection) FlushClose(deadline time.Time) error {
+	c.mtx.Lock()
+	subs := c.streamConnections()
+	c.mtx.Unlock()
+	for _, sub := range subs {
+		_ = sub.FlushClose(deadline)
+	}
+	return c.Close()
+}
+
+// Close implements Connection. It closes the entire QUIC connection.
+func (c *quicConnection) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.doneCh)
+
+		c.mtx.Lock()
+		subs := c.streamConnections()
+		c.mtx.Unlock()
+		for _, sub := range subs {
+			_ = sub.Close()
+		}
+		err = c.conn.CloseWithError(0, "")
+	})
+	return err
+}
+
+// quicStream adapts a QUIC stream to net.Conn.
+type quicStream struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = (*quicStream)(nil)
+
+// Read implements net.Conn.
+func (s *quicStream) Read(b []byte) (int, error) {
+	n, err := s.Stream.Read(b)
+	return n, quicError(err)
+}
+
+// Write implements net.Conn.
+func (s *quicStream) Write(b []byte) (int, error) {
+	n, err := s.Stream.Write(b)
+	return n, quicError(err)
+}
+
+// Close implements net.Conn. It closes both directions of the stream.
+func (s *quicStream) Close() error {
+	s.Stream.CancelRead(0)
+	return s.Stream.Close()
+}
+
+// LocalAddr implements net.Conn.
+func (s *quicStream) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn.
+func (s *quicStream) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+// quicError converts a graceful close of the connection or a stream by either
+// side into io.EOF, matching the behavior of TCP connections.
+func quicError(err error) error {
+	var (
+		appErr    *quic.ApplicationError
+		streamErr *quic.StreamError
+	)
+	if errors.As(err, &appErr) && appErr.ErrorCode == 0 {
+		return io.EOF
+	}
+	if errors.As(err, &streamErr) && streamErr.ErrorCode == 0 {
+		return io.EOF
+	}
+	return err
+}
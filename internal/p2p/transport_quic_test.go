@@ -0,0 +1,135 @@
+package p2p_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/conn"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Transports are mainly tested by common tests in transport_test.go, we
+// register a transport factory here to get included in those tests.
+func init() {
+	testTransports["quic"] = func(t *testing.T) p2p.Transport {
+		transport, err := p2p.NewQUICTransport(
+			log.NewNopLogger(),
+			conn.DefaultMConnConfig(),
+			[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+			p2p.QUICTransportOptions{},
+		)
+		require.NoError(t, err)
+		err = transport.Listen(&p2p.Endpoint{
+			Protocol: p2p.QUICProtocol,
+			IP:       net.IPv4(127, 0, 0, 1),
+			Port:     0, // assign a random port
+		})
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = transport.Close() })
+
+		return transport
+	}
+}
+
+func TestQUICTransport_AcceptBeforeListen(t *testing.T) {
+	transport, err := p2p.NewQUICTransport(
+		log.NewNopLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+		p2p.QUICTransportOptions{},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = transport.Accept(ctx)
+	require.Error(t, err)
+	require.NotEqual(t, io.EOF, err) // io.EOF should be returned after Close()
+}
+
+func TestQUICTransport_Listen(t *testing.T) {
+	transport, err := p2p.NewQUICTransport(
+		log.NewNopLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+		p2p.QUICTransportOptions{},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	// Listening on an MConn endpoint should fail.
+	err = transport.Listen(&p2p.Endpoint{
+		Protocol: p2p.MConnProtocol,
+		IP:       net.IPv4(127, 0, 0, 1),
+	})
+	require.Error(t, err)
+
+	err = transport.Listen(&p2p.Endpoint{
+		Protocol: p2p.QUICProtocol,
+		IP:       net.IPv4(127, 0, 0, 1),
+	})
+	require.NoError(t, err)
+
+	endpoint, err := transport.Endpoint()
+	require.NoError(t, err)
+	require.Equal(t, p2p.QUICProtocol, endpoint.Protocol)
+	require.True(t, endpoint.IP.Equal(net.IPv4(127, 0, 0, 1)))
+	require.NotZero(t, endpoint.Port)
+
+	// Listening twice should fail.
+	err = transport.Listen(endpoint)
+	require.Error(t, err)
+}
+
+func TestQUICTransport_ChannelStreams(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	otherChID := p2p.ChannelID(2)
+	makeTransport := func() p2p.Transport {
+		transport, err := p2p.NewQUICTransport(
+			log.NewNopLogger(),
+			conn.DefaultMConnConfig(),
+			[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}, {ID: otherChID, Priority: 1}},
+			p2p.QUICTransportOptions{},
+		)
+		require.NoError(t, err)
+		require.NoError(t, transport.Listen(&p2p.Endpoint{
+			Protocol: p2p.QUICProtocol,
+			IP:       net.IPv4(127, 0, 0, 1),
+		}))
+		t.Cleanup(func() { _ = transport.Close() })
+		return transport
+	}
+	ab, ba := dialAcceptHandshake(ctx, t, makeTransport(), makeTransport())
+
+	// Messages are delivered on every channel, in both directions, each
+	// channel being carried by its own stream.
+	for _, id := range []p2p.ChannelID{chID, otherChID} {
+		require.NoError(t, ab.SendMessage(ctx, id, []byte{byte(id)}))
+		ch, msg, err := ba.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, id, ch)
+		require.Equal(t, []byte{byte(id)}, msg)
+
+		require.NoError(t, ba.SendMessage(ctx, id, []byte{byte(id)}))
+		ch, msg, err = ab.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, id, ch)
+		require.Equal(t, []byte{byte(id)}, msg)
+	}
+
+	// Sending on a channel the connection doesn't have fails.
+	require.Error(t, ab.SendMessage(ctx, p2p.ChannelID(3), []byte("unknown")))
+}
@@ -174,6 +174,11 @@ func (app *proxyClient) CheckTx(ctx context.Context, req *types.RequestCheckTx)
 	return app.client.CheckTx(ctx, req)
 }
 
+func (app *proxyClient) CheckTxBatch(ctx context.Context, reqs []*types.RequestCheckTx) ([]*types.ResponseCheckTx, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "check_tx_batch", "type", "sync"))()
+	return app.client.CheckTxBatch(ctx, reqs)
+}
+
 func (app *proxyClient) Echo(ctx context.Context, msg string) (*types.ResponseEcho, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "echo", "type", "sync"))()
 	return app.client.Echo(ctx, msg)
@@ -196,7 +196,7 @@ func compileCondition(cond syntax.Condition) (condition, error) {
 // TODO(creachadair): The existing implementation allows anything number shaped
 // to be treated as a number. This preserves the parts of that behavior we had
 // tests for, but we should probably get rid of that.
-var extractNum = regexp.MustCompile(`^\d+(\.\d+)?`)
+var extractNum = regexp.MustCompile(`^-?\d+(\.\d+)?`)
 
 func parseNumber(s string) (float64, error) {
 	return strconv.ParseFloat(extractNum.FindString(s), 64)
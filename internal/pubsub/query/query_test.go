@@ -107,6 +107,12 @@ func TestCompiledMatches(t *testing.T) {
 		{`peaches.kg < 4`,
 			newTestEvents(`peaches|kg=5`),
 			false},
+		{`account.balance > -10 AND account.balance < 0`,
+			newTestEvents(`account|balance=-2.5`),
+			true},
+		{`account.balance < -10`,
+			newTestEvents(`account|balance=-2.5`),
+			false},
 		{`tx.date > DATE 2017-01-01`,
 			newTestEvents(`tx|date=` + time.Now().Format(syntax.DateFormat)),
 			true},
@@ -22,8 +22,8 @@
 //   // A datestamp (YYYY-MM-DD)
 //   date   = #'DATE \d{4}-\d{2}-\d{2}'
 //
-//   // A number with optional fractional parts (0, 10, 3.25)
-//   number = #'\d+(\.\d+)?'
+//   // A number with an optional sign and fractional parts (0, 10, 3.25, -2)
+//   number = #'-?\d+(\.\d+)?'
 //
 //   // An RFC3339 timestamp (2021-11-23T22:04:19-09:00)
 //   time   = #'TIME \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}([-+]\d{2}:\d{2}|Z)'
@@ -75,7 +75,7 @@ func (a *Arg) Number() float64 {
 		return -1
 	}
 	v, err := strconv.ParseFloat(a.text, 64)
-	if err == nil && v >= 0 {
+	if err == nil {
 		return v
 	}
 	return math.NaN()
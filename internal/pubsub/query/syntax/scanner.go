@@ -105,6 +105,8 @@ func (s *Scanner) Next() error {
 			return s.scanTagLike(ch)
 		}
 		switch ch {
+		case '-':
+			return s.scanNumber(ch)
 		case '\'':
 			return s.scanString(ch)
 		case '<', '>', '=':
@@ -127,10 +129,17 @@ func (s *Scanner) Pos() int { return s.pos }
 // Err returns the last error reported by Next, if any.
 func (s *Scanner) Err() error { return s.err }
 
-// scanNumber scans for numbers with optional fractional parts.
-// Examples: 0, 1, 3.14
+// scanNumber scans for numbers with an optional sign and fractional parts.
+// Examples: 0, 1, 3.14, -2.5
 func (s *Scanner) scanNumber(first rune) error {
 	s.buf.WriteRune(first)
+	if first == '-' {
+		ch, err := s.rune()
+		if err != nil || !isDigit(ch) {
+			return s.invalid(first)
+		}
+		s.buf.WriteRune(ch)
+	}
 	if err := s.scanWhile(isDigit); err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ func TestScanner(t *testing.T) {
 		// Numbers
 		{`0 123`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
 		{`0.32 3.14`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
+		{`-23 -0.5`, []syntax.Token{syntax.TNumber, syntax.TNumber}},
 
 		// Tags
 		{`foo foo.bar`, []syntax.Token{syntax.TTag, syntax.TTag}},
@@ -71,7 +72,9 @@ func TestScannerErrors(t *testing.T) {
 		input string
 	}{
 		{`'incomplete string`},
-		{`-23`},
+		{`-`},
+		{`-x`},
+		{`- 23`},
 		{`&`},
 		{`DATE xyz-pdq`},
 		{`DATE xyzp-dq-zv`},
@@ -158,7 +161,9 @@ func TestParseValid(t *testing.T) {
 
 		{"account.balance=100", true},
 		{"account.balance >= 200", true},
-		{"account.balance >= -300", false},
+		{"account.balance >= -300", true},
+		{"account.balance >= -3.5", true},
+		{"account.balance >= --300", false},
 		{"account.balance >>= 400", false},
 		{"account.balance=33.22.1", false},
 
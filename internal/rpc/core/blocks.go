@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
@@ -50,6 +52,61 @@ func (env *Environment) BlockchainInfo(ctx context.Context, req *coretypes.Reque
 	}, nil
 }
 
+// heightETAWindow is the maximum number of block intervals HeightETA averages
+// over.
+const heightETAWindow = 100
+
+// HeightETA estimates when the given height will be committed, from the
+// average interval between the latest blocks in the block store.
+//
+// If the height was already committed, it is reported as reached, along with
+// the time elapsed since its block was committed.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/height_eta
+func (env *Environment) HeightETA(ctx context.Context, req *coretypes.RequestHeightETA) (*coretypes.ResultHeightETA, error) {
+	height := int64(req.Height)
+	if height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+
+	latestHeight := env.BlockStore.Height()
+	latest := env.BlockStore.LoadBlockMeta(latestHeight)
+	if latest == nil {
+		return nil, coretypes.ErrNotEnoughBlocks
+	}
+	now := time.Now()
+	result := &coretypes.ResultHeightETA{
+		Height:          height,
+		LatestHeight:    latestHeight,
+		LatestBlockTime: latest.Header.Time,
+	}
+
+	if height <= latestHeight {
+		result.Reached = true
+		if meta := env.BlockStore.LoadBlockMeta(height); meta != nil {
+			result.EstimatedTime = meta.Header.Time
+			result.Elapsed = now.Sub(meta.Header.Time)
+		}
+		return result, nil
+	}
+
+	startHeight := tmmath.MaxInt64(env.BlockStore.Base(), latestHeight-heightETAWindow)
+	start := env.BlockStore.LoadBlockMeta(startHeight)
+	if start == nil || startHeight >= latestHeight {
+		return nil, coretypes.ErrNotEnoughBlocks
+	}
+
+	interval := latest.Header.Time.Sub(start.Header.Time) / time.Duration(latestHeight-startHeight)
+	result.AvgBlockInterval = interval
+	result.WindowStartHeight = startHeight
+	result.WindowEndHeight = latestHeight
+	result.EstimatedTime = latest.Header.Time.Add(interval * time.Duration(height-latestHeight))
+	if eta := result.EstimatedTime.Sub(now); eta > 0 {
+		result.ETA = eta
+	}
+	return result, nil
+}
+
 // error if either min or max are negative or min > max
 // if 0, use blockstore base for min, latest block height for max
 // enforce limit.
@@ -114,6 +171,39 @@ func (env *Environment) BlockByHash(ctx context.Context, req *coretypes.RequestB
 	return &coretypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
 }
 
+// BlockByAppHash gets the block whose execution produced the given app hash.
+// Note that the app hash is recorded in the header of the following block. If
+// several blocks produced the same app hash, the lowest is returned.
+// More: https://docs.tendermint.com/master/rpc/#/Info/block_by_app_hash
+func (env *Environment) BlockByAppHash(ctx context.Context, req *coretypes.RequestBlockByAppHash) (*coretypes.ResultBlock, error) {
+	if !indexer.KVSinkEnabled(env.EventSinks) {
+		return nil, fmt.Errorf("block querying by app hash is disabled due to no kvEventSink")
+	}
+
+	var kvsink indexer.EventSink
+	for _, sink := range env.EventSinks {
+		if sink.Type() == indexer.KV {
+			kvsink = sink
+		}
+	}
+
+	height, err := kvsink.GetBlockHeightByAppHash(req.AppHash)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return &coretypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return &coretypes.ResultBlock{BlockID: types.BlockID{}, Block: nil}, nil
+	}
+
+	block := env.BlockStore.LoadBlock(height)
+	return &coretypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+}
+
 // Header gets block header at a given height.
 // If no height is provided, it will fetch the latest header.
 // More: https://docs.tendermint.com/master/rpc/#/Info/header
@@ -192,9 +282,21 @@ func (env *Environment) BlockResults(ctx context.Context, req *coretypes.Request
 		return nil, err
 	}
 
-	var totalGasUsed int64
+	var totalGasUsed, totalGasWanted int64
 	for _, res := range results.GetTxResults() {
 		totalGasUsed += res.GetGasUsed()
+		totalGasWanted += res.GetGasWanted()
+	}
+
+	// The consensus params may have been pruned, in which case the block
+	// fullness is left unset rather than failing the request.
+	var maxGas int64
+	var gasPercent float64
+	if params, err := env.StateStore.LoadConsensusParams(height); err == nil {
+		maxGas = params.Block.MaxGas
+		if maxGas > 0 {
+			gasPercent = float64(totalGasUsed) * 100 / float64(maxGas)
+		}
 	}
 
 	return &coretypes.ResultBlockResults{
@@ -204,10 +306,15 @@ func (env *Environment) BlockResults(ctx context.Context, req *coretypes.Request
 		FinalizeBlockEvents:   results.Events,
 		ValidatorUpdates:      results.ValidatorUpdates,
 		ConsensusParamUpdates: results.ConsensusParamUpdates,
+		TotalGasWanted:        totalGasWanted,
+		MaxBlockGas:           maxGas,
+		BlockGasPercent:       gasPercent,
 	}, nil
 }
 
 // BlockSearch searches for a paginated set of blocks matching the provided query.
+// The blocks are sorted by height, by time or by a numeric FinalizeBlock event
+// attribute, as given by the order_by parameter (see parseBlockOrderBy).
 func (env *Environment) BlockSearch(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
 	if !indexer.KVSinkEnabled(env.EventSinks) {
 		return nil, fmt.Errorf("block searching is disabled due to no kvEventSink")
@@ -231,15 +338,19 @@ func (env *Environment) BlockSearch(ctx context.Context, req *coretypes.RequestB
 	}
 
 	// sort results (must be done before pagination)
-	switch req.OrderBy {
-	case "desc", "":
-		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
-
-	case "asc":
-		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	key, desc, err := parseBlockOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	switch key {
+	case types.BlockHeightKey, blockTimeOrderKey:
+		// Block times strictly increase with height, so both orders are the same.
+		sort.Slice(results, func(i, j int) bool { return (results[i] < results[j]) != desc })
 
 	default:
-		return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
+		if err := kvsink.SortBlockHeights(ctx, results, key, desc); err != nil {
+			return nil, err
+		}
 	}
 
 	// paginate results
@@ -270,3 +381,31 @@ func (env *Environment) BlockSearch(ctx context.Context, req *coretypes.RequestB
 
 	return &coretypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
 }
+
+// blockTimeOrderKey is the key to sort block search results by time.
+const blockTimeOrderKey = "block.time"
+
+// parseBlockOrderBy parses the order_by parameter of BlockSearch, of the form
+// "[<key>] [asc|desc]". The key is block.height, which is the default,
+// block.time, or the composite key of a numeric FinalizeBlock event
+// attribute, e.g. "rewards.amount". The order is descending by default.
+func parseBlockOrderBy(orderBy string) (key string, desc bool, err error) {
+	key, desc = types.BlockHeightKey, true
+
+	fields := strings.Fields(orderBy)
+	if n := len(fields); n > 0 && (fields[n-1] == "asc" || fields[n-1] == "desc") {
+		desc = fields[n-1] == "desc"
+		fields = fields[:n-1]
+	}
+	switch len(fields) {
+	case 0:
+	case 1:
+		if key = fields[0]; !strings.Contains(key, ".") {
+			return "", false, fmt.Errorf("order_by key %q is not a composite key: %w", key, coretypes.ErrInvalidRequest)
+		}
+	default:
+		return "", false, fmt.Errorf("expected order_by to be of the form `[<key>] [asc|desc]`: %w",
+			coretypes.ErrInvalidRequest)
+	}
+	return key, desc, nil
+}
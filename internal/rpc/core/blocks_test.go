@@ -4,16 +4,22 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
 	"github.com/tendermint/tendermint/internal/state/mocks"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -71,15 +77,27 @@ func TestBlockchainInfo(t *testing.T) {
 func TestBlockResults(t *testing.T) {
 	results := &abci.ResponseFinalizeBlock{
 		TxResults: []*abci.ExecTxResult{
-			{Code: 0, Data: []byte{0x01}, Log: "ok", GasUsed: 10},
-			{Code: 0, Data: []byte{0x02}, Log: "ok", GasUsed: 5},
-			{Code: 1, Log: "not ok", GasUsed: 0},
+			{Code: 0, Data: []byte{0x01}, Log: "ok", GasWanted: 10, GasUsed: 10},
+			{Code: 0, Data: []byte{0x02}, Log: "ok", GasWanted: 10, GasUsed: 5},
+			{Code: 1, Log: "not ok", GasWanted: 5, GasUsed: 0},
 		},
 	}
 
+	genDoc := &types.GenesisDoc{
+		ChainID:         "block-results",
+		InitialHeight:   100,
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      []types.GenesisValidator{{PubKey: ed25519.GenPrivKey().PubKey(), Power: 10}},
+	}
+	genDoc.ConsensusParams.Block.MaxGas = 20
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
 	env := &Environment{}
 	env.StateStore = sm.NewStore(dbm.NewMemDB())
-	err := env.StateStore.SaveFinalizeBlockResponses(100, results)
+	require.NoError(t, env.StateStore.Save(state))
+	err = env.StateStore.SaveFinalizeBlockResponses(100, results)
 	require.NoError(t, err)
 	mockstore := &mocks.BlockStore{}
 	mockstore.On("Height").Return(int64(100))
@@ -101,6 +119,9 @@ func TestBlockResults(t *testing.T) {
 			FinalizeBlockEvents:   results.Events,
 			ValidatorUpdates:      results.ValidatorUpdates,
 			ConsensusParamUpdates: results.ConsensusParamUpdates,
+			TotalGasWanted:        25,
+			MaxBlockGas:           20,
+			BlockGasPercent:       75,
 		}},
 	}
 
@@ -117,3 +138,128 @@ func TestBlockResults(t *testing.T) {
 		}
 	}
 }
+
+func TestHeightETA(t *testing.T) {
+	genesisTime := time.Now().Add(-time.Hour)
+	blockTime := func(height int64) time.Time {
+		return genesisTime.Add(time.Duration(height) * time.Second)
+	}
+	newEnv := func(base, height int64) *Environment {
+		mockstore := &mocks.BlockStore{}
+		mockstore.On("Base").Return(base)
+		mockstore.On("Height").Return(height)
+		mockstore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(func(h int64) *types.BlockMeta {
+			if h < base || h > height {
+				return nil
+			}
+			return &types.BlockMeta{Header: types.Header{Height: h, Time: blockTime(h)}}
+		})
+		return &Environment{BlockStore: mockstore}
+	}
+	ctx := context.Background()
+
+	_, err := newEnv(1, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 0})
+	require.ErrorIs(t, err, coretypes.ErrZeroOrNegativeHeight)
+
+	// one block per second, estimated over the last 100 blocks
+	res, err := newEnv(1, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 4100})
+	require.NoError(t, err)
+	assert.False(t, res.Reached)
+	assert.EqualValues(t, 500, res.LatestHeight)
+	assert.Equal(t, time.Second, res.AvgBlockInterval)
+	assert.EqualValues(t, 400, res.WindowStartHeight)
+	assert.EqualValues(t, 500, res.WindowEndHeight)
+	assert.True(t, res.EstimatedTime.Equal(blockTime(4100)))
+	assert.InDelta(t, float64(500*time.Second), float64(res.ETA), float64(time.Minute))
+
+	// the window is bounded by the base
+	res, err = newEnv(450, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 501})
+	require.NoError(t, err)
+	assert.EqualValues(t, 450, res.WindowStartHeight)
+	assert.Equal(t, time.Second, res.AvgBlockInterval)
+
+	// the estimated time is in the past, the chain is stalled
+	res, err = newEnv(1, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 501})
+	require.NoError(t, err)
+	assert.Zero(t, res.ETA)
+
+	// the height was already reached
+	res, err = newEnv(1, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 100})
+	require.NoError(t, err)
+	assert.True(t, res.Reached)
+	assert.Zero(t, res.ETA)
+	assert.True(t, res.EstimatedTime.Equal(blockTime(100)))
+	assert.InDelta(t, float64(time.Hour-100*time.Second), float64(res.Elapsed), float64(time.Minute))
+
+	// not enough blocks to estimate the interval
+	_, err = newEnv(500, 500).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 501})
+	require.ErrorIs(t, err, coretypes.ErrNotEnoughBlocks)
+	_, err = newEnv(0, 0).HeightETA(ctx, &coretypes.RequestHeightETA{Height: 1})
+	require.ErrorIs(t, err, coretypes.ErrNotEnoughBlocks)
+}
+
+func TestBlockSearchOrderBy(t *testing.T) {
+	eventSink := kv.NewEventSink(dbm.NewMemDB())
+	amounts := []string{"", "20", "5", "20", "", "1"} // by height, from 1
+	for i, amount := range amounts {
+		var events []abci.Event
+		if amount != "" {
+			events = []abci.Event{{
+				Type:       "rewards",
+				Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte(amount), Index: true}},
+			}}
+		}
+		require.NoError(t, eventSink.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header:              types.Header{Height: int64(i + 1)},
+			ResultFinalizeBlock: abci.ResponseFinalizeBlock{Events: events},
+		}))
+	}
+
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("LoadBlock", mock.AnythingOfType("int64")).Return(func(h int64) *types.Block {
+		return &types.Block{Header: types.Header{Height: h}}
+	})
+	mockstore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(&types.BlockMeta{})
+	env := &Environment{BlockStore: mockstore, EventSinks: []indexer.EventSink{eventSink}}
+
+	search := func(orderBy string, page int) ([]int64, error) {
+		p, perPage := coretypes.Int64(page), coretypes.Int64(4)
+		res, err := env.BlockSearch(context.Background(), &coretypes.RequestBlockSearch{
+			Query:   "block.height > 0",
+			Page:    &p,
+			PerPage: &perPage,
+			OrderBy: orderBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		heights := make([]int64, len(res.Blocks))
+		for i, block := range res.Blocks {
+			heights[i] = block.Block.Height
+		}
+		return heights, nil
+	}
+
+	for _, tc := range []struct {
+		orderBy string
+		pages   [][]int64
+	}{
+		{"", [][]int64{{6, 5, 4, 3}, {2, 1}}},
+		{"asc", [][]int64{{1, 2, 3, 4}, {5, 6}}},
+		{"block.height asc", [][]int64{{1, 2, 3, 4}, {5, 6}}},
+		{"block.time", [][]int64{{6, 5, 4, 3}, {2, 1}}},
+		{"rewards.amount asc", [][]int64{{6, 3, 2, 4}, {1, 5}}},
+		{"rewards.amount", [][]int64{{4, 2, 3, 6}, {5, 1}}},
+	} {
+		for i, want := range tc.pages {
+			heights, err := search(tc.orderBy, i+1)
+			require.NoError(t, err, tc.orderBy)
+			assert.Equal(t, want, heights, "order_by %q, page %d", tc.orderBy, i+1)
+		}
+	}
+
+	for _, orderBy := range []string{"ascending", "rewards.amount asc desc", "rewards.amount up"} {
+		_, err := search(orderBy, 1)
+		assert.ErrorIs(t, err, coretypes.ErrInvalidRequest, orderBy)
+	}
+}
@@ -2,9 +2,15 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
-	tmmath "github.com/tendermint/tendermint/libs/math"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Validators gets the validator set at the given block height.
@@ -13,6 +19,9 @@ import (
 // validators are sorted by their voting power - this is the canonical order
 // for the validators in the set as used in computing their Merkle root.
 //
+// If an address prefix is provided, only validators whose address starts with
+// it are returned, and the total counts only the matching validators.
+//
 // More: https://docs.tendermint.com/master/rpc/#/Info/validators
 func (env *Environment) Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error) {
 	// The latest validator that we know is the NextValidator of the last block.
@@ -21,21 +30,20 @@ func (env *Environment) Validators(ctx context.Context, req *coretypes.RequestVa
 		return nil, err
 	}
 
-	validators, err := env.StateStore.LoadValidators(height)
-	if err != nil {
-		return nil, err
+	perPage := env.validatePerPage(req.PerPage.IntPtr())
+	skipCount := 0
+	if req.Page != nil {
+		skipCount = validateSkipCount(int(*req.Page), perPage)
 	}
 
-	totalCount := len(validators.Validators)
-	perPage := env.validatePerPage(req.PerPage.IntPtr())
-	page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+	v, totalCount, err := env.StateStore.LoadValidatorsPage(height, skipCount, perPage, req.AddressPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-
-	v := validators.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+	if _, err := validatePage(req.Page.IntPtr(), perPage, totalCount); err != nil {
+		return nil, err
+	}
 
 	return &coretypes.ResultValidators{
 		BlockHeight: height,
@@ -91,13 +99,80 @@ func (env *Environment) DumpConsensusState(ctx context.Context) (*coretypes.Resu
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_state
 func (env *Environment) GetConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
+	if env.ConsensusState == nil {
+		// consensus is not running in inspect mode.
+		return env.persistedConsensusState()
+	}
+
 	// Get self round state.
 	bz, err := env.ConsensusState.GetRoundStateSimpleJSON()
 	return &coretypes.ResultConsensusState{RoundState: bz}, err
 }
 
-// ConsensusParams gets the consensus parameters at the given block height.
-// If no height is provided, it will fetch the latest consensus params.
+// ConsensusVotes returns the prevotes and precommits received by the node in
+// its current round, and the proposal block it has. Unlike the consensus state
+// dumps, it doesn't marshal the vote sets, so it is cheap to poll.
+// UNSTABLE
+// More: https://docs.tendermint.com/master/rpc/#/Info/consensus_votes
+func (env *Environment) ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error) {
+	if env.ConsensusState == nil {
+		return nil, errors.New("consensus is not running")
+	}
+
+	rs := env.ConsensusState.GetRoundState()
+	res := &coretypes.ResultConsensusVotes{
+		Height: rs.Height,
+		Round:  rs.Round,
+		Step:   rs.Step.String(),
+	}
+	if rs.Validators != nil {
+		res.Validators = make([]bytes.HexBytes, len(rs.Validators.Validators))
+		for i, val := range rs.Validators.Validators {
+			res.Validators[i] = val.Address
+		}
+	}
+	if rs.Votes != nil {
+		res.Prevotes = rs.Votes.Prevotes(rs.Round).BitArray()
+		res.Precommits = rs.Votes.Precommits(rs.Round).BitArray()
+	}
+	if rs.ProposalBlockParts != nil {
+		res.ProposalBlockParts = rs.ProposalBlockParts.BitArray()
+		if rs.ProposalBlockParts.IsComplete() {
+			res.ProposalBlockHash = rs.ProposalBlock.Hash()
+		}
+	}
+	return res, nil
+}
+
+// persistedConsensusState returns the round state consensus would start from,
+// according to the state store.
+func (env *Environment) persistedConsensusState() (*coretypes.ResultConsensusState, error) {
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found in the state store")
+	}
+
+	rs := cstypes.RoundStateSimple{
+		HeightRoundStep: fmt.Sprintf("%d/%d/%d", state.LastBlockHeight+1, 0, cstypes.RoundStepNewHeight),
+		StartTime:       state.LastBlockTime,
+		Votes:           json.RawMessage("null"),
+	}
+	if proposer := state.Validators.GetProposer(); proposer != nil {
+		idx, _ := state.Validators.GetByAddress(proposer.Address)
+		rs.Proposer = types.ValidatorInfo{Address: proposer.Address, Index: idx}
+	}
+
+	bz, err := json.Marshal(rs)
+	return &coretypes.ResultConsensusState{RoundState: bz}, err
+}
+
+// ConsensusParams gets the consensus parameters at the given block height,
+// which are the ones the block at that height was created with. If no height is
+// provided, it will fetch the latest consensus params, which apply to the next
+// block.
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_params
 func (env *Environment) ConsensusParams(ctx context.Context, req *coretypes.RequestConsensusParams) (*coretypes.ResultConsensusParams, error) {
 	// The latest consensus params that we know is the consensus params after
@@ -108,7 +183,11 @@ func (env *Environment) ConsensusParams(ctx context.Context, req *coretypes.Requ
 	}
 
 	consensusParams, err := env.StateStore.LoadConsensusParams(height)
-	if err != nil {
+	if errors.As(err, &sm.ErrNoConsensusParamsForHeight{}) {
+		// The state store doesn't go as far back as the block store after a
+		// state sync, whose backfill only restores the validator sets.
+		return nil, fmt.Errorf("%w (requested height: %d): %v", coretypes.ErrHeightNotAvailable, height, err)
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestConsensusParams(t *testing.T) {
+	genDoc := &types.GenesisDoc{
+		ChainID:         "consensus-params",
+		ConsensusParams: types.DefaultConsensusParams(),
+		Validators:      []types.GenesisValidator{{PubKey: ed25519.GenPrivKey().PubKey(), Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	oldMaxBytes := state.ConsensusParams.Block.MaxBytes
+	newMaxBytes := oldMaxBytes + 1
+
+	// The params are changed by the block at height 2, and apply from the
+	// block at height 3.
+	env := &Environment{StateStore: sm.NewStore(dbm.NewMemDB())}
+	require.NoError(t, env.StateStore.Save(state))
+	for height := int64(1); height <= 4; height++ {
+		state.LastBlockHeight = height
+		if height == 2 {
+			state.ConsensusParams.Block.MaxBytes = newMaxBytes
+			state.LastHeightConsensusParamsChanged = 3
+		}
+		require.NoError(t, env.StateStore.Save(state))
+	}
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(4))
+	blockStore.On("Base").Return(int64(1))
+	env.BlockStore = blockStore
+
+	ctx := context.Background()
+	testCases := []struct {
+		height   *int64
+		expected int64
+		maxBytes int64
+		err      error
+	}{
+		{nil, 5, newMaxBytes, nil}, // the params of the next block
+		{int64Ptr(1), 1, oldMaxBytes, nil},
+		{int64Ptr(2), 2, oldMaxBytes, nil},
+		{int64Ptr(3), 3, newMaxBytes, nil},
+		{int64Ptr(5), 5, newMaxBytes, nil},
+		{int64Ptr(0), 0, 0, coretypes.ErrZeroOrNegativeHeight},
+		{int64Ptr(6), 0, 0, coretypes.ErrHeightExceedsChainHead},
+	}
+	for _, tc := range testCases {
+		res, err := env.ConsensusParams(ctx, &coretypes.RequestConsensusParams{Height: (*coretypes.Int64)(tc.height)})
+		if tc.err != nil {
+			assert.ErrorIs(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, res.BlockHeight)
+		assert.Equal(t, tc.maxBytes, res.ConsensusParams.Block.MaxBytes, "height %d", res.BlockHeight)
+	}
+
+	// After a state sync, the block store is backfilled below the first
+	// height of the state store.
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	state.LastBlockHeight = 9
+	require.NoError(t, env.StateStore.Save(state))
+	blockStore = &mocks.BlockStore{}
+	blockStore.On("Height").Return(int64(9))
+	blockStore.On("Base").Return(int64(5))
+	env.BlockStore = blockStore
+	for _, h := range []int64{5, 10} {
+		_, err = env.ConsensusParams(ctx, &coretypes.RequestConsensusParams{Height: (*coretypes.Int64)(int64Ptr(h))})
+		assert.ErrorIs(t, err, coretypes.ErrHeightNotAvailable, "height %d", h)
+	}
+}
+
+func int64Ptr(h int64) *int64 { return &h }
@@ -2,8 +2,14 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
 
+	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 // UnsafeFlushMempool removes all transactions from the mempool.
@@ -11,3 +17,99 @@ func (env *Environment) UnsafeFlushMempool(ctx context.Context) (*coretypes.Resu
 	env.Mempool.Flush()
 	return &coretypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeRemoveTx removes the transaction with the given hash from the mempool,
+// e.g. to purge a transaction stuck in it. If blacklist is set, the mempool
+// rejects the transaction afterwards, until the node restarts.
+func (env *Environment) UnsafeRemoveTx(ctx context.Context, req *coretypes.RequestUnsafeRemoveTx) (*coretypes.ResultUnsafeRemoveTx, error) {
+	if len(req.Hash) != sha256.Size {
+		return nil, fmt.Errorf("invalid transaction hash %X: expected %d bytes", []byte(req.Hash), sha256.Size)
+	}
+	txKey := types.TxKey(req.Hash)
+	if err := env.Mempool.RemoveTxByHash(txKey, req.Blacklist); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultUnsafeRemoveTx{
+		Hash:        req.Hash,
+		Blacklisted: req.Blacklist,
+	}, nil
+}
+
+// UnsafeABCITimings returns the time spent in each ABCI call made for the last
+// committed block, to tell whether slow blocks are caused by the application
+// or by consensus.
+func (env *Environment) UnsafeABCITimings(ctx context.Context) (*coretypes.ResultUnsafeABCITimings, error) {
+	if env.BlockExecutor == nil {
+		return nil, errors.New("block execution is not running")
+	}
+	timings, ok := env.BlockExecutor.LastABCITimings()
+	if !ok {
+		return nil, errors.New("no block has been committed yet")
+	}
+	return &coretypes.ResultUnsafeABCITimings{
+		Height:          timings.Height,
+		PrepareProposal: timings.PrepareProposal,
+		ProcessProposal: timings.ProcessProposal,
+		FinalizeBlock:   timings.FinalizeBlock,
+		Commit:          timings.Commit,
+	}, nil
+}
+
+// UnsafePeerScores returns how every known peer is currently scored, including
+// any ban in place, for debugging peer selection.
+func (env *Environment) UnsafePeerScores(ctx context.Context) (*coretypes.ResultUnsafePeerScores, error) {
+	infos := env.PeerManager.ScoreInfos()
+	peers := make([]coretypes.PeerScore, 0, len(infos))
+	for _, info := range infos {
+		peers = append(peers, coretypes.PeerScore{
+			ID:           info.ID,
+			Score:        int(info.Score),
+			MutableScore: info.MutableScore,
+			NumBans:      info.NumBans,
+			BannedUntil:  info.BannedUntil,
+		})
+	}
+	return &coretypes.ResultUnsafePeerScores{Peers: peers}, nil
+}
+
+// UnsafePauseConsensus stops the node from signing votes and proposals, while
+// it keeps syncing and gossiping, e.g. during maintenance.
+func (env *Environment) UnsafePauseConsensus(ctx context.Context) (*coretypes.ResultUnsafeConsensusPause, error) {
+	if env.ConsensusState == nil {
+		return nil, errors.New("consensus is not running")
+	}
+	env.ConsensusState.Pause()
+	return &coretypes.ResultUnsafeConsensusPause{Paused: true}, nil
+}
+
+// UnsafeResumeConsensus resumes signing votes and proposals after
+// UnsafePauseConsensus.
+func (env *Environment) UnsafeResumeConsensus(ctx context.Context) (*coretypes.ResultUnsafeConsensusPause, error) {
+	if env.ConsensusState == nil {
+		return nil, errors.New("consensus is not running")
+	}
+	env.ConsensusState.Resume()
+	return &coretypes.ResultUnsafeConsensusPause{Paused: false}, nil
+}
+
+// UnsafeSetChannelPriority overrides the send priority of a p2p channel on all
+// peer connections, e.g. to favour consensus over block sync on a congested
+// link. A priority of 0 restores the default priority of the channel.
+func (env *Environment) UnsafeSetChannelPriority(ctx context.Context, req *coretypes.RequestUnsafeSetChannelPriority) (*coretypes.ResultUnsafeSetChannelPriority, error) {
+	if env.Router == nil {
+		return nil, errors.New("p2p router is not running")
+	}
+	if req.Channel < 0 || req.Channel > math.MaxUint16 {
+		return nil, fmt.Errorf("invalid channel %d", req.Channel)
+	}
+	if req.Priority < 0 || req.Priority > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid priority %d", req.Priority)
+	}
+	if err := env.Router.SetChannelPriority(p2p.ChannelID(req.Channel), int(req.Priority)); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultUnsafeSetChannelPriority{
+		Channel:  int64(req.Channel),
+		Priority: int64(req.Priority),
+	}, nil
+}
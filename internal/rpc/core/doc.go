@@ -17,6 +17,7 @@ curl 'localhost:26657'
 ```plain
 Available endpoints:
 /abci_info
+/consensus_votes
 /dump_consensus_state
 /genesis
 /net_info
@@ -24,23 +25,36 @@ Available endpoints:
 /status
 /lag_status
 /health
+/mempool_entries
+/pending_evidence
 /unconfirmed_txs
+/unsafe_abci_timings
 /unsafe_flush_mempool
+/unsafe_peer_scores
+/validator_uptime
 /validators
 
 Endpoints that require arguments:
 /abci_query?path=_&data=_&prove=_
 /block?height=_
+/block_by_app_hash?app_hash=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
 /broadcast_tx_commit?tx=_
 /broadcast_tx_sync?tx=_
 /commit?height=_
+/height_eta?height=_
+/pending_evidence?type=_&page=_&per_page=_
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
 /subscribe?event=_
 /tx?hash=_&prove=_
+/tx_events?hash=_
+/tx_proof?hash=_
 /unsubscribe?event=_
+/validator_uptime?window=_
+/unsafe_set_channel_priority?channel=_&priority=_
+/unsafe_remove_tx?hash=_&blacklist=_
 ```
 */
 package core
@@ -15,6 +15,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/blocksync"
 	"github.com/tendermint/tendermint/internal/consensus"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/eventlog"
 	"github.com/tendermint/tendermint/internal/mempool"
@@ -26,7 +27,6 @@ import (
 	"github.com/tendermint/tendermint/internal/statesync"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/libs/strings"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	"github.com/tendermint/tendermint/types"
@@ -55,6 +55,18 @@ type consensusState interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetRoundState() *cstypes.RoundState
+	Pause()
+	Resume()
+	IsPaused() bool
+}
+
+type blockExecutor interface {
+	LastABCITimings() (sm.ABCITimings, bool)
+}
+
+type router interface {
+	SetChannelPriority(p2p.ChannelID, int) error
 }
 
 type peerManager interface {
@@ -62,6 +74,7 @@ type peerManager interface {
 	Score(types.NodeID) int
 	State(types.NodeID) string
 	Addresses(types.NodeID) []p2p.NodeAddress
+	ScoreInfos() []p2p.PeerScoreInfo
 }
 
 // ----------------------------------------------
@@ -78,6 +91,7 @@ type Environment struct {
 	ConsensusState   consensusState
 	ConsensusReactor *consensus.Reactor
 	BlockSyncReactor *blocksync.Reactor
+	BlockExecutor    blockExecutor
 
 	IsListening bool
 	Listeners   []string
@@ -85,6 +99,7 @@ type Environment struct {
 
 	// interfaces for new p2p interfaces
 	PeerManager peerManager
+	Router      router
 
 	// objects
 	PubKey            crypto.PubKey
@@ -101,6 +116,9 @@ type Environment struct {
 
 	// cache of chunked genesis data.
 	genChunks []string
+
+	// cache of the recent blocks signed by the local validator.
+	uptime uptimeTracker
 }
 
 //----------------------------------------------
@@ -226,14 +244,23 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 		fmt.Sprintf("Listener(@%v)", conf.P2P.ExternalAddress),
 	}
 
-	listenAddrs := strings.SplitAndTrimEmpty(conf.RPC.ListenAddress, ",", " ")
-	routes := NewRoutesMap(env, &RouteOptions{
-		Unsafe: conf.RPC.Unsafe,
-	})
-
 	cfg := rpcserver.DefaultConfig()
 	cfg.MaxBodyBytes = conf.RPC.MaxBodyBytes
 	cfg.MaxHeaderBytes = conf.RPC.MaxHeaderBytes
+	cfg.ReadTimeout = conf.RPC.ReadTimeout
+	cfg.WriteTimeout = conf.RPC.WriteTimeout
+	cfg.IdleTimeout = conf.RPC.IdleTimeout
+	var err error
+	if cfg.RateLimits, cfg.DefaultRateLimit, err = conf.RPC.ParseRateLimits(); err != nil {
+		return nil, err
+	}
+	cfg.RateLimitPerClient = conf.RPC.RateLimitPerClient
+	// The listeners share the rate limits, which would otherwise be
+	// multiplied by the number of listeners.
+	cfg.RateLimiter = rpcserver.NewRateLimiter(cfg)
+	if cfg.UnixSocketPermissions, err = conf.RPC.UnixSocketFileMode(); err != nil {
+		return nil, err
+	}
 	cfg.MaxOpenConnections = conf.RPC.MaxOpenConnections
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
@@ -278,11 +305,30 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 		env.Logger.Info("Event log subscription enabled")
 	}
 
-	// We may expose the RPC over both TCP and a Unix-domain socket.
-	listeners := make([]net.Listener, len(listenAddrs))
-	for i, listenAddr := range listenAddrs {
+	// We may expose the RPC over several TCP and Unix-domain socket
+	// addresses, each with its own access settings. The servers share the
+	// environment, which is safe for concurrent use.
+	listenerConfs := conf.RPC.AllListeners()
+	allRoutes := NewRoutesMap(env, &RouteOptions{Unsafe: true})
+	for _, lconf := range listenerConfs {
+		// Catch typos, which would silently leave a denied method enabled.
+		for _, name := range append(append([]string{}, lconf.AllowedMethods...), lconf.DeniedMethods...) {
+			if _, ok := allRoutes[name]; !ok {
+				return nil, fmt.Errorf("RPC listener %s: unknown method %q", lconf.ListenAddress, name)
+			}
+		}
+	}
+
+	listeners := make([]net.Listener, 0, len(listenerConfs))
+	for _, lconf := range listenerConfs {
+		routes := NewRoutesMap(env, &RouteOptions{
+			Unsafe:         lconf.Unsafe,
+			AllowedMethods: lconf.AllowedMethods,
+			DeniedMethods:  lconf.DeniedMethods,
+		})
+
 		mux := http.NewServeMux()
-		rpcLogger := env.Logger.With("module", "rpc-server")
+		rpcLogger := env.Logger.With("module", "rpc-server", "laddr", lconf.ListenAddress)
 		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
 
 		if conf.RPC.ExperimentalDisableWebsocket {
@@ -303,35 +349,37 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 			mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		}
 
-		listener, err := rpcserver.Listen(
-			listenAddr,
-			cfg.MaxOpenConnections,
-		)
+		lcfg := *cfg
+		lcfg.AuthToken = lconf.AuthToken
+		if lconf.IsCorsEnabled() {
+			lcfg.CORS = &cors.Options{
+				AllowedOrigins: lconf.CORSAllowedOrigins,
+				AllowedMethods: lconf.CORSAllowedMethods,
+				AllowedHeaders: lconf.CORSAllowedHeaders,
+			}
+		}
+		listener, err := rpcserver.Listen(lconf.ListenAddress, &lcfg)
 		if err != nil {
+			// Don't leak the listeners already started, the caller only
+			// closes them on success.
+			for _, l := range listeners {
+				_ = l.Close()
+			}
 			return nil, err
 		}
 
-		var rootHandler http.Handler = mux
-		if conf.RPC.IsCorsEnabled() {
-			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: conf.RPC.CORSAllowedOrigins,
-				AllowedMethods: conf.RPC.CORSAllowedMethods,
-				AllowedHeaders: conf.RPC.CORSAllowedHeaders,
-			})
-			rootHandler = corsMiddleware.Handler(mux)
-		}
 		if conf.RPC.IsTLSEnabled() {
 			go func() {
 				if err := rpcserver.ServeTLS(
 					ctx,
 					listener,
-					rootHandler,
+					mux,
 					conf.RPC.CertFile(),
 					conf.RPC.KeyFile(),
 					rpcLogger,
-					cfg,
+					&lcfg,
 				); err != nil {
-					env.Logger.Error("error serving server with TLS", "err", err)
+					rpcLogger.Error("error serving server with TLS", "err", err)
 				}
 			}()
 		} else {
@@ -339,16 +387,16 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 				if err := rpcserver.Serve(
 					ctx,
 					listener,
-					rootHandler,
+					mux,
 					rpcLogger,
-					cfg,
+					&lcfg,
 				); err != nil {
-					env.Logger.Error("error serving server", "err", err)
+					rpcLogger.Error("error serving server", "err", err)
 				}
 			}()
 		}
 
-		listeners[i] = listener
+		listeners = append(listeners, listener)
 	}
 
 	return listeners, nil
@@ -1,10 +1,18 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
 )
 
 func TestPaginationPage(t *testing.T) {
@@ -88,3 +96,70 @@ func TestPaginationPerPage(t *testing.T) {
 	assert.Equal(t, perPage, p)
 	env.Config.Unsafe = false
 }
+
+func TestStartServiceListeners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conf := config.TestConfig()
+	conf.RPC.ListenAddress = "tcp://127.0.0.1:0"
+	conf.RPC.Unsafe = false
+	conf.RPC.ExperimentalDisableWebsocket = true
+	conf.RPC.Listeners = []config.RPCListenerConfig{
+		{ListenAddress: "tcp://127.0.0.1:0", Unsafe: true},
+		{ListenAddress: "tcp://127.0.0.1:0", AuthToken: "secret"},
+		{ListenAddress: "tcp://127.0.0.1:0", DeniedMethods: []string{"health"}},
+	}
+
+	env := &Environment{Logger: log.NewNopLogger()}
+	listeners, err := env.StartService(ctx, conf)
+	require.NoError(t, err)
+	require.Len(t, listeners, 4)
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	call := func(i int, method, token string) (int, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+listeners[i].Addr().String(),
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	// the unsafe methods are only served by the unsafe listener
+	_, body := call(0, "unsafe_abci_timings", "")
+	assert.Contains(t, body, "Method not found")
+	_, body = call(1, "unsafe_abci_timings", "")
+	assert.Contains(t, body, "block execution is not running")
+
+	// the auth token is only required by its listener
+	status, _ := call(0, "health", "")
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = call(2, "health", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = call(2, "health", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = call(2, "health", "secret")
+	assert.Equal(t, http.StatusOK, status)
+
+	// the denied methods are only disabled on their listener
+	_, body = call(3, "health", "")
+	assert.Contains(t, body, "Method not allowed")
+	_, body = call(0, "health", "")
+	assert.NotContains(t, body, "error")
+
+	// unknown method names are rejected
+	conf.RPC.Listeners = []config.RPCListenerConfig{{ListenAddress: "tcp://127.0.0.1:0", DeniedMethods: []string{"helth"}}}
+	_, err = env.StartService(ctx, conf)
+	assert.ErrorContains(t, err, `unknown method "helth"`)
+}
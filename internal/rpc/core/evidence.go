@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 // BroadcastEvidence broadcasts evidence of the misbehavior.
@@ -21,3 +23,76 @@ func (env *Environment) BroadcastEvidence(ctx context.Context, req *coretypes.Re
 	}
 	return &coretypes.ResultBroadcastEvidence{Hash: req.Evidence.Hash()}, nil
 }
+
+// ExpiredEvidence returns the evidence that has expired under the consensus
+// evidence params but is still kept by the node's evidence retention setting.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/expired_evidence
+func (env *Environment) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	evs, err := env.EvidencePool.ExpiredEvidence()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load expired evidence: %w", err)
+	}
+	return &coretypes.ResultExpiredEvidence{
+		Count:    len(evs),
+		Evidence: evs,
+	}, nil
+}
+
+// PendingEvidence lists the evidence in the pool that is pending inclusion in
+// a block, from oldest to newest, optionally only of the given type.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/pending_evidence
+func (env *Environment) PendingEvidence(ctx context.Context, req *coretypes.RequestPendingEvidence) (*coretypes.ResultPendingEvidence, error) {
+	switch req.Type {
+	case "", coretypes.EvidenceTypeDuplicateVote, coretypes.EvidenceTypeLightClientAttack:
+	default:
+		return nil, fmt.Errorf("%w: unknown evidence type %q", coretypes.ErrInvalidRequest, req.Type)
+	}
+
+	pending, _ := env.EvidencePool.PendingEvidence(-1)
+	evs := make(types.EvidenceList, 0, len(pending))
+	for _, ev := range pending {
+		if req.Type == "" || evidenceType(ev) == req.Type {
+			evs = append(evs, ev)
+		}
+	}
+
+	totalCount := len(evs)
+	perPage := env.validatePerPage(req.PerPage.IntPtr())
+	page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	evs = evs[skipCount:tmmath.MinInt(skipCount+perPage, totalCount)]
+
+	info := make([]coretypes.EvidenceInfo, len(evs))
+	for i, ev := range evs {
+		info[i] = coretypes.EvidenceInfo{
+			Hash:   ev.Hash(),
+			Type:   evidenceType(ev),
+			Height: ev.Height(),
+			Time:   ev.Time(),
+		}
+		for _, mb := range ev.ABCI() {
+			info[i].Validators = append(info[i].Validators, mb.Validator.Address)
+		}
+	}
+
+	return &coretypes.ResultPendingEvidence{
+		Count:    len(evs),
+		Total:    totalCount,
+		Info:     info,
+		Evidence: evs,
+	}, nil
+}
+
+func evidenceType(ev types.Evidence) string {
+	switch ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return coretypes.EvidenceTypeDuplicateVote
+	case *types.LightClientAttackEvidence:
+		return coretypes.EvidenceTypeLightClientAttack
+	default:
+		return ""
+	}
+}
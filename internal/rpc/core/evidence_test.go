@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPendingEvidence(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	var pending []types.Evidence
+	for h := int64(1); h <= 3; h++ {
+		ev, err := types.NewMockDuplicateVoteEvidence(ctx, h, now, "test-chain")
+		require.NoError(t, err)
+		pending = append(pending, ev)
+	}
+	byzantine := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	pending = append(pending, &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 3}},
+		},
+		CommonHeight:        2,
+		ByzantineValidators: []*types.Validator{byzantine},
+	})
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", int64(-1)).Return(pending, int64(0))
+	env := &Environment{EvidencePool: evpool}
+
+	res, err := env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Count)
+	assert.Equal(t, 4, res.Total)
+	assert.Equal(t, types.EvidenceList(pending), res.Evidence)
+	require.Len(t, res.Info, 4)
+
+	dve := pending[0].(*types.DuplicateVoteEvidence)
+	assert.Equal(t, coretypes.EvidenceInfo{
+		Hash:       dve.Hash(),
+		Type:       coretypes.EvidenceTypeDuplicateVote,
+		Height:     1,
+		Time:       now,
+		Validators: []bytes.HexBytes{dve.VoteA.ValidatorAddress},
+	}, res.Info[0])
+	assert.Equal(t, coretypes.EvidenceTypeLightClientAttack, res.Info[3].Type)
+	assert.Equal(t, []bytes.HexBytes{byzantine.Address}, res.Info[3].Validators)
+
+	// filtered by type, and paginated
+	page, perPage := coretypes.Int64(2), coretypes.Int64(2)
+	res, err = env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{
+		Type:    coretypes.EvidenceTypeDuplicateVote,
+		Page:    &page,
+		PerPage: &perPage,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Count)
+	assert.Equal(t, 3, res.Total)
+	assert.Equal(t, types.EvidenceList{pending[2]}, res.Evidence)
+
+	_, err = env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{Type: "unknown"})
+	require.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+
+	page = 3
+	_, err = env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{Page: &page, PerPage: &perPage})
+	require.Error(t, err)
+}
@@ -157,6 +157,9 @@ func (env *Environment) UnconfirmedTxs(ctx context.Context, req *coretypes.Reque
 	skipCount := validateSkipCount(page, perPage)
 
 	txs := env.Mempool.ReapMaxTxs(skipCount + tmmath.MinInt(perPage, totalCount-skipCount))
+	if skipCount > len(txs) {
+		skipCount = len(txs)
+	}
 	result := txs[skipCount:]
 
 	return &coretypes.ResultUnconfirmedTxs{
@@ -167,6 +170,45 @@ func (env *Environment) UnconfirmedTxs(ctx context.Context, req *coretypes.Reque
 	}, nil
 }
 
+// MempoolEntries gets unconfirmed transactions from the mempool in order of
+// priority, along with the metadata the mempool tracks for each of them.
+// More: https://docs.tendermint.com/master/rpc/#/Info/mempool_entries
+func (env *Environment) MempoolEntries(ctx context.Context, req *coretypes.RequestMempoolEntries) (*coretypes.ResultMempoolEntries, error) {
+	totalCount := env.Mempool.Size()
+	perPage := env.validatePerPage(req.PerPage.IntPtr())
+	page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+
+	txEntries := env.Mempool.ReapMaxTxEntries(skipCount + tmmath.MinInt(perPage, totalCount-skipCount))
+	if skipCount > len(txEntries) {
+		skipCount = len(txEntries)
+	}
+
+	entries := make([]coretypes.MempoolEntry, 0, len(txEntries)-skipCount)
+	for _, e := range txEntries[skipCount:] {
+		entries = append(entries, coretypes.MempoolEntry{
+			Hash:           e.Tx.Hash(),
+			Size:           len(e.Tx),
+			Priority:       e.Priority,
+			Sender:         e.Sender,
+			GasWanted:      e.GasWanted,
+			Time:           e.Timestamp,
+			PendingRecheck: e.PendingRecheck,
+		})
+	}
+
+	return &coretypes.ResultMempoolEntries{
+		Count:      len(entries),
+		Total:      totalCount,
+		TotalBytes: env.Mempool.SizeBytes(),
+		Entries:    entries,
+	}, nil
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/num_unconfirmed_txs
 func (env *Environment) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
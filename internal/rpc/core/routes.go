@@ -15,6 +15,11 @@ type RoutesMap map[string]*rpc.RPCFunc
 // is ready for use and provides defaults as specified.
 type RouteOptions struct {
 	Unsafe bool // include "unsafe" methods (default false)
+
+	// If not empty, the methods not in AllowedMethods are disabled. The
+	// methods in DeniedMethods are disabled. See rpc.RestrictRPCFuncs.
+	AllowedMethods []string
+	DeniedMethods  []string
 }
 
 // NewRoutesMap constructs an RPC routing map for the given service
@@ -39,25 +44,32 @@ func NewRoutesMap(svc RPCService, opts *RouteOptions) RoutesMap {
 		"lag_status":           rpc.NewRPCFunc(svc.LagStatus),
 		"net_info":             rpc.NewRPCFunc(svc.NetInfo),
 		"blockchain":           rpc.NewRPCFunc(svc.BlockchainInfo),
+		"height_eta":           rpc.NewRPCFunc(svc.HeightETA),
 		"genesis":              rpc.NewRPCFunc(svc.Genesis),
 		"genesis_chunked":      rpc.NewRPCFunc(svc.GenesisChunked),
 		"header":               rpc.NewRPCFunc(svc.Header),
 		"header_by_hash":       rpc.NewRPCFunc(svc.HeaderByHash),
 		"block":                rpc.NewRPCFunc(svc.Block),
 		"block_by_hash":        rpc.NewRPCFunc(svc.BlockByHash),
+		"block_by_app_hash":    rpc.NewRPCFunc(svc.BlockByAppHash),
 		"block_results":        rpc.NewRPCFunc(svc.BlockResults),
 		"commit":               rpc.NewRPCFunc(svc.Commit),
 		"check_tx":             rpc.NewRPCFunc(svc.CheckTx),
 		"remove_tx":            rpc.NewRPCFunc(svc.RemoveTx),
 		"tx":                   rpc.NewRPCFunc(svc.Tx),
+		"tx_events":            rpc.NewRPCFunc(svc.TxEvents),
+		"tx_proof":             rpc.NewRPCFunc(svc.TxProof),
 		"tx_search":            rpc.NewRPCFunc(svc.TxSearch),
 		"block_search":         rpc.NewRPCFunc(svc.BlockSearch),
 		"validators":           rpc.NewRPCFunc(svc.Validators),
+		"validator_uptime":     rpc.NewRPCFunc(svc.ValidatorUptime),
 		"dump_consensus_state": rpc.NewRPCFunc(svc.DumpConsensusState),
 		"consensus_state":      rpc.NewRPCFunc(svc.GetConsensusState),
+		"consensus_votes":      rpc.NewRPCFunc(svc.ConsensusVotes),
 		"consensus_params":     rpc.NewRPCFunc(svc.ConsensusParams),
 		"unconfirmed_txs":      rpc.NewRPCFunc(svc.UnconfirmedTxs),
 		"num_unconfirmed_txs":  rpc.NewRPCFunc(svc.NumUnconfirmedTxs),
+		"mempool_entries":      rpc.NewRPCFunc(svc.MempoolEntries),
 
 		// tx broadcast API
 		"broadcast_tx": rpc.NewRPCFunc(svc.BroadcastTx),
@@ -73,9 +85,20 @@ func NewRoutesMap(svc RPCService, opts *RouteOptions) RoutesMap {
 
 		// evidence API
 		"broadcast_evidence": rpc.NewRPCFunc(svc.BroadcastEvidence),
+		"expired_evidence":   rpc.NewRPCFunc(svc.ExpiredEvidence),
+		"pending_evidence":   rpc.NewRPCFunc(svc.PendingEvidence),
 	}
 	if u, ok := svc.(RPCUnsafe); ok && opts.Unsafe {
 		out["unsafe_flush_mempool"] = rpc.NewRPCFunc(u.UnsafeFlushMempool)
+		out["unsafe_peer_scores"] = rpc.NewRPCFunc(u.UnsafePeerScores)
+		out["unsafe_pause_consensus"] = rpc.NewRPCFunc(u.UnsafePauseConsensus)
+		out["unsafe_resume_consensus"] = rpc.NewRPCFunc(u.UnsafeResumeConsensus)
+		out["unsafe_set_channel_priority"] = rpc.NewRPCFunc(u.UnsafeSetChannelPriority)
+		out["unsafe_abci_timings"] = rpc.NewRPCFunc(u.UnsafeABCITimings)
+		out["unsafe_remove_tx"] = rpc.NewRPCFunc(u.UnsafeRemoveTx)
+	}
+	if len(opts.AllowedMethods) != 0 || len(opts.DeniedMethods) != 0 {
+		return rpc.RestrictRPCFuncs(out, opts.AllowedMethods, opts.DeniedMethods)
 	}
 	return out
 }
@@ -87,6 +110,7 @@ type RPCService interface {
 	ABCIQuery(ctx context.Context, req *coretypes.RequestABCIQuery) (*coretypes.ResultABCIQuery, error)
 	Block(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, req *coretypes.RequestBlockByHash) (*coretypes.ResultBlock, error)
+	BlockByAppHash(ctx context.Context, req *coretypes.RequestBlockByAppHash) (*coretypes.ResultBlock, error)
 	BlockResults(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlockResults, error)
 	BlockSearch(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error)
 	BlockchainInfo(ctx context.Context, req *coretypes.RequestBlockchainInfo) (*coretypes.ResultBlockchainInfo, error)
@@ -98,30 +122,44 @@ type RPCService interface {
 	CheckTx(ctx context.Context, req *coretypes.RequestCheckTx) (*coretypes.ResultCheckTx, error)
 	Commit(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultCommit, error)
 	ConsensusParams(ctx context.Context, req *coretypes.RequestConsensusParams) (*coretypes.ResultConsensusParams, error)
+	ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error)
 	DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error)
 	Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error)
+	ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error)
 	Genesis(ctx context.Context) (*coretypes.ResultGenesis, error)
 	GenesisChunked(ctx context.Context, req *coretypes.RequestGenesisChunked) (*coretypes.ResultGenesisChunk, error)
 	GetConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error)
 	Header(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, req *coretypes.RequestBlockByHash) (*coretypes.ResultHeader, error)
 	Health(ctx context.Context) (*coretypes.ResultHealth, error)
+	HeightETA(ctx context.Context, req *coretypes.RequestHeightETA) (*coretypes.ResultHeightETA, error)
+	MempoolEntries(ctx context.Context, req *coretypes.RequestMempoolEntries) (*coretypes.ResultMempoolEntries, error)
 	NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error)
 	NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error)
+	PendingEvidence(ctx context.Context, req *coretypes.RequestPendingEvidence) (*coretypes.ResultPendingEvidence, error)
 	RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error
 	Status(ctx context.Context) (*coretypes.ResultStatus, error)
 	LagStatus(ctx context.Context) (*coretypes.ResultLagStatus, error)
 	Subscribe(ctx context.Context, req *coretypes.RequestSubscribe) (*coretypes.ResultSubscribe, error)
 	Tx(ctx context.Context, req *coretypes.RequestTx) (*coretypes.ResultTx, error)
+	TxEvents(ctx context.Context, req *coretypes.RequestTxEvents) (*coretypes.ResultTxEvents, error)
+	TxProof(ctx context.Context, req *coretypes.RequestTxProof) (*coretypes.ResultTxProof, error)
 	TxSearch(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error)
 	UnconfirmedTxs(ctx context.Context, req *coretypes.RequestUnconfirmedTxs) (*coretypes.ResultUnconfirmedTxs, error)
 	Unsubscribe(ctx context.Context, req *coretypes.RequestUnsubscribe) (*coretypes.ResultUnsubscribe, error)
 	UnsubscribeAll(ctx context.Context) (*coretypes.ResultUnsubscribe, error)
 	Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error)
+	ValidatorUptime(ctx context.Context, req *coretypes.RequestValidatorUptime) (*coretypes.ResultValidatorUptime, error)
 }
 
 // RPCUnsafe defines the set of "unsafe" methods that may optionally be
 // exported by the RPC service.
 type RPCUnsafe interface {
 	UnsafeFlushMempool(ctx context.Context) (*coretypes.ResultUnsafeFlushMempool, error)
+	UnsafePeerScores(ctx context.Context) (*coretypes.ResultUnsafePeerScores, error)
+	UnsafePauseConsensus(ctx context.Context) (*coretypes.ResultUnsafeConsensusPause, error)
+	UnsafeResumeConsensus(ctx context.Context) (*coretypes.ResultUnsafeConsensusPause, error)
+	UnsafeSetChannelPriority(ctx context.Context, req *coretypes.RequestUnsafeSetChannelPriority) (*coretypes.ResultUnsafeSetChannelPriority, error)
+	UnsafeABCITimings(ctx context.Context) (*coretypes.ResultUnsafeABCITimings, error)
+	UnsafeRemoveTx(ctx context.Context, req *coretypes.RequestUnsafeRemoveTx) (*coretypes.ResultUnsafeRemoveTx, error)
 }
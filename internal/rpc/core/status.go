@@ -59,10 +59,16 @@ func (env *Environment) Status(ctx context.Context) (*coretypes.ResultStatus, er
 			VotingPower: votingPower,
 		}
 	}
+	if env.ConsensusState != nil {
+		validatorInfo.ConsensusPaused = env.ConsensusState.IsPaused()
+	}
 
+	// The application is not connected in inspect mode.
 	var applicationInfo coretypes.ApplicationInfo
-	if abciInfo, err := env.ABCIInfo(ctx); err == nil {
-		applicationInfo.Version = fmt.Sprint(abciInfo.Response.AppVersion)
+	if env.ProxyApp != nil {
+		if abciInfo, err := env.ABCIInfo(ctx); err == nil {
+			applicationInfo.Version = fmt.Sprint(abciInfo.Response.AppVersion)
+		}
 	}
 
 	result := &coretypes.ResultStatus{
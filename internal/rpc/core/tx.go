@@ -1,11 +1,13 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"sort"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -50,6 +52,97 @@ func (env *Environment) Tx(ctx context.Context, req *coretypes.RequestTx) (*core
 	return nil, fmt.Errorf("transaction querying is disabled on this node due to the KV event sink being disabled")
 }
 
+// TxProof returns a transaction along with the Merkle proof of its inclusion in
+// the transactions of its block, so that it can be verified against the
+// DataHash of the block header without fetching the block. Like Tx, it requires
+// the kv event sink.
+// More: https://docs.tendermint.com/master/rpc/#/Info/tx_proof
+func (env *Environment) TxProof(ctx context.Context, req *coretypes.RequestTxProof) (*coretypes.ResultTxProof, error) {
+	var sink indexer.EventSink
+	for _, s := range env.EventSinks {
+		if s.Type() == indexer.KV {
+			sink = s
+			break
+		}
+	}
+	if sink == nil {
+		return nil, errors.New("transaction querying is disabled due to no kvEventSink")
+	}
+
+	r, err := sink.GetTxByHash(req.Hash)
+	if r == nil {
+		return nil, fmt.Errorf("tx (%X) not found, err: %w", req.Hash, err)
+	}
+
+	block := env.BlockStore.LoadBlock(r.Height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found, it may have been pruned", r.Height)
+	}
+	if int(r.Index) >= len(block.Data.Txs) || !bytes.Equal(block.Data.Txs[r.Index].Hash(), req.Hash) {
+		return nil, fmt.Errorf("tx (%X) not found at index %d of block %d", req.Hash, r.Index, r.Height)
+	}
+	proof := block.Data.Txs.Proof(int(r.Index))
+	if err := proof.Validate(block.DataHash); err != nil {
+		return nil, fmt.Errorf("invalid proof of tx (%X): %w", req.Hash, err)
+	}
+
+	return &coretypes.ResultTxProof{
+		Hash:     req.Hash,
+		Height:   r.Height,
+		Index:    r.Index,
+		Tx:       proof.Data,
+		DataHash: block.DataHash,
+		Proof:    proof.Proof,
+	}, nil
+}
+
+// TxEvents returns the events emitted by a transaction in emission order, along
+// with their index among the events emitted by all the transactions of the
+// block and the composite keys they were indexed under. Both the kv and psql
+// event sinks are supported; the kv sink is preferred when both are enabled.
+// More: https://docs.tendermint.com/master/rpc/#/Info/tx_events
+func (env *Environment) TxEvents(ctx context.Context, req *coretypes.RequestTxEvents) (*coretypes.ResultTxEvents, error) {
+	var sink indexer.EventSink
+	for _, s := range env.EventSinks {
+		if s.Type() == indexer.KV || (s.Type() == indexer.PSQL && sink == nil) {
+			sink = s
+		}
+	}
+	if sink == nil {
+		return nil, errors.New("transaction event querying is disabled due to no kv or psql event sink")
+	}
+
+	r, err := sink.GetTxEvents(req.Hash)
+	if err != nil {
+		return nil, err
+	} else if r == nil {
+		return nil, fmt.Errorf("tx (%X) not found", req.Hash)
+	}
+
+	events := make([]coretypes.TxEvent, len(r.Events))
+	for i, ev := range r.Events {
+		events[i] = coretypes.TxEvent{
+			Index:         ev.Index,
+			Type:          ev.Event.Type,
+			Attributes:    ev.Event.Attributes,
+			CompositeKeys: ev.CompositeKeys,
+		}
+		if events[i].Attributes == nil {
+			events[i].Attributes = []abci.EventAttribute{}
+		}
+		if events[i].CompositeKeys == nil {
+			events[i].CompositeKeys = []string{}
+		}
+	}
+
+	return &coretypes.ResultTxEvents{
+		Hash:   req.Hash,
+		Height: r.Height,
+		Index:  r.Index,
+		Events: events,
+	}, nil
+}
+
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
 // More: https://docs.tendermint.com/master/rpc/#/Info/tx_search
@@ -68,7 +161,8 @@ func (env *Environment) TxSearch(ctx context.Context, req *coretypes.RequestTxSe
 	for _, sink := range env.EventSinks {
 		if sink.Type() == indexer.KV {
 			results, err := sink.SearchTxEvents(ctx, q)
-			if err != nil {
+			truncated := errors.Is(err, indexer.ErrSearchTruncated)
+			if err != nil && !truncated {
 				return nil, err
 			}
 
@@ -124,7 +218,7 @@ func (env *Environment) TxSearch(ctx context.Context, req *coretypes.RequestTxSe
 				})
 			}
 
-			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount, Truncated: truncated}, nil
 		}
 	}
 
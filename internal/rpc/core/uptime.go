@@ -0,0 +1,159 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpc "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// defaultUptimeWindow is the number of recent blocks ValidatorUptime
+	// reports on, unless told otherwise.
+	defaultUptimeWindow = 100
+	// maxUptimeWindow bounds the window of ValidatorUptime, and thus the
+	// number of heights the uptime tracker caches.
+	maxUptimeWindow = 1000
+)
+
+type blockSignStatus uint8
+
+const (
+	blockSignUnknown  blockSignStatus = iota // commit or validator set not found
+	blockSignInactive                        // not in the validator set
+	blockSignSigned                          // voted, possibly for nil
+	blockSignMissed                          // absent from the commit
+)
+
+// uptimeTracker records, for the heights of the recent blocks, whether the
+// local validator signed their commit, so that each query only loads the
+// commits and validator sets of the blocks committed since the previous one.
+type uptimeTracker struct {
+	mtx     sync.Mutex
+	address crypto.Address
+	status  map[int64]blockSignStatus
+}
+
+// ValidatorUptime reports how many of the recent blocks were committed without
+// the vote of the local validator. Blocks committed while the node wasn't a
+// validator are not counted, and the result is not applicable if it wasn't a
+// validator for any of them. Each defaultUptimeWindow blocks of the window
+// count as one call against the rate limit of the method.
+func (env *Environment) ValidatorUptime(ctx context.Context, req *coretypes.RequestValidatorUptime) (*coretypes.ResultValidatorUptime, error) {
+	window := int64(defaultUptimeWindow)
+	if req.Window != nil {
+		window = int64(*req.Window)
+		if window <= 0 || window > maxUptimeWindow {
+			return nil, fmt.Errorf("%w: window must be between 1 and %d, got %d",
+				coretypes.ErrInvalidRequest, maxUptimeWindow, window)
+		}
+	}
+	// The call itself already took one token.
+	cost := int((window+defaultUptimeWindow-1)/defaultUptimeWindow) - 1
+	if err := rpc.TakeRateLimitTokens(ctx, "validator_uptime", cost); err != nil {
+		return nil, err
+	}
+
+	result := &coretypes.ResultValidatorUptime{}
+	if env.PubKey == nil {
+		return result, nil
+	}
+	result.Address = env.PubKey.Address()
+
+	latest := env.BlockStore.Height()
+	if latest == 0 {
+		return result, nil
+	}
+	start := latest - window + 1
+	if base := env.BlockStore.Base(); start < base {
+		start = base
+	}
+	result.WindowStartHeight = start
+	result.WindowEndHeight = latest
+
+	for h, status := range env.uptime.statuses(env, result.Address, start, latest) {
+		switch status {
+		case blockSignSigned:
+			result.SignedBlocks++
+		case blockSignMissed:
+			result.MissedBlocks++
+			if h > result.LastMissedHeight {
+				result.LastMissedHeight = h
+			}
+		default:
+			continue
+		}
+		result.ValidatorBlocks++
+	}
+
+	if result.ValidatorBlocks > 0 {
+		result.Applicable = true
+		result.MissedRate = float64(result.MissedBlocks) / float64(result.ValidatorBlocks)
+	}
+	return result, nil
+}
+
+// statuses returns the sign status of address for the heights start to end.
+// Cached heights below start are evicted.
+func (t *uptimeTracker) statuses(env *Environment, address crypto.Address, start, end int64) map[int64]blockSignStatus {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.status == nil || !bytes.Equal(address, t.address) {
+		t.address = address
+		t.status = make(map[int64]blockSignStatus)
+	}
+	for h := range t.status {
+		if h < start {
+			delete(t.status, h)
+		}
+	}
+
+	out := make(map[int64]blockSignStatus, end-start+1)
+	for h := start; h <= end; h++ {
+		status, ok := t.status[h]
+		if !ok {
+			status = env.blockSignStatus(address, h, h == end)
+			// The commit of the latest block may still gain votes, and a
+			// missing commit or validator set may be found later on.
+			if h != end && status != blockSignUnknown {
+				t.status[h] = status
+			}
+		}
+		out[h] = status
+	}
+	return out
+}
+
+// blockSignStatus looks up whether address signed the commit of the block at
+// height h. The commit of the latest block is the one seen by the node, as the
+// canonical one is only stored with the next block.
+func (env *Environment) blockSignStatus(address crypto.Address, h int64, latest bool) blockSignStatus {
+	vals, err := env.StateStore.LoadValidators(h)
+	if err != nil {
+		return blockSignUnknown
+	}
+	idx, _ := vals.GetByAddress(address)
+	if idx < 0 {
+		return blockSignInactive
+	}
+
+	var commit *types.Commit
+	if latest {
+		commit = env.BlockStore.LoadSeenCommit()
+	} else {
+		commit = env.BlockStore.LoadBlockCommit(h)
+	}
+	if commit == nil || commit.Height != h || int(idx) >= len(commit.Signatures) {
+		return blockSignUnknown
+	}
+	if commit.Signatures[idx].BlockIDFlag == types.BlockIDFlagAbsent {
+		return blockSignMissed
+	}
+	return blockSignSigned
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestValidatorUptime(t *testing.T) {
+	const (
+		latest = 20
+		joined = 5 // the local validator is in the validator set from then on
+	)
+	missed := map[int64]bool{8: true, 12: true, latest: true}
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	other := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	withUs := types.NewValidatorSet([]*types.Validator{other, types.NewValidator(pubKey, 10)})
+	withoutUs := types.NewValidatorSet([]*types.Validator{other})
+
+	commit := func(h int64) *types.Commit {
+		vals := withoutUs
+		if h >= joined {
+			vals = withUs
+		}
+		sigs := make([]types.CommitSig, vals.Size())
+		for i, val := range vals.Validators {
+			if val.Address.String() == pubKey.Address().String() && missed[h] {
+				sigs[i] = types.NewCommitSigAbsent()
+				continue
+			}
+			sigs[i] = types.CommitSig{BlockIDFlag: types.BlockIDFlagCommit, ValidatorAddress: val.Address}
+		}
+		return &types.Commit{Height: h, Signatures: sigs}
+	}
+
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(
+		func(h int64) *types.ValidatorSet {
+			if h >= joined {
+				return withUs
+			}
+			return withoutUs
+		},
+		func(h int64) error {
+			if h > latest {
+				return errors.New("no validators")
+			}
+			return nil
+		},
+	)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(latest))
+	blockStore.On("LoadBlockCommit", mock.AnythingOfType("int64")).Return(commit)
+	blockStore.On("LoadSeenCommit").Return(commit(latest))
+
+	env := &Environment{StateStore: stateStore, BlockStore: blockStore, PubKey: pubKey}
+	ctx := context.Background()
+
+	res, err := env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{})
+	require.NoError(t, err)
+	assert.True(t, res.Applicable)
+	assert.Equal(t, pubKey.Address(), res.Address)
+	assert.EqualValues(t, 1, res.WindowStartHeight)
+	assert.EqualValues(t, latest, res.WindowEndHeight)
+	assert.EqualValues(t, latest-joined+1, res.ValidatorBlocks)
+	assert.EqualValues(t, 3, res.MissedBlocks)
+	assert.EqualValues(t, latest-joined+1-3, res.SignedBlocks)
+	assert.EqualValues(t, latest, res.LastMissedHeight)
+	assert.InDelta(t, 3.0/16, res.MissedRate, 1e-9)
+
+	// Blocks before the window are not counted, and cached statuses are
+	// reused.
+	window := coretypes.Int64(10)
+	res, err = env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{Window: &window})
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, res.WindowStartHeight)
+	assert.EqualValues(t, 10, res.ValidatorBlocks)
+	assert.EqualValues(t, 2, res.MissedBlocks)
+	blockStore.AssertNumberOfCalls(t, "LoadBlockCommit", latest-joined)
+
+	// The node was not a validator in the window.
+	window = coretypes.Int64(latest - joined + 1)
+	env.uptime = uptimeTracker{}
+	blockStore.On("Height").Unset()
+	blockStore.On("Height").Return(int64(joined - 1))
+	res, err = env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{Window: &window})
+	require.NoError(t, err)
+	assert.False(t, res.Applicable)
+	assert.Zero(t, res.ValidatorBlocks)
+
+	// The node is not a validator at all.
+	res, err = (&Environment{BlockStore: blockStore}).ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{})
+	require.NoError(t, err)
+	assert.False(t, res.Applicable)
+	assert.Nil(t, res.Address)
+
+	window = coretypes.Int64(maxUptimeWindow + 1)
+	_, err = env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{Window: &window})
+	require.ErrorIs(t, err, coretypes.ErrInvalidRequest)
+}
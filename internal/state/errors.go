@@ -26,6 +26,16 @@ type (
 		StoreBase int64
 	}
 
+	ErrAppVersionMismatch struct {
+		ParamsVersion uint64
+		AppVersion    uint64
+	}
+
+	ErrStoreHeightsDiverged struct {
+		StateHeight int64
+		StoreHeight int64
+	}
+
 	ErrLastStateMismatch struct {
 		Height int64
 		Core   []byte
@@ -44,6 +54,7 @@ type (
 
 	ErrNoConsensusParamsForHeight struct {
 		Height int64
+		Err    error
 	}
 
 	ErrNoFinalizeBlockResponsesForHeight struct {
@@ -72,6 +83,22 @@ func (e ErrAppBlockHeightTooLow) Error() string {
 	return fmt.Sprintf("app block height (%d) is too far below block store base (%d)", e.AppHeight, e.StoreBase)
 }
 
+func (e ErrAppVersionMismatch) Error() string {
+	return fmt.Sprintf("app version (%d) does not match the app version of the consensus params (%d): if the "+
+		"app was upgraded intentionally, restart with allow-app-version-upgrade set", e.AppVersion, e.ParamsVersion)
+}
+
+func (e ErrStoreHeightsDiverged) Error() string {
+	if e.StoreHeight < e.StateHeight {
+		return fmt.Sprintf("block store height (%d) is below state height (%d): the blocks the state was built from "+
+			"are missing, restore the block store from a backup taken along with the state store",
+			e.StoreHeight, e.StateHeight)
+	}
+	return fmt.Sprintf("block store height (%d) is more than one above state height (%d): the blocks above the "+
+		"state height were never applied to it, remove them with `tendermint rollback --hard`",
+		e.StoreHeight, e.StateHeight)
+}
+
 func (e ErrLastStateMismatch) Error() string {
 	return fmt.Sprintf(
 		"latest tendermint block (%d) LastAppHash (%X) does not match app's AppHash (%X)",
@@ -99,9 +126,14 @@ func (e ErrNoValSetForHeight) Error() string {
 func (e ErrNoValSetForHeight) Unwrap() error { return e.Err }
 
 func (e ErrNoConsensusParamsForHeight) Error() string {
-	return fmt.Sprintf("could not find consensus params for height #%d", e.Height)
+	if e.Err == nil {
+		return fmt.Sprintf("could not find consensus params for height #%d", e.Height)
+	}
+	return fmt.Sprintf("could not find consensus params for height #%d: %s", e.Height, e.Err.Error())
 }
 
+func (e ErrNoConsensusParamsForHeight) Unwrap() error { return e.Err }
+
 func (e ErrNoFinalizeBlockResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find FinalizeBlock responses for height #%d", e.Height)
 }
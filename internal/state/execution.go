@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
+	"sync"
 	"time"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/eventbus"
@@ -47,8 +50,63 @@ type BlockExecutor struct {
 	logger  log.Logger
 	metrics *Metrics
 
+	// order proposed transactions by a seed instead of mempool order
+	seededTxOrder bool
+
+	// application defined block checks, may be nil
+	validateBlockExtension ValidateBlockExtension
+
 	// cache the verification results over a single height
 	cache map[string]struct{}
+
+	hooksMtx            sync.RWMutex
+	validatorSetChanged []ValidatorSetChangeHook
+
+	// time spent in the ABCI calls for the height being executed, and for
+	// the last committed block
+	timingsMtx     sync.Mutex
+	pendingTimings ABCITimings
+	lastTimings    ABCITimings
+}
+
+// ABCITimings is the time spent in each ABCI call made for a block, which
+// tells whether a slow block was caused by the application or by consensus.
+// The proposal calls add up every round of the height: PrepareProposal is zero
+// unless the node proposed, and ProcessProposal is zero if the block wasn't
+// received as a proposal, e.g. during block sync.
+type ABCITimings struct {
+	Height          int64
+	PrepareProposal time.Duration
+	ProcessProposal time.Duration
+	FinalizeBlock   time.Duration
+	Commit          time.Duration
+}
+
+// ValidatorSetChangeHook is called when the active validator set changes.
+// height is the first height at which newVals is active, and oldVals is the
+// set that was active at the previous height. The validator sets are copies and
+// may be retained by the hook.
+type ValidatorSetChangeHook func(height int64, oldVals, newVals *types.ValidatorSet)
+
+// BlockExecutorOption sets an optional parameter on the BlockExecutor.
+type BlockExecutorOption func(*BlockExecutor)
+
+// WithSeededTxOrder makes the proposer order the transactions reaped from the
+// mempool deterministically, using the hash of the previous block as a seed,
+// instead of in mempool priority order. The same set of transactions is
+// reaped, so the block size and gas limits are unaffected.
+func WithSeededTxOrder() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.seededTxOrder = true
+	}
+}
+
+// WithValidateBlockExtension makes the executor reject the blocks for which
+// ext returns an error, see ValidateBlockExtension.
+func WithValidateBlockExtension(ext ValidateBlockExtension) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.validateBlockExtension = ext
+	}
 }
 
 // NewBlockExecutor returns a new BlockExecutor with the passed-in EventBus.
@@ -61,8 +119,9 @@ func NewBlockExecutor(
 	blockStore BlockStore,
 	eventBus *eventbus.EventBus,
 	metrics *Metrics,
+	options ...BlockExecutorOption,
 ) *BlockExecutor {
-	return &BlockExecutor{
+	blockExec := &BlockExecutor{
 		eventBus:   eventBus,
 		store:      stateStore,
 		appClient:  appClient,
@@ -73,12 +132,79 @@ func NewBlockExecutor(
 		cache:      make(map[string]struct{}),
 		blockStore: blockStore,
 	}
+	for _, option := range options {
+		option(blockExec)
+	}
+	return blockExec
 }
 
 func (blockExec *BlockExecutor) Store() Store {
 	return blockExec.store
 }
 
+// LastABCITimings returns the time spent in the ABCI calls for the last block
+// committed by the executor, and false if no block was committed yet.
+func (blockExec *BlockExecutor) LastABCITimings() (ABCITimings, bool) {
+	blockExec.timingsMtx.Lock()
+	defer blockExec.timingsMtx.Unlock()
+	return blockExec.lastTimings, blockExec.lastTimings.Height != 0
+}
+
+// addABCITiming adds elapsed to the timing selected by field for the block at
+// height. Adding the Commit timing completes the timings of the block.
+func (blockExec *BlockExecutor) addABCITiming(height int64, field func(*ABCITimings) *time.Duration, elapsed time.Duration) {
+	blockExec.timingsMtx.Lock()
+	defer blockExec.timingsMtx.Unlock()
+
+	if blockExec.pendingTimings.Height != height {
+		blockExec.pendingTimings = ABCITimings{Height: height}
+	}
+	*field(&blockExec.pendingTimings) += elapsed
+}
+
+// completeABCITimings makes the timings of the block at height, which was just
+// committed, the last ones.
+func (blockExec *BlockExecutor) completeABCITimings(height int64, commit time.Duration) {
+	blockExec.addABCITiming(height, func(t *ABCITimings) *time.Duration { return &t.Commit }, commit)
+
+	blockExec.timingsMtx.Lock()
+	defer blockExec.timingsMtx.Unlock()
+	blockExec.lastTimings = blockExec.pendingTimings
+	blockExec.pendingTimings = ABCITimings{}
+}
+
+// RegisterValidatorSetChangeHook registers hook to be called whenever applying
+// a block changes the active validator set. Validator updates returned by the
+// application at height H take effect at height H+2, so the hook is called
+// once, while applying block H, with height H+2 and the validator sets of
+// heights H+1 and H+2. Updates that leave the set unchanged, e.g. setting a
+// validator's power to its current value, don't call the hook.
+//
+// Hooks are called synchronously, after the state is saved and the block's
+// events are fired, and must not block. As with events, a hook may not be
+// called for a block applied right before a crash.
+func (blockExec *BlockExecutor) RegisterValidatorSetChangeHook(hook ValidatorSetChangeHook) {
+	blockExec.hooksMtx.Lock()
+	defer blockExec.hooksMtx.Unlock()
+	blockExec.validatorSetChanged = append(blockExec.validatorSetChanged, hook)
+}
+
+// notifyValidatorSetChange calls the validator set change hooks if applying a
+// block changed the next validator set, which becomes active two heights
+// after the block.
+func (blockExec *BlockExecutor) notifyValidatorSetChange(prevState, state State) {
+	if bytes.Equal(prevState.NextValidators.Hash(), state.NextValidators.Hash()) {
+		return
+	}
+
+	height := state.LastBlockHeight + 2
+	blockExec.hooksMtx.RLock()
+	defer blockExec.hooksMtx.RUnlock()
+	for _, hook := range blockExec.validatorSetChanged {
+		hook(height, prevState.NextValidators.Copy(), state.NextValidators.Copy())
+	}
+}
+
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
@@ -102,8 +228,18 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	if blockExec.seededTxOrder {
+		txs = orderTxsBySeed(txs, state.LastBlockID.Hash)
+	}
 	commit := lastExtCommit.ToCommit()
+	if state.ConsensusParams.Validator.AggregateCommitsEnabled(commit.Height) {
+		var err error
+		if commit, err = commit.Aggregate(state.LastValidators); err != nil {
+			return nil, fmt.Errorf("aggregating the last commit: %w", err)
+		}
+	}
 	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	start := time.Now()
 	rpp, err := blockExec.appClient.PrepareProposal(
 		ctx,
 		&abci.RequestPrepareProposal{
@@ -127,6 +263,7 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 			LastResultsHash:       block.LastResultsHash,
 		},
 	)
+	blockExec.addABCITiming(height, func(t *ABCITimings) *time.Duration { return &t.PrepareProposal }, time.Since(start))
 	if err != nil {
 		// The App MUST ensure that only valid (and hence 'processable') transactions
 		// enter the mempool. Hence, at this point, we can't have any non-processable
@@ -163,6 +300,7 @@ func (blockExec *BlockExecutor) ProcessProposal(
 	state State,
 ) (bool, error) {
 	txs := block.Data.Txs.ToSliceOfBytes()
+	start := time.Now()
 	resp, err := blockExec.appClient.ProcessProposal(ctx, &abci.RequestProcessProposal{
 		Hash:                  block.Header.Hash(),
 		Height:                block.Header.Height,
@@ -183,6 +321,7 @@ func (blockExec *BlockExecutor) ProcessProposal(
 		LastCommitHash:        block.LastCommitHash,
 		LastResultsHash:       block.LastResultsHash,
 	})
+	blockExec.addABCITiming(block.Height, func(t *ABCITimings) *time.Duration { return &t.ProcessProposal }, time.Since(start))
 	if err != nil {
 		return false, ErrInvalidBlock(err)
 	}
@@ -198,12 +337,28 @@ func (blockExec *BlockExecutor) ProcessProposal(
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
 func (blockExec *BlockExecutor) ValidateBlock(ctx context.Context, state State, block *types.Block) error {
+	return blockExec.validateBlock(ctx, state, block, true)
+}
+
+// ValidateBlockTrusted validates the given block against the given state like
+// ValidateBlock, except that the signatures of its LastCommit are not
+// verified: only the structure of the commit is checked. ApplyBlock then
+// accepts the block without verifying them either.
+//
+// This is NOT safe for blocks from an untrusted source, since anyone can
+// forge a commit which passes these checks. It is only used by block sync
+// below the configured trusted height, see BlockSyncConfig.TrustedHeight.
+func (blockExec *BlockExecutor) ValidateBlockTrusted(ctx context.Context, state State, block *types.Block) error {
+	return blockExec.validateBlock(ctx, state, block, false)
+}
+
+func (blockExec *BlockExecutor) validateBlock(ctx context.Context, state State, block *types.Block, verifyLastCommit bool) error {
 	hash := block.Hash()
 	if _, ok := blockExec.cache[hash.String()]; ok {
 		return nil
 	}
 
-	err := validateBlock(state, block)
+	err := validateBlock(state, block, blockExec.validateBlockExtension, verifyLastCommit)
 	if err != nil {
 		return err
 	}
@@ -246,6 +401,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		defer finalizeBlockSpan.End()
 	}
 	txs := block.Data.Txs.ToSliceOfBytes()
+	finalizeStart := time.Now()
 	fBlockRes, err := blockExec.appClient.FinalizeBlock(
 		ctx,
 		&abci.RequestFinalizeBlock{
@@ -269,6 +425,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 			LastResultsHash:       block.LastResultsHash,
 		},
 	)
+	blockExec.addABCITiming(block.Height, func(t *ABCITimings) *time.Duration { return &t.FinalizeBlock }, time.Since(finalizeStart))
 	if finalizeBlockSpan != nil {
 		finalizeBlockSpan.End()
 	}
@@ -317,6 +474,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, fmt.Errorf("marshaling TxResults: %w", err)
 	}
 	h := merkle.HashFromByteSlices(rs)
+	prevState := state
 	state, err = state.Update(blockID, &block.Header, h, fBlockRes.ConsensusParamUpdates, validatorUpdates)
 	if err != nil {
 		return state, fmt.Errorf("commit failed for application: %w", err)
@@ -361,6 +519,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
 	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, fBlockRes, validatorUpdates)
+	blockExec.notifyValidatorSetChange(prevState, state)
 
 	return state, nil
 }
@@ -426,7 +585,9 @@ func (blockExec *BlockExecutor) Commit(
 		blockExec.logger.Error("client error during proxyAppConn.Commit", "err", err)
 		return 0, err
 	}
-	blockExec.metrics.ApplicationCommitTime.Observe(float64(time.Since(start)))
+	commitTime := time.Since(start)
+	blockExec.metrics.ApplicationCommitTime.Observe(float64(commitTime))
+	blockExec.completeABCITimings(block.Height, commitTime)
 
 	// ResponseCommit has no error code - just data
 	blockExec.logger.Info(
@@ -805,7 +966,7 @@ func ExecCommitBlock(
 			return nil, err
 		}
 
-		bps, err := block.MakePartSet(types.BlockPartSizeBytes)
+		bps, err := block.MakePartSet(s.ConsensusParams.Block.PartSize())
 		if err != nil {
 			return nil, err
 		}
@@ -841,3 +1002,20 @@ func (blockExec *BlockExecutor) pruneBlocks(retainHeight int64) (uint64, error)
 	}
 	return pruned, nil
 }
+
+// orderTxsBySeed returns the transactions sorted by the hash of the seed and
+// the transaction hash. The order only depends on the seed and the set of
+// transactions, not on their order in txs.
+func orderTxsBySeed(txs types.Txs, seed []byte) types.Txs {
+	keys := make(map[string][]byte, len(txs))
+	for _, tx := range txs {
+		keys[string(tx)] = crypto.Checksum(append(append([]byte{}, seed...), tx.Hash()...))
+	}
+
+	ordered := make(types.Txs, len(txs))
+	copy(ordered, txs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return bytes.Compare(keys[string(ordered[i])], keys[string(ordered[j])]) < 0
+	})
+	return ordered
+}
@@ -74,11 +74,25 @@ func TestApplyBlock(t *testing.T) {
 	require.NoError(t, err)
 	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
 
+	_, ok := blockExec.LastABCITimings()
+	require.False(t, ok)
+
+	accepted, err := blockExec.ProcessProposal(ctx, block, state)
+	require.NoError(t, err)
+	require.True(t, accepted)
 	state, err = blockExec.ApplyBlock(ctx, state, blockID, block, nil)
 	require.NoError(t, err)
 
 	// TODO check state and mempool
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
+
+	timings, ok := blockExec.LastABCITimings()
+	require.True(t, ok)
+	assert.EqualValues(t, 1, timings.Height)
+	assert.Zero(t, timings.PrepareProposal)
+	assert.Positive(t, timings.ProcessProposal)
+	assert.Positive(t, timings.FinalizeBlock)
+	assert.Positive(t, timings.Commit)
 }
 
 // TestFinalizeBlockDecidedLastCommit ensures we correctly send the
@@ -589,6 +603,74 @@ func TestFinalizeBlockValidatorUpdates(t *testing.T) {
 	}
 }
 
+func TestValidatorSetChangeHook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &testApp{}
+	logger := log.NewNopLogger()
+	cc := abciclient.NewLocalClient(logger, app)
+	proxyApp := proxy.New(cc, logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, privVals := makeState(t, 1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	mp.On("TxStore").Return(nil)
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp, mp, sm.EmptyEvidencePool{}, blockStore, eventBus, sm.NopMetrics())
+
+	type change struct {
+		height           int64
+		oldVals, newVals *types.ValidatorSet
+	}
+	var changes []change
+	blockExec.RegisterValidatorSetChangeHook(func(height int64, oldVals, newVals *types.ValidatorSet) {
+		changes = append(changes, change{height, oldVals, newVals})
+	})
+
+	val := state.Validators.Validators[0]
+	pk, err := encoding.PubKeyToProto(val.PubKey)
+	require.NoError(t, err)
+	updates := map[int64][]abci.ValidatorUpdate{
+		1: {{PubKey: pk, Power: val.VotingPower + 10}},
+		2: {{PubKey: pk, Power: val.VotingPower + 20}},
+		4: {{PubKey: pk, Power: val.VotingPower + 20}}, // does not change the set
+	}
+
+	lastCommit := new(types.Commit)
+	for height := int64(1); height <= 6; height++ {
+		app.ValidatorUpdates = updates[height]
+		var extCommit *types.ExtendedCommit
+		state, _, extCommit = makeAndCommitGoodBlock(ctx, t, state, height, lastCommit, val.Address, blockExec, privVals, nil)
+		lastCommit = extCommit.ToCommit()
+	}
+
+	// The updates returned at heights 1 and 2 take effect at heights 3 and 4.
+	require.Len(t, changes, 2)
+	assert.EqualValues(t, 3, changes[0].height)
+	assert.Equal(t, val.VotingPower, changes[0].oldVals.TotalVotingPower())
+	assert.Equal(t, val.VotingPower+10, changes[0].newVals.TotalVotingPower())
+	assert.EqualValues(t, 4, changes[1].height)
+	assert.Equal(t, val.VotingPower+10, changes[1].oldVals.TotalVotingPower())
+	assert.Equal(t, val.VotingPower+20, changes[1].newVals.TotalVotingPower())
+}
+
 // TestFinalizeBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
 // would result in empty set causes no panic, an error is raised and NextValidators is not updated
 func TestFinalizeBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
@@ -804,6 +886,73 @@ func TestPrepareProposalReorderTxs(t *testing.T) {
 
 }
 
+// TestPrepareProposalSeededTxOrder tests that, with WithSeededTxOrder, the
+// transactions passed to PrepareProposal are in the same order regardless of
+// the order in which the mempool returns them.
+func TestPrepareProposalSeededTxOrder(t *testing.T) {
+	const height = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	state, stateDB, privVals := makeState(t, 1, height)
+	stateStore := sm.NewStore(stateDB)
+
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return([]types.Evidence{}, int64(0))
+
+	txs := types.Txs(factory.MakeNTxs(height, 10))
+	reversed := make(types.Txs, len(txs))
+	for i, tx := range txs {
+		reversed[len(txs)-1-i] = tx
+	}
+	mp := &mpmocks.Mempool{}
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(txs).Once()
+	mp.On("ReapMaxBytesMaxGas", mock.Anything, mock.Anything).Return(reversed).Once()
+
+	app := abcimocks.NewApplication(t)
+	app.On("PrepareProposal", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *abci.RequestPrepareProposal) *abci.ResponsePrepareProposal {
+			trs := make([]*abci.TxRecord, len(req.Txs))
+			for i, tx := range req.Txs {
+				trs[i] = &abci.TxRecord{Action: abci.TxRecord_UNMODIFIED, Tx: tx}
+			}
+			return &abci.ResponsePrepareProposal{TxRecords: trs}
+		}, nil)
+
+	cc := abciclient.NewLocalClient(logger, app)
+	proxyApp := proxy.New(cc, logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		logger,
+		proxyApp,
+		mp,
+		evpool,
+		nil,
+		eventBus,
+		sm.NopMetrics(),
+		sm.WithSeededTxOrder(),
+	)
+	pa, _ := state.Validators.GetByIndex(0)
+	commit, _ := makeValidCommit(ctx, t, height, types.BlockID{}, state.Validators, privVals)
+	first, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+	second, err := blockExec.CreateProposalBlock(ctx, height, state, commit, pa)
+	require.NoError(t, err)
+
+	require.Len(t, first.Data.Txs, len(txs))
+	require.Equal(t, first.Data.Txs, second.Data.Txs)
+	require.NotEqual(t, txs, first.Data.Txs)
+	require.NotEqual(t, reversed, first.Data.Txs)
+
+	mp.AssertExpectations(t)
+}
+
 // TestPrepareProposalErrorOnTooManyTxs tests that the block creation logic returns
 // an error if the ResponsePrepareProposal returned from the application is invalid.
 func TestPrepareProposalErrorOnTooManyTxs(t *testing.T) {
@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/google/orderedcode"
@@ -45,10 +45,33 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 	return idx.store.Has(key)
 }
 
+// HeightByAppHash returns the height of the block whose execution produced the
+// given app hash, or 0 if no such block has been indexed. If several blocks
+// produced the same app hash, e.g. because they did not change the application
+// state, the lowest indexed height is returned.
+func (idx *BlockerIndexer) HeightByAppHash(appHash []byte) (int64, error) {
+	if len(appHash) == 0 {
+		return 0, errors.New("app hash cannot be empty")
+	}
+
+	key, err := appHashKey(appHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create block app hash index key: %w", err)
+	}
+
+	bz, err := idx.store.Get(key)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	return int64FromBytes(bz), nil
+}
+
 // Index indexes FinalizeBlock events for a given block by its height.
 // The following is indexed:
 //
 // primary key: encode(block.height | height) => encode(height)
+// app hash: encode(block.app_hash | appHash) => encode(height)
+// proposer: encode(block.proposer|proposerAddress|height|block) => encode(height)
 // FinalizeBlock events: encode(eventType.eventAttr|eventValue|height|finalize_block) => encode(height)
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	batch := idx.store.NewBatch()
@@ -65,7 +88,17 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 		return err
 	}
 
-	// 2. index FinalizeBlock events
+	// 2. index by the app hash produced by the block
+	if err := idx.indexAppHash(batch, bh.ResultFinalizeBlock.AppHash, height); err != nil {
+		return fmt.Errorf("failed to index block app hash: %w", err)
+	}
+
+	// 3. index by proposer
+	if err := idx.indexProposer(batch, bh.Header.ProposerAddress, height); err != nil {
+		return fmt.Errorf("failed to index block proposer: %w", err)
+	}
+
+	// 4. index FinalizeBlock events
 	if err := idx.indexEvents(batch, bh.ResultFinalizeBlock.Events, "finalize_block", height); err != nil {
 		return fmt.Errorf("failed to index FinalizeBlock events: %w", err)
 	}
@@ -151,7 +184,13 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 			continue
 		}
 
-		startKey, err := orderedcode.Append(nil, c.Tag, c.Arg.Value())
+		value := c.Arg.Value()
+		if c.Tag == types.BlockProposerKey {
+			// Proposer addresses are indexed as uppercase hex.
+			value = strings.ToUpper(value)
+		}
+
+		startKey, err := orderedcode.Append(nil, c.Tag, value)
 		if err != nil {
 			return nil, err
 		}
@@ -204,6 +243,82 @@ heights:
 	return results, nil
 }
 
+// SortHeights sorts heights by the numeric value of the FinalizeBlock event
+// attribute with the given composite key, e.g. "rewards.amount", at each
+// height, in descending order if desc is true. If the attribute has several
+// values at a height, the lowest one is used in ascending order and the
+// highest one in descending order. Heights with equal values are ordered by
+// height in the same direction, and heights without the attribute come last,
+// so that the order is total and the results can be paginated. An error is
+// returned if the attribute has a non-numeric value at one of the heights.
+func (idx *BlockerIndexer) SortHeights(ctx context.Context, heights []int64, key string, desc bool) error {
+	switch key {
+	case types.BlockHeightKey, types.BlockProposerKey, blockAppHashKey, types.EventTypeKey:
+		return fmt.Errorf("cannot sort by %s, which is not an event attribute", key)
+	}
+
+	values := make(map[int64]*big.Rat, len(heights))
+	for _, h := range heights {
+		values[h] = nil
+	}
+
+	prefix, err := orderedcode.Append(nil, key)
+	if err != nil {
+		return fmt.Errorf("failed to create prefix key: %w", err)
+	}
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to create prefix iterator: %w", err)
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		var (
+			compositeKey, typ, eventValue string
+			height                        int64
+		)
+		if _, err := orderedcode.Parse(string(it.Key()), &compositeKey, &eventValue, &height, &typ); err != nil {
+			continue
+		}
+		prev, ok := values[height]
+		if !ok {
+			continue
+		}
+
+		v, ok := new(big.Rat).SetString(eventValue)
+		if !ok {
+			return fmt.Errorf("cannot sort by %s: value %q at height %d is not numeric", key, eventValue, height)
+		}
+		if prev == nil || (v.Cmp(prev) < 0) != desc {
+			values[height] = v
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		vi, vj := values[heights[i]], values[heights[j]]
+		switch {
+		case vi == nil && vj == nil:
+		case vi == nil || vj == nil:
+			return vj == nil
+		default:
+			if c := vi.Cmp(vj); c != 0 {
+				return (c < 0) != desc
+			}
+		}
+		return (heights[i] < heights[j]) != desc
+	})
+	return nil
+}
+
 // matchRange returns all matching block heights that match a given QueryRange
 // and start key. An already filtered result (filteredHeights) is provided such
 // that any non-intersecting matches are removed.
@@ -225,8 +340,6 @@ func (idx *BlockerIndexer) matchRange(
 	}
 
 	tmpHeights := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(idx.store, startKey)
 	if err != nil {
@@ -238,37 +351,22 @@ iter:
 	for ; it.Valid(); it.Next() {
 		var (
 			eventValue string
+			attrType   abci.EventAttributeType
 			err        error
 		)
 
 		if qr.Key == types.BlockHeightKey {
 			eventValue, err = parseValueFromPrimaryKey(it.Key())
 		} else {
-			eventValue, err = parseValueFromEventKey(it.Key())
+			eventValue, attrType, err = parseValueFromEventKey(it.Key())
 		}
 
 		if err != nil {
 			continue
 		}
 
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(eventValue, 10, 64)
-			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHeights[string(it.Value())] = it.Value()
-			}
+		if qr.ContainsValue(eventValue, attrType) {
+			tmpHeights[string(it.Value())] = it.Value()
 		}
 
 		select {
@@ -396,7 +494,7 @@ func (idx *BlockerIndexer) match(
 
 	iterContains:
 		for ; it.Valid(); it.Next() {
-			eventValue, err := parseValueFromEventKey(it.Key())
+			eventValue, _, err := parseValueFromEventKey(it.Key())
 			if err != nil {
 				continue
 			}
@@ -430,7 +528,7 @@ func (idx *BlockerIndexer) match(
 
 	iterMatches:
 		for ; it.Valid(); it.Next() {
-			eventValue, err := parseValueFromEventKey(it.Key())
+			eventValue, _, err := parseValueFromEventKey(it.Key())
 			if err != nil {
 				continue
 			}
@@ -483,6 +581,49 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
+// indexAppHash maps appHash to height, unless it is empty or already mapped to
+// a lower height. App hashes repeat when blocks leave the application state
+// unchanged, in which case the first block to produce the app hash is kept.
+func (idx *BlockerIndexer) indexAppHash(batch dbm.Batch, appHash []byte, height int64) error {
+	if len(appHash) == 0 {
+		return nil
+	}
+
+	key, err := appHashKey(appHash)
+	if err != nil {
+		return err
+	}
+
+	bz, err := idx.store.Get(key)
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		if existing := int64FromBytes(bz); existing > 0 && existing <= height {
+			return nil
+		}
+	}
+
+	return batch.Set(key, int64ToBytes(height))
+}
+
+// indexProposer indexes the block at height under the address of its proposer,
+// so that it matches queries like "block.proposer = 'ADDR'". The block at the
+// initial height is proposed by the genesis validator set's proposer and is
+// indexed like any other; a header without a proposer address, which consensus
+// never produces, is not indexed by proposer.
+func (idx *BlockerIndexer) indexProposer(batch dbm.Batch, proposer types.Address, height int64) error {
+	if len(proposer) == 0 {
+		return nil
+	}
+
+	key, err := eventKey(types.BlockProposerKey, "block", proposer.String(), height, abci.EventAttributeType_UNSPECIFIED)
+	if err != nil {
+		return err
+	}
+	return batch.Set(key, int64ToBytes(height))
+}
+
 func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
 	heightBz := int64ToBytes(height)
 
@@ -499,12 +640,12 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 
 			// index iff the event specified index:true and it's not a reserved event
 			compositeKey := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
-			if compositeKey == types.BlockHeightKey {
+			if compositeKey == types.BlockHeightKey || compositeKey == types.BlockProposerKey {
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
 			}
 
 			if attr.GetIndex() {
-				key, err := eventKey(compositeKey, typ, string(attr.Value), height)
+				key, err := eventKey(compositeKey, typ, string(attr.Value), height, attr.Type)
 				if err != nil {
 					return fmt.Errorf("failed to create block index key: %w", err)
 				}
@@ -142,3 +142,150 @@ func TestBlockIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerHeightByAppHash(t *testing.T) {
+	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	appHashes := map[int64][]byte{
+		1: []byte("apphash1"),
+		2: []byte("apphash2"),
+		3: []byte("apphash2"), // block 3 did not change the app state
+		4: nil,
+		5: []byte("apphash5"),
+	}
+	// Index out of order, as a reindex of part of the chain would.
+	for _, height := range []int64{2, 1, 5, 4, 3} {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header:              types.Header{Height: height},
+			ResultFinalizeBlock: abci.ResponseFinalizeBlock{AppHash: appHashes[height]},
+		}))
+	}
+
+	testCases := map[string]struct {
+		appHash []byte
+		height  int64
+	}{
+		"unique app hash":    {[]byte("apphash1"), 1},
+		"repeated app hash":  {[]byte("apphash2"), 2},
+		"last app hash":      {[]byte("apphash5"), 5},
+		"unindexed app hash": {[]byte("apphash4"), 0},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			height, err := indexer.HeightByAppHash(tc.appHash)
+			require.NoError(t, err)
+			require.Equal(t, tc.height, height)
+		})
+	}
+
+	_, err := indexer.HeightByAppHash(nil)
+	require.Error(t, err)
+}
+
+func TestBlockIndexerProposer(t *testing.T) {
+	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	proposerA := types.Address([]byte("proposer-address-aaaa"))
+	proposerB := types.Address([]byte("proposer-address-bbbb"))
+	proposers := map[int64]types.Address{
+		1: proposerA, // the initial height is proposed like any other block
+		2: proposerB,
+		3: proposerA,
+		4: nil,
+	}
+	for height, proposer := range proposers {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height, ProposerAddress: proposer},
+		}))
+	}
+
+	testCases := map[string]struct {
+		q       *query.Query
+		results []int64
+	}{
+		"proposer A": {
+			q:       query.MustCompile(fmt.Sprintf(`block.proposer = '%s'`, proposerA)),
+			results: []int64{1, 3},
+		},
+		"proposer B lowercase": {
+			q:       query.MustCompile(fmt.Sprintf(`block.proposer = '%x'`, []byte(proposerB))),
+			results: []int64{2},
+		},
+		"unknown proposer": {
+			q:       query.MustCompile(`block.proposer = 'FF'`),
+			results: []int64{},
+		},
+		"proposer A with height range": {
+			q:       query.MustCompile(fmt.Sprintf(`block.proposer = '%s' AND block.height > 1`, proposerA)),
+			results: []int64{3},
+		},
+		"proposer exists": {
+			q:       query.MustCompile(`block.proposer EXISTS`),
+			results: []int64{1, 2, 3},
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), tc.q)
+			require.NoError(t, err)
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestBlockIndexerSortHeights(t *testing.T) {
+	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	// the reward amounts by height; height 5 has none
+	amounts := map[int64][]string{
+		1: {"30"},
+		2: {"100000000000000000000001"},
+		3: {"2.5", "7"},
+		4: {"30"},
+		6: {"100000000000000000000000"},
+	}
+	for h := int64(1); h <= 6; h++ {
+		var events []abci.Event
+		for _, amount := range amounts[h] {
+			events = append(events, abci.Event{
+				Type:       "rewards",
+				Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte(amount), Index: true}},
+			})
+		}
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header:              types.Header{Height: h},
+			ResultFinalizeBlock: abci.ResponseFinalizeBlock{Events: events},
+		}))
+	}
+	ctx := context.Background()
+
+	heights := []int64{1, 2, 3, 4, 5, 6}
+	require.NoError(t, indexer.SortHeights(ctx, heights, "rewards.amount", false))
+	require.Equal(t, []int64{3, 1, 4, 6, 2, 5}, heights)
+
+	require.NoError(t, indexer.SortHeights(ctx, heights, "rewards.amount", true))
+	require.Equal(t, []int64{2, 6, 4, 1, 3, 5}, heights)
+
+	// only the given heights are sorted
+	heights = []int64{4, 5, 1}
+	require.NoError(t, indexer.SortHeights(ctx, heights, "rewards.amount", false))
+	require.Equal(t, []int64{1, 4, 5}, heights)
+
+	// non-numeric values are rejected
+	require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 7},
+		ResultFinalizeBlock: abci.ResponseFinalizeBlock{Events: []abci.Event{{
+			Type:       "rewards",
+			Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte("10stake"), Index: true}},
+		}}},
+	}))
+	require.NoError(t, indexer.SortHeights(ctx, []int64{1, 2}, "rewards.amount", false))
+	require.Error(t, indexer.SortHeights(ctx, []int64{1, 7}, "rewards.amount", false))
+
+	require.Error(t, indexer.SortHeights(ctx, heights, types.BlockHeightKey, false))
+}
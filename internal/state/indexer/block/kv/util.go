@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/orderedcode"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/pubsub/query/syntax"
 	"github.com/tendermint/tendermint/types"
 )
@@ -32,24 +33,35 @@ func int64ToBytes(i int64) []byte {
 	return buf[:n]
 }
 
-func heightKey(height int64) ([]byte, error) {
+// blockAppHashKey is the key prefix of the app hash to height index.
+const blockAppHashKey = "block.app_hash"
+
+func appHashKey(appHash []byte) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
-		types.BlockHeightKey,
-		height,
+		blockAppHashKey,
+		string(appHash),
 	)
 }
 
-func eventKey(compositeKey, typ, eventValue string, height int64) ([]byte, error) {
+func heightKey(height int64) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
-		compositeKey,
-		eventValue,
+		types.BlockHeightKey,
 		height,
-		typ,
 	)
 }
 
+// eventKey returns the key of an event attribute. The keys of attributes with
+// a type hint end with it.
+func eventKey(compositeKey, typ, eventValue string, height int64, attrType abci.EventAttributeType) ([]byte, error) {
+	items := []interface{}{compositeKey, eventValue, height, typ}
+	if attrType != abci.EventAttributeType_UNSPECIFIED {
+		items = append(items, int64(attrType))
+	}
+	return orderedcode.Append(nil, items...)
+}
+
 func parseValueFromPrimaryKey(key []byte) (string, error) {
 	var (
 		compositeKey string
@@ -68,22 +80,28 @@ func parseValueFromPrimaryKey(key []byte) (string, error) {
 	return strconv.FormatInt(height, 10), nil
 }
 
-func parseValueFromEventKey(key []byte) (string, error) {
+func parseValueFromEventKey(key []byte) (string, abci.EventAttributeType, error) {
 	var (
 		compositeKey, typ, eventValue string
-		height                        int64
+		height, attrType              int64
 	)
 
 	remaining, err := orderedcode.Parse(string(key), &compositeKey, &eventValue, &height, &typ)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse event key: %w", err)
+		return "", 0, fmt.Errorf("failed to parse event key: %w", err)
 	}
 
 	if len(remaining) != 0 {
-		return "", fmt.Errorf("unexpected remainder in key: %s", remaining)
+		if remaining, err = orderedcode.Parse(remaining, &attrType); err != nil {
+			return "", 0, fmt.Errorf("failed to parse event key: %w", err)
+		}
+	}
+
+	if len(remaining) != 0 {
+		return "", 0, fmt.Errorf("unexpected remainder in key: %s", remaining)
 	}
 
-	return eventValue, nil
+	return eventValue, abci.EventAttributeType(attrType), nil
 }
 
 func lookForHeight(conditions []syntax.Condition) (int64, bool) {
@@ -36,18 +36,32 @@ type EventSink interface {
 	// supported by the kvEventSink.
 	SearchBlockEvents(context.Context, *query.Query) ([]int64, error)
 
-	// SearchTxEvents provides the transaction search by given query conditions. This function only
-	// supported by the kvEventSink.
+	// SortBlockHeights sorts the given block heights by the numeric value of the FinalizeBlock
+	// event attribute with the given composite key at each height, in descending order if the
+	// last argument is true. This function only supported by the kvEventSink.
+	SortBlockHeights(context.Context, []int64, string, bool) error
+
+	// SearchTxEvents provides the transaction search by given query conditions. If the search
+	// was cut short by a limit of the sink, the results found so far are returned along with
+	// ErrSearchTruncated. This function only supported by the kvEventSink.
 	SearchTxEvents(context.Context, *query.Query) ([]*abci.TxResult, error)
 
 	// GetTxByHash provides the transaction search by given transaction hash. This function only
 	// supported by the kvEventSink.
 	GetTxByHash([]byte) (*abci.TxResult, error)
 
+	// GetTxEvents provides the events emitted by the transaction with the given hash, in
+	// emission order, along with the composite keys they were indexed under.
+	GetTxEvents([]byte) (*TxEvents, error)
+
 	// HasBlock provides the transaction search by given transaction hash. This function only
 	// supported by the kvEventSink.
 	HasBlock(int64) (bool, error)
 
+	// GetBlockHeightByAppHash provides the height of the block whose execution produced the
+	// given app hash, or 0 if it is not indexed. This function only supported by the kvEventSink.
+	GetBlockHeightByAppHash([]byte) (int64, error)
+
 	// Type checks the eventsink structure type.
 	Type() EventSinkType
 
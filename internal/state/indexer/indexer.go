@@ -20,8 +20,35 @@ type TxIndexer interface {
 	// or stored.
 	Get(hash []byte) (*abci.TxResult, error)
 
-	// Search allows you to query for transactions.
+	// Search allows you to query for transactions. If the search was cut short
+	// by a limit of the indexer, it returns the results found so far along
+	// with ErrSearchTruncated.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
+
+	// Events returns the events emitted by the transaction specified by hash,
+	// in emission order, or nil if the transaction is not indexed.
+	Events(hash []byte) (*TxEvents, error)
+}
+
+// TxEvents holds the events emitted by an indexed transaction, in the order
+// they were emitted.
+type TxEvents struct {
+	Height int64
+	Index  uint32
+	Events []TxEvent
+}
+
+// TxEvent is an event emitted by a transaction, along with the details of how
+// it was indexed.
+type TxEvent struct {
+	// Index is the position of the event among the events emitted by all the
+	// transactions of the block, i.e. counting the events of the preceding
+	// transactions in the block.
+	Index uint64
+	Event abci.Event
+	// CompositeKeys are the composite keys ("type.key") the attributes of the
+	// event were indexed under, in attribute order.
+	CompositeKeys []string
 }
 
 // BlockIndexer defines an interface contract for indexing block events.
@@ -36,6 +63,10 @@ type BlockIndexer interface {
 	// Search performs a query for block heights that match a given FinalizeBlock
 	// event search criteria.
 	Search(ctx context.Context, q *query.Query) ([]int64, error)
+
+	// HeightByAppHash returns the height of the block whose execution produced
+	// the given app hash, or 0 if no such block has been indexed.
+	HeightByAppHash(appHash []byte) (int64, error)
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
@@ -64,3 +95,7 @@ func (b *Batch) Size() int { return len(b.Ops) }
 
 // ErrorEmptyHash indicates empty hash
 var ErrorEmptyHash = errors.New("transaction hash cannot be empty")
+
+// ErrSearchTruncated is returned along with the results of a search that was
+// cut short by a limit of the indexer, so the results may be incomplete.
+var ErrSearchTruncated = errors.New("search results truncated")
@@ -38,14 +38,21 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "transactions_indexed",
 			Help:      "Number of transactions indexed.",
 		}, labels).With(labelsAndValues...),
+		TxSearchScanLimitHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_search_scan_limit_hits",
+			Help:      "Number of transaction searches that reached the maximum number of index keys to scan.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		BlockEventsSeconds:  discard.NewHistogram(),
-		TxEventsSeconds:     discard.NewHistogram(),
-		BlocksIndexed:       discard.NewCounter(),
-		TransactionsIndexed: discard.NewCounter(),
+		BlockEventsSeconds:    discard.NewHistogram(),
+		TxEventsSeconds:       discard.NewHistogram(),
+		BlocksIndexed:         discard.NewCounter(),
+		TransactionsIndexed:   discard.NewCounter(),
+		TxSearchScanLimitHits: discard.NewCounter(),
 	}
 }
@@ -22,4 +22,8 @@ type Metrics struct {
 
 	// Number of transactions indexed.
 	TransactionsIndexed metrics.Counter
+
+	// Number of transaction searches that reached the maximum number of
+	// index keys to scan.
+	TxSearchScanLimitHits metrics.Counter
 }
@@ -20,6 +20,27 @@ type EventSink struct {
 	mock.Mock
 }
 
+// GetBlockHeightByAppHash provides a mock function with given fields: _a0
+func (_m *EventSink) GetBlockHeightByAppHash(_a0 []byte) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func([]byte) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxByHash provides a mock function with given fields: _a0
 func (_m *EventSink) GetTxByHash(_a0 []byte) (*types.TxResult, error) {
 	ret := _m.Called(_a0)
@@ -43,6 +64,29 @@ func (_m *EventSink) GetTxByHash(_a0 []byte) (*types.TxResult, error) {
 	return r0, r1
 }
 
+// GetTxEvents provides a mock function with given fields: _a0
+func (_m *EventSink) GetTxEvents(_a0 []byte) (*indexer.TxEvents, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *indexer.TxEvents
+	if rf, ok := ret.Get(0).(func([]byte) *indexer.TxEvents); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*indexer.TxEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasBlock provides a mock function with given fields: _a0
 func (_m *EventSink) HasBlock(_a0 int64) (bool, error) {
 	ret := _m.Called(_a0)
@@ -138,6 +182,20 @@ func (_m *EventSink) SearchTxEvents(_a0 context.Context, _a1 *query.Query) ([]*t
 	return r0, r1
 }
 
+// SortBlockHeights provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EventSink) SortBlockHeights(_a0 context.Context, _a1 []int64, _a2 string, _a3 bool) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, string, bool) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields:
 func (_m *EventSink) Stop() error {
 	ret := _m.Called()
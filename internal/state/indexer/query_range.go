@@ -1,8 +1,11 @@
 package indexer
 
 import (
+	"math/big"
+	"regexp"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/pubsub/query/syntax"
 )
 
@@ -13,8 +16,8 @@ type QueryRanges map[string]QueryRange
 
 // QueryRange defines a range within a query condition.
 type QueryRange struct {
-	LowerBound        interface{} // int || time.Time
-	UpperBound        interface{} // int || time.Time
+	LowerBound        interface{} // *big.Rat || time.Time
+	UpperBound        interface{} // *big.Rat || time.Time
 	Key               string
 	IncludeLowerBound bool
 	IncludeUpperBound bool
@@ -29,50 +32,94 @@ func (qr QueryRange) AnyBound() interface{} {
 	return qr.UpperBound
 }
 
-// LowerBoundValue returns the value for the lower bound. If the lower bound is
-// nil, nil will be returned.
-func (qr QueryRange) LowerBoundValue() interface{} {
-	if qr.LowerBound == nil {
-		return nil
+// ContainsNumber reports whether the number v is within the numeric bounds of
+// the range.
+func (qr QueryRange) ContainsNumber(v *big.Rat) bool {
+	if lower, ok := qr.LowerBound.(*big.Rat); ok {
+		if c := v.Cmp(lower); c < 0 || (c == 0 && !qr.IncludeLowerBound) {
+			return false
+		}
+	}
+	if upper, ok := qr.UpperBound.(*big.Rat); ok {
+		if c := v.Cmp(upper); c > 0 || (c == 0 && !qr.IncludeUpperBound) {
+			return false
+		}
 	}
+	return true
+}
 
-	if qr.IncludeLowerBound {
-		return qr.LowerBound
+// ContainsTime reports whether the time t is within the time bounds of the
+// range.
+func (qr QueryRange) ContainsTime(t time.Time) bool {
+	if lower, ok := qr.LowerBound.(time.Time); ok {
+		if t.Before(lower) || (t.Equal(lower) && !qr.IncludeLowerBound) {
+			return false
+		}
 	}
+	if upper, ok := qr.UpperBound.(time.Time); ok {
+		if t.After(upper) || (t.Equal(upper) && !qr.IncludeUpperBound) {
+			return false
+		}
+	}
+	return true
+}
 
-	switch t := qr.LowerBound.(type) {
-	case int64:
-		return t + 1
+// ContainsValue reports whether the event attribute value is within the range,
+// comparing it according to its type hint:
+//
+//   - UNSPECIFIED values are compared as numbers if ParseNumber accepts them,
+//     and never match a time range.
+//   - STRING values never match a range.
+//   - INT values must be signed integers, and DECIMAL values signed integers or
+//     fixed-precision decimals. They never match a time range.
+//   - TIME values must be RFC3339 timestamps or YYYY-MM-DD dates, and only
+//     match time ranges.
+func (qr QueryRange) ContainsValue(value string, typ abci.EventAttributeType) bool {
+	switch qr.AnyBound().(type) {
+	case *big.Rat:
+		var (
+			v  *big.Rat
+			ok bool
+		)
+		switch typ {
+		case abci.EventAttributeType_UNSPECIFIED, abci.EventAttributeType_DECIMAL:
+			v, ok = ParseNumber(value)
+		case abci.EventAttributeType_INT:
+			var i *big.Int
+			if i, ok = new(big.Int).SetString(value, 10); ok {
+				v = new(big.Rat).SetInt(i)
+			}
+		}
+		return ok && qr.ContainsNumber(v)
 
 	case time.Time:
-		return t.Unix() + 1
+		if typ != abci.EventAttributeType_TIME {
+			return false
+		}
+		t, err := syntax.ParseTime(value)
+		if err != nil {
+			if t, err = syntax.ParseDate(value); err != nil {
+				return false
+			}
+		}
+		return qr.ContainsTime(t)
 
 	default:
-		panic("not implemented")
+		return false
 	}
 }
 
-// UpperBoundValue returns the value for the upper bound. If the upper bound is
-// nil, nil will be returned.
-func (qr QueryRange) UpperBoundValue() interface{} {
-	if qr.UpperBound == nil {
-		return nil
-	}
+// numberPattern matches the numbers ParseNumber accepts.
+var numberPattern = regexp.MustCompile(`^[-+]?\d+(\.\d+)?$`)
 
-	if qr.IncludeUpperBound {
-		return qr.UpperBound
-	}
-
-	switch t := qr.UpperBound.(type) {
-	case int64:
-		return t - 1
-
-	case time.Time:
-		return t.Unix() - 1
-
-	default:
-		panic("not implemented")
+// ParseNumber parses an event attribute value as a signed integer or
+// fixed-precision decimal, e.g. "-12" or "3.25", so that it's compared exactly
+// to the bounds of a range. It returns false if s isn't such a number.
+func ParseNumber(s string) (*big.Rat, bool) {
+	if !numberPattern.MatchString(s) {
+		return nil, false
 	}
+	return new(big.Rat).SetString(s)
 }
 
 // LookForRanges returns a mapping of QueryRanges and the matching indexes in
@@ -128,7 +175,10 @@ func conditionArg(c syntax.Condition) interface{} {
 	}
 	switch c.Arg.Type {
 	case syntax.TNumber:
-		return int64(c.Arg.Number())
+		if v, ok := ParseNumber(c.Arg.Value()); ok {
+			return v
+		}
+		return nil
 	case syntax.TTime, syntax.TDate:
 		return c.Arg.Time()
 	default:
@@ -23,9 +23,11 @@ type EventSink struct {
 	store dbm.DB
 }
 
-func NewEventSink(store dbm.DB) indexer.EventSink {
+// NewEventSink constructs an event sink backed by store. The options are
+// applied to its transaction indexer.
+func NewEventSink(store dbm.DB, txOptions ...kvt.TxIndexOption) indexer.EventSink {
 	return &EventSink{
-		txi:   kvt.NewTxIndex(store),
+		txi:   kvt.NewTxIndex(store, txOptions...),
 		bi:    kvb.New(store),
 		store: store,
 	}
@@ -47,6 +49,10 @@ func (kves *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([
 	return kves.bi.Search(ctx, q)
 }
 
+func (kves *EventSink) SortBlockHeights(ctx context.Context, heights []int64, key string, desc bool) error {
+	return kves.bi.SortHeights(ctx, heights, key, desc)
+}
+
 func (kves *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return kves.txi.Search(ctx, q)
 }
@@ -55,10 +61,18 @@ func (kves *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
 	return kves.txi.Get(hash)
 }
 
+func (kves *EventSink) GetTxEvents(hash []byte) (*indexer.TxEvents, error) {
+	return kves.txi.Events(hash)
+}
+
 func (kves *EventSink) HasBlock(h int64) (bool, error) {
 	return kves.bi.Has(h)
 }
 
+func (kves *EventSink) GetBlockHeightByAppHash(appHash []byte) (int64, error) {
+	return kves.bi.HeightByAppHash(appHash)
+}
+
 func (kves *EventSink) Stop() error {
 	return kves.store.Close()
 }
@@ -34,6 +34,10 @@ func (nes *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]
 	return nil, nil
 }
 
+func (nes *EventSink) SortBlockHeights(ctx context.Context, heights []int64, key string, desc bool) error {
+	return nil
+}
+
 func (nes *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return nil, nil
 }
@@ -42,10 +46,18 @@ func (nes *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
 	return nil, nil
 }
 
+func (nes *EventSink) GetTxEvents(hash []byte) (*indexer.TxEvents, error) {
+	return nil, nil
+}
+
 func (nes *EventSink) HasBlock(h int64) (bool, error) {
 	return false, nil
 }
 
+func (nes *EventSink) GetBlockHeightByAppHash(appHash []byte) (int64, error) {
+	return 0, nil
+}
+
 func (nes *EventSink) Stop() error {
 	return nil
 }
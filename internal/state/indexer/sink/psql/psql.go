@@ -31,23 +31,56 @@ const (
 type EventSink struct {
 	store   *sql.DB
 	chainID string
+
+	// schema and tablePrefix locate the relations of the sink, so that several
+	// chains can share a database. By default, the relations are unprefixed
+	// and in the default schema of the connection.
+	schema      string
+	tablePrefix string
+}
+
+// EventSinkOption sets an optional parameter on the EventSink.
+type EventSinkOption func(*EventSink)
+
+// WithSchema sets the schema the relations of the sink are located in.
+func WithSchema(schema string) EventSinkOption {
+	return func(es *EventSink) { es.schema = schema }
+}
+
+// WithTablePrefix sets the prefix of the names of the relations of the sink.
+func WithTablePrefix(prefix string) EventSinkOption {
+	return func(es *EventSink) { es.tablePrefix = prefix }
 }
 
 // NewEventSink constructs an event sink associated with the PostgreSQL
 // database specified by connStr. Events written to the sink are attributed to
-// the specified chainID.
-func NewEventSink(connStr, chainID string) (*EventSink, error) {
+// the specified chainID. If the relations of the sink don't exist yet, they
+// are created.
+func NewEventSink(connStr, chainID string, options ...EventSinkOption) (*EventSink, error) {
+	es := &EventSink{chainID: chainID}
+	for _, option := range options {
+		option(es)
+	}
+	if err := ValidateSchema(es.schema); err != nil {
+		return nil, err
+	}
+	if err := ValidateTablePrefix(es.tablePrefix); err != nil {
+		return nil, err
+	}
+
 	db, err := sql.Open(driverName, connStr)
 	if err != nil {
 		return nil, err
 	} else if err := db.Ping(); err != nil {
 		return nil, err
 	}
+	es.store = db
 
-	return &EventSink{
-		store:   db,
-		chainID: chainID,
-	}, nil
+	if err := es.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return es, nil
 }
 
 // DB returns the underlying Postgres connection used by the sink.
@@ -89,7 +122,7 @@ func queryWithID(tx *sql.Tx, query string, args ...interface{}) (uint32, error)
 //
 // If txID > 0, the event is attributed to the Tendermint transaction with that
 // ID; otherwise it is recorded as a block event.
-func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
+func (es *EventSink) insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
 	// Populate the transaction ID field iff one is defined (> 0).
 	var txIDArg interface{}
 	if txID > 0 {
@@ -105,7 +138,7 @@ func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
 		}
 
 		eid, err := queryWithID(dbtx, `
-INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES ($1, $2, $3)
+INSERT INTO `+es.table(tableEvents)+` (block_id, tx_id, type) VALUES ($1, $2, $3)
   RETURNING rowid;
 `, blockID, txIDArg, evt.Type)
 		if err != nil {
@@ -119,7 +152,7 @@ INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES ($1, $2, $3)
 			}
 			compositeKey := evt.Type + "." + string(attr.Key)
 			if _, err := dbtx.Exec(`
-INSERT INTO `+tableAttributes+` (event_id, key, composite_key, value)
+INSERT INTO `+es.table(tableAttributes)+` (event_id, key, composite_key, value)
   VALUES ($1, $2, $3, $4);
 `, eid, attr.Key, compositeKey, attr.Value); err != nil {
 				return err
@@ -152,7 +185,7 @@ func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
 		// Add the block to the blocks table and report back its row ID for use
 		// in indexing the events for the block.
 		blockID, err := queryWithID(dbtx, `
-INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
+INSERT INTO `+es.table(tableBlocks)+` (height, chain_id, created_at)
   VALUES ($1, $2, $3)
   ON CONFLICT DO NOTHING
   RETURNING rowid;
@@ -163,14 +196,20 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
 			return fmt.Errorf("indexing block header: %w", err)
 		}
 
-		// Insert the special block meta-event for height.
-		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
+		// Insert the special block meta-events for height and proposer. A
+		// header without a proposer address is not indexed by proposer.
+		metaEvents := []abci.Event{
 			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
-		}); err != nil {
+		}
+		if len(h.Header.ProposerAddress) != 0 {
+			metaEvents = append(metaEvents,
+				makeIndexedEvent(types.BlockProposerKey, h.Header.ProposerAddress.String()))
+		}
+		if err := es.insertEvents(dbtx, blockID, 0, metaEvents); err != nil {
 			return fmt.Errorf("block meta-events: %w", err)
 		}
 		// Insert all the block events. Order is important here,
-		if err := insertEvents(dbtx, blockID, 0, h.ResultFinalizeBlock.Events); err != nil {
+		if err := es.insertEvents(dbtx, blockID, 0, h.ResultFinalizeBlock.Events); err != nil {
 			return fmt.Errorf("finalize-block events: %w", err)
 		}
 		return nil
@@ -194,7 +233,7 @@ func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
 			// Find the block associated with this transaction. The block header
 			// must have been indexed prior to the transactions belonging to it.
 			blockID, err := queryWithID(dbtx, `
-SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
+SELECT rowid FROM `+es.table(tableBlocks)+` WHERE height = $1 AND chain_id = $2;
 `, txr.Height, es.chainID)
 			if err != nil {
 				return fmt.Errorf("finding block ID: %w", err)
@@ -202,7 +241,7 @@ SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
 
 			// Insert a record for this tx_result and capture its ID for indexing events.
 			txID, err := queryWithID(dbtx, `
-INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
+INSERT INTO `+es.table(tableTxResults)+` (block_id, index, created_at, tx_hash, tx_result)
   VALUES ($1, $2, $3, $4, $5)
   ON CONFLICT DO NOTHING
   RETURNING rowid;
@@ -214,14 +253,14 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
 			}
 
 			// Insert the special transaction meta-events for hash and height.
-			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
+			if err := es.insertEvents(dbtx, blockID, txID, []abci.Event{
 				makeIndexedEvent(types.TxHashKey, txHash),
 				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
 			}); err != nil {
 				return fmt.Errorf("indexing transaction meta-events: %w", err)
 			}
 			// Index any events packaged with the transaction.
-			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
+			if err := es.insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
 				return fmt.Errorf("indexing transaction events: %w", err)
 			}
 			return nil
@@ -233,11 +272,154 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
 	return nil
 }
 
+// GetTxEvents returns the events emitted by the transaction specified by hash,
+// in emission order, along with the composite keys they were indexed under. It
+// returns nil if the transaction is not indexed.
+func (es *EventSink) GetTxEvents(hash []byte) (*indexer.TxEvents, error) {
+	var (
+		txID, blockID uint32
+		resultData    []byte
+	)
+	if err := es.store.QueryRow(`
+SELECT `+es.table(tableTxResults)+`.rowid, block_id, tx_result FROM `+es.table(tableTxResults)+`
+  JOIN `+es.table(tableBlocks)+` ON (`+es.table(tableBlocks)+`.rowid = `+es.table(tableTxResults)+`.block_id)
+  WHERE tx_hash = $1 AND chain_id = $2
+  ORDER BY `+es.table(tableTxResults)+`.rowid DESC LIMIT 1;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&txID, &blockID, &resultData); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("finding tx_result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+
+	offset, err := es.eventOffset(blockID, txr.Index)
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := es.indexedKeys(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]indexer.TxEvent, len(txr.Result.Events))
+	for i, evt := range txr.Result.Events {
+		events[i] = indexer.TxEvent{Index: offset + uint64(i), Event: evt}
+
+		// Events with an empty type are not recorded (see insertEvents), so
+		// they have no entry in indexed.
+		if evt.Type == "" {
+			continue
+		}
+		if len(indexed) == 0 {
+			return nil, fmt.Errorf("missing indexed events for tx %X", hash)
+		}
+		keys := indexed[0]
+		indexed = indexed[1:]
+
+		// Report the keys in attribute order.
+		for _, attr := range evt.Attributes {
+			compositeKey := evt.Type + "." + string(attr.Key)
+			if keys[compositeKey] {
+				events[i].CompositeKeys = append(events[i].CompositeKeys, compositeKey)
+				delete(keys, compositeKey)
+			}
+		}
+	}
+
+	return &indexer.TxEvents{
+		Height: txr.Height,
+		Index:  txr.Index,
+		Events: events,
+	}, nil
+}
+
+// eventOffset returns the number of events emitted by the transactions that
+// precede the transaction at index in the block with ID blockID.
+func (es *EventSink) eventOffset(blockID, index uint32) (uint64, error) {
+	rows, err := es.store.Query(`
+SELECT tx_result FROM `+es.table(tableTxResults)+` WHERE block_id = $1 AND index < $2;
+`, blockID, index)
+	if err != nil {
+		return 0, fmt.Errorf("loading preceding tx_results: %w", err)
+	}
+	defer rows.Close()
+
+	var offset uint64
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return 0, err
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return 0, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		offset += uint64(len(txr.Result.Events))
+	}
+	return offset, rows.Err()
+}
+
+// indexedKeys returns the sets of composite keys indexed for each of the
+// events recorded for the transaction with ID txID, in emission order. The
+// transaction meta-events are not included.
+func (es *EventSink) indexedKeys(txID uint32) ([]map[string]bool, error) {
+	rows, err := es.store.Query(`
+SELECT `+es.table(tableEvents)+`.rowid, composite_key FROM `+es.table(tableEvents)+`
+  LEFT JOIN `+es.table(tableAttributes)+` ON (`+es.table(tableEvents)+`.rowid = `+es.table(tableAttributes)+`.event_id)
+  WHERE tx_id = $1
+  ORDER BY `+es.table(tableEvents)+`.rowid;
+`, txID)
+	if err != nil {
+		return nil, fmt.Errorf("loading tx events: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		indexed []map[string]bool
+		lastID  int64 = -1
+	)
+	for rows.Next() {
+		var (
+			eventID      int64
+			compositeKey sql.NullString
+		)
+		if err := rows.Scan(&eventID, &compositeKey); err != nil {
+			return nil, err
+		}
+		if eventID != lastID {
+			indexed = append(indexed, make(map[string]bool))
+			lastID = eventID
+		}
+		if compositeKey.Valid {
+			indexed[len(indexed)-1][compositeKey.String] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Skip the meta-events for hash and height (see IndexTxEvents).
+	const numMetaEvents = 2
+	if len(indexed) < numMetaEvents {
+		return nil, fmt.Errorf("missing meta-events for tx %d", txID)
+	}
+	return indexed[numMetaEvents:], nil
+}
+
 // SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
 func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
 	return nil, errors.New("block search is not supported via the postgres event sink")
 }
 
+// SortBlockHeights is not implemented by this sink, and reports an error for all calls.
+func (es *EventSink) SortBlockHeights(ctx context.Context, heights []int64, key string, desc bool) error {
+	return errors.New("block search is not supported via the postgres event sink")
+}
+
 // SearchTxEvents is not implemented by this sink, and reports an error for all queries.
 func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return nil, errors.New("tx search is not supported via the postgres event sink")
@@ -253,5 +435,10 @@ func (es *EventSink) HasBlock(h int64) (bool, error) {
 	return false, errors.New("hasBlock is not supported via the postgres event sink")
 }
 
+// GetBlockHeightByAppHash is not implemented by this sink, and reports an error for all queries.
+func (es *EventSink) GetBlockHeightByAppHash(appHash []byte) (int64, error) {
+	return 0, errors.New("getBlockHeightByAppHash is not supported via the postgres event sink")
+}
+
 // Stop closes the underlying PostgreSQL database.
 func (es *EventSink) Stop() error { return es.store.Close() }
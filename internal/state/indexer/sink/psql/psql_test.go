@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"testing"
 	"time"
 
@@ -210,6 +211,111 @@ func TestIndexing(t *testing.T) {
 	})
 }
 
+func TestGetTxEvents(t *testing.T) {
+	indexer := &EventSink{store: testDB(), chainID: chainID}
+	require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 3},
+	}))
+
+	txResult1 := txResultWithEvents([]abci.Event{
+		makeIndexedEvent("account.number", "1"),
+		{Type: "", Attributes: []abci.EventAttribute{{Key: []byte("not_allowed"), Value: []byte("Vlad"), Index: true}}},
+	})
+	txResult1.Height = 3
+	txResult1.Tx = types.Tx("FIRST TX")
+	txResult2 := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{
+			{Key: []byte("number"), Value: []byte("2"), Index: true},
+			{Key: []byte("owner"), Value: []byte("Ivan"), Index: false},
+			{Key: []byte("memo"), Value: []byte("hi"), Index: true},
+		}},
+		{Type: "transfer"},
+	})
+	txResult2.Height = 3
+	txResult2.Index = 1
+	txResult2.Tx = types.Tx("SECOND TX")
+	require.NoError(t, indexer.IndexTxEvents([]*abci.TxResult{txResult1, txResult2}))
+
+	evs, err := indexer.GetTxEvents(types.Tx(txResult1.Tx).Hash())
+	require.NoError(t, err)
+	require.NotNil(t, evs)
+	require.Len(t, evs.Events, 2)
+	assert.EqualValues(t, 0, evs.Events[0].Index)
+	assert.Equal(t, []string{"account.number"}, evs.Events[0].CompositeKeys)
+	assert.EqualValues(t, 1, evs.Events[1].Index)
+	assert.Empty(t, evs.Events[1].CompositeKeys)
+
+	evs, err = indexer.GetTxEvents(types.Tx(txResult2.Tx).Hash())
+	require.NoError(t, err)
+	require.NotNil(t, evs)
+	assert.EqualValues(t, 3, evs.Height)
+	assert.EqualValues(t, 1, evs.Index)
+	require.Len(t, evs.Events, 2)
+	assert.EqualValues(t, 2, evs.Events[0].Index)
+	assert.Equal(t, []string{"account.number", "account.memo"}, evs.Events[0].CompositeKeys)
+	assert.EqualValues(t, 3, evs.Events[1].Index)
+	assert.Empty(t, evs.Events[1].CompositeKeys)
+
+	evs, err = indexer.GetTxEvents(types.Tx("UNKNOWN").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, evs)
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	for _, valid := range []string{"", "chain1", "_chain", "chain_1"} {
+		assert.NoError(t, ValidateSchema(valid), valid)
+		assert.NoError(t, ValidateTablePrefix(valid), valid)
+	}
+	for _, invalid := range []string{"Chain1", "1chain", "chain-1", `chain"`, "chain; DROP TABLE blocks; --"} {
+		assert.Error(t, ValidateSchema(invalid), invalid)
+		assert.Error(t, ValidateTablePrefix(invalid), invalid)
+	}
+	assert.NoError(t, ValidateSchema(strings.Repeat("a", 63)))
+	assert.Error(t, ValidateSchema(strings.Repeat("a", 64)))
+	assert.NoError(t, ValidateTablePrefix(strings.Repeat("a", 40)))
+	assert.Error(t, ValidateTablePrefix(strings.Repeat("a", 41)))
+}
+
+func TestSchemaDDL(t *testing.T) {
+	es := &EventSink{}
+	assert.Equal(t, `"blocks"`, es.table(tableBlocks))
+
+	es = &EventSink{schema: "chains", tablePrefix: "chain1_"}
+	assert.Equal(t, `"chains"."chain1_tx_results"`, es.table(tableTxResults))
+
+	ddl := es.schemaDDL()
+	assert.Contains(t, ddl, `CREATE TABLE "chains"."chain1_blocks" (`)
+	assert.Contains(t, ddl, `REFERENCES "chains"."chain1_tx_results"(rowid)`)
+	assert.Contains(t, ddl, `CREATE INDEX "chain1_idx_blocks_height_chain" ON "chains"."chain1_blocks"(height, chain_id);`)
+	assert.Contains(t, ddl, `CREATE VIEW "chains"."chain1_tx_events" AS`)
+	assert.NotContains(t, ddl, "CREATE TABLE blocks")
+}
+
+func TestTablePrefix(t *testing.T) {
+	// A sink with a schema and table prefix creates its own relations, which
+	// are independent of those with the default names.
+	es := &EventSink{store: testDB(), chainID: chainID, schema: "other", tablePrefix: "chain2_"}
+	require.NoError(t, es.migrate())
+	require.NoError(t, es.migrate()) // migrating again is a no-op
+
+	require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 7},
+	}))
+	txResult := txResultWithEvents([]abci.Event{makeIndexedEvent("account.number", "1")})
+	txResult.Height = 7
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{txResult}))
+
+	var count int
+	require.NoError(t, testDB().QueryRow(`
+SELECT COUNT(*) FROM other.chain2_blocks WHERE height = 7;
+`).Scan(&count))
+	assert.Equal(t, 1, count)
+	require.NoError(t, testDB().QueryRow(`
+SELECT COUNT(*) FROM `+tableBlocks+` WHERE height = 7;
+`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
 func TestStop(t *testing.T) {
 	indexer := &EventSink{store: testDB()}
 	require.NoError(t, indexer.Stop())
@@ -0,0 +1,112 @@
+package psql
+
+import (
+	"database/sql"
+	_ "embed" // for the schema
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultSchemaDDL is the schema of the sink with the default relation names.
+//
+//go:embed schema.sql
+var defaultSchemaDDL string
+
+const (
+	// maxIdentifierLen is the maximum length of a PostgreSQL identifier.
+	maxIdentifierLen = 63
+
+	// indexBlocksHeightChain is the longest relation name in the schema.
+	indexBlocksHeightChain = "idx_blocks_height_chain"
+)
+
+var (
+	// identifierPattern matches the identifiers accepted for the schema and
+	// table prefix. Only lowercase letters are allowed so that quoted and
+	// unquoted references to the relations are equivalent.
+	identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+	// relationPattern matches the names of the relations defined in schema.sql.
+	relationPattern = regexp.MustCompile(
+		`\b(blocks|tx_results|events|attributes|event_attributes|block_events|tx_events|` +
+			indexBlocksHeightChain + `)\b`)
+)
+
+// ValidateSchema returns an error if schema can't be used as the schema of
+// the sink. The empty string denotes the default schema of the connection.
+func ValidateSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	if len(schema) > maxIdentifierLen || !identifierPattern.MatchString(schema) {
+		return fmt.Errorf("invalid psql schema %q: must be at most %d lowercase letters, digits "+
+			"or underscores, not starting with a digit", schema, maxIdentifierLen)
+	}
+	return nil
+}
+
+// ValidateTablePrefix returns an error if prefix can't be used as the prefix of
+// the relation names of the sink.
+func ValidateTablePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	maxLen := maxIdentifierLen - len(indexBlocksHeightChain)
+	if len(prefix) > maxLen || !identifierPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid psql table prefix %q: must be at most %d lowercase letters, digits "+
+			"or underscores, not starting with a digit", prefix, maxLen)
+	}
+	return nil
+}
+
+// quoteIdentifier quotes name for use as an identifier in a query.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// table returns the qualified, quoted name of the relation of the sink with
+// the given default name.
+func (es *EventSink) table(name string) string {
+	ident := quoteIdentifier(es.tablePrefix + name)
+	if es.schema == "" {
+		return ident
+	}
+	return quoteIdentifier(es.schema) + "." + ident
+}
+
+// schemaDDL returns the DDL creating the relations of the sink.
+func (es *EventSink) schemaDDL() string {
+	return relationPattern.ReplaceAllStringFunc(defaultSchemaDDL, func(name string) string {
+		if name == indexBlocksHeightChain {
+			// Indexes are always created in the schema of their table.
+			return quoteIdentifier(es.tablePrefix + name)
+		}
+		return es.table(name)
+	})
+}
+
+// migrate creates the relations of the sink if they don't exist yet. Existing
+// relations, such as those of deployments using the default names, are left
+// untouched.
+func (es *EventSink) migrate() error {
+	var blocks sql.NullString
+	if err := es.store.QueryRow(`SELECT to_regclass($1)::text;`, es.table(tableBlocks)).Scan(&blocks); err != nil {
+		return fmt.Errorf("checking for existing relations: %w", err)
+	}
+	if blocks.Valid {
+		return nil
+	}
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		if es.schema != "" {
+			if _, err := dbtx.Exec(`CREATE SCHEMA IF NOT EXISTS ` + quoteIdentifier(es.schema) + `;`); err != nil {
+				return fmt.Errorf("creating schema: %w", err)
+			}
+		}
+		if _, err := dbtx.Exec(es.schemaDDL()); err != nil {
+			return fmt.Errorf("creating relations: %w", err)
+		}
+		return nil
+	})
+}
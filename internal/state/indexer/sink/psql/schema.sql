@@ -1,7 +1,10 @@
 /*
   This file defines the database schema for the PostgresQL ("psql") event sink
-  implementation in Tendermint. The operator must create a database and install
-  this schema before using the database to index events.
+  implementation in Tendermint. The operator must create a database; if this
+  schema is not installed, the sink installs it when it starts.
+
+  The sink may be configured with a schema and a table prefix, in which case
+  the relations below are created in that schema and their names prefixed.
  */
 
 -- The blocks table records metadata about each block.
@@ -9,11 +9,17 @@ import (
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/null"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/psql"
+	kvt "github.com/tendermint/tendermint/internal/state/indexer/tx/kv"
 )
 
 // EventSinksFromConfig constructs a slice of indexer.EventSink using the provided
 // configuration.
-func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID string) ([]indexer.EventSink, error) {
+func EventSinksFromConfig(
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+	chainID string,
+	metrics *indexer.Metrics,
+) ([]indexer.EventSink, error) {
 	if len(cfg.TxIndex.Indexer) == 0 {
 		return []indexer.EventSink{null.NewEventSink()}, nil
 	}
@@ -41,7 +47,11 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 				return nil, err
 			}
 
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
+			eventSinks = append(eventSinks, kv.NewEventSink(store,
+				kvt.WithMaxScannedKeys(cfg.TxIndex.MaxScannedKeys),
+				kvt.WithMaxResults(cfg.TxIndex.MaxSearchResults),
+				kvt.WithMetrics(metrics),
+			))
 
 		case indexer.PSQL:
 			conn := cfg.TxIndex.PsqlConn
@@ -49,7 +59,10 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 				return nil, errors.New("the psql connection settings cannot be empty")
 			}
 
-			es, err := psql.NewEventSink(conn, chainID)
+			es, err := psql.NewEventSink(conn, chainID,
+				psql.WithSchema(cfg.TxIndex.PsqlSchema),
+				psql.WithTablePrefix(cfg.TxIndex.PsqlTablePrefix),
+			)
 			if err != nil {
 				return nil, err
 			}
@@ -1,11 +1,12 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -27,13 +28,46 @@ var _ indexer.TxIndexer = (*TxIndex)(nil)
 // 2. event - txhash   (secondary key)
 type TxIndex struct {
 	store dbm.DB
+
+	// maxScannedKeys and maxResults bound the work done by a search. Zero
+	// means no limit.
+	maxScannedKeys int
+	maxResults     int
+	metrics        *indexer.Metrics
+}
+
+// TxIndexOption sets an optional parameter on the TxIndex.
+type TxIndexOption func(*TxIndex)
+
+// WithMaxScannedKeys sets the maximum number of index keys a search scans.
+// Once it's reached, the search stops scanning and checks the conditions it
+// hasn't evaluated yet against the events of the transactions matched so far.
+// Zero means no limit.
+func WithMaxScannedKeys(n int) TxIndexOption {
+	return func(txi *TxIndex) { txi.maxScannedKeys = n }
+}
+
+// WithMaxResults sets the maximum number of transactions a search returns.
+// Zero means no limit.
+func WithMaxResults(n int) TxIndexOption {
+	return func(txi *TxIndex) { txi.maxResults = n }
+}
+
+// WithMetrics sets the metrics of the TxIndex.
+func WithMetrics(metrics *indexer.Metrics) TxIndexOption {
+	return func(txi *TxIndex) { txi.metrics = metrics }
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
-		store: store,
+func NewTxIndex(store dbm.DB, options ...TxIndexOption) *TxIndex {
+	txi := &TxIndex{
+		store:   store,
+		metrics: indexer.NopMetrics(),
+	}
+	for _, opt := range options {
+		opt(txi)
 	}
+	return txi
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -116,7 +150,7 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeTag)
 			}
 			if attr.GetIndex() {
-				err := store.Set(keyFromEvent(compositeTag, string(attr.Value), result), hash)
+				err := store.Set(keyFromEvent(compositeTag, string(attr.Value), attr.Type, result), hash)
 				if err != nil {
 					return err
 				}
@@ -127,6 +161,82 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 	return nil
 }
 
+// Events returns the events emitted by the transaction specified by hash, in
+// emission order, along with the composite keys they were indexed under. It
+// returns nil if the transaction is not indexed.
+func (txi *TxIndex) Events(hash []byte) (*indexer.TxEvents, error) {
+	result, err := txi.Get(hash)
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	offset, err := txi.eventOffset(result.Height, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]indexer.TxEvent, len(result.Result.Events))
+	for i, event := range result.Result.Events {
+		events[i] = indexer.TxEvent{
+			Index:         offset + uint64(i),
+			Event:         event,
+			CompositeKeys: indexedKeys(event),
+		}
+	}
+	return &indexer.TxEvents{
+		Height: result.Height,
+		Index:  result.Index,
+		Events: events,
+	}, nil
+}
+
+// eventOffset returns the number of events emitted by the transactions that
+// precede the transaction specified by hash in the block at height.
+func (txi *TxIndex) eventOffset(height int64, hash []byte) (uint64, error) {
+	prefix, err := orderedcode.Append(prefixFromCompositeKeyAndValue(types.TxHeightKey, fmt.Sprint(height)), height)
+	if err != nil {
+		return 0, err
+	}
+
+	// The height index is ordered by transaction index, so the preceding
+	// transactions are the ones iterated before reaching hash.
+	it, err := dbm.IteratePrefix(txi.store, prefix)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var offset uint64
+	for ; it.Valid(); it.Next() {
+		if bytes.Equal(it.Value(), hash) {
+			break
+		}
+		result, err := txi.Get(it.Value())
+		if err != nil {
+			return 0, err
+		} else if result != nil {
+			offset += uint64(len(result.Result.Events))
+		}
+	}
+	return offset, it.Error()
+}
+
+// indexedKeys returns the composite keys indexEvents indexes event under.
+func indexedKeys(event abci.Event) []string {
+	if len(event.Type) == 0 {
+		return nil
+	}
+
+	var keys []string
+	for _, attr := range event.Attributes {
+		if len(attr.Key) == 0 || !attr.GetIndex() {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("%s.%s", event.Type, string(attr.Key)))
+	}
+	return keys
+}
+
 // Search performs a search using the given query.
 //
 // It breaks the query into conditions (like "tx.height > 5"). For each
@@ -134,10 +244,13 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 // "tx.hash" is found, it returns tx result for it (2) for range queries it is
 // better for the client to provide both lower and upper bounds, so we are not
 // performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// and returned to the caller, ordered by height and index.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
+//
+// If the search reaches the maximum number of keys to scan or of results, it
+// returns the results found so far along with indexer.ErrSearchTruncated.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	select {
 	case <-ctx.Done():
@@ -147,11 +260,16 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	}
 
 	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
+	filteredHashes := make(map[string]txRef)
 
 	// get a list of conditions (like "tx.height > 5")
 	conditions := q.Syntax()
 
+	var limit *scanLimit
+	if txi.maxScannedKeys > 0 {
+		limit = &scanLimit{remaining: txi.maxScannedKeys}
+	}
+
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
@@ -179,8 +297,11 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		skipIndexes = append(skipIndexes, rangeIndexes...)
 
 		for _, qr := range ranges {
+			if limit.isReached() {
+				break
+			}
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), filteredHashes, true)
+				filteredHashes = txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), filteredHashes, true, limit)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -189,7 +310,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 					break
 				}
 			} else {
-				filteredHashes = txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), filteredHashes, false)
+				filteredHashes = txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), filteredHashes, false, limit)
 			}
 		}
 	}
@@ -199,12 +320,15 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 
 	// for all other conditions
 	for i, c := range conditions {
+		if limit.isReached() {
+			break
+		}
 		if intInSlice(i, skipIndexes) {
 			continue
 		}
 
 		if !hashesInitialized {
-			filteredHashes = txi.match(ctx, c, prefixForCondition(c, height), filteredHashes, true)
+			filteredHashes = txi.match(ctx, c, prefixForCondition(c, height), filteredHashes, true, limit)
 			hashesInitialized = true
 
 			// Ignore any remaining conditions if the first condition resulted
@@ -213,16 +337,44 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				break
 			}
 		} else {
-			filteredHashes = txi.match(ctx, c, prefixForCondition(c, height), filteredHashes, false)
+			filteredHashes = txi.match(ctx, c, prefixForCondition(c, height), filteredHashes, false, limit)
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
+	// If the scan stopped early, the conditions that weren't evaluated against
+	// the index are checked against the events of the matched transactions.
+	truncated := limit.isReached()
+	if truncated {
+		txi.metrics.TxSearchScanLimitHits.Add(1)
+	}
+
+	// Results are truncated in the order of the chain, so that the results
+	// kept don't depend on the iteration order of the map.
+	refs := make([]txRef, 0, len(filteredHashes))
+	for _, ref := range filteredHashes {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].height != refs[j].height {
+			return refs[i].height < refs[j].height
+		}
+		return refs[i].index < refs[j].index
+	})
+
+	results := make([]*abci.TxResult, 0, len(refs))
 hashes:
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
+	for _, ref := range refs {
+		if txi.maxResults > 0 && len(results) == txi.maxResults {
+			truncated = true
+			break
+		}
+
+		res, err := txi.Get(ref.hash)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", ref.hash, err)
+		}
+		if limit.isReached() && !q.Matches(indexedEvents(res)) {
+			continue
 		}
 		results = append(results, res)
 
@@ -234,9 +386,88 @@ hashes:
 		}
 	}
 
+	if truncated {
+		return results, indexer.ErrSearchTruncated
+	}
 	return results, nil
 }
 
+// txRef is a transaction matched by a search: its hash, and its height and
+// index parsed from the matching event key.
+type txRef struct {
+	hash   []byte
+	height int64
+	index  int64
+}
+
+// newTxRef returns the reference to the transaction hash indexed under key.
+// Keys that can't be parsed sort first.
+func newTxRef(key, hash []byte) txRef {
+	_, height, index, _, _ := parseKey(key)
+	return txRef{hash: hash, height: height, index: index}
+}
+
+// scanLimit counts down the index keys a search may still scan. A nil
+// scanLimit allows any number of keys.
+type scanLimit struct {
+	remaining int
+	reached   bool
+}
+
+// next reports whether another key may be scanned, and counts it if so.
+func (l *scanLimit) next() bool {
+	if l == nil {
+		return true
+	}
+	if l.remaining == 0 {
+		l.reached = true
+		return false
+	}
+	l.remaining--
+	return true
+}
+
+// isReached reports whether a key was left unscanned because of the limit.
+func (l *scanLimit) isReached() bool {
+	return l != nil && l.reached
+}
+
+// indexedEvents returns the events result is indexed under, as matched by a
+// query: its indexed event attributes, and its hash and height.
+func indexedEvents(result *abci.TxResult) []abci.Event {
+	events := make([]abci.Event, 0, len(result.Result.Events)+3)
+	for _, event := range result.Result.Events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		indexed := abci.Event{Type: event.Type}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) != 0 && attr.GetIndex() {
+				indexed.Attributes = append(indexed.Attributes, attr)
+			}
+		}
+		if len(indexed.Attributes) != 0 {
+			events = append(events, indexed)
+		}
+	}
+	hashTokens := strings.Split(types.TxHashKey, ".")
+	heightTokens := strings.Split(types.TxHeightKey, ".")
+	return append(events, types.EventTx,
+		abci.Event{
+			Type: hashTokens[0],
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(hashTokens[1]), Value: []byte(fmt.Sprintf("%X", types.Tx(result.Tx).Hash()))},
+			},
+		},
+		abci.Event{
+			Type: heightTokens[0],
+			Attributes: []abci.EventAttribute{
+				{Key: []byte(heightTokens[1]), Value: []byte(fmt.Sprintf("%d", result.Height))},
+			},
+		},
+	)
+}
+
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
 	for _, c := range conditions {
 		if c.Tag == types.TxHashKey {
@@ -266,16 +497,17 @@ func (txi *TxIndex) match(
 	ctx context.Context,
 	c syntax.Condition,
 	startKeyBz []byte,
-	filteredHashes map[string][]byte,
+	filteredHashes map[string]txRef,
 	firstRun bool,
-) map[string][]byte {
+	limit *scanLimit,
+) map[string]txRef {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
 	if !firstRun && len(filteredHashes) == 0 {
 		return filteredHashes
 	}
 
-	tmpHashes := make(map[string][]byte)
+	tmpHashes := make(map[string]txRef)
 
 	switch {
 	case c.Op == syntax.TEq:
@@ -287,7 +519,10 @@ func (txi *TxIndex) match(
 
 	iterEqual:
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			if !limit.next() {
+				break
+			}
+			tmpHashes[string(it.Value())] = newTxRef(it.Key(), it.Value())
 
 			// Potentially exit early.
 			select {
@@ -311,7 +546,10 @@ func (txi *TxIndex) match(
 
 	iterExists:
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			if !limit.next() {
+				break
+			}
+			tmpHashes[string(it.Value())] = newTxRef(it.Key(), it.Value())
 
 			// Potentially exit early.
 			select {
@@ -336,12 +574,15 @@ func (txi *TxIndex) match(
 
 	iterContains:
 		for ; it.Valid(); it.Next() {
-			value, err := parseValueFromKey(it.Key())
+			if !limit.next() {
+				break
+			}
+			value, _, err := parseValueFromKey(it.Key())
 			if err != nil {
 				continue
 			}
 			if strings.Contains(value, c.Arg.Value()) {
-				tmpHashes[string(it.Value())] = it.Value()
+				tmpHashes[string(it.Value())] = newTxRef(it.Key(), it.Value())
 			}
 
 			// Potentially exit early.
@@ -364,12 +605,15 @@ func (txi *TxIndex) match(
 
 	iterMatches:
 		for ; it.Valid(); it.Next() {
-			value, err := parseValueFromKey(it.Key())
+			if !limit.next() {
+				break
+			}
+			value, _, err := parseValueFromKey(it.Key())
 			if err != nil {
 				continue
 			}
 			if match, _ := regexp.MatchString(c.Arg.Value(), value); match {
-				tmpHashes[string(it.Value())] = it.Value()
+				tmpHashes[string(it.Value())] = newTxRef(it.Key(), it.Value())
 			}
 
 			// Potentially exit early.
@@ -400,7 +644,7 @@ func (txi *TxIndex) match(
 	// Remove/reduce matches in filteredHashes that were not found in this
 	// match (tmpHashes).
 	for k := range filteredHashes {
-		if tmpHashes[k] == nil {
+		if _, ok := tmpHashes[k]; !ok {
 			delete(filteredHashes, k)
 
 			// Potentially exit early.
@@ -424,18 +668,17 @@ func (txi *TxIndex) matchRange(
 	ctx context.Context,
 	qr indexer.QueryRange,
 	startKey []byte,
-	filteredHashes map[string][]byte,
+	filteredHashes map[string]txRef,
 	firstRun bool,
-) map[string][]byte {
+	limit *scanLimit,
+) map[string]txRef {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
 	if !firstRun && len(filteredHashes) == 0 {
 		return filteredHashes
 	}
 
-	tmpHashes := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
+	tmpHashes := make(map[string]txRef)
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -445,35 +688,15 @@ func (txi *TxIndex) matchRange(
 
 iter:
 	for ; it.Valid(); it.Next() {
-		value, err := parseValueFromKey(it.Key())
+		if !limit.next() {
+			break
+		}
+		value, typ, err := parseValueFromKey(it.Key())
 		if err != nil {
 			continue
 		}
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
-			}
-
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		if qr.ContainsValue(value, typ) {
+			tmpHashes[string(it.Value())] = newTxRef(it.Key(), it.Value())
 		}
 
 		// Potentially exit early.
@@ -501,7 +724,7 @@ iter:
 	// Remove/reduce matches in filteredHashes that were not found in this
 	// match (tmpHashes).
 	for k := range filteredHashes {
-		if tmpHashes[k] == nil {
+		if _, ok := tmpHashes[k]; !ok {
 			delete(filteredHashes, k)
 
 			// Potentially exit early.
@@ -529,6 +752,7 @@ iter:
 // example the value could be "5" or "Ivan"
 // 3. The height of the Tx that aligns with the key and value.
 // 4. The index of the Tx that aligns with the key and value
+// The keys of attributes with a type hint end with a 5th part, the type.
 
 // the hash/primary key
 func primaryKey(hash []byte) []byte {
@@ -544,44 +768,55 @@ func primaryKey(hash []byte) []byte {
 }
 
 // The event/secondary key
-func secondaryKey(compositeKey, value string, height int64, index uint32) []byte {
-	key, err := orderedcode.Append(
-		nil,
-		compositeKey,
-		value,
-		height,
-		int64(index),
-	)
+func secondaryKey(compositeKey, value string, height int64, index uint32, typ abci.EventAttributeType) []byte {
+	items := []interface{}{compositeKey, value, height, int64(index)}
+	if typ != abci.EventAttributeType_UNSPECIFIED {
+		items = append(items, int64(typ))
+	}
+	key, err := orderedcode.Append(nil, items...)
 	if err != nil {
 		panic(err)
 	}
 	return key
 }
 
-// parseValueFromKey parses an event key and extracts out the value, returning an error if one arises.
-// This will also involve ensuring that the key has the correct format.
+// parseValueFromKey parses an event key and extracts out the value and its type hint, returning an error if
+// one arises. This will also involve ensuring that the key has the correct format.
 // CONTRACT: function doesn't check that the prefix is correct. This should have already been done by the iterator
-func parseValueFromKey(key []byte) (string, error) {
+func parseValueFromKey(key []byte) (string, abci.EventAttributeType, error) {
+	value, _, _, typ, err := parseKey(key)
+	return value, typ, err
+}
+
+// parseKey parses an event key into the value, the height and index of the
+// transaction, and the type hint of the value.
+func parseKey(key []byte) (value string, height, index int64, typ abci.EventAttributeType, err error) {
 	var (
-		compositeKey, value string
-		height, index       int64
+		compositeKey string
+		typHint      int64
 	)
 	remaining, err := orderedcode.Parse(string(key), &compositeKey, &value, &height, &index)
 	if err != nil {
-		return "", err
+		return "", 0, 0, 0, err
+	}
+	if len(remaining) != 0 {
+		if remaining, err = orderedcode.Parse(remaining, &typHint); err != nil {
+			return "", 0, 0, 0, err
+		}
 	}
 	if len(remaining) != 0 {
-		return "", fmt.Errorf("unexpected remainder in key: %s", remaining)
+		return "", 0, 0, 0, fmt.Errorf("unexpected remainder in key: %s", remaining)
 	}
-	return value, nil
+	return value, height, index, abci.EventAttributeType(typHint), nil
 }
 
-func keyFromEvent(compositeKey string, value string, result *abci.TxResult) []byte {
-	return secondaryKey(compositeKey, value, result.Height, result.Index)
+func keyFromEvent(compositeKey string, value string, typ abci.EventAttributeType, result *abci.TxResult) []byte {
+	return secondaryKey(compositeKey, value, result.Height, result.Index, typ)
 }
 
 func KeyFromHeight(result *abci.TxResult) []byte {
-	return secondaryKey(types.TxHeightKey, fmt.Sprintf("%d", result.Height), result.Height, result.Index)
+	return secondaryKey(types.TxHeightKey, fmt.Sprintf("%d", result.Height), result.Height, result.Index,
+		abci.EventAttributeType_UNSPECIFIED)
 }
 
 // Prefixes: these represent an initial part of the key and are used by iterators to iterate over a small
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -322,6 +323,217 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchSignedAndDecimalRanges(t *testing.T) {
+	indexer := NewTxIndex(dbm.NewMemDB())
+
+	values := []string{"-10", "-2.5", "-1", "0", "0.25", "3", "12", "not a number"}
+	for i, value := range values {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("balance"), Value: []byte(value), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index([]*abci.TxResult{txResult}))
+	}
+
+	testCases := []struct {
+		q        string
+		expected []string
+	}{
+		{"account.balance < 0", []string{"-10", "-2.5", "-1"}},
+		{"account.balance <= -2.5", []string{"-10", "-2.5"}},
+		{"account.balance > -2.5 AND account.balance < 3", []string{"-1", "0", "0.25"}},
+		{"account.balance >= -1 AND account.balance <= 0.25", []string{"-1", "0", "0.25"}},
+		{"account.balance > 0.2", []string{"0.25", "3", "12"}},
+		{"account.balance > 2.99 AND account.balance < 3.01", []string{"3"}},
+		{"account.balance >= -10.5 AND account.balance < -10", []string{}},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+			require.NoError(t, err)
+
+			balances := make([]string, 0, len(results))
+			for _, res := range results {
+				balances = append(balances, string(res.Result.Events[0].Attributes[0].Value))
+			}
+			assert.ElementsMatch(t, tc.expected, balances)
+		})
+	}
+}
+
+func TestTxSearchTypeHints(t *testing.T) {
+	indexer := NewTxIndex(dbm.NewMemDB())
+
+	attrs := []abci.EventAttribute{
+		{Value: []byte("7")},
+		{Value: []byte("5"), Type: abci.EventAttributeType_STRING},
+		{Value: []byte("10"), Type: abci.EventAttributeType_INT},
+		{Value: []byte("1.5"), Type: abci.EventAttributeType_INT},
+		{Value: []byte("-1.50"), Type: abci.EventAttributeType_DECIMAL},
+		{Value: []byte("2022-01-02T03:04:05Z"), Type: abci.EventAttributeType_TIME},
+		{Value: []byte("2022-03-01"), Type: abci.EventAttributeType_TIME},
+	}
+	for i, attr := range attrs {
+		attr.Key = []byte("value")
+		attr.Index = true
+		txResult := txResultWithEvents([]abci.Event{{Type: "account", Attributes: []abci.EventAttribute{attr}}})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index([]*abci.TxResult{txResult}))
+	}
+
+	testCases := []struct {
+		q        string
+		expected []string
+	}{
+		{"account.value > -2", []string{"7", "10", "-1.50"}},
+		{"account.value > 1 AND account.value < 2", []string{}},
+		{"account.value <= -1.5", []string{"-1.50"}},
+		{"account.value >= TIME 2022-01-02T03:04:05Z", []string{"2022-01-02T03:04:05Z", "2022-03-01"}},
+		{"account.value < DATE 2022-02-01", []string{"2022-01-02T03:04:05Z"}},
+		// the type hint doesn't change equality, which compares the values as strings
+		{"account.value = '5'", []string{"5"}},
+		{"account.value = 1.5", []string{"1.5"}},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+			require.NoError(t, err)
+
+			values := make([]string, 0, len(results))
+			for _, res := range results {
+				values = append(values, string(res.Result.Events[0].Attributes[0].Value))
+			}
+			assert.ElementsMatch(t, tc.expected, values)
+		})
+	}
+}
+
+func TestTxSearchLimits(t *testing.T) {
+	store := dbm.NewMemDB()
+	for i := 1; i <= 10; i++ {
+		owner := "Bob"
+		if i%2 == 0 {
+			owner = "Ivan"
+		}
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: []byte("number"), Value: []byte(fmt.Sprint(i)), Index: true},
+				{Key: []byte("owner"), Value: []byte(owner), Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Height = int64(i)
+		require.NoError(t, NewTxIndex(store).Index([]*abci.TxResult{txResult}))
+	}
+
+	ctx := context.Background()
+	metrics := indexer.NopMetrics()
+	scanLimitHits := generic.NewCounter("scan_limit_hits")
+	metrics.TxSearchScanLimitHits = scanLimitHits
+
+	isOwnedByIvan := func(res *abci.TxResult) bool {
+		return string(res.Result.Events[0].Attributes[1].Value) == "Ivan"
+	}
+
+	// without limits, all the matches are returned
+	results, err := NewTxIndex(store).Search(ctx, query.MustCompile(`account.owner = 'Ivan'`))
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+
+	// the limit is only reached if keys are left unscanned
+	txi := NewTxIndex(store, WithMaxScannedKeys(5), WithMetrics(metrics))
+	results, err = txi.Search(ctx, query.MustCompile(`account.owner = 'Ivan'`))
+	require.NoError(t, err)
+	assert.Len(t, results, 5)
+	assert.Zero(t, scanLimitHits.Value())
+
+	txi = NewTxIndex(store, WithMaxScannedKeys(3), WithMetrics(metrics))
+	results, err = txi.Search(ctx, query.MustCompile(`account.owner = 'Ivan'`))
+	require.ErrorIs(t, err, indexer.ErrSearchTruncated)
+	assert.Len(t, results, 3)
+	assert.EqualValues(t, 1, scanLimitHits.Value())
+
+	// the conditions that weren't scanned are still applied
+	txi = NewTxIndex(store, WithMaxScannedKeys(7), WithMetrics(metrics))
+	results, err = txi.Search(ctx, query.MustCompile(`account.number >= 1 AND account.owner = 'Ivan'`))
+	require.ErrorIs(t, err, indexer.ErrSearchTruncated)
+	require.NotEmpty(t, results)
+	assert.Less(t, len(results), 5)
+	for _, res := range results {
+		assert.True(t, isOwnedByIvan(res), res.Height)
+	}
+	assert.EqualValues(t, 2, scanLimitHits.Value())
+
+	// the number of results is capped, keeping the lowest heights
+	txi = NewTxIndex(store, WithMaxResults(2), WithMetrics(metrics))
+	for i := 0; i < 10; i++ {
+		results, err = txi.Search(ctx, query.MustCompile(`account.owner = 'Ivan'`))
+		require.ErrorIs(t, err, indexer.ErrSearchTruncated)
+		require.Len(t, results, 2)
+		assert.EqualValues(t, 2, results[0].Height)
+		assert.EqualValues(t, 4, results[1].Height)
+	}
+	assert.EqualValues(t, 2, scanLimitHits.Value())
+
+	results, err = txi.Search(ctx, query.MustCompile(`account.owner = 'Ivan' AND account.number = 4`))
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestTxEvents(t *testing.T) {
+	txIndexer := NewTxIndex(dbm.NewMemDB())
+
+	txResult1 := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: []byte("number"), Value: []byte("1"), Index: true}}},
+		{Type: "", Attributes: []abci.EventAttribute{{Key: []byte("not_allowed"), Value: []byte("Vlad"), Index: true}}},
+	})
+	txResult2 := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{
+			{Key: []byte("number"), Value: []byte("2"), Index: true},
+			{Key: []byte("owner"), Value: []byte("Ivan"), Index: false},
+			{Key: []byte("memo"), Value: []byte("hi"), Index: true},
+		}},
+		{Type: "transfer"},
+	})
+	txResult2.Tx = types.Tx("BYE BYE WORLD")
+	txResult2.Index = 1
+	require.NoError(t, txIndexer.Index([]*abci.TxResult{txResult1, txResult2}))
+
+	evs, err := txIndexer.Events(types.Tx(txResult1.Tx).Hash())
+	require.NoError(t, err)
+	require.NotNil(t, evs)
+	assert.EqualValues(t, 1, evs.Height)
+	assert.EqualValues(t, 0, evs.Index)
+	require.Len(t, evs.Events, 2)
+	assert.EqualValues(t, 0, evs.Events[0].Index)
+	assert.Equal(t, []string{"account.number"}, evs.Events[0].CompositeKeys)
+	assert.EqualValues(t, 1, evs.Events[1].Index)
+	assert.Empty(t, evs.Events[1].CompositeKeys)
+
+	// The events of the second tx are indexed after those of the first.
+	evs, err = txIndexer.Events(types.Tx(txResult2.Tx).Hash())
+	require.NoError(t, err)
+	require.NotNil(t, evs)
+	assert.EqualValues(t, 1, evs.Index)
+	require.Len(t, evs.Events, 2)
+	assert.EqualValues(t, 2, evs.Events[0].Index)
+	assert.Equal(t, txResult2.Result.Events[0], evs.Events[0].Event)
+	assert.Equal(t, []string{"account.number", "account.memo"}, evs.Events[0].CompositeKeys)
+	assert.EqualValues(t, 3, evs.Events[1].Index)
+	assert.Equal(t, "transfer", evs.Events[1].Event.Type)
+	assert.Empty(t, evs.Events[1].CompositeKeys)
+
+	evs, err = txIndexer.Events(types.Tx("UNKNOWN").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, evs)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -32,3 +32,8 @@ func (txi *TxIndex) Index(results []*abci.TxResult) error {
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return []*abci.TxResult{}, nil
 }
+
+// Events on a TxIndex is disabled and returns an error when invoked.
+func (txi *TxIndex) Events(hash []byte) (*indexer.TxEvents, error) {
+	return nil, errors.New(`indexing is disabled (set 'tx_index = "kv"' in config)`)
+}
@@ -212,6 +212,11 @@ func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	_m.Called(block, blockParts, seenCommit)
 }
 
+// SaveBlockWithAggregatedCommit provides a mock function with given fields: block, blockParts, seenCommit
+func (_m *BlockStore) SaveBlockWithAggregatedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit) {
+	_m.Called(block, blockParts, seenCommit)
+}
+
 // SaveBlockWithExtendedCommit provides a mock function with given fields: block, blockParts, seenCommit
 func (_m *BlockStore) SaveBlockWithExtendedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit) {
 	_m.Called(block, blockParts, seenCommit)
@@ -44,6 +44,29 @@ func (_m *EvidencePool) CheckEvidence(_a0 context.Context, _a1 types.EvidenceLis
 	return r0
 }
 
+// ExpiredEvidence provides a mock function with given fields:
+func (_m *EvidencePool) ExpiredEvidence() ([]types.Evidence, error) {
+	ret := _m.Called()
+
+	var r0 []types.Evidence
+	if rf, ok := ret.Get(0).(func() []types.Evidence); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Evidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PendingEvidence provides a mock function with given fields: maxBytes
 func (_m *EvidencePool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	ret := _m.Called(maxBytes)
@@ -30,6 +30,20 @@ func (_m *Store) Bootstrap(_a0 state.State) error {
 	return r0
 }
 
+// ClearRollback provides a mock function with given fields:
+func (_m *Store) ClearRollback() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Store) Close() error {
 	ret := _m.Called()
@@ -109,6 +123,27 @@ func (_m *Store) LoadFinalizeBlockResponses(_a0 int64) (*abcitypes.ResponseFinal
 	return r0, r1
 }
 
+// LoadRollbackHeight provides a mock function with given fields:
+func (_m *Store) LoadRollbackHeight() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -132,6 +167,36 @@ func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	return r0, r1
 }
 
+// LoadValidatorsPage provides a mock function with given fields: height, offset, limit, addressPrefix
+func (_m *Store) LoadValidatorsPage(height int64, offset int, limit int, addressPrefix []byte) ([]*types.Validator, int, error) {
+	ret := _m.Called(height, offset, limit, addressPrefix)
+
+	var r0 []*types.Validator
+	if rf, ok := ret.Get(0).(func(int64, int, int, []byte) []*types.Validator); ok {
+		r0 = rf(height, offset, limit, addressPrefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Validator)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int64, int, int, []byte) int); ok {
+		r1 = rf(height, offset, limit, addressPrefix)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int, int, []byte) error); ok {
+		r2 = rf(height, offset, limit, addressPrefix)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PruneStates provides a mock function with given fields: _a0
 func (_m *Store) PruneStates(_a0 int64) error {
 	ret := _m.Called(_a0)
@@ -174,6 +239,20 @@ func (_m *Store) SaveFinalizeBlockResponses(_a0 int64, _a1 *abcitypes.ResponseFi
 	return r0
 }
 
+// SaveRollback provides a mock function with given fields: _a0, _a1
+func (_m *Store) SaveRollback(_a0 state.State, _a1 int64) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(state.State, int64) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveValidatorSets provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Store) SaveValidatorSets(_a0 int64, _a1 int64, _a2 *types.ValidatorSet) error {
 	ret := _m.Called(_a0, _a1, _a2)
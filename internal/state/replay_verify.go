@@ -0,0 +1,134 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// AppHashMismatch describes a block after which the app hash computed by the
+// app differs from the one recorded by the chain.
+type AppHashMismatch struct {
+	// Height is the height of the last block executed by the app.
+	Height int64
+	// Recorded is the app hash recorded in the header of the next block, or in
+	// the state for the latest block.
+	Recorded []byte
+	// Computed is the app hash returned by the app.
+	Computed []byte
+}
+
+// ReplayVerifyResult describes the outcome of VerifyReplay.
+type ReplayVerifyResult struct {
+	// StartHeight and EndHeight are the first and last heights to replay.
+	StartHeight int64
+	EndHeight   int64
+	// Replayed is the number of blocks executed by the app.
+	Replayed int64
+	// Mismatch is the first mismatching app hash, or nil if all app hashes
+	// matched.
+	Mismatch *AppHashMismatch
+}
+
+// VerifyReplay executes the blocks following appHeight up to endHeight against
+// the app, and compares the app hash it returns for each block to the one
+// recorded by the chain, stopping at the first mismatch. appHeight and appHash
+// are the last block height and app hash reported by the app, which is
+// checked first. An endHeight of zero replays up to the latest block with a
+// recorded app hash.
+//
+// Blocks are executed with ExecCommitBlock, which neither validates nor
+// mutates the state and block stores.
+func VerifyReplay(
+	ctx context.Context,
+	logger log.Logger,
+	appClient abciclient.Client,
+	stateStore Store,
+	blockStore BlockStore,
+	appHeight int64,
+	appHash []byte,
+	endHeight int64,
+) (*ReplayVerifyResult, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+
+	lastHeight := blockStore.Height()
+	if state.LastBlockHeight < lastHeight {
+		// The app hash of the latest block is only recorded once the block is
+		// applied to the state.
+		lastHeight = state.LastBlockHeight
+	}
+	switch {
+	case endHeight == 0:
+		endHeight = lastHeight
+	case endHeight > lastHeight:
+		return nil, fmt.Errorf("end height %d is above the latest block with a recorded app hash (%d)",
+			endHeight, lastHeight)
+	}
+
+	startHeight := appHeight + 1
+	if startHeight < state.InitialHeight {
+		startHeight = state.InitialHeight
+	}
+	if base := blockStore.Base(); startHeight < base {
+		return nil, ErrAppBlockHeightTooLow{AppHeight: appHeight, StoreBase: base}
+	}
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("nothing to replay: the app is at height %d and the end height is %d",
+			appHeight, endHeight)
+	}
+
+	res := &ReplayVerifyResult{StartHeight: startHeight, EndHeight: endHeight}
+
+	// The app hash the app starts from. If the app was just initialized and
+	// returned no app hash, the genesis one is recorded instead.
+	if appHeight >= state.InitialHeight || len(appHash) > 0 {
+		if res.Mismatch, err = checkRecordedAppHash(blockStore, state, startHeight-1, appHash); err != nil || res.Mismatch != nil {
+			return res, err
+		}
+	}
+
+	for height := startHeight; height <= endHeight; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return res, ErrUnknownBlock{Height: height}
+		}
+		appHash, err = ExecCommitBlock(ctx, nil, appClient, block, logger, stateStore, state.InitialHeight, state)
+		if err != nil {
+			return res, fmt.Errorf("failed to execute block %d: %w", height, err)
+		}
+		res.Replayed++
+
+		if res.Mismatch, err = checkRecordedAppHash(blockStore, state, height, appHash); err != nil || res.Mismatch != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// checkRecordedAppHash compares appHash to the app hash recorded after the
+// block at height, returning the mismatch if they differ.
+func checkRecordedAppHash(blockStore BlockStore, state State, height int64, appHash []byte) (*AppHashMismatch, error) {
+	var recorded []byte
+	if meta := blockStore.LoadBlockMeta(height + 1); meta != nil {
+		recorded = meta.Header.AppHash
+	} else if height == state.LastBlockHeight {
+		recorded = state.AppHash
+	} else {
+		return nil, fmt.Errorf("no app hash recorded for height %d", height)
+	}
+
+	if !bytes.Equal(recorded, appHash) {
+		return &AppHashMismatch{Height: height, Recorded: recorded, Computed: appHash}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,108 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abciclientmocks "github.com/tendermint/tendermint/abci/client/mocks"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyReplay(t *testing.T) {
+	const lastHeight = 10
+	appHash := func(height int64) []byte { return []byte(fmt.Sprintf("app hash %d", height)) }
+
+	// The block at each height records the app hash after the previous one.
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(lastHeight))
+	blockStore.On("LoadBlock", mock.AnythingOfType("int64")).Return(func(height int64) *types.Block {
+		return &types.Block{
+			Header:     types.Header{Height: height, AppHash: appHash(height - 1)},
+			LastCommit: &types.Commit{},
+		}
+	})
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(func(height int64) *types.BlockMeta {
+		if height > lastHeight {
+			return nil
+		}
+		return &types.BlockMeta{Header: types.Header{Height: height, AppHash: appHash(height - 1)}}
+	})
+
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(state.State{
+		InitialHeight:   1,
+		LastBlockHeight: lastHeight,
+		AppHash:         appHash(lastHeight),
+		Validators:      types.NewValidatorSet(nil),
+	}, nil)
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(types.NewValidatorSet(nil), nil)
+
+	// newApp returns an app computing the recorded app hashes, up to the
+	// height it diverges at.
+	newApp := func(divergeAt int64) *abciclientmocks.Client {
+		app := &abciclientmocks.Client{}
+		app.On("FinalizeBlock", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, req *abci.RequestFinalizeBlock) *abci.ResponseFinalizeBlock {
+				if req.Height == divergeAt {
+					return &abci.ResponseFinalizeBlock{AppHash: []byte("nondeterministic")}
+				}
+				return &abci.ResponseFinalizeBlock{AppHash: appHash(req.Height)}
+			}, nil)
+		app.On("Commit", mock.Anything).Return(&abci.ResponseCommit{}, nil)
+		return app
+	}
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+
+	// all app hashes match
+	res, err := state.VerifyReplay(ctx, logger, newApp(0), stateStore, blockStore, 2, appHash(2), 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, res.StartHeight)
+	assert.EqualValues(t, lastHeight, res.EndHeight)
+	assert.EqualValues(t, lastHeight-2, res.Replayed)
+	assert.Nil(t, res.Mismatch)
+
+	// the app diverges at height 6
+	res, err = state.VerifyReplay(ctx, logger, newApp(6), stateStore, blockStore, 0, nil, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.StartHeight)
+	assert.EqualValues(t, 6, res.Replayed)
+	require.NotNil(t, res.Mismatch)
+	assert.EqualValues(t, 6, res.Mismatch.Height)
+	assert.Equal(t, appHash(6), res.Mismatch.Recorded)
+	assert.Equal(t, []byte("nondeterministic"), res.Mismatch.Computed)
+
+	// the app starts from a diverging app hash
+	app := newApp(0)
+	res, err = state.VerifyReplay(ctx, logger, app, stateStore, blockStore, 4, []byte("other"), 0)
+	require.NoError(t, err)
+	assert.Zero(t, res.Replayed)
+	require.NotNil(t, res.Mismatch)
+	assert.EqualValues(t, 4, res.Mismatch.Height)
+	app.AssertNotCalled(t, "FinalizeBlock", mock.Anything, mock.Anything)
+
+	// the end height is bounded by the latest recorded app hash
+	_, err = state.VerifyReplay(ctx, logger, newApp(0), stateStore, blockStore, 2, appHash(2), lastHeight+1)
+	require.Error(t, err)
+	res, err = state.VerifyReplay(ctx, logger, newApp(0), stateStore, blockStore, 2, appHash(2), 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, res.Replayed)
+
+	// the app is already at the end height
+	_, err = state.VerifyReplay(ctx, logger, newApp(0), stateStore, blockStore, lastHeight, appHash(lastHeight), 0)
+	require.Error(t, err)
+
+	// no store is written to
+	stateStore.AssertNotCalled(t, "Save", mock.Anything)
+	blockStore.AssertNotCalled(t, "SaveBlock", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -24,18 +24,54 @@ func resetPrivValidatorConfig(privValidatorConfig config.PrivValidatorConfig) er
 	return nil
 }
 
-// Rollback overwrites the current Tendermint state (height n) with the most
-// recent previous state (height n - 1).
+// RollbackResult describes the state a node was left at by Rollback.
+type RollbackResult struct {
+	// Height is the last block height of the resulting state.
+	Height int64
+	// AppHash is the app hash of the resulting state.
+	AppHash []byte
+	// ValidatorsHash is the hash of the validator set of the resulting state.
+	ValidatorsHash []byte
+	// RemovedBlock is true if blocks were removed from the block store.
+	RemovedBlock bool
+	// RolledBackHeights lists the heights that were rolled back, in
+	// descending order. It is empty if only an unapplied block was discarded.
+	RolledBackHeights []int64
+}
+
+// Rollback overwrites the current Tendermint state (height n) with the state at
+// targetHeight. A targetHeight of zero rolls back a single height to n - 1.
+// Every intermediate state is computed and validated before anything is
+// persisted, so if targetHeight cannot be reached the state store is left
+// untouched.
+// If removeBlock is true the rolled back blocks are removed from the block store
+// and if resetPrivVal is true the private validator's last sign state is reset.
 // Note that this function does not affect application state.
-func Rollback(bs BlockStore, ss Store, removeBlock bool, privValidatorConfig *config.PrivValidatorConfig) (int64, []byte, error) {
+func Rollback(
+	bs BlockStore,
+	ss Store,
+	removeBlock bool,
+	resetPrivVal bool,
+	privValidatorConfig *config.PrivValidatorConfig,
+	targetHeight int64,
+) (*RollbackResult, error) {
 	// Only the latest state is stored
 	latestState, err := ss.Load()
 	fmt.Printf("Initial tendermint state height=%d, appHash=%X, lastResultHash=%X\n", latestState.LastBlockHeight, latestState.AppHash, latestState.LastResultsHash)
 	if err != nil {
-		return -1, nil, err
+		return nil, err
 	}
 	if latestState.IsEmpty() {
-		return -1, nil, errors.New("no state found")
+		return nil, errors.New("no state found")
+	}
+
+	// finish any earlier rollback that was interrupted before touching the stores again
+	recoveredHeight, err := RecoverRollback(bs, ss)
+	if err != nil {
+		return nil, err
+	}
+	if recoveredHeight > 0 {
+		fmt.Printf("Completed interrupted rollback to height=%d\n", recoveredHeight)
 	}
 
 	height := bs.Height()
@@ -46,40 +82,221 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool, privValidatorConfig *co
 		fmt.Printf("Invalid state in the latest block height=%d, removing it first \n", height)
 		if removeBlock {
 			if err := bs.DeleteLatestBlock(); err != nil {
-				return -1, nil, fmt.Errorf("failed to remove final block from blockstore: %w", err)
+				return nil, fmt.Errorf("failed to remove final block from blockstore: %w", err)
 			}
 		}
-		return latestState.LastBlockHeight, latestState.AppHash, nil
+		return &RollbackResult{
+			Height:         latestState.LastBlockHeight,
+			AppHash:        latestState.AppHash,
+			ValidatorsHash: latestState.Validators.Hash(),
+			RemovedBlock:   removeBlock,
+		}, nil
+	}
+	// If the block store diverged further, e.g. because the state store was restored from
+	// an older backup, none of the blocks above the state height were applied to it. These
+	// can only be removed, bringing the block store back to the state height.
+	if height > latestState.LastBlockHeight+1 && removeBlock {
+		fmt.Printf("Blocks above the state height=%d were never applied, removing them first\n", latestState.LastBlockHeight)
+		if err := trimBlockStore(bs, ss, latestState.LastBlockHeight); err != nil {
+			return nil, err
+		}
+		return &RollbackResult{
+			Height:         latestState.LastBlockHeight,
+			AppHash:        latestState.AppHash,
+			ValidatorsHash: latestState.Validators.Hash(),
+			RemovedBlock:   true,
+		}, nil
+	}
+
+	rolledBackState, rolledBackHeights, err := rollbackToHeight(bs, ss, latestState, targetHeight)
+	if err != nil {
+		return nil, err
+	}
+	targetHeight = rolledBackState.LastBlockHeight
+
+	// If removeBlock is true then also remove the blocks associated with the rolled back states.
+	// This will mean both the last state and last block height is equal to targetHeight.
+	// The state is saved together with a record of the target height so that, should the
+	// process stop before the blocks are removed, RecoverRollback can finish the job.
+	if removeBlock {
+		if err := ss.SaveRollback(rolledBackState, targetHeight); err != nil {
+			return nil, fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+
+		if err := trimBlockStore(bs, ss, targetHeight); err != nil {
+			return nil, err
+		}
+	} else {
+		// persist the new state. This overrides the invalid one. NOTE: this will also
+		// persist the validator set and consensus params over the existing structures,
+		// but both should be the same
+		if err := ss.Save(rolledBackState); err != nil {
+			return nil, fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+	}
+
+	if resetPrivVal {
+		if err := resetPrivValidatorConfig(*privValidatorConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("Saved tendermint state height=%d, appHash=%X, lastResultHash=%X\n", rolledBackState.LastBlockHeight, rolledBackState.AppHash, rolledBackState.LastResultsHash)
+	return &RollbackResult{
+		Height:            rolledBackState.LastBlockHeight,
+		AppHash:           rolledBackState.AppHash,
+		ValidatorsHash:    rolledBackState.Validators.Hash(),
+		RemovedBlock:      removeBlock,
+		RolledBackHeights: rolledBackHeights,
+	}, nil
+}
+
+// RollbackPreview computes the state that Rollback would leave the node at for
+// the given targetHeight without modifying the state store, the block store or
+// the private validator. If the block store holds a block that was never
+// applied to the state, Rollback would only discard that block and the current
+// state is returned.
+func RollbackPreview(bs BlockStore, ss Store, targetHeight int64) (State, error) {
+	latestState, err := ss.Load()
+	if err != nil {
+		return State{}, err
+	}
+	if latestState.IsEmpty() {
+		return State{}, errors.New("no state found")
+	}
+
+	if bs.Height() == latestState.LastBlockHeight+1 {
+		return latestState, nil
 	}
 
+	rolledBackState, _, err := rollbackToHeight(bs, ss, latestState, targetHeight)
+	return rolledBackState, err
+}
+
+// rollbackToHeight computes the state at targetHeight, or at one below the
+// latest height if targetHeight is zero, by walking back one height at a time
+// in memory. Nothing is persisted. Returns the rolled back state along with the
+// heights that were rolled back, in descending order.
+func rollbackToHeight(bs BlockStore, ss Store, latestState State, targetHeight int64) (State, []int64, error) {
 	// If the state store isn't one below nor equal to the blockstore height than this violates the
 	// invariant
-	if height != latestState.LastBlockHeight {
-		return -1, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
+	if height := bs.Height(); height != latestState.LastBlockHeight {
+		return State{}, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
 			latestState.LastBlockHeight, height)
 	}
 
-	// state store height is equal to blockstore height. We're good to proceed with rolling back state
+	if targetHeight == 0 {
+		targetHeight = latestState.LastBlockHeight - 1
+	}
+	if targetHeight >= latestState.LastBlockHeight || targetHeight < latestState.InitialHeight {
+		return State{}, nil, fmt.Errorf("target height (%d) must be at least the initial height (%d) and below the current height (%d)",
+			targetHeight, latestState.InitialHeight, latestState.LastBlockHeight)
+	}
+
+	// state store height is equal to blockstore height. We're good to proceed with rolling back state.
+	// Walk back one height at a time so that nothing is persisted unless every height down to the
+	// target can be rolled back.
+	var (
+		rolledBackState   = latestState
+		rolledBackHeights []int64
+		err               error
+	)
+	for rolledBackState.LastBlockHeight > targetHeight {
+		rolledBackHeights = append(rolledBackHeights, rolledBackState.LastBlockHeight)
+		rolledBackState, err = rollbackOneHeight(bs, ss, rolledBackState)
+		if err != nil {
+			return State{}, nil, err
+		}
+	}
+	return rolledBackState, rolledBackHeights, nil
+}
+
+// CheckStoreHeights verifies that the heights of the block store and of the
+// state store haven't diverged, and should be called on startup after
+// RecoverRollback. Since a block is saved before the state it results in, the
+// block store may be one block ahead after a crash: this is repaired by the
+// handshake with the app, which applies that block. Any other divergence can't
+// be repaired automatically, and an ErrStoreHeightsDiverged is returned.
+func CheckStoreHeights(bs BlockStore, ss Store) error {
+	latestState, err := ss.Load()
+	if err != nil {
+		return err
+	}
+
+	// Neither a node without a state yet, nor one that was state synced and
+	// has no blocks, can have diverged.
+	storeHeight := bs.Height()
+	if latestState.IsEmpty() || storeHeight == 0 {
+		return nil
+	}
+
+	if storeHeight < latestState.LastBlockHeight || storeHeight > latestState.LastBlockHeight+1 {
+		return ErrStoreHeightsDiverged{StateHeight: latestState.LastBlockHeight, StoreHeight: storeHeight}
+	}
+	return nil
+}
+
+// RecoverRollback completes a hard rollback that was interrupted after the
+// rolled back state was saved but before the block store was trimmed to the
+// same height. It returns the height the rollback was completed to, or 0 if
+// no rollback was in progress, and should be called before the stores are used
+// on startup.
+func RecoverRollback(bs BlockStore, ss Store) (int64, error) {
+	targetHeight, err := ss.LoadRollbackHeight()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load rollback height: %w", err)
+	}
+	if targetHeight == 0 {
+		return 0, nil
+	}
+
+	if err := trimBlockStore(bs, ss, targetHeight); err != nil {
+		return 0, err
+	}
+	return targetHeight, nil
+}
+
+// trimBlockStore removes blocks until the block store height is equal to
+// targetHeight and then clears the rollback record from the state store.
+func trimBlockStore(bs BlockStore, ss Store, targetHeight int64) error {
+	for height := bs.Height(); height > targetHeight; height = bs.Height() {
+		if err := bs.DeleteLatestBlock(); err != nil {
+			return fmt.Errorf("failed to remove final block from blockstore: %w", err)
+		}
+		if bs.Height() >= height {
+			return fmt.Errorf("failed to remove block at height %d from blockstore", height)
+		}
+	}
+
+	if err := ss.ClearRollback(); err != nil {
+		return fmt.Errorf("failed to clear rollback height: %w", err)
+	}
+	return nil
+}
+
+// rollbackOneHeight builds the state at height n - 1 from the state at height n
+// without persisting anything.
+func rollbackOneHeight(bs BlockStore, ss Store, latestState State) (State, error) {
 	rollbackHeight := latestState.LastBlockHeight - 1
 	rollbackBlock := bs.LoadBlockMeta(rollbackHeight)
 	if rollbackBlock == nil {
-		return -1, nil, fmt.Errorf("block at height %d not found", rollbackHeight)
+		return State{}, fmt.Errorf("block at height %d not found", rollbackHeight)
 	}
 
 	// we also need to retrieve the latest block because the app hash and last results hash is only agreed upon in the following block
 	latestBlock := bs.LoadBlockMeta(latestState.LastBlockHeight)
 	if latestBlock == nil {
-		return -1, nil, fmt.Errorf("block at height %d not found", latestState.LastBlockHeight)
+		return State{}, fmt.Errorf("block at height %d not found", latestState.LastBlockHeight)
 	}
 
 	previousLastValidatorSet, err := ss.LoadValidators(rollbackHeight)
 	if err != nil {
-		return -1, nil, err
+		return State{}, err
 	}
 
 	previousParams, err := ss.LoadConsensusParams(rollbackHeight + 1)
 	if err != nil {
-		return -1, nil, err
+		return State{}, err
 	}
 
 	valChangeHeight := latestState.LastHeightValidatorsChanged
@@ -102,7 +319,7 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool, privValidatorConfig *co
 	fmt.Printf("Latest block Height=%d, appHash=%X\n", latestBlock.Header.Height, latestBlock.Header.AppHash)
 
 	// build the new state from the old state and the prior block
-	rolledBackState := State{
+	return State{
 		Version: Version{
 			Consensus: version.Consensus{
 				Block: version.BlockProtocol,
@@ -128,28 +345,5 @@ func Rollback(bs BlockStore, ss Store, removeBlock bool, privValidatorConfig *co
 
 		ConsensusParams:                  previousParams,
 		LastHeightConsensusParamsChanged: paramsChangeHeight,
-	}
-
-	// persist the new state. This overrides the invalid one. NOTE: this will also
-	// persist the validator set and consensus params over the existing structures,
-	// but both should be the same
-	if err := ss.Save(rolledBackState); err != nil {
-		return -1, nil, fmt.Errorf("failed to save rolled back state: %w", err)
-	}
-
-	// If removeBlock is true then also remove the block associated with the previous state.
-	// This will mean both the last state and last block height is equal to n - 1
-	if removeBlock {
-		if err := bs.DeleteLatestBlock(); err != nil {
-			return -1, nil, fmt.Errorf("failed to remove final block from blockstore: %w", err)
-		}
-
-		err = resetPrivValidatorConfig(*privValidatorConfig)
-		if err != nil {
-			return -1, nil, err
-		}
-	}
-
-	fmt.Printf("Saved tendermint state height=%d, appHash=%X, lastResultHash=%X\n", lastBlockHeight, rolledBackState.AppHash, rolledBackState.LastResultsHash)
-	return lastBlockHeight, rolledBackState.AppHash, nil
+	}, nil
 }
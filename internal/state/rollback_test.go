@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/internal/state/mocks"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/privval"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
@@ -74,10 +76,13 @@ func TestRollback(t *testing.T) {
 	blockStore.On("Height").Return(nextHeight)
 
 	// rollback the state
-	rollbackHeight, rollbackHash, err := state.Rollback(blockStore, stateStore, false, cfg.PrivValidator)
+	res, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 0)
 	require.NoError(t, err)
-	require.EqualValues(t, height, rollbackHeight)
-	require.EqualValues(t, initialState.AppHash, rollbackHash)
+	require.EqualValues(t, height, res.Height)
+	require.Equal(t, []int64{nextHeight}, res.RolledBackHeights)
+	require.EqualValues(t, initialState.AppHash, res.AppHash)
+	require.EqualValues(t, initialState.Validators.Hash(), res.ValidatorsHash)
+	require.False(t, res.RemovedBlock)
 	blockStore.AssertExpectations(t)
 
 	// assert that we've recovered the prior state
@@ -91,7 +96,7 @@ func TestRollbackNoState(t *testing.T) {
 	stateStore := state.NewStore(dbm.NewMemDB())
 	blockStore := &mocks.BlockStore{}
 
-	_, _, err := state.Rollback(blockStore, stateStore, false, cfg.PrivValidator)
+	_, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 0)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "no state found")
 }
@@ -106,7 +111,7 @@ func TestRollbackNoBlocks(t *testing.T) {
 	blockStore.On("LoadBlockMeta", height).Return(nil)
 	blockStore.On("LoadBlockMeta", height-1).Return(nil)
 
-	_, _, err := state.Rollback(blockStore, stateStore, false, cfg.PrivValidator)
+	_, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 0)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "block at height 99 not found")
 }
@@ -118,11 +123,221 @@ func TestRollbackDifferentStateHeight(t *testing.T) {
 	blockStore.On("Height").Return(height + 2)
 
 	cfg, _ := rpctest.CreateConfig(t, t.Name())
-	_, _, err := state.Rollback(blockStore, stateStore, false, cfg.PrivValidator)
+	_, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 0)
 	require.Error(t, err)
 	require.Equal(t, err.Error(), "statestore height (100) is not one below or equal to blockstore height (102)")
 }
 
+func TestRollbackMultipleHeights(t *testing.T) {
+	const height int64 = 100
+	cfg, _ := rpctest.CreateConfig(t, t.Name())
+	blockStore := &mocks.BlockStore{}
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	// advance the state by two heights
+	midState := initialState.Copy()
+	midState.LastBlockHeight = height + 1
+	midState.LastBlockID = factory.MakeBlockID()
+	midState.AppHash = factory.RandomHash()
+	midState.LastResultsHash = factory.RandomHash()
+	midState.LastValidators = initialState.Validators
+	midState.Validators = initialState.NextValidators
+	midState.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(midState))
+
+	latestState := midState.Copy()
+	latestState.LastBlockHeight = height + 2
+	latestState.LastBlockID = factory.MakeBlockID()
+	latestState.AppHash = factory.RandomHash()
+	latestState.LastResultsHash = factory.RandomHash()
+	latestState.LastValidators = midState.Validators
+	latestState.Validators = midState.NextValidators
+	latestState.NextValidators = midState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(latestState))
+
+	blockStore.On("Height").Return(height + 2)
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height},
+	})
+	blockStore.On("LoadBlockMeta", height+1).Return(&types.BlockMeta{
+		BlockID: midState.LastBlockID,
+		Header: types.Header{
+			Height:          height + 1,
+			AppHash:         initialState.AppHash,
+			LastResultsHash: initialState.LastResultsHash,
+		},
+	})
+	blockStore.On("LoadBlockMeta", height+2).Return(&types.BlockMeta{
+		BlockID: latestState.LastBlockID,
+		Header: types.Header{
+			Height:          height + 2,
+			AppHash:         midState.AppHash,
+			LastResultsHash: midState.LastResultsHash,
+		},
+	})
+
+	res, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, height)
+	require.NoError(t, err)
+	require.Equal(t, height, res.Height)
+	require.EqualValues(t, initialState.AppHash, res.AppHash)
+	require.Equal(t, []int64{height + 2, height + 1}, res.RolledBackHeights)
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, height, loadedState.LastBlockHeight)
+	require.Equal(t, initialState.LastBlockID, loadedState.LastBlockID)
+	require.EqualValues(t, initialState.AppHash, loadedState.AppHash)
+	require.EqualValues(t, initialState.LastResultsHash, loadedState.LastResultsHash)
+	require.Equal(t, initialState.Validators.Hash(), loadedState.Validators.Hash())
+	require.Equal(t, initialState.NextValidators.Hash(), loadedState.NextValidators.Hash())
+}
+
+func TestRollbackMultipleHeightsIsAtomic(t *testing.T) {
+	const height int64 = 100
+	cfg, _ := rpctest.CreateConfig(t, t.Name())
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(height)
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height, AppHash: factory.RandomHash()},
+	})
+	blockStore.On("LoadBlockMeta", height-1).Return(&types.BlockMeta{
+		BlockID: factory.MakeBlockID(),
+		Header:  types.Header{Height: height - 1},
+	})
+	blockStore.On("LoadBlockMeta", height-2).Return(nil)
+
+	_, err = state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, height-3)
+	require.Error(t, err)
+
+	// the first height could have been rolled back but the state must not have changed
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, initialState, loadedState)
+}
+
+func TestRollbackPreview(t *testing.T) {
+	const height int64 = 100
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	nextState := initialState.Copy()
+	nextState.LastBlockHeight = height + 1
+	nextState.LastBlockID = factory.MakeBlockID()
+	nextState.AppHash = factory.RandomHash()
+	nextState.LastResultsHash = factory.RandomHash()
+	nextState.LastValidators = initialState.Validators
+	nextState.Validators = initialState.NextValidators
+	nextState.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(nextState))
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(height + 1)
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height},
+	})
+	blockStore.On("LoadBlockMeta", height+1).Return(&types.BlockMeta{
+		BlockID: nextState.LastBlockID,
+		Header: types.Header{
+			Height:          height + 1,
+			AppHash:         initialState.AppHash,
+			LastResultsHash: initialState.LastResultsHash,
+		},
+	})
+
+	previewState, err := state.RollbackPreview(blockStore, stateStore, 0)
+	require.NoError(t, err)
+	require.Equal(t, height, previewState.LastBlockHeight)
+	require.EqualValues(t, initialState.AppHash, previewState.AppHash)
+	require.Equal(t, initialState.Validators.Hash(), previewState.Validators.Hash())
+
+	// nothing should have been persisted
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, nextState, loadedState)
+	blockStore.AssertNotCalled(t, "DeleteLatestBlock")
+}
+
+// TestRollbackAppHashAndLastResultsHash checks that the rolled back state takes
+// both hashes from the header of block n: the app hash and last results hash of
+// the state at height n - 1 are only agreed upon in the following block.
+func TestRollbackAppHashAndLastResultsHash(t *testing.T) {
+	const height int64 = 100
+
+	for _, removeBlock := range []bool{false, true} {
+		removeBlock := removeBlock
+		t.Run(fmt.Sprintf("removeBlock=%t", removeBlock), func(t *testing.T) {
+			cfg, err := rpctest.CreateConfig(t, "rollback")
+			require.NoError(t, err)
+			blockStore, stateStore, initialState := setupTwoBlockChain(t, height)
+			require.NotEqual(t, initialState.AppHash, initialState.LastResultsHash)
+
+			res, err := state.Rollback(blockStore, stateStore, removeBlock, removeBlock, cfg.PrivValidator, 0)
+			require.NoError(t, err)
+			require.Equal(t, height, res.Height)
+			require.EqualValues(t, initialState.AppHash, res.AppHash)
+			require.Equal(t, removeBlock, res.RemovedBlock)
+
+			loadedState, err := stateStore.Load()
+			require.NoError(t, err)
+			require.Equal(t, height, loadedState.LastBlockHeight)
+			require.EqualValues(t, initialState.AppHash, loadedState.AppHash)
+			require.EqualValues(t, initialState.LastResultsHash, loadedState.LastResultsHash)
+
+			if removeBlock {
+				require.Equal(t, height, blockStore.Height())
+			} else {
+				require.Equal(t, height+1, blockStore.Height())
+			}
+		})
+	}
+}
+
+func TestRollbackSkipPrivValReset(t *testing.T) {
+	const height int64 = 100
+	cfg, err := rpctest.CreateConfig(t, t.Name())
+	require.NoError(t, err)
+	blockStore, stateStore, _ := setupTwoBlockChain(t, height)
+
+	filePV, err := privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	filePV.LastSignState.Height = height + 1
+	require.NoError(t, filePV.LastSignState.Save())
+
+	_, err = state.Rollback(blockStore, stateStore, true, false, cfg.PrivValidator, 0)
+	require.NoError(t, err)
+	require.Equal(t, height, blockStore.Height())
+
+	filePV, err = privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	require.Equal(t, height+1, filePV.LastSignState.Height)
+}
+
+func TestRollbackInvalidTargetHeight(t *testing.T) {
+	const height int64 = 100
+	cfg, _ := rpctest.CreateConfig(t, t.Name())
+	stateStore := setupStateStore(t, height)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(height)
+
+	_, err := state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, height)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "target height (100)")
+
+	_, err = state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 5)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "target height (5)")
+}
+
 func setupStateStore(t *testing.T, height int64) state.Store {
 	stateStore := state.NewStore(dbm.NewMemDB())
 	ctx, cancel := context.WithCancel(context.Background())
@@ -234,15 +449,17 @@ func TestRollbackHard(t *testing.T) {
 	require.NoError(t, err)
 	blockStore.SaveBlock(nextBlock, nextPartSet, &types.Commit{Height: nextBlock.Height})
 
-	rollbackHeight, rollbackHash, err := state.Rollback(
+	res, err := state.Rollback(
 		blockStore,
 		stateStore,
 		true,
+		true,
 		cfg.PrivValidator,
+		0,
 	)
 	require.NoError(t, err)
-	require.Equal(t, rollbackHeight, currState.LastBlockHeight)
-	require.Equal(t, rollbackHash, currState.AppHash)
+	require.Equal(t, res.Height, currState.LastBlockHeight)
+	require.Equal(t, res.AppHash, currState.AppHash)
 
 	// state should not have been changed
 	loadedState, err := stateStore.Load()
@@ -272,10 +489,165 @@ func TestRollbackHard(t *testing.T) {
 	}
 	require.NoError(t, stateStore.Save(nextState))
 
-	rollbackHeight, rollbackHash, err = state.Rollback(blockStore, stateStore, true, cfg.PrivValidator)
+	res, err = state.Rollback(blockStore, stateStore, true, true, cfg.PrivValidator, 0)
+	require.NoError(t, err)
+	require.Equal(t, res.Height, currState.LastBlockHeight)
+	require.Equal(t, res.AppHash, currState.AppHash)
+}
+
+func TestRecoverRollback(t *testing.T) {
+	const height int64 = 100
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := setupStateStore(t, height)
+	rolledBackState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	for h := height; h <= height+2; h++ {
+		block := &types.Block{
+			Header:     *factory.MakeHeader(t, &types.Header{Height: h}),
+			LastCommit: &types.Commit{Height: h - 1},
+		}
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	require.Equal(t, height+2, blockStore.Height())
+
+	// nothing to recover
+	recoveredHeight, err := state.RecoverRollback(blockStore, stateStore)
+	require.NoError(t, err)
+	require.Zero(t, recoveredHeight)
+	require.Equal(t, height+2, blockStore.Height())
+
+	// simulate a hard rollback that stopped after saving the state
+	require.NoError(t, stateStore.SaveRollback(rolledBackState, height))
+	recoveredHeight, err = state.RecoverRollback(blockStore, stateStore)
+	require.NoError(t, err)
+	require.Equal(t, height, recoveredHeight)
+	require.Equal(t, height, blockStore.Height())
+
+	rollbackHeight, err := stateStore.LoadRollbackHeight()
+	require.NoError(t, err)
+	require.Zero(t, rollbackHeight)
+}
+
+func TestCheckStoreHeights(t *testing.T) {
+	const height int64 = 100
+
+	testCases := []struct {
+		name        string
+		storeHeight int64
+		errMsg      string
+	}{
+		{"no blocks", 0, ""},
+		{"same height", height, ""},
+		{"block store one ahead", height + 1, ""},
+		{"block store two ahead", height + 2, "rollback --hard"},
+		{"block store one behind", height - 1, "missing"},
+		{"block store far behind", height - 10, "missing"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stateStore := setupStateStore(t, height)
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("Height").Return(tc.storeHeight)
+
+			err := state.CheckStoreHeights(blockStore, stateStore)
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, state.ErrStoreHeightsDiverged{StateHeight: height, StoreHeight: tc.storeHeight}, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	// there is nothing to compare the block store to without a state
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(height)
+	require.NoError(t, state.CheckStoreHeights(blockStore, state.NewStore(dbm.NewMemDB())))
+}
+
+func TestRollbackHardDivergedBlockStore(t *testing.T) {
+	const height int64 = 100
+	cfg, _ := rpctest.CreateConfig(t, t.Name())
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := setupStateStore(t, height)
+	latestState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	for h := height; h <= height+3; h++ {
+		block := &types.Block{
+			Header:     *factory.MakeHeader(t, &types.Header{Height: h}),
+			LastCommit: &types.Commit{Height: h - 1},
+		}
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: h})
+	}
+	require.Error(t, state.CheckStoreHeights(blockStore, stateStore))
+
+	// a soft rollback can't repair the block store
+	_, err = state.Rollback(blockStore, stateStore, false, false, cfg.PrivValidator, 0)
+	require.Error(t, err)
+	require.Equal(t, height+3, blockStore.Height())
+
+	res, err := state.Rollback(blockStore, stateStore, true, false, cfg.PrivValidator, 0)
+	require.NoError(t, err)
+	require.True(t, res.RemovedBlock)
+	require.Equal(t, height, res.Height)
+	require.Equal(t, latestState.AppHash, []byte(res.AppHash))
+	require.Equal(t, height, blockStore.Height())
+	require.NoError(t, state.CheckStoreHeights(blockStore, stateStore))
+
+	// the state is left untouched
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, latestState.LastBlockHeight, loadedState.LastBlockHeight)
+}
+
+// setupTwoBlockChain returns stores holding blocks at height and height + 1,
+// with the state at height + 1, along with the state at height.
+func setupTwoBlockChain(t *testing.T, height int64) (*store.BlockStore, state.Store, state.State) {
+	t.Helper()
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	rollbackBlock := &types.Block{
+		Header:     *factory.MakeHeader(t, &types.Header{Height: height}),
+		LastCommit: &types.Commit{Height: height - 1},
+	}
+	rollbackPartSet, err := rollbackBlock.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockStore.SaveBlock(rollbackBlock, rollbackPartSet, &types.Commit{Height: height})
+
+	latestBlock := &types.Block{
+		Header: *factory.MakeHeader(t, &types.Header{
+			Height:          height + 1,
+			AppHash:         initialState.AppHash,
+			LastResultsHash: initialState.LastResultsHash,
+			LastBlockID:     types.BlockID{Hash: rollbackBlock.Hash(), PartSetHeader: rollbackPartSet.Header()},
+		}),
+		LastCommit: &types.Commit{Height: height},
+	}
+	latestPartSet, err := latestBlock.MakePartSet(types.BlockPartSizeBytes)
 	require.NoError(t, err)
-	require.Equal(t, rollbackHeight, currState.LastBlockHeight)
-	require.Equal(t, rollbackHash, currState.AppHash)
+	blockStore.SaveBlock(latestBlock, latestPartSet, &types.Commit{Height: height + 1})
+
+	latestState := initialState.Copy()
+	latestState.LastBlockHeight = height + 1
+	latestState.LastBlockID = types.BlockID{Hash: latestBlock.Hash(), PartSetHeader: latestPartSet.Header()}
+	latestState.AppHash = factory.RandomHash()
+	latestState.LastResultsHash = factory.RandomHash()
+	latestState.LastValidators = initialState.Validators
+	latestState.Validators = initialState.NextValidators
+	latestState.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(latestState))
+
+	return blockStore, stateStore, initialState
 }
 
 func makeBlockIDRandom() types.BlockID {
@@ -28,6 +28,7 @@ type BlockStore interface {
 
 	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit)
 	SaveBlockWithExtendedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit)
+	SaveBlockWithAggregatedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit)
 
 	PruneBlocks(height int64) (uint64, error)
 
@@ -53,6 +54,7 @@ type EvidencePool interface {
 	AddEvidence(context.Context, types.Evidence) error
 	Update(context.Context, State, types.EvidenceList)
 	CheckEvidence(context.Context, types.EvidenceList) error
+	ExpiredEvidence() ([]types.Evidence, error)
 }
 
 // EmptyEvidencePool is an empty implementation of EvidencePool, useful for testing. It also complies
@@ -67,4 +69,5 @@ func (EmptyEvidencePool) Update(context.Context, State, types.EvidenceList) {}
 func (EmptyEvidencePool) CheckEvidence(ctx context.Context, evList types.EvidenceList) error {
 	return nil
 }
+func (EmptyEvidencePool) ExpiredEvidence() ([]types.Evidence, error)      { return nil, nil }
 func (EmptyEvidencePool) ReportConflictingVotes(voteA, voteB *types.Vote) {}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -272,10 +273,19 @@ func (state State) MakeBlock(
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, commit, evidence)
 
+	// A clock behind the median time of the last commit would make the block
+	// invalid, so the block time is at least the median time.
+	now := tmtime.Now()
+	if height > state.InitialHeight && commit != nil && state.ConsensusParams.Synchrony.MedianTimeEnabled(height) {
+		if medianTime := MedianTime(commit, state.LastValidators); now.Before(medianTime) {
+			now = medianTime
+		}
+	}
+
 	// Fill rest of header with state data.
 	block.Header.Populate(
 		state.Version.Consensus, state.ChainID,
-		tmtime.Now(), state.LastBlockID,
+		now, state.LastBlockID,
 		state.Validators.Hash(), state.NextValidators.Hash(),
 		state.ConsensusParams.HashConsensusParams(), state.AppHash, state.LastResultsHash,
 		proposerAddress,
@@ -284,6 +294,43 @@ func (state State) MakeBlock(
 	return block
 }
 
+// MedianTime computes the BFT median time of the commit: the timestamp of its
+// signatures for the block, weighted by the voting power of their validators,
+// that at least half of the power signed at or before. It returns the zero
+// time if none of the signatures for the block are from the validators.
+func MedianTime(commit *types.Commit, validators *types.ValidatorSet) time.Time {
+	type weightedTime struct {
+		time   time.Time
+		weight int64
+	}
+
+	var (
+		times      []weightedTime
+		totalPower int64
+	)
+	for _, sig := range commit.Signatures {
+		if !sig.ForBlock() {
+			continue
+		}
+		_, val := validators.GetByAddress(sig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+		times = append(times, weightedTime{sig.Timestamp, val.VotingPower})
+		totalPower += val.VotingPower
+	}
+
+	sort.SliceStable(times, func(i, j int) bool { return times[i].time.Before(times[j].time) })
+	median := totalPower / 2
+	for _, t := range times {
+		if median <= t.weight {
+			return t.time
+		}
+		median -= t.weight
+	}
+	return time.Time{}
+}
+
 //------------------------------------------------------------------------
 // Genesis
 
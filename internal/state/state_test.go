@@ -9,6 +9,7 @@ import (
 	mrand "math/rand"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	sm "github.com/tendermint/tendermint/internal/state"
 	statefactory "github.com/tendermint/tendermint/internal/state/test/factory"
+	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -1066,6 +1068,48 @@ func TestStateMakeBlock(t *testing.T) {
 	assert.Equal(t, proposerAddress, block.ProposerAddress)
 }
 
+func TestMedianTime(t *testing.T) {
+	vals := make([]*types.Validator, 4)
+	for i := range vals {
+		vals[i] = types.NewValidator(ed25519.GenPrivKey().PubKey(), int64(i+1)*10)
+	}
+	valSet := types.NewValidatorSet(vals)
+	now := tmtime.Now()
+
+	makeCommit := func(offsets ...time.Duration) *types.Commit {
+		commit := &types.Commit{Signatures: make([]types.CommitSig, valSet.Size())}
+		for i := range commit.Signatures {
+			if offsets[i] < 0 {
+				commit.Signatures[i] = types.NewCommitSigAbsent()
+				continue
+			}
+			_, val := valSet.GetByIndex(int32(i))
+			commit.Signatures[i] = types.CommitSig{
+				BlockIDFlag:      types.BlockIDFlagCommit,
+				ValidatorAddress: val.Address,
+				Timestamp:        now.Add(offsets[i]),
+			}
+		}
+		return commit
+	}
+	power := func(i int) time.Duration {
+		_, val := valSet.GetByIndex(int32(i))
+		return time.Duration(val.VotingPower) * time.Second
+	}
+
+	// Using the voting powers (40, 30, 20, 10) as offsets, the timestamps are
+	// in the order of the powers, and the median is that of the validator
+	// reaching half the total power.
+	assert.Equal(t, now.Add(power(1)), sm.MedianTime(makeCommit(power(0), power(1), power(2), power(3)), valSet))
+	// Out of order timestamps are sorted.
+	assert.Equal(t, now.Add(power(2)), sm.MedianTime(makeCommit(power(3), power(2), power(1), power(0)), valSet))
+	// Duplicate timestamps are weighted together.
+	assert.Equal(t, now, sm.MedianTime(makeCommit(0, 0, 0, time.Hour), valSet))
+	// Absent signatures don't count.
+	assert.Equal(t, now.Add(time.Hour), sm.MedianTime(makeCommit(-1, -1, time.Hour, 0), valSet))
+	assert.True(t, sm.MedianTime(&types.Commit{}, valSet).IsZero())
+}
+
 // TestConsensusParamsChangesSaveLoad tests saving and loading consensus params
 // with changes.
 func TestConsensusParamsChangesSaveLoad(t *testing.T) {
@@ -2,8 +2,10 @@ package state
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -28,9 +30,9 @@ const (
 
 // key prefixes
 // NB: Before modifying these, cross-check them with those in
-// * internal/store/store.go    [0..4, 13]
-// * internal/state/store.go    [5..8, 14]
-// * internal/evidence/pool.go  [9..10]
+// * internal/store/store.go    [0..4, 13, 17]
+// * internal/state/store.go    [5..8, 14..15, 18..19]
+// * internal/evidence/pool.go  [9..10, 16]
 // * light/store/db/db.go       [11..12]
 // TODO(thane): Move all these to their own package.
 // TODO: what about these (they already collide):
@@ -43,6 +45,9 @@ const (
 	prefixABCIResponses          = int64(7) // deprecated in v0.36
 	prefixState                  = int64(8)
 	prefixFinalizeBlockResponses = int64(14)
+	prefixRollback               = int64(15)
+	prefixValidator              = int64(18)
+	prefixValidatorAddress       = int64(19)
 )
 
 func encodeKey(prefix int64, height int64) []byte {
@@ -57,6 +62,23 @@ func validatorsKey(height int64) []byte {
 	return encodeKey(prefixValidators, height)
 }
 
+// validatorKey is the key of the validator at the given index of a validator
+// set stored at the given height.
+func validatorKey(height int64, index int32) []byte {
+	res, err := orderedcode.Append(nil, prefixValidator, height, int64(index))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// validatorAddressKey is the key of the index, in the validator set stored at
+// the given height, of the validator with the given address. The address is
+// appended as is, so that validators can be iterated by address prefix.
+func validatorAddressKey(height int64, address []byte) []byte {
+	return append(encodeKey(prefixValidatorAddress, height), address...)
+}
+
 func consensusParamsKey(height int64) []byte {
 	return encodeKey(prefixConsensusParams, height)
 }
@@ -69,8 +91,11 @@ func finalizeBlockResponsesKey(height int64) []byte {
 	return encodeKey(prefixFinalizeBlockResponses, height)
 }
 
-// stateKey should never change after being set in init()
-var stateKey []byte
+// stateKey and rollbackKey should never change after being set in init()
+var (
+	stateKey    []byte
+	rollbackKey []byte
+)
 
 func init() {
 	var err error
@@ -78,6 +103,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	rollbackKey, err = orderedcode.Append(nil, prefixRollback)
+	if err != nil {
+		panic(err)
+	}
 }
 
 //----------------------
@@ -93,19 +122,32 @@ type Store interface {
 	Load() (State, error)
 	// LoadValidators loads the validator set at a given height
 	LoadValidators(int64) (*types.ValidatorSet, error)
+	// LoadValidatorsPage loads a page of the validators at a given height whose
+	// address has the given prefix, along with the total number of matches
+	LoadValidatorsPage(height int64, offset, limit int, addressPrefix []byte) ([]*types.Validator, int, error)
 	// LoadFinalizeBlockResponses loads the responses to FinalizeBlock for a given height
 	LoadFinalizeBlockResponses(int64) (*abci.ResponseFinalizeBlock, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (types.ConsensusParams, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
+	// SaveRollback overwrites the previous state with a rolled back one and, in the
+	// same write, records the block height the block store is being trimmed to
+	SaveRollback(State, int64) error
+	// LoadRollbackHeight returns the block height recorded by an unfinished rollback,
+	// or zero if there is none
+	LoadRollbackHeight() (int64, error)
+	// ClearRollback removes the record of an unfinished rollback
+	ClearRollback() error
 	// SaveFinalizeBlockResponses saves responses to FinalizeBlock for a given height
 	SaveFinalizeBlockResponses(int64, *abci.ResponseFinalizeBlock) error
 	// SaveValidatorSet saves the validator set at a given height
 	SaveValidatorSets(int64, int64, *types.ValidatorSet) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
-	// PruneStates takes the height from which to prune up to (exclusive)
+	// PruneStates takes the height from which to prune up to (exclusive). The
+	// validator sets and consensus params still needed by the retained blocks
+	// are kept.
 	PruneStates(int64) error
 	// Close closes the connection with the database
 	Close() error
@@ -162,6 +204,13 @@ func (store dbStore) save(state State, key []byte) error {
 	batch := store.db.NewBatch()
 	defer batch.Close()
 
+	if err := store.saveToBatch(state, key, batch); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+func (store dbStore) saveToBatch(state State, key []byte, batch dbm.Batch) error {
 	nextHeight := state.LastBlockHeight + 1
 	// If first block, save validators for the block.
 	if nextHeight == 1 {
@@ -189,13 +238,54 @@ func (store dbStore) save(state State, key []byte) error {
 		return err
 	}
 
-	if err := batch.Set(key, stateBz); err != nil {
+	// fmt.Printf("Tendermint State Saved height=%d hash=%X lastResultHash=%X\n", state.LastBlockHeight, state.AppHash, state.LastResultsHash)
+	return batch.Set(key, stateBz)
+}
+
+// SaveRollback persists a rolled back State together with the height the block
+// store must be trimmed to. The state and the record are written in a single
+// batch so that an interrupted rollback can always be detected and completed
+// using LoadRollbackHeight.
+func (store dbStore) SaveRollback(state State, blockHeight int64) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	if err := store.saveToBatch(state, stateKey, batch); err != nil {
 		return err
 	}
-	// fmt.Printf("Tendermint State Saved height=%d hash=%X lastResultHash=%X\n", state.LastBlockHeight, state.AppHash, state.LastResultsHash)
+
+	bz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(bz, blockHeight)
+	if err := batch.Set(rollbackKey, bz[:n]); err != nil {
+		return err
+	}
+
 	return batch.WriteSync()
 }
 
+// LoadRollbackHeight returns the block height recorded by SaveRollback, or
+// zero if no rollback is in progress.
+func (store dbStore) LoadRollbackHeight() (int64, error) {
+	buf, err := store.db.Get(rollbackKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	height, n := binary.Varint(buf)
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid rollback height record %X", buf)
+	}
+	return height, nil
+}
+
+// ClearRollback removes the record written by SaveRollback.
+func (store dbStore) ClearRollback() error {
+	return store.db.DeleteSync(rollbackKey)
+}
+
 // BootstrapState saves a new state, used e.g. by state sync when starting from non-zero height.
 func (store dbStore) Bootstrap(state State) error {
 	height := state.LastBlockHeight + 1
@@ -241,6 +331,13 @@ func (store dbStore) Bootstrap(state State) error {
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at retain height must also exist.
 // Pruning is done in descending order.
+//
+// The validator sets and consensus params of every height from the retain height
+// onwards remain loadable, so that the retained blocks can still be verified, e.g.
+// by light clients. So does the validator set of the height below the retain
+// height, which signed the last commit of the block at the retain height. Since
+// the consensus params are only saved up to the height of the next block, a retain
+// height beyond it is rejected and nothing still in use is pruned.
 func (store dbStore) PruneStates(retainHeight int64) error {
 	if retainHeight <= 0 {
 		return fmt.Errorf("height %v must be greater than 0", retainHeight)
@@ -255,7 +352,14 @@ func (store dbStore) PruneStates(retainHeight int64) error {
 		return err
 	}
 
-	if err := store.pruneValidatorSets(retainHeight); err != nil {
+	// The last commit of the block at the retain height is verified against the
+	// validator set of the previous height, unless the retain height is the
+	// initial height.
+	valsRetainHeight := retainHeight
+	if _, err := loadValidatorsInfo(store.db, retainHeight-1); err == nil {
+		valsRetainHeight = retainHeight - 1
+	}
+	if err := store.pruneValidatorSets(valsRetainHeight); err != nil {
 		return err
 	}
 
@@ -292,20 +396,25 @@ func (store dbStore) pruneValidatorSets(retainHeight int64) error {
 	// if this is not equal to the retain height, prune from the retain height to the height above
 	// the last saved validator set. This way we can skip over the dependent validator set.
 	if lastRecordedValSetHeight < retainHeight {
-		err := store.pruneRange(
-			validatorsKey(lastRecordedValSetHeight+1),
-			validatorsKey(retainHeight),
-		)
-		if err != nil {
+		if err := store.pruneValidatorSetRange(lastRecordedValSetHeight+1, retainHeight); err != nil {
 			return err
 		}
 	}
 
 	// prune all the validators sets up to last saved validator set
-	return store.pruneRange(
-		validatorsKey(1),
-		validatorsKey(lastRecordedValSetHeight),
-	)
+	return store.pruneValidatorSetRange(1, lastRecordedValSetHeight)
+}
+
+// pruneValidatorSetRange deletes the validator sets, along with the entries of
+// their individual validators, from the start height to the end height
+// (exclusive).
+func (store dbStore) pruneValidatorSetRange(start, end int64) error {
+	for _, prefix := range []int64{prefixValidators, prefixValidator, prefixValidatorAddress} {
+		if err := store.pruneRange(encodeKey(prefix, start), encodeKey(prefix, end)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // pruneConsensusParams calls a reverse iterator from base height to retain height batch deleting
@@ -551,6 +660,190 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadValidatorsPage loads up to limit validators, after skipping the first
+// offset, from the validator set at a given height. Only validators whose
+// address starts with addressPrefix are considered; an empty prefix matches all
+// validators. The total number of matching validators is returned alongside
+// the page.
+//
+// When the validator set is stored at the given height, i.e. at the heights it
+// changed and at checkpoints, only the validators on the requested page are
+// read from the database, along with the index of each validator matching the
+// address prefix. At the other heights, the proposer priorities must be derived
+// from the last stored set, which requires loading the full set.
+// Returns ErrNoValSetForHeight if the validator set can't be found for this height.
+func (store dbStore) LoadValidatorsPage(
+	height int64,
+	offset, limit int,
+	addressPrefix []byte,
+) ([]*types.Validator, int, error) {
+	if offset < 0 || limit < 0 {
+		return nil, 0, fmt.Errorf("invalid validator page (offset %d, limit %d)", offset, limit)
+	}
+
+	indexes, found, err := store.loadValidatorIndexes(height, addressPrefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !found {
+		vs, err := store.LoadValidators(height)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		matched := make([]*types.Validator, 0, len(vs.Validators))
+		for _, v := range vs.Validators {
+			if bytes.HasPrefix(v.Address, addressPrefix) {
+				matched = append(matched, v)
+			}
+		}
+
+		start, end := pageBounds(len(matched), offset, limit)
+		return matched[start:end], len(matched), nil
+	}
+
+	start, end := pageBounds(indexes.total(), offset, limit)
+	vals := make([]*types.Validator, 0, end-start)
+	if indexes.all != 0 {
+		// the matching validators are contiguous, so the page is read with a
+		// single iterator
+		iter, err := store.db.Iterator(validatorKey(height, int32(start)), validatorKey(height, int32(end)))
+		if err != nil {
+			return nil, 0, err
+		}
+		defer iter.Close()
+
+		for ; iter.Valid(); iter.Next() {
+			v, err := unmarshalValidator(iter.Value())
+			if err != nil {
+				return nil, 0, err
+			}
+			vals = append(vals, v)
+		}
+		if err := iter.Error(); err != nil {
+			return nil, 0, err
+		}
+	} else {
+		for _, idx := range indexes.matched[start:end] {
+			buf, err := store.db.Get(validatorKey(height, idx))
+			if err != nil {
+				return nil, 0, err
+			}
+			if len(buf) == 0 {
+				return nil, 0, fmt.Errorf("validator %d at height %d not found", idx, height)
+			}
+			v, err := unmarshalValidator(buf)
+			if err != nil {
+				return nil, 0, err
+			}
+			vals = append(vals, v)
+		}
+	}
+
+	return vals, indexes.total(), nil
+}
+
+// validatorIndexes are the indexes of the validators matching an address
+// prefix: either all of the first `all` validators of the set, or the ones in
+// `matched`, in ascending order.
+type validatorIndexes struct {
+	all     int
+	matched []int32
+}
+
+func (vi validatorIndexes) total() int {
+	if vi.all != 0 {
+		return vi.all
+	}
+	return len(vi.matched)
+}
+
+// loadValidatorIndexes returns the indexes of the validators whose address
+// starts with addressPrefix, from the entries of the individual validators of
+// the set stored at the given height. It returns false if there are none, i.e.
+// if the set is not stored at that height or was stored before these entries
+// were written.
+func (store dbStore) loadValidatorIndexes(height int64, addressPrefix []byte) (validatorIndexes, bool, error) {
+	// the last validator entry gives the size of the set
+	iter, err := store.db.ReverseIterator(
+		encodeKey(prefixValidator, height),
+		encodeKey(prefixValidator, height+1),
+	)
+	if err != nil {
+		return validatorIndexes{}, false, err
+	}
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return validatorIndexes{}, false, iter.Error()
+	}
+	var prefix, h, lastIdx int64
+	if _, err := orderedcode.Parse(string(iter.Key()), &prefix, &h, &lastIdx); err != nil {
+		return validatorIndexes{}, false, fmt.Errorf("invalid validator key %X: %w", iter.Key(), err)
+	}
+	if len(addressPrefix) == 0 {
+		return validatorIndexes{all: int(lastIdx) + 1}, true, nil
+	}
+
+	start := validatorAddressKey(height, addressPrefix)
+	end := prefixEnd(start)
+	if end == nil {
+		end = encodeKey(prefixValidatorAddress, height+1)
+	}
+	addrIter, err := store.db.Iterator(start, end)
+	if err != nil {
+		return validatorIndexes{}, false, err
+	}
+	defer addrIter.Close()
+
+	var indexes validatorIndexes
+	for ; addrIter.Valid(); addrIter.Next() {
+		idx, n := binary.Varint(addrIter.Value())
+		if n <= 0 {
+			return validatorIndexes{}, false, fmt.Errorf("invalid validator index %X", addrIter.Value())
+		}
+		indexes.matched = append(indexes.matched, int32(idx))
+	}
+	if err := addrIter.Error(); err != nil {
+		return validatorIndexes{}, false, err
+	}
+	sort.Slice(indexes.matched, func(i, j int) bool { return indexes.matched[i] < indexes.matched[j] })
+
+	return indexes, true, nil
+}
+
+// prefixEnd returns the smallest key greater than all the keys starting with
+// prefix, or nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func unmarshalValidator(buf []byte) (*types.Validator, error) {
+	pv := new(tmproto.Validator)
+	if err := pv.Unmarshal(buf); err != nil {
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		panic(fmt.Sprintf("data has been corrupted or its spec has changed: %+v", err))
+	}
+	return types.ValidatorFromProto(pv)
+}
+
+// pageBounds returns the bounds of the page of at most limit items starting
+// at offset, clamped to total.
+func pageBounds(total, offset, limit int) (int, int) {
+	start := tmmath.MinInt(offset, total)
+	end := tmmath.MinInt(start+limit, total)
+	return start, end
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -594,6 +887,11 @@ func (store dbStore) saveValidatorsInfo(
 	valInfo := &tmstate.ValidatorsInfo{
 		LastHeightChanged: lastHeightChanged,
 	}
+	// The validator set saved earlier at this height, if any, may have been
+	// bigger: remove the entries of its validators.
+	if err := store.deleteValidatorEntries(height, batch); err != nil {
+		return err
+	}
 	// Only persist validator set if it was updated or checkpoint height (see
 	// valSetCheckpointInterval) is reached.
 	if height == lastHeightChanged || height%valSetCheckpointInterval == 0 {
@@ -602,6 +900,23 @@ func (store dbStore) saveValidatorsInfo(
 			return err
 		}
 		valInfo.ValidatorSet = pv
+
+		// Also persist each validator, and its index by address, so that
+		// pages of the set can be loaded without reading the full set.
+		for idx, v := range pv.Validators {
+			bz, err := v.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := batch.Set(validatorKey(height, int32(idx)), bz); err != nil {
+				return err
+			}
+			idxBz := make([]byte, binary.MaxVarintLen64)
+			n := binary.PutVarint(idxBz, int64(idx))
+			if err := batch.Set(validatorAddressKey(height, v.Address), idxBz[:n]); err != nil {
+				return err
+			}
+		}
 	}
 
 	bz, err := valInfo.Marshal()
@@ -612,6 +927,31 @@ func (store dbStore) saveValidatorsInfo(
 	return batch.Set(validatorsKey(height), bz)
 }
 
+// deleteValidatorEntries adds to the batch the deletion of the entries of the
+// validators of the set stored at the given height.
+func (store dbStore) deleteValidatorEntries(height int64, batch dbm.Batch) error {
+	for _, prefix := range []int64{prefixValidator, prefixValidatorAddress} {
+		iter, err := store.db.Iterator(encodeKey(prefix, height), encodeKey(prefix, height+1))
+		if err != nil {
+			return err
+		}
+		for ; iter.Valid(); iter.Next() {
+			if err := batch.Delete(iter.Key()); err != nil {
+				iter.Close()
+				return err
+			}
+		}
+		if err := iter.Error(); err != nil {
+			iter.Close()
+			return err
+		}
+		if err := iter.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 
 // ConsensusParamsInfo represents the latest consensus params, or the last height it changed
@@ -622,22 +962,23 @@ var (
 	emptypb = tmproto.ConsensusParams{}
 )
 
-// LoadConsensusParams loads the ConsensusParams for a given height.
+// LoadConsensusParams loads the ConsensusParams for a given height, which are
+// the ones the block at that height was created with.
+// Returns ErrNoConsensusParamsForHeight if they can't be found for this height,
+// e.g. if it was pruned, or backfilled after a state sync.
 func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, error) {
 	paramsInfo, err := store.loadConsensusParamsInfo(height)
 	if err != nil {
-		return empty, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+		return empty, ErrNoConsensusParamsForHeight{Height: height, Err: err}
 	}
 
 	if paramsInfo.ConsensusParams.Equal(&emptypb) {
 		paramsInfo2, err := store.loadConsensusParamsInfo(paramsInfo.LastHeightChanged)
 		if err != nil {
-			return empty, fmt.Errorf(
-				"couldn't find consensus params at height %d (height %d was originally requested): %w",
-				paramsInfo.LastHeightChanged,
-				height,
-				err,
-			)
+			return empty, ErrNoConsensusParamsForHeight{
+				Height: height,
+				Err:    fmt.Errorf("params last changed at height %d: %w", paramsInfo.LastHeightChanged, err),
+			}
 		}
 
 		paramsInfo = paramsInfo2
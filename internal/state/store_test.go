@@ -1,6 +1,7 @@
 package state_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
@@ -55,6 +56,35 @@ func TestStoreBootstrap(t *testing.T) {
 	require.Equal(t, bootstrapState, state)
 }
 
+func TestStoreSaveRollback(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, _, err := factory.Validator(ctx, 10+int64(rand.Uint32()))
+	require.NoError(t, err)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	rolledBackState := makeRandomStateFromValidatorSet(vals, 100, 100)
+
+	height, err := stateStore.LoadRollbackHeight()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	require.NoError(t, stateStore.SaveRollback(rolledBackState, 99))
+
+	height, err = stateStore.LoadRollbackHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 99, height)
+
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, rolledBackState, state)
+
+	require.NoError(t, stateStore.ClearRollback())
+	height, err = stateStore.LoadRollbackHeight()
+	require.NoError(t, err)
+	require.Zero(t, height)
+}
+
 func TestStoreLoadValidators(t *testing.T) {
 	stateDB := dbm.NewMemDB()
 	stateStore := sm.NewStore(stateDB)
@@ -101,6 +131,86 @@ func TestStoreLoadValidators(t *testing.T) {
 	require.NotEqual(t, vals.CopyIncrementProposerPriority(valSetCheckpointInterval), loadedVals)
 }
 
+func TestStoreLoadValidatorsPage(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vals, _ := factory.ValidatorSet(ctx, t, 10, 10)
+
+	// The validator set is stored in full at height 1, while height 3 only
+	// refers back to the last height the set changed.
+	require.NoError(t, stateStore.Save(makeRandomStateFromValidatorSet(vals, 1, 1)))
+	require.NoError(t, stateStore.Save(makeRandomStateFromValidatorSet(vals.CopyIncrementProposerPriority(1), 2, 1)))
+
+	for _, height := range []int64{1, 3} {
+		expected, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+
+		page, total, err := stateStore.LoadValidatorsPage(height, 0, 100, nil)
+		require.NoError(t, err)
+		require.Equal(t, 10, total)
+		require.Equal(t, expected.Validators, page)
+
+		page, total, err = stateStore.LoadValidatorsPage(height, 4, 3, nil)
+		require.NoError(t, err)
+		require.Equal(t, 10, total)
+		require.Equal(t, expected.Validators[4:7], page)
+
+		page, total, err = stateStore.LoadValidatorsPage(height, 8, 5, nil)
+		require.NoError(t, err)
+		require.Equal(t, 10, total)
+		require.Equal(t, expected.Validators[8:], page)
+
+		page, total, err = stateStore.LoadValidatorsPage(height, 20, 5, nil)
+		require.NoError(t, err)
+		require.Equal(t, 10, total)
+		require.Empty(t, page)
+
+		target := expected.Validators[5]
+		page, total, err = stateStore.LoadValidatorsPage(height, 0, 100, target.Address)
+		require.NoError(t, err)
+		require.Equal(t, 1, total)
+		require.Equal(t, []*types.Validator{target}, page)
+
+		var matched []*types.Validator
+		for _, v := range expected.Validators {
+			if bytes.HasPrefix(v.Address, target.Address[:1]) {
+				matched = append(matched, v)
+			}
+		}
+		page, total, err = stateStore.LoadValidatorsPage(height, 0, 100, target.Address[:1])
+		require.NoError(t, err)
+		require.Equal(t, len(matched), total)
+		require.Equal(t, matched, page)
+
+		page, total, err = stateStore.LoadValidatorsPage(height, 0, 100, []byte{0xff, 0xff, 0xff, 0xff})
+		require.NoError(t, err)
+		require.Zero(t, total)
+		require.Empty(t, page)
+
+		_, _, err = stateStore.LoadValidatorsPage(height, -1, 5, nil)
+		require.Error(t, err)
+	}
+
+	// A smaller set saved over a stored set replaces all of its validators.
+	require.NoError(t, stateStore.SaveValidatorSets(5, 5, vals))
+	smallVals := types.NewValidatorSet(vals.Validators[:3])
+	require.NoError(t, stateStore.SaveValidatorSets(5, 5, smallVals))
+	page, total, err := stateStore.LoadValidatorsPage(5, 0, 100, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, smallVals.Validators, page)
+	page, total, err = stateStore.LoadValidatorsPage(5, 0, 100, vals.Validators[5].Address)
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, page)
+
+	_, _, err = stateStore.LoadValidatorsPage(100, 0, 5, nil)
+	require.Error(t, err)
+}
+
 // This benchmarks the speed of loading validators from different heights if there is no validator set change.
 // NOTE: This isn't too indicative of validator retrieval speed as the db is always (regardless of height) only
 // performing two operations: 1) retrieve validator info at height x, which has a last validator set change of 1
@@ -169,6 +279,9 @@ func TestStoreLoadConsensusParams(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, res, params)
 	require.NotEqual(t, res, differentParams)
+
+	_, err = stateStore.LoadConsensusParams(5)
+	require.ErrorAs(t, err, &sm.ErrNoConsensusParamsForHeight{})
 }
 
 func TestPruneStates(t *testing.T) {
@@ -186,6 +299,7 @@ func TestPruneStates(t *testing.T) {
 		"prune all":                              {1, 100, 100, false, 93, 95},
 		"prune from non 1 height":                {10, 50, 40, false, 33, 35},
 		"prune some":                             {1, 10, 8, false, 3, 5},
+		"prune none":                             {1, 10, 1, false, 0, 0},
 		// we test this because we flush to disk every 1000 "states"
 		"prune more than 1000 state": {1, 1010, 1010, false, 1003, 1005},
 		"prune across checkpoint":    {99900, 100002, 100002, false, 100000, 99995},
@@ -271,8 +385,10 @@ func TestPruneStates(t *testing.T) {
 			emptyParams := types.ConsensusParams{}
 
 			for h := tc.startHeight; h < tc.pruneHeight; h++ {
+				// The validators below the prune height signed the last commit
+				// of the first retained block.
 				vals, err := stateStore.LoadValidators(h)
-				if h == tc.remainingValSetHeight {
+				if h == tc.remainingValSetHeight || h == tc.pruneHeight-1 {
 					require.NoError(t, err, h)
 					require.NotNil(t, vals, h)
 				} else {
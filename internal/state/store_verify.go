@@ -0,0 +1,135 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// StoreInconsistency describes the first inconsistency found between or
+// within the state and block stores.
+type StoreInconsistency struct {
+	// Height is the height of the inconsistent block or state.
+	Height int64
+	// Reason describes the inconsistency.
+	Reason string
+}
+
+// StoreVerifyResult describes the outcome of VerifyStores.
+type StoreVerifyResult struct {
+	// Base and Height are the lowest and highest heights of the block store.
+	Base   int64
+	Height int64
+	// Verified is the number of blocks verified without inconsistency.
+	Verified int64
+	// Inconsistency is the first inconsistency found, or nil if the stores
+	// are consistent.
+	Inconsistency *StoreInconsistency
+}
+
+// VerifyStores walks the blocks of the block store from its base to its
+// height and checks, for each one, that it's well formed, that its parts
+// hash to the part set header of its block ID, and that its commit is signed
+// by the validator set of the state store for its height. It then checks that
+// the heights and latest block ID of the state agree with the block store. It
+// stops at the first inconsistency, which is returned in the result.
+//
+// Neither store is written to.
+func VerifyStores(stateStore Store, blockStore BlockStore) (*StoreVerifyResult, error) {
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+
+	res := &StoreVerifyResult{Base: blockStore.Base(), Height: blockStore.Height()}
+	if res.Height == 0 {
+		// A state synced node may not have any block yet.
+		return res, nil
+	}
+
+	for height := res.Base; height <= res.Height; height++ {
+		if reason := verifyStoredBlock(stateStore, blockStore, state.ChainID, height, height == res.Height); reason != "" {
+			res.Inconsistency = &StoreInconsistency{Height: height, Reason: reason}
+			return res, nil
+		}
+		res.Verified++
+	}
+
+	if err := CheckStoreHeights(blockStore, stateStore); err != nil {
+		res.Inconsistency = &StoreInconsistency{Height: res.Height, Reason: err.Error()}
+		return res, nil
+	}
+	if meta := blockStore.LoadBlockMeta(state.LastBlockHeight); meta != nil && !meta.BlockID.Equals(state.LastBlockID) {
+		res.Inconsistency = &StoreInconsistency{
+			Height: state.LastBlockHeight,
+			Reason: fmt.Sprintf("state last block ID %v does not match the stored block ID %v",
+				state.LastBlockID, meta.BlockID),
+		}
+	}
+	return res, nil
+}
+
+// verifyStoredBlock checks the stored block at height, returning the reason it
+// is inconsistent, or an empty string if it isn't. isHead tells whether the
+// block is the latest one, whose commit is the seen commit.
+func verifyStoredBlock(stateStore Store, blockStore BlockStore, chainID string, height int64, isHead bool) string {
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return "block meta not found"
+	}
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return "block not found"
+	}
+	if block.Height != height {
+		return fmt.Sprintf("block has height %d", block.Height)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Sprintf("invalid block: %v", err)
+	}
+	if !bytes.Equal(block.Hash(), meta.BlockID.Hash) {
+		return fmt.Sprintf("block hash %X does not match the block ID hash %X", block.Hash(), meta.BlockID.Hash)
+	}
+
+	psh := meta.BlockID.PartSetHeader
+	for i := 0; i < int(psh.Total); i++ {
+		part := blockStore.LoadBlockPart(height, i)
+		if part == nil {
+			return fmt.Sprintf("block part %d of %d not found", i, psh.Total)
+		}
+		if int(part.Index) != i {
+			return fmt.Sprintf("block part %d has index %d", i, part.Index)
+		}
+		if err := part.Proof.Verify(psh.Hash, part.Bytes); err != nil {
+			return fmt.Sprintf("block part %d does not match the part set hash %X: %v", i, psh.Hash, err)
+		}
+	}
+
+	vals, err := stateStore.LoadValidators(height)
+	if err != nil {
+		return fmt.Sprintf("failed to load the validator set: %v", err)
+	}
+	if !bytes.Equal(vals.Hash(), block.ValidatorsHash) {
+		return fmt.Sprintf("stored validator set hash %X does not match the header validators hash %X",
+			vals.Hash(), block.ValidatorsHash)
+	}
+
+	var commit *types.Commit
+	if isHead {
+		commit = blockStore.LoadSeenCommit()
+	} else {
+		commit = blockStore.LoadBlockCommit(height)
+	}
+	if commit == nil {
+		return "commit not found"
+	}
+	if err := vals.VerifyCommit(chainID, meta.BlockID, height, commit); err != nil {
+		return fmt.Sprintf("invalid commit: %v", err)
+	}
+	return ""
+}
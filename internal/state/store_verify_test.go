@@ -0,0 +1,119 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyStores(t *testing.T) {
+	const lastHeight = 5
+	ctx := context.Background()
+
+	s, stateDB, privVals := makeState(t, 2, 1)
+	stateStore := state.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	lastCommit := &types.Commit{}
+	for height := int64(1); height <= lastHeight; height++ {
+		block := s.MakeBlock(height, factory.MakeNTxs(height, 3), lastCommit, nil, s.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		extCommit, _ := makeValidCommit(ctx, t, height, blockID, s.Validators, privVals)
+		lastCommit = extCommit.ToCommit()
+		blockStore.SaveBlock(block, partSet, lastCommit)
+
+		s.LastBlockHeight = height
+		s.LastBlockID = blockID
+		s.LastValidators = s.Validators.Copy()
+		require.NoError(t, stateStore.Save(s))
+	}
+
+	// consistent stores
+	res, err := state.VerifyStores(stateStore, blockStore)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Base)
+	assert.EqualValues(t, lastHeight, res.Height)
+	assert.EqualValues(t, lastHeight, res.Verified)
+	assert.Nil(t, res.Inconsistency)
+
+	// a corrupted block part
+	part := *blockStore.LoadBlockPart(3, 0)
+	part.Bytes = append([]byte{0}, part.Bytes...)
+	res, err = state.VerifyStores(stateStore, tamperedBlockStore{BlockStore: blockStore, height: 3, part: &part})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Verified)
+	require.NotNil(t, res.Inconsistency)
+	assert.EqualValues(t, 3, res.Inconsistency.Height)
+	assert.Contains(t, res.Inconsistency.Reason, "block part 0")
+
+	// a commit with an invalid signature
+	commit := blockStore.LoadBlockCommit(2)
+	commit.Signatures[0].Signature = make([]byte, len(commit.Signatures[0].Signature))
+	res, err = state.VerifyStores(stateStore, tamperedBlockStore{BlockStore: blockStore, height: 2, commit: commit})
+	require.NoError(t, err)
+	require.NotNil(t, res.Inconsistency)
+	assert.EqualValues(t, 2, res.Inconsistency.Height)
+	assert.Contains(t, res.Inconsistency.Reason, "invalid commit")
+
+	// the state is ahead of the block store
+	ahead := s.Copy()
+	ahead.LastBlockHeight = lastHeight + 1
+	res, err = state.VerifyStores(fixedStateStore{Store: stateStore, state: ahead}, blockStore)
+	require.NoError(t, err)
+	assert.EqualValues(t, lastHeight, res.Verified)
+	require.NotNil(t, res.Inconsistency)
+	assert.EqualValues(t, lastHeight, res.Inconsistency.Height)
+
+	// the state records another latest block
+	forked := s.Copy()
+	forked.LastBlockID = factory.MakeBlockID()
+	res, err = state.VerifyStores(fixedStateStore{Store: stateStore, state: forked}, blockStore)
+	require.NoError(t, err)
+	require.NotNil(t, res.Inconsistency)
+	assert.EqualValues(t, lastHeight, res.Inconsistency.Height)
+	assert.Contains(t, res.Inconsistency.Reason, "last block ID")
+
+	// no state
+	_, err = state.VerifyStores(state.NewStore(dbm.NewMemDB()), blockStore)
+	require.Error(t, err)
+}
+
+// tamperedBlockStore returns the given part or commit for the block at height.
+type tamperedBlockStore struct {
+	state.BlockStore
+	height int64
+	part   *types.Part
+	commit *types.Commit
+}
+
+func (bs tamperedBlockStore) LoadBlockPart(height int64, index int) *types.Part {
+	if height == bs.height && bs.part != nil {
+		return bs.part
+	}
+	return bs.BlockStore.LoadBlockPart(height, index)
+}
+
+func (bs tamperedBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	if height == bs.height && bs.commit != nil {
+		return bs.commit
+	}
+	return bs.BlockStore.LoadBlockCommit(height)
+}
+
+// fixedStateStore loads a fixed state.
+type fixedStateStore struct {
+	state.Store
+	state state.State
+}
+
+func (ss fixedStateStore) Load() (state.State, error) { return ss.state, nil }
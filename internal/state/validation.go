@@ -11,7 +11,25 @@ import (
 //-----------------------------------------------------
 // Validate block
 
-func validateBlock(state State, block *types.Block) error {
+// ValidateBlockExtension is an application defined check of a block, run
+// after the built-in checks whenever a block is validated: for a proposal,
+// whether proposed by this node or received, before committing it, and during
+// block sync. An error rejects the block.
+//
+// It decides which blocks are valid, so it must be deterministic, and be the
+// same on all nodes: validators running different rules disagree on blocks,
+// which can halt the chain.
+type ValidateBlockExtension func(block *types.Block) error
+
+// validateBlock validates block against state. ext, if not nil, is run once
+// the block passed the other checks. If verifyLastCommit is false,
+// only the structure of the LastCommit is checked, not its signatures.
+func validateBlock(
+	state State,
+	block *types.Block,
+	ext ValidateBlockExtension,
+	verifyLastCommit bool,
+) error {
 	// Validate internal consistency.
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -87,12 +105,20 @@ func validateBlock(state State, block *types.Block) error {
 		if len(block.LastCommit.Signatures) != 0 {
 			return errors.New("initial block can't have LastCommit signatures")
 		}
-	} else {
+	} else if verifyLastCommit {
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
 			return err
 		}
+	} else if err := types.VerifyCommitStructure(
+		state.LastValidators, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+		return err
+	}
+	if len(block.LastCommit.AggregatedSignature) != 0 &&
+		!state.ConsensusParams.Validator.AggregateCommitsEnabled(block.Height-1) {
+		return fmt.Errorf("block.LastCommit is aggregated, but aggregation is not enabled at height %d",
+			block.Height-1)
 	}
 
 	// NOTE: We can't actually verify it's the right proposer because we don't
@@ -114,6 +140,21 @@ func validateBlock(state State, block *types.Block) error {
 				state.LastBlockTime,
 			)
 		}
+		// The proposer's clock can't be behind the clocks of the validators
+		// that signed the last commit, before the block was proposed, by more
+		// than the precision. Since less than a third of the voting power is
+		// faulty, the median time of the commit is that of a correct validator.
+		if state.ConsensusParams.Synchrony.MedianTimeEnabled(block.Height) {
+			medianTime := MedianTime(block.LastCommit, state.LastValidators)
+			precision := state.ConsensusParams.Synchrony.SynchronyParamsOrDefaults().Precision
+			if block.Time.Before(medianTime.Add(-precision)) {
+				return fmt.Errorf("block time %v is before the median time %v of the last commit by more than the precision %v",
+					block.Time,
+					medianTime,
+					precision,
+				)
+			}
+		}
 
 	case block.Height == state.InitialHeight:
 		genesisTime := state.LastBlockTime
@@ -134,5 +175,11 @@ func validateBlock(state State, block *types.Block) error {
 		return types.NewErrEvidenceOverflow(max, got)
 	}
 
+	if ext != nil {
+		if err := ext(block); err != nil {
+			return fmt.Errorf("block rejected by validation extension: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -287,6 +288,123 @@ func TestValidateBlockCommit(t *testing.T) {
 	}
 }
 
+func TestValidateBlockTime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	proxyApp := proxy.New(abciclient.NewLocalClient(logger, &testApp{}), logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	state, stateDB, privVals := makeState(t, 3, 1)
+	stateStore := sm.NewStore(stateDB)
+	mp := &mpmocks.Mempool{}
+	mp.On("Lock").Return()
+	mp.On("Unlock").Return()
+	mp.On("FlushAppConn", mock.Anything).Return(nil)
+	mp.On("Update",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(nil)
+	mp.On("TxStore").Return(nil)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		logger,
+		proxyApp,
+		mp,
+		sm.EmptyEvidencePool{},
+		blockStore,
+		eventBus,
+		sm.NopMetrics(),
+	)
+
+	// At the initial height, there is no previous block: the block time may
+	// be equal to the genesis time, but not before it.
+	genesisTime := state.LastBlockTime
+	block := statefactory.MakeBlock(state, 1, new(types.Commit))
+	block.Time = genesisTime
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+	block.Time = genesisTime.Add(-time.Millisecond)
+	err := blockExec.ValidateBlock(ctx, state, block)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "before genesis time")
+
+	state, blockID, lastExtCommit := makeAndCommitGoodBlock(ctx, t,
+		state, 1, new(types.Commit), state.Validators.GetProposer().Address, blockExec, privVals, nil)
+	lastCommit := lastExtCommit.ToCommit()
+
+	testCases := []struct {
+		name      string
+		blockTime time.Time
+		errMsg    string
+	}{
+		{"duplicate time", state.LastBlockTime, "not greater than last block time"},
+		{"out of order time", state.LastBlockTime.Add(-time.Second), "not greater than last block time"},
+		{"time after last block", state.LastBlockTime.Add(time.Nanosecond), ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			block := statefactory.MakeBlock(state, 2, lastCommit)
+			block.Time = tc.blockTime
+			err := blockExec.ValidateBlock(ctx, state, block)
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	// A block time behind the median time of the last commit by more than the
+	// precision is invalid, even if it is after the last block time.
+	precision := state.ConsensusParams.Synchrony.Precision
+	commitTime := state.LastBlockTime.Add(time.Minute)
+	signatures := make([]types.CommitSig, state.LastValidators.Size())
+	for i := range signatures {
+		_, val := state.LastValidators.GetByIndex(int32(i))
+		vote, err := testfactory.MakeVote(ctx, privVals[val.Address.String()], chainID,
+			int32(i), 1, 0, 2, blockID, commitTime)
+		require.NoError(t, err)
+		signatures[i] = vote.CommitSig()
+	}
+	lateCommit := &types.Commit{Height: 1, BlockID: blockID, Signatures: signatures}
+	require.Equal(t, commitTime, sm.MedianTime(lateCommit, state.LastValidators))
+
+	// The check is only enabled from the configured height.
+	block = statefactory.MakeBlock(state, 2, lateCommit)
+	block.Time = commitTime.Add(-precision - time.Millisecond)
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+	// Validated blocks are cached, so other block times are used.
+	state.ConsensusParams.Synchrony.MedianTimeEnableHeight = 3
+	block.Time = commitTime.Add(-precision - 3*time.Millisecond)
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+
+	state.ConsensusParams.Synchrony.MedianTimeEnableHeight = 2
+	block.Time = commitTime.Add(-precision - 2*time.Millisecond)
+	err = blockExec.ValidateBlock(ctx, state, block)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "before the median time")
+
+	block.Time = commitTime.Add(-precision)
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+
+	// Once enabled, the blocks made by a proposer with a clock behind the
+	// median time are valid.
+	block = state.MakeBlock(2, nil, lateCommit, nil, state.Validators.GetProposer().Address)
+	require.False(t, block.Time.Before(commitTime))
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+}
+
 func TestValidateBlockEvidence(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -394,3 +512,57 @@ func TestValidateBlockEvidence(t *testing.T) {
 
 	}
 }
+
+func TestValidateBlockExtension(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	proxyApp := proxy.New(abciclient.NewLocalClient(logger, &testApp{}), logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	state, stateDB, _ := makeState(t, 1, 1)
+
+	// an application limit on the number of transactions of a block
+	const maxTxs = 5
+	errTooManyTxs := errors.New("too many txs")
+	calls := 0
+	ext := func(block *types.Block) error {
+		calls++
+		if len(block.Txs) > maxTxs {
+			return errTooManyTxs
+		}
+		return nil
+	}
+
+	blockExec := sm.NewBlockExecutor(
+		sm.NewStore(stateDB),
+		logger,
+		proxyApp,
+		&mpmocks.Mempool{},
+		sm.EmptyEvidencePool{},
+		store.NewBlockStore(dbm.NewMemDB()),
+		eventBus,
+		sm.NopMetrics(),
+		sm.WithValidateBlockExtension(ext),
+	)
+	proposer := state.Validators.GetProposer().Address
+
+	block := state.MakeBlock(1, testfactory.MakeNTxs(1, maxTxs), new(types.Commit), nil, proposer)
+	require.NoError(t, blockExec.ValidateBlock(ctx, state, block))
+	assert.Equal(t, 1, calls)
+
+	block = state.MakeBlock(1, testfactory.MakeNTxs(1, maxTxs+1), new(types.Commit), nil, proposer)
+	err := blockExec.ValidateBlock(ctx, state, block)
+	require.ErrorIs(t, err, errTooManyTxs)
+	assert.Equal(t, 2, calls)
+
+	// the extension only sees blocks passing the built-in checks
+	block = state.MakeBlock(1, testfactory.MakeNTxs(1, maxTxs), new(types.Commit), nil, proposer)
+	block.ChainID = "wrong-chain"
+	require.Error(t, blockExec.ValidateBlock(ctx, state, block))
+	assert.Equal(t, 2, calls)
+}
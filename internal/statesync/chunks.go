@@ -1,6 +1,8 @@
 package statesync
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
@@ -9,11 +11,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
 	"github.com/tendermint/tendermint/types"
 )
 
-// errDone is returned by chunkQueue.Next() when all chunks have been returned.
-var errDone = errors.New("chunk queue has completed")
+var (
+	// errDone is returned by chunkQueue.Next() when all chunks have been returned.
+	errDone = errors.New("chunk queue has completed")
+	// errWindowFull is returned by chunkQueue.Allocate() when all chunks within the
+	// allocation window have been allocated, but later chunks remain.
+	errWindowFull = errors.New("chunk allocation window is full")
+	// errInvalidChecksum is returned by chunkQueue.Add() when a chunk does not match
+	// its checksum.
+	errInvalidChecksum = errors.New("chunk checksum mismatch")
+)
 
 // chunk contains data for a chunk.
 type chunk struct {
@@ -22,25 +33,44 @@ type chunk struct {
 	Index  uint32
 	Chunk  []byte
 	Sender types.NodeID
+
+	// Checksum is the SHA-256 checksum of Chunk as provided by the sender, if any. It
+	// detects chunks corrupted after the sender loaded them; chunks forged by the
+	// sender are rejected by the application when applied.
+	Checksum []byte
+}
+
+// chunkChecksum returns the checksum of a chunk's contents.
+func chunkChecksum(body []byte) []byte {
+	sum := sha256.Sum256(body)
+	return sum[:]
 }
 
 // chunkQueue manages chunks for a state sync process, ordering them if requested. It acts as an
 // iterator over all chunks, but callers can request chunks to be retried, optionally after
 // refetching.
+//
+// To bound the number of chunks buffered ahead of the one being applied, only chunks within a
+// window of the next chunk to be returned are handed out by Allocate().
 type chunkQueue struct {
 	sync.Mutex
 	snapshot       *snapshot                  // if this is nil, the queue has been closed
 	dir            string                     // temp dir for on-disk chunk storage
+	persistent     bool                       // if true, chunks are kept on disk when closed
+	window         uint32                     // max chunks allocated ahead of Next(), 0 for no limit
+	allocatable    chan struct{}              // closed when more chunks may become allocatable
 	chunkFiles     map[uint32]string          // path to temporary chunk file
 	chunkSenders   map[uint32]types.NodeID    // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
+	rejections     map[uint32]chan struct{}   // closed when a chunk fails its checksum
 }
 
-// newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
-// Callers must call Close() when done.
-func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
+// newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage. At most
+// window chunks are allocated ahead of the next chunk to be returned, or all of them if window is
+// 0. Callers must call Close() when done.
+func newChunkQueue(snapshot *snapshot, tempDir string, window uint32) (*chunkQueue, error) {
 	dir, err := os.MkdirTemp(tempDir, "tm-statesync")
 	if err != nil {
 		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
@@ -52,14 +82,72 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 	return &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
+		window:         window,
+		allocatable:    make(chan struct{}),
 		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
 		chunkSenders:   make(map[uint32]types.NodeID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
+		rejections:     make(map[uint32]chan struct{}),
 	}, nil
 }
 
+// openChunkQueue opens a persistent chunk queue for a snapshot in dir, such that fetched chunks
+// survive node restarts. If dir holds chunks for the same snapshot they are loaded into the
+// queue, otherwise its contents are replaced. Unlike newChunkQueue, Close() keeps the chunks on
+// disk; callers must remove dir once the chunks are no longer needed.
+func openChunkQueue(snapshot *snapshot, dir string, window uint32) (*chunkQueue, error) {
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+
+	saved, err := loadResumeSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil || saved.Key() != snapshot.Key() {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("failed to clear state sync resume dir %v: %w", dir, err)
+		}
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create state sync resume dir %v: %w", dir, err)
+	}
+	if err := saveResumeSnapshot(dir, snapshot); err != nil {
+		return nil, err
+	}
+
+	q := &chunkQueue{
+		snapshot:       snapshot,
+		dir:            dir,
+		persistent:     true,
+		window:         window,
+		allocatable:    make(chan struct{}),
+		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
+		chunkSenders:   make(map[uint32]types.NodeID, snapshot.Chunks),
+		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
+		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		waiters:        make(map[uint32][]chan<- uint32),
+		rejections:     make(map[uint32]chan struct{}),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state sync resume dir %v: %w", dir, err)
+	}
+	for _, entry := range entries {
+		index, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil || uint32(index) >= snapshot.Chunks {
+			continue
+		}
+		q.chunkFiles[uint32(index)] = filepath.Join(dir, entry.Name())
+		q.chunkAllocated[uint32(index)] = true
+	}
+
+	return q, nil
+}
+
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
 func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk == nil || chunk.Chunk == nil {
@@ -81,12 +169,27 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	if chunk.Index >= q.snapshot.Chunks {
 		return false, fmt.Errorf("received unexpected chunk %v", chunk.Index)
 	}
+	if len(chunk.Checksum) > 0 && !bytes.Equal(chunk.Checksum, chunkChecksum(chunk.Chunk)) {
+		// Signal the fetcher so that it refetches the chunk, from another peer.
+		if ch, ok := q.rejections[chunk.Index]; ok {
+			close(ch)
+			delete(q.rejections, chunk.Index)
+		}
+		return false, fmt.Errorf("%w for chunk %v", errInvalidChecksum, chunk.Index)
+	}
 	if q.chunkFiles[chunk.Index] != "" {
 		return false, nil
 	}
 
 	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
-	err := os.WriteFile(path, chunk.Chunk, 0600)
+	var err error
+	if q.persistent {
+		// Chunks must not be left partially written if the node stops, since they are
+		// loaded again when resuming.
+		err = tempfile.WriteFileAtomic(path, chunk.Chunk, 0600)
+	} else {
+		err = os.WriteFile(path, chunk.Chunk, 0600)
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
@@ -106,7 +209,9 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 }
 
 // Allocate allocates a chunk to the caller, making it responsible for fetching it. Returns
-// errDone once no chunks are left or the queue is closed.
+// errDone once no chunks are left or the queue is closed, and errWindowFull if the remaining
+// chunks are outside the allocation window. In the latter case, callers should wait on
+// WaitForAllocatable() before trying again.
 func (q *chunkQueue) Allocate() (uint32, error) {
 	q.Lock()
 	defer q.Unlock()
@@ -119,17 +224,40 @@ func (q *chunkQueue) Allocate() (uint32, error) {
 		return 0, errDone
 	}
 
-	for i := uint32(0); i < q.snapshot.Chunks; i++ {
+	limit := q.snapshot.Chunks
+	if q.window > 0 {
+		if next, err := q.nextUp(); err == nil && next+q.window < limit {
+			limit = next + q.window
+		}
+	}
+
+	for i := uint32(0); i < limit; i++ {
 		if !q.chunkAllocated[i] {
 			q.chunkAllocated[i] = true
 			return i, nil
 		}
 	}
 
-	return 0, errDone
+	return 0, errWindowFull
+}
+
+// WaitForAllocatable returns a channel that is closed when chunks outside the allocation window
+// may have become allocatable, i.e. when a chunk is returned or discarded, or the queue is
+// closed.
+func (q *chunkQueue) WaitForAllocatable() <-chan struct{} {
+	q.Lock()
+	defer q.Unlock()
+	return q.allocatable
+}
+
+// signalAllocatable wakes up any callers waiting in WaitForAllocatable(). The caller must hold
+// the mutex lock.
+func (q *chunkQueue) signalAllocatable() {
+	close(q.allocatable)
+	q.allocatable = make(chan struct{})
 }
 
-// Close closes the chunk queue, cleaning up all temporary files.
+// Close closes the chunk queue, cleaning up all temporary files unless the queue is persistent.
 func (q *chunkQueue) Close() error {
 	q.Lock()
 	defer q.Unlock()
@@ -145,7 +273,13 @@ func (q *chunkQueue) Close() error {
 	}
 
 	q.waiters = nil
+	q.rejections = nil
 	q.snapshot = nil
+	q.signalAllocatable()
+
+	if q.persistent {
+		return nil
+	}
 
 	if err := os.RemoveAll(q.dir); err != nil {
 		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
@@ -181,6 +315,7 @@ func (q *chunkQueue) discard(index uint32) error {
 	delete(q.chunkFiles, index)
 	delete(q.chunkReturned, index)
 	delete(q.chunkAllocated, index)
+	q.signalAllocatable()
 
 	return nil
 }
@@ -253,6 +388,7 @@ func (q *chunkQueue) Next() (*chunk, error) {
 		chunk, err = q.load(index)
 		if err == nil {
 			q.chunkReturned[index] = true
+			q.signalAllocatable()
 		}
 	}
 
@@ -280,6 +416,7 @@ func (q *chunkQueue) Next() (*chunk, error) {
 	}
 
 	q.chunkReturned[index] = true
+	q.signalAllocatable()
 	return chunk, nil
 }
 
@@ -355,6 +492,25 @@ func (q *chunkQueue) WaitFor(index uint32) <-chan uint32 {
 	return ch
 }
 
+// WaitForRejection returns a channel that is closed when a chunk with the given index is
+// rejected by Add() for failing its checksum, so that it can be refetched. The channel is never
+// closed if the queue is closed.
+func (q *chunkQueue) WaitForRejection(index uint32) <-chan struct{} {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.snapshot == nil {
+		return make(chan struct{})
+	}
+
+	ch, ok := q.rejections[index]
+	if !ok {
+		ch = make(chan struct{})
+		q.rejections[index] = ch
+	}
+	return ch
+}
+
 func (q *chunkQueue) numChunksReturned() int {
 	q.Lock()
 	defer q.Unlock()
@@ -2,6 +2,7 @@ package statesync
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,7 +19,7 @@ func setupChunkQueue(t *testing.T) (*chunkQueue, func()) {
 		Hash:     []byte{7},
 		Metadata: nil,
 	}
-	queue, err := newChunkQueue(snapshot, t.TempDir())
+	queue, err := newChunkQueue(snapshot, t.TempDir(), 0)
 	require.NoError(t, err)
 	teardown := func() {
 		err := queue.Close()
@@ -36,7 +37,7 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 		Metadata: nil,
 	}
 	dir := t.TempDir()
-	queue, err := newChunkQueue(snapshot, dir)
+	queue, err := newChunkQueue(snapshot, dir, 0)
 	require.NoError(t, err)
 
 	files, err := os.ReadDir(dir)
@@ -51,6 +52,43 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestOpenChunkQueue(t *testing.T) {
+	s := &snapshot{Height: 3, Format: 1, Chunks: 5, Hash: []byte{7}}
+	dir := filepath.Join(t.TempDir(), "resume")
+
+	queue, err := openChunkQueue(s, dir, 0)
+	require.NoError(t, err)
+	for _, index := range []uint32{0, 2} {
+		added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: index, Chunk: []byte{3, 1, byte(index)}})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	require.NoError(t, queue.Close())
+
+	// Reopening the queue for the same snapshot loads the stored chunks, which
+	// need not be fetched again.
+	queue, err = openChunkQueue(s, dir, 0)
+	require.NoError(t, err)
+	assert.True(t, queue.Has(0))
+	assert.False(t, queue.Has(1))
+	assert.True(t, queue.Has(2))
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3, 1, 0}, c.Chunk)
+	require.NoError(t, queue.Close())
+
+	// Opening the queue for a different snapshot discards the stored chunks.
+	queue, err = openChunkQueue(&snapshot{Height: 4, Format: 1, Chunks: 5, Hash: []byte{8}}, dir, 0)
+	require.NoError(t, err)
+	assert.False(t, queue.Has(0))
+	assert.False(t, queue.Has(2))
+	require.NoError(t, queue.Close())
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -175,6 +213,84 @@ func TestChunkQueue_Allocate(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_Allocate_Window(t *testing.T) {
+	snapshot := &snapshot{Height: 3, Format: 1, Chunks: 5, Hash: []byte{7}}
+	queue, err := newChunkQueue(snapshot, t.TempDir(), 2)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	// Only the first two chunks are within the window.
+	for i := uint32(0); i < 2; i++ {
+		index, err := queue.Allocate()
+		require.NoError(t, err)
+		assert.EqualValues(t, i, index)
+	}
+	allocatable := queue.WaitForAllocatable()
+	_, err = queue.Allocate()
+	assert.Equal(t, errWindowFull, err)
+
+	// Adding a chunk does not advance the window, but returning it does.
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0}})
+	require.NoError(t, err)
+	_, err = queue.Allocate()
+	assert.Equal(t, errWindowFull, err)
+
+	c, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, c.Index)
+	select {
+	case <-allocatable:
+	default:
+		t.Fatal("expected allocatable to be signaled")
+	}
+
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+	_, err = queue.Allocate()
+	assert.Equal(t, errWindowFull, err)
+
+	// Closing the queue signals waiters.
+	allocatable = queue.WaitForAllocatable()
+	require.NoError(t, queue.Close())
+	select {
+	case <-allocatable:
+	default:
+		t.Fatal("expected allocatable to be signaled")
+	}
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+}
+
+func TestChunkQueue_Add_Checksum(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	// A chunk with an invalid checksum is rejected, and its fetcher is signaled.
+	rejected := queue.WaitForRejection(0)
+	added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0},
+		Checksum: chunkChecksum([]byte{3, 1, 1})})
+	require.ErrorIs(t, err, errInvalidChecksum)
+	assert.False(t, added)
+	assert.False(t, queue.Has(0))
+	select {
+	case <-rejected:
+	default:
+		t.Fatal("rejection was not signaled")
+	}
+
+	// A chunk with a valid checksum is accepted.
+	added, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{3, 1, 0},
+		Checksum: chunkChecksum([]byte{3, 1, 0})})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// A chunk without a checksum is accepted without verification.
+	added, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1, 1}})
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
 func TestChunkQueue_Discard(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
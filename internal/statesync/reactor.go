@@ -143,6 +143,7 @@ type Reactor struct {
 
 	conn           abciclient.Client
 	tempDir        string
+	resumeDir      string
 	peerEvents     p2p.PeerEventSubscriber
 	sendBlockError func(context.Context, p2p.PeerError) error
 	postSyncHook   func(context.Context, sm.State) error
@@ -232,6 +233,13 @@ func NewReactor(
 	return r
 }
 
+// SetResumeDir sets the directory in which the progress of a state sync is
+// persisted, allowing a restarted node to resume restoring the same snapshot
+// without refetching its chunks. If unset, state sync starts over on restart.
+func (r *Reactor) SetResumeDir(dir string) {
+	r.resumeDir = dir
+}
+
 func (r *Reactor) SetSnapshotChannel(ch *p2p.Channel) {
 	r.snapshotChannel = ch
 }
@@ -267,6 +275,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 			snapshotCh:    r.snapshotChannel,
 			chunkCh:       r.chunkChannel,
 			tempDir:       r.tempDir,
+			resumeDir:     r.resumeDir,
 			fetchers:      r.cfg.Fetchers,
 			retryTimeout:  r.cfg.ChunkRequestTimeout,
 			metrics:       r.metrics,
@@ -276,12 +285,12 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 		return &ssproto.LightBlockRequest{
 			Height: height,
 		}
-	})
+	}, light.DispatcherSizeLimits(r.cfg.LightBlockSizeLimits()))
 	r.requestSnaphot = func() error {
 		// request snapshots from all currently connected peers
 		return r.snapshotChannel.Send(ctx, p2p.Envelope{
 			Broadcast: true,
-			Message:   &ssproto.SnapshotsRequest{},
+			Message:   &ssproto.SnapshotsRequest{Incremental: true},
 		})
 	}
 	r.sendBlockError = r.lightBlockChannel.SendError
@@ -319,7 +328,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 			return nil
 		}
 
-		stateProvider, err := light.NewRPCStateProvider(ctx, chainID, initialHeight, r.cfg.VerifyLightBlockTimeout, r.cfg.RPCServers, to, spLogger, r.cfg.BlacklistTTL)
+		stateProvider, err := light.NewRPCStateProvider(ctx, chainID, initialHeight, r.cfg.VerifyLightBlockTimeout, r.cfg.RPCServers, to, spLogger, r.cfg.BlacklistTTL, r.cfg.LightBlockSizeLimits())
 		if err != nil {
 			return fmt.Errorf("failed to initialize RPC state provider: %w", err)
 		}
@@ -638,7 +647,9 @@ func (r *Reactor) handleSnapshotMessage(ctx context.Context, envelope *p2p.Envel
 
 	switch msg := envelope.Message.(type) {
 	case *ssproto.SnapshotsRequest:
-		snapshots, err := r.recentSnapshots(ctx, recentSnapshots)
+		// Incremental snapshots are only advertised to peers able to restore
+		// them, older peers would try to restore them as full snapshots.
+		snapshots, err := r.recentSnapshots(ctx, recentSnapshots, msg.Incremental)
 		if err != nil {
 			logger.Error("failed to fetch snapshots", "err", err)
 			return nil
@@ -655,11 +666,13 @@ func (r *Reactor) handleSnapshotMessage(ctx context.Context, envelope *p2p.Envel
 			if err := snapshotCh.Send(ctx, p2p.Envelope{
 				To: envelope.From,
 				Message: &ssproto.SnapshotsResponse{
-					Height:   snapshot.Height,
-					Format:   snapshot.Format,
-					Chunks:   snapshot.Chunks,
-					Hash:     snapshot.Hash,
-					Metadata: snapshot.Metadata,
+					Height:     snapshot.Height,
+					Format:     snapshot.Format,
+					Chunks:     snapshot.Chunks,
+					Hash:       snapshot.Hash,
+					Metadata:   snapshot.Metadata,
+					BaseHeight: snapshot.BaseHeight,
+					BaseHash:   snapshot.BaseHash,
 				},
 			}); err != nil {
 				return err
@@ -677,11 +690,13 @@ func (r *Reactor) handleSnapshotMessage(ctx context.Context, envelope *p2p.Envel
 
 		logger.Info("received snapshot", "height", msg.Height, "format", msg.Format)
 		_, err := r.syncer.AddSnapshot(envelope.From, &snapshot{
-			Height:   msg.Height,
-			Format:   msg.Format,
-			Chunks:   msg.Chunks,
-			Hash:     msg.Hash,
-			Metadata: msg.Metadata,
+			Height:     msg.Height,
+			Format:     msg.Format,
+			Chunks:     msg.Chunks,
+			Hash:       msg.Hash,
+			Metadata:   msg.Metadata,
+			BaseHeight: msg.BaseHeight,
+			BaseHash:   msg.BaseHash,
 		})
 		if err != nil {
 			logger.Error(
@@ -739,14 +754,19 @@ func (r *Reactor) handleChunkMessage(ctx context.Context, envelope *p2p.Envelope
 			"chunk", msg.Index,
 			"peer", envelope.From,
 		)
+		var checksum []byte
+		if resp.Chunk != nil {
+			checksum = chunkChecksum(resp.Chunk)
+		}
 		if err := chunkCh.Send(ctx, p2p.Envelope{
 			To: envelope.From,
 			Message: &ssproto.ChunkResponse{
-				Height:  msg.Height,
-				Format:  msg.Format,
-				Index:   msg.Index,
-				Chunk:   resp.Chunk,
-				Missing: resp.Chunk == nil,
+				Height:   msg.Height,
+				Format:   msg.Format,
+				Index:    msg.Index,
+				Chunk:    resp.Chunk,
+				Missing:  resp.Chunk == nil,
+				Checksum: checksum,
 			},
 		}); err != nil {
 			return err
@@ -769,11 +789,12 @@ func (r *Reactor) handleChunkMessage(ctx context.Context, envelope *p2p.Envelope
 			"peer", envelope.From,
 		)
 		_, err := r.syncer.AddChunk(&chunk{
-			Height: msg.Height,
-			Format: msg.Format,
-			Index:  msg.Index,
-			Chunk:  msg.Chunk,
-			Sender: envelope.From,
+			Height:   msg.Height,
+			Format:   msg.Format,
+			Index:    msg.Index,
+			Chunk:    msg.Chunk,
+			Sender:   envelope.From,
+			Checksum: msg.Checksum,
 		})
 		if err != nil {
 			r.logger.Error(
@@ -1055,8 +1076,9 @@ func (r *Reactor) processPeerUpdates(ctx context.Context, peerUpdates *p2p.PeerU
 	}
 }
 
-// recentSnapshots fetches the n most recent snapshots from the app
-func (r *Reactor) recentSnapshots(ctx context.Context, n uint32) ([]*snapshot, error) {
+// recentSnapshots fetches the n most recent snapshots from the app, including
+// incremental snapshots if incremental is set.
+func (r *Reactor) recentSnapshots(ctx context.Context, n uint32, incremental bool) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshots(ctx, &abci.RequestListSnapshots{})
 	if err != nil {
 		return nil, err
@@ -1076,24 +1098,73 @@ func (r *Reactor) recentSnapshots(ctx context.Context, n uint32) ([]*snapshot, e
 		}
 	})
 
+	// An incremental snapshot is only advertised along with its bases, so
+	// that the peer is able to restore it.
 	snapshots := make([]*snapshot, 0, n)
-	for i, s := range resp.Snapshots {
-		if i >= recentSnapshots {
-			break
+	advertised := make(map[*abci.Snapshot]bool)
+	for _, s := range resp.Snapshots {
+		if advertised[s] || (s.BaseHeight > 0 && !incremental) {
+			continue
 		}
+		chain, ok := snapshotBases(resp.Snapshots, s)
+		if !ok {
+			continue
+		}
+		chain = append(chain, s)
 
-		snapshots = append(snapshots, &snapshot{
-			Height:   s.Height,
-			Format:   s.Format,
-			Chunks:   s.Chunks,
-			Hash:     s.Hash,
-			Metadata: s.Metadata,
-		})
+		var missing []*abci.Snapshot
+		for _, c := range chain {
+			if !advertised[c] {
+				missing = append(missing, c)
+			}
+		}
+		if len(snapshots)+len(missing) > int(n) {
+			continue
+		}
+
+		for _, c := range missing {
+			advertised[c] = true
+			snapshots = append(snapshots, &snapshot{
+				Height:     c.Height,
+				Format:     c.Format,
+				Chunks:     c.Chunks,
+				Hash:       c.Hash,
+				Metadata:   c.Metadata,
+				BaseHeight: c.BaseHeight,
+				BaseHash:   c.BaseHash,
+			})
+		}
 	}
 
 	return snapshots, nil
 }
 
+// snapshotBases returns the bases of an incremental snapshot among the given
+// snapshots, starting with a full snapshot. It returns false if a base is
+// missing.
+func snapshotBases(snapshots []*abci.Snapshot, s *abci.Snapshot) ([]*abci.Snapshot, bool) {
+	var bases []*abci.Snapshot
+	for s.BaseHeight > 0 {
+		if s.BaseHeight >= s.Height {
+			return nil, false
+		}
+		var base *abci.Snapshot
+		for _, candidate := range snapshots {
+			if candidate.Height == s.BaseHeight && candidate.Format == s.Format &&
+				bytes.Equal(candidate.Hash, s.BaseHash) {
+				base = candidate
+				break
+			}
+		}
+		if base == nil {
+			return nil, false
+		}
+		bases = append([]*abci.Snapshot{base}, bases...)
+		s = base
+	}
+	return bases, true
+}
+
 // fetchLightBlock works out whether the node has a light block at a particular
 // height and if so returns it so it can be gossiped to peers
 func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
@@ -265,12 +265,14 @@ func TestReactor_ChunkRequest(t *testing.T) {
 		"chunk is returned": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
 			[]byte{1, 2, 3},
-			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3}},
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3},
+				Checksum: chunkChecksum([]byte{1, 2, 3})},
 		},
 		"empty chunk is returned, as empty": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
 			[]byte{},
-			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{}},
+			&ssproto.ChunkResponse{Height: 1, Format: 1, Index: 1, Chunk: []byte{},
+				Checksum: chunkChecksum([]byte{})},
 		},
 		"nil (missing) chunk is returned as missing": {
 			&ssproto.ChunkRequest{Height: 1, Format: 1, Index: 1},
@@ -411,6 +413,63 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_SnapshotsRequest_Incremental(t *testing.T) {
+	snapshots := []*abci.Snapshot{
+		{Height: 4, Format: 1, Chunks: 1, Hash: []byte{4}, BaseHeight: 9, BaseHash: []byte{9}},
+		{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}, BaseHeight: 2, BaseHash: []byte{2}},
+		{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 1, BaseHash: []byte{1}},
+		{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+	}
+	testcases := map[string]struct {
+		incremental     bool
+		expectResponses []*ssproto.SnapshotsResponse
+	}{
+		"full snapshots only": {false, []*ssproto.SnapshotsResponse{
+			{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+		}},
+		"incremental snapshots with their bases": {true, []*ssproto.SnapshotsResponse{
+			{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+			{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 1, BaseHash: []byte{1}},
+			{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}, BaseHeight: 2, BaseHash: []byte{2}},
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for name, tc := range testcases {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			conn := &clientmocks.Client{}
+			conn.On("ListSnapshots", mock.Anything, &abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+				Snapshots: snapshots,
+			}, nil)
+
+			rts := setup(ctx, t, conn, nil, 100)
+
+			rts.snapshotInCh <- p2p.Envelope{
+				From:      types.NodeID("aa"),
+				ChannelID: SnapshotChannel,
+				Message:   &ssproto.SnapshotsRequest{Incremental: tc.incremental},
+			}
+
+			retryUntil(ctx, t, func() bool { return len(rts.snapshotOutCh) == len(tc.expectResponses) }, time.Second)
+
+			responses := make([]*ssproto.SnapshotsResponse, len(tc.expectResponses))
+			for i := 0; i < len(tc.expectResponses); i++ {
+				e := <-rts.snapshotOutCh
+				responses[i] = e.Message.(*ssproto.SnapshotsResponse)
+			}
+
+			require.Equal(t, tc.expectResponses, responses)
+			require.Empty(t, rts.snapshotOutCh)
+		})
+	}
+}
+
 func TestReactor_LightBlockResponse(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
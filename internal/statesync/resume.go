@@ -0,0 +1,73 @@
+package statesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+)
+
+// resumeSnapshotFile is the name of the file, within the resume directory, that
+// records the snapshot being restored. The snapshot's chunks are stored
+// alongside it, as files named by chunk index.
+const resumeSnapshotFile = "snapshot.json"
+
+// resumeSnapshot is the on-disk representation of an in-progress snapshot.
+type resumeSnapshot struct {
+	Height   uint64 `json:"height"`
+	Format   uint32 `json:"format"`
+	Chunks   uint32 `json:"chunks"`
+	Hash     []byte `json:"hash"`
+	Metadata []byte `json:"metadata"`
+}
+
+// loadResumeSnapshot loads the in-progress snapshot recorded in dir, or nil if
+// there is none.
+func loadResumeSnapshot(dir string) (*snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, resumeSnapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read resumable snapshot: %w", err)
+	}
+
+	var rs resumeSnapshot
+	if err := json.Unmarshal(bz, &rs); err != nil {
+		return nil, fmt.Errorf("failed to decode resumable snapshot: %w", err)
+	}
+
+	return &snapshot{
+		Height:   rs.Height,
+		Format:   rs.Format,
+		Chunks:   rs.Chunks,
+		Hash:     rs.Hash,
+		Metadata: rs.Metadata,
+	}, nil
+}
+
+// saveResumeSnapshot records snapshot as the in-progress snapshot in dir.
+func saveResumeSnapshot(dir string, snapshot *snapshot) error {
+	bz, err := json.Marshal(resumeSnapshot{
+		Height:   snapshot.Height,
+		Format:   snapshot.Format,
+		Chunks:   snapshot.Chunks,
+		Hash:     snapshot.Hash,
+		Metadata: snapshot.Metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode resumable snapshot: %w", err)
+	}
+
+	return tempfile.WriteFileAtomic(filepath.Join(dir, resumeSnapshotFile), bz, 0600)
+}
+
+// discardResumeState removes the in-progress snapshot and its chunks from dir.
+func discardResumeState(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove state sync resume dir %v: %w", dir, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"fmt"
 	"math/rand"
@@ -22,11 +23,17 @@ type snapshot struct {
 	Hash     []byte
 	Metadata []byte
 
+	// BaseHeight and BaseHash identify the base snapshot of an incremental
+	// snapshot, whose chunks are diffs applied on top of the restored base.
+	// BaseHeight is 0 for full snapshots.
+	BaseHeight uint64
+	BaseHash   []byte
+
 	trustedAppHash []byte // populated by light client
 }
 
 // Key generates a snapshot key, used for lookups. It takes into account not only the height and
-// format, but also the chunks, hash, and metadata in case peers have generated snapshots in a
+// format, but also the chunks, hash, metadata and base in case peers have generated snapshots in a
 // non-deterministic manner. All fields must be equal for the snapshot to be considered the same.
 func (s *snapshot) Key() snapshotKey {
 	// Hash.Write() never returns an error.
@@ -34,11 +41,21 @@ func (s *snapshot) Key() snapshotKey {
 	hasher.Write([]byte(fmt.Sprintf("%v:%v:%v", s.Height, s.Format, s.Chunks)))
 	hasher.Write(s.Hash)
 	hasher.Write(s.Metadata)
+	if s.IsIncremental() {
+		hasher.Write([]byte(fmt.Sprintf(":%v:", s.BaseHeight)))
+		hasher.Write(s.BaseHash)
+	}
 	var key snapshotKey
 	copy(key[:], hasher.Sum(nil))
 	return key
 }
 
+// IsIncremental returns true if the snapshot must be applied on top of a base
+// snapshot.
+func (s *snapshot) IsIncremental() bool {
+	return s.BaseHeight > 0
+}
+
 // snapshotPool discovers and aggregates snapshots across peers.
 type snapshotPool struct {
 	sync.Mutex
@@ -119,13 +136,44 @@ func (p *snapshotPool) Add(peerID types.NodeID, snapshot *snapshot) (bool, error
 	return true, nil
 }
 
-// Best returns the "best" currently known snapshot, if any.
+// Best returns the "best" currently known snapshot, if any. Incremental
+// snapshots are only returned if all their bases are known.
 func (p *snapshotPool) Best() *snapshot {
-	ranked := p.Ranked()
-	if len(ranked) == 0 {
-		return nil
+	for _, snapshot := range p.Ranked() {
+		if !snapshot.IsIncremental() || p.Bases(snapshot) != nil {
+			return snapshot
+		}
+	}
+	return nil
+}
+
+// Bases returns the chain of base snapshots an incremental snapshot is applied
+// on, starting with a full snapshot, or nil if a base is not in the pool. Bases
+// must have the same format as the snapshot and a lower height.
+func (p *snapshotPool) Bases(incremental *snapshot) []*snapshot {
+	p.Lock()
+	defer p.Unlock()
+
+	var bases []*snapshot
+	for s := incremental; s.IsIncremental(); {
+		if s.BaseHeight >= s.Height {
+			return nil
+		}
+		var base *snapshot
+		for key := range p.heightIndex[s.BaseHeight] {
+			candidate := p.snapshots[key]
+			if candidate.Format == s.Format && bytes.Equal(candidate.Hash, s.BaseHash) {
+				base = candidate
+				break
+			}
+		}
+		if base == nil {
+			return nil
+		}
+		bases = append([]*snapshot{base}, bases...)
+		s = base
 	}
-	return ranked[0]
+	return bases
 }
 
 // GetPeer returns a random peer for a snapshot, if any.
@@ -137,6 +185,21 @@ func (p *snapshotPool) GetPeer(snapshot *snapshot) types.NodeID {
 	return peers[rand.Intn(len(peers))] // nolint:gosec // G404: Use of weak random number generator
 }
 
+// GetPeerExcluding returns a random peer for a snapshot which is not in the
+// given set, if any.
+func (p *snapshotPool) GetPeerExcluding(snapshot *snapshot, exclude map[types.NodeID]bool) types.NodeID {
+	peers := make([]types.NodeID, 0)
+	for _, peer := range p.GetPeers(snapshot) {
+		if !exclude[peer] {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) == 0 {
+		return ""
+	}
+	return peers[rand.Intn(len(peers))] // nolint:gosec // G404: Use of weak random number generator
+}
+
 // GetPeers returns the peers for a snapshot.
 func (p *snapshotPool) GetPeers(snapshot *snapshot) []types.NodeID {
 	key := snapshot.Key()
@@ -17,6 +17,7 @@ func TestSnapshot_Key(t *testing.T) {
 		"new chunk count": {func(s *snapshot) { s.Chunks = 9 }},
 		"new hash":        {func(s *snapshot) { s.Hash = []byte{9} }},
 		"no metadata":     {func(s *snapshot) { s.Metadata = nil }},
+		"new base":        {func(s *snapshot) { s.BaseHeight, s.BaseHash = 2, []byte{2} }},
 	}
 	for name, tc := range testcases {
 		tc := tc
@@ -100,6 +101,27 @@ func TestSnapshotPool_GetPeer(t *testing.T) {
 	require.EqualValues(t, "", peer)
 }
 
+func TestSnapshotPool_GetPeerExcluding(t *testing.T) {
+	pool := newSnapshotPool()
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+
+	peerAID := types.NodeID("aa")
+	peerBID := types.NodeID("bb")
+
+	_, err := pool.Add(peerAID, s)
+	require.NoError(t, err)
+
+	_, err = pool.Add(peerBID, s)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, peerBID, pool.GetPeerExcluding(s, map[types.NodeID]bool{peerAID: true}))
+	}
+	require.Empty(t, pool.GetPeerExcluding(s, map[types.NodeID]bool{peerAID: true, peerBID: true}))
+	require.NotEmpty(t, pool.GetPeerExcluding(s, nil))
+}
+
 func TestSnapshotPool_GetPeers(t *testing.T) {
 	pool := newSnapshotPool()
 
@@ -166,6 +188,38 @@ func TestSnapshotPool_Ranked_Best(t *testing.T) {
 	require.Nil(t, pool.Best())
 }
 
+func TestSnapshotPool_Bases(t *testing.T) {
+	pool := newSnapshotPool()
+	peerID := types.NodeID("aa")
+
+	full := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	diff1 := &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 1, BaseHash: []byte{1}}
+	diff2 := &snapshot{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}, BaseHeight: 2, BaseHash: []byte{2}}
+	otherFormat := &snapshot{Height: 2, Format: 2, Chunks: 1, Hash: []byte{2}}
+
+	for _, s := range []*snapshot{diff2, otherFormat} {
+		_, err := pool.Add(peerID, s)
+		require.NoError(t, err)
+	}
+	require.Nil(t, pool.Bases(diff2))
+	require.Equal(t, otherFormat, pool.Best())
+
+	_, err := pool.Add(peerID, diff1)
+	require.NoError(t, err)
+	require.Nil(t, pool.Bases(diff2))
+
+	_, err = pool.Add(peerID, full)
+	require.NoError(t, err)
+	require.Equal(t, []*snapshot{full, diff1}, pool.Bases(diff2))
+	require.Equal(t, []*snapshot{full}, pool.Bases(diff1))
+	require.Empty(t, pool.Bases(full))
+	require.Equal(t, diff2, pool.Best())
+
+	// A base with a height above the snapshot is never used.
+	invalid := &snapshot{Height: 4, Format: 1, Chunks: 1, Hash: []byte{4}, BaseHeight: 5, BaseHash: []byte{1}}
+	require.Nil(t, pool.Bases(invalid))
+}
+
 func TestSnapshotPool_Reject(t *testing.T) {
 	pool := newSnapshotPool()
 
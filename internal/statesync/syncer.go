@@ -23,6 +23,10 @@ const (
 	// chunkTimeout is the timeout while waiting for the next chunk from the chunk queue.
 	chunkTimeout = 2 * time.Minute
 
+	// chunkWindowPerFetcher is the number of chunks each fetcher may fetch ahead of the
+	// chunk being applied, bounding the number of chunks buffered during a restore.
+	chunkWindowPerFetcher = 4
+
 	// minimumDiscoveryTime is the lowest allowable time for a
 	// SyncAny discovery time.
 	minimumDiscoveryTime = 5 * time.Second
@@ -59,6 +63,7 @@ type syncer struct {
 	snapshotCh    *p2p.Channel
 	chunkCh       *p2p.Channel
 	tempDir       string
+	resumeDir     string
 	fetchers      int32
 	retryTimeout  time.Duration
 
@@ -115,7 +120,7 @@ func (s *syncer) AddPeer(ctx context.Context, peerID types.NodeID) error {
 
 	return s.snapshotCh.Send(ctx, p2p.Envelope{
 		To:      peerID,
-		Message: &ssproto.SnapshotsRequest{},
+		Message: &ssproto.SnapshotsRequest{Incremental: true},
 	})
 }
 
@@ -148,7 +153,7 @@ func (s *syncer) SyncAny(
 	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
 	// the snapshot and chunk queue from the previous loop iteration.
 	var (
-		snapshot *snapshot
+		snapshot = s.resumableSnapshot()
 		chunks   *chunkQueue
 		err      error
 	)
@@ -167,7 +172,12 @@ func (s *syncer) SyncAny(
 			continue
 		}
 		if chunks == nil {
-			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			window := uint32(s.fetchers) * chunkWindowPerFetcher
+			if s.resumeDir != "" {
+				chunks, err = openChunkQueue(snapshot, s.resumeDir, window)
+			} else {
+				chunks, err = newChunkQueue(snapshot, s.tempDir, window)
+			}
 			if err != nil {
 				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
@@ -182,9 +192,11 @@ func (s *syncer) SyncAny(
 		case err == nil:
 			s.metrics.SnapshotHeight.Set(float64(snapshot.Height))
 			s.lastSyncedSnapshotHeight = int64(snapshot.Height)
+			s.discardResumeState()
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.discardResumeState()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -230,6 +242,46 @@ func (s *syncer) SyncAny(
 	}
 }
 
+// resumableSnapshot returns the snapshot that was being restored when the node
+// last stopped, if any. The snapshot is only resumed if it is still offered by
+// a peer, otherwise its chunks are discarded.
+func (s *syncer) resumableSnapshot() *snapshot {
+	if s.resumeDir == "" {
+		return nil
+	}
+
+	snapshot, err := loadResumeSnapshot(s.resumeDir)
+	if err != nil {
+		s.logger.Error("Failed to load resumable snapshot, discarding it", "err", err)
+		s.discardResumeState()
+		return nil
+	}
+	if snapshot == nil {
+		return nil
+	}
+
+	if len(s.snapshots.GetPeers(snapshot)) == 0 {
+		s.logger.Info("Resumable snapshot is no longer offered by any peer, discarding it",
+			"height", snapshot.Height, "format", snapshot.Format, "hash", snapshot.Hash)
+		s.discardResumeState()
+		return nil
+	}
+
+	s.logger.Info("Resuming state sync of snapshot", "height", snapshot.Height,
+		"format", snapshot.Format, "hash", snapshot.Hash)
+	return snapshot
+}
+
+// discardResumeState removes any persisted state sync progress.
+func (s *syncer) discardResumeState() {
+	if s.resumeDir == "" {
+		return
+	}
+	if err := discardResumeState(s.resumeDir); err != nil {
+		s.logger.Error("Failed to discard state sync resume state", "err", err)
+	}
+}
+
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
 // the caller must use to bootstrap the node.
 func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
@@ -246,29 +298,30 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 		s.mtx.Unlock()
 	}()
 
-	hctx, hcancel := context.WithTimeout(ctx, 30*time.Second)
-	defer hcancel()
-
-	// Fetch the app hash corresponding to the snapshot
-	appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
-	if err != nil {
-		// check if the main context was triggered
-		if ctx.Err() != nil {
-			return sm.State{}, nil, ctx.Err()
+	// Restore the bases of an incremental snapshot first, the snapshot is
+	// then applied on top of them.
+	if snapshot.IsIncremental() {
+		bases := s.snapshots.Bases(snapshot)
+		if bases == nil {
+			s.logger.Info("Base of incremental snapshot is unknown, dropping snapshot",
+				"height", snapshot.Height, "base_height", snapshot.BaseHeight)
+			return sm.State{}, nil, errRejectSnapshot
 		}
-		// catch the case where all the light client providers have been exhausted
-		if err == light.ErrNoWitnesses {
-			return sm.State{}, nil,
-				fmt.Errorf("failed to get app hash at height %d. No witnesses remaining", snapshot.Height)
+		for _, base := range bases {
+			if err := s.restoreBase(ctx, base); err != nil {
+				return sm.State{}, nil, err
+			}
 		}
-		s.logger.Info("failed to get and verify tendermint state. Dropping snapshot and trying again",
-			"err", err, "height", snapshot.Height)
-		return sm.State{}, nil, errRejectSnapshot
+		s.swapChunks(chunks)
+	}
+
+	// Fetch the app hash corresponding to the snapshot
+	if err := s.trustSnapshot(ctx, snapshot); err != nil {
+		return sm.State{}, nil, err
 	}
-	snapshot.trustedAppHash = appHash
 
 	// Offer snapshot to ABCI app.
-	err = s.offerSnapshot(ctx, snapshot)
+	err := s.offerSnapshot(ctx, snapshot)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
@@ -332,18 +385,89 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 	return state, commit, nil
 }
 
+// trustSnapshot fetches the app hash at the snapshot height from the light
+// client, and sets it as the trusted app hash of the snapshot.
+func (s *syncer) trustSnapshot(ctx context.Context, snapshot *snapshot) error {
+	hctx, hcancel := context.WithTimeout(ctx, 30*time.Second)
+	defer hcancel()
+
+	appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
+	if err != nil {
+		// check if the main context was triggered
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// catch the case where all the light client providers have been exhausted
+		if err == light.ErrNoWitnesses {
+			return fmt.Errorf("failed to get app hash at height %d. No witnesses remaining", snapshot.Height)
+		}
+		s.logger.Info("failed to get and verify tendermint state. Dropping snapshot and trying again",
+			"err", err, "height", snapshot.Height)
+		return errRejectSnapshot
+	}
+	snapshot.trustedAppHash = appHash
+	return nil
+}
+
+// restoreBase offers and applies a base snapshot of an incremental snapshot.
+// The chunks of the base are fetched into a temporary chunk queue, which
+// receives chunks from peers while the base is restored.
+func (s *syncer) restoreBase(ctx context.Context, base *snapshot) error {
+	chunks, err := newChunkQueue(base, s.tempDir, uint32(s.fetchers)*chunkWindowPerFetcher)
+	if err != nil {
+		return fmt.Errorf("failed to create chunk queue: %w", err)
+	}
+	defer func() {
+		if err := chunks.Close(); err != nil {
+			s.logger.Error("Failed to clean up chunk queue", "err", err)
+		}
+	}()
+	s.swapChunks(chunks)
+
+	if err := s.trustSnapshot(ctx, base); err != nil {
+		return err
+	}
+	if err := s.offerSnapshot(ctx, base); err != nil {
+		return err
+	}
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetchStartTime := time.Now()
+	for i := int32(0); i < s.fetchers; i++ {
+		go s.fetchChunks(fetchCtx, base, chunks)
+	}
+	if err := s.applyChunks(ctx, chunks, fetchStartTime); err != nil {
+		return err
+	}
+
+	s.logger.Info("Base snapshot restored", "height", base.Height, "format", base.Format,
+		"hash", base.Hash)
+	return nil
+}
+
+// swapChunks replaces the chunk queue receiving chunks from peers during a
+// sync.
+func (s *syncer) swapChunks(chunks *chunkQueue) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.chunks = chunks
+}
+
 // offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
 // response, or nil if the snapshot was accepted.
 func (s *syncer) offerSnapshot(ctx context.Context, snapshot *snapshot) error {
 	s.logger.Info("Offering snapshot to ABCI app", "height", snapshot.Height,
-		"format", snapshot.Format, "hash", snapshot.Hash)
+		"format", snapshot.Format, "hash", snapshot.Hash, "base_height", snapshot.BaseHeight)
 	resp, err := s.conn.OfferSnapshot(ctx, &abci.RequestOfferSnapshot{
 		Snapshot: &abci.Snapshot{
-			Height:   snapshot.Height,
-			Format:   snapshot.Format,
-			Chunks:   snapshot.Chunks,
-			Hash:     snapshot.Hash,
-			Metadata: snapshot.Metadata,
+			Height:     snapshot.Height,
+			Format:     snapshot.Format,
+			Chunks:     snapshot.Chunks,
+			Hash:       snapshot.Hash,
+			Metadata:   snapshot.Metadata,
+			BaseHeight: snapshot.BaseHeight,
+			BaseHash:   snapshot.BaseHash,
 		},
 		AppHash: snapshot.trustedAppHash,
 	})
@@ -430,17 +554,29 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 }
 
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
-// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
+// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add(). If a chunk
+// request times out, it is retried from a peer that has not yet been asked for the chunk, if
+// any.
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
 	var (
 		next  = true
 		index uint32
+		tried map[types.NodeID]bool
 		err   error
 	)
 
 	for {
 		if next {
+			allocatable := chunks.WaitForAllocatable()
 			index, err = chunks.Allocate()
+			if errors.Is(err, errWindowFull) {
+				select {
+				case <-ctx.Done():
+					return
+				case <-allocatable:
+					continue
+				}
+			}
 			if errors.Is(err, errDone) {
 				// Keep checking until the context is canceled (restore is done), in case any
 				// chunks need to be refetched.
@@ -455,6 +591,12 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 				s.logger.Error("Failed to allocate chunk from queue", "err", err)
 				return
 			}
+
+			// If the chunk was discarded for refetching, prefer a different sender.
+			tried = make(map[types.NodeID]bool)
+			if sender := chunks.GetSender(index); sender != "" {
+				tried[sender] = true
+			}
 		}
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
@@ -462,7 +604,8 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		ticker := time.NewTicker(s.retryTimeout)
 		defer ticker.Stop()
 
-		if err := s.requestChunk(ctx, snapshot, index); err != nil {
+		rejected := chunks.WaitForRejection(index)
+		if err := s.requestChunk(ctx, snapshot, index, tried); err != nil {
 			return
 		}
 
@@ -470,6 +613,10 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		case <-chunks.WaitFor(index):
 			next = true
 
+		case <-rejected:
+			// The chunk failed its checksum: refetch it right away, from another peer.
+			next = false
+
 		case <-ticker.C:
 			next = false
 
@@ -481,19 +628,29 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
+// requestChunk requests a chunk from a peer, preferring peers that are not in
+// the tried set and adding the chosen peer to it.
 //
 // returns nil if there are no peers for the given snapshot or the
 // request is successfully made and an error if the request cannot be
 // completed
-func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uint32) error {
-	peer := s.snapshots.GetPeer(snapshot)
+func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uint32, tried map[types.NodeID]bool) error {
+	peer := s.snapshots.GetPeerExcluding(snapshot, tried)
+	if peer == "" {
+		// All peers have been tried, so start over.
+		for p := range tried {
+			delete(tried, p)
+		}
+		peer = s.snapshots.GetPeer(snapshot)
+	}
 	if peer == "" {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
 		return nil
 	}
 
+	tried[peer] = true
+
 	s.logger.Debug(
 		"Requesting snapshot chunk",
 		"height", snapshot.Height,
@@ -3,6 +3,8 @@ package statesync
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/internal/proxy"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/statesync/mocks"
+	"github.com/tendermint/tendermint/libs/log"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
@@ -79,13 +82,13 @@ func TestSyncer_SyncAny(t *testing.T) {
 	err = rts.syncer.AddPeer(ctx, peerAID)
 	require.NoError(t, err)
 	e := <-rts.snapshotOutCh
-	require.Equal(t, &ssproto.SnapshotsRequest{}, e.Message)
+	require.Equal(t, &ssproto.SnapshotsRequest{Incremental: true}, e.Message)
 	require.Equal(t, peerAID, e.To)
 
 	err = rts.syncer.AddPeer(ctx, peerBID)
 	require.NoError(t, err)
 	e = <-rts.snapshotOutCh
-	require.Equal(t, &ssproto.SnapshotsRequest{}, e.Message)
+	require.Equal(t, &ssproto.SnapshotsRequest{Incremental: true}, e.Message)
 	require.Equal(t, peerBID, e.To)
 
 	// Both peers report back with snapshots. One of them also returns a snapshot we don't want, in
@@ -219,6 +222,103 @@ func TestSyncer_SyncAny(t *testing.T) {
 	conn.AssertExpectations(t)
 }
 
+func TestSyncer_SyncAny_incremental(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state := sm.State{
+		ChainID: "chain",
+		Version: sm.Version{
+			Consensus: version.Consensus{
+				Block: version.BlockProtocol,
+				App:   testAppVersion,
+			},
+			Software: version.TMVersion,
+		},
+		LastBlockHeight: 2,
+		AppHash:         []byte("app_hash_2"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+
+	base := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	diff := &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 1, BaseHash: []byte{1}}
+	chunks := map[uint64][]*chunk{
+		1: {
+			{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 0}},
+			{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 1}},
+		},
+		2: {
+			{Height: 2, Format: 1, Index: 0, Chunk: []byte{2, 0}},
+		},
+	}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return([]byte("app_hash_1"), nil)
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(state, nil)
+	conn := &clientmocks.Client{}
+
+	rts := setup(ctx, t, conn, stateProvider, 2)
+
+	peerID := types.NodeID("aa")
+
+	// The incremental snapshot is only usable once its base is known.
+	_, err := rts.syncer.AddSnapshot(peerID, diff)
+	require.NoError(t, err)
+	require.Nil(t, rts.syncer.snapshots.Best())
+	_, err = rts.syncer.AddSnapshot(peerID, base)
+	require.NoError(t, err)
+	require.Equal(t, diff, rts.syncer.snapshots.Best())
+
+	// The base is restored first, then the incremental snapshot is applied
+	// on top of it.
+	conn.On("OfferSnapshot", mock.Anything, &abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}},
+		AppHash:  []byte("app_hash_1"),
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	conn.On("OfferSnapshot", mock.Anything, &abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}, BaseHeight: 1, BaseHash: []byte{1}},
+		AppHash:  []byte("app_hash_2"),
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+
+	var applied []uint64
+	for _, c := range append(chunks[1], chunks[2]...) {
+		c := c
+		conn.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+			Index: c.Index, Chunk: c.Chunk,
+		}).Once().Run(func(args mock.Arguments) { applied = append(applied, c.Height) }).Return(
+			&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	conn.On("Info", mock.Anything, &proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  2,
+		LastBlockAppHash: []byte("app_hash_2"),
+	}, nil)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-rts.chunkOutCh:
+				msg, ok := e.Message.(*ssproto.ChunkRequest)
+				assert.True(t, ok)
+				_, err := rts.syncer.AddChunk(chunks[msg.Height][msg.Index])
+				assert.NoError(t, err)
+			}
+		}
+	}()
+
+	newState, lastCommit, err := rts.syncer.SyncAny(ctx, 0, func() error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, state, newState)
+	require.Equal(t, commit, lastCommit)
+	require.Equal(t, []uint64{1, 1, 2}, applied)
+
+	conn.AssertExpectations(t)
+}
+
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
@@ -411,6 +511,47 @@ func TestSyncer_SyncAny_abciError(t *testing.T) {
 	rts.conn.AssertExpectations(t)
 }
 
+func TestSyncer_resumableSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "resume")
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	peerID := types.NodeID("aa")
+
+	saveProgress := func() {
+		queue, err := openChunkQueue(s, dir, 0)
+		require.NoError(t, err)
+		_, err = queue.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1, 0}})
+		require.NoError(t, err)
+		require.NoError(t, queue.Close())
+	}
+
+	newSyncer := func() *syncer {
+		return &syncer{
+			logger:    log.NewNopLogger(),
+			snapshots: newSnapshotPool(),
+			resumeDir: dir,
+		}
+	}
+
+	// Without progress, there is nothing to resume.
+	require.Nil(t, newSyncer().resumableSnapshot())
+
+	// A snapshot still offered by a peer is resumed.
+	saveProgress()
+	syncer := newSyncer()
+	_, err := syncer.snapshots.Add(peerID, &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}})
+	require.NoError(t, err)
+	resumed := syncer.resumableSnapshot()
+	require.NotNil(t, resumed)
+	require.Equal(t, s.Key(), resumed.Key())
+	_, err = os.Stat(filepath.Join(dir, "0"))
+	require.NoError(t, err)
+
+	// A snapshot no longer offered by any peer is discarded.
+	require.Nil(t, newSyncer().resumableSnapshot())
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
+
 func TestSyncer_offerSnapshot(t *testing.T) {
 	unknownErr := errors.New("unknown error")
 	boom := errors.New("boom")
@@ -498,7 +639,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 			rts := setup(ctx, t, nil, stateProvider, 2)
 
 			body := []byte{1, 2, 3}
-			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, t.TempDir())
+			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 1}, t.TempDir(), 0)
 			require.NoError(t, err)
 
 			fetchStartTime := time.Now()
@@ -557,7 +698,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 
 			rts := setup(ctx, t, nil, stateProvider, 2)
 
-			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, t.TempDir())
+			chunks, err := newChunkQueue(&snapshot{Height: 1, Format: 1, Chunks: 3}, t.TempDir(), 0)
 			require.NoError(t, err)
 
 			fetchStartTime := time.Now()
@@ -655,7 +796,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			_, err = rts.syncer.AddSnapshot(peerCID, s2)
 			require.NoError(t, err)
 
-			chunks, err := newChunkQueue(s1, t.TempDir())
+			chunks, err := newChunkQueue(s1, t.TempDir(), 0)
 			require.NoError(t, err)
 
 			fetchStartTime := time.Now()
@@ -717,6 +858,52 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 	}
 }
 
+func TestSyncer_fetchChunks_InvalidChecksum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rts := setup(ctx, t, nil, nil, 2)
+	// Refetches can only be prompted by the rejection of the chunk.
+	rts.syncer.retryTimeout = time.Hour
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+	for _, peerID := range []types.NodeID{"aa", "bb"} {
+		_, err := rts.syncer.AddSnapshot(peerID, s)
+		require.NoError(t, err)
+	}
+
+	queue, err := newChunkQueue(s, t.TempDir(), 0)
+	require.NoError(t, err)
+	defer queue.Close()
+	rts.syncer.swapChunks(queue)
+
+	go rts.syncer.fetchChunks(ctx, s, queue)
+
+	// The first peer sends a chunk that does not match its checksum, which is rejected.
+	body := []byte{1, 1, 0}
+	e := <-rts.chunkOutCh
+	require.Equal(t, &ssproto.ChunkRequest{Height: 1, Format: 1, Index: 0}, e.Message)
+	_, err = rts.syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1, 1},
+		Sender: e.To, Checksum: chunkChecksum(body)})
+	require.ErrorIs(t, err, errInvalidChecksum)
+	require.False(t, queue.Has(0))
+
+	// The chunk is refetched right away, from the other peer.
+	first := e.To
+	select {
+	case e = <-rts.chunkOutCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rejected chunk was not refetched")
+	}
+	require.Equal(t, &ssproto.ChunkRequest{Height: 1, Format: 1, Index: 0}, e.Message)
+	require.NotEqual(t, first, e.To)
+
+	added, err := rts.syncer.AddChunk(&chunk{Height: 1, Format: 1, Index: 0, Chunk: body,
+		Sender: e.To, Checksum: chunkChecksum(body)})
+	require.NoError(t, err)
+	require.True(t, added)
+}
+
 func TestSyncer_verifyApp(t *testing.T) {
 	boom := errors.New("boom")
 	const appVersion = 9
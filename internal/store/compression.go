@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression identifies the codec used to compress block parts before they
+// are written to the underlying database. The codec is recorded per block so
+// that the configured codec can be changed without rewriting existing blocks.
+type Compression byte
+
+const (
+	// CompressionNone stores block parts as plain protobuf. Blocks without a
+	// recorded codec (e.g. those written before compression was supported)
+	// are treated as uncompressed.
+	CompressionNone Compression = iota
+	// CompressionSnappy compresses block parts with snappy.
+	CompressionSnappy
+	// CompressionZstd compresses block parts with zstd.
+	CompressionZstd
+)
+
+// String returns the configuration name of the codec.
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionSnappy:
+		return "snappy"
+	case CompressionZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(c))
+	}
+}
+
+// ParseCompression returns the codec with the given configuration name. The
+// empty string is equivalent to "none".
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "", "none":
+		return CompressionNone, nil
+	case "snappy":
+		return CompressionSnappy, nil
+	case "zstd":
+		return CompressionZstd, nil
+	default:
+		return CompressionNone, fmt.Errorf("unknown block compression codec %q", name)
+	}
+}
+
+// zstd encoders and decoders are safe for concurrent use when only the
+// EncodeAll and DecodeAll methods are used, so a single instance of each is
+// shared by all block stores.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func (c Compression) compress(bz []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return bz, nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, bz), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(bz, make([]byte, 0, len(bz))), nil
+	default:
+		return nil, fmt.Errorf("unknown block compression codec %v", c)
+	}
+}
+
+func (c Compression) decompress(bz []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return bz, nil
+	case CompressionSnappy:
+		return snappy.Decode(nil, bz)
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(bz, nil)
+	default:
+		return nil, fmt.Errorf("unknown block compression codec %v", c)
+	}
+}
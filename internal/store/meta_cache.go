@@ -0,0 +1,100 @@
+package store
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// blockMetaCache is a thread-safe LRU cache of block metas by height. A nil
+// blockMetaCache caches nothing.
+//
+// Loading a meta from the database and adding it to the cache aren't atomic,
+// so a meta loaded before its height was deleted could be added after the
+// deletion. To prevent this, each invalidation bumps the generation of the
+// cache, and metas loaded in a previous generation are not added.
+type blockMetaCache struct {
+	mtx        sync.Mutex
+	size       int
+	generation uint64
+	entries    map[int64]*list.Element
+	list       *list.List // of *blockMetaEntry, most recently used at the back
+}
+
+type blockMetaEntry struct {
+	height int64
+	meta   types.BlockMeta
+}
+
+func newBlockMetaCache(size int) *blockMetaCache {
+	if size <= 0 {
+		return nil
+	}
+	return &blockMetaCache{
+		size:    size,
+		entries: make(map[int64]*list.Element, size),
+		list:    list.New(),
+	}
+}
+
+// get returns a copy of the cached meta at height, or nil if it isn't cached,
+// along with the current generation of the cache, to pass to add.
+func (c *blockMetaCache) get(height int64) (*types.BlockMeta, uint64) {
+	if c == nil {
+		return nil, 0
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[height]
+	if !ok {
+		return nil, c.generation
+	}
+	c.list.MoveToBack(elem)
+	meta := elem.Value.(*blockMetaEntry).meta
+	return &meta, c.generation
+}
+
+// add caches a copy of the meta at height, which was loaded from the database
+// during the given generation, evicting the least recently used meta if the
+// cache is full. It does nothing if the cache was invalidated since.
+func (c *blockMetaCache) add(height int64, meta *types.BlockMeta, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if elem, ok := c.entries[height]; ok {
+		c.list.MoveToBack(elem)
+		return
+	}
+	if c.list.Len() >= c.size {
+		front := c.list.Front()
+		delete(c.entries, front.Value.(*blockMetaEntry).height)
+		c.list.Remove(front)
+	}
+	c.entries[height] = c.list.PushBack(&blockMetaEntry{height: height, meta: *meta})
+}
+
+// invalidate removes the cached metas at the heights for which remove returns
+// true, and starts a new generation.
+func (c *blockMetaCache) invalidate(remove func(height int64) bool) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.generation++
+	for height, elem := range c.entries {
+		if remove(height) {
+			delete(c.entries, height)
+			c.list.Remove(elem)
+		}
+	}
+}
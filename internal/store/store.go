@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -22,6 +24,10 @@ There are three types of information stored:
   - Block part:  Parts of each block, aggregated w/ PartSet
   - Commit:      The commit part of each block, for gossiping precommit votes
 
+Block parts may optionally be compressed (see WithCompression). The codec used
+for each block is recorded alongside it, so blocks written with different
+codecs, or before compression was enabled, can always be loaded.
+
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
@@ -32,13 +38,40 @@ The store can be assumed to contain all contiguous blocks between base and heigh
 // deserializing loaded data, indicating probable corruption on disk.
 */
 type BlockStore struct {
-	db dbm.DB
+	db          dbm.DB
+	compression Compression
+	metaCache   *blockMetaCache
+}
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithCompression sets the codec used to compress the parts of newly saved
+// blocks. Previously saved blocks are loaded using the codec they were
+// written with.
+func WithCompression(c Compression) BlockStoreOption {
+	return func(bs *BlockStore) {
+		bs.compression = c
+	}
+}
+
+// WithBlockMetaCacheSize sets the number of block metas kept in memory by
+// LoadBlockMeta, evicting the least recently used ones. Zero, the default,
+// disables the cache.
+func WithBlockMetaCacheSize(size int) BlockStoreOption {
+	return func(bs *BlockStore) {
+		bs.metaCache = newBlockMetaCache(size)
+	}
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	return &BlockStore{db}
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bs := &BlockStore{db: db}
+	for _, opt := range options {
+		opt(bs)
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -216,6 +249,11 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 		return nil
 	}
 
+	bz, err = bs.loadBlockCompression(height).decompress(bz)
+	if err != nil {
+		panic(fmt.Errorf("decompressing block part failed: %w", err))
+	}
+
 	err = proto.Unmarshal(bz, pbpart)
 	if err != nil {
 		panic(fmt.Errorf("unmarshal to tmproto.Part failed: %w", err))
@@ -228,9 +266,28 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 	return part
 }
 
+// loadBlockCompression returns the codec that the parts of the block at the
+// given height were compressed with. Blocks without a recorded codec are
+// uncompressed.
+func (bs *BlockStore) loadBlockCompression(height int64) Compression {
+	bz, err := bs.db.Get(blockCompressionKey(height))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) == 0 {
+		return CompressionNone
+	}
+	return Compression(bz[0])
+}
+
 // LoadBlockMeta returns the BlockMeta for the given height.
 // If no block is found for the given height, it returns nil.
 func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	cached, generation := bs.metaCache.get(height)
+	if cached != nil {
+		return cached
+	}
+
 	var pbbm = new(tmproto.BlockMeta)
 	bz, err := bs.db.Get(blockMetaKey(height))
 
@@ -252,6 +309,7 @@ func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 		panic(fmt.Errorf("error from proto blockMeta: %w", err))
 	}
 
+	bs.metaCache.add(height, blockMeta, generation)
 	return blockMeta
 }
 
@@ -337,6 +395,12 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		return 0, fmt.Errorf("height must be equal to or less than the latest height %d", bs.Height())
 	}
 
+	// The cache is invalidated both before pruning, so that it doesn't serve
+	// the metas being deleted, and after, to drop the ones loaded meanwhile.
+	isPruned := func(h int64) bool { return h < height }
+	bs.metaCache.invalidate(isPruned)
+	defer bs.metaCache.invalidate(isPruned)
+
 	// when removing the block meta, use the hash to remove the hash key at the same time
 	removeBlockHash := func(key, value []byte, batch dbm.Batch) error {
 		// unmarshal block meta
@@ -374,9 +438,50 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		return pruned, err
 	}
 
+	if _, err := bs.pruneRange(blockCompressionKey(0), blockCompressionKey(height), nil); err != nil {
+		return pruned, err
+	}
+
 	return pruned, nil
 }
 
+// PruneBlocksToTime removes all blocks with a block time before the given
+// cutoff, always retaining the latest block. It binary searches the stored
+// heights for the earliest block at or after the cutoff and prunes everything
+// below it, returning the number of blocks pruned and the new base height. If
+// all blocks are at or after the cutoff, nothing is pruned.
+func (bs *BlockStore) PruneBlocksToTime(cutoff time.Time) (uint64, int64, error) {
+	base, height := bs.Base(), bs.Height()
+	if base == 0 {
+		return 0, 0, nil
+	}
+
+	// Block times are monotonically increasing with height, so we can search
+	// for the first height whose block time is not before the cutoff. The
+	// latest block is never pruned, so the search is bounded by its height.
+	var searchErr error
+	retainHeight := base + int64(sort.Search(int(height-base), func(i int) bool {
+		meta := bs.LoadBlockMeta(base + int64(i))
+		if meta == nil {
+			searchErr = fmt.Errorf("missing block meta at height %d", base+int64(i))
+			return true
+		}
+		return !meta.Header.Time.Before(cutoff)
+	}))
+	if searchErr != nil {
+		return 0, base, searchErr
+	}
+	if retainHeight <= base {
+		return 0, base, nil
+	}
+
+	pruned, err := bs.PruneBlocks(retainHeight)
+	if err != nil {
+		return pruned, bs.Base(), err
+	}
+	return pruned, retainHeight, nil
+}
+
 // pruneRange is a generic function for deleting a range of values based on the lowest
 // height up to but excluding retainHeight. For each key/value pair, an optional hook can be
 // executed before the deletion itself is made. pruneRange will use batch delete to delete
@@ -501,6 +606,22 @@ func (bs *BlockStore) SaveBlockWithExtendedCommit(block *types.Block, blockParts
 	if err := seenExtendedCommit.EnsureExtensions(); err != nil {
 		panic(fmt.Errorf("saving block with extensions: %w", err))
 	}
+	bs.saveBlockWithExtendedCommit(block, blockParts, seenExtendedCommit)
+}
+
+// SaveBlockWithAggregatedCommit persists the given block, blockParts, and
+// seenExtendedCommit to the underlying db, like SaveBlockWithExtendedCommit,
+// but without requiring vote extensions. It is used for the blocks whose
+// commit is aggregated when it is included in the next block, so that the
+// individual signatures are persisted.
+func (bs *BlockStore) SaveBlockWithAggregatedCommit(block *types.Block, blockParts *types.PartSet, seenExtendedCommit *types.ExtendedCommit) {
+	if block == nil {
+		panic("BlockStore can only save a non-nil block")
+	}
+	bs.saveBlockWithExtendedCommit(block, blockParts, seenExtendedCommit)
+}
+
+func (bs *BlockStore) saveBlockWithExtendedCommit(block *types.Block, blockParts *types.PartSet, seenExtendedCommit *types.ExtendedCommit) {
 	batch := bs.db.NewBatch()
 	if err := bs.saveBlockToBatch(batch, block, blockParts, seenExtendedCommit.ToCommit()); err != nil {
 		panic(err)
@@ -544,6 +665,11 @@ func (bs *BlockStore) saveBlockToBatch(batch dbm.Batch, block *types.Block, bloc
 	// typically load the block meta first as an indication that the block exists
 	// and then go on to load block parts - we must make sure the block is
 	// complete as soon as the block meta is written.
+	if bs.compression != CompressionNone {
+		if err := batch.Set(blockCompressionKey(height), []byte{byte(bs.compression)}); err != nil {
+			return err
+		}
+	}
 	for i := 0; i < int(blockParts.Total()); i++ {
 		part := blockParts.GetPart(i)
 		bs.saveBlockPart(height, i, part, batch)
@@ -585,7 +711,10 @@ func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part, b
 	if err != nil {
 		panic(fmt.Errorf("unable to make part into proto: %w", err))
 	}
-	partBytes := mustEncode(pbp)
+	partBytes, err := bs.compression.compress(mustEncode(pbp))
+	if err != nil {
+		panic(fmt.Errorf("unable to compress part: %w", err))
+	}
 	if err := batch.Set(blockPartKey(height, index), partBytes); err != nil {
 		panic(err)
 	}
@@ -651,9 +780,9 @@ func (bs *BlockStore) Close() error {
 
 // key prefixes
 // NB: Before modifying these, cross-check them with those in
-// * internal/store/store.go    [0..4, 13]
-// * internal/state/store.go    [5..8, 14]
-// * internal/evidence/pool.go  [9..10]
+// * internal/store/store.go    [0..4, 13, 17]
+// * internal/state/store.go    [5..8, 14..15, 18..19]
+// * internal/evidence/pool.go  [9..10, 16]
 // * light/store/db/db.go       [11..12]
 // TODO(thane): Move all these to their own package.
 // TODO: what about these (they already collide):
@@ -667,6 +796,7 @@ const (
 	prefixSeenCommit  = int64(3)
 	prefixBlockHash   = int64(4)
 	prefixExtCommit   = int64(13)
+	prefixBlockCodec  = int64(17)
 )
 
 func blockMetaKey(height int64) []byte {
@@ -724,6 +854,14 @@ func extCommitKey(height int64) []byte {
 	return key
 }
 
+func blockCompressionKey(height int64) []byte {
+	key, err := orderedcode.Append(nil, prefixBlockCodec, height)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 func blockHashKey(hash []byte) []byte {
 	key, err := orderedcode.Append(nil, prefixBlockHash, string(hash))
 	if err != nil {
@@ -749,6 +887,13 @@ func mustEncode(pb proto.Message) []byte {
 // lowering height by one.
 func (bs *BlockStore) DeleteLatestBlock() error {
 	targetHeight := bs.Height()
+
+	// See PruneBlocks for why the cache is invalidated twice. The heights
+	// above the target height aren't expected, but are not served either.
+	isDeleted := func(h int64) bool { return h >= targetHeight }
+	bs.metaCache.invalidate(isDeleted)
+	defer bs.metaCache.invalidate(isDeleted)
+
 	batch := bs.db.NewBatch()
 	fmt.Printf("Permanently deleting target height=%d from block store\n", targetHeight)
 	// delete what we can, skipping what's already missing, to ensure partial
@@ -763,6 +908,9 @@ func (bs *BlockStore) DeleteLatestBlock() error {
 			}
 		}
 	}
+	if err := batch.Delete(blockCompressionKey(targetHeight)); err != nil {
+		return err
+	}
 	if err := batch.Delete(blockCommitKey(targetHeight)); err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -324,12 +325,14 @@ func TestSaveBlockWithExtendedCommitPanicOnAbsentExtension(t *testing.T) {
 
 // TestLoadBlockExtendedCommit tests loading the extended commit for a previously
 // saved block. The load method should return nil when only a commit was saved and
-// return the extended commit otherwise.
+// return the extended commit otherwise, with or without extensions when the
+// commit was saved for aggregation.
 func TestLoadBlockExtendedCommit(t *testing.T) {
 	for _, testCase := range []struct {
-		name         string
-		saveExtended bool
-		expectResult bool
+		name           string
+		saveExtended   bool
+		saveAggregated bool
+		expectResult   bool
 	}{
 		{
 			name:         "save commit",
@@ -341,6 +344,11 @@ func TestLoadBlockExtendedCommit(t *testing.T) {
 			saveExtended: true,
 			expectResult: true,
 		},
+		{
+			name:           "save aggregated commit",
+			saveAggregated: true,
+			expectResult:   true,
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			state, bs, cleanup, err := makeStateAndBlockStore(t.TempDir())
@@ -350,9 +358,13 @@ func TestLoadBlockExtendedCommit(t *testing.T) {
 			seenCommit := makeTestExtCommit(block.Header.Height, tmtime.Now())
 			ps, err := block.MakePartSet(2)
 			require.NoError(t, err)
-			if testCase.saveExtended {
+			switch {
+			case testCase.saveExtended:
 				bs.SaveBlockWithExtendedCommit(block, ps, seenCommit)
-			} else {
+			case testCase.saveAggregated:
+				seenCommit.StripExtensions()
+				bs.SaveBlockWithAggregatedCommit(block, ps, seenCommit)
+			default:
 				bs.SaveBlock(block, ps, seenCommit.ToCommit())
 			}
 			res := bs.LoadBlockExtendedCommit(block.Height)
@@ -519,6 +531,57 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestPruneBlocksToTime(t *testing.T) {
+	state, bs, cleanup, err := makeStateAndBlockStore(t.TempDir())
+	defer cleanup()
+	require.NoError(t, err)
+
+	// Pruning an empty store is a no-op.
+	pruned, base, err := bs.PruneBlocksToTime(tmtime.Now())
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+	assert.EqualValues(t, 0, base)
+
+	// Save blocks one minute apart.
+	genesis := tmtime.Now().Add(-time.Hour)
+	blockTime := func(h int64) time.Time { return genesis.Add(time.Duration(h) * time.Minute) }
+	for h := int64(1); h <= 20; h++ {
+		block := factory.MakeBlock(state, h, new(types.Commit))
+		block.Header.Time = blockTime(h)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(h, tmtime.Now()))
+	}
+
+	// All blocks are newer than the cutoff, so nothing is pruned.
+	pruned, base, err = bs.PruneBlocksToTime(genesis)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+	assert.EqualValues(t, 1, base)
+
+	// A cutoff between blocks retains the first block after it.
+	pruned, base, err = bs.PruneBlocksToTime(blockTime(5).Add(time.Second))
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, pruned)
+	assert.EqualValues(t, 6, base)
+	assert.EqualValues(t, 6, bs.Base())
+
+	// A cutoff at a block's time retains that block.
+	pruned, base, err = bs.PruneBlocksToTime(blockTime(10))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pruned)
+	assert.EqualValues(t, 10, base)
+	require.Nil(t, bs.LoadBlock(9))
+	require.NotNil(t, bs.LoadBlock(10))
+
+	// The latest block is retained even if it is older than the cutoff.
+	pruned, base, err = bs.PruneBlocksToTime(tmtime.Now())
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, pruned)
+	assert.EqualValues(t, 20, base)
+	assert.EqualValues(t, 20, bs.Height())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := newInMemoryBlockStore()
 	height := int64(10)
@@ -653,3 +716,258 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+func TestParseCompression(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionSnappy, CompressionZstd} {
+		parsed, err := ParseCompression(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+
+	parsed, err := ParseCompression("")
+	require.NoError(t, err)
+	require.Equal(t, CompressionNone, parsed)
+
+	_, err = ParseCompression("gzip")
+	require.Error(t, err)
+}
+
+func TestBlockStoreCompression(t *testing.T) {
+	state, _, cleanup, err := makeStateAndBlockStore(t.TempDir())
+	defer cleanup()
+	require.NoError(t, err)
+
+	// Save each block with a different codec to the same DB, starting with
+	// an uncompressed block as written by stores predating compression.
+	db := dbm.NewMemDB()
+	codecs := []Compression{CompressionNone, CompressionSnappy, CompressionZstd}
+	blocks := make([]*types.Block, len(codecs))
+	for i, c := range codecs {
+		height := int64(i + 1)
+		block := factory.MakeBlock(state, height, new(types.Commit))
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		NewBlockStore(db, WithCompression(c)).
+			SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(height, tmtime.Now()))
+		blocks[i] = block
+
+		part := partSet.GetPart(0)
+		pbp, err := part.ToProto()
+		require.NoError(t, err)
+		raw, err := db.Get(blockPartKey(height, 0))
+		require.NoError(t, err)
+		if c == CompressionNone {
+			require.Equal(t, mustEncode(pbp), raw)
+		} else {
+			require.NotEqual(t, mustEncode(pbp), raw)
+		}
+	}
+
+	// Every block is loadable regardless of the codec the store is
+	// configured with.
+	for _, c := range codecs {
+		bs := NewBlockStore(db, WithCompression(c))
+		for i, block := range blocks {
+			height := int64(i + 1)
+			require.Equal(t, codecs[i], bs.loadBlockCompression(height))
+			loaded := bs.LoadBlock(height)
+			require.NotNil(t, loaded)
+			require.Equal(t, block.Hash(), loaded.Hash())
+		}
+	}
+
+	// Pruning and deleting blocks removes their codec flag.
+	bs := NewBlockStore(db)
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	has, err := db.Has(blockCompressionKey(2))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, bs.DeleteLatestBlock())
+	has, err = db.Has(blockCompressionKey(3))
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestBlockMetaCache(t *testing.T) {
+	state, _, cleanup, err := makeStateAndBlockStore(t.TempDir())
+	defer cleanup()
+	require.NoError(t, err)
+
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, WithBlockMetaCacheSize(3))
+	saveBlock := func(height int64, txs ...types.Tx) *types.Block {
+		block := state.MakeBlock(height, txs, new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(height, tmtime.Now()))
+		return block
+	}
+	for h := int64(1); h <= 5; h++ {
+		saveBlock(h)
+	}
+
+	// Loaded metas are served from the cache, and are copies which callers
+	// may modify.
+	meta := bs.LoadBlockMeta(4)
+	require.NotNil(t, meta)
+	require.NoError(t, db.Delete(blockMetaKey(4)))
+	cached := bs.LoadBlockMeta(4)
+	require.Equal(t, meta, cached)
+	cached.NumTxs = 100
+	require.Equal(t, meta, bs.LoadBlockMeta(4))
+	require.NoError(t, db.Set(blockMetaKey(4), mustEncode(meta.ToProto())))
+
+	// The least recently used meta is evicted once the cache is full.
+	bs.LoadBlockMeta(1)
+	bs.LoadBlockMeta(2)
+	bs.LoadBlockMeta(4)
+	bs.LoadBlockMeta(3)
+	require.Equal(t, 3, bs.metaCache.list.Len())
+	require.NotContains(t, bs.metaCache.entries, int64(1))
+	require.Contains(t, bs.metaCache.entries, int64(2))
+
+	// Pruned metas are never served.
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	require.Nil(t, bs.LoadBlockMeta(2))
+	require.NotContains(t, bs.metaCache.entries, int64(2))
+	require.NotNil(t, bs.LoadBlockMeta(3))
+
+	// Neither are deleted ones, even if their height is saved again.
+	bs.LoadBlockMeta(5)
+	require.NoError(t, bs.DeleteLatestBlock())
+	require.Nil(t, bs.LoadBlockMeta(5))
+	block := saveBlock(5, types.Tx("tx"))
+	meta = bs.LoadBlockMeta(5)
+	require.NotNil(t, meta)
+	require.Equal(t, block.Hash(), meta.BlockID.Hash)
+	require.EqualValues(t, 1, meta.NumTxs)
+
+	// A meta loaded before an invalidation isn't added after it.
+	_, generation := bs.metaCache.get(4)
+	bs.metaCache.invalidate(func(int64) bool { return true })
+	bs.metaCache.add(4, meta, generation)
+	require.Empty(t, bs.metaCache.entries)
+}
+
+func TestBlockMetaCacheConcurrency(t *testing.T) {
+	state, _, cleanup, err := makeStateAndBlockStore(t.TempDir())
+	defer cleanup()
+	require.NoError(t, err)
+
+	bs := NewBlockStore(dbm.NewMemDB(), WithBlockMetaCacheSize(10))
+	for h := int64(1); h <= 50; h++ {
+		block := factory.MakeBlock(state, h, new(types.Commit))
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(h, tmtime.Now()))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for h := int64(1); h <= 50; h++ {
+				if meta := bs.LoadBlockMeta(h); meta != nil {
+					assert.Equal(t, h, meta.Header.Height)
+				}
+			}
+		}()
+	}
+	for h := int64(10); h <= 40; h += 10 {
+		_, err := bs.PruneBlocks(h)
+		require.NoError(t, err)
+	}
+	wg.Wait()
+
+	for h := int64(1); h < 40; h++ {
+		require.Nil(t, bs.LoadBlockMeta(h))
+	}
+	require.NotNil(t, bs.LoadBlockMeta(40))
+}
+
+// makeBenchmarkBlock returns a block with txs resembling typical
+// application payloads, which compress reasonably well.
+func makeBenchmarkBlock(b *testing.B) (*types.Block, *types.PartSet) {
+	cfg, err := config.ResetTestRoot(b.TempDir(), "block_store_benchmark")
+	require.NoError(b, err)
+	state, err := sm.MakeGenesisStateFromFile(cfg.GenesisFile())
+	require.NoError(b, err)
+
+	txs := make([]types.Tx, 1000)
+	for i := range txs {
+		txs[i] = types.Tx(fmt.Sprintf(
+			`{"from":"addr%08d","to":"addr%08d","amount":"%d","denom":"usei","memo":"%x"}`,
+			i, i+1, i*1000, tmrand.Bytes(16),
+		))
+	}
+	block := state.MakeBlock(1, txs, new(types.Commit), nil, state.Validators.GetProposer().Address)
+	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(b, err)
+	return block, partSet
+}
+
+// storedPartBytes returns the total size of the block parts stored in db.
+func storedPartBytes(b *testing.B, db dbm.DB) int {
+	iter, err := db.Iterator(blockPartKey(0, 0), blockPartKey(1<<63-1, 0))
+	require.NoError(b, err)
+	defer iter.Close()
+
+	size := 0
+	for ; iter.Valid(); iter.Next() {
+		size += len(iter.Value())
+	}
+	return size
+}
+
+func BenchmarkBlockStoreCompression(b *testing.B) {
+	block, partSet := makeBenchmarkBlock(b)
+	seenCommit := makeTestExtCommit(block.Height, tmtime.Now())
+
+	for _, c := range []Compression{CompressionNone, CompressionSnappy, CompressionZstd} {
+		b.Run(fmt.Sprintf("save/%v", c), func(b *testing.B) {
+			var db dbm.DB
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				db = dbm.NewMemDB()
+				NewBlockStore(db, WithCompression(c)).SaveBlockWithExtendedCommit(block, partSet, seenCommit)
+			}
+			b.ReportMetric(float64(storedPartBytes(b, db)), "stored-bytes")
+		})
+
+		b.Run(fmt.Sprintf("load/%v", c), func(b *testing.B) {
+			db := dbm.NewMemDB()
+			bs := NewBlockStore(db, WithCompression(c))
+			bs.SaveBlockWithExtendedCommit(block, partSet, seenCommit)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bs.LoadBlock(block.Height) == nil {
+					b.Fatal("failed to load block")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLoadBlockMeta(b *testing.B) {
+	block, partSet := makeBenchmarkBlock(b)
+	seenCommit := makeTestExtCommit(block.Height, tmtime.Now())
+
+	for _, size := range []int{0, 1000} {
+		b.Run(fmt.Sprintf("cache-size=%d", size), func(b *testing.B) {
+			bs := NewBlockStore(dbm.NewMemDB(), WithBlockMetaCacheSize(size))
+			bs.SaveBlockWithExtendedCommit(block, partSet, seenCommit)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bs.LoadBlockMeta(block.Height) == nil {
+					b.Fatal("failed to load block meta")
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package log
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,11 +27,26 @@ type defaultLogger struct {
 // that in a generic interface, all logging methods accept a series of key/value
 // pair tuples, where the key must be a string.
 func NewDefaultLogger(format, level string) (Logger, error) {
+	return NewDefaultLoggerWithFields(format, level, nil)
+}
+
+// NewDefaultLoggerWithFields returns a default logger that adds the given
+// static fields, e.g. the chain ID, to every line. The fields can't use the
+// reserved keys.
+func NewDefaultLoggerWithFields(format, level string, fields map[string]string) (Logger, error) {
+	return newDefaultLogger(os.Stderr, format, level, fields)
+}
+
+func newDefaultLogger(w io.Writer, format, level string, fields map[string]string) (Logger, error) {
+	if err := ValidateStaticFields(fields); err != nil {
+		return nil, err
+	}
+
 	var logWriter io.Writer
 	switch strings.ToLower(format) {
 	case LogFormatPlain, LogFormatText:
 		logWriter = zerolog.ConsoleWriter{
-			Out:        os.Stderr,
+			Out:        w,
 			NoColor:    true,
 			TimeFormat: time.RFC3339,
 			FormatLevel: func(i interface{}) string {
@@ -41,7 +58,7 @@ func NewDefaultLogger(format, level string) (Logger, error) {
 		}
 
 	case LogFormatJSON:
-		logWriter = os.Stderr
+		logWriter = w
 
 	default:
 		return nil, fmt.Errorf("unsupported log format: %s", format)
@@ -55,11 +72,35 @@ func NewDefaultLogger(format, level string) (Logger, error) {
 	// make the writer thread-safe
 	logWriter = newSyncWriter(logWriter)
 
+	ctx := zerolog.New(logWriter).Level(logLevel).With().Timestamp()
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ctx = ctx.Str(key, fields[key])
+	}
+
 	return &defaultLogger{
-		Logger: zerolog.New(logWriter).Level(logLevel).With().Timestamp().Logger(),
+		Logger: ctx.Logger(),
 	}, nil
 }
 
+// ValidateStaticFields returns an error if a static field has an empty key or
+// uses a reserved key.
+func ValidateStaticFields(fields map[string]string) error {
+	for key := range fields {
+		switch key {
+		case "":
+			return errors.New("log field with an empty key")
+		case KeyLevel, KeyTime, KeyMessage, KeyModule, KeyHeight, KeyRound:
+			return fmt.Errorf("log field %q uses a reserved key", key)
+		}
+	}
+	return nil
+}
+
 func (l defaultLogger) Info(msg string, keyVals ...interface{}) {
 	l.Logger.Info().Fields(getLogFields(keyVals...)).Msg(msg)
 }
@@ -82,12 +123,19 @@ func (l defaultLogger) With(keyVals ...interface{}) Logger {
 // a new logger, and makes it possible to reconfigure an existing
 // logger that has already been propagated to callers.
 func OverrideWithNewLogger(logger Logger, format, level string) error {
+	return OverrideWithNewLoggerWithFields(logger, format, level, nil)
+}
+
+// OverrideWithNewLoggerWithFields is like OverrideWithNewLogger, and makes the
+// new logger add the given static fields to every line, see
+// NewDefaultLoggerWithFields.
+func OverrideWithNewLoggerWithFields(logger Logger, format, level string, fields map[string]string) error {
 	ol, ok := logger.(*defaultLogger)
 	if !ok {
 		return fmt.Errorf("logger %T cannot be overridden", logger)
 	}
 
-	newLogger, err := NewDefaultLogger(format, level)
+	newLogger, err := NewDefaultLoggerWithFields(format, level, fields)
 	if err != nil {
 		return err
 	}
@@ -100,14 +148,35 @@ func OverrideWithNewLogger(logger Logger, format, level string) error {
 	return nil
 }
 
-func getLogFields(keyVals ...interface{}) map[string]interface{} {
+// getLogFields returns the key/value pairs as a list of fields for
+// zerolog.Event.Fields, which encodes them in order without reflection for the
+// common types. The list is only copied if a key isn't a string or is
+// reserved.
+func getLogFields(keyVals ...interface{}) []interface{} {
 	if len(keyVals)%2 != 0 {
 		return nil
 	}
 
-	fields := make(map[string]interface{}, len(keyVals))
+	fields := keyVals
+	copied := false
 	for i := 0; i < len(keyVals); i += 2 {
-		fields[fmt.Sprint(keyVals[i])] = keyVals[i+1]
+		key, ok := keyVals[i].(string)
+		if ok && key != KeyLevel && key != KeyTime && key != KeyMessage {
+			continue
+		}
+		if !copied {
+			fields = make([]interface{}, len(keyVals))
+			copy(fields, keyVals)
+			copied = true
+		}
+		if !ok {
+			key = fmt.Sprint(keyVals[i])
+		}
+		switch key {
+		case KeyLevel, KeyTime, KeyMessage:
+			key = "_" + key
+		}
+		fields[i] = key
 	}
 
 	return fields
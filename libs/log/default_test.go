@@ -1,8 +1,13 @@
 package log_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -44,3 +49,55 @@ func TestNewDefaultLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultLoggerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := log.NewDefaultLoggerWithWriter(&buf, log.LogFormatJSON, log.LogLevelInfo,
+		map[string]string{"chain_id": "test-chain", "node_id": "abc"})
+	require.NoError(t, err)
+
+	logger.With("module", "consensus").Info("committed block",
+		"height", int64(10), "round", int32(1), "err", errors.New("boom"), "message", "field", 7, "int key")
+	logger.Debug("not logged", "height", 11)
+
+	line := strings.TrimSpace(buf.String())
+	require.NotContains(t, line, "\n")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(line), &entry))
+	assert.Equal(t, "info", entry[log.KeyLevel])
+	assert.Contains(t, entry, log.KeyTime)
+	assert.Equal(t, "committed block", entry[log.KeyMessage])
+	assert.Equal(t, "test-chain", entry["chain_id"])
+	assert.Equal(t, "abc", entry["node_id"])
+	assert.Equal(t, "consensus", entry[log.KeyModule])
+	assert.EqualValues(t, 10, entry[log.KeyHeight])
+	assert.EqualValues(t, 1, entry[log.KeyRound])
+	assert.Equal(t, "boom", entry["err"])
+	assert.Equal(t, "field", entry["_message"])
+	assert.Equal(t, "int key", entry["7"])
+
+	// Static fields come first, followed by the fields in call order.
+	assert.Less(t, strings.Index(line, `"node_id"`), strings.Index(line, `"module"`))
+	assert.Less(t, strings.Index(line, `"height"`), strings.Index(line, `"round"`))
+}
+
+func TestDefaultLoggerStaticFields(t *testing.T) {
+	for _, key := range []string{"", log.KeyLevel, log.KeyTime, log.KeyMessage, log.KeyModule, log.KeyHeight, log.KeyRound} {
+		_, err := log.NewDefaultLoggerWithFields(log.LogFormatJSON, log.LogLevelInfo, map[string]string{key: "value"})
+		assert.Error(t, err, key)
+	}
+}
+
+func BenchmarkDefaultLoggerJSON(b *testing.B) {
+	logger, err := log.NewDefaultLoggerWithWriter(&bytes.Buffer{}, log.LogFormatJSON, log.LogLevelInfo,
+		map[string]string{"chain_id": "test-chain"})
+	require.NoError(b, err)
+	logger = logger.With("module", "consensus")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info("committed block", "height", int64(i), "round", int32(0), "num_txs", 10)
+	}
+}
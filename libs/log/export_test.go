@@ -0,0 +1,10 @@
+package log
+
+import "time"
+
+var NewDefaultLoggerWithWriter = newDefaultLogger
+
+// SetSamplerClock sets the clock of a logger returned by NewSampledLogger.
+func SetSamplerClock(logger Logger, now func() time.Time) {
+	logger.(*sampledLogger).sampler.now = now
+}
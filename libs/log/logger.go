@@ -30,6 +30,21 @@ const (
 	LogLevelError = "error"
 )
 
+// Reserved keys of the JSON log format. Every line has a level, a time (RFC
+// 3339) and a message, followed by the static fields of the logger and the
+// fields passed by the caller, in order. Caller fields using the level, time or
+// message key are prefixed with an underscore. The module, height and round
+// keys identify the module logging the line and the consensus height and round
+// it refers to, and can't be used as static fields.
+const (
+	KeyLevel   = "level"
+	KeyTime    = "time"
+	KeyMessage = "message"
+	KeyModule  = "module"
+	KeyHeight  = "height"
+	KeyRound   = "round"
+)
+
 // Logger defines a generic logging interface compatible with Tendermint.
 type Logger interface {
 	Debug(msg string, keyVals ...interface{})
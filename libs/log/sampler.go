@@ -0,0 +1,146 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// maxSampledMessages bounds the number of messages tracked by a sampled
+// logger before counters of expired windows are dropped.
+const maxSampledMessages = 1024
+
+// SamplingConfig configures a sampled logger. Within each Window, the first
+// First occurrences of a message are logged, then one in Thereafter.
+type SamplingConfig struct {
+	First      int
+	Thereafter int
+	Window     time.Duration
+}
+
+// DefaultSamplingConfig logs the first 10 occurrences of a message per second,
+// then one in 100.
+func DefaultSamplingConfig() SamplingConfig {
+	return SamplingConfig{
+		First:      10,
+		Thereafter: 100,
+		Window:     time.Second,
+	}
+}
+
+type sampledLogger struct {
+	next    Logger
+	sampler *sampler
+}
+
+// NewSampledLogger wraps next and samples the lines logged through it, to keep
+// messages repeated at a high rate, e.g. during p2p storms, from drowning out
+// other logs. Messages are identified by their level and message string,
+// regardless of their fields. Each logged line of a message carries a
+// "suppressed" field with the number of occurrences dropped since the previous
+// one, if any.
+//
+// Loggers derived with With share the counters of the sampled logger. Call
+// sites that must not be sampled should log through the unwrapped logger.
+func NewSampledLogger(next Logger, cfg SamplingConfig) Logger {
+	return &sampledLogger{
+		next: next,
+		sampler: &sampler{
+			cfg:      cfg,
+			now:      time.Now,
+			counters: make(map[sampleKey]*sampleCounter),
+		},
+	}
+}
+
+func (l *sampledLogger) Debug(msg string, keyVals ...interface{}) {
+	if log, suppressed := l.sampler.sample(levelDebug, msg); log {
+		l.next.Debug(msg, withSuppressed(keyVals, suppressed)...)
+	}
+}
+
+func (l *sampledLogger) Info(msg string, keyVals ...interface{}) {
+	if log, suppressed := l.sampler.sample(levelInfo, msg); log {
+		l.next.Info(msg, withSuppressed(keyVals, suppressed)...)
+	}
+}
+
+func (l *sampledLogger) Error(msg string, keyVals ...interface{}) {
+	if log, suppressed := l.sampler.sample(levelError, msg); log {
+		l.next.Error(msg, withSuppressed(keyVals, suppressed)...)
+	}
+}
+
+func (l *sampledLogger) With(keyVals ...interface{}) Logger {
+	return &sampledLogger{
+		next:    l.next.With(keyVals...),
+		sampler: l.sampler,
+	}
+}
+
+func withSuppressed(keyVals []interface{}, suppressed int) []interface{} {
+	if suppressed == 0 {
+		return keyVals
+	}
+	return append(keyVals[:len(keyVals):len(keyVals)], "suppressed", suppressed)
+}
+
+type sampleKey struct {
+	level level
+	msg   string
+}
+
+type sampleCounter struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+type sampler struct {
+	cfg SamplingConfig
+	now func() time.Time
+
+	mtx      sync.Mutex
+	counters map[sampleKey]*sampleCounter
+}
+
+// sample returns whether an occurrence of the message must be logged and, if
+// so, the number of occurrences suppressed since the last logged one.
+func (s *sampler) sample(lvl level, msg string) (bool, int) {
+	now := s.now()
+	key := sampleKey{level: lvl, msg: msg}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		if len(s.counters) >= maxSampledMessages {
+			s.dropExpired(now)
+		}
+		c = &sampleCounter{start: now}
+		s.counters[key] = c
+	} else if now.Sub(c.start) >= s.cfg.Window {
+		c.start = now
+		c.count = 0
+	}
+
+	c.count++
+	n := c.count - s.cfg.First
+	if n > 0 && (s.cfg.Thereafter <= 0 || n%s.cfg.Thereafter != 0) {
+		c.suppressed++
+		return false, 0
+	}
+	suppressed := c.suppressed
+	c.suppressed = 0
+	return true, suppressed
+}
+
+// dropExpired removes the counters whose window expired and which have no
+// suppressed occurrences left to report.
+func (s *sampler) dropExpired(now time.Time) {
+	for key, c := range s.counters {
+		if c.suppressed == 0 && now.Sub(c.start) >= s.cfg.Window {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package log_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type recordingLogger struct {
+	mtx   *sync.Mutex
+	lines *[]string
+	with  []interface{}
+}
+
+func newRecordingLogger() recordingLogger {
+	return recordingLogger{mtx: &sync.Mutex{}, lines: &[]string{}}
+}
+
+func (l recordingLogger) record(level, msg string, keyVals []interface{}) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	*l.lines = append(*l.lines, fmt.Sprint(level, " ", msg, append(l.with[:len(l.with):len(l.with)], keyVals...)))
+}
+
+func (l recordingLogger) Debug(msg string, keyVals ...interface{}) { l.record("D", msg, keyVals) }
+func (l recordingLogger) Info(msg string, keyVals ...interface{})  { l.record("I", msg, keyVals) }
+func (l recordingLogger) Error(msg string, keyVals ...interface{}) { l.record("E", msg, keyVals) }
+
+func (l recordingLogger) With(keyVals ...interface{}) log.Logger {
+	l.with = append(l.with[:len(l.with):len(l.with)], keyVals...)
+	return l
+}
+
+func TestSampledLogger(t *testing.T) {
+	now := time.Now()
+	next := newRecordingLogger()
+	logger := log.NewSampledLogger(next, log.SamplingConfig{First: 2, Thereafter: 3, Window: time.Second})
+	log.SetSamplerClock(logger, func() time.Time { return now })
+
+	peerLogger := logger.With("peer", "a")
+	for i := 1; i <= 8; i++ {
+		peerLogger.Info("dropping message", "n", i)
+	}
+	// Messages are sampled by level and message.
+	logger.Error("dropping message")
+	logger.Info("other message")
+
+	// The counters restart with a new window.
+	now = now.Add(time.Second)
+	logger.Info("dropping message", "n", 9)
+	logger.Info("dropping message", "n", 10)
+
+	assert.Equal(t, []string{
+		"I dropping message[peer a n 1]",
+		"I dropping message[peer a n 2]",
+		"I dropping message[peer a n 5 suppressed 2]",
+		"I dropping message[peer a n 8 suppressed 2]",
+		"E dropping message[]",
+		"I other message[]",
+		"I dropping message[n 9]",
+		"I dropping message[n 10]",
+	}, *next.lines)
+}
+
+func TestSampledLoggerDropAll(t *testing.T) {
+	next := newRecordingLogger()
+	logger := log.NewSampledLogger(next, log.SamplingConfig{First: 1, Window: time.Hour})
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("dropping message")
+	}
+	assert.Equal(t, []string{"D dropping message[]"}, *next.lines)
+}
+
+func TestSampledLoggerConcurrent(t *testing.T) {
+	next := newRecordingLogger()
+	logger := log.NewSampledLogger(next, log.SamplingConfig{First: 10, Thereafter: 10, Window: time.Hour})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("dropping message")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 10 first occurrences, then one in 10 of the remaining 990.
+	require.Len(t, *next.lines, 10+99)
+}
@@ -397,7 +397,7 @@ func (c *Client) Update(ctx context.Context, now time.Time) (*types.LightBlock,
 
 	// If there is a new light block then verify it
 	if latestBlock.Height > lastTrustedHeight {
-		err = c.verifyLightBlock(ctx, latestBlock, now)
+		err = c.verifyLightBlock(ctx, latestBlock, now, c.DefaultVerifyOptions())
 		if err != nil {
 			return nil, err
 		}
@@ -438,7 +438,75 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 		return nil, err
 	}
 
-	return l, c.verifyLightBlock(ctx, l, now)
+	return l, c.verifyLightBlock(ctx, l, now, c.DefaultVerifyOptions())
+}
+
+// VerifyOptions holds the verification parameters that can be overridden for a
+// single call to VerifyWithOptions.
+type VerifyOptions struct {
+	// TrustLevel is the fraction of the trusted validator set (in terms of
+	// voting power) that must sign a non-adjacent header. It only applies to
+	// skipping verification.
+	TrustLevel tmmath.Fraction
+	// MaxClockDrift is how much a new header's time can drift into the future
+	// relative to the light client's local time.
+	MaxClockDrift time.Duration
+}
+
+// ValidateBasic performs basic validation.
+func (opts VerifyOptions) ValidateBasic() error {
+	if err := ValidateTrustLevel(opts.TrustLevel); err != nil {
+		return err
+	}
+	if opts.MaxClockDrift < 0 {
+		return errors.New("negative max clock drift")
+	}
+	return nil
+}
+
+// DefaultVerifyOptions returns the verification parameters the client was
+// configured with. It is meant to be used as a starting point for the options
+// passed to VerifyWithOptions.
+func (c *Client) DefaultVerifyOptions() VerifyOptions {
+	return VerifyOptions{
+		TrustLevel:    c.trustLevel,
+		MaxClockDrift: c.maxClockDrift,
+	}
+}
+
+// VerifyWithOptions is like VerifyLightBlockAtHeight, but verifies the light
+// block using the given trust level and max clock drift instead of the ones
+// the client was configured with. The client's configuration is left
+// unchanged, and the latest trusted light block only ever advances to a higher
+// height.
+//
+// The trust level is ignored if the client uses sequential verification.
+func (c *Client) VerifyWithOptions(
+	ctx context.Context,
+	height int64,
+	now time.Time,
+	opts VerifyOptions,
+) (*types.LightBlock, error) {
+	if height <= 0 {
+		return nil, errors.New("negative or zero height")
+	}
+	if err := opts.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid verify options: %w", err)
+	}
+
+	// Check if the light block is already verified.
+	h, err := c.TrustedLightBlock(height)
+	if err == nil {
+		c.logger.Debug("header has already been verified", "height", height, "hash", h.Hash())
+		return h, nil
+	}
+
+	l, err := c.lightBlockFromPrimary(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, c.verifyLightBlock(ctx, l, now, opts)
 }
 
 // VerifyHeader verifies a new header against the trusted state. It returns
@@ -500,14 +568,19 @@ func (c *Client) VerifyHeader(ctx context.Context, newHeader *types.Header, now
 		return fmt.Errorf("header from primary %X does not match newHeader %X", l.Hash(), newHeader.Hash())
 	}
 
-	return c.verifyLightBlock(ctx, l, now)
+	return c.verifyLightBlock(ctx, l, now, c.DefaultVerifyOptions())
 }
 
-func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.LightBlock, now time.Time) error {
+func (c *Client) verifyLightBlock(
+	ctx context.Context,
+	newLightBlock *types.LightBlock,
+	now time.Time,
+	opts VerifyOptions,
+) error {
 	c.logger.Info("verify light block", "height", newLightBlock.Height, "hash", newLightBlock.Hash())
 
 	var (
-		verifyFunc func(ctx context.Context, trusted *types.LightBlock, new *types.LightBlock, now time.Time) error
+		verifyFunc func(ctx context.Context, trusted *types.LightBlock, new *types.LightBlock, now time.Time, opts VerifyOptions) error
 		err        error
 	)
 
@@ -528,7 +601,7 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 	switch {
 	// Verifying forwards
 	case newLightBlock.Height >= c.latestTrustedBlock.Height:
-		err = verifyFunc(ctx, c.latestTrustedBlock, newLightBlock, now)
+		err = verifyFunc(ctx, c.latestTrustedBlock, newLightBlock, now, opts)
 
 	// Verifying backwards
 	case newLightBlock.Height < firstBlockHeight:
@@ -548,7 +621,7 @@ func (c *Client) verifyLightBlock(ctx context.Context, newLightBlock *types.Ligh
 		if err != nil {
 			return fmt.Errorf("can't get signed header before height %d: %w", newLightBlock.Height, err)
 		}
-		err = verifyFunc(ctx, closestBlock, newLightBlock, now)
+		err = verifyFunc(ctx, closestBlock, newLightBlock, now, opts)
 	}
 	if err != nil {
 		c.logger.Error("failed to verify", "err", err)
@@ -564,7 +637,8 @@ func (c *Client) verifySequential(
 	ctx context.Context,
 	trustedBlock *types.LightBlock,
 	newLightBlock *types.LightBlock,
-	now time.Time) error {
+	now time.Time,
+	opts VerifyOptions) error {
 
 	var (
 		verifiedBlock = trustedBlock
@@ -592,7 +666,7 @@ func (c *Client) verifySequential(
 			"newHash", interimBlock.Hash())
 
 		err = VerifyAdjacent(verifiedBlock.SignedHeader, interimBlock.SignedHeader, interimBlock.ValidatorSet,
-			c.trustingPeriod, now, c.maxClockDrift)
+			c.trustingPeriod, now, opts.MaxClockDrift)
 		if err != nil {
 			err := ErrVerificationFailed{From: verifiedBlock.Height, To: interimBlock.Height, Reason: err}
 
@@ -658,7 +732,8 @@ func (c *Client) verifySkipping(
 	source provider.Provider,
 	trustedBlock *types.LightBlock,
 	newLightBlock *types.LightBlock,
-	now time.Time) ([]*types.LightBlock, error) {
+	now time.Time,
+	opts VerifyOptions) ([]*types.LightBlock, error) {
 
 	var (
 		// The block cache is ordered in height from highest to lowest. We start
@@ -681,7 +756,7 @@ func (c *Client) verifySkipping(
 		// Verify the untrusted header. This function is equivalent to
 		// ValidAndVerified in the spec
 		err := Verify(verifiedBlock.SignedHeader, verifiedBlock.ValidatorSet, blockCache[depth].SignedHeader,
-			blockCache[depth].ValidatorSet, c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+			blockCache[depth].ValidatorSet, c.trustingPeriod, now, opts.MaxClockDrift, opts.TrustLevel)
 		switch err.(type) {
 		case nil:
 			// If we have verified the last header then depth will be 0 and we
@@ -739,9 +814,10 @@ func (c *Client) verifySkippingAgainstPrimary(
 	ctx context.Context,
 	trustedBlock *types.LightBlock,
 	newLightBlock *types.LightBlock,
-	now time.Time) error {
+	now time.Time,
+	opts VerifyOptions) error {
 
-	trace, err := c.verifySkipping(ctx, c.primary, trustedBlock, newLightBlock, now)
+	trace, err := c.verifySkipping(ctx, c.primary, trustedBlock, newLightBlock, now, opts)
 	if err == nil {
 		// Success! Now compare the header with the witnesses to ensure it's not a fork.
 		// More witnesses we have, more chance to notice one.
@@ -806,7 +882,7 @@ func (c *Client) verifySkippingAgainstPrimary(
 	}
 
 	// attempt to verify the header again from the trusted block
-	return c.verifySkippingAgainstPrimary(ctx, trustedBlock, replacementBlock, now)
+	return c.verifySkippingAgainstPrimary(ctx, trustedBlock, replacementBlock, now, opts)
 }
 
 // LastTrustedHeight returns a last trusted height. -1 and nil are returned if
@@ -15,6 +15,7 @@ import (
 
 	"github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/light"
 	"github.com/tendermint/tendermint/light/provider"
 	provider_mocks "github.com/tendermint/tendermint/light/provider/mocks"
@@ -389,6 +390,71 @@ func TestClient(t *testing.T) {
 		}
 
 	})
+	t.Run("VerifyWithOptions", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		transitKeys := keys.Extend(3)
+		transitVals := transitKeys.ToValidators(10, 1)
+
+		mockNode := mockNodeFromHeadersAndVals(
+			map[int64]*types.SignedHeader{
+				1: h1,
+				2: h2,
+				// signed by 1/3+ but 2/3- of vals
+				3: transitKeys.GenSignedHeader(t, chainID, 3, bTime.Add(2*time.Hour), nil, transitVals, transitVals,
+					hash("app_hash"), hash("cons_hash"), hash("results_hash"), 2, len(transitKeys)),
+			},
+			map[int64]*types.ValidatorSet{
+				1: vals,
+				2: vals,
+				3: transitVals,
+			})
+		mockNode.On("LightBlock", mock.Anything, mock.Anything).Return(nil, provider.ErrLightBlockNotFound)
+
+		c, err := light.NewClient(
+			ctx,
+			chainID,
+			trustOptions,
+			mockNode,
+			[]provider.Provider{mockNode},
+			dbs.New(dbm.NewMemDB()),
+			blacklistTTL,
+			light.SkippingVerification(light.DefaultTrustLevel),
+			light.Logger(log.NewNopLogger()),
+		)
+		require.NoError(t, err)
+
+		defaults := c.DefaultVerifyOptions()
+		assert.Equal(t, light.VerifyOptions{TrustLevel: light.DefaultTrustLevel, MaxClockDrift: 10 * time.Second}, defaults)
+
+		// invalid trust level
+		_, err = c.VerifyWithOptions(ctx, 3, bTime.Add(3*time.Hour),
+			light.VerifyOptions{TrustLevel: tmmath.Fraction{Numerator: 1, Denominator: 4}})
+		require.Error(t, err)
+
+		// less than 2/3 of the trusted validators signed the header, so a
+		// stricter trust level can't be met
+		strict := light.VerifyOptions{
+			TrustLevel:    tmmath.Fraction{Numerator: 2, Denominator: 3},
+			MaxClockDrift: defaults.MaxClockDrift,
+		}
+		_, err = c.VerifyWithOptions(ctx, 3, bTime.Add(3*time.Hour), strict)
+		require.Error(t, err)
+		height, err := c.LastTrustedHeight()
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, height)
+
+		// the client's own options were not changed
+		assert.Equal(t, defaults, c.DefaultVerifyOptions())
+
+		l, err := c.VerifyWithOptions(ctx, 3, bTime.Add(3*time.Hour), defaults)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, l.Height)
+		height, err = c.LastTrustedHeight()
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, height)
+	})
 	t.Run("LargeBisectionVerification", func(t *testing.T) {
 		// start from a large light block to make sure that the pivot height doesn't select a height outside
 		// the appropriate range
@@ -265,7 +265,10 @@ func (c *Client) handleConflictingHeaders(
 	)
 	if err != nil {
 		c.logger.Info("error validating primary's divergent header", "primary", c.primary, "err", err)
-		return ErrLightClientAttack
+		return ErrAttackDetected{
+			WitnessID:              supportingWitness.ID(),
+			EvidenceAgainstPrimary: evidenceAgainstPrimary,
+		}
 	}
 
 	// We now use the primary trace to create evidence against the witness and send it to the primary
@@ -275,7 +278,11 @@ func (c *Client) handleConflictingHeaders(
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
 	// We return the error and don't process anymore witnesses
-	return ErrLightClientAttack
+	return ErrAttackDetected{
+		WitnessID:              supportingWitness.ID(),
+		EvidenceAgainstPrimary: evidenceAgainstPrimary,
+		EvidenceAgainstWitness: evidenceAgainstWitness,
+	}
 }
 
 // examineConflictingHeaderAgainstTrace takes a trace from one provider and a divergent header that
@@ -326,7 +333,8 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 			// before sending back the divergent block and trace we need to ensure we have verified
 			// the final gap between the previouslyVerifiedBlock and the targetBlock
 			if previouslyVerifiedBlock.Height != targetBlock.Height {
-				sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, targetBlock, now)
+				sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, targetBlock, now,
+					c.DefaultVerifyOptions())
 				if err != nil {
 					return nil, nil, fmt.Errorf("verifySkipping of conflicting header failed: %w", err)
 				}
@@ -357,7 +365,7 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 
 		// we check that the source provider can verify a block at the same height of the
 		// intermediate height
-		sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, sourceBlock, now)
+		sourceTrace, err = c.verifySkipping(ctx, source, previouslyVerifiedBlock, sourceBlock, now, c.DefaultVerifyOptions())
 		if err != nil {
 			return nil, nil, fmt.Errorf("verifySkipping of conflicting header failed: %w", err)
 		}
@@ -70,6 +70,7 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
 	})).Return(nil)
 
+	mockWitness.On("ID").Return("witness")
 	mockPrimary.On("ReportEvidence", mock.Anything, mock.MatchedBy(func(evidence types.Evidence) bool {
 		evAgainstWitness := &types.LightClientAttackEvidence{
 			// when forming evidence against witness we learn that the canonical chain continued to change validator sets
@@ -102,9 +103,27 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 	// Check verification returns an error.
 	_, err = c.VerifyLightBlockAtHeight(ctx, latestHeight, bTime.Add(1*time.Hour))
 	if assert.Error(t, err) {
-		assert.Equal(t, light.ErrLightClientAttack, err)
+		assert.ErrorIs(t, err, light.ErrLightClientAttack)
 	}
 
+	// Check the error holds the evidence against the primary.
+	var attackErr light.ErrAttackDetected
+	require.ErrorAs(t, err, &attackErr)
+	assert.Equal(t, "witness", attackErr.WitnessID)
+	evAgainstPrimary := attackErr.EvidenceAgainstPrimary
+	require.NotNil(t, evAgainstPrimary)
+	assert.EqualValues(t, 1, evAgainstPrimary.CommonHeight)
+	assert.Equal(t, primaryHeaders[latestHeight].Hash(), evAgainstPrimary.ConflictingBlock.Hash())
+	require.NotNil(t, attackErr.EvidenceAgainstWitness)
+	assert.EqualValues(t, divergenceHeight-1, attackErr.EvidenceAgainstWitness.CommonHeight)
+
+	// The evidence must be serializable for submission to a full node.
+	pb, err := evAgainstPrimary.ToProto()
+	require.NoError(t, err)
+	decoded, err := types.LightClientAttackEvidenceFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, evAgainstPrimary.Hash(), decoded.Hash())
+
 	mockWitness.AssertExpectations(t)
 	mockPrimary.AssertExpectations(t)
 }
@@ -193,6 +212,7 @@ func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 				}
 				return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
 			})).Return(nil)
+			mockWitness.On("ID").Return("witness")
 			mockPrimary.On("ReportEvidence", mock.Anything, mock.MatchedBy(func(evidence types.Evidence) bool {
 				evAgainstWitness := &types.LightClientAttackEvidence{
 					ConflictingBlock: &types.LightBlock{
@@ -224,7 +244,7 @@ func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 			// Check verification returns an error.
 			_, err = c.VerifyLightBlockAtHeight(ctx, testCase.latestHeight, bTime.Add(300*time.Second))
 			if assert.Error(t, err) {
-				assert.Equal(t, light.ErrLightClientAttack, err)
+				assert.ErrorIs(t, err, light.ErrLightClientAttack)
 			}
 
 			mockWitness.AssertExpectations(t)
@@ -300,6 +320,8 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
 	})).Return(nil).Twice()
 
+	mockWitness.On("ID").Return("witness")
+
 	// In order to perform the attack, the primary needs at least one accomplice as a witness to also
 	// send the forged block
 	accomplice := mockPrimary
@@ -349,14 +371,14 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 	// to allow a window for the attack to manifest itself.
 	_, err = c.Update(ctx, bTime.Add(time.Duration(forgedHeight)*time.Minute))
 	if assert.Error(t, err) {
-		assert.Equal(t, light.ErrLightClientAttack, err)
+		assert.ErrorIs(t, err, light.ErrLightClientAttack)
 	}
 
 	// We attempt the same call but now the supporting witness has a block which should
 	// immediately conflict in time with the primary
 	_, err = c.VerifyLightBlockAtHeight(ctx, forgedHeight, bTime.Add(time.Duration(forgedHeight)*time.Minute))
 	if assert.Error(t, err) {
-		assert.Equal(t, light.ErrLightClientAttack, err)
+		assert.ErrorIs(t, err, light.ErrLightClientAttack)
 	}
 
 	// Lastly we test the unfortunate case where the light clients supporting witness doesn't update
@@ -33,14 +33,37 @@ type Dispatcher struct {
 	calls map[types.NodeID]chan *types.LightBlock
 
 	lightBlockMsgCreator func(uint64) proto.Message
+
+	// responses exceeding these limits are rejected before being converted
+	sizeLimits types.LightBlockSizeLimits
+}
+
+// DispatcherOption sets an optional parameter on the Dispatcher.
+type DispatcherOption func(*Dispatcher)
+
+// DispatcherSizeLimits sets the size limits of the light blocks received in
+// responses, which default to types.DefaultLightBlockSizeLimits.
+func DispatcherSizeLimits(limits types.LightBlockSizeLimits) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.sizeLimits = limits
+	}
 }
 
-func NewDispatcher(requestChannel *p2p.Channel, lightBlockMsgCreator func(uint64) proto.Message) *Dispatcher {
-	return &Dispatcher{
+func NewDispatcher(
+	requestChannel *p2p.Channel,
+	lightBlockMsgCreator func(uint64) proto.Message,
+	options ...DispatcherOption,
+) *Dispatcher {
+	d := &Dispatcher{
 		requestCh:            requestChannel,
 		calls:                make(map[types.NodeID]chan *types.LightBlock),
 		lightBlockMsgCreator: lightBlockMsgCreator,
+		sizeLimits:           types.DefaultLightBlockSizeLimits(),
+	}
+	for _, option := range options {
+		option(d)
 	}
+	return d
 }
 
 // LightBlock uses the request channel to fetch a light block from a given peer
@@ -130,6 +153,9 @@ func (d *Dispatcher) Respond(ctx context.Context, lb *tmproto.LightBlock, peer t
 		}
 	}
 
+	if err := d.sizeLimits.CheckProto(lb); err != nil {
+		return err
+	}
 	block, err := types.LightBlockFromProto(lb)
 	if err != nil {
 		return err
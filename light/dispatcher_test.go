@@ -125,6 +125,42 @@ func TestDispatcherTimeOutWaitingOnLightBlock(t *testing.T) {
 	require.Nil(t, lb)
 }
 
+func TestDispatcherRejectsOversizedLightBlock(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chans, ch := testChannel(100)
+
+	d := NewDispatcher(ch, func(height uint64) proto.Message {
+		return &ssproto.LightBlockRequest{
+			Height: height,
+		}
+	}, DispatcherSizeLimits(types.LightBlockSizeLimits{MaxHeaderBytes: 1024}))
+	peer := factory.NodeID(t, "a")
+
+	go func() {
+		<-chans.Out
+		resp := mockLBResp(ctx, t, peer, 1, time.Now())
+		// pad the header with an oversized chain ID
+		resp.block.ChainID = strings.Repeat("a", 1024)
+		block, err := resp.block.ToProto()
+		require.NoError(t, err)
+		require.ErrorIs(t, d.Respond(ctx, block, peer), types.ErrLightBlockTooLarge)
+
+		// a response within the limits is accepted
+		resp = mockLBResp(ctx, t, peer, 1, time.Now())
+		block, err = resp.block.ToProto()
+		require.NoError(t, err)
+		require.NoError(t, d.Respond(ctx, block, peer))
+	}()
+
+	lb, err := d.LightBlock(ctx, 1, peer)
+	require.NoError(t, err)
+	require.NotNil(t, lb)
+}
+
 func TestDispatcherProviders(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
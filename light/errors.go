@@ -72,10 +72,68 @@ var ErrLightClientAttack = errors.New(`attempted attack detected.
 	Check logs for full evidence and trace`,
 )
 
+// ErrAttackDetected is returned when the light client has detected an attempt
+// to verify a false header. It carries the evidence formed at the bifurcation
+// point found while verifying the witness's conflicting header, ready to be
+// submitted to a full node. It wraps ErrLightClientAttack.
+type ErrAttackDetected struct {
+	// WitnessID is the ID of the witness that provided the conflicting header.
+	WitnessID string
+	// EvidenceAgainstPrimary holds the primary's conflicting block and the
+	// common height, taking the witness as the source of truth.
+	EvidenceAgainstPrimary *types.LightClientAttackEvidence
+	// EvidenceAgainstWitness holds the witness's conflicting block and the
+	// common height, taking the primary as the source of truth. It is nil if
+	// the primary could not verify its own trace against the witness's header.
+	EvidenceAgainstWitness *types.LightClientAttackEvidence
+}
+
+// Unwrap returns ErrLightClientAttack.
+func (e ErrAttackDetected) Unwrap() error {
+	return ErrLightClientAttack
+}
+
+func (e ErrAttackDetected) Error() string {
+	return fmt.Sprintf("attempted attack detected: conflicting header %X at height %d (common height %d) from witness %s",
+		e.EvidenceAgainstPrimary.ConflictingBlock.Hash(), e.EvidenceAgainstPrimary.ConflictingBlock.Height,
+		e.EvidenceAgainstPrimary.CommonHeight, e.WitnessID)
+}
+
 // ErrNoWitnesses means that there are not enough witnesses connected to
 // continue running the light client.
 var ErrNoWitnesses = errors.New("no witnesses connected. please reset light client")
 
+// ErrProofMalformed means an ABCI query proof could not be decoded, does not
+// match the queried key, or does not prove anything against the trusted app
+// hash.
+type ErrProofMalformed struct {
+	Reason error
+}
+
+// Unwrap returns underlying reason.
+func (e ErrProofMalformed) Unwrap() error {
+	return e.Reason
+}
+
+func (e ErrProofMalformed) Error() string {
+	return fmt.Sprintf("malformed proof: %v", e.Reason)
+}
+
+// ErrProofValueMismatch means an ABCI query proof is valid against the trusted
+// app hash, but proves a different value than the one in the query response.
+type ErrProofValueMismatch struct {
+	Reason error
+}
+
+// Unwrap returns underlying reason.
+func (e ErrProofValueMismatch) Unwrap() error {
+	return e.Reason
+}
+
+func (e ErrProofValueMismatch) Error() string {
+	return fmt.Sprintf("proof does not match value: %v", e.Reason)
+}
+
 // ----------------------------- INTERNAL ERRORS ---------------------------------
 
 // ErrConflictingHeaders is thrown when two conflicting headers are discovered.
@@ -0,0 +1,103 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/types"
+)
+
+// VerifyABCIQueryProof verifies the proof of an ABCI query response against the
+// AppHash of a trusted light block. keyPath is the Merkle key path of the
+// queried key (see merkle.KeyPath). If the response has no value, the proof is
+// verified as an absence proof.
+//
+// NOTE: AppHash for height H is in header H+1, so the trusted light block must
+// be at resp.Height+1.
+//
+// prt is used to decode the proof operators. If nil, merkle.DefaultProofRuntime
+// is used, which knows about simple value proofs. To verify e.g. IAVL proofs,
+// register their op-decoders with a custom runtime.
+//
+// ErrProofMalformed is returned if the proof can't be decoded or does not
+// verify against the AppHash, and ErrProofValueMismatch if the proof is valid
+// but proves a different value than the one in the response.
+func VerifyABCIQueryProof(
+	prt *merkle.ProofRuntime,
+	resp abci.ResponseQuery,
+	keyPath string,
+	trustedBlock *types.LightBlock,
+) error {
+	if trustedBlock == nil || trustedBlock.SignedHeader == nil || trustedBlock.Header == nil {
+		return errors.New("nil trusted light block")
+	}
+	if trustedBlock.Height != resp.Height+1 {
+		return fmt.Errorf("expected trusted light block at height %d (query height + 1), got %d",
+			resp.Height+1, trustedBlock.Height)
+	}
+	if resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
+		return ErrProofMalformed{Reason: errors.New("no proof ops")}
+	}
+
+	if prt == nil {
+		prt = merkle.DefaultProofRuntime()
+	}
+	poz, err := prt.DecodeProof(resp.ProofOps)
+	if err != nil {
+		return ErrProofMalformed{Reason: err}
+	}
+
+	appHash := trustedBlock.AppHash
+	if resp.Value == nil {
+		if err := poz.Verify(appHash, keyPath, nil); err != nil {
+			return ErrProofMalformed{Reason: fmt.Errorf("verify absence proof: %w", err)}
+		}
+		return nil
+	}
+
+	err = poz.VerifyValue(appHash, keyPath, resp.Value)
+	if err == nil {
+		return nil
+	}
+	if provesOtherValue(poz, appHash, keyPath) {
+		return ErrProofValueMismatch{Reason: err}
+	}
+	return ErrProofMalformed{Reason: fmt.Errorf("verify value proof: %w", err)}
+}
+
+// provesOtherValue reports whether the proof is valid against root for the key
+// path, using the value committed to by the proof itself rather than the one
+// returned by the query. This can only be determined for simple value proofs.
+func provesOtherValue(poz merkle.ProofOperators, root []byte, keyPath string) bool {
+	if len(poz) == 0 {
+		return false
+	}
+	op, ok := poz[0].(merkle.ValueOp)
+	if !ok {
+		return false
+	}
+
+	keys, err := merkle.KeyPathToKeys(keyPath)
+	if err != nil || len(keys) == 0 || !bytes.Equal(keys[len(keys)-1], op.GetKey()) {
+		return false
+	}
+	keys = keys[:len(keys)-1]
+
+	leafRoot, err := op.Proof.ComputeRootHash()
+	if err != nil {
+		return false
+	}
+	if len(poz) == 1 {
+		return len(keys) == 0 && bytes.Equal(root, leafRoot)
+	}
+
+	// Verify the remaining operators against the rest of the key path.
+	kp := merkle.KeyPath{}
+	for _, key := range keys {
+		kp = kp.AppendKey(key, merkle.KeyEncodingHex)
+	}
+	return poz[1:].Verify(root, kp.String(), [][]byte{leafRoot}) == nil
+}
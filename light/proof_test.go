@@ -0,0 +1,92 @@
+package light_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/light"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"github.com/tendermint/tendermint/types"
+)
+
+// kvLeaf encodes a key/value pair as a leaf of a simple value proof.
+func kvLeaf(key, value []byte) []byte {
+	vhash := sha256.Sum256(value)
+	leaf := binary.AppendUvarint(nil, uint64(len(key)))
+	leaf = append(leaf, key...)
+	leaf = binary.AppendUvarint(leaf, uint64(len(vhash)))
+	return append(leaf, vhash[:]...)
+}
+
+func TestVerifyABCIQueryProof(t *testing.T) {
+	key, value := []byte("foo"), []byte("bar")
+	appHash, proofs := merkle.ProofsFromByteSlices([][]byte{
+		kvLeaf([]byte("baz"), []byte("qux")),
+		kvLeaf(key, value),
+		kvLeaf([]byte("quux"), []byte("corge")),
+	})
+	proofOps := &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{merkle.NewValueOp(key, proofs[1]).ProofOp()}}
+	keyPath := merkle.KeyPath{}.AppendKey(key, merkle.KeyEncodingURL).String()
+
+	const height = 10
+	trustedBlock := func(appHash []byte) *types.LightBlock {
+		return &types.LightBlock{SignedHeader: &types.SignedHeader{
+			Header: &types.Header{Height: height + 1, AppHash: appHash},
+		}}
+	}
+
+	testCases := []struct {
+		name         string
+		resp         abci.ResponseQuery
+		keyPath      string
+		trustedBlock *types.LightBlock
+		expectErr    error
+	}{
+		{"valid", abci.ResponseQuery{Height: height, Value: value, ProofOps: proofOps},
+			keyPath, trustedBlock(appHash), nil},
+		{"value mismatch", abci.ResponseQuery{Height: height, Value: []byte("baz"), ProofOps: proofOps},
+			keyPath, trustedBlock(appHash), light.ErrProofValueMismatch{}},
+		{"app hash mismatch", abci.ResponseQuery{Height: height, Value: value, ProofOps: proofOps},
+			keyPath, trustedBlock([]byte("other")), light.ErrProofMalformed{}},
+		{"key mismatch", abci.ResponseQuery{Height: height, Value: value, ProofOps: proofOps},
+			merkle.KeyPath{}.AppendKey([]byte("baz"), merkle.KeyEncodingURL).String(),
+			trustedBlock(appHash), light.ErrProofMalformed{}},
+		{"no proof ops", abci.ResponseQuery{Height: height, Value: value},
+			keyPath, trustedBlock(appHash), light.ErrProofMalformed{}},
+		{"undecodable proof op", abci.ResponseQuery{Height: height, Value: value, ProofOps: &tmcrypto.ProofOps{
+			Ops: []tmcrypto.ProofOp{{Type: merkle.ProofOpValue, Key: key, Data: []byte("garbage")}},
+		}}, keyPath, trustedBlock(appHash), light.ErrProofMalformed{}},
+		{"unknown proof op", abci.ResponseQuery{Height: height, Value: value, ProofOps: &tmcrypto.ProofOps{
+			Ops: []tmcrypto.ProofOp{{Type: "iavl:v", Key: key}},
+		}}, keyPath, trustedBlock(appHash), light.ErrProofMalformed{}},
+		{"absence proof", abci.ResponseQuery{Height: height, ProofOps: proofOps},
+			keyPath, trustedBlock(appHash), light.ErrProofMalformed{}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := light.VerifyABCIQueryProof(nil, tc.resp, tc.keyPath, tc.trustedBlock)
+			switch tc.expectErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case light.ErrProofValueMismatch:
+				require.True(t, errors.As(err, &light.ErrProofValueMismatch{}), err)
+			case light.ErrProofMalformed:
+				require.True(t, errors.As(err, &light.ErrProofMalformed{}), err)
+			}
+		})
+	}
+
+	// The trusted block must be at the height following the query height.
+	err := light.VerifyABCIQueryProof(nil, abci.ResponseQuery{Height: height + 1, Value: value, ProofOps: proofOps},
+		keyPath, trustedBlock(appHash))
+	require.Error(t, err)
+	require.False(t, errors.As(err, &light.ErrProofMalformed{}))
+}
@@ -22,6 +22,12 @@ var defaultOptions = Options{
 	Timeout:             5 * time.Second,
 	NoBlockThreshold:    5,
 	NoResponseThreshold: 5,
+	SizeLimits:          types.DefaultLightBlockSizeLimits(),
+}
+
+// DefaultOptions returns the options used by New and NewWithClient.
+func DefaultOptions() Options {
+	return defaultOptions
 }
 
 // http provider uses an RPC client to obtain the necessary information.
@@ -47,6 +53,9 @@ type http struct {
 	// with exponential backoff to reach the client. If this
 	// exceeds the maxRetry attempts, this result in a ErrNoResponse
 	maxRetryAttempts uint16
+
+	// Light blocks exceeding these limits are rejected
+	sizeLimits types.LightBlockSizeLimits
 }
 
 type Options struct {
@@ -60,6 +69,9 @@ type Options struct {
 	// The amount of requests that a client doesn't respond to
 	// before the provider deems the client unreliable
 	NoResponseThreshold uint16
+	// The size limits of the light blocks returned by the client. Zero
+	// limits are not enforced.
+	SizeLimits types.LightBlockSizeLimits
 }
 
 // New creates a HTTP provider, which is using the rpchttp.HTTP client under
@@ -97,6 +109,7 @@ func NewWithClientAndOptions(chainID string, client rpcclient.RemoteClient, opti
 		maxRetryAttempts:    options.MaxRetryAttempts,
 		noResponseThreshold: options.NoResponseThreshold,
 		noBlockThreshold:    options.NoBlockThreshold,
+		sizeLimits:          options.SizeLimits,
 	}
 }
 
@@ -130,6 +143,11 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		}
 	}
 
+	// check the header before fetching the validators it commits to
+	if err := p.sizeLimits.Check(&types.LightBlock{SignedHeader: sh}); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+
 	vs, err := p.validatorSet(ctx, &sh.Height)
 	if err != nil {
 		return nil, err
@@ -140,6 +158,10 @@ func (p *http) LightBlock(ctx context.Context, height int64) (*types.LightBlock,
 		ValidatorSet: vs,
 	}
 
+	if err := p.sizeLimits.Check(lb); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+
 	err = lb.ValidateBasic(p.chainID)
 	if err != nil {
 		return nil, provider.ErrBadLightBlock{Reason: err}
@@ -76,6 +76,13 @@ func TestProvider(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, lower, lb.Height)
 
+	// light blocks exceeding the size limits are rejected
+	opts := lighthttp.DefaultOptions()
+	opts.SizeLimits.MaxHeaderBytes = 64
+	_, err = lighthttp.NewWithClientAndOptions(chainID, c, opts).LightBlock(ctx, lower)
+	assert.IsType(t, provider.ErrBadLightBlock{}, err)
+	assert.ErrorIs(t, err, types.ErrLightBlockTooLarge)
+
 	// fetching missing heights (both future and pruned) should return appropriate errors
 	lb, err = p.LightBlock(ctx, 9001)
 	require.Error(t, err)
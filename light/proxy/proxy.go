@@ -116,7 +116,7 @@ func (p *Proxy) listen(ctx context.Context) (net.Listener, *http.ServeMux, error
 	}
 
 	// 4) Start listening for new connections.
-	listener, err := rpcserver.Listen(p.Addr, p.Config.MaxOpenConnections)
+	listener, err := rpcserver.Listen(p.Addr, p.Config)
 	if err != nil {
 		return nil, mux, err
 	}
@@ -32,6 +32,10 @@ func (p proxyService) BlockByHash(ctx context.Context, req *coretypes.RequestBlo
 	return p.Client.BlockByHash(ctx, req.Hash)
 }
 
+func (p proxyService) BlockByAppHash(ctx context.Context, req *coretypes.RequestBlockByAppHash) (*coretypes.ResultBlock, error) {
+	return p.Client.BlockByAppHash(ctx, req.AppHash)
+}
+
 func (p proxyService) BlockResults(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlockResults, error) {
 	return p.Client.BlockResults(ctx, (*int64)(req.Height))
 }
@@ -48,6 +52,14 @@ func (p proxyService) BroadcastEvidence(ctx context.Context, req *coretypes.Requ
 	return p.Client.BroadcastEvidence(ctx, req.Evidence)
 }
 
+func (p proxyService) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	return p.Client.ExpiredEvidence(ctx)
+}
+
+func (p proxyService) PendingEvidence(ctx context.Context, req *coretypes.RequestPendingEvidence) (*coretypes.ResultPendingEvidence, error) {
+	return p.Client.PendingEvidence(ctx, req.Type, req.Page.IntPtr(), req.PerPage.IntPtr())
+}
+
 func (p proxyService) BroadcastTxAsync(ctx context.Context, req *coretypes.RequestBroadcastTx) (*coretypes.ResultBroadcastTx, error) {
 	return p.Client.BroadcastTxAsync(ctx, req.Tx)
 }
@@ -76,6 +88,10 @@ func (p proxyService) ConsensusParams(ctx context.Context, req *coretypes.Reques
 	return p.Client.ConsensusParams(ctx, (*int64)(req.Height))
 }
 
+func (p proxyService) ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error) {
+	return p.Client.ConsensusVotes(ctx)
+}
+
 func (p proxyService) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	return p.Client.DumpConsensusState(ctx)
 }
@@ -108,6 +124,14 @@ func (p proxyService) Health(ctx context.Context) (*coretypes.ResultHealth, erro
 	return p.Client.Health(ctx)
 }
 
+func (p proxyService) HeightETA(ctx context.Context, req *coretypes.RequestHeightETA) (*coretypes.ResultHeightETA, error) {
+	return p.Client.HeightETA(ctx, int64(req.Height))
+}
+
+func (p proxyService) MempoolEntries(ctx context.Context, req *coretypes.RequestMempoolEntries) (*coretypes.ResultMempoolEntries, error) {
+	return p.Client.MempoolEntries(ctx, req.Page.IntPtr(), req.PerPage.IntPtr())
+}
+
 func (p proxyService) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return p.Client.NetInfo(ctx)
 }
@@ -136,6 +160,14 @@ func (p proxyService) Tx(ctx context.Context, req *coretypes.RequestTx) (*corety
 	return p.Client.Tx(ctx, req.Hash, req.Prove)
 }
 
+func (p proxyService) TxEvents(ctx context.Context, req *coretypes.RequestTxEvents) (*coretypes.ResultTxEvents, error) {
+	return p.Client.TxEvents(ctx, req.Hash)
+}
+
+func (p proxyService) TxProof(ctx context.Context, req *coretypes.RequestTxProof) (*coretypes.ResultTxProof, error) {
+	return p.Client.TxProof(ctx, req.Hash)
+}
+
 func (p proxyService) TxSearch(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
 	return p.Client.TxSearch(ctx, req.Query, req.Prove, req.Page.IntPtr(), req.PerPage.IntPtr(), req.OrderBy)
 }
@@ -152,6 +184,10 @@ func (p proxyService) UnsubscribeAll(ctx context.Context) (*coretypes.ResultUnsu
 	return p.Client.UnsubscribeAllWS(ctx)
 }
 
+func (p proxyService) ValidatorUptime(ctx context.Context, req *coretypes.RequestValidatorUptime) (*coretypes.ResultValidatorUptime, error) {
+	return p.Client.ValidatorUptime(ctx, req.Window.IntPtr())
+}
+
 func (p proxyService) Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error) {
 	return p.Client.Validators(ctx, (*int64)(req.Height), req.Page.IntPtr(), req.PerPage.IntPtr())
 }
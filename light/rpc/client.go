@@ -51,6 +51,9 @@ type Client struct {
 	prt       *merkle.ProofRuntime
 	keyPathFn KeyPathFunc
 
+	// size limits of the block metas returned by BlockchainInfo
+	sizeLimits types.LightBlockSizeLimits
+
 	closers []func()
 }
 
@@ -68,6 +71,14 @@ func KeyPathFn(fn KeyPathFunc) Option {
 	}
 }
 
+// SizeLimits option sets the size limits of the headers returned by the
+// underlying client, which default to types.DefaultLightBlockSizeLimits.
+func SizeLimits(limits types.LightBlockSizeLimits) Option {
+	return func(c *Client) {
+		c.sizeLimits = limits
+	}
+}
+
 // DefaultMerkleKeyPathFn creates a function used to generate merkle key paths
 // from a path string and a key. This is the default used by the cosmos SDK.
 // This merkle key paths are required when verifying /abci_query calls
@@ -95,6 +106,8 @@ func NewClient(logger log.Logger, next rpcclient.Client, lc LightClient, opts ..
 		next: next,
 		lc:   lc,
 		prt:  merkle.DefaultProofRuntime(),
+
+		sizeLimits: types.DefaultLightBlockSizeLimits(),
 	}
 	c.BaseService = *service.NewBaseService(logger, "Client", c)
 	for _, o := range opts {
@@ -247,6 +260,10 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconf
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) MempoolEntries(ctx context.Context, page, perPage *int) (*coretypes.ResultMempoolEntries, error) {
+	return c.next.MempoolEntries(ctx, page, perPage)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -267,6 +284,10 @@ func (c *Client) ConsensusState(ctx context.Context) (*coretypes.ResultConsensus
 	return c.next.ConsensusState(ctx)
 }
 
+func (c *Client) ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error) {
+	return c.next.ConsensusVotes(ctx)
+}
+
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
@@ -317,6 +338,9 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 		if meta == nil {
 			return nil, fmt.Errorf("nil block meta %d", i)
 		}
+		if err := c.sizeLimits.CheckBlockMeta(meta); err != nil {
+			return nil, fmt.Errorf("invalid block meta %d: %w", i, err)
+		}
 		if err := meta.ValidateBasic(); err != nil {
 			return nil, fmt.Errorf("invalid block meta %d: %w", i, err)
 		}
@@ -345,6 +369,12 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return res, nil
 }
 
+// HeightETA calls rpcclient#HeightETA. The estimate is derived from block
+// times, so it is not verified.
+func (c *Client) HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error) {
+	return c.next.HeightETA(ctx, height)
+}
+
 func (c *Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
@@ -387,6 +417,53 @@ func (c *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlo
 	return res, nil
 }
 
+// BlockByAppHash calls rpcclient#BlockByAppHash and then verifies the result.
+// The app hash produced by the block is recorded in the header of the next
+// block, so that header is verified as well.
+func (c *Client) BlockByAppHash(ctx context.Context, appHash tmbytes.HexBytes) (*coretypes.ResultBlock, error) {
+	res, err := c.next.BlockByAppHash(ctx, appHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if err := res.BlockID.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := res.Block.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
+		return nil, fmt.Errorf("blockID %X does not match with block %X",
+			bmH, bH)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify block.
+	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
+		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+			bH, tH)
+	}
+
+	// Verify the app hash.
+	nextHeight := res.Block.Height + 1
+	next, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(next.AppHash, appHash) {
+		return nil, fmt.Errorf("app hash %X at height %d does not match with requested app hash %X",
+			next.AppHash, nextHeight, appHash)
+	}
+
+	return res, nil
+}
+
 // BlockByHash calls rpcclient#BlockByHash and then verifies the result.
 func (c *Client) BlockByHash(ctx context.Context, hash tmbytes.HexBytes) (*coretypes.ResultBlock, error) {
 	res, err := c.next.BlockByHash(ctx, hash)
@@ -549,6 +626,38 @@ func (c *Client) Tx(ctx context.Context, hash tmbytes.HexBytes, prove bool) (*co
 	return res, res.Proof.Validate(l.DataHash)
 }
 
+// TxEvents calls rpcclient#TxEvents. Events are not part of the results hash,
+// so the result can't be verified.
+func (c *Client) TxEvents(ctx context.Context, hash tmbytes.HexBytes) (*coretypes.ResultTxEvents, error) {
+	return c.next.TxEvents(ctx, hash)
+}
+
+// TxProof calls rpcclient#TxProof and then verifies the proof against the
+// DataHash of the trusted header at the tx's height.
+func (c *Client) TxProof(ctx context.Context, hash tmbytes.HexBytes) (*coretypes.ResultTxProof, error) {
+	res, err := c.next.TxProof(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+	if !bytes.Equal(res.Tx.Hash(), hash) {
+		return nil, fmt.Errorf("tx hash %X does not match requested hash %X", res.Tx.Hash(), hash)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof.
+	return res, res.Validate(l.DataHash)
+}
+
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -568,6 +677,12 @@ func (c *Client) BlockSearch(
 	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
 }
 
+// ValidatorUptime calls rpcclient#ValidatorUptime. It reports on the validator
+// of the primary, and is not verified.
+func (c *Client) ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error) {
+	return c.next.ValidatorUptime(ctx, window)
+}
+
 // Validators fetches and verifies validators.
 func (c *Client) Validators(
 	ctx context.Context,
@@ -604,6 +719,14 @@ func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*cor
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+func (c *Client) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	return c.next.ExpiredEvidence(ctx)
+}
+
+func (c *Client) PendingEvidence(ctx context.Context, evidenceType string, page, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	return c.next.PendingEvidence(ctx, evidenceType, page, perPage)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...) //nolint:staticcheck
@@ -61,11 +61,15 @@ func NewRPCStateProvider(
 	trustOptions TrustOptions,
 	logger log.Logger,
 	blacklistTTL time.Duration,
+	sizeLimits types.LightBlockSizeLimits,
 ) (StateProvider, error) {
 	if len(servers) < 2 {
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %d", len(servers))
 	}
 
+	providerOptions := lighthttp.DefaultOptions()
+	providerOptions.SizeLimits = sizeLimits
+
 	providers := make([]lightprovider.Provider, 0, len(servers))
 	providerRemotes := make(map[lightprovider.Provider]string)
 	for _, server := range servers {
@@ -73,7 +77,7 @@ func NewRPCStateProvider(
 		if err != nil {
 			return nil, fmt.Errorf("failed to set up RPC client: %w", err)
 		}
-		provider := lighthttp.NewWithClient(chainID, client)
+		provider := lighthttp.NewWithClientAndOptions(chainID, client, providerOptions)
 		providers = append(providers, provider)
 		// We store the RPC addresses keyed by provider, so we can find the address of the primary
 		// provider used by the light client and use it to fetch consensus parameters.
@@ -15,9 +15,9 @@ import (
 
 // key prefixes
 // NB: Before modifying these, cross-check them with those in
-// * internal/store/store.go    [0..4, 13]
-// * internal/state/store.go    [5..8, 14]
-// * internal/evidence/pool.go  [9..10]
+// * internal/store/store.go    [0..4, 13, 17]
+// * internal/state/store.go    [5..8, 14..15, 18..19]
+// * internal/evidence/pool.go  [9..10, 16]
 // * light/store/db/db.go       [11..12]
 // TODO(sergio): Move all these to their own package.
 // TODO: what about these (they already collide):
@@ -1,11 +1,15 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -18,12 +22,14 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/blocksync"
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/dbsync"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/eventlog"
 	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
@@ -35,6 +41,7 @@ import (
 	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/privval"
@@ -77,6 +84,7 @@ type nodeImpl struct {
 	shutdownOps    closer
 	rpcEnv         *rpccore.Environment
 	prometheusSrv  *http.Server
+	haltCh         <-chan struct{} // closed once consensus halted at the halt height
 }
 
 // newDefaultNode returns a Tendermint node with default settings for the
@@ -116,7 +124,7 @@ func newDefaultNode(
 		return nil, err
 	}
 
-	return makeNode(
+	n, err := makeNode(
 		ctx,
 		cfg,
 		restartCh,
@@ -129,6 +137,10 @@ func newDefaultNode(
 		[]trace.TracerProviderOption{},
 		DefaultMetricsProvider(cfg.Instrumentation)(cfg.ChainID()),
 	)
+	if err != nil && pval != nil {
+		_ = pval.Close()
+	}
+	return n, err
 }
 
 // makeNode returns a new, ready to go, Tendermint Node.
@@ -144,7 +156,12 @@ func makeNode(
 	logger log.Logger,
 	tracerProviderOptions []trace.TracerProviderOption,
 	nodeMetrics *NodeMetrics,
+	options ...Option,
 ) (service.Service, error) {
+	var opts nodeOptions
+	for _, option := range options {
+		option(&opts)
+	}
 
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
@@ -159,6 +176,18 @@ func makeNode(
 
 	stateStore := sm.NewStore(stateDB)
 
+	// complete any rollback that was interrupted before the block store was trimmed
+	recoveredHeight, err := sm.RecoverRollback(blockStore, stateStore)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+	if recoveredHeight > 0 {
+		logger.Info("completed interrupted rollback", "height", recoveredHeight)
+	}
+	if err := sm.CheckStoreHeights(blockStore, stateStore); err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
 	genDoc, err := genesisDocProvider()
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
@@ -167,6 +196,9 @@ func makeNode(
 	if err = genDoc.ValidateAndComplete(); err != nil {
 		return nil, combineCloseError(fmt.Errorf("error in genesis doc: %w", err), makeCloser(closers))
 	}
+	if err := merkle.SetHashFunction(genDoc.MerkleHashFunction); err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
 
 	state, err := loadStateFromDBOrGenesisDocProvider(stateStore, genDoc)
 	if err != nil {
@@ -188,7 +220,7 @@ func makeNode(
 			return nil, combineCloseError(fmt.Errorf("initializing event log: %w", err), makeCloser(closers))
 		}
 	}
-	eventSinks, err := sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID)
+	eventSinks, err := sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID, nodeMetrics.indexer)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
@@ -270,6 +302,7 @@ func makeNode(
 			fmt.Errorf("failed to create router: %w", err),
 			makeCloser(closers))
 	}
+	node.rpcEnv.Router = node.router
 
 	evReactor, evPool, edbCloser, err := createEvidenceReactor(logger, cfg, dbProvider,
 		stateStore, blockStore, peerManager.Subscribe, nodeMetrics.evidence, eventBus)
@@ -289,7 +322,7 @@ func makeNode(
 	shoulddbsync := cfg.DBSync.Enable && info.LastBlockHeight == 0
 
 	mpReactor, mp := createMempoolReactor(logger, cfg, proxyApp, stateStore, nodeMetrics.mempool,
-		peerManager.Subscribe, peerManager)
+		peerManager.Subscribe, peerManager, opts.mempoolOptions...)
 	node.router.AddChDescToBeAdded(mempool.GetChannelDescriptor(cfg.Mempool), mpReactor.SetChannel)
 	if !shoulddbsync {
 		mpReactor.MarkReadyToStart()
@@ -298,6 +331,13 @@ func makeNode(
 	node.services = append(node.services, mpReactor)
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	var blockExecOpts []sm.BlockExecutorOption
+	if cfg.Consensus.DeterministicTxOrder {
+		blockExecOpts = append(blockExecOpts, sm.WithSeededTxOrder())
+	}
+	if opts.validateBlockExtension != nil {
+		blockExecOpts = append(blockExecOpts, sm.WithValidateBlockExtension(opts.validateBlockExtension))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
@@ -307,7 +347,12 @@ func makeNode(
 		blockStore,
 		eventBus,
 		nodeMetrics.state,
+		blockExecOpts...,
 	)
+	for _, hook := range opts.validatorSetChanged {
+		blockExec.RegisterValidatorSetChangeHook(hook)
+	}
+	node.rpcEnv.BlockExecutor = blockExec
 
 	// Determine whether we should attempt state sync.
 	stateSync := cfg.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
@@ -341,11 +386,13 @@ func makeNode(
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 	node.rpcEnv.ConsensusState = csState
+	node.haltCh = csState.Halted()
 
 	csReactor := consensus.NewReactor(
 		logger,
 		csState,
 		peerManager.Subscribe,
+		peerManager,
 		eventBus,
 		waitSync,
 		nodeMetrics.consensus,
@@ -355,6 +402,9 @@ func makeNode(
 	node.router.AddChDescToBeAdded(consensus.GetDataChannelDescriptor(), csReactor.SetDataChannel)
 	node.router.AddChDescToBeAdded(consensus.GetVoteChannelDescriptor(), csReactor.SetVoteChannel)
 	node.router.AddChDescToBeAdded(consensus.GetVoteSetChannelDescriptor(), csReactor.SetVoteSetChannel)
+	if cfg.Consensus.ErasureCodedGossip {
+		node.router.AddChDescToBeAdded(consensus.GetParityChannelDescriptor(), csReactor.SetParityChannel)
+	}
 	node.services = append(node.services, csReactor)
 	node.rpcEnv.ConsensusReactor = csReactor
 
@@ -372,7 +422,9 @@ func makeNode(
 		nodeMetrics.consensus,
 		eventBus,
 		restartCh,
+		cfg.BlockSync,
 		cfg.SelfRemediation,
+		cfg.Consensus.HaltHeight,
 	)
 	node.router.AddChDescToBeAdded(blocksync.GetChannelDescriptor(), bcReactor.SetChannel)
 	node.services = append(node.services, bcReactor)
@@ -435,6 +487,7 @@ func makeNode(
 		restartCh,
 		cfg.SelfRemediation,
 	)
+	ssReactor.SetResumeDir(filepath.Join(cfg.DBDir(), "statesync"))
 
 	node.shouldHandshake = !stateSync && !shoulddbsync
 	node.services = append(node.services, ssReactor)
@@ -506,6 +559,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 		// and replays any blocks as necessary to sync tendermint with the app.
 		if err := consensus.NewHandshaker(n.logger.With("module", "handshaker"),
 			n.stateStore, n.initialState, n.blockStore, n.rpcEnv.EventBus, n.genesisDoc,
+			consensus.HandshakerAllowAppVersionUpgrade(n.config.Consensus.AllowAppVersionUpgrade),
 		).Handshake(ctx, n.rpcEnv.ProxyApp); err != nil {
 			return err
 		}
@@ -551,6 +605,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 			}
 		}()
 	}
+	startHeapProfiler(ctx, n.config, n.logger.With("module", "heapwatch"))
 
 	now := tmtime.Now()
 	genTime := n.genesisDoc.GenesisTime
@@ -633,6 +688,11 @@ func (n *nodeImpl) OnStop() {
 		pvsc.Stop()
 		pvsc.Wait()
 	}
+	if pv, ok := n.privValidator.(*privval.FilePV); ok {
+		if err := pv.Close(); err != nil {
+			n.logger.Error("problem releasing private validator lock", "err", err)
+		}
+	}
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -711,6 +771,12 @@ func (n *nodeImpl) RPCEnvironment() *rpccore.Environment {
 	return n.rpcEnv
 }
 
+// Halted returns a channel which is closed once consensus halted after
+// committing the block at the configured halt height.
+func (n *nodeImpl) Halted() <-chan struct{} {
+	return n.haltCh
+}
+
 //------------------------------------------------------------------------------
 
 // genesisDocProvider returns a GenesisDoc.
@@ -719,13 +785,63 @@ func (n *nodeImpl) RPCEnvironment() *rpccore.Environment {
 type genesisDocProvider func() (*types.GenesisDoc, error)
 
 // defaultGenesisDocProviderFunc returns a GenesisDocProvider that loads
-// the GenesisDoc from the config.GenesisFile() on the filesystem.
+// the GenesisDoc from the config.GenesisFile() on the filesystem. If the file
+// doesn't exist and a genesis URL is configured, the GenesisDoc is fetched
+// from it and saved to config.GenesisFile() first.
 func defaultGenesisDocProviderFunc(cfg *config.Config) genesisDocProvider {
 	return func() (*types.GenesisDoc, error) {
+		if cfg.GenesisURL != "" && !tmos.FileExists(cfg.GenesisFile()) {
+			if err := fetchGenesisFile(cfg, &http.Client{Timeout: genesisFetchTimeout}); err != nil {
+				return nil, err
+			}
+		}
 		return types.GenesisDocFromFile(cfg.GenesisFile())
 	}
 }
 
+const (
+	genesisFetchTimeout = 10 * time.Minute
+	maxGenesisSizeBytes = 1 << 30 // 1GB
+)
+
+// fetchGenesisFile fetches the genesis file from the configured genesis URL,
+// verifies its SHA-256 hash and saves it to config.GenesisFile().
+func fetchGenesisFile(cfg *config.Config, client *http.Client) error {
+	hash, err := cfg.GenesisHash()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(cfg.GenesisURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch genesis from %s: %w", cfg.GenesisURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch genesis from %s: %s", cfg.GenesisURL, resp.Status)
+	}
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, maxGenesisSizeBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to fetch genesis from %s: %w", cfg.GenesisURL, err)
+	}
+	if len(bz) > maxGenesisSizeBytes {
+		return fmt.Errorf("genesis fetched from %s is larger than %d bytes", cfg.GenesisURL, maxGenesisSizeBytes)
+	}
+
+	if actual := sha256.Sum256(bz); !bytes.Equal(actual[:], hash) {
+		return fmt.Errorf("genesis fetched from %s has hash %X, expected genesis-sha256 %X",
+			cfg.GenesisURL, actual, hash)
+	}
+	if _, err := types.GenesisDocFromJSON(bz); err != nil {
+		return fmt.Errorf("invalid genesis fetched from %s: %w", cfg.GenesisURL, err)
+	}
+
+	if err := tmos.EnsureDir(filepath.Dir(cfg.GenesisFile()), 0700); err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(cfg.GenesisFile(), bz, 0644)
+}
+
 type NodeMetrics struct {
 	consensus *consensus.Metrics
 	eventlog  *eventlog.Metrics
@@ -755,20 +871,24 @@ func NoOpMetricsProvider() *NodeMetrics {
 }
 
 // defaultMetricsProvider returns Metrics build using Prometheus client library
-// if Prometheus is enabled. Otherwise, it returns no-op Metrics.
+// if Prometheus is enabled. Otherwise, it returns no-op Metrics. The metrics
+// are labeled with the chain ID and the constant labels of the config.
 func DefaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 	return func(chainID string) *NodeMetrics {
 		if cfg.Prometheus {
+			// the constant labels are checked by ValidateBasic
+			constLabels, _ := cfg.ConstLabelsAndValues()
+			labels := append([]string{config.MetricsLabelChainID, chainID}, constLabels...)
 			return &NodeMetrics{
-				consensus: consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				eventlog:  eventlog.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				indexer:   indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				mempool:   mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				p2p:       p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				proxy:     proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				state:     sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				statesync: statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				evidence:  evidence.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				consensus: consensus.PrometheusMetrics(cfg.Namespace, labels...),
+				eventlog:  eventlog.PrometheusMetrics(cfg.Namespace, labels...),
+				indexer:   indexer.PrometheusMetrics(cfg.Namespace, labels...),
+				mempool:   mempool.PrometheusMetrics(cfg.Namespace, labels...),
+				p2p:       p2p.PrometheusMetrics(cfg.Namespace, labels...),
+				proxy:     proxy.PrometheusMetrics(cfg.Namespace, labels...),
+				state:     sm.PrometheusMetrics(cfg.Namespace, labels...),
+				statesync: statesync.PrometheusMetrics(cfg.Namespace, labels...),
+				evidence:  evidence.PrometheusMetrics(cfg.Namespace, labels...),
 			}
 		}
 		return NoOpMetricsProvider()
@@ -807,7 +927,8 @@ func loadStateFromDBOrGenesisDocProvider(stateStore sm.Store, genDoc *types.Gene
 
 func getRouterConfig(conf *config.Config, appClient abciclient.Client) p2p.RouterOptions {
 	opts := p2p.RouterOptions{
-		QueueType: conf.P2P.QueueType,
+		QueueType:    conf.P2P.QueueType,
+		DrainTimeout: conf.P2P.DrainTimeout,
 	}
 
 	if conf.FilterPeers && appClient != nil {
@@ -2,11 +2,16 @@ package node
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +36,7 @@ import (
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
@@ -575,11 +581,12 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	pb, err := block.ToProto()
 	require.NoError(t, err)
 
-	// require that the header and commit be the max possible size
+	// require that the header be the max possible size. The commit is smaller
+	// than the max, since ed25519 signatures are smaller than BLS12-381 ones.
 	require.Equal(t, int64(pb.Header.Size()), types.MaxHeaderBytes)
-	require.Equal(t, int64(pb.LastCommit.Size()), types.MaxCommitBytes(types.MaxVotesCount))
+	require.LessOrEqual(t, int64(pb.LastCommit.Size()), types.MaxCommitBytes(types.MaxVotesCount))
 	// make sure that the block is less than the max possible size
-	assert.Equal(t, int64(pb.Size()), maxBytes)
+	assert.LessOrEqual(t, int64(pb.Size()), maxBytes)
 	// because of the proto overhead we expect the part set bytes to be equal or
 	// less than the pb block size
 	assert.LessOrEqual(t, partSet.ByteSize(), int64(pb.Size()))
@@ -647,7 +654,7 @@ func TestNodeSetEventSink(t *testing.T) {
 		genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
 		require.NoError(t, err)
 
-		eventSinks, err := sink.EventSinksFromConfig(cfg, config.DefaultDBProvider, genDoc.ChainID)
+		eventSinks, err := sink.EventSinksFromConfig(cfg, config.DefaultDBProvider, genDoc.ChainID, indexer.NopMetrics())
 		require.NoError(t, err)
 
 		return eventSinks
@@ -786,3 +793,38 @@ func loadStatefromGenesis(ctx context.Context, t *testing.T) sm.State {
 
 	return state
 }
+
+func TestFetchGenesisFile(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), "fetch_genesis")
+	require.NoError(t, err)
+	genesis, err := os.ReadFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(cfg.GenesisFile()))
+
+	var requests int
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(genesis)
+	}))
+	defer srv.Close()
+
+	cfg.GenesisURL = srv.URL
+	cfg.GenesisSHA256 = strings.Repeat("00", sha256.Size)
+	err = fetchGenesisFile(cfg, srv.Client())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected genesis-sha256")
+	require.False(t, tmos.FileExists(cfg.GenesisFile()))
+
+	hash := sha256.Sum256(genesis)
+	cfg.GenesisSHA256 = hex.EncodeToString(hash[:])
+	require.NoError(t, fetchGenesisFile(cfg, srv.Client()))
+	cached, err := os.ReadFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	require.Equal(t, genesis, cached)
+
+	// the cached genesis is loaded without fetching it again
+	genDoc, err := defaultGenesisDocProviderFunc(cfg)()
+	require.NoError(t, err)
+	require.Equal(t, cfg.ChainID(), genDoc.ChainID)
+	require.Equal(t, 2, requests)
+}
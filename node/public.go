@@ -6,7 +6,10 @@ import (
 	"fmt"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/mempool"
+	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/privval"
@@ -27,6 +30,65 @@ func NewDefault(
 	return newDefaultNode(ctx, conf, logger, restartCh)
 }
 
+// Option sets an optional parameter on a node constructed by New.
+type Option func(*nodeOptions)
+
+type nodeOptions struct {
+	validateBlockExtension sm.ValidateBlockExtension
+	validatorSetChanged    []sm.ValidatorSetChangeHook
+	mempoolOptions         []mempool.TxMempoolOption
+}
+
+// WithValidateBlockExtension makes the node reject blocks for which ext
+// returns an error, on top of the built-in block validation, e.g. to enforce
+// application specific limits. ext is run on every block validated by the
+// node: proposals, whether proposed by the node or received, blocks before
+// they are committed, and blocks fetched during block sync.
+//
+// ext decides which blocks are valid, so it must be deterministic, and be the
+// same on all nodes of the network: validators running different rules
+// disagree on blocks, which can halt the chain.
+func WithValidateBlockExtension(ext func(block *types.Block) error) Option {
+	return func(opts *nodeOptions) {
+		opts.validateBlockExtension = ext
+	}
+}
+
+// WithValidatorSetChangeHook registers hook to be called whenever a committed
+// block changes the active validator set. height is the first height at which
+// newVals is active, and oldVals is the set that was active at the previous
+// height. The hook is called synchronously by the block executor and must not
+// block. The option may be passed several times to register several hooks.
+func WithValidatorSetChangeHook(hook func(height int64, oldVals, newVals *types.ValidatorSet)) Option {
+	return func(opts *nodeOptions) {
+		opts.validatorSetChanged = append(opts.validatorSetChanged, hook)
+	}
+}
+
+// WithMempoolTxComparator sets a tiebreak used by the mempool to order
+// transactions of equal priority when reaping them for a block, e.g. to prefer
+// smaller transactions. compare returns a negative number if a should be
+// reaped before b, and a positive number if b should be reaped before a.
+// Without one, or if it returns zero, the transaction seen first is reaped
+// first.
+func WithMempoolTxComparator(compare func(a, b types.Tx) int) Option {
+	return func(opts *nodeOptions) {
+		opts.mempoolOptions = append(opts.mempoolOptions, mempool.WithTxComparator(compare))
+	}
+}
+
+// WithMempoolTxExpiryHeight lets transactions declare the last height at
+// which they may be included in a block, e.g. for a "valid until block N"
+// semantic. expiryHeight is called after CheckTx, and the mempool drops the
+// transaction once a block at the returned height has been committed, even if
+// its TTL has not elapsed. Returning zero means the transaction does not
+// expire by height.
+func WithMempoolTxExpiryHeight(expiryHeight func(types.Tx, *abci.ResponseCheckTx) int64) Option {
+	return func(opts *nodeOptions) {
+		opts.mempoolOptions = append(opts.mempoolOptions, mempool.WithTxExpiryHeight(expiryHeight))
+	}
+}
+
 // New constructs a tendermint node. The ClientCreator makes it
 // possible to construct an ABCI application that runs in the same
 // process as the tendermint node.  The final option is a pointer to a
@@ -42,6 +104,7 @@ func New(
 	gen *types.GenesisDoc,
 	tracerProviderOptions []trace.TracerProviderOption,
 	nodeMetrics *NodeMetrics,
+	options ...Option,
 ) (service.Service, error) {
 	nodeKey, err := types.LoadOrGenNodeKey(conf.NodeKeyFile())
 	if err != nil {
@@ -62,8 +125,11 @@ func New(
 		if err != nil {
 			return nil, err
 		}
+		if err := setPrivvalLock(conf, pval); err != nil {
+			return nil, err
+		}
 
-		return makeNode(
+		n, err := makeNode(
 			ctx,
 			conf,
 			restartCh,
@@ -75,7 +141,13 @@ func New(
 			logger,
 			tracerProviderOptions,
 			nodeMetrics,
+			options...,
 		)
+		if err != nil {
+			_ = pval.Close()
+			return nil, err
+		}
+		return n, nil
 	case config.ModeSeed:
 		return makeSeedNode(
 			ctx,
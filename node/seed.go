@@ -127,7 +127,7 @@ func makeSeedNode(
 	}
 	closers = append(closers, dbCloser)
 
-	eventSinks, err := sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID)
+	eventSinks, err := sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID, nodeMetrics.indexer)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
@@ -194,6 +194,7 @@ func (n *seedNodeImpl) OnStart(ctx context.Context) error {
 			}
 		}()
 	}
+	startHeapProfiler(ctx, n.config, n.logger.With("module", "heapwatch"))
 
 	now := tmtime.Now()
 	genTime := n.genesisDoc.GenesisTime
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/libs/heapwatch"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
@@ -27,6 +29,7 @@ import (
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
@@ -79,9 +82,16 @@ func initDBs(
 	if err != nil {
 		return nil, nil, func() error { return nil }, fmt.Errorf("unable to initialize blockstore: %w", err)
 	}
-	closers := []closer{}
-	blockStore := store.NewBlockStore(blockStoreDB)
-	closers = append(closers, blockStoreDB.Close)
+	closers := []closer{blockStoreDB.Close}
+
+	compression, err := store.ParseCompression(cfg.BlockCompression)
+	if err != nil {
+		return nil, nil, makeCloser(closers), err
+	}
+	blockStore := store.NewBlockStore(blockStoreDB,
+		store.WithCompression(compression),
+		store.WithBlockMetaCacheSize(cfg.BlockMetaCacheSize),
+	)
 
 	stateDB, err := dbProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
@@ -130,6 +140,26 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger log.Logger,
 	}
 }
 
+// startHeapProfiler starts capturing heap profiles when the memory usage of
+// the node crosses one of the configured thresholds, until ctx is done.
+func startHeapProfiler(ctx context.Context, cfg *config.Config, logger log.Logger) {
+	if !cfg.Instrumentation.HeapProfilingEnabled() {
+		return
+	}
+	dir := cfg.Instrumentation.HeapProfileDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cfg.RootDir, dir)
+	}
+	logger.Info("Starting heap profiler", "dir", dir)
+	go heapwatch.New(logger, heapwatch.Config{
+		Dir:           dir,
+		RSSThreshold:  cfg.Instrumentation.HeapProfileRSSThreshold,
+		HeapThreshold: cfg.Instrumentation.HeapProfileHeapThreshold,
+		MinInterval:   cfg.Instrumentation.HeapProfileMinInterval,
+		MaxProfiles:   cfg.Instrumentation.HeapProfileRetention,
+	}).Run(ctx)
+}
+
 func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	if state.Validators.Size() > 1 {
 		return false
@@ -146,6 +176,7 @@ func createMempoolReactor(
 	memplMetrics *mempool.Metrics,
 	peerEvents p2p.PeerEventSubscriber,
 	peerManager *p2p.PeerManager,
+	options ...mempool.TxMempoolOption,
 ) (*mempool.Reactor, mempool.Mempool) {
 	logger = logger.With("module", "mempool")
 
@@ -154,9 +185,11 @@ func createMempoolReactor(
 		cfg.Mempool,
 		appClient,
 		peerManager,
-		mempool.WithMetrics(memplMetrics),
-		mempool.WithPreCheck(sm.TxPreCheckFromStore(store)),
-		mempool.WithPostCheck(sm.TxPostCheckFromStore(store)),
+		append([]mempool.TxMempoolOption{
+			mempool.WithMetrics(memplMetrics),
+			mempool.WithPreCheck(sm.TxPreCheckFromStore(store)),
+			mempool.WithPostCheck(sm.TxPostCheckFromStore(store)),
+		}, options...)...,
 	)
 
 	reactor := mempool.NewReactor(
@@ -190,7 +223,8 @@ func createEvidenceReactor(
 
 	logger = logger.With("module", "evidence")
 
-	evidencePool := evidence.NewPool(logger, evidenceDB, store, blockStore, metrics, eventBus)
+	evidencePool := evidence.NewPool(logger, evidenceDB, store, blockStore, metrics, eventBus,
+		evidence.WithRetention(cfg.Evidence.Retention))
 	evidenceReactor := evidence.NewReactor(logger, peerEvents, evidencePool)
 
 	return evidenceReactor, evidencePool, evidenceDB.Close, nil
@@ -228,12 +262,25 @@ func createPeerManager(
 		SelfAddress:            selfAddr,
 		MaxConnected:           maxConns,
 		MaxConnectedUpgrade:    maxUpgradeConns,
+		MaxInbound:             cfg.P2P.MaxInboundConnections,
+		MaxOutbound:            cfg.P2P.MaxOutboundConnections,
+		ReservedSlots:          cfg.P2P.ReservedConnections,
 		MaxPeers:               maxUpgradeConns + 2*maxConns,
 		MinRetryTime:           250 * time.Millisecond,
 		MaxRetryTime:           2 * time.Minute,
 		MaxRetryTimePersistent: 2 * time.Minute,
 		RetryTimeJitter:        5 * time.Second,
 		PrivatePeers:           privatePeerIDs,
+		ScoreDecayInterval:     cfg.P2P.PeerScoreDecayInterval,
+		BanThreshold:           cfg.P2P.PeerBanThreshold,
+		MinBanDuration:         cfg.P2P.PeerMinBanDuration,
+		MaxBanDuration:         cfg.P2P.PeerMaxBanDuration,
+	}
+	if options.AllowedCIDRs, err = cfg.P2P.AllowedIPNets(); err != nil {
+		return nil, func() error { return nil }, fmt.Errorf("invalid allowed CIDRs: %w", err)
+	}
+	if options.DeniedCIDRs, err = cfg.P2P.DeniedIPNets(); err != nil {
+		return nil, func() error { return nil }, fmt.Errorf("invalid denied CIDRs: %w", err)
 	}
 
 	peers := []p2p.NodeAddress{}
@@ -295,18 +342,40 @@ func createRouter(
 	transportConf.SendRate = cfg.P2P.SendRate
 	transportConf.RecvRate = cfg.P2P.RecvRate
 	transportConf.MaxPacketMsgPayloadSize = cfg.P2P.MaxPacketMsgPayloadSize
-	transport := p2p.NewMConnTransport(
-		p2pLogger, transportConf, []*p2p.ChannelDescriptor{},
-		p2p.MConnTransportOptions{
-			MaxAcceptedConnections: uint32(cfg.P2P.MaxConnections),
-		},
-	)
 
 	ep, err := p2p.NewEndpoint(nodeKey.ID.AddressString(cfg.P2P.ListenAddress))
 	if err != nil {
 		return nil, err
 	}
 
+	var transport p2p.Transport
+	switch cfg.P2P.Transport {
+	case "quic":
+		transport, err = p2p.NewQUICTransport(
+			p2pLogger, transportConf, []*p2p.ChannelDescriptor{},
+			p2p.QUICTransportOptions{
+				MaxAcceptedConnections: uint32(cfg.P2P.MaxConnections),
+				HandshakeTimeout:       cfg.P2P.HandshakeTimeout,
+				AuthTimeout:            cfg.P2P.AuthTimeout,
+				Metrics:                p2pMetrics,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ep.Protocol = p2p.QUICProtocol
+	default:
+		transport = p2p.NewMConnTransport(
+			p2pLogger, transportConf, []*p2p.ChannelDescriptor{},
+			p2p.MConnTransportOptions{
+				MaxAcceptedConnections: uint32(cfg.P2P.MaxConnections),
+				HandshakeTimeout:       cfg.P2P.HandshakeTimeout,
+				AuthTimeout:            cfg.P2P.AuthTimeout,
+				Metrics:                p2pMetrics,
+			},
+		)
+	}
+
 	return p2p.NewRouter(
 		p2pLogger,
 		p2pMetrics,
@@ -340,9 +409,10 @@ func makeNodeInfo(
 			Block: versionInfo.Block,
 			App:   versionInfo.App,
 		},
-		NodeID:  nodeKey.ID,
-		Network: genDoc.ChainID,
-		Version: version.TMVersion,
+		NodeID:             nodeKey.ID,
+		Network:            genDoc.ChainID,
+		Version:            version.TMVersion,
+		MerkleHashFunction: genDoc.MerkleHashFunction,
 		Channels: []byte{
 			byte(blocksync.BlockSyncChannel),
 			byte(consensus.StateChannel),
@@ -372,6 +442,12 @@ func makeNodeInfo(
 		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
 	}
 
+	rotation, err := loadNodeKeyRotation(cfg, nodeKey)
+	if err != nil {
+		return nodeInfo, err
+	}
+	nodeInfo.KeyRotation = rotation
+
 	return nodeInfo, nodeInfo.Validate()
 }
 
@@ -387,9 +463,10 @@ func makeSeedNodeInfo(
 			Block: state.Version.Consensus.Block,
 			App:   state.Version.Consensus.App,
 		},
-		NodeID:  nodeKey.ID,
-		Network: genDoc.ChainID,
-		Version: version.TMVersion,
+		NodeID:             nodeKey.ID,
+		Network:            genDoc.ChainID,
+		Version:            version.TMVersion,
+		MerkleHashFunction: genDoc.MerkleHashFunction,
 		Channels: []byte{
 			pex.PexChannel,
 		},
@@ -405,9 +482,32 @@ func makeSeedNodeInfo(
 		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
 	}
 
+	rotation, err := loadNodeKeyRotation(cfg, nodeKey)
+	if err != nil {
+		return nodeInfo, err
+	}
+	nodeInfo.KeyRotation = rotation
+
 	return nodeInfo, nodeInfo.Validate()
 }
 
+// loadNodeKeyRotation loads the rotation of the node key to its current key,
+// if it was rotated with a grace period which has not expired yet.
+func loadNodeKeyRotation(cfg *config.Config, nodeKey types.NodeKey) (*types.NodeKeyRotation, error) {
+	path := cfg.NodeKeyRotationFile()
+	if !tmos.FileExists(path) {
+		return nil, nil
+	}
+	rotation, err := types.LoadNodeKeyRotation(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading node key rotation %s: %w", path, err)
+	}
+	if rotation.NodeID != nodeKey.ID || rotation.IsExpired(time.Now()) {
+		return nil, nil
+	}
+	return rotation, nil
+}
+
 func createAndStartPrivValidatorSocketClient(
 	ctx context.Context,
 	listenAddr, chainID string,
@@ -472,12 +572,33 @@ func makeDefaultPrivval(conf *config.Config) (*privval.FilePV, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := setPrivvalLock(conf, pval); err != nil {
+			return nil, err
+		}
 		return pval, nil
 	}
 
 	return nil, nil
 }
 
+// setPrivvalLock sets the signer lock configured for the FilePV, if any.
+// Validators acquire it right away, so that a second node started with the same
+// key fails to start, rather than at its first signature.
+func setPrivvalLock(conf *config.Config, pval *privval.FilePV) error {
+	lockFile := conf.PrivValidator.LockFile()
+	if lockFile == "" {
+		return nil
+	}
+	lock := privval.NewFileLock(lockFile, privval.DefaultLockLease)
+	pval.SetLock(lock)
+	if conf.Mode == config.ModeValidator {
+		if err := lock.Lock(); err != nil {
+			return fmt.Errorf("failed to acquire signer lock %s: %w", lockFile, err)
+		}
+	}
+	return nil
+}
+
 func createPrivval(ctx context.Context, logger log.Logger, conf *config.Config, genDoc *types.GenesisDoc, defaultPV *privval.FilePV) (types.PrivValidator, error) {
 	if conf.PrivValidator.ListenAddr != "" {
 		protocol, _ := tmnet.ProtocolAndAddress(conf.PrivValidator.ListenAddr)
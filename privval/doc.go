@@ -25,5 +25,12 @@ SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
 
+When a signer connects, SignerClient negotiates the optional protocol features
+it supports. Signers whose PrivValidator implements TwoPhaseSigner sign votes
+and proposals in two phases: they first return a commitment, e.g. to a nonce,
+and then the signature. Older signers keep using single-phase signing. The
+gRPC SignerClient, in privval/grpc, negotiates the same features before its
+first signing request.
+
 */
 package privval
@@ -22,6 +22,10 @@ var (
 	ErrWriteTimeout       = errors.New("endpoint write timed out")
 )
 
+// ErrTwoPhaseSigningUnsupported is returned by a signer that is asked to sign
+// in two phases, but whose PrivValidator does not implement TwoPhaseSigner.
+var ErrTwoPhaseSigningUnsupported = errors.New("two-phase signing is not supported")
+
 // RemoteSignerError allows (remote) validators to include meaningful error
 // descriptions in their reply.
 type RemoteSignerError struct {
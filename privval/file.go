@@ -12,6 +12,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/internal/jsontypes"
@@ -190,6 +191,8 @@ func (lss *FilePVLastSignState) Save() error {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	lock SignerLock
 }
 
 var _ types.PrivValidator = (*FilePV)(nil)
@@ -216,6 +219,8 @@ func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
 	switch keyType {
 	case types.ABCIPubKeyTypeSecp256k1:
 		return NewFilePV(secp256k1.GenPrivKey(), keyFilePath, stateFilePath), nil
+	case types.ABCIPubKeyTypeBls12381:
+		return NewFilePV(bls12381.GenPrivKey(), keyFilePath, stateFilePath), nil
 	case "", types.ABCIPubKeyTypeEd25519:
 		return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath), nil
 	default:
@@ -311,6 +316,9 @@ func (pv *FilePV) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	if err := pv.checkLock(); err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
 	if err := pv.signVote(chainID, vote); err != nil {
 		return fmt.Errorf("error signing vote: %w", err)
 	}
@@ -320,12 +328,29 @@ func (pv *FilePV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vo
 // SignProposal signs a canonical representation of the proposal, along with
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	if err := pv.checkLock(); err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
 	if err := pv.signProposal(chainID, proposal); err != nil {
 		return fmt.Errorf("error signing proposal: %w", err)
 	}
 	return nil
 }
 
+// SetLock sets a lock the FilePV acquires before signing, preventing another
+// process from signing with the same key. The lock is released by Close.
+func (pv *FilePV) SetLock(lock SignerLock) {
+	pv.lock = lock
+}
+
+// Close releases the lock set by SetLock, if any.
+func (pv *FilePV) Close() error {
+	if pv.lock == nil {
+		return nil
+	}
+	return pv.lock.Unlock()
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() error {
 	if err := pv.Key.Save(); err != nil {
@@ -354,6 +379,14 @@ func (pv *FilePV) String() string {
 
 //------------------------------------------------------------------------------------
 
+// checkLock acquires the lock set by SetLock, or checks it is still held.
+func (pv *FilePV) checkLock() error {
+	if pv.lock == nil {
+		return nil
+	}
+	return pv.lock.Lock()
+}
+
 // signVote checks if the vote is good to sign and sets the vote signature.
 // It may need to set the timestamp as well if the vote is otherwise the same as
 // a previously signed vote (ie. we crashed after signing but before the vote hit the WAL).
@@ -0,0 +1,242 @@
+package privval
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+)
+
+// DefaultLockLease is the lease duration of a FileLock created by the node.
+const DefaultLockLease = 15 * time.Second
+
+const (
+	// guardTimeout bounds how long a FileLock waits for another process to
+	// release the guard file, since it is waited for on the signing path.
+	guardTimeout = time.Second
+	guardRetry   = 10 * time.Millisecond
+)
+
+// SignerLock is an exclusive lock a FilePV holds while signing, preventing
+// another process from signing with the same key, e.g. when an operator
+// accidentally runs two nodes with the same validator key.
+type SignerLock interface {
+	// Lock acquires the lock, or checks that it is still held. It is called
+	// before every signature, so checking a held lock must be cheap, and must
+	// fail if another process holds the lock.
+	Lock() error
+
+	// Unlock releases the lock.
+	Unlock() error
+}
+
+// ErrLockHeld is returned by a SignerLock held by another process.
+type ErrLockHeld struct {
+	Holder  string
+	Expires time.Time
+}
+
+func (e ErrLockHeld) Error() string {
+	return fmt.Sprintf("signer lock is held by %s until %v", e.Holder, e.Expires)
+}
+
+// fileLease is the content of a FileLock file.
+type fileLease struct {
+	Owner    string    `json:"owner"`
+	Hostname string    `json:"hostname"`
+	PID      int       `json:"pid"`
+	Expires  time.Time `json:"expires"`
+}
+
+func (l fileLease) holder() string {
+	return fmt.Sprintf("pid %d on %s", l.PID, l.Hostname)
+}
+
+// FileLock is a SignerLock implemented as a lease stored in a file, which may
+// be on storage shared by several hosts.
+//
+// The lease is acquired by the first call to Lock, and then renewed in the
+// background while the lock is held, so that later calls don't access the
+// file. If the process holding it crashes, the lease is not renewed, and the
+// lock becomes stale and can be acquired by another process once the lease
+// expires.
+//
+// The lease is only read and written while holding an exclusive flock on a
+// guard file next to it, so that two processes finding the lease stale at the
+// same time can't both acquire it. The guard is released by the kernel if the
+// process crashes, and the guard file is never removed.
+type FileLock struct {
+	path  string
+	lease time.Duration
+	owner string
+
+	mtx     sync.Mutex
+	held    bool
+	expires time.Time // expiry of the last lease written
+	lost    error     // set if the last renewal failed
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+var _ SignerLock = (*FileLock)(nil)
+
+// NewFileLock returns a FileLock stored at path, with the given lease
+// duration.
+func NewFileLock(path string, lease time.Duration) *FileLock {
+	return &FileLock{
+		path:  path,
+		lease: lease,
+		owner: hex.EncodeToString(crypto.CRandBytes(16)),
+	}
+}
+
+// Lock acquires the lock if it is free or stale. If it is already held, Lock
+// only checks that the last renewal of the lease succeeded and that the lease
+// hasn't expired, and otherwise tries to acquire it again.
+func (fl *FileLock) Lock() error {
+	fl.mtx.Lock()
+	defer fl.mtx.Unlock()
+
+	if fl.held && fl.lost == nil && tmtime.Now().Before(fl.expires) {
+		return nil
+	}
+	if err := fl.acquire(); err != nil {
+		return err
+	}
+	if !fl.held {
+		fl.held = true
+		fl.stop = make(chan struct{})
+		fl.done = make(chan struct{})
+		go fl.renewRoutine(fl.stop, fl.done)
+	}
+	return nil
+}
+
+// Unlock stops renewing the lease and removes the lock file, unless the lease
+// was taken over by another process.
+func (fl *FileLock) Unlock() error {
+	fl.mtx.Lock()
+	if !fl.held {
+		fl.mtx.Unlock()
+		return nil
+	}
+	fl.held = false
+	close(fl.stop)
+	done := fl.done
+	fl.mtx.Unlock()
+	<-done
+
+	fl.mtx.Lock()
+	defer fl.mtx.Unlock()
+	return fl.guard(func() error {
+		lease, err := fl.read()
+		if err != nil || lease == nil || lease.Owner != fl.owner {
+			return err
+		}
+		return os.Remove(fl.path)
+	})
+}
+
+// acquire writes a new lease if the lock file is absent, stale or ours.
+func (fl *FileLock) acquire() error {
+	if err := fl.guard(fl.writeLease); err != nil {
+		fl.lost = err
+		return err
+	}
+	fl.lost = nil
+	return nil
+}
+
+func (fl *FileLock) writeLease() error {
+	lease, err := fl.read()
+	if err != nil {
+		return err
+	}
+	now := tmtime.Now()
+	if lease != nil && lease.Owner != fl.owner && now.Before(lease.Expires) {
+		return ErrLockHeld{Holder: lease.holder(), Expires: lease.Expires}
+	}
+
+	hostname, _ := os.Hostname()
+	expires := now.Add(fl.lease)
+	bz, err := json.Marshal(fileLease{
+		Owner:    fl.owner,
+		Hostname: hostname,
+		PID:      os.Getpid(),
+		Expires:  expires,
+	})
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(fl.path, bz, 0600); err != nil {
+		return fmt.Errorf("writing signer lock: %w", err)
+	}
+	fl.expires = expires
+	return nil
+}
+
+// guard calls f while holding an exclusive flock on the guard file of the lock,
+// retrying for up to guardTimeout while another process holds it.
+func (fl *FileLock) guard(f func() error) error {
+	g, err := os.OpenFile(fl.path+".guard", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("opening signer lock guard: %w", err)
+	}
+	// Closing the file releases the flock.
+	defer g.Close()
+
+	deadline := time.Now().Add(guardTimeout)
+	for {
+		ok, err := tryLockFile(g)
+		if err != nil {
+			return fmt.Errorf("locking signer lock guard: %w", err)
+		}
+		if ok {
+			return f()
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("locking signer lock guard: still held by another process after %v", guardTimeout)
+		}
+		time.Sleep(guardRetry)
+	}
+}
+
+// read returns the lease stored in the lock file, or nil if there is none.
+func (fl *FileLock) read() (*fileLease, error) {
+	bz, err := os.ReadFile(fl.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading signer lock: %w", err)
+	}
+	var lease fileLease
+	if err := json.Unmarshal(bz, &lease); err != nil {
+		return nil, fmt.Errorf("reading signer lock %v: %w", fl.path, err)
+	}
+	return &lease, nil
+}
+
+func (fl *FileLock) renewRoutine(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(fl.lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			fl.mtx.Lock()
+			// If the lease was lost, the next call to Lock reports it.
+			_ = fl.acquire()
+			fl.mtx.Unlock()
+		}
+	}
+}
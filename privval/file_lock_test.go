@@ -0,0 +1,199 @@
+package privval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.lock")
+
+	first := NewFileLock(path, time.Minute)
+	second := NewFileLock(path, time.Minute)
+
+	require.NoError(t, first.Lock())
+	// Locking again checks the lock is still held.
+	require.NoError(t, first.Lock())
+
+	var lockErr ErrLockHeld
+	require.True(t, errors.As(second.Lock(), &lockErr))
+	assert.Contains(t, lockErr.Holder, "pid")
+
+	require.NoError(t, first.Unlock())
+	assert.NoFileExists(t, path)
+	require.NoError(t, second.Lock())
+
+	// The lease taken over by the second lock is reported to the first one,
+	// and isn't removed by it.
+	require.True(t, errors.As(first.Lock(), &lockErr))
+	require.NoError(t, first.Unlock())
+	assert.FileExists(t, path)
+	require.NoError(t, second.Unlock())
+}
+
+func TestFileLockStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.lock")
+
+	// Simulate a process that crashed without releasing the lock.
+	bz, err := json.Marshal(fileLease{
+		Owner:    "crashed",
+		Hostname: "localhost",
+		PID:      1,
+		Expires:  time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bz, 0600))
+
+	lock := NewFileLock(path, time.Minute)
+	require.NoError(t, lock.Lock())
+	require.NoError(t, lock.Unlock())
+}
+
+func TestFileLockStaleRace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.lock")
+	bz, err := json.Marshal(fileLease{
+		Owner:    "crashed",
+		Hostname: "localhost",
+		PID:      1,
+		Expires:  time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	// Locks racing to take over a stale lease never both acquire it.
+	for i := 0; i < 100; i++ {
+		require.NoError(t, os.WriteFile(path, bz, 0600))
+
+		locks := make([]*FileLock, 8)
+		for j := range locks {
+			locks[j] = NewFileLock(path, time.Minute)
+		}
+		errs := make(chan error, len(locks))
+		start := make(chan struct{})
+		for _, lock := range locks {
+			go func(lock *FileLock) {
+				<-start
+				errs <- lock.Lock()
+			}(lock)
+		}
+		close(start)
+
+		var acquired int
+		for range locks {
+			var lockErr ErrLockHeld
+			if err := <-errs; err == nil {
+				acquired++
+			} else {
+				require.True(t, errors.As(err, &lockErr), err)
+			}
+		}
+		require.Equal(t, 1, acquired)
+
+		for _, lock := range locks {
+			require.NoError(t, lock.Unlock())
+		}
+	}
+
+	// The lease isn't read or written while another process holds the guard.
+	g, err := os.OpenFile(path+".guard", os.O_CREATE|os.O_RDWR, 0600)
+	require.NoError(t, err)
+	ok, err := tryLockFile(g)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A guard held for longer than guardTimeout makes Lock fail instead of
+	// blocking the signer.
+	lock := NewFileLock(path, time.Minute)
+	require.Error(t, lock.Lock())
+
+	errs := make(chan error, 1)
+	go func() { errs <- lock.Lock() }()
+	select {
+	case <-errs:
+		t.Fatal("lock acquired while the guard was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, g.Close())
+	require.NoError(t, <-errs)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestFileLockRenew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.lock")
+
+	first := NewFileLock(path, 300*time.Millisecond)
+	second := NewFileLock(path, time.Minute)
+
+	require.NoError(t, first.Lock())
+	time.Sleep(time.Second)
+
+	// The lease of the first lock was renewed in the background.
+	var lockErr ErrLockHeld
+	require.True(t, errors.As(second.Lock(), &lockErr))
+	require.NoError(t, first.Unlock())
+}
+
+func TestFileLockHeld(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signer.lock")
+
+	lock := NewFileLock(path, 300*time.Millisecond)
+	require.NoError(t, lock.Lock())
+
+	// Checking a held lock doesn't access the file.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, lock.Lock())
+
+	// A lease taken over by another process is detected by the renewal.
+	bz, err := json.Marshal(fileLease{
+		Owner:    "other",
+		Hostname: "localhost",
+		PID:      1,
+		Expires:  time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bz, 0600))
+	time.Sleep(300 * time.Millisecond)
+
+	var lockErr ErrLockHeld
+	require.True(t, errors.As(lock.Lock(), &lockErr))
+	require.NoError(t, lock.Unlock())
+	assert.FileExists(t, path)
+}
+
+func TestFilePVLock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "signer.lock")
+	privVal, _, _ := newTestFilePV(t)
+	privVal.SetLock(NewFileLock(path, time.Minute))
+
+	other := NewFileLock(path, time.Minute)
+	require.NoError(t, other.Lock())
+
+	hash := tmrand.Bytes(crypto.HashSize)
+	blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 1}}
+	vote := newVote(privVal.Key.Address, 0, 10, 1, tmproto.PrevoteType, blockID, nil)
+	proposal := newProposal(10, 1, blockID, time.Now())
+
+	var lockErr ErrLockHeld
+	assert.True(t, errors.As(privVal.SignVote(ctx, "mychainid", vote.ToProto()), &lockErr))
+	assert.True(t, errors.As(privVal.SignProposal(ctx, "mychainid", proposal.ToProto()), &lockErr))
+
+	require.NoError(t, other.Unlock())
+	require.NoError(t, privVal.SignVote(ctx, "mychainid", vote.ToProto()))
+	require.NoError(t, privVal.Close())
+	assert.NoFileExists(t, path)
+}
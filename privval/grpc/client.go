@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"sync"
 
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/tendermint/tendermint/crypto"
@@ -22,6 +24,10 @@ type SignerClient struct {
 	client  privvalproto.PrivValidatorAPIClient
 	conn    *grpc.ClientConn
 	chainID string
+
+	// capabilities of the signer, negotiated before the first signing request
+	mtx          sync.Mutex
+	capabilities *privvalproto.CapabilitiesResponse
 }
 
 var _ types.PrivValidator = (*SignerClient)(nil)
@@ -70,9 +76,19 @@ func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 	return pk, nil
 }
 
-// SignVote requests a remote signer to sign a vote
+// SignVote requests a remote signer to sign a vote. If the signer supports
+// two-phase signing, the vote is prepared first.
 func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
-	resp, err := sc.client.SignVote(ctx, &privvalproto.SignVoteRequest{ChainId: sc.chainID, Vote: vote})
+	commitment, err := sc.prepare(ctx, func() (*privvalproto.SignCommitmentResponse, error) {
+		return sc.client.PrepareSignVote(ctx, &privvalproto.PrepareSignVoteRequest{ChainId: sc.chainID, Vote: vote})
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("Client PrepareSignVote", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	resp, err := sc.client.SignVote(ctx, &privvalproto.SignVoteRequest{ChainId: sc.chainID, Vote: vote, Commitment: commitment})
 	if err != nil {
 		errStatus, _ := status.FromError(err)
 		sc.logger.Error("Client SignVote", "err", errStatus.Message())
@@ -84,10 +100,20 @@ func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmpr
 	return nil
 }
 
-// SignProposal requests a remote signer to sign a proposal
+// SignProposal requests a remote signer to sign a proposal. If the signer
+// supports two-phase signing, the proposal is prepared first.
 func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	commitment, err := sc.prepare(ctx, func() (*privvalproto.SignCommitmentResponse, error) {
+		return sc.client.PrepareSignProposal(ctx, &privvalproto.PrepareSignProposalRequest{ChainId: chainID, Proposal: proposal})
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::PrepareSignProposal", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
 	resp, err := sc.client.SignProposal(
-		ctx, &privvalproto.SignProposalRequest{ChainId: chainID, Proposal: proposal})
+		ctx, &privvalproto.SignProposalRequest{ChainId: chainID, Proposal: proposal, Commitment: commitment})
 
 	if err != nil {
 		errStatus, _ := status.FromError(err)
@@ -99,3 +125,50 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// prepare runs the first phase of two-phase signing with prepareFn, and
+// returns the commitment of the signer. It returns a nil commitment, without
+// calling prepareFn, if the signer doesn't support two-phase signing.
+func (sc *SignerClient) prepare(
+	ctx context.Context,
+	prepareFn func() (*privvalproto.SignCommitmentResponse, error),
+) ([]byte, error) {
+	capabilities, err := sc.getCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !capabilities.TwoPhaseSigning {
+		return nil, nil
+	}
+
+	resp, err := prepareFn()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Commitment, nil
+}
+
+// getCapabilities returns the optional protocol features supported by the
+// signer, asking it the first time. Signers that predate capability
+// negotiation don't implement GetCapabilities, and are assumed to support
+// none.
+func (sc *SignerClient) getCapabilities(ctx context.Context) (*privvalproto.CapabilitiesResponse, error) {
+	sc.mtx.Lock()
+	defer sc.mtx.Unlock()
+
+	if sc.capabilities != nil {
+		return sc.capabilities, nil
+	}
+
+	resp, err := sc.client.GetCapabilities(ctx, &privvalproto.CapabilitiesRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		resp = &privvalproto.CapabilitiesResponse{}
+	case err != nil:
+		return nil, err
+	}
+
+	sc.logger.Info("SignerClient: Negotiated capabilities", "two_phase_signing", resp.TwoPhaseSigning)
+	sc.capabilities = resp
+	return resp, nil
+}
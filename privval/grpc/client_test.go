@@ -1,7 +1,9 @@
 package grpc_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"net"
 	"testing"
 	"time"
@@ -169,3 +171,74 @@ func TestSignerClient_SignProposal(t *testing.T) {
 
 	assert.Equal(t, pbWant.Signature, pbHave.Signature)
 }
+
+// twoPhaseMockPV is a privval.TwoPhaseSigner signing with a MockPV, which only
+// signs votes and proposals prepared with the expected commitment.
+type twoPhaseMockPV struct {
+	types.MockPV
+	prepared int
+}
+
+func (pv *twoPhaseMockPV) commitment(signBytes []byte) []byte {
+	return crypto.Checksum(signBytes)
+}
+
+func (pv *twoPhaseMockPV) PrepareSignVote(_ context.Context, chainID string, vote *tmproto.Vote) ([]byte, error) {
+	pv.prepared++
+	return pv.commitment(types.VoteSignBytes(chainID, vote)), nil
+}
+
+func (pv *twoPhaseMockPV) PrepareSignProposal(_ context.Context, chainID string, proposal *tmproto.Proposal) ([]byte, error) {
+	pv.prepared++
+	return pv.commitment(types.ProposalSignBytes(chainID, proposal)), nil
+}
+
+func (pv *twoPhaseMockPV) SignVoteWithCommitment(ctx context.Context, chainID string, vote *tmproto.Vote, commitment []byte) error {
+	if !bytes.Equal(commitment, pv.commitment(types.VoteSignBytes(chainID, vote))) {
+		return errors.New("vote was not prepared")
+	}
+	return pv.MockPV.SignVote(ctx, chainID, vote)
+}
+
+func (pv *twoPhaseMockPV) SignProposalWithCommitment(ctx context.Context, chainID string, proposal *tmproto.Proposal, commitment []byte) error {
+	if !bytes.Equal(commitment, pv.commitment(types.ProposalSignBytes(chainID, proposal))) {
+		return errors.New("proposal was not prepared")
+	}
+	return pv.MockPV.SignProposal(ctx, chainID, proposal)
+}
+
+func TestSignerClient_TwoPhase(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockPV := types.NewMockPV()
+	pv := &twoPhaseMockPV{MockPV: mockPV}
+	logger := log.NewTestingLogger(t)
+	srv, dialer := dialer(t, pv, logger)
+	defer srv.Stop()
+
+	conn, err := grpc.DialContext(ctx, "",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(dialer),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client, err := tmgrpc.NewSignerClient(conn, chainID, logger)
+	require.NoError(t, err)
+
+	hash := tmrand.Bytes(crypto.HashSize)
+	blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}}
+	want := &types.Vote{Type: tmproto.PrecommitType, Height: 1, Round: 2, BlockID: blockID, Timestamp: time.Now()}
+	have := *want
+
+	pbWant, pbHave := want.ToProto(), have.ToProto()
+	require.NoError(t, mockPV.SignVote(ctx, chainID, pbWant))
+	require.NoError(t, client.SignVote(ctx, chainID, pbHave))
+	assert.Equal(t, pbWant.Signature, pbHave.Signature)
+
+	proposal := &types.Proposal{Type: tmproto.ProposalType, Height: 1, Round: 2, POLRound: -1, BlockID: blockID, Timestamp: time.Now()}
+	require.NoError(t, client.SignProposal(ctx, chainID, proposal.ToProto()))
+
+	assert.Equal(t, 2, pv.prepared)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	"github.com/tendermint/tendermint/types"
 )
@@ -57,7 +58,14 @@ func (ss *SignerServer) GetPubKey(ctx context.Context, req *privvalproto.PubKeyR
 func (ss *SignerServer) SignVote(ctx context.Context, req *privvalproto.SignVoteRequest) (*privvalproto.SignedVoteResponse, error) {
 	vote := req.Vote
 
-	err := ss.privVal.SignVote(ctx, req.ChainId, vote)
+	var err error
+	if len(req.Commitment) == 0 {
+		err = ss.privVal.SignVote(ctx, req.ChainId, vote)
+	} else if tps, ok := ss.privVal.(privval.TwoPhaseSigner); ok {
+		err = tps.SignVoteWithCommitment(ctx, req.ChainId, vote, req.Commitment)
+	} else {
+		err = privval.ErrTwoPhaseSigningUnsupported
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "error signing vote: %v", err)
 	}
@@ -72,7 +80,14 @@ func (ss *SignerServer) SignVote(ctx context.Context, req *privvalproto.SignVote
 func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.SignProposalRequest) (*privvalproto.SignedProposalResponse, error) {
 	proposal := req.Proposal
 
-	err := ss.privVal.SignProposal(ctx, req.ChainId, proposal)
+	var err error
+	if len(req.Commitment) == 0 {
+		err = ss.privVal.SignProposal(ctx, req.ChainId, proposal)
+	} else if tps, ok := ss.privVal.(privval.TwoPhaseSigner); ok {
+		err = tps.SignProposalWithCommitment(ctx, req.ChainId, proposal, req.Commitment)
+	} else {
+		err = privval.ErrTwoPhaseSigningUnsupported
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "error signing proposal: %v", err)
 	}
@@ -81,3 +96,48 @@ func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.Sign
 
 	return &privvalproto.SignedProposalResponse{Proposal: *proposal}, nil
 }
+
+// GetCapabilities returns the optional protocol features supported by the
+// signer: two-phase signing if its PrivValidator implements
+// privval.TwoPhaseSigner.
+func (ss *SignerServer) GetCapabilities(ctx context.Context, req *privvalproto.CapabilitiesRequest) (
+	*privvalproto.CapabilitiesResponse, error) {
+	_, twoPhase := ss.privVal.(privval.TwoPhaseSigner)
+	return &privvalproto.CapabilitiesResponse{TwoPhaseSigning: twoPhase}, nil
+}
+
+// PrepareSignVote receives the first phase of a two-phase vote signing
+// request, returns the commitment to the signature on success and error on
+// failure
+func (ss *SignerServer) PrepareSignVote(ctx context.Context, req *privvalproto.PrepareSignVoteRequest) (
+	*privvalproto.SignCommitmentResponse, error) {
+	tps, ok := ss.privVal.(privval.TwoPhaseSigner)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, privval.ErrTwoPhaseSigningUnsupported.Error())
+	}
+
+	commitment, err := tps.PrepareSignVote(ctx, req.ChainId, req.Vote)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error preparing vote: %v", err)
+	}
+
+	return &privvalproto.SignCommitmentResponse{Commitment: commitment}, nil
+}
+
+// PrepareSignProposal receives the first phase of a two-phase proposal
+// signing request, returns the commitment to the signature on success and
+// error on failure
+func (ss *SignerServer) PrepareSignProposal(ctx context.Context, req *privvalproto.PrepareSignProposalRequest) (
+	*privvalproto.SignCommitmentResponse, error) {
+	tps, ok := ss.privVal.(privval.TwoPhaseSigner)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, privval.ErrTwoPhaseSigningUnsupported.Error())
+	}
+
+	commitment, err := tps.PrepareSignProposal(ctx, req.ChainId, req.Proposal)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error preparing proposal: %v", err)
+	}
+
+	return &privvalproto.SignCommitmentResponse{Commitment: commitment}, nil
+}
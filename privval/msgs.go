@@ -32,6 +32,16 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
 		msg.Sum = &privvalproto.Message_PingResponse{PingResponse: pb}
+	case *privvalproto.CapabilitiesRequest:
+		msg.Sum = &privvalproto.Message_CapabilitiesRequest{CapabilitiesRequest: pb}
+	case *privvalproto.CapabilitiesResponse:
+		msg.Sum = &privvalproto.Message_CapabilitiesResponse{CapabilitiesResponse: pb}
+	case *privvalproto.PrepareSignVoteRequest:
+		msg.Sum = &privvalproto.Message_PrepareSignVoteRequest{PrepareSignVoteRequest: pb}
+	case *privvalproto.PrepareSignProposalRequest:
+		msg.Sum = &privvalproto.Message_PrepareSignProposalRequest{PrepareSignProposalRequest: pb}
+	case *privvalproto.SignCommitmentResponse:
+		msg.Sum = &privvalproto.Message_SignCommitmentResponse{SignCommitmentResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
@@ -102,9 +102,15 @@ func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 	return pk, nil
 }
 
-// SignVote requests a remote signer to sign a vote
+// SignVote requests a remote signer to sign a vote. If the signer supports
+// two-phase signing, the vote is prepared first.
 func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
-	response, err := sc.endpoint.SendRequest(ctx, mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID}))
+	response, err := sc.endpoint.SendSignRequest(ctx,
+		mustWrapMsg(&privvalproto.PrepareSignVoteRequest{Vote: vote, ChainId: chainID}),
+		func(commitment []byte) privvalproto.Message {
+			return mustWrapMsg(&privvalproto.SignVoteRequest{Vote: vote, ChainId: chainID, Commitment: commitment})
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -122,11 +128,17 @@ func (sc *SignerClient) SignVote(ctx context.Context, chainID string, vote *tmpr
 	return nil
 }
 
-// SignProposal requests a remote signer to sign a proposal
+// SignProposal requests a remote signer to sign a proposal. If the signer
+// supports two-phase signing, the proposal is prepared first.
 func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
-	response, err := sc.endpoint.SendRequest(ctx, mustWrapMsg(
-		&privvalproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
-	))
+	response, err := sc.endpoint.SendSignRequest(ctx,
+		mustWrapMsg(&privvalproto.PrepareSignProposalRequest{Proposal: proposal, ChainId: chainID}),
+		func(commitment []byte) privvalproto.Message {
+			return mustWrapMsg(&privvalproto.SignProposalRequest{
+				Proposal: proposal, ChainId: chainID, Commitment: commitment,
+			})
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,9 @@
 package privval
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -212,6 +214,47 @@ func TestSignerVote(t *testing.T) {
 	}
 }
 
+func TestSignerVoteExtension(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+
+	for _, tc := range getSignerTestCases(ctx, t, logger) {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.closer()
+
+			pubKey, err := tc.mockPV.GetPubKey(ctx)
+			require.NoError(t, err)
+			hash := tmrand.Bytes(crypto.HashSize)
+
+			// the extension is signed by the remote signer, even if empty
+			for _, ext := range [][]byte{[]byte("extension"), nil} {
+				vote := &types.Vote{
+					Type:             tmproto.PrecommitType,
+					Height:           1,
+					Round:            2,
+					BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+					Timestamp:        time.Now(),
+					ValidatorAddress: pubKey.Address(),
+					ValidatorIndex:   1,
+					Extension:        ext,
+				}
+				v := vote.ToProto()
+				require.NoError(t, tc.signerClient.SignVote(ctx, tc.chainID, v))
+
+				signed, err := types.VoteFromProto(v)
+				require.NoError(t, err)
+				assert.Equal(t, ext, signed.Extension)
+				require.NotEmpty(t, signed.ExtensionSignature)
+				assert.NoError(t, signed.VerifyVoteAndExtension(tc.chainID, pubKey))
+			}
+		})
+	}
+}
+
 func TestSignerVoteResetDeadline(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
@@ -445,3 +488,119 @@ func TestSignerUnexpectedResponse(t *testing.T) {
 		})
 	}
 }
+
+// twoPhaseMockPV is a TwoPhaseSigner signing with a MockPV, which only signs
+// votes and proposals prepared with the expected commitment.
+type twoPhaseMockPV struct {
+	types.MockPV
+	prepared int
+}
+
+func (pv *twoPhaseMockPV) commitment(signBytes []byte) []byte {
+	return crypto.Checksum(signBytes)
+}
+
+func (pv *twoPhaseMockPV) PrepareSignVote(_ context.Context, chainID string, vote *tmproto.Vote) ([]byte, error) {
+	pv.prepared++
+	return pv.commitment(types.VoteSignBytes(chainID, vote)), nil
+}
+
+func (pv *twoPhaseMockPV) PrepareSignProposal(_ context.Context, chainID string, proposal *tmproto.Proposal) ([]byte, error) {
+	pv.prepared++
+	return pv.commitment(types.ProposalSignBytes(chainID, proposal)), nil
+}
+
+func (pv *twoPhaseMockPV) SignVoteWithCommitment(ctx context.Context, chainID string, vote *tmproto.Vote, commitment []byte) error {
+	if !bytes.Equal(commitment, pv.commitment(types.VoteSignBytes(chainID, vote))) {
+		return errors.New("vote was not prepared")
+	}
+	return pv.MockPV.SignVote(ctx, chainID, vote)
+}
+
+func (pv *twoPhaseMockPV) SignProposalWithCommitment(ctx context.Context, chainID string, proposal *tmproto.Proposal, commitment []byte) error {
+	if !bytes.Equal(commitment, pv.commitment(types.ProposalSignBytes(chainID, proposal))) {
+		return errors.New("proposal was not prepared")
+	}
+	return pv.MockPV.SignProposal(ctx, chainID, proposal)
+}
+
+func TestSignerTwoPhase(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+
+	for _, tc := range getSignerTestCases(ctx, t, logger) {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.closer()
+
+			mockPV := types.NewMockPV()
+			pv := &twoPhaseMockPV{MockPV: mockPV}
+			tc.signerServer.privVal = pv
+
+			require.NoError(t, tc.signerClient.WaitForConnection(ctx, time.Second))
+			assert.True(t, tc.signerClient.endpoint.TwoPhaseSigning())
+
+			hash := tmrand.Bytes(crypto.HashSize)
+			blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}}
+			want := &types.Vote{Type: tmproto.PrecommitType, Height: 1, Round: 2, BlockID: blockID, Timestamp: time.Now()}
+			have := *want
+
+			wantpb, havepb := want.ToProto(), have.ToProto()
+			require.NoError(t, mockPV.SignVote(ctx, tc.chainID, wantpb))
+			require.NoError(t, tc.signerClient.SignVote(ctx, tc.chainID, havepb))
+			assert.Equal(t, wantpb.Signature, havepb.Signature)
+
+			proposal := &types.Proposal{Type: tmproto.ProposalType, Height: 1, Round: 2, POLRound: -1, BlockID: blockID, Timestamp: time.Now()}
+			require.NoError(t, tc.signerClient.SignProposal(ctx, tc.chainID, proposal.ToProto()))
+
+			assert.Equal(t, 2, pv.prepared)
+		})
+	}
+}
+
+// legacyHandler handles requests like a signer that predates capability
+// negotiation.
+func legacyHandler(ctx context.Context, privVal types.PrivValidator, request privvalproto.Message,
+	chainID string) (privvalproto.Message, error) {
+	switch request.Sum.(type) {
+	case *privvalproto.Message_CapabilitiesRequest,
+		*privvalproto.Message_PrepareSignVoteRequest,
+		*privvalproto.Message_PrepareSignProposalRequest:
+		return privvalproto.Message{}, fmt.Errorf("unknown msg: %v", request.Sum)
+	default:
+		return DefaultValidationRequestHandler(ctx, privVal, request, chainID)
+	}
+}
+
+func TestSignerLegacyServer(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+
+	for _, tc := range getSignerTestCases(ctx, t, logger) {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.closer()
+
+			tc.signerServer.SetRequestHandler(legacyHandler)
+
+			require.NoError(t, tc.signerClient.WaitForConnection(ctx, time.Second))
+			assert.False(t, tc.signerClient.endpoint.TwoPhaseSigning())
+
+			hash := tmrand.Bytes(crypto.HashSize)
+			blockID := types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}}
+			want := &types.Vote{Type: tmproto.PrecommitType, Height: 1, Round: 2, BlockID: blockID, Timestamp: time.Now()}
+			have := *want
+
+			wantpb, havepb := want.ToProto(), have.ToProto()
+			require.NoError(t, tc.mockPV.SignVote(ctx, tc.chainID, wantpb))
+			require.NoError(t, tc.signerClient.SignVote(ctx, tc.chainID, havepb))
+			assert.Equal(t, wantpb.Signature, havepb.Signature)
+		})
+	}
+}
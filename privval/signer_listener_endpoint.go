@@ -40,6 +40,10 @@ type SignerListenerEndpoint struct {
 	pingInterval  time.Duration
 
 	instanceMtx sync.Mutex // Ensures instance public methods access, i.e. SendRequest
+
+	// capabilities of the signer on the current connection, negotiated when
+	// the connection is established. Protected by instanceMtx.
+	capabilities privvalproto.CapabilitiesResponse
 }
 
 // NewSignerListenerEndpoint returns an instance of SignerListenerEndpoint.
@@ -115,7 +119,58 @@ func (sl *SignerListenerEndpoint) SendRequest(ctx context.Context, request privv
 		return nil, err
 	}
 
-	err = sl.WriteMessage(request)
+	return sl.roundTrip(request)
+}
+
+// SendSignRequest ensures there is a connection and sends a signing request.
+// If the signer on the connection supports two-phase signing, prepare is sent
+// first, and the request is built by passing the commitment returned by the
+// signer to request. Otherwise, request is called with a nil commitment. Both
+// phases are sent over the same connection.
+func (sl *SignerListenerEndpoint) SendSignRequest(
+	ctx context.Context,
+	prepare privvalproto.Message,
+	request func(commitment []byte) privvalproto.Message,
+) (*privvalproto.Message, error) {
+	sl.instanceMtx.Lock()
+	defer sl.instanceMtx.Unlock()
+
+	err := sl.ensureConnection(ctx, sl.timeoutAccept)
+	if err != nil {
+		return nil, err
+	}
+
+	var commitment []byte
+	if sl.capabilities.TwoPhaseSigning {
+		res, err := sl.roundTrip(prepare)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := res.GetSignCommitmentResponse()
+		if resp == nil {
+			return nil, ErrUnexpectedResponse
+		}
+		if resp.Error != nil {
+			return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+		}
+		commitment = resp.Commitment
+	}
+
+	return sl.roundTrip(request(commitment))
+}
+
+// TwoPhaseSigning reports whether the signer on the current connection
+// supports two-phase signing. It returns false if there is no connection.
+func (sl *SignerListenerEndpoint) TwoPhaseSigning() bool {
+	sl.instanceMtx.Lock()
+	defer sl.instanceMtx.Unlock()
+	return sl.IsConnected() && sl.capabilities.TwoPhaseSigning
+}
+
+// roundTrip sends request on the current connection and waits for a response.
+func (sl *SignerListenerEndpoint) roundTrip(request privvalproto.Message) (*privvalproto.Message, error) {
+	err := sl.WriteMessage(request)
 	if err != nil {
 		return nil, err
 	}
@@ -137,14 +192,34 @@ func (sl *SignerListenerEndpoint) ensureConnection(ctx context.Context, maxWait
 	}
 
 	// Is there a connection ready? then use it
-	if sl.GetAvailableConnection(sl.connectionAvailableCh) {
-		return nil
+	if !sl.GetAvailableConnection(sl.connectionAvailableCh) {
+		// block until connected or timeout
+		sl.logger.Info("SignerListener: Blocking for connection")
+		sl.triggerConnect()
+		if err := sl.WaitConnection(ctx, sl.connectionAvailableCh, maxWait); err != nil {
+			return err
+		}
 	}
 
-	// block until connected or timeout
-	sl.logger.Info("SignerListener: Blocking for connection")
-	sl.triggerConnect()
-	return sl.WaitConnection(ctx, sl.connectionAvailableCh, maxWait)
+	return sl.negotiateCapabilities()
+}
+
+// negotiateCapabilities asks the signer on a new connection which optional
+// protocol features it supports. Signers that predate capability negotiation
+// reply with an empty message, and are assumed to support none.
+func (sl *SignerListenerEndpoint) negotiateCapabilities() error {
+	sl.capabilities = privvalproto.CapabilitiesResponse{}
+
+	res, err := sl.roundTrip(mustWrapMsg(&privvalproto.CapabilitiesRequest{}))
+	if err != nil {
+		return fmt.Errorf("negotiating signer capabilities: %w", err)
+	}
+	if resp := res.GetCapabilitiesResponse(); resp != nil {
+		sl.capabilities = *resp
+	}
+
+	sl.logger.Info("SignerListener: Negotiated capabilities", "two_phase_signing", sl.capabilities.TwoPhaseSigning)
+	return nil
 }
 
 func (sl *SignerListenerEndpoint) acceptNewConnection() (net.Conn, error) {
@@ -59,7 +59,7 @@ func DefaultValidationRequestHandler(
 
 		vote := r.SignVoteRequest.Vote
 
-		err = privVal.SignVote(ctx, chainID, vote)
+		err = signVote(ctx, privVal, chainID, vote, r.SignVoteRequest.Commitment)
 		if err != nil {
 			res = mustWrapMsg(&privvalproto.SignedVoteResponse{
 				Vote: tmproto.Vote{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
@@ -78,7 +78,7 @@ func DefaultValidationRequestHandler(
 
 		proposal := r.SignProposalRequest.Proposal
 
-		err = privVal.SignProposal(ctx, chainID, proposal)
+		err = signProposal(ctx, privVal, chainID, proposal, r.SignProposalRequest.Commitment)
 		if err != nil {
 			res = mustWrapMsg(&privvalproto.SignedProposalResponse{
 				Proposal: tmproto.Proposal{}, Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
@@ -88,9 +88,79 @@ func DefaultValidationRequestHandler(
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
+	case *privvalproto.Message_CapabilitiesRequest:
+		_, twoPhase := privVal.(TwoPhaseSigner)
+		res = mustWrapMsg(&privvalproto.CapabilitiesResponse{TwoPhaseSigning: twoPhase})
+
+	case *privvalproto.Message_PrepareSignVoteRequest:
+		if r.PrepareSignVoteRequest.ChainId != chainID {
+			res = mustWrapMsg(&privvalproto.SignCommitmentResponse{Error: &privvalproto.RemoteSignerError{
+				Code: 0, Description: "unable to prepare vote"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.PrepareSignVoteRequest.GetChainId(), chainID)
+		}
+
+		var commitment []byte
+		if tps, ok := privVal.(TwoPhaseSigner); ok {
+			commitment, err = tps.PrepareSignVote(ctx, chainID, r.PrepareSignVoteRequest.Vote)
+		} else {
+			err = ErrTwoPhaseSigningUnsupported
+		}
+		res = signCommitmentResponse(commitment, err)
+
+	case *privvalproto.Message_PrepareSignProposalRequest:
+		if r.PrepareSignProposalRequest.ChainId != chainID {
+			res = mustWrapMsg(&privvalproto.SignCommitmentResponse{Error: &privvalproto.RemoteSignerError{
+				Code: 0, Description: "unable to prepare proposal"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.PrepareSignProposalRequest.GetChainId(), chainID)
+		}
+
+		var commitment []byte
+		if tps, ok := privVal.(TwoPhaseSigner); ok {
+			commitment, err = tps.PrepareSignProposal(ctx, chainID, r.PrepareSignProposalRequest.Proposal)
+		} else {
+			err = ErrTwoPhaseSigningUnsupported
+		}
+		res = signCommitmentResponse(commitment, err)
+
 	default:
 		err = fmt.Errorf("unknown msg: %v", r)
 	}
 
 	return res, err
 }
+
+func signCommitmentResponse(commitment []byte, err error) privvalproto.Message {
+	if err != nil {
+		return mustWrapMsg(&privvalproto.SignCommitmentResponse{
+			Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+	}
+	return mustWrapMsg(&privvalproto.SignCommitmentResponse{Commitment: commitment})
+}
+
+// signVote signs vote with privVal, using the commitment of the first phase
+// of two-phase signing if there is one.
+func signVote(ctx context.Context, privVal types.PrivValidator, chainID string, vote *tmproto.Vote,
+	commitment []byte) error {
+	if len(commitment) == 0 {
+		return privVal.SignVote(ctx, chainID, vote)
+	}
+	tps, ok := privVal.(TwoPhaseSigner)
+	if !ok {
+		return ErrTwoPhaseSigningUnsupported
+	}
+	return tps.SignVoteWithCommitment(ctx, chainID, vote, commitment)
+}
+
+// signProposal signs proposal with privVal, using the commitment of the first
+// phase of two-phase signing if there is one.
+func signProposal(ctx context.Context, privVal types.PrivValidator, chainID string, proposal *tmproto.Proposal,
+	commitment []byte) error {
+	if len(commitment) == 0 {
+		return privVal.SignProposal(ctx, chainID, proposal)
+	}
+	tps, ok := privVal.(TwoPhaseSigner)
+	if !ok {
+		return ErrTwoPhaseSigningUnsupported
+	}
+	return tps.SignProposalWithCommitment(ctx, chainID, proposal, commitment)
+}
@@ -0,0 +1,34 @@
+package privval
+
+import (
+	"context"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TwoPhaseSigner is implemented by private validators that sign in two
+// phases, such as HSMs or threshold signers that pre-commit to the nonce of a
+// signature before producing it.
+//
+// When the PrivValidator served by a SignerServer implements TwoPhaseSigner,
+// the server advertises two-phase signing to the SignerClient, which then
+// prepares every vote and proposal before requesting its signature.
+// Commitments must not be empty.
+type TwoPhaseSigner interface {
+	types.PrivValidator
+
+	// PrepareSignVote returns a commitment to the signature of vote.
+	PrepareSignVote(ctx context.Context, chainID string, vote *tmproto.Vote) (commitment []byte, err error)
+
+	// PrepareSignProposal returns a commitment to the signature of proposal.
+	PrepareSignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) (commitment []byte, err error)
+
+	// SignVoteWithCommitment signs vote, which must have been prepared with
+	// the given commitment.
+	SignVoteWithCommitment(ctx context.Context, chainID string, vote *tmproto.Vote, commitment []byte) error
+
+	// SignProposalWithCommitment signs proposal, which must have been
+	// prepared with the given commitment.
+	SignProposalWithCommitment(ctx context.Context, chainID string, proposal *tmproto.Proposal, commitment []byte) error
+}
@@ -36,6 +36,9 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *VoteSetBits:
 		m.Sum = &Message_VoteSetBits{VoteSetBits: msg}
 
+	case *ParityPart:
+		m.Sum = &Message_ParityPart{ParityPart: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -74,6 +77,9 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_ParityPart:
+		return m.GetParityPart(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
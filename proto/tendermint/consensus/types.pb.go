@@ -351,6 +351,76 @@ func (m *BlockPart) GetPart() types.Part {
 	return types.Part{}
 }
 
+// ParityPart is sent, in addition to block parts, to peers supporting
+// erasure-coded block gossip.
+type ParityPart struct {
+	Height        int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	PartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=part_set_header,json=partSetHeader,proto3" json:"part_set_header"`
+	Part          types.ParityPart    `protobuf:"bytes,4,opt,name=part,proto3" json:"part"`
+}
+
+func (m *ParityPart) Reset()         { *m = ParityPart{} }
+func (m *ParityPart) String() string { return proto.CompactTextString(m) }
+func (*ParityPart) ProtoMessage()    {}
+func (*ParityPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{5}
+}
+func (m *ParityPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ParityPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ParityPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ParityPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParityPart.Merge(m, src)
+}
+func (m *ParityPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *ParityPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParityPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParityPart proto.InternalMessageInfo
+
+func (m *ParityPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ParityPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *ParityPart) GetPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.PartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *ParityPart) GetPart() types.ParityPart {
+	if m != nil {
+		return m.Part
+	}
+	return types.ParityPart{}
+}
+
 // Vote is sent when voting for a proposal (or lack thereof).
 type Vote struct {
 	Vote *types.Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
@@ -360,7 +430,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{5}
+	return fileDescriptor_81a22d2efc008981, []int{6}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -408,7 +478,7 @@ func (m *HasVote) Reset()         { *m = HasVote{} }
 func (m *HasVote) String() string { return proto.CompactTextString(m) }
 func (*HasVote) ProtoMessage()    {}
 func (*HasVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{6}
+	return fileDescriptor_81a22d2efc008981, []int{7}
 }
 func (m *HasVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -477,7 +547,7 @@ func (m *VoteSetMaj23) Reset()         { *m = VoteSetMaj23{} }
 func (m *VoteSetMaj23) String() string { return proto.CompactTextString(m) }
 func (*VoteSetMaj23) ProtoMessage()    {}
 func (*VoteSetMaj23) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{7}
+	return fileDescriptor_81a22d2efc008981, []int{8}
 }
 func (m *VoteSetMaj23) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -548,7 +618,7 @@ func (m *VoteSetBits) Reset()         { *m = VoteSetBits{} }
 func (m *VoteSetBits) String() string { return proto.CompactTextString(m) }
 func (*VoteSetBits) ProtoMessage()    {}
 func (*VoteSetBits) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{8}
+	return fileDescriptor_81a22d2efc008981, []int{9}
 }
 func (m *VoteSetBits) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -623,6 +693,7 @@ type Message struct {
 	//	*Message_HasVote
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
+	//	*Message_ParityPart
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -630,7 +701,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{9}
+	return fileDescriptor_81a22d2efc008981, []int{10}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -692,6 +763,9 @@ type Message_VoteSetMaj23 struct {
 type Message_VoteSetBits struct {
 	VoteSetBits *VoteSetBits `protobuf:"bytes,9,opt,name=vote_set_bits,json=voteSetBits,proto3,oneof" json:"vote_set_bits,omitempty"`
 }
+type Message_ParityPart struct {
+	ParityPart *ParityPart `protobuf:"bytes,10,opt,name=parity_part,json=parityPart,proto3,oneof" json:"parity_part,omitempty"`
+}
 
 func (*Message_NewRoundStep) isMessage_Sum()  {}
 func (*Message_NewValidBlock) isMessage_Sum() {}
@@ -702,6 +776,7 @@ func (*Message_Vote) isMessage_Sum()          {}
 func (*Message_HasVote) isMessage_Sum()       {}
 func (*Message_VoteSetMaj23) isMessage_Sum()  {}
 func (*Message_VoteSetBits) isMessage_Sum()   {}
+func (*Message_ParityPart) isMessage_Sum()    {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -773,6 +848,13 @@ func (m *Message) GetVoteSetBits() *VoteSetBits {
 	return nil
 }
 
+func (m *Message) GetParityPart() *ParityPart {
+	if x, ok := m.GetSum().(*Message_ParityPart); ok {
+		return x.ParityPart
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -785,6 +867,7 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_HasVote)(nil),
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
+		(*Message_ParityPart)(nil),
 	}
 }
 
@@ -794,6 +877,7 @@ func init() {
 	proto.RegisterType((*Proposal)(nil), "tendermint.consensus.Proposal")
 	proto.RegisterType((*ProposalPOL)(nil), "tendermint.consensus.ProposalPOL")
 	proto.RegisterType((*BlockPart)(nil), "tendermint.consensus.BlockPart")
+	proto.RegisterType((*ParityPart)(nil), "tendermint.consensus.ParityPart")
 	proto.RegisterType((*Vote)(nil), "tendermint.consensus.Vote")
 	proto.RegisterType((*HasVote)(nil), "tendermint.consensus.HasVote")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
@@ -804,61 +888,64 @@ func init() {
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 853 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
-	0x14, 0xb7, 0x59, 0x67, 0x93, 0x7d, 0xde, 0xec, 0xc2, 0x68, 0x5b, 0x85, 0x00, 0x49, 0x30, 0x97,
-	0x15, 0x42, 0x0e, 0xca, 0x1e, 0x90, 0x0a, 0x12, 0x60, 0xfe, 0xd4, 0xad, 0x9a, 0x36, 0x72, 0x4a,
-	0x85, 0xb8, 0x58, 0x4e, 0x3c, 0x4a, 0x86, 0xc6, 0x1e, 0xcb, 0x33, 0xc9, 0xb2, 0x57, 0x3e, 0x01,
-	0x1f, 0x80, 0xaf, 0x81, 0xc4, 0x47, 0xe8, 0xb1, 0x47, 0x4e, 0x15, 0xca, 0x7e, 0x04, 0x04, 0x67,
-	0x34, 0xe3, 0x49, 0x3c, 0xa1, 0xde, 0x85, 0xbd, 0x20, 0xf5, 0x36, 0xe3, 0xf7, 0xde, 0x6f, 0xde,
-	0xfc, 0xde, 0x7b, 0x3f, 0x0f, 0xf4, 0x38, 0x4e, 0x63, 0x9c, 0x27, 0x24, 0xe5, 0xfd, 0x29, 0x4d,
-	0x19, 0x4e, 0xd9, 0x92, 0xf5, 0xf9, 0x45, 0x86, 0x99, 0x9b, 0xe5, 0x94, 0x53, 0x74, 0x52, 0x7a,
-	0xb8, 0x5b, 0x8f, 0xf6, 0xc9, 0x8c, 0xce, 0xa8, 0x74, 0xe8, 0x8b, 0x55, 0xe1, 0xdb, 0x7e, 0x5b,
-	0x43, 0x93, 0x18, 0x3a, 0x52, 0x5b, 0x3f, 0x6b, 0x41, 0x26, 0xac, 0x3f, 0x21, 0x7c, 0xc7, 0xc3,
-	0xf9, 0xc5, 0x84, 0xc3, 0x87, 0xf8, 0x3c, 0xa0, 0xcb, 0x34, 0x1e, 0x73, 0x9c, 0xa1, 0xdb, 0xb0,
-	0x3f, 0xc7, 0x64, 0x36, 0xe7, 0x2d, 0xb3, 0x67, 0x9e, 0xee, 0x05, 0x6a, 0x87, 0x4e, 0xa0, 0x96,
-	0x0b, 0xa7, 0xd6, 0x6b, 0x3d, 0xf3, 0xb4, 0x16, 0x14, 0x1b, 0x84, 0xc0, 0x62, 0x1c, 0x67, 0xad,
-	0xbd, 0x9e, 0x79, 0xda, 0x0c, 0xe4, 0x1a, 0x7d, 0x04, 0x2d, 0x86, 0xa7, 0x34, 0x8d, 0x59, 0xc8,
-	0x48, 0x3a, 0xc5, 0x21, 0xe3, 0x51, 0xce, 0x43, 0x4e, 0x12, 0xdc, 0xb2, 0x24, 0xe6, 0x2d, 0x65,
-	0x1f, 0x0b, 0xf3, 0x58, 0x58, 0x1f, 0x93, 0x04, 0xa3, 0xf7, 0xe1, 0x8d, 0x45, 0xc4, 0x78, 0x38,
-	0xa5, 0x49, 0x42, 0x78, 0x58, 0x1c, 0x57, 0x93, 0xc7, 0x1d, 0x0b, 0xc3, 0x17, 0xf2, 0xbb, 0x4c,
-	0xd5, 0xf9, 0xd3, 0x84, 0xe6, 0x43, 0x7c, 0xfe, 0x24, 0x5a, 0x90, 0xd8, 0x5b, 0xd0, 0xe9, 0xd3,
-	0x1b, 0x26, 0xfe, 0x2d, 0xdc, 0x9a, 0x88, 0xb0, 0x30, 0x13, 0xb9, 0x31, 0xcc, 0xc3, 0x39, 0x8e,
-	0x62, 0x9c, 0xcb, 0x9b, 0xd8, 0x83, 0xae, 0xab, 0xd5, 0xa0, 0xe0, 0x6b, 0x14, 0xe5, 0x7c, 0x8c,
-	0xb9, 0x2f, 0xdd, 0x3c, 0xeb, 0xd9, 0x8b, 0xae, 0x11, 0x20, 0x89, 0xb1, 0x63, 0x41, 0x9f, 0x82,
-	0x5d, 0x22, 0x33, 0x79, 0x63, 0x7b, 0xd0, 0xd1, 0xf1, 0x44, 0x25, 0x5c, 0x51, 0x09, 0xd7, 0x23,
-	0xfc, 0xf3, 0x3c, 0x8f, 0x2e, 0x02, 0xd8, 0x02, 0x31, 0xf4, 0x16, 0x1c, 0x10, 0xa6, 0x48, 0x90,
-	0xd7, 0x6f, 0x04, 0x0d, 0xc2, 0x8a, 0xcb, 0x3b, 0x3e, 0x34, 0x46, 0x39, 0xcd, 0x28, 0x8b, 0x16,
-	0xe8, 0x13, 0x68, 0x64, 0x6a, 0x2d, 0xef, 0x6c, 0x0f, 0xda, 0x15, 0x69, 0x2b, 0x0f, 0x95, 0xf1,
-	0x36, 0xc2, 0xf9, 0xd9, 0x04, 0x7b, 0x63, 0x1c, 0x3d, 0x7a, 0x70, 0x25, 0x7f, 0x1f, 0x00, 0xda,
-	0xc4, 0x84, 0x19, 0x5d, 0x84, 0x3a, 0x99, 0xaf, 0x6f, 0x2c, 0x23, 0xba, 0x90, 0x75, 0x41, 0x77,
-	0xe1, 0x50, 0xf7, 0x56, 0x74, 0xfe, 0xcb, 0xf5, 0x55, 0x6e, 0xb6, 0x86, 0xe6, 0x3c, 0x85, 0x03,
-	0x6f, 0xc3, 0xc9, 0x0d, 0x6b, 0xfb, 0x21, 0x58, 0x82, 0x7b, 0x75, 0xf6, 0xed, 0xea, 0x52, 0xaa,
-	0x33, 0xa5, 0xa7, 0x33, 0x00, 0xeb, 0x09, 0xe5, 0xa2, 0x03, 0xad, 0x15, 0xe5, 0x58, 0xb1, 0x59,
-	0x11, 0x29, 0xbc, 0x02, 0xe9, 0xe3, 0xfc, 0x68, 0x42, 0xdd, 0x8f, 0x98, 0x8c, 0xbb, 0x59, 0x7e,
-	0x67, 0x60, 0x09, 0x34, 0x99, 0xdf, 0x51, 0x55, 0xab, 0x8d, 0xc9, 0x2c, 0xc5, 0xf1, 0x90, 0xcd,
-	0x1e, 0x5f, 0x64, 0x38, 0x90, 0xce, 0x02, 0x8a, 0xa4, 0x31, 0xfe, 0x41, 0x36, 0x54, 0x2d, 0x28,
-	0x36, 0xce, 0xaf, 0x26, 0x1c, 0x8a, 0x0c, 0xc6, 0x98, 0x0f, 0xa3, 0xef, 0x07, 0x67, 0xff, 0x47,
-	0x26, 0x5f, 0x41, 0xa3, 0x68, 0x70, 0x12, 0xab, 0xee, 0x7e, 0xf3, 0xe5, 0x40, 0x59, 0xbb, 0x7b,
-	0x5f, 0x7a, 0xc7, 0x82, 0xe5, 0xf5, 0x8b, 0x6e, 0x5d, 0x7d, 0x08, 0xea, 0x32, 0xf6, 0x5e, 0xec,
-	0xfc, 0x61, 0x82, 0xad, 0x52, 0xf7, 0x08, 0x67, 0xaf, 0x4e, 0xe6, 0xe8, 0x0e, 0xd4, 0x44, 0x07,
-	0x30, 0x39, 0x9c, 0xff, 0xb5, 0xb9, 0x8b, 0x10, 0xe7, 0x2f, 0x0b, 0xea, 0x43, 0xcc, 0x58, 0x34,
-	0xc3, 0xe8, 0x3e, 0x1c, 0xa5, 0xf8, 0xbc, 0x18, 0xa8, 0x50, 0xca, 0x68, 0xd1, 0x77, 0x8e, 0x5b,
-	0xf5, 0x03, 0x70, 0x75, 0x99, 0xf6, 0x8d, 0xe0, 0x30, 0xd5, 0x65, 0x7b, 0x08, 0xc7, 0x02, 0x6b,
-	0x25, 0xf4, 0x30, 0x94, 0x89, 0x4a, 0xbe, 0xec, 0xc1, 0x7b, 0x57, 0x82, 0x95, 0xda, 0xe9, 0x1b,
-	0x41, 0x33, 0xdd, 0x11, 0x53, 0x5d, 0x5a, 0x2a, 0x46, 0xb8, 0xc4, 0xd9, 0x28, 0x88, 0xaf, 0x49,
-	0x0b, 0xfa, 0xfa, 0x1f, 0x22, 0x50, 0x70, 0xfd, 0xee, 0xf5, 0x08, 0xa3, 0x47, 0x0f, 0xfc, 0x5d,
-	0x0d, 0x40, 0x9f, 0x01, 0x94, 0x52, 0xaa, 0xd8, 0xee, 0x56, 0xa3, 0x6c, 0xb5, 0xc2, 0x37, 0x82,
-	0x83, 0xad, 0x98, 0x0a, 0x29, 0x90, 0x03, 0xbd, 0xff, 0xb2, 0x3c, 0x96, 0xb1, 0xa2, 0x0b, 0x7d,
-	0xa3, 0x18, 0x6b, 0x74, 0x07, 0x1a, 0xf3, 0x88, 0x85, 0x32, 0xaa, 0x2e, 0xa3, 0xde, 0xa9, 0x8e,
-	0x52, 0xb3, 0xef, 0x1b, 0x41, 0x7d, 0xae, 0x64, 0xe0, 0x3e, 0x1c, 0x89, 0x38, 0xf9, 0x3b, 0x49,
-	0xc4, 0x38, 0xb6, 0x1a, 0xd7, 0x15, 0x54, 0x1f, 0x5c, 0x51, 0xd0, 0x95, 0x3e, 0xc8, 0x77, 0xa1,
-	0xb9, 0xc5, 0x12, 0xfd, 0xd4, 0x3a, 0xb8, 0x8e, 0x44, 0x6d, 0x90, 0x04, 0x89, 0xab, 0x72, 0xeb,
-	0xd5, 0x60, 0x8f, 0x2d, 0x13, 0xef, 0x9b, 0x67, 0xeb, 0x8e, 0xf9, 0x7c, 0xdd, 0x31, 0x7f, 0x5f,
-	0x77, 0xcc, 0x9f, 0x2e, 0x3b, 0xc6, 0xf3, 0xcb, 0x8e, 0xf1, 0xdb, 0x65, 0xc7, 0xf8, 0xee, 0xe3,
-	0x19, 0xe1, 0xf3, 0xe5, 0xc4, 0x9d, 0xd2, 0xa4, 0xaf, 0xbf, 0x26, 0xca, 0x65, 0xf1, 0xea, 0xa8,
-	0x7a, 0xb7, 0x4c, 0xf6, 0xa5, 0xed, 0xec, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x5c, 0x91,
-	0x04, 0xd6, 0x08, 0x00, 0x00,
+	// 903 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x41, 0x6f, 0x1b, 0x45,
+	0x14, 0xde, 0x25, 0x76, 0xec, 0xbc, 0x8d, 0x1b, 0x18, 0xa5, 0xd5, 0x12, 0x8a, 0x63, 0x96, 0x4b,
+	0x84, 0x90, 0x8d, 0x1c, 0x09, 0xa4, 0x82, 0x04, 0xb8, 0x40, 0xb7, 0xa8, 0x69, 0xad, 0x75, 0xa9,
+	0x10, 0x97, 0xd5, 0xda, 0x3b, 0xb2, 0x87, 0xda, 0x3b, 0xab, 0x9d, 0x49, 0x42, 0xae, 0xfc, 0x02,
+	0x7e, 0x00, 0x7f, 0x03, 0x09, 0x89, 0x13, 0xb7, 0x1e, 0x7b, 0xe4, 0x54, 0xa1, 0xe4, 0x27, 0x20,
+	0xee, 0x68, 0xde, 0x8c, 0xed, 0x31, 0xd9, 0x44, 0x18, 0x24, 0xa4, 0xde, 0x66, 0x76, 0xde, 0xfb,
+	0xe6, 0xbd, 0xef, 0xbd, 0xf7, 0xcd, 0x42, 0x4b, 0xd2, 0x2c, 0xa5, 0xc5, 0x8c, 0x65, 0xb2, 0x33,
+	0xe2, 0x99, 0xa0, 0x99, 0x38, 0x16, 0x1d, 0x79, 0x96, 0x53, 0xd1, 0xce, 0x0b, 0x2e, 0x39, 0xd9,
+	0x5d, 0x5a, 0xb4, 0x17, 0x16, 0x7b, 0xbb, 0x63, 0x3e, 0xe6, 0x68, 0xd0, 0x51, 0x2b, 0x6d, 0xbb,
+	0x77, 0xdb, 0x42, 0x43, 0x0c, 0x1b, 0x69, 0xcf, 0xbe, 0x6b, 0xca, 0x86, 0xa2, 0x33, 0x64, 0x72,
+	0xc5, 0x22, 0xf8, 0xc9, 0x85, 0xed, 0x87, 0xf4, 0x34, 0xe2, 0xc7, 0x59, 0x3a, 0x90, 0x34, 0x27,
+	0xb7, 0x60, 0x73, 0x42, 0xd9, 0x78, 0x22, 0x7d, 0xb7, 0xe5, 0x1e, 0x6c, 0x44, 0x66, 0x47, 0x76,
+	0xa1, 0x5a, 0x28, 0x23, 0xff, 0x95, 0x96, 0x7b, 0x50, 0x8d, 0xf4, 0x86, 0x10, 0xa8, 0x08, 0x49,
+	0x73, 0x7f, 0xa3, 0xe5, 0x1e, 0x34, 0x22, 0x5c, 0x93, 0x0f, 0xc0, 0x17, 0x74, 0xc4, 0xb3, 0x54,
+	0xc4, 0x82, 0x65, 0x23, 0x1a, 0x0b, 0x99, 0x14, 0x32, 0x96, 0x6c, 0x46, 0xfd, 0x0a, 0x62, 0xde,
+	0x34, 0xe7, 0x03, 0x75, 0x3c, 0x50, 0xa7, 0x8f, 0xd9, 0x8c, 0x92, 0x77, 0xe0, 0xb5, 0x69, 0x22,
+	0x64, 0x3c, 0xe2, 0xb3, 0x19, 0x93, 0xb1, 0xbe, 0xae, 0x8a, 0xd7, 0xed, 0xa8, 0x83, 0xbb, 0xf8,
+	0x1d, 0x43, 0x0d, 0xfe, 0x74, 0xa1, 0xf1, 0x90, 0x9e, 0x3e, 0x49, 0xa6, 0x2c, 0xed, 0x4d, 0xf9,
+	0xe8, 0xe9, 0x9a, 0x81, 0x7f, 0x0d, 0x37, 0x87, 0xca, 0x2d, 0xce, 0x55, 0x6c, 0x82, 0xca, 0x78,
+	0x42, 0x93, 0x94, 0x16, 0x98, 0x89, 0xd7, 0xdd, 0x6f, 0x5b, 0x35, 0xd0, 0x7c, 0xf5, 0x93, 0x42,
+	0x0e, 0xa8, 0x0c, 0xd1, 0xac, 0x57, 0x79, 0xf6, 0x62, 0xdf, 0x89, 0x08, 0x62, 0xac, 0x9c, 0x90,
+	0x8f, 0xc1, 0x5b, 0x22, 0x0b, 0xcc, 0xd8, 0xeb, 0x36, 0x6d, 0x3c, 0x55, 0x89, 0xb6, 0xaa, 0x44,
+	0xbb, 0xc7, 0xe4, 0xa7, 0x45, 0x91, 0x9c, 0x45, 0xb0, 0x00, 0x12, 0xe4, 0x0d, 0xd8, 0x62, 0xc2,
+	0x90, 0x80, 0xe9, 0xd7, 0xa3, 0x3a, 0x13, 0x3a, 0xf9, 0x20, 0x84, 0x7a, 0xbf, 0xe0, 0x39, 0x17,
+	0xc9, 0x94, 0x7c, 0x04, 0xf5, 0xdc, 0xac, 0x31, 0x67, 0xaf, 0xbb, 0x57, 0x12, 0xb6, 0xb1, 0x30,
+	0x11, 0x2f, 0x3c, 0x82, 0x1f, 0x5d, 0xf0, 0xe6, 0x87, 0xfd, 0x47, 0x0f, 0xae, 0xe4, 0xef, 0x5d,
+	0x20, 0x73, 0x9f, 0x38, 0xe7, 0xd3, 0xd8, 0x26, 0xf3, 0xd5, 0xf9, 0x49, 0x9f, 0x4f, 0xb1, 0x2e,
+	0xe4, 0x1e, 0x6c, 0xdb, 0xd6, 0xfe, 0xc6, 0x3f, 0x49, 0xdf, 0xc4, 0xe6, 0x59, 0x68, 0xc1, 0x53,
+	0xd8, 0xea, 0xcd, 0x39, 0x59, 0xb3, 0xb6, 0xef, 0x41, 0x45, 0x71, 0x6f, 0xee, 0xbe, 0x55, 0x5e,
+	0x4a, 0x73, 0x27, 0x5a, 0x06, 0xbf, 0xba, 0x00, 0xfd, 0xa4, 0x60, 0xf2, 0xec, 0x5f, 0x5c, 0x77,
+	0x04, 0x3b, 0xff, 0xa9, 0x89, 0x1a, 0xf9, 0x4a, 0xff, 0xbc, 0x6f, 0xa2, 0xd7, 0x8d, 0x73, 0xbb,
+	0x14, 0xc3, 0x04, 0xba, 0x92, 0x43, 0x17, 0x2a, 0x4f, 0xb8, 0x54, 0x53, 0x54, 0x39, 0xe1, 0x92,
+	0xfa, 0xee, 0x55, 0xd9, 0x2b, 0xab, 0x08, 0x6d, 0x82, 0xef, 0x5d, 0xa8, 0x85, 0x89, 0x40, 0xbf,
+	0xf5, 0x92, 0x3e, 0x84, 0x8a, 0x42, 0xc3, 0x4c, 0x6f, 0x94, 0x65, 0x3a, 0x60, 0xe3, 0x8c, 0xa6,
+	0x47, 0x62, 0xfc, 0xf8, 0x2c, 0xa7, 0x11, 0x1a, 0x2b, 0x28, 0x96, 0xa5, 0xf4, 0x3b, 0xcc, 0xad,
+	0x1a, 0xe9, 0x4d, 0xf0, 0xb3, 0x0b, 0xdb, 0x2a, 0x82, 0x01, 0x95, 0x47, 0xc9, 0xb7, 0xdd, 0xc3,
+	0xff, 0x23, 0x92, 0xcf, 0xa1, 0xae, 0x87, 0x94, 0xa5, 0x86, 0xe8, 0xd7, 0x2f, 0x3b, 0x62, 0xff,
+	0xdd, 0xff, 0xac, 0xb7, 0xa3, 0x58, 0x3e, 0x7f, 0xb1, 0x5f, 0x33, 0x1f, 0xa2, 0x1a, 0xfa, 0xde,
+	0x4f, 0x83, 0x3f, 0x5c, 0xf0, 0x4c, 0xe8, 0x3d, 0x26, 0xc5, 0xcb, 0x13, 0x39, 0xb9, 0x03, 0x55,
+	0xd5, 0x01, 0xc2, 0xaf, 0xae, 0x31, 0xa0, 0xda, 0x25, 0xf8, 0xa5, 0x0a, 0xb5, 0x23, 0x2a, 0x44,
+	0x32, 0xa6, 0xe4, 0x4b, 0xb8, 0x91, 0xd1, 0x53, 0x2d, 0x0a, 0x31, 0x3e, 0x05, 0xba, 0xef, 0x82,
+	0x76, 0xd9, 0x23, 0xd6, 0xb6, 0x9f, 0x9a, 0xd0, 0x89, 0xb6, 0x33, 0x6b, 0xaf, 0x06, 0x49, 0x61,
+	0x9d, 0x28, 0x4d, 0x8f, 0x31, 0x50, 0xe4, 0xcb, 0xeb, 0xbe, 0x7d, 0x25, 0xd8, 0x52, 0xff, 0x43,
+	0x27, 0x6a, 0x64, 0xf6, 0x87, 0x15, 0x79, 0x2c, 0x91, 0xa1, 0x25, 0xce, 0x5c, 0x05, 0x43, 0x4b,
+	0x1e, 0xc9, 0x17, 0x7f, 0x13, 0x32, 0xcd, 0xf5, 0x5b, 0xd7, 0x23, 0xf4, 0x1f, 0x3d, 0x08, 0x57,
+	0x75, 0x8c, 0x7c, 0x02, 0xb0, 0x7c, 0x0e, 0x0c, 0xdb, 0xfb, 0xe5, 0x28, 0x0b, 0xbd, 0x0b, 0x9d,
+	0x68, 0x6b, 0xf1, 0x20, 0x28, 0x39, 0xc3, 0x81, 0xde, 0xbc, 0x2c, 0xf1, 0x4b, 0x5f, 0xd5, 0x85,
+	0xa1, 0xa3, 0xc7, 0x9a, 0xdc, 0x81, 0xfa, 0x24, 0x11, 0x31, 0x7a, 0xd5, 0xd0, 0xeb, 0xcd, 0x72,
+	0x2f, 0x33, 0xfb, 0xa1, 0x13, 0xd5, 0x26, 0x7a, 0xa9, 0x0a, 0xaa, 0xfc, 0x50, 0xcd, 0x66, 0x6a,
+	0x1c, 0xfd, 0xfa, 0x75, 0x05, 0xb5, 0x07, 0x57, 0x15, 0xf4, 0xc4, 0x1e, 0xe4, 0x7b, 0xd0, 0x58,
+	0x60, 0xa9, 0x7e, 0xf2, 0xb7, 0xae, 0x23, 0xd1, 0x1a, 0x24, 0x45, 0xe2, 0xc9, 0x72, 0x4b, 0xee,
+	0x82, 0x97, 0xa3, 0xea, 0x69, 0x16, 0x01, 0x61, 0x5a, 0x57, 0xd4, 0x62, 0x21, 0x8f, 0xa1, 0x13,
+	0x41, 0xbe, 0xd8, 0xf5, 0xaa, 0xb0, 0x21, 0x8e, 0x67, 0xbd, 0xaf, 0x9e, 0x9d, 0x37, 0xdd, 0xe7,
+	0xe7, 0x4d, 0xf7, 0xf7, 0xf3, 0xa6, 0xfb, 0xc3, 0x45, 0xd3, 0x79, 0x7e, 0xd1, 0x74, 0x7e, 0xbb,
+	0x68, 0x3a, 0xdf, 0x7c, 0x38, 0x66, 0x72, 0x72, 0x3c, 0x6c, 0x8f, 0xf8, 0xac, 0x63, 0xff, 0x56,
+	0x2d, 0x97, 0xfa, 0xf7, 0xab, 0xec, 0x07, 0x6e, 0xb8, 0x89, 0x67, 0x87, 0x7f, 0x0d, 0x00, 0x3f,
+	0xcd, 0x9a, 0xd8, 0xdf, 0x09, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1093,6 +1180,59 @@ func (m *BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ParityPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Part.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.PartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Vote) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1498,6 +1638,27 @@ func (m *Message_VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_ParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ParityPart != nil {
+		{
+			size, err := m.ParityPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1602,6 +1763,25 @@ func (m *BlockPart) Size() (n int) {
 	return n
 }
 
+func (m *ParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.PartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.Part.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *Vote) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1798,6 +1978,18 @@ func (m *Message_VoteSetBits) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_ParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ParityPart != nil {
+		l = m.ParityPart.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2452,6 +2644,160 @@ func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ParityPart) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ParityPart: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ParityPart: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Vote) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3321,6 +3667,41 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VoteSetBits{v}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParityPart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ParityPart{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_ParityPart{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
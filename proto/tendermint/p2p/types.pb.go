@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -88,14 +89,16 @@ func (m *ProtocolVersion) GetApp() uint64 {
 }
 
 type NodeInfo struct {
-	ProtocolVersion ProtocolVersion `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
-	NodeID          string          `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	ListenAddr      string          `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
-	Network         string          `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
-	Version         string          `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
-	Channels        []byte          `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
-	Moniker         string          `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
-	Other           NodeInfoOther   `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ProtocolVersion    ProtocolVersion  `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
+	NodeID             string           `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ListenAddr         string           `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	Network            string           `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	Version            string           `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Channels           []byte           `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	Moniker            string           `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	Other              NodeInfoOther    `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	KeyRotation        *NodeKeyRotation `protobuf:"bytes,9,opt,name=key_rotation,json=keyRotation,proto3" json:"key_rotation,omitempty"`
+	MerkleHashFunction string           `protobuf:"bytes,10,opt,name=merkle_hash_function,json=merkleHashFunction,proto3" json:"merkle_hash_function,omitempty"`
 }
 
 func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
@@ -187,6 +190,20 @@ func (m *NodeInfo) GetOther() NodeInfoOther {
 	return NodeInfoOther{}
 }
 
+func (m *NodeInfo) GetKeyRotation() *NodeKeyRotation {
+	if m != nil {
+		return m.KeyRotation
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetMerkleHashFunction() string {
+	if m != nil {
+		return m.MerkleHashFunction
+	}
+	return ""
+}
+
 type NodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -239,6 +256,76 @@ func (m *NodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+// NodeKeyRotation is signed by the previous node key of a node, to let peers
+// dialing its previous node ID connect to it until it expires.
+type NodeKeyRotation struct {
+	NodeID         string           `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	PreviousPubKey crypto.PublicKey `protobuf:"bytes,2,opt,name=previous_pub_key,json=previousPubKey,proto3" json:"previous_pub_key"`
+	Expires        time.Time        `protobuf:"bytes,3,opt,name=expires,proto3,stdtime" json:"expires"`
+	Signature      []byte           `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *NodeKeyRotation) Reset()         { *m = NodeKeyRotation{} }
+func (m *NodeKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*NodeKeyRotation) ProtoMessage()    {}
+func (*NodeKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{3}
+}
+func (m *NodeKeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeKeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeKeyRotation.Merge(m, src)
+}
+func (m *NodeKeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeKeyRotation proto.InternalMessageInfo
+
+func (m *NodeKeyRotation) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *NodeKeyRotation) GetPreviousPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.PreviousPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *NodeKeyRotation) GetExpires() time.Time {
+	if m != nil {
+		return m.Expires
+	}
+	return time.Time{}
+}
+
+func (m *NodeKeyRotation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type PeerInfo struct {
 	ID            string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	AddressInfo   []*PeerAddressInfo `protobuf:"bytes,2,rep,name=address_info,json=addressInfo,proto3" json:"address_info,omitempty"`
@@ -249,7 +336,7 @@ func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
 func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()    {}
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{3}
+	return fileDescriptor_c8a29e659aeca578, []int{4}
 }
 func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -310,7 +397,7 @@ func (m *PeerAddressInfo) Reset()         { *m = PeerAddressInfo{} }
 func (m *PeerAddressInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerAddressInfo) ProtoMessage()    {}
 func (*PeerAddressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{4}
+	return fileDescriptor_c8a29e659aeca578, []int{5}
 }
 func (m *PeerAddressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -371,6 +458,7 @@ func init() {
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*NodeInfo)(nil), "tendermint.p2p.NodeInfo")
 	proto.RegisterType((*NodeInfoOther)(nil), "tendermint.p2p.NodeInfoOther")
+	proto.RegisterType((*NodeKeyRotation)(nil), "tendermint.p2p.NodeKeyRotation")
 	proto.RegisterType((*PeerInfo)(nil), "tendermint.p2p.PeerInfo")
 	proto.RegisterType((*PeerAddressInfo)(nil), "tendermint.p2p.PeerAddressInfo")
 }
@@ -378,46 +466,55 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x4e, 0x1b, 0x3d,
-	0x14, 0xcd, 0x24, 0x21, 0x09, 0x37, 0x84, 0xf0, 0x59, 0xe8, 0xd3, 0x10, 0xa9, 0x19, 0x14, 0x36,
-	0xac, 0x26, 0x52, 0xaa, 0x2e, 0xba, 0x64, 0x40, 0xad, 0x22, 0x55, 0x25, 0x9a, 0xa2, 0x2e, 0xda,
-	0xc5, 0x68, 0x32, 0x76, 0x82, 0xc5, 0xc4, 0xb6, 0x3c, 0x4e, 0x4b, 0xdf, 0x82, 0x37, 0xe9, 0x63,
-	0x94, 0x25, 0xcb, 0xae, 0xd2, 0x6a, 0xd8, 0xf6, 0x21, 0x2a, 0xdb, 0x33, 0x40, 0xa2, 0x2e, 0xd8,
-	0xf9, 0xdc, 0xe3, 0x73, 0xee, 0x8f, 0xad, 0x0b, 0x3d, 0x45, 0x18, 0x26, 0x72, 0x41, 0x99, 0x1a,
-	0x8a, 0x91, 0x18, 0xaa, 0x6f, 0x82, 0x64, 0xbe, 0x90, 0x5c, 0x71, 0xb4, 0xfb, 0xc8, 0xf9, 0x62,
-	0x24, 0x7a, 0xfb, 0x73, 0x3e, 0xe7, 0x86, 0x1a, 0xea, 0x93, 0xbd, 0xd5, 0xf3, 0xe6, 0x9c, 0xcf,
-	0x53, 0x32, 0x34, 0x68, 0xba, 0x9c, 0x0d, 0x15, 0x5d, 0x90, 0x4c, 0xc5, 0x0b, 0x61, 0x2f, 0x0c,
-	0x2e, 0xa0, 0x3b, 0xd1, 0x87, 0x84, 0xa7, 0x1f, 0x89, 0xcc, 0x28, 0x67, 0xe8, 0x00, 0x6a, 0x62,
-	0x24, 0x5c, 0xe7, 0xd0, 0x39, 0xae, 0x07, 0xcd, 0x7c, 0xe5, 0xd5, 0x26, 0xa3, 0x49, 0xa8, 0x63,
-	0x68, 0x1f, 0xb6, 0xa6, 0x29, 0x4f, 0xae, 0xdc, 0xaa, 0x26, 0x43, 0x0b, 0xd0, 0x1e, 0xd4, 0x62,
-	0x21, 0xdc, 0x9a, 0x89, 0xe9, 0xe3, 0xe0, 0x47, 0x15, 0x5a, 0xef, 0x39, 0x26, 0x63, 0x36, 0xe3,
-	0x68, 0x02, 0x7b, 0xa2, 0x48, 0x11, 0x7d, 0xb1, 0x39, 0x8c, 0x79, 0x7b, 0xe4, 0xf9, 0xeb, 0x4d,
-	0xf8, 0x1b, 0xa5, 0x04, 0xf5, 0xdb, 0x95, 0x57, 0x09, 0xbb, 0x62, 0xa3, 0xc2, 0x23, 0x68, 0x32,
-	0x8e, 0x49, 0x44, 0xb1, 0x29, 0x64, 0x3b, 0x80, 0x7c, 0xe5, 0x35, 0x4c, 0xc2, 0xb3, 0xb0, 0xa1,
-	0xa9, 0x31, 0x46, 0x1e, 0xb4, 0x53, 0x9a, 0x29, 0xc2, 0xa2, 0x18, 0x63, 0x69, 0xaa, 0xdb, 0x0e,
-	0xc1, 0x86, 0x4e, 0x30, 0x96, 0xc8, 0x85, 0x26, 0x23, 0xea, 0x2b, 0x97, 0x57, 0x6e, 0xdd, 0x90,
-	0x25, 0xd4, 0x4c, 0x59, 0xe8, 0x96, 0x65, 0x0a, 0x88, 0x7a, 0xd0, 0x4a, 0x2e, 0x63, 0xc6, 0x48,
-	0x9a, 0xb9, 0x8d, 0x43, 0xe7, 0x78, 0x27, 0x7c, 0xc0, 0x5a, 0xb5, 0xe0, 0x8c, 0x5e, 0x11, 0xe9,
-	0x36, 0xad, 0xaa, 0x80, 0xe8, 0x35, 0x6c, 0x71, 0x75, 0x49, 0xa4, 0xdb, 0x32, 0x6d, 0xbf, 0xd8,
-	0x6c, 0xbb, 0x1c, 0xd5, 0xb9, 0xbe, 0x54, 0x34, 0x6d, 0x15, 0x83, 0xcf, 0xd0, 0x59, 0x63, 0xd1,
-	0x01, 0xb4, 0xd4, 0x75, 0x44, 0x19, 0x26, 0xd7, 0x66, 0x8a, 0xdb, 0x61, 0x53, 0x5d, 0x8f, 0x35,
-	0x44, 0x43, 0x68, 0x4b, 0x91, 0x98, 0x76, 0x49, 0x96, 0x15, 0xa3, 0xd9, 0xcd, 0x57, 0x1e, 0x84,
-	0x93, 0xd3, 0x13, 0x1b, 0x0d, 0x41, 0x8a, 0xa4, 0x38, 0x0f, 0xbe, 0x3b, 0xd0, 0x9a, 0x10, 0x22,
-	0xcd, 0x33, 0xfd, 0x0f, 0x55, 0x8a, 0xad, 0x65, 0xd0, 0xc8, 0x57, 0x5e, 0x75, 0x7c, 0x16, 0x56,
-	0x29, 0x46, 0x01, 0xec, 0x14, 0x8e, 0x11, 0x65, 0x33, 0xee, 0x56, 0x0f, 0x6b, 0xff, 0x7c, 0x3a,
-	0x42, 0x64, 0xe1, 0xab, 0xed, 0xc2, 0x76, 0xfc, 0x08, 0xd0, 0x5b, 0xd8, 0x4d, 0xe3, 0x4c, 0x45,
-	0x09, 0x67, 0x8c, 0x24, 0x8a, 0x60, 0xf3, 0x1c, 0xed, 0x51, 0xcf, 0xb7, 0xff, 0xd3, 0x2f, 0xff,
-	0xa7, 0x7f, 0x51, 0xfe, 0xcf, 0xa0, 0x7e, 0xf3, 0xcb, 0x73, 0xc2, 0x8e, 0xd6, 0x9d, 0x96, 0xb2,
-	0xc1, 0x1f, 0x07, 0xba, 0x1b, 0x99, 0xf4, 0xdc, 0xcb, 0x96, 0x8b, 0x81, 0x14, 0x10, 0xbd, 0x83,
-	0xff, 0x4c, 0x5a, 0x4c, 0xe3, 0x34, 0xca, 0x96, 0x49, 0x52, 0x8e, 0xe5, 0x39, 0x99, 0xbb, 0x5a,
-	0x7a, 0x46, 0xe3, 0xf4, 0x83, 0x15, 0xae, 0xbb, 0xcd, 0x62, 0x9a, 0x2e, 0x25, 0x79, 0x76, 0x1f,
-	0x0f, 0x6e, 0x6f, 0xac, 0x10, 0x1d, 0x41, 0xe7, 0xa9, 0x51, 0x66, 0xfe, 0x60, 0x27, 0xdc, 0xc1,
-	0x8f, 0x77, 0xb2, 0xe0, 0xfc, 0x36, 0xef, 0x3b, 0x77, 0x79, 0xdf, 0xf9, 0x9d, 0xf7, 0x9d, 0x9b,
-	0xfb, 0x7e, 0xe5, 0xee, 0xbe, 0x5f, 0xf9, 0x79, 0xdf, 0xaf, 0x7c, 0x7a, 0x35, 0xa7, 0xea, 0x72,
-	0x39, 0xf5, 0x13, 0xbe, 0x18, 0x3e, 0xd9, 0x12, 0x4f, 0x17, 0x86, 0xd9, 0x05, 0xeb, 0x1b, 0x64,
-	0xda, 0x30, 0xd1, 0x97, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x0b, 0xe9, 0x56, 0xd3, 0x5a, 0x04,
-	0x00, 0x00,
+	// 761 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x6f, 0xdb, 0x36,
+	0x14, 0x8f, 0xec, 0xc4, 0x7f, 0xe8, 0x24, 0xee, 0x88, 0x60, 0x50, 0x8d, 0xcc, 0x0a, 0xdc, 0x4b,
+	0x4f, 0xf2, 0xe0, 0x61, 0x87, 0x5d, 0x06, 0xd4, 0x0d, 0xba, 0x05, 0x29, 0x56, 0x81, 0x2b, 0x76,
+	0xd8, 0x0e, 0x82, 0x2c, 0x3d, 0xdb, 0x84, 0x64, 0x92, 0x20, 0xa9, 0x2e, 0xfa, 0x16, 0xfd, 0x26,
+	0xfb, 0x04, 0xbb, 0xf7, 0xd8, 0xe3, 0x4e, 0xde, 0xa0, 0x5c, 0xf7, 0x21, 0x06, 0x52, 0x52, 0xed,
+	0x18, 0x1d, 0xd0, 0xde, 0xf8, 0x7b, 0xef, 0xfd, 0xde, 0xff, 0x47, 0x34, 0xd2, 0xc0, 0x12, 0x90,
+	0x1b, 0xca, 0xf4, 0x54, 0xcc, 0xc4, 0x54, 0x17, 0x02, 0x94, 0x2f, 0x24, 0xd7, 0x1c, 0x9f, 0xef,
+	0x74, 0xbe, 0x98, 0x89, 0xd1, 0xc5, 0x8a, 0xaf, 0xb8, 0x55, 0x4d, 0xcd, 0xab, 0xb2, 0x1a, 0x79,
+	0x2b, 0xce, 0x57, 0x19, 0x4c, 0x2d, 0x5a, 0xe4, 0xcb, 0xa9, 0xa6, 0x1b, 0x50, 0x3a, 0xda, 0x88,
+	0xda, 0xe0, 0x72, 0x2f, 0x44, 0x2c, 0x0b, 0xa1, 0xf9, 0x34, 0x85, 0xa2, 0x0e, 0x32, 0x79, 0x8d,
+	0x86, 0x81, 0x79, 0xc4, 0x3c, 0xfb, 0x05, 0xa4, 0xa2, 0x9c, 0xe1, 0xc7, 0xa8, 0x2d, 0x66, 0xc2,
+	0x75, 0xae, 0x9c, 0xa7, 0xc7, 0xf3, 0x6e, 0xb9, 0xf5, 0xda, 0xc1, 0x2c, 0x20, 0x46, 0x86, 0x2f,
+	0xd0, 0xc9, 0x22, 0xe3, 0x71, 0xea, 0xb6, 0x8c, 0x92, 0x54, 0x00, 0x3f, 0x42, 0xed, 0x48, 0x08,
+	0xb7, 0x6d, 0x65, 0xe6, 0x39, 0xf9, 0xb3, 0x8d, 0x7a, 0x3f, 0xf1, 0x04, 0x6e, 0xd8, 0x92, 0xe3,
+	0x00, 0x3d, 0x12, 0x75, 0x88, 0xf0, 0x4d, 0x15, 0xc3, 0x3a, 0x1f, 0xcc, 0x3c, 0xff, 0x61, 0x89,
+	0xfe, 0x41, 0x2a, 0xf3, 0xe3, 0x77, 0x5b, 0xef, 0x88, 0x0c, 0xc5, 0x41, 0x86, 0x4f, 0x50, 0x97,
+	0xf1, 0x04, 0x42, 0x9a, 0xd8, 0x44, 0xfa, 0x73, 0x54, 0x6e, 0xbd, 0x8e, 0x0d, 0x78, 0x4d, 0x3a,
+	0x46, 0x75, 0x93, 0x60, 0x0f, 0x0d, 0x32, 0xaa, 0x34, 0xb0, 0x30, 0x4a, 0x12, 0x69, 0xb3, 0xeb,
+	0x13, 0x54, 0x89, 0x9e, 0x25, 0x89, 0xc4, 0x2e, 0xea, 0x32, 0xd0, 0xbf, 0x73, 0x99, 0xba, 0xc7,
+	0x56, 0xd9, 0x40, 0xa3, 0x69, 0x12, 0x3d, 0xa9, 0x34, 0x35, 0xc4, 0x23, 0xd4, 0x8b, 0xd7, 0x11,
+	0x63, 0x90, 0x29, 0xb7, 0x73, 0xe5, 0x3c, 0x3d, 0x25, 0x1f, 0xb0, 0x61, 0x6d, 0x38, 0xa3, 0x29,
+	0x48, 0xb7, 0x5b, 0xb1, 0x6a, 0x88, 0xbf, 0x43, 0x27, 0x5c, 0xaf, 0x41, 0xba, 0x3d, 0x5b, 0xf6,
+	0x57, 0x87, 0x65, 0x37, 0xad, 0x7a, 0x65, 0x8c, 0xea, 0xa2, 0x2b, 0x06, 0x9e, 0xa3, 0xd3, 0x14,
+	0x8a, 0x50, 0x72, 0x1d, 0x69, 0x93, 0x4f, 0xff, 0xe3, 0x8d, 0x33, 0x1e, 0x6e, 0xa1, 0x20, 0xb5,
+	0x19, 0x19, 0xa4, 0x3b, 0x80, 0xbf, 0x46, 0x17, 0x1b, 0x90, 0x69, 0x06, 0xe1, 0x3a, 0x52, 0xeb,
+	0x70, 0x99, 0xb3, 0xd8, 0xfa, 0x42, 0x36, 0x4b, 0x5c, 0xe9, 0x7e, 0x8c, 0xd4, 0xfa, 0x45, 0xad,
+	0x99, 0xfc, 0x86, 0xce, 0x1e, 0xe4, 0x84, 0x1f, 0xa3, 0x9e, 0xbe, 0x0b, 0x29, 0x4b, 0xe0, 0xce,
+	0xce, 0xae, 0x4f, 0xba, 0xfa, 0xee, 0xc6, 0x40, 0x3c, 0x45, 0x03, 0x29, 0x62, 0xdb, 0x64, 0x50,
+	0xaa, 0x1e, 0xc8, 0x79, 0xb9, 0xf5, 0x10, 0x09, 0x9e, 0x3f, 0xab, 0xa4, 0x04, 0x49, 0x11, 0xd7,
+	0xef, 0x49, 0xe9, 0xa0, 0xe1, 0x41, 0xbe, 0xfb, 0x13, 0x75, 0xfe, 0x77, 0xa2, 0x2f, 0xcd, 0x22,
+	0xc1, 0x1b, 0xca, 0x73, 0x15, 0x8a, 0x7c, 0x11, 0xa6, 0x50, 0xd8, 0x70, 0x83, 0xd9, 0xe5, 0x7e,
+	0x3f, 0xaa, 0x25, 0xf7, 0x83, 0x7c, 0x91, 0xd1, 0xf8, 0x16, 0x8a, 0xba, 0xa1, 0xe7, 0x0d, 0x37,
+	0xc8, 0x17, 0xb7, 0x50, 0xe0, 0xef, 0x51, 0x17, 0xee, 0x04, 0x95, 0xa0, 0xec, 0x6e, 0x0c, 0x66,
+	0x23, 0xbf, 0x3a, 0x25, 0xbf, 0x39, 0x25, 0xff, 0x75, 0x73, 0x4a, 0xf3, 0x9e, 0x71, 0xf1, 0xf6,
+	0x6f, 0xcf, 0x21, 0x0d, 0x09, 0x5f, 0xa2, 0xbe, 0xa2, 0x2b, 0x16, 0xe9, 0x5c, 0x82, 0x5d, 0xa0,
+	0x53, 0xb2, 0x13, 0x4c, 0xfe, 0x70, 0x50, 0x2f, 0x00, 0x90, 0xf6, 0x02, 0xbe, 0x44, 0xad, 0x0f,
+	0x85, 0x75, 0xca, 0xad, 0xd7, 0xba, 0xb9, 0x26, 0x2d, 0x9a, 0x98, 0xe1, 0xd6, 0x6d, 0x0b, 0x29,
+	0x5b, 0x72, 0xb7, 0x75, 0xd5, 0xfe, 0xe8, 0x55, 0x00, 0xc8, 0xba, 0x79, 0xc6, 0x1d, 0x19, 0x44,
+	0x3b, 0x80, 0x7f, 0x40, 0xe7, 0x59, 0xa4, 0x74, 0x18, 0x73, 0xc6, 0x20, 0xd6, 0x90, 0x7c, 0x42,
+	0x35, 0xc7, 0xb6, 0x92, 0x33, 0xc3, 0x7b, 0xde, 0xd0, 0x26, 0xff, 0x3a, 0x68, 0x78, 0x10, 0xc9,
+	0xac, 0x74, 0x33, 0xd7, 0x7a, 0xea, 0x35, 0xc4, 0x2f, 0xd1, 0x17, 0x36, 0x6c, 0x42, 0xa3, 0x2c,
+	0x54, 0x79, 0x1c, 0x37, 0xb3, 0xff, 0x94, 0xc8, 0x43, 0x43, 0xbd, 0xa6, 0x51, 0xf6, 0x73, 0x45,
+	0x7c, 0xe8, 0x6d, 0x19, 0xd1, 0xcc, 0xf4, 0xb4, 0xfd, 0xb9, 0xde, 0x5e, 0x54, 0x44, 0xfc, 0x04,
+	0x9d, 0xed, 0x3b, 0x52, 0x76, 0x3a, 0x67, 0xe4, 0x34, 0xd9, 0xd9, 0xa8, 0xf9, 0xab, 0x77, 0xe5,
+	0xd8, 0x79, 0x5f, 0x8e, 0x9d, 0x7f, 0xca, 0xb1, 0xf3, 0xf6, 0x7e, 0x7c, 0xf4, 0xfe, 0x7e, 0x7c,
+	0xf4, 0xd7, 0xfd, 0xf8, 0xe8, 0xd7, 0x6f, 0x57, 0x54, 0xaf, 0xf3, 0x85, 0x1f, 0xf3, 0xcd, 0x74,
+	0xef, 0xef, 0xdc, 0x7b, 0x56, 0x9f, 0xf0, 0xc3, 0xaf, 0x7b, 0xd1, 0xb1, 0xd2, 0x6f, 0xfe, 0x1b,
+	0x00, 0x4c, 0x90, 0x2a, 0x98, 0xd3, 0x05, 0x00, 0x00,
 }
 
 func (m *ProtocolVersion) Marshal() (dAtA []byte, err error) {
@@ -478,6 +575,25 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.MerkleHashFunction) > 0 {
+		i -= len(m.MerkleHashFunction)
+		copy(dAtA[i:], m.MerkleHashFunction)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MerkleHashFunction)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.KeyRotation != nil {
+		{
+			size, err := m.KeyRotation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -580,6 +696,61 @@ func (m *NodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NodeKeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeKeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeKeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Expires):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintTypes(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.PreviousPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -601,12 +772,12 @@ func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.LastConnected != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
-		if err3 != nil {
-			return 0, err3
+		n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
+		if err6 != nil {
+			return 0, err6
 		}
-		i -= n3
-		i = encodeVarintTypes(dAtA, i, uint64(n3))
+		i -= n6
+		i = encodeVarintTypes(dAtA, i, uint64(n6))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -660,22 +831,22 @@ func (m *PeerAddressInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x20
 	}
 	if m.LastDialFailure != nil {
-		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
-		if err4 != nil {
-			return 0, err4
+		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
+		if err7 != nil {
+			return 0, err7
 		}
-		i -= n4
-		i = encodeVarintTypes(dAtA, i, uint64(n4))
+		i -= n7
+		i = encodeVarintTypes(dAtA, i, uint64(n7))
 		i--
 		dAtA[i] = 0x1a
 	}
 	if m.LastDialSuccess != nil {
-		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
-		if err5 != nil {
-			return 0, err5
+		n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
+		if err8 != nil {
+			return 0, err8
 		}
-		i -= n5
-		i = encodeVarintTypes(dAtA, i, uint64(n5))
+		i -= n8
+		i = encodeVarintTypes(dAtA, i, uint64(n8))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -752,6 +923,14 @@ func (m *NodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if m.KeyRotation != nil {
+		l = m.KeyRotation.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.MerkleHashFunction)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -772,6 +951,27 @@ func (m *NodeInfoOther) Size() (n int) {
 	return n
 }
 
+func (m *NodeKeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.PreviousPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Expires)
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *PeerInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1221,6 +1421,74 @@ func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KeyRotation == nil {
+				m.KeyRotation = &NodeKeyRotation{}
+			}
+			if err := m.KeyRotation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MerkleHashFunction", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MerkleHashFunction = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1356,6 +1624,188 @@ func (m *NodeInfoOther) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *NodeKeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeKeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeKeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PreviousPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -27,23 +27,27 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
 
 var fileDescriptor_7afe74f9f46d3dc9 = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
-	0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xa8,
-	0xd0, 0x83, 0xaa, 0x90, 0x92, 0xc3, 0xa2, 0xab, 0xa4, 0xb2, 0x20, 0xb5, 0x18, 0xa2, 0xc7, 0x68,
-	0x09, 0x13, 0x97, 0x40, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62, 0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e,
-	0x91, 0x63, 0x80, 0xa7, 0x50, 0x10, 0x17, 0xa7, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x92, 0x77, 0x6a,
-	0xa5, 0x90, 0xa2, 0x1e, 0xa6, 0xb1, 0x7a, 0x10, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x29, 0x25, 0x7c, 0x4a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xc2, 0xb9, 0x38, 0x82, 0x33,
-	0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x94, 0xb1, 0xa9, 0x87, 0xc9, 0xc2, 0x0c, 0x55, 0xc3,
-	0xa5, 0x28, 0x35, 0x05, 0xa2, 0x0c, 0x6a, 0x70, 0x32, 0x17, 0x0f, 0x48, 0x34, 0xa0, 0x28, 0xbf,
-	0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x1d, 0x97, 0x3e, 0x98, 0x0a, 0x98, 0x05, 0x5a, 0xb8, 0x2d,
-	0x40, 0x28, 0x85, 0x58, 0xe2, 0x14, 0x7c, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c,
-	0x51, 0x96, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x48, 0x61, 0x8d,
-	0x12, 0xec, 0xf9, 0x25, 0xf9, 0xfa, 0x98, 0xf1, 0x90, 0xc4, 0x06, 0x96, 0x31, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x42, 0x60, 0x24, 0x48, 0xda, 0x01, 0x00, 0x00,
+	// 318 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x4b, 0xf3, 0x40,
+	0x10, 0xc6, 0x5b, 0x78, 0x79, 0xd1, 0x45, 0xa8, 0xac, 0xb7, 0x1e, 0x16, 0xff, 0x80, 0x8a, 0x87,
+	0x0d, 0xe8, 0xc9, 0xa3, 0xf6, 0x50, 0xc4, 0x4b, 0x68, 0xa1, 0x82, 0xb7, 0x4d, 0x33, 0xd4, 0x81,
+	0x24, 0xbb, 0xdd, 0x9d, 0x06, 0xfa, 0x2d, 0xfc, 0x58, 0x1e, 0x7b, 0xf4, 0x28, 0xed, 0xc5, 0x8f,
+	0x21, 0xda, 0xa4, 0x8d, 0x26, 0x31, 0x5e, 0x33, 0xbf, 0x79, 0x7e, 0xd9, 0xe1, 0x61, 0x87, 0x04,
+	0x49, 0x08, 0x36, 0xc6, 0x84, 0x3c, 0x63, 0x31, 0x4d, 0x55, 0xe4, 0x39, 0xb0, 0x29, 0x8e, 0x41,
+	0x1a, 0xab, 0x49, 0x73, 0xbe, 0x25, 0x64, 0x46, 0x74, 0x45, 0xc5, 0x16, 0xcd, 0x0d, 0xb8, 0xf5,
+	0xce, 0xe5, 0xfb, 0x3f, 0xb6, 0xef, 0x5b, 0x4c, 0x47, 0x2a, 0xc2, 0x50, 0x91, 0xb6, 0x37, 0xfe,
+	0x1d, 0x1f, 0xb0, 0xdd, 0x3e, 0x90, 0x3f, 0x0b, 0xee, 0x61, 0xce, 0x8f, 0x64, 0x39, 0x56, 0xae,
+	0x67, 0x03, 0x98, 0xce, 0xc0, 0x51, 0xf7, 0xf8, 0x37, 0xc4, 0x19, 0x9d, 0x38, 0xe0, 0x0f, 0x6c,
+	0x67, 0x88, 0x93, 0x64, 0xa4, 0x09, 0xf8, 0x49, 0x15, 0x9f, 0x4f, 0xf3, 0xd0, 0xd3, 0x3a, 0x08,
+	0xc2, 0x35, 0x96, 0x05, 0x8f, 0xd9, 0xde, 0xe7, 0x57, 0xdf, 0x6a, 0xa3, 0x9d, 0x8a, 0xf8, 0x59,
+	0xdd, 0x5e, 0x4e, 0xe4, 0x82, 0x8b, 0x7a, 0xc1, 0x16, 0xcd, 0x24, 0x21, 0xeb, 0xf4, 0x81, 0x7a,
+	0xca, 0xa8, 0x00, 0x23, 0x24, 0x04, 0x57, 0xed, 0x29, 0x12, 0xb9, 0xe7, 0xbc, 0x19, 0xcc, 0x2c,
+	0xc8, 0x3a, 0xbe, 0x05, 0xa3, 0x2c, 0x6c, 0x4e, 0x55, 0xf9, 0x93, 0x3f, 0xa0, 0xc6, 0x07, 0xf5,
+	0x74, 0x1c, 0x23, 0xc5, 0x90, 0xd0, 0x46, 0x35, 0x65, 0x07, 0x85, 0x94, 0xcd, 0xf1, 0x64, 0x83,
+	0xee, 0xcf, 0x37, 0x2c, 0x2b, 0x6f, 0x87, 0x2f, 0x4b, 0xd1, 0x5e, 0x2c, 0x45, 0xfb, 0x6d, 0x29,
+	0xda, 0xcf, 0x2b, 0xd1, 0x5a, 0xac, 0x44, 0xeb, 0x75, 0x25, 0x5a, 0x8f, 0xd7, 0x13, 0xa4, 0xa7,
+	0x59, 0x20, 0xc7, 0x3a, 0xf6, 0x0a, 0x7d, 0xfd, 0x56, 0x5d, 0x4d, 0xda, 0x2b, 0x77, 0x39, 0xf8,
+	0xff, 0x35, 0xb9, 0xfa, 0x18, 0x00, 0xb4, 0xd5, 0x7a, 0x9b, 0x1e, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -61,6 +65,11 @@ type PrivValidatorAPIClient interface {
 	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
 	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
 	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	// Optional features, see CapabilitiesResponse. Signers that don't implement
+	// GetCapabilities are assumed to support none.
+	GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	PrepareSignVote(ctx context.Context, in *PrepareSignVoteRequest, opts ...grpc.CallOption) (*SignCommitmentResponse, error)
+	PrepareSignProposal(ctx context.Context, in *PrepareSignProposalRequest, opts ...grpc.CallOption) (*SignCommitmentResponse, error)
 }
 
 type privValidatorAPIClient struct {
@@ -98,11 +107,43 @@ func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignPropo
 	return out, nil
 }
 
+func (c *privValidatorAPIClient) GetCapabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) PrepareSignVote(ctx context.Context, in *PrepareSignVoteRequest, opts ...grpc.CallOption) (*SignCommitmentResponse, error) {
+	out := new(SignCommitmentResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/PrepareSignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) PrepareSignProposal(ctx context.Context, in *PrepareSignProposalRequest, opts ...grpc.CallOption) (*SignCommitmentResponse, error) {
+	out := new(SignCommitmentResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/PrepareSignProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
 type PrivValidatorAPIServer interface {
 	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
 	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
 	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	// Optional features, see CapabilitiesResponse. Signers that don't implement
+	// GetCapabilities are assumed to support none.
+	GetCapabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+	PrepareSignVote(context.Context, *PrepareSignVoteRequest) (*SignCommitmentResponse, error)
+	PrepareSignProposal(context.Context, *PrepareSignProposalRequest) (*SignCommitmentResponse, error)
 }
 
 // UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
@@ -118,6 +159,15 @@ func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *S
 func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
 }
+func (*UnimplementedPrivValidatorAPIServer) GetCapabilities(ctx context.Context, req *CapabilitiesRequest) (*CapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) PrepareSignVote(ctx context.Context, req *PrepareSignVoteRequest) (*SignCommitmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareSignVote not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) PrepareSignProposal(ctx context.Context, req *PrepareSignProposalRequest) (*SignCommitmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareSignProposal not implemented")
+}
 
 func RegisterPrivValidatorAPIServer(s *grpc.Server, srv PrivValidatorAPIServer) {
 	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
@@ -177,6 +227,60 @@ func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PrivValidatorAPI_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetCapabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_PrepareSignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareSignVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).PrepareSignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/PrepareSignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).PrepareSignVote(ctx, req.(*PrepareSignVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_PrepareSignProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareSignProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).PrepareSignProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/PrepareSignProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).PrepareSignProposal(ctx, req.(*PrepareSignProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.privval.PrivValidatorAPI",
 	HandlerType: (*PrivValidatorAPIServer)(nil),
@@ -193,6 +297,18 @@ var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SignProposal",
 			Handler:    _PrivValidatorAPI_SignProposal_Handler,
 		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _PrivValidatorAPI_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "PrepareSignVote",
+			Handler:    _PrivValidatorAPI_PrepareSignVote_Handler,
+		},
+		{
+			MethodName: "PrepareSignProposal",
+			Handler:    _PrivValidatorAPI_PrepareSignProposal_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/privval/service.proto",
@@ -188,6 +188,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 }
 
 type SnapshotsRequest struct {
+	// incremental is set by peers able to restore incremental snapshots.
+	Incremental bool `protobuf:"varint,1,opt,name=incremental,proto3" json:"incremental,omitempty"`
 }
 
 func (m *SnapshotsRequest) Reset()         { *m = SnapshotsRequest{} }
@@ -223,12 +225,21 @@ func (m *SnapshotsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SnapshotsRequest proto.InternalMessageInfo
 
+func (m *SnapshotsRequest) GetIncremental() bool {
+	if m != nil {
+		return m.Incremental
+	}
+	return false
+}
+
 type SnapshotsResponse struct {
-	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
-	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
-	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Height     uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format     uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks     uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash       []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata   []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	BaseHeight uint64 `protobuf:"varint,6,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
+	BaseHash   []byte `protobuf:"bytes,7,opt,name=base_hash,json=baseHash,proto3" json:"base_hash,omitempty"`
 }
 
 func (m *SnapshotsResponse) Reset()         { *m = SnapshotsResponse{} }
@@ -299,6 +310,20 @@ func (m *SnapshotsResponse) GetMetadata() []byte {
 	return nil
 }
 
+func (m *SnapshotsResponse) GetBaseHeight() uint64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+func (m *SnapshotsResponse) GetBaseHash() []byte {
+	if m != nil {
+		return m.BaseHash
+	}
+	return nil
+}
+
 type ChunkRequest struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
@@ -360,11 +385,12 @@ func (m *ChunkRequest) GetIndex() uint32 {
 }
 
 type ChunkResponse struct {
-	Height  uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Format  uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
-	Index   uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
-	Chunk   []byte `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
-	Missing bool   `protobuf:"varint,5,opt,name=missing,proto3" json:"missing,omitempty"`
+	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format   uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Index    uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk    []byte `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Missing  bool   `protobuf:"varint,5,opt,name=missing,proto3" json:"missing,omitempty"`
+	Checksum []byte `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
 func (m *ChunkResponse) Reset()         { *m = ChunkResponse{} }
@@ -435,6 +461,13 @@ func (m *ChunkResponse) GetMissing() bool {
 	return false
 }
 
+func (m *ChunkResponse) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
 type LightBlockRequest struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 }
@@ -634,44 +667,48 @@ func init() {
 func init() { proto.RegisterFile("tendermint/statesync/types.proto", fileDescriptor_a1c2869546ca7914) }
 
 var fileDescriptor_a1c2869546ca7914 = []byte{
-	// 589 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0x4f, 0x8b, 0xd3, 0x40,
-	0x18, 0xc6, 0x13, 0xb7, 0xdd, 0x96, 0x77, 0x9b, 0x6e, 0x3b, 0x16, 0x29, 0x65, 0x8d, 0x6b, 0x14,
-	0x77, 0x41, 0x68, 0x41, 0x8f, 0xe2, 0xa5, 0x7b, 0x59, 0x61, 0x45, 0x99, 0x75, 0x41, 0x45, 0x28,
-	0x69, 0x3a, 0x26, 0xc1, 0xe6, 0x8f, 0x7d, 0xa7, 0xe0, 0x82, 0x57, 0x4f, 0x5e, 0xfc, 0x2c, 0x7e,
-	0x8a, 0x3d, 0xee, 0xd1, 0x93, 0x48, 0xfb, 0x45, 0x24, 0x93, 0x69, 0x32, 0x6d, 0xda, 0x2e, 0x82,
-	0xb7, 0xbc, 0xcf, 0x3c, 0xf9, 0xf5, 0x99, 0xc9, 0xc3, 0x14, 0x0e, 0x39, 0x0b, 0x47, 0x6c, 0x12,
-	0xf8, 0x21, 0xef, 0x21, 0xb7, 0x39, 0xc3, 0xcb, 0xd0, 0xe9, 0xf1, 0xcb, 0x98, 0x61, 0x37, 0x9e,
-	0x44, 0x3c, 0x22, 0xad, 0xdc, 0xd1, 0xcd, 0x1c, 0x9d, 0x96, 0x1b, 0xb9, 0x91, 0x30, 0xf4, 0x92,
-	0xa7, 0xd4, 0xdb, 0x39, 0x50, 0x68, 0x82, 0xa1, 0x92, 0x3a, 0x77, 0x0b, 0xab, 0xb1, 0x3d, 0xb1,
-	0x03, 0xb9, 0x6c, 0xfd, 0x2c, 0x43, 0xe5, 0x25, 0x43, 0xb4, 0x5d, 0x46, 0x2e, 0xa0, 0x89, 0xa1,
-	0x1d, 0xa3, 0x17, 0x71, 0x1c, 0x4c, 0xd8, 0xe7, 0x29, 0x43, 0xde, 0xd6, 0x0f, 0xf5, 0xe3, 0xbd,
-	0x27, 0x8f, 0xba, 0xeb, 0x02, 0x75, 0xcf, 0x17, 0x76, 0x9a, 0xba, 0x4f, 0x35, 0xda, 0xc0, 0x15,
-	0x8d, 0xbc, 0x05, 0xa2, 0x62, 0x31, 0x8e, 0x42, 0x64, 0xed, 0x5b, 0x82, 0x7b, 0x74, 0x23, 0x37,
-	0xb5, 0x9f, 0x6a, 0xb4, 0x89, 0xab, 0x22, 0x79, 0x01, 0x86, 0xe3, 0x4d, 0xc3, 0x4f, 0x59, 0xd8,
-	0x1d, 0x01, 0xb5, 0xd6, 0x43, 0x4f, 0x12, 0x6b, 0x1e, 0xb4, 0xe6, 0x28, 0x33, 0x39, 0x83, 0xfa,
-	0x02, 0x25, 0x03, 0x96, 0x04, 0xeb, 0xc1, 0x56, 0x56, 0x16, 0xce, 0x70, 0x54, 0x81, 0xbc, 0x83,
-	0xdb, 0x63, 0xdf, 0xf5, 0xf8, 0x60, 0x38, 0x8e, 0x9c, 0x3c, 0x5e, 0x79, 0xdb, 0x9e, 0xcf, 0x92,
-	0x17, 0xfa, 0x89, 0x3f, 0xcf, 0xd8, 0x1c, 0xaf, 0x8a, 0xe4, 0x03, 0xb4, 0x96, 0xd1, 0x32, 0xee,
-	0xae, 0x60, 0x1f, 0xdf, 0xcc, 0xce, 0x32, 0x93, 0x71, 0x41, 0x4d, 0x8e, 0x21, 0xad, 0x47, 0x96,
-	0xb9, 0xb2, 0xed, 0x18, 0x5e, 0x0b, 0x6f, 0x9e, 0xd7, 0x88, 0x55, 0x81, 0xbc, 0x82, 0xfd, 0x8c,
-	0x26, 0x63, 0x56, 0x05, 0xee, 0xe1, 0x76, 0x5c, 0x16, 0xb1, 0x1e, 0x2f, 0x29, 0xfd, 0x32, 0xec,
-	0xe0, 0x34, 0xb0, 0x08, 0x34, 0x56, 0x9b, 0x67, 0x7d, 0xd7, 0xa1, 0x59, 0xa8, 0x0d, 0xb9, 0x03,
-	0xbb, 0x1e, 0x4b, 0xb6, 0x29, 0x7a, 0x5c, 0xa2, 0x72, 0x4a, 0xf4, 0x8f, 0xd1, 0x24, 0xb0, 0xb9,
-	0xe8, 0xa1, 0x41, 0xe5, 0x94, 0xe8, 0xe2, 0x4b, 0xa2, 0xa8, 0x92, 0x41, 0xe5, 0x44, 0x08, 0x94,
-	0x3c, 0x1b, 0x3d, 0x51, 0x8a, 0x1a, 0x15, 0xcf, 0xa4, 0x03, 0xd5, 0x80, 0x71, 0x7b, 0x64, 0x73,
-	0x5b, 0x7c, 0xd9, 0x1a, 0xcd, 0x66, 0xeb, 0x0d, 0xd4, 0xd4, 0xba, 0xfd, 0x73, 0x8e, 0x16, 0x94,
-	0xfd, 0x70, 0xc4, 0xbe, 0xc8, 0x18, 0xe9, 0x60, 0x7d, 0xd3, 0xc1, 0x58, 0x6a, 0xde, 0xff, 0xe1,
-	0x26, 0xaa, 0xd8, 0xa7, 0xdc, 0x5e, 0x3a, 0x90, 0x36, 0x54, 0x02, 0x1f, 0xd1, 0x0f, 0x5d, 0xb1,
-	0xbd, 0x2a, 0x5d, 0x8c, 0xd6, 0x63, 0x68, 0x16, 0xda, 0xba, 0x29, 0x8a, 0x75, 0x0e, 0xa4, 0x58,
-	0x3f, 0xf2, 0x1c, 0xf6, 0x94, 0x1a, 0xcb, 0x5b, 0xe6, 0x40, 0xad, 0x45, 0x7a, 0x89, 0x29, 0xaf,
-	0x42, 0xde, 0x57, 0xeb, 0x08, 0x8c, 0xa5, 0xee, 0x6d, 0xfc, 0xf5, 0xaf, 0x50, 0x5f, 0x6e, 0xd5,
-	0xc6, 0x23, 0xa3, 0xd0, 0x70, 0x12, 0x43, 0x88, 0x53, 0x1c, 0xa4, 0xbd, 0x93, 0x97, 0xd4, 0xfd,
-	0x62, 0xac, 0x93, 0x85, 0x33, 0x85, 0xf7, 0x4b, 0x57, 0xbf, 0xef, 0x69, 0x74, 0xdf, 0x59, 0x91,
-	0x2f, 0xae, 0x66, 0xa6, 0x7e, 0x3d, 0x33, 0xf5, 0x3f, 0x33, 0x53, 0xff, 0x31, 0x37, 0xb5, 0xeb,
-	0xb9, 0xa9, 0xfd, 0x9a, 0x9b, 0xda, 0xfb, 0x67, 0xae, 0xcf, 0xbd, 0xe9, 0xb0, 0xeb, 0x44, 0x41,
-	0x4f, 0xbd, 0xa1, 0xf3, 0xc7, 0xf4, 0x9e, 0x5f, 0xf7, 0x4f, 0x31, 0xdc, 0x15, 0x6b, 0x4f, 0xff,
-	0x06, 0x00, 0x00, 0xff, 0xff, 0xa1, 0xb2, 0xfd, 0x65, 0x48, 0x06, 0x00, 0x00,
+	// 648 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x6a, 0x13, 0x4f,
+	0x1c, 0xcd, 0xfe, 0x9b, 0xaf, 0xff, 0x2f, 0xd9, 0xb6, 0x19, 0x83, 0x94, 0x58, 0xd3, 0xba, 0x8a,
+	0x2d, 0x08, 0x09, 0xa8, 0x77, 0xe2, 0x4d, 0x7b, 0x53, 0xa1, 0xa2, 0x4c, 0x2d, 0xa8, 0x08, 0x61,
+	0xb2, 0x1d, 0x77, 0x97, 0x66, 0x3f, 0xdc, 0x99, 0x05, 0x0b, 0x3e, 0x84, 0xaf, 0xe0, 0x2b, 0xf8,
+	0x14, 0xf5, 0xae, 0x97, 0x5e, 0x89, 0xb4, 0x2f, 0x22, 0xf3, 0x91, 0xdd, 0x49, 0xb6, 0x49, 0x11,
+	0xbc, 0xdb, 0xdf, 0x99, 0x33, 0x67, 0xce, 0xfc, 0xf6, 0xcc, 0x0c, 0x6c, 0x73, 0x1a, 0x9d, 0xd0,
+	0x34, 0x0c, 0x22, 0x3e, 0x64, 0x9c, 0x70, 0xca, 0xce, 0x22, 0x77, 0xc8, 0xcf, 0x12, 0xca, 0x06,
+	0x49, 0x1a, 0xf3, 0x18, 0x75, 0x0b, 0xc6, 0x20, 0x67, 0xf4, 0xba, 0x5e, 0xec, 0xc5, 0x92, 0x30,
+	0x14, 0x5f, 0x8a, 0xdb, 0xdb, 0x34, 0xd4, 0xa4, 0x86, 0xa9, 0xd4, 0xbb, 0x5b, 0x1a, 0x4d, 0x48,
+	0x4a, 0x42, 0x3d, 0xec, 0x7c, 0xaf, 0x41, 0xe3, 0x25, 0x65, 0x8c, 0x78, 0x14, 0x1d, 0x43, 0x87,
+	0x45, 0x24, 0x61, 0x7e, 0xcc, 0xd9, 0x28, 0xa5, 0x9f, 0x32, 0xca, 0xf8, 0x86, 0xb5, 0x6d, 0xed,
+	0xb6, 0x1e, 0x3f, 0x1c, 0x5c, 0x67, 0x68, 0x70, 0x34, 0xa5, 0x63, 0xc5, 0x3e, 0xa8, 0xe0, 0x75,
+	0x36, 0x87, 0xa1, 0xb7, 0x80, 0x4c, 0x59, 0x96, 0xc4, 0x11, 0xa3, 0x1b, 0xff, 0x49, 0xdd, 0x9d,
+	0x1b, 0x75, 0x15, 0xfd, 0xa0, 0x82, 0x3b, 0x6c, 0x1e, 0x44, 0x2f, 0xc0, 0x76, 0xfd, 0x2c, 0x3a,
+	0xcd, 0xcd, 0xae, 0x48, 0x51, 0xe7, 0x7a, 0xd1, 0x7d, 0x41, 0x2d, 0x8c, 0xb6, 0x5d, 0xa3, 0x46,
+	0x87, 0xb0, 0x3a, 0x95, 0xd2, 0x06, 0xab, 0x52, 0xeb, 0xfe, 0x52, 0xad, 0xdc, 0x9c, 0xed, 0x9a,
+	0x00, 0x7a, 0x07, 0xb7, 0x26, 0x81, 0xe7, 0xf3, 0xd1, 0x78, 0x12, 0xbb, 0x85, 0xbd, 0xda, 0xb2,
+	0x3d, 0x1f, 0x8a, 0x09, 0x7b, 0x82, 0x5f, 0x78, 0xec, 0x4c, 0xe6, 0x41, 0xf4, 0x01, 0xba, 0xb3,
+	0xd2, 0xda, 0x6e, 0x5d, 0x6a, 0xef, 0xde, 0xac, 0x9d, 0x7b, 0x46, 0x93, 0x12, 0x2a, 0xda, 0xa0,
+	0xe2, 0x91, 0x7b, 0x6e, 0x2c, 0x6b, 0xc3, 0x6b, 0xc9, 0x2d, 0xfc, 0xda, 0x89, 0x09, 0xa0, 0x57,
+	0xb0, 0x96, 0xab, 0x69, 0x9b, 0x4d, 0x29, 0xf7, 0x60, 0xb9, 0x5c, 0x6e, 0x71, 0x35, 0x99, 0x41,
+	0xf6, 0x6a, 0xb0, 0xc2, 0xb2, 0xd0, 0x79, 0x0a, 0xeb, 0xf3, 0xc9, 0x43, 0xdb, 0xd0, 0x0a, 0x22,
+	0x37, 0xa5, 0x21, 0x8d, 0x38, 0x99, 0xc8, 0xd8, 0x36, 0xb1, 0x09, 0x39, 0x3f, 0x2c, 0xe8, 0x94,
+	0x82, 0x85, 0x6e, 0x43, 0xdd, 0xa7, 0xa2, 0x11, 0x72, 0x4a, 0x15, 0xeb, 0x4a, 0xe0, 0x1f, 0xe3,
+	0x34, 0x24, 0x5c, 0x26, 0xd5, 0xc6, 0xba, 0x12, 0xb8, 0xfc, 0xd7, 0x4c, 0x86, 0xcd, 0xc6, 0xba,
+	0x42, 0x08, 0xaa, 0x3e, 0x61, 0xbe, 0x8c, 0x4d, 0x1b, 0xcb, 0x6f, 0xd4, 0x83, 0x66, 0x48, 0x39,
+	0x39, 0x21, 0x9c, 0xc8, 0x7f, 0xdf, 0xc6, 0x79, 0x8d, 0xb6, 0xa0, 0x35, 0x26, 0x8c, 0x8e, 0xf4,
+	0xe2, 0x75, 0xb9, 0x38, 0x08, 0xe8, 0x40, 0x19, 0xb8, 0x03, 0xff, 0x2b, 0x82, 0x50, 0x6d, 0xa8,
+	0xd9, 0x72, 0x98, 0x30, 0xdf, 0x79, 0x03, 0x6d, 0x33, 0xce, 0x7f, 0xbd, 0x8b, 0x2e, 0xd4, 0x82,
+	0xe8, 0x84, 0x7e, 0xd6, 0x9b, 0x50, 0x85, 0xf3, 0xcd, 0x02, 0x7b, 0x26, 0xd9, 0xff, 0x46, 0x57,
+	0xa0, 0xb2, 0x4b, 0xba, 0x39, 0xaa, 0x40, 0x1b, 0xd0, 0x08, 0x03, 0xc6, 0x82, 0xc8, 0x93, 0xcd,
+	0x69, 0xe2, 0x69, 0x29, 0xfa, 0xe6, 0xfa, 0xd4, 0x3d, 0x65, 0x59, 0x28, 0x1b, 0xd3, 0xc6, 0x79,
+	0xed, 0x3c, 0x82, 0x4e, 0xe9, 0xa4, 0x2c, 0xb2, 0xe9, 0x1c, 0x01, 0x2a, 0x47, 0x1f, 0x3d, 0x87,
+	0x96, 0x71, 0x84, 0xf4, 0x0d, 0xb7, 0x69, 0x46, 0x52, 0x5d, 0xa0, 0xc6, 0x54, 0x28, 0xce, 0x8a,
+	0xb3, 0x03, 0xf6, 0x4c, 0xee, 0x17, 0xae, 0xfe, 0x05, 0x56, 0x67, 0x13, 0xbd, 0xb0, 0x9d, 0x18,
+	0xd6, 0x5d, 0x41, 0x88, 0x58, 0xc6, 0x46, 0x2a, 0xf3, 0xfa, 0x82, 0xbc, 0x57, 0xb6, 0xb5, 0x3f,
+	0x65, 0x2a, 0xf1, 0xbd, 0xea, 0xf9, 0xaf, 0xad, 0x0a, 0x5e, 0x73, 0xe7, 0xe0, 0xe3, 0xf3, 0xcb,
+	0xbe, 0x75, 0x71, 0xd9, 0xb7, 0x7e, 0x5f, 0xf6, 0xad, 0xaf, 0x57, 0xfd, 0xca, 0xc5, 0x55, 0xbf,
+	0xf2, 0xf3, 0xaa, 0x5f, 0x79, 0xff, 0xcc, 0x0b, 0xb8, 0x9f, 0x8d, 0x07, 0x6e, 0x1c, 0x0e, 0xcd,
+	0xd7, 0xa1, 0xf8, 0x54, 0x6f, 0xcc, 0x75, 0xaf, 0xd4, 0xb8, 0x2e, 0xc7, 0x9e, 0xfc, 0x19, 0x00,
+	0x6f, 0xb2, 0x9f, 0x57, 0xc4, 0x06, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -894,6 +931,16 @@ func (m *SnapshotsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Incremental {
+		i--
+		if m.Incremental {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -917,6 +964,18 @@ func (m *SnapshotsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.BaseHash) > 0 {
+		i -= len(m.BaseHash)
+		copy(dAtA[i:], m.BaseHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.BaseHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Metadata) > 0 {
 		i -= len(m.Metadata)
 		copy(dAtA[i:], m.Metadata)
@@ -1007,6 +1066,13 @@ func (m *ChunkResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Missing {
 		i--
 		if m.Missing {
@@ -1296,6 +1362,9 @@ func (m *SnapshotsRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.Incremental {
+		n += 2
+	}
 	return n
 }
 
@@ -1322,6 +1391,13 @@ func (m *SnapshotsResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
+	l = len(m.BaseHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1365,6 +1441,10 @@ func (m *ChunkResponse) Size() (n int) {
 	if m.Missing {
 		n += 2
 	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1784,6 +1864,26 @@ func (m *SnapshotsRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: SnapshotsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Incremental", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Incremental = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1959,6 +2059,59 @@ func (m *SnapshotsResponse) Unmarshal(dAtA []byte) error {
 				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BaseHash = append(m.BaseHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BaseHash == nil {
+				m.BaseHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2227,6 +2380,40 @@ func (m *ChunkResponse) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Missing = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = append(m.Checksum[:0], dAtA[iNdEx:postIndex]...)
+			if m.Checksum == nil {
+				m.Checksum = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -129,6 +129,10 @@ type BlockParams struct {
 	// Max gas per block.
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	// Size of the parts blocks are split into for gossiping, in bytes.
+	// Note: must be 0, meaning the default of 1MB, or a power of two between
+	// 16KB and 1MB
+	PartSizeBytes uint32 `protobuf:"varint,3,opt,name=part_size_bytes,json=partSizeBytes,proto3" json:"part_size_bytes,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
@@ -178,6 +182,13 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetPartSizeBytes() uint32 {
+	if m != nil {
+		return m.PartSizeBytes
+	}
+	return 0
+}
+
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	// Max age of evidence, in blocks.
@@ -255,6 +266,11 @@ func (m *EvidenceParams) GetMaxBytes() int64 {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes,proto3" json:"pub_key_types,omitempty"`
+	// aggregate_commits_enable_height configures the first height whose commit
+	// aggregates the signatures of the BLS12-381 validators that voted for the
+	// block into a single signature, when it is included in the next block.
+	// 0 disables aggregation.
+	AggregateCommitsEnableHeight int64 `protobuf:"varint,2,opt,name=aggregate_commits_enable_height,json=aggregateCommitsEnableHeight,proto3" json:"aggregate_commits_enable_height,omitempty"`
 }
 
 func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
@@ -297,6 +313,13 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 	return nil
 }
 
+func (m *ValidatorParams) GetAggregateCommitsEnableHeight() int64 {
+	if m != nil {
+		return m.AggregateCommitsEnableHeight
+	}
+	return 0
+}
+
 // VersionParams contains the ABCI application version.
 type VersionParams struct {
 	AppVersion uint64 `protobuf:"varint,1,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
@@ -346,8 +369,9 @@ func (m *VersionParams) GetAppVersion() uint64 {
 //
 // It is hashed into the Header.ConsensusHash.
 type HashedParams struct {
-	BlockMaxBytes int64 `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
-	BlockMaxGas   int64 `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+	BlockMaxBytes      int64  `protobuf:"varint,1,opt,name=block_max_bytes,json=blockMaxBytes,proto3" json:"block_max_bytes,omitempty"`
+	BlockMaxGas        int64  `protobuf:"varint,2,opt,name=block_max_gas,json=blockMaxGas,proto3" json:"block_max_gas,omitempty"`
+	BlockPartSizeBytes uint32 `protobuf:"varint,3,opt,name=block_part_size_bytes,json=blockPartSizeBytes,proto3" json:"block_part_size_bytes,omitempty"`
 }
 
 func (m *HashedParams) Reset()         { *m = HashedParams{} }
@@ -397,6 +421,13 @@ func (m *HashedParams) GetBlockMaxGas() int64 {
 	return 0
 }
 
+func (m *HashedParams) GetBlockPartSizeBytes() uint32 {
+	if m != nil {
+		return m.BlockPartSizeBytes
+	}
+	return 0
+}
+
 // SynchronyParams configure the bounds under which a proposed block's timestamp is considered valid.
 // These parameters are part of the proposer-based timestamps algorithm. For more information,
 // see the specification of proposer-based timestamps:
@@ -408,6 +439,10 @@ type SynchronyParams struct {
 	// precision bounds how skewed a proposer's clock may be from any validator
 	// on the network while still producing valid proposals.
 	Precision *time.Duration `protobuf:"bytes,2,opt,name=precision,proto3,stdduration" json:"precision,omitempty"`
+	// median_time_enable_height configures the first height at which the time
+	// of a block must not be before the median time of its last commit by more
+	// than the precision. 0 disables the check.
+	MedianTimeEnableHeight int64 `protobuf:"varint,3,opt,name=median_time_enable_height,json=medianTimeEnableHeight,proto3" json:"median_time_enable_height,omitempty"`
 }
 
 func (m *SynchronyParams) Reset()         { *m = SynchronyParams{} }
@@ -457,6 +492,13 @@ func (m *SynchronyParams) GetPrecision() *time.Duration {
 	return nil
 }
 
+func (m *SynchronyParams) GetMedianTimeEnableHeight() int64 {
+	if m != nil {
+		return m.MedianTimeEnableHeight
+	}
+	return 0
+}
+
 // TimeoutParams configure the timeouts for the steps of the Tendermint consensus algorithm.
 type TimeoutParams struct {
 	// These fields configure the timeouts for the propose step of the Tendermint
@@ -653,55 +695,60 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 762 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xdf, 0x6e, 0xdb, 0x36,
-	0x14, 0xc6, 0xad, 0xd8, 0x71, 0xec, 0xe3, 0x38, 0x0e, 0xb8, 0x0d, 0xd3, 0xb2, 0x59, 0xce, 0x74,
-	0x31, 0x04, 0x18, 0x20, 0x07, 0x09, 0x86, 0x60, 0xc0, 0xfe, 0x20, 0xb6, 0x83, 0x65, 0x18, 0x32,
-	0x0c, 0x5a, 0xda, 0x8b, 0xdc, 0x08, 0x94, 0xcc, 0xca, 0x42, 0x2c, 0x51, 0x10, 0x29, 0xc3, 0x7a,
-	0x8b, 0x5e, 0x15, 0x7d, 0x84, 0xf6, 0xa6, 0xcf, 0x91, 0xcb, 0x5c, 0xf6, 0xaa, 0x2d, 0x9c, 0x37,
-	0xe8, 0x13, 0x14, 0xa4, 0xa8, 0x38, 0x76, 0x9a, 0xc6, 0x57, 0xa6, 0x79, 0xbe, 0x1f, 0x0f, 0xf9,
-	0x9d, 0x23, 0x12, 0xda, 0x9c, 0x44, 0x43, 0x92, 0x84, 0x41, 0xc4, 0xbb, 0x3c, 0x8b, 0x09, 0xeb,
-	0xc6, 0x38, 0xc1, 0x21, 0xb3, 0xe2, 0x84, 0x72, 0x8a, 0xb6, 0xe7, 0x61, 0x4b, 0x86, 0x77, 0xbe,
-	0xf6, 0xa9, 0x4f, 0x65, 0xb0, 0x2b, 0x46, 0xb9, 0x6e, 0xc7, 0xf0, 0x29, 0xf5, 0xc7, 0xa4, 0x2b,
-	0xff, 0xb9, 0xe9, 0xb3, 0xee, 0x30, 0x4d, 0x30, 0x0f, 0x68, 0x94, 0xc7, 0xcd, 0x37, 0x65, 0x68,
-	0xf5, 0x69, 0xc4, 0x48, 0xc4, 0x52, 0xf6, 0x9f, 0xcc, 0x80, 0x0e, 0x61, 0xdd, 0x1d, 0x53, 0xef,
-	0x52, 0xd7, 0x76, 0xb5, 0xbd, 0xc6, 0x41, 0xdb, 0x5a, 0xce, 0x65, 0xf5, 0x44, 0x38, 0x57, 0xdb,
-	0xb9, 0x16, 0xfd, 0x06, 0x35, 0x32, 0x09, 0x86, 0x24, 0xf2, 0x88, 0xbe, 0x26, 0xb9, 0xdd, 0xfb,
-	0xdc, 0x89, 0x52, 0x28, 0xf4, 0x96, 0x40, 0x7f, 0x42, 0x7d, 0x82, 0xc7, 0xc1, 0x10, 0x73, 0x9a,
-	0xe8, 0x65, 0x89, 0xff, 0x78, 0x1f, 0x7f, 0x5a, 0x48, 0x14, 0x3f, 0x67, 0xd0, 0xaf, 0xb0, 0x31,
-	0x21, 0x09, 0x0b, 0x68, 0xa4, 0x57, 0x24, 0xde, 0xf9, 0x0c, 0x9e, 0x0b, 0x14, 0x5c, 0xe8, 0x45,
-	0x6e, 0x96, 0x45, 0xde, 0x28, 0xa1, 0x51, 0xa6, 0xaf, 0x3f, 0x94, 0xfb, 0xff, 0x42, 0x52, 0xe4,
-	0xbe, 0x65, 0x44, 0x6e, 0x1e, 0x84, 0x84, 0xa6, 0x5c, 0xaf, 0x3e, 0x94, 0xfb, 0x3c, 0x17, 0x14,
-	0xb9, 0x95, 0x1e, 0xed, 0x43, 0x05, 0xbb, 0x5e, 0xa0, 0x6f, 0x48, 0xee, 0x87, 0xfb, 0xdc, 0x71,
-	0xaf, 0xff, 0xb7, 0x82, 0xa4, 0xd2, 0xec, 0x43, 0xe3, 0x8e, 0xfb, 0xe8, 0x7b, 0xa8, 0x87, 0x78,
-	0xea, 0xb8, 0x19, 0x27, 0x4c, 0xd6, 0xab, 0x6c, 0xd7, 0x42, 0x3c, 0xed, 0x89, 0xff, 0xe8, 0x5b,
-	0xd8, 0x10, 0x41, 0x1f, 0x33, 0x59, 0x92, 0xb2, 0x5d, 0x0d, 0xf1, 0xf4, 0x2f, 0xcc, 0xcc, 0xd7,
-	0x1a, 0x6c, 0x2d, 0xd6, 0x02, 0xfd, 0x0c, 0x48, 0x68, 0xb1, 0x4f, 0x9c, 0x28, 0x0d, 0x1d, 0x59,
-	0xd4, 0x62, 0xc5, 0x56, 0x88, 0xa7, 0xc7, 0x3e, 0xf9, 0x37, 0x0d, 0x65, 0x6a, 0x86, 0xce, 0x60,
-	0xbb, 0x10, 0x17, 0xfd, 0xa4, 0x8a, 0xfe, 0x9d, 0x95, 0x37, 0x9c, 0x55, 0x34, 0x9c, 0x35, 0x50,
-	0x82, 0x5e, 0xed, 0xea, 0x5d, 0xa7, 0xf4, 0xf2, 0x7d, 0x47, 0xb3, 0xb7, 0xf2, 0xf5, 0x8a, 0xc8,
-	0xe2, 0x21, 0xca, 0x8b, 0x87, 0x30, 0x7f, 0x81, 0xd6, 0x52, 0xdd, 0x91, 0x09, 0xcd, 0x38, 0x75,
-	0x9d, 0x4b, 0x92, 0x39, 0xd2, 0x25, 0x5d, 0xdb, 0x2d, 0xef, 0xd5, 0xed, 0x46, 0x9c, 0xba, 0xff,
-	0x90, 0xec, 0x5c, 0x4c, 0x99, 0xfb, 0xd0, 0x5c, 0xa8, 0x37, 0xea, 0x40, 0x03, 0xc7, 0xb1, 0x53,
-	0x74, 0x89, 0x38, 0x59, 0xc5, 0x06, 0x1c, 0xc7, 0x4a, 0x66, 0x5e, 0xc0, 0xe6, 0x29, 0x66, 0x23,
-	0x32, 0x54, 0xc0, 0x4f, 0xd0, 0x92, 0x2e, 0x38, 0xcb, 0x06, 0x37, 0xe5, 0xf4, 0x59, 0xe1, 0xb2,
-	0x09, 0xcd, 0xb9, 0x6e, 0xee, 0x75, 0xa3, 0x50, 0x09, 0xc3, 0x5f, 0x68, 0xd0, 0x5a, 0xea, 0x20,
-	0x34, 0x80, 0x66, 0x48, 0x18, 0x93, 0x26, 0x92, 0x31, 0xce, 0xd4, 0xe7, 0xf6, 0x05, 0x07, 0x2b,
-	0xd2, 0xbd, 0x4d, 0x45, 0x0d, 0x04, 0x84, 0x7e, 0x87, 0x7a, 0x9c, 0x10, 0x2f, 0x60, 0x2b, 0xd5,
-	0x20, 0x5f, 0x61, 0x4e, 0x98, 0x1f, 0xd7, 0xa0, 0xb9, 0xd0, 0x9b, 0xa2, 0x9b, 0xe3, 0x84, 0xc6,
-	0x94, 0x91, 0x55, 0x37, 0x54, 0xe8, 0xc5, 0x89, 0xd4, 0x50, 0x9c, 0x88, 0xe3, 0x55, 0xf7, 0xb3,
-	0xa9, 0xa8, 0x81, 0x80, 0xd0, 0x21, 0x54, 0x26, 0x94, 0x13, 0x75, 0x0d, 0x3c, 0x0a, 0x4b, 0x31,
-	0xfa, 0x03, 0x40, 0xfc, 0xaa, 0xbc, 0x95, 0x15, 0x7d, 0x10, 0x48, 0x9e, 0xf4, 0x08, 0xaa, 0x1e,
-	0x0d, 0xc3, 0x80, 0xab, 0x1b, 0xe0, 0x51, 0x56, 0xc9, 0xd1, 0x01, 0x7c, 0xe3, 0x66, 0x31, 0x66,
-	0xcc, 0xc9, 0x27, 0x9c, 0xbb, 0x57, 0x41, 0xcd, 0xfe, 0x2a, 0x0f, 0xf6, 0x65, 0x4c, 0x19, 0x6d,
-	0x46, 0x00, 0xf3, 0xef, 0x1a, 0x1d, 0x43, 0x5b, 0x6e, 0x9d, 0x4c, 0x39, 0x89, 0x44, 0x51, 0x98,
-	0x43, 0x22, 0xec, 0x8e, 0x89, 0x33, 0x22, 0x81, 0x3f, 0xe2, 0xaa, 0xeb, 0x76, 0x84, 0xe8, 0xe4,
-	0x56, 0x73, 0x22, 0x25, 0xa7, 0x52, 0x81, 0xda, 0x00, 0x09, 0xf1, 0x46, 0xc4, 0xbb, 0x74, 0xf8,
-	0x54, 0xba, 0x5e, 0xb3, 0xeb, 0x6a, 0xe6, 0x7c, 0xda, 0x7b, 0xf2, 0x6a, 0x66, 0x68, 0x57, 0x33,
-	0x43, 0xbb, 0x9e, 0x19, 0xda, 0x87, 0x99, 0xa1, 0x3d, 0xbf, 0x31, 0x4a, 0xd7, 0x37, 0x46, 0xe9,
-	0xed, 0x8d, 0x51, 0xba, 0x38, 0xf2, 0x03, 0x3e, 0x4a, 0x5d, 0xcb, 0xa3, 0x61, 0xf7, 0xee, 0xa3,
-	0x33, 0x1f, 0xe6, 0xaf, 0xca, 0xf2, 0x83, 0xe4, 0x56, 0xe5, 0xfc, 0xe1, 0xa7, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xf0, 0x06, 0x54, 0xd3, 0xab, 0x06, 0x00, 0x00,
+	// 841 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xc7, 0xcd, 0x48, 0xb6, 0xa5, 0x91, 0x19, 0x05, 0xdb, 0xa6, 0x65, 0xdc, 0x98, 0x72, 0x79,
+	0x08, 0x02, 0x14, 0xa0, 0xd2, 0xf8, 0x10, 0x04, 0xe8, 0x07, 0x2c, 0xdb, 0x68, 0x8a, 0x22, 0x45,
+	0xc1, 0xb8, 0x3d, 0xf4, 0x42, 0x2c, 0xa9, 0x29, 0x45, 0x58, 0xe4, 0x12, 0xdc, 0xa5, 0x21, 0x06,
+	0x7d, 0x85, 0x02, 0x3d, 0xf6, 0x11, 0xda, 0x4b, 0x9f, 0x23, 0xc7, 0xf4, 0xd6, 0x53, 0x5b, 0xd8,
+	0x6f, 0xd0, 0x27, 0x28, 0xf6, 0x83, 0xfa, 0x72, 0x82, 0xea, 0x24, 0x6a, 0xe7, 0xf7, 0xdf, 0xe1,
+	0xce, 0x7f, 0x86, 0x0b, 0x07, 0x02, 0xf3, 0x31, 0x96, 0x59, 0x9a, 0x8b, 0xa1, 0xa8, 0x0b, 0xe4,
+	0xc3, 0x82, 0x96, 0x34, 0xe3, 0x7e, 0x51, 0x32, 0xc1, 0xc8, 0x9d, 0x45, 0xd8, 0x57, 0xe1, 0xfd,
+	0x77, 0x13, 0x96, 0x30, 0x15, 0x1c, 0xca, 0x27, 0xcd, 0xed, 0xbb, 0x09, 0x63, 0xc9, 0x14, 0x87,
+	0xea, 0x5f, 0x54, 0xfd, 0x30, 0x1c, 0x57, 0x25, 0x15, 0x29, 0xcb, 0x75, 0xdc, 0xfb, 0xbd, 0x05,
+	0xfd, 0x13, 0x96, 0x73, 0xcc, 0x79, 0xc5, 0xbf, 0x51, 0x19, 0xc8, 0x11, 0x6c, 0x47, 0x53, 0x16,
+	0x5f, 0x38, 0xd6, 0xa1, 0xf5, 0xb0, 0xf7, 0xf8, 0xc0, 0x5f, 0xcf, 0xe5, 0x8f, 0x64, 0x58, 0xd3,
+	0x81, 0x66, 0xc9, 0x27, 0xd0, 0xc1, 0xcb, 0x74, 0x8c, 0x79, 0x8c, 0xce, 0x2d, 0xa5, 0x3b, 0xbc,
+	0xa9, 0x3b, 0x33, 0x84, 0x91, 0xce, 0x15, 0xe4, 0x73, 0xe8, 0x5e, 0xd2, 0x69, 0x3a, 0xa6, 0x82,
+	0x95, 0x4e, 0x4b, 0xc9, 0x3f, 0xbc, 0x29, 0xff, 0xae, 0x41, 0x8c, 0x7e, 0xa1, 0x21, 0x4f, 0x61,
+	0xf7, 0x12, 0x4b, 0x9e, 0xb2, 0xdc, 0x69, 0x2b, 0xf9, 0xe0, 0x0d, 0x72, 0x0d, 0x18, 0x71, 0xc3,
+	0xcb, 0xdc, 0xbc, 0xce, 0xe3, 0x49, 0xc9, 0xf2, 0xda, 0xd9, 0x7e, 0x5b, 0xee, 0x17, 0x0d, 0xd2,
+	0xe4, 0x9e, 0x6b, 0x64, 0x6e, 0x91, 0x66, 0xc8, 0x2a, 0xe1, 0xec, 0xbc, 0x2d, 0xf7, 0xb9, 0x06,
+	0x9a, 0xdc, 0x86, 0x27, 0x8f, 0xa0, 0x4d, 0xa3, 0x38, 0x75, 0x76, 0x95, 0xee, 0xfe, 0x4d, 0xdd,
+	0xf1, 0xe8, 0xe4, 0x4b, 0x23, 0x52, 0xa4, 0x77, 0x01, 0xbd, 0xa5, 0xea, 0x93, 0x0f, 0xa0, 0x9b,
+	0xd1, 0x59, 0x18, 0xd5, 0x02, 0xb9, 0xf2, 0xab, 0x15, 0x74, 0x32, 0x3a, 0x1b, 0xc9, 0xff, 0xe4,
+	0x7d, 0xd8, 0x95, 0xc1, 0x84, 0x72, 0x65, 0x49, 0x2b, 0xd8, 0xc9, 0xe8, 0xec, 0x0b, 0xca, 0xc9,
+	0x03, 0xe8, 0x17, 0xb4, 0x14, 0x21, 0x4f, 0x5f, 0xa2, 0xd1, 0xca, 0xa2, 0xdb, 0x81, 0x2d, 0x97,
+	0x5f, 0xa4, 0x2f, 0x51, 0x6d, 0xe0, 0xfd, 0x66, 0xc1, 0xed, 0x55, 0xcf, 0xc8, 0x47, 0x40, 0xe4,
+	0x9e, 0x34, 0xc1, 0x30, 0xaf, 0xb2, 0x50, 0x99, 0xdf, 0x64, 0xee, 0x67, 0x74, 0x76, 0x9c, 0xe0,
+	0xd7, 0x55, 0xa6, 0x5e, 0x91, 0x93, 0xe7, 0x70, 0xa7, 0x81, 0x9b, 0xbe, 0x33, 0xcd, 0x71, 0xcf,
+	0xd7, 0x8d, 0xe9, 0x37, 0x8d, 0xe9, 0x9f, 0x1a, 0x60, 0xd4, 0x79, 0xf5, 0xd7, 0x60, 0xeb, 0x97,
+	0xbf, 0x07, 0x56, 0x70, 0x5b, 0xef, 0xd7, 0x44, 0x56, 0x0f, 0xdb, 0x5a, 0x3d, 0xac, 0xf7, 0x23,
+	0xf4, 0xd7, 0xfa, 0x83, 0x78, 0x60, 0x17, 0x55, 0x14, 0x5e, 0x60, 0x1d, 0xaa, 0x6a, 0x3a, 0xd6,
+	0x61, 0xeb, 0x61, 0x37, 0xe8, 0x15, 0x55, 0xf4, 0x15, 0xd6, 0xe7, 0x72, 0x89, 0x9c, 0xc1, 0x80,
+	0x26, 0x49, 0x89, 0x09, 0x15, 0x18, 0xc6, 0x2c, 0xcb, 0x52, 0xc1, 0x43, 0xcc, 0x69, 0x34, 0xc5,
+	0x70, 0x82, 0x69, 0x32, 0x11, 0xa6, 0x76, 0xf7, 0xe7, 0xd8, 0x89, 0xa6, 0xce, 0x14, 0xf4, 0x4c,
+	0x31, 0xde, 0x23, 0xb0, 0x57, 0xda, 0x8b, 0x0c, 0xa0, 0x47, 0x8b, 0x22, 0x6c, 0x9a, 0x52, 0x16,
+	0xa8, 0x1d, 0x00, 0x2d, 0x0a, 0x83, 0x79, 0x3f, 0x59, 0xb0, 0xf7, 0x8c, 0xf2, 0x09, 0x8e, 0x8d,
+	0xe2, 0x01, 0xf4, 0x55, 0x35, 0xc3, 0x75, 0x43, 0x6d, 0xb5, 0xfc, 0xbc, 0x71, 0xd5, 0x03, 0x7b,
+	0xc1, 0x2d, 0xbc, 0xed, 0x35, 0x94, 0x34, 0xf8, 0x63, 0xb8, 0xab, 0x99, 0x37, 0xdb, 0x4c, 0x22,
+	0xd3, 0x42, 0x4b, 0x5e, 0xff, 0x61, 0x41, 0x7f, 0xad, 0xc9, 0xc9, 0x29, 0xd8, 0x19, 0x72, 0xae,
+	0xfc, 0xc3, 0x29, 0xad, 0x1d, 0xeb, 0xff, 0xcc, 0x6b, 0x2b, 0xe3, 0xf6, 0x8c, 0xea, 0x54, 0x8a,
+	0xc8, 0xa7, 0xd0, 0x2d, 0x4a, 0x8c, 0x53, 0xbe, 0x91, 0xfd, 0x7a, 0x87, 0x85, 0x82, 0x3c, 0x85,
+	0x7b, 0x19, 0x8e, 0x53, 0x9a, 0x87, 0x72, 0x6a, 0xd6, 0xbc, 0xd1, 0x5d, 0xf0, 0x9e, 0x06, 0xe4,
+	0x90, 0xad, 0xb8, 0xf2, 0xef, 0x2d, 0xb0, 0x57, 0x26, 0x4f, 0xce, 0x6a, 0x51, 0xb2, 0x82, 0x71,
+	0xdc, 0xf4, 0x2c, 0x0d, 0x2f, 0x8b, 0x61, 0x1e, 0x65, 0x31, 0x04, 0xdd, 0xf4, 0x28, 0x7b, 0x46,
+	0x75, 0x2a, 0x45, 0xe4, 0x08, 0xda, 0x97, 0x4c, 0xa0, 0xd3, 0xda, 0x4c, 0xac, 0x60, 0xf2, 0x19,
+	0x80, 0xfc, 0x35, 0x79, 0xdb, 0x1b, 0x96, 0x50, 0x4a, 0x74, 0xd2, 0x27, 0xb0, 0xa3, 0x5b, 0xdb,
+	0xd9, 0xde, 0x4c, 0x6b, 0x70, 0xf2, 0x18, 0xee, 0x46, 0x75, 0x41, 0x39, 0x37, 0xa3, 0x11, 0x2e,
+	0x7f, 0xe8, 0x3a, 0xc1, 0x3b, 0x3a, 0xa8, 0x07, 0xc2, 0x14, 0xda, 0xcb, 0x01, 0x16, 0x5f, 0x2d,
+	0x72, 0x0c, 0x07, 0xea, 0xd5, 0x71, 0x26, 0x30, 0x97, 0x7e, 0xae, 0x4f, 0x97, 0xee, 0xf1, 0x7d,
+	0x09, 0x9d, 0xcd, 0x99, 0x65, 0x17, 0xc9, 0x01, 0x40, 0x89, 0xf1, 0x04, 0xe3, 0x8b, 0x50, 0xcc,
+	0x54, 0xd5, 0x3b, 0x41, 0xd7, 0xac, 0x9c, 0xcf, 0x46, 0xdf, 0xfe, 0x7a, 0xe5, 0x5a, 0xaf, 0xae,
+	0x5c, 0xeb, 0xf5, 0x95, 0x6b, 0xfd, 0x73, 0xe5, 0x5a, 0x3f, 0x5f, 0xbb, 0x5b, 0xaf, 0xaf, 0xdd,
+	0xad, 0x3f, 0xaf, 0xdd, 0xad, 0xef, 0x9f, 0x24, 0xa9, 0x98, 0x54, 0x91, 0x1f, 0xb3, 0x6c, 0xb8,
+	0x7c, 0xa5, 0x2e, 0x1e, 0xf5, 0x9d, 0xb9, 0x7e, 0xdd, 0x46, 0x3b, 0x6a, 0xfd, 0xe8, 0xbf, 0x01,
+	0x00, 0x27, 0xf4, 0xbe, 0xca, 0x89, 0x07, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -771,6 +818,9 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.MaxGas != that1.MaxGas {
 		return false
 	}
+	if this.PartSizeBytes != that1.PartSizeBytes {
+		return false
+	}
 	return true
 }
 func (this *EvidenceParams) Equal(that interface{}) bool {
@@ -830,6 +880,9 @@ func (this *ValidatorParams) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.AggregateCommitsEnableHeight != that1.AggregateCommitsEnableHeight {
+		return false
+	}
 	return true
 }
 func (this *VersionParams) Equal(that interface{}) bool {
@@ -881,6 +934,9 @@ func (this *HashedParams) Equal(that interface{}) bool {
 	if this.BlockMaxGas != that1.BlockMaxGas {
 		return false
 	}
+	if this.BlockPartSizeBytes != that1.BlockPartSizeBytes {
+		return false
+	}
 	return true
 }
 func (this *SynchronyParams) Equal(that interface{}) bool {
@@ -920,6 +976,9 @@ func (this *SynchronyParams) Equal(that interface{}) bool {
 	} else if that1.Precision != nil {
 		return false
 	}
+	if this.MedianTimeEnableHeight != that1.MedianTimeEnableHeight {
+		return false
+	}
 	return true
 }
 func (this *TimeoutParams) Equal(that interface{}) bool {
@@ -1145,6 +1204,11 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PartSizeBytes))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.MaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.MaxGas))
 		i--
@@ -1219,6 +1283,11 @@ func (m *ValidatorParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AggregateCommitsEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.AggregateCommitsEnableHeight))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.PubKeyTypes) > 0 {
 		for iNdEx := len(m.PubKeyTypes) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.PubKeyTypes[iNdEx])
@@ -1279,6 +1348,11 @@ func (m *HashedParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockPartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BlockPartSizeBytes))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.BlockMaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.BlockMaxGas))
 		i--
@@ -1312,6 +1386,11 @@ func (m *SynchronyParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MedianTimeEnableHeight != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MedianTimeEnableHeight))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Precision != nil {
 		n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Precision, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Precision):])
 		if err9 != nil {
@@ -1516,6 +1595,9 @@ func (m *BlockParams) Size() (n int) {
 	if m.MaxGas != 0 {
 		n += 1 + sovParams(uint64(m.MaxGas))
 	}
+	if m.PartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.PartSizeBytes))
+	}
 	return n
 }
 
@@ -1548,6 +1630,9 @@ func (m *ValidatorParams) Size() (n int) {
 			n += 1 + l + sovParams(uint64(l))
 		}
 	}
+	if m.AggregateCommitsEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.AggregateCommitsEnableHeight))
+	}
 	return n
 }
 
@@ -1575,6 +1660,9 @@ func (m *HashedParams) Size() (n int) {
 	if m.BlockMaxGas != 0 {
 		n += 1 + sovParams(uint64(m.BlockMaxGas))
 	}
+	if m.BlockPartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.BlockPartSizeBytes))
+	}
 	return n
 }
 
@@ -1592,6 +1680,9 @@ func (m *SynchronyParams) Size() (n int) {
 		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Precision)
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.MedianTimeEnableHeight != 0 {
+		n += 1 + sovParams(uint64(m.MedianTimeEnableHeight))
+	}
 	return n
 }
 
@@ -2017,6 +2108,25 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSizeBytes", wireType)
+			}
+			m.PartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -2220,6 +2330,25 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 			}
 			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateCommitsEnableHeight", wireType)
+			}
+			m.AggregateCommitsEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AggregateCommitsEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -2377,6 +2506,25 @@ func (m *HashedParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSizeBytes", wireType)
+			}
+			m.BlockPartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockPartSizeBytes |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -2499,6 +2647,25 @@ func (m *SynchronyParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MedianTimeEnableHeight", wireType)
+			}
+			m.MedianTimeEnableHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MedianTimeEnableHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
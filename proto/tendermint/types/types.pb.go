@@ -37,6 +37,9 @@ const (
 	BlockIDFlagAbsent  BlockIDFlag = 1
 	BlockIDFlagCommit  BlockIDFlag = 2
 	BlockIDFlagNil     BlockIDFlag = 3
+	// voted for the block, with a signature aggregated into the
+	// aggregated_signature of the commit
+	BlockIDFlagAggregated BlockIDFlag = 4
 )
 
 var BlockIDFlag_name = map[int32]string{
@@ -44,13 +47,15 @@ var BlockIDFlag_name = map[int32]string{
 	1: "BLOCK_ID_FLAG_ABSENT",
 	2: "BLOCK_ID_FLAG_COMMIT",
 	3: "BLOCK_ID_FLAG_NIL",
+	4: "BLOCK_ID_FLAG_AGGREGATED",
 }
 
 var BlockIDFlag_value = map[string]int32{
-	"BLOCK_ID_FLAG_UNKNOWN": 0,
-	"BLOCK_ID_FLAG_ABSENT":  1,
-	"BLOCK_ID_FLAG_COMMIT":  2,
-	"BLOCK_ID_FLAG_NIL":     3,
+	"BLOCK_ID_FLAG_UNKNOWN":    0,
+	"BLOCK_ID_FLAG_ABSENT":     1,
+	"BLOCK_ID_FLAG_COMMIT":     2,
+	"BLOCK_ID_FLAG_NIL":        3,
+	"BLOCK_ID_FLAG_AGGREGATED": 4,
 }
 
 func (x BlockIDFlag) String() string {
@@ -208,6 +213,68 @@ func (m *Part) GetProof() crypto.Proof {
 	return crypto.Proof{}
 }
 
+// ParityPart is an erasure-coded part of a block, computed from the parts of
+// its part set.
+type ParityPart struct {
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Size_ uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Bytes []byte `protobuf:"bytes,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *ParityPart) Reset()         { *m = ParityPart{} }
+func (m *ParityPart) String() string { return proto.CompactTextString(m) }
+func (*ParityPart) ProtoMessage()    {}
+func (*ParityPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{2}
+}
+func (m *ParityPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ParityPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ParityPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ParityPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParityPart.Merge(m, src)
+}
+func (m *ParityPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *ParityPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParityPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParityPart proto.InternalMessageInfo
+
+func (m *ParityPart) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ParityPart) GetSize_() uint32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *ParityPart) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
 // BlockID
 type BlockID struct {
 	Hash          []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -218,7 +285,7 @@ func (m *BlockID) Reset()         { *m = BlockID{} }
 func (m *BlockID) String() string { return proto.CompactTextString(m) }
 func (*BlockID) ProtoMessage()    {}
 func (*BlockID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{2}
+	return fileDescriptor_d3a6e55e2345de56, []int{3}
 }
 func (m *BlockID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -288,7 +355,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{3}
+	return fileDescriptor_d3a6e55e2345de56, []int{4}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -427,7 +494,7 @@ func (m *Data) Reset()         { *m = Data{} }
 func (m *Data) String() string { return proto.CompactTextString(m) }
 func (*Data) ProtoMessage()    {}
 func (*Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{4}
+	return fileDescriptor_d3a6e55e2345de56, []int{5}
 }
 func (m *Data) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -471,7 +538,7 @@ func (m *TxKey) Reset()         { *m = TxKey{} }
 func (m *TxKey) String() string { return proto.CompactTextString(m) }
 func (*TxKey) ProtoMessage()    {}
 func (*TxKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{5}
+	return fileDescriptor_d3a6e55e2345de56, []int{6}
 }
 func (m *TxKey) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -532,7 +599,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{6}
+	return fileDescriptor_d3a6e55e2345de56, []int{7}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -638,13 +705,16 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// aggregate of the BLS12-381 signatures of the signatures flagged as
+	// BLOCK_ID_FLAG_AGGREGATED
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{7}
+	return fileDescriptor_d3a6e55e2345de56, []int{8}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -701,6 +771,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=tendermint.types.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -713,7 +790,7 @@ func (m *CommitSig) Reset()         { *m = CommitSig{} }
 func (m *CommitSig) String() string { return proto.CompactTextString(m) }
 func (*CommitSig) ProtoMessage()    {}
 func (*CommitSig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{8}
+	return fileDescriptor_d3a6e55e2345de56, []int{9}
 }
 func (m *CommitSig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -781,7 +858,7 @@ func (m *ExtendedCommit) Reset()         { *m = ExtendedCommit{} }
 func (m *ExtendedCommit) String() string { return proto.CompactTextString(m) }
 func (*ExtendedCommit) ProtoMessage()    {}
 func (*ExtendedCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{9}
+	return fileDescriptor_d3a6e55e2345de56, []int{10}
 }
 func (m *ExtendedCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -855,7 +932,7 @@ func (m *ExtendedCommitSig) Reset()         { *m = ExtendedCommitSig{} }
 func (m *ExtendedCommitSig) String() string { return proto.CompactTextString(m) }
 func (*ExtendedCommitSig) ProtoMessage()    {}
 func (*ExtendedCommitSig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{10}
+	return fileDescriptor_d3a6e55e2345de56, []int{11}
 }
 func (m *ExtendedCommitSig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -945,7 +1022,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{11}
+	return fileDescriptor_d3a6e55e2345de56, []int{12}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1067,7 +1144,7 @@ func (m *SignedHeader) Reset()         { *m = SignedHeader{} }
 func (m *SignedHeader) String() string { return proto.CompactTextString(m) }
 func (*SignedHeader) ProtoMessage()    {}
 func (*SignedHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{12}
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
 }
 func (m *SignedHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1119,7 +1196,7 @@ func (m *LightBlock) Reset()         { *m = LightBlock{} }
 func (m *LightBlock) String() string { return proto.CompactTextString(m) }
 func (*LightBlock) ProtoMessage()    {}
 func (*LightBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{13}
+	return fileDescriptor_d3a6e55e2345de56, []int{14}
 }
 func (m *LightBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1173,7 +1250,7 @@ func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
 func (m *BlockMeta) String() string { return proto.CompactTextString(m) }
 func (*BlockMeta) ProtoMessage()    {}
 func (*BlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{14}
+	return fileDescriptor_d3a6e55e2345de56, []int{15}
 }
 func (m *BlockMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1242,7 +1319,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{15}
+	return fileDescriptor_d3a6e55e2345de56, []int{16}
 }
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1294,7 +1371,6 @@ func (m *TxProof) GetProof() *crypto.Proof {
 
 type Evidence struct {
 	// Types that are valid to be assigned to Sum:
-	//
 	//	*Evidence_DuplicateVoteEvidence
 	//	*Evidence_LightClientAttackEvidence
 	Sum isEvidence_Sum `protobuf_oneof:"sum"`
@@ -1304,7 +1380,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{16}
+	return fileDescriptor_d3a6e55e2345de56, []int{17}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1392,7 +1468,7 @@ func (m *DuplicateVoteEvidence) Reset()         { *m = DuplicateVoteEvidence{} }
 func (m *DuplicateVoteEvidence) String() string { return proto.CompactTextString(m) }
 func (*DuplicateVoteEvidence) ProtoMessage()    {}
 func (*DuplicateVoteEvidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{17}
+	return fileDescriptor_d3a6e55e2345de56, []int{18}
 }
 func (m *DuplicateVoteEvidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1470,7 +1546,7 @@ func (m *LightClientAttackEvidence) Reset()         { *m = LightClientAttackEvid
 func (m *LightClientAttackEvidence) String() string { return proto.CompactTextString(m) }
 func (*LightClientAttackEvidence) ProtoMessage()    {}
 func (*LightClientAttackEvidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{18}
+	return fileDescriptor_d3a6e55e2345de56, []int{19}
 }
 func (m *LightClientAttackEvidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1542,7 +1618,7 @@ func (m *EvidenceList) Reset()         { *m = EvidenceList{} }
 func (m *EvidenceList) String() string { return proto.CompactTextString(m) }
 func (*EvidenceList) ProtoMessage()    {}
 func (*EvidenceList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{19}
+	return fileDescriptor_d3a6e55e2345de56, []int{20}
 }
 func (m *EvidenceList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1583,6 +1659,7 @@ func init() {
 	proto.RegisterEnum("tendermint.types.SignedMsgType", SignedMsgType_name, SignedMsgType_value)
 	proto.RegisterType((*PartSetHeader)(nil), "tendermint.types.PartSetHeader")
 	proto.RegisterType((*Part)(nil), "tendermint.types.Part")
+	proto.RegisterType((*ParityPart)(nil), "tendermint.types.ParityPart")
 	proto.RegisterType((*BlockID)(nil), "tendermint.types.BlockID")
 	proto.RegisterType((*Header)(nil), "tendermint.types.Header")
 	proto.RegisterType((*Data)(nil), "tendermint.types.Data")
@@ -1606,117 +1683,121 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1747 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcd, 0x6f, 0x23, 0x49,
-	0x15, 0x4f, 0xfb, 0xdb, 0xcf, 0x76, 0xe2, 0xd4, 0x26, 0x33, 0x8e, 0x67, 0xc6, 0xb1, 0x3c, 0x82,
-	0xcd, 0x7e, 0xe0, 0x84, 0x2c, 0x02, 0x16, 0xc1, 0xc1, 0x4e, 0xb2, 0x33, 0xd6, 0xe4, 0xc3, 0xb4,
-	0xbd, 0x83, 0xd8, 0x4b, 0xab, 0xed, 0xae, 0x71, 0x9a, 0x69, 0x77, 0xb7, 0xba, 0xcb, 0x59, 0x67,
-	0xfe, 0x02, 0x94, 0xd3, 0x9c, 0xb8, 0xe5, 0x04, 0x07, 0xfe, 0x00, 0x24, 0x8e, 0x20, 0x4e, 0x7b,
-	0x41, 0xda, 0x1b, 0x5c, 0x58, 0x60, 0x06, 0xf1, 0x77, 0xa0, 0x7a, 0x55, 0xfd, 0xe1, 0xd8, 0xde,
-	0x19, 0x46, 0x23, 0x90, 0xf6, 0x62, 0x75, 0xbd, 0xf7, 0x7b, 0x55, 0xef, 0xe3, 0x57, 0x55, 0xaf,
-	0x0c, 0x77, 0x19, 0xb5, 0x0d, 0xea, 0x8d, 0x4d, 0x9b, 0xed, 0xb2, 0x4b, 0x97, 0xfa, 0xe2, 0xb7,
-	0xe9, 0x7a, 0x0e, 0x73, 0x48, 0x39, 0xd2, 0x36, 0x51, 0x5e, 0xdd, 0x18, 0x39, 0x23, 0x07, 0x95,
-	0xbb, 0xfc, 0x4b, 0xe0, 0xaa, 0xdb, 0x23, 0xc7, 0x19, 0x59, 0x74, 0x17, 0x47, 0x83, 0xc9, 0x93,
-	0x5d, 0x66, 0x8e, 0xa9, 0xcf, 0xf4, 0xb1, 0x2b, 0x01, 0xf7, 0x62, 0xcb, 0x0c, 0xbd, 0x4b, 0x97,
-	0x39, 0x1c, 0xeb, 0x3c, 0x91, 0xea, 0x5a, 0x4c, 0x7d, 0x41, 0x3d, 0xdf, 0x74, 0xec, 0xb8, 0x1f,
-	0xd5, 0xfa, 0x9c, 0x97, 0x17, 0xba, 0x65, 0x1a, 0x3a, 0x73, 0x3c, 0x81, 0x68, 0x7c, 0x0c, 0xa5,
-	0xae, 0xee, 0xb1, 0x1e, 0x65, 0x0f, 0xa9, 0x6e, 0x50, 0x8f, 0x6c, 0x40, 0x9a, 0x39, 0x4c, 0xb7,
-	0x2a, 0x4a, 0x5d, 0xd9, 0x29, 0xa9, 0x62, 0x40, 0x08, 0xa4, 0xce, 0x75, 0xff, 0xbc, 0x92, 0xa8,
-	0x2b, 0x3b, 0x45, 0x15, 0xbf, 0x1b, 0xe7, 0x90, 0xe2, 0xa6, 0xdc, 0xc2, 0xb4, 0x0d, 0x3a, 0x0d,
-	0x2c, 0x70, 0xc0, 0xa5, 0x83, 0x4b, 0x46, 0x7d, 0x69, 0x22, 0x06, 0xe4, 0x7b, 0x90, 0x46, 0xff,
-	0x2b, 0xc9, 0xba, 0xb2, 0x53, 0xd8, 0xaf, 0x34, 0x63, 0x89, 0x12, 0xf1, 0x35, 0xbb, 0x5c, 0xdf,
-	0x4e, 0x7d, 0xf1, 0xd5, 0xf6, 0x8a, 0x2a, 0xc0, 0x0d, 0x0b, 0xb2, 0x6d, 0xcb, 0x19, 0x3e, 0xed,
-	0x1c, 0x86, 0x8e, 0x28, 0x91, 0x23, 0xe4, 0x04, 0xd6, 0x5c, 0xdd, 0x63, 0x9a, 0x4f, 0x99, 0x76,
-	0x8e, 0x51, 0xe0, 0xa2, 0x85, 0xfd, 0xed, 0xe6, 0xcd, 0x3a, 0x34, 0x67, 0x82, 0x95, 0xab, 0x94,
-	0xdc, 0xb8, 0xb0, 0xf1, 0xef, 0x14, 0x64, 0x64, 0x32, 0x7e, 0x02, 0x59, 0x99, 0x56, 0x5c, 0xb0,
-	0xb0, 0x7f, 0x2f, 0x3e, 0xa3, 0x54, 0x35, 0x0f, 0x1c, 0xdb, 0xa7, 0xb6, 0x3f, 0xf1, 0xe5, 0x7c,
-	0x81, 0x0d, 0xf9, 0x36, 0xe4, 0x86, 0xe7, 0xba, 0x69, 0x6b, 0xa6, 0x81, 0x1e, 0xe5, 0xdb, 0x85,
-	0x17, 0x5f, 0x6d, 0x67, 0x0f, 0xb8, 0xac, 0x73, 0xa8, 0x66, 0x51, 0xd9, 0x31, 0xc8, 0x2d, 0xc8,
-	0x9c, 0x53, 0x73, 0x74, 0xce, 0x30, 0x2d, 0x49, 0x55, 0x8e, 0xc8, 0x0f, 0x21, 0xc5, 0x09, 0x51,
-	0x49, 0xe1, 0xda, 0xd5, 0xa6, 0x60, 0x4b, 0x33, 0x60, 0x4b, 0xb3, 0x1f, 0xb0, 0xa5, 0x9d, 0xe3,
-	0x0b, 0x3f, 0xff, 0xfb, 0xb6, 0xa2, 0xa2, 0x05, 0x39, 0x80, 0x92, 0xa5, 0xfb, 0x4c, 0x1b, 0xf0,
-	0xb4, 0xf1, 0xe5, 0xd3, 0x38, 0xc5, 0xd6, 0x7c, 0x42, 0x64, 0x62, 0xa5, 0xeb, 0x05, 0x6e, 0x25,
-	0x44, 0x06, 0xd9, 0x81, 0x32, 0x4e, 0x32, 0x74, 0xc6, 0x63, 0x93, 0x69, 0x98, 0xf7, 0x0c, 0xe6,
-	0x7d, 0x95, 0xcb, 0x0f, 0x50, 0xfc, 0x90, 0x57, 0xe0, 0x0e, 0xe4, 0x0d, 0x9d, 0xe9, 0x02, 0x92,
-	0x45, 0x48, 0x8e, 0x0b, 0x50, 0xf9, 0x2e, 0xac, 0x85, 0xac, 0xf3, 0x05, 0x24, 0x27, 0x66, 0x89,
-	0xc4, 0x08, 0xdc, 0x83, 0x0d, 0x9b, 0x4e, 0x99, 0x76, 0x13, 0x9d, 0x47, 0x34, 0xe1, 0xba, 0xc7,
-	0xb3, 0x16, 0xdf, 0x82, 0xd5, 0x61, 0x90, 0x7c, 0x81, 0x05, 0xc4, 0x96, 0x42, 0x29, 0xc2, 0xb6,
-	0x20, 0xa7, 0xbb, 0xae, 0x00, 0x14, 0x10, 0x90, 0xd5, 0x5d, 0x17, 0x55, 0xef, 0xc3, 0x3a, 0xc6,
-	0xe8, 0x51, 0x7f, 0x62, 0x31, 0x39, 0x49, 0x11, 0x31, 0x6b, 0x5c, 0xa1, 0x0a, 0x39, 0x62, 0xef,
-	0x43, 0x89, 0x5e, 0x98, 0x06, 0xb5, 0x87, 0x54, 0xe0, 0x4a, 0x88, 0x2b, 0x06, 0x42, 0x04, 0xbd,
-	0x07, 0x65, 0xd7, 0x73, 0x5c, 0xc7, 0xa7, 0x9e, 0xa6, 0x1b, 0x86, 0x47, 0x7d, 0xbf, 0xb2, 0x2a,
-	0xe6, 0x0b, 0xe4, 0x2d, 0x21, 0x6e, 0x54, 0x20, 0x75, 0xa8, 0x33, 0x9d, 0x94, 0x21, 0xc9, 0xa6,
-	0x7e, 0x45, 0xa9, 0x27, 0x77, 0x8a, 0x2a, 0xff, 0x6c, 0xd4, 0x20, 0xdd, 0x9f, 0x3e, 0xa2, 0x97,
-	0x64, 0x13, 0x32, 0x6c, 0xaa, 0x3d, 0xa5, 0x97, 0x92, 0xf0, 0x69, 0xc6, 0xc5, 0x8d, 0xdf, 0x27,
-	0x21, 0xf5, 0xd8, 0x61, 0x94, 0x7c, 0x04, 0x29, 0x5e, 0x46, 0xd4, 0xae, 0x2e, 0xe2, 0x7b, 0xcf,
-	0x1c, 0xd9, 0xd4, 0x38, 0xf1, 0x47, 0xfd, 0x4b, 0x97, 0xaa, 0x08, 0x8e, 0xd1, 0x2d, 0x31, 0x43,
-	0xb7, 0x0d, 0x48, 0x7b, 0xce, 0xc4, 0x36, 0x90, 0x85, 0x69, 0x55, 0x0c, 0xc8, 0x11, 0xe4, 0x42,
-	0x16, 0xa5, 0x5e, 0xc5, 0xa2, 0x35, 0xce, 0x22, 0xce, 0x71, 0x29, 0x50, 0xb3, 0x03, 0x49, 0xa6,
-	0x36, 0xe4, 0xc3, 0xc3, 0x4d, 0xb2, 0xf1, 0xf5, 0x08, 0x1d, 0x99, 0x91, 0x0f, 0x60, 0x3d, 0xe4,
-	0x46, 0x98, 0x5c, 0xc1, 0xc8, 0x72, 0xa8, 0x90, 0xd9, 0x9d, 0xa1, 0x9d, 0x26, 0x0e, 0xa8, 0x2c,
-	0xc6, 0x15, 0xd1, 0xae, 0x83, 0x27, 0xd5, 0x5d, 0xc8, 0xfb, 0xe6, 0xc8, 0xd6, 0xd9, 0xc4, 0xa3,
-	0x92, 0x99, 0x91, 0x80, 0x6b, 0xe9, 0x94, 0x51, 0x1b, 0x0f, 0x01, 0xc1, 0xc4, 0x48, 0x40, 0x76,
-	0xe1, 0x9d, 0x70, 0xa0, 0x45, 0xb3, 0x08, 0x16, 0x92, 0x50, 0xd5, 0x0b, 0x34, 0x8d, 0x3f, 0x2a,
-	0x90, 0x11, 0x1b, 0x27, 0x56, 0x06, 0x65, 0x71, 0x19, 0x12, 0xcb, 0xca, 0x90, 0x7c, 0xf3, 0x32,
-	0xb4, 0x00, 0x42, 0x37, 0xfd, 0x4a, 0xaa, 0x9e, 0xdc, 0x29, 0xec, 0xdf, 0x99, 0x9f, 0x48, 0xb8,
-	0xd8, 0x33, 0x47, 0xf2, 0x5c, 0x88, 0x19, 0x35, 0xfe, 0xa6, 0x40, 0x3e, 0xd4, 0x93, 0x16, 0x94,
-	0x02, 0xbf, 0xb4, 0x27, 0x96, 0x3e, 0x92, 0x54, 0xbc, 0xb7, 0xd4, 0xb9, 0x4f, 0x2c, 0x7d, 0xa4,
-	0x16, 0xa4, 0x3f, 0x7c, 0xb0, 0xb8, 0xac, 0x89, 0x25, 0x65, 0x9d, 0xe1, 0x51, 0xf2, 0xcd, 0x78,
-	0x34, 0x53, 0xf1, 0xd4, 0x8d, 0x8a, 0x37, 0xfe, 0xa9, 0xc0, 0xea, 0xd1, 0x14, 0xdd, 0x37, 0xfe,
-	0x9f, 0xa5, 0xfa, 0x4c, 0x72, 0xcb, 0xa0, 0x86, 0x36, 0x57, 0xb3, 0xfb, 0xf3, 0x33, 0xce, 0xfa,
-	0x1c, 0xd5, 0x8e, 0x04, 0xb3, 0xf4, 0xa2, 0x1a, 0xfe, 0x2e, 0x01, 0xeb, 0x73, 0xf8, 0x6f, 0x5e,
-	0x2d, 0x67, 0x77, 0x6f, 0xfa, 0x35, 0x77, 0x6f, 0x66, 0xe9, 0xee, 0xfd, 0x43, 0x0a, 0x72, 0x5d,
-	0x3c, 0xc5, 0x75, 0xeb, 0x7f, 0x71, 0xf6, 0xde, 0x81, 0xbc, 0xeb, 0x58, 0x9a, 0xd0, 0xa4, 0x50,
-	0x93, 0x73, 0x1d, 0x4b, 0x9d, 0xa3, 0x59, 0xfa, 0x2d, 0x1d, 0xcc, 0x99, 0xb7, 0x50, 0x84, 0xec,
-	0xcd, 0x22, 0xec, 0x41, 0x56, 0x5c, 0x62, 0x7e, 0x25, 0x87, 0xe4, 0xbd, 0x3d, 0xef, 0x27, 0x5e,
-	0x77, 0x6a, 0x06, 0xaf, 0x37, 0x9f, 0xfc, 0x08, 0x72, 0xc1, 0xa5, 0x8a, 0x67, 0x6e, 0x61, 0xbf,
-	0xb6, 0x80, 0xef, 0x12, 0x71, 0x6c, 0xfa, 0x4c, 0x0d, 0xf1, 0xe4, 0x63, 0x28, 0xc4, 0xba, 0x16,
-	0x3c, 0x8a, 0x6f, 0x34, 0x9a, 0xf1, 0x23, 0x4e, 0x85, 0xa8, 0x95, 0x21, 0xdf, 0xe7, 0xc5, 0xc1,
-	0xfe, 0xb1, 0xb0, 0xcc, 0x6a, 0xa6, 0x71, 0x94, 0xe8, 0x85, 0x77, 0x7e, 0x71, 0xf1, 0x9d, 0xef,
-	0x41, 0x51, 0xd0, 0x42, 0x76, 0x98, 0x7b, 0xe1, 0x92, 0xca, 0xd7, 0x2f, 0x19, 0x2e, 0xb6, 0x07,
-	0x19, 0x19, 0x5a, 0xe2, 0x15, 0xa1, 0x49, 0x5c, 0xe3, 0x57, 0x0a, 0xc0, 0x31, 0x67, 0x19, 0xd6,
-	0x9e, 0xf7, 0x86, 0x3e, 0xba, 0xa0, 0xcd, 0xac, 0x5c, 0x5b, 0x46, 0x60, 0xb9, 0x7e, 0xd1, 0x8f,
-	0xfb, 0x7d, 0x00, 0xa5, 0x68, 0x9f, 0xfb, 0x34, 0x70, 0x66, 0xc1, 0x24, 0x61, 0xcb, 0xd6, 0xa3,
-	0x4c, 0x2d, 0x5e, 0xc4, 0x46, 0x8d, 0x3f, 0x29, 0x90, 0x47, 0x9f, 0x4e, 0x28, 0xd3, 0x67, 0xf8,
-	0xac, 0xbc, 0x39, 0x9f, 0xef, 0x01, 0x88, 0x69, 0x7c, 0xf3, 0x19, 0x95, 0xbb, 0x2c, 0x8f, 0x92,
-	0x9e, 0xf9, 0x8c, 0xc6, 0x6a, 0x9c, 0xfc, 0xaf, 0x6a, 0x7c, 0x1b, 0xb2, 0xf6, 0x64, 0xac, 0xf1,
-	0x46, 0x2d, 0x25, 0x76, 0xae, 0x3d, 0x19, 0xf7, 0xa7, 0x7e, 0xe3, 0x17, 0x90, 0xed, 0x4f, 0xf1,
-	0xd1, 0xc2, 0xb7, 0xab, 0xe7, 0x38, 0xb2, 0x53, 0x16, 0x0d, 0x5b, 0x8e, 0x0b, 0xb0, 0x31, 0x24,
-	0x90, 0xe2, 0x2d, 0x71, 0xf0, 0x84, 0xe2, 0xdf, 0xa4, 0xf9, 0x9a, 0xcf, 0xa1, 0xe0, 0x21, 0xf4,
-	0x2f, 0x05, 0x72, 0x01, 0xed, 0x89, 0x0e, 0xb7, 0x8d, 0x89, 0x6b, 0x99, 0x43, 0x9d, 0x51, 0xed,
-	0xc2, 0x61, 0x54, 0x0b, 0xf7, 0x8c, 0x48, 0xdf, 0xbb, 0xf3, 0xa1, 0x1d, 0x06, 0x06, 0xbc, 0x7b,
-	0x0c, 0x66, 0x7a, 0xb8, 0xa2, 0x6e, 0x1a, 0x8b, 0x14, 0xc4, 0x86, 0xbb, 0x16, 0x27, 0x8e, 0x36,
-	0xb4, 0x4c, 0x6a, 0x33, 0x4d, 0x67, 0x4c, 0x1f, 0x3e, 0x8d, 0xd6, 0x11, 0x45, 0xff, 0x60, 0x7e,
-	0x1d, 0xa4, 0xdb, 0x01, 0x1a, 0xb5, 0xd0, 0x26, 0xb6, 0xd6, 0x96, 0xb5, 0x4c, 0xd9, 0x4e, 0x43,
-	0xd2, 0x9f, 0x8c, 0x1b, 0xcf, 0x13, 0xb0, 0xb9, 0xd0, 0x53, 0xf2, 0x1d, 0xc8, 0x60, 0xa4, 0xba,
-	0x0c, 0xf1, 0xd6, 0x02, 0xbe, 0x39, 0x8c, 0xaa, 0x69, 0x8e, 0x6a, 0x85, 0xf0, 0x81, 0xf4, 0xf4,
-	0x6b, 0xe1, 0x6d, 0xf2, 0x21, 0x10, 0x7c, 0xee, 0xf2, 0x6c, 0x9a, 0xf6, 0x48, 0x73, 0x9d, 0xcf,
-	0x25, 0x4f, 0x92, 0x6a, 0x19, 0x35, 0x8f, 0x51, 0xd1, 0xe5, 0xf2, 0xd9, 0x06, 0x53, 0x40, 0x05,
-	0x33, 0xa2, 0x06, 0x53, 0x00, 0xdf, 0x42, 0xeb, 0xdb, 0xf8, 0x73, 0x02, 0xb6, 0x96, 0x26, 0x95,
-	0x74, 0x60, 0x7d, 0xe8, 0xd8, 0x4f, 0x2c, 0x73, 0x88, 0x7e, 0x23, 0xdb, 0x65, 0x86, 0xee, 0x2e,
-	0x29, 0x0e, 0xee, 0x1b, 0xb5, 0x1c, 0x33, 0x13, 0xa7, 0xc3, 0x7d, 0x28, 0xf1, 0x63, 0xc3, 0xb1,
-	0xb5, 0x99, 0x7b, 0xaa, 0x28, 0x84, 0x0f, 0xc5, 0x6d, 0x75, 0x0a, 0x1b, 0x83, 0xcb, 0x67, 0xba,
-	0xcd, 0x4c, 0x9b, 0xc6, 0x9e, 0x6b, 0x95, 0xe4, 0xb2, 0x7e, 0x32, 0x3c, 0x04, 0xd4, 0x77, 0x42,
-	0xc3, 0xe8, 0x2d, 0xb7, 0x24, 0xf1, 0xa9, 0x25, 0x89, 0x7f, 0x1b, 0xf9, 0x3c, 0x86, 0x62, 0xfc,
-	0xfe, 0x20, 0x3f, 0x8e, 0xdd, 0x38, 0x0a, 0x46, 0x51, 0x5d, 0x7e, 0xe3, 0xc8, 0xa3, 0x21, 0xb4,
-	0x78, 0xff, 0x2f, 0x0a, 0x14, 0x62, 0x2d, 0x11, 0xf9, 0x2e, 0x6c, 0xb6, 0x8f, 0xcf, 0x0e, 0x1e,
-	0x69, 0x9d, 0x43, 0xed, 0x93, 0xe3, 0xd6, 0x03, 0xed, 0xd3, 0xd3, 0x47, 0xa7, 0x67, 0x3f, 0x3b,
-	0x2d, 0xaf, 0x54, 0x6f, 0x5d, 0x5d, 0xd7, 0x49, 0x0c, 0xfb, 0xa9, 0xfd, 0xd4, 0x76, 0x3e, 0xe7,
-	0xbd, 0xc8, 0xc6, 0xac, 0x49, 0xab, 0xdd, 0x3b, 0x3a, 0xed, 0x97, 0x95, 0xea, 0xe6, 0xd5, 0x75,
-	0x7d, 0x3d, 0x66, 0xd1, 0x1a, 0xf8, 0xd4, 0x66, 0xf3, 0x06, 0x07, 0x67, 0x27, 0x27, 0x9d, 0x7e,
-	0x39, 0x31, 0x67, 0x20, 0x6f, 0xb7, 0xf7, 0x60, 0x7d, 0xd6, 0xe0, 0xb4, 0x73, 0x5c, 0x4e, 0x56,
-	0xc9, 0xd5, 0x75, 0x7d, 0x35, 0x86, 0x3e, 0x35, 0xad, 0x6a, 0xee, 0x97, 0xbf, 0xae, 0xad, 0xfc,
-	0xf6, 0x37, 0x35, 0x85, 0x47, 0x56, 0x9a, 0xe9, 0x63, 0xc8, 0x87, 0x70, 0xbb, 0xd7, 0x79, 0x70,
-	0x7a, 0x74, 0xa8, 0x9d, 0xf4, 0x1e, 0x68, 0xfd, 0x9f, 0x77, 0x8f, 0x62, 0xd1, 0xad, 0x5d, 0x5d,
-	0xd7, 0x0b, 0x32, 0xa4, 0x65, 0xe8, 0xae, 0x7a, 0xf4, 0xf8, 0xac, 0x7f, 0x54, 0x56, 0x04, 0xba,
-	0xeb, 0x51, 0xbe, 0xfb, 0x10, 0xbd, 0x07, 0x5b, 0x0b, 0xd0, 0x61, 0x60, 0xeb, 0x57, 0xd7, 0xf5,
-	0x52, 0xd7, 0xa3, 0xe2, 0x5e, 0x43, 0x8b, 0x26, 0x54, 0xe6, 0x2d, 0xce, 0xba, 0x67, 0xbd, 0xd6,
-	0x71, 0xb9, 0x5e, 0x2d, 0x5f, 0x5d, 0xd7, 0x8b, 0x41, 0xc3, 0xc6, 0xf1, 0x51, 0x64, 0xed, 0x9f,
-	0x7e, 0xf1, 0xa2, 0xa6, 0x7c, 0xf9, 0xa2, 0xa6, 0xfc, 0xe3, 0x45, 0x4d, 0x79, 0xfe, 0xb2, 0xb6,
-	0xf2, 0xe5, 0xcb, 0xda, 0xca, 0x5f, 0x5f, 0xd6, 0x56, 0x3e, 0xfb, 0xc1, 0xc8, 0x64, 0xe7, 0x93,
-	0x41, 0x73, 0xe8, 0x8c, 0x77, 0xe3, 0x7f, 0xa0, 0x45, 0x9f, 0xe2, 0x8f, 0xbc, 0x9b, 0x7f, 0xae,
-	0x0d, 0x32, 0x28, 0xff, 0xe8, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x3b, 0x8a, 0xfc, 0xb7, 0x1d,
-	0x14, 0x00, 0x00,
+	// 1817 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0x4f, 0x6f, 0x1b, 0xc7,
+	0x15, 0xd7, 0x92, 0xcb, 0x7f, 0x8f, 0xa4, 0x44, 0x8d, 0x25, 0x9b, 0xa2, 0x6d, 0x8a, 0x60, 0xd0,
+	0x46, 0x71, 0x52, 0x4a, 0x51, 0x8a, 0xa6, 0x29, 0xda, 0x03, 0x29, 0x31, 0x32, 0x61, 0xfd, 0x61,
+	0x97, 0x8c, 0x8b, 0xe6, 0xb2, 0x58, 0x72, 0xc7, 0xd4, 0xd6, 0xcb, 0xdd, 0xc5, 0xee, 0x50, 0xa1,
+	0xf2, 0x09, 0x0a, 0x9d, 0x7c, 0xea, 0x4d, 0xa7, 0xf6, 0xd0, 0x0f, 0x50, 0xa0, 0xc7, 0x02, 0x3d,
+	0xe5, 0x52, 0x20, 0xb7, 0xf6, 0xd2, 0xb4, 0xb5, 0x8b, 0x7e, 0x81, 0x7e, 0x81, 0x62, 0xde, 0xec,
+	0x3f, 0x8a, 0x64, 0x9c, 0x18, 0x42, 0x0b, 0xf4, 0x42, 0xec, 0xbc, 0xf7, 0x7b, 0x33, 0xef, 0xcf,
+	0x6f, 0x66, 0xde, 0x10, 0x1e, 0x30, 0x6a, 0xe9, 0xd4, 0x1d, 0x1b, 0x16, 0xdb, 0x65, 0x97, 0x0e,
+	0xf5, 0xc4, 0x6f, 0xc3, 0x71, 0x6d, 0x66, 0x93, 0x52, 0xa4, 0x6d, 0xa0, 0xbc, 0xb2, 0x31, 0xb2,
+	0x47, 0x36, 0x2a, 0x77, 0xf9, 0x97, 0xc0, 0x55, 0xb6, 0x47, 0xb6, 0x3d, 0x32, 0xe9, 0x2e, 0x8e,
+	0x06, 0x93, 0x67, 0xbb, 0xcc, 0x18, 0x53, 0x8f, 0x69, 0x63, 0xc7, 0x07, 0x3c, 0x8c, 0x2d, 0x33,
+	0x74, 0x2f, 0x1d, 0x66, 0x73, 0xac, 0xfd, 0xcc, 0x57, 0x57, 0x63, 0xea, 0x0b, 0xea, 0x7a, 0x86,
+	0x6d, 0xc5, 0xfd, 0xa8, 0xd4, 0xe6, 0xbc, 0xbc, 0xd0, 0x4c, 0x43, 0xd7, 0x98, 0xed, 0x0a, 0x44,
+	0xfd, 0x23, 0x28, 0x76, 0x35, 0x97, 0xf5, 0x28, 0x7b, 0x4c, 0x35, 0x9d, 0xba, 0x64, 0x03, 0x52,
+	0xcc, 0x66, 0x9a, 0x59, 0x96, 0x6a, 0xd2, 0x4e, 0x51, 0x11, 0x03, 0x42, 0x40, 0x3e, 0xd7, 0xbc,
+	0xf3, 0x72, 0xa2, 0x26, 0xed, 0x14, 0x14, 0xfc, 0xae, 0x9f, 0x83, 0xcc, 0x4d, 0xb9, 0x85, 0x61,
+	0xe9, 0x74, 0x1a, 0x58, 0xe0, 0x80, 0x4b, 0x07, 0x97, 0x8c, 0x7a, 0xbe, 0x89, 0x18, 0x90, 0xef,
+	0x43, 0x0a, 0xfd, 0x2f, 0x27, 0x6b, 0xd2, 0x4e, 0x7e, 0xbf, 0xdc, 0x88, 0x25, 0x4a, 0xc4, 0xd7,
+	0xe8, 0x72, 0x7d, 0x4b, 0xfe, 0xe2, 0xab, 0xed, 0x15, 0x45, 0x80, 0xeb, 0xc7, 0x00, 0x5d, 0xcd,
+	0x35, 0xd8, 0xe5, 0xd7, 0xac, 0x47, 0x40, 0xf6, 0x8c, 0xcf, 0x29, 0x2e, 0x57, 0x54, 0xf0, 0x3b,
+	0xf2, 0x21, 0x19, 0xf3, 0xa1, 0x6e, 0x42, 0xa6, 0x65, 0xda, 0xc3, 0xe7, 0x9d, 0xc3, 0x30, 0x2c,
+	0x29, 0x0a, 0x8b, 0x9c, 0xc0, 0x9a, 0xa3, 0xb9, 0x4c, 0xf5, 0x28, 0x53, 0xcf, 0x31, 0x27, 0x38,
+	0x67, 0x7e, 0x7f, 0xbb, 0x71, 0xb3, 0xaa, 0x8d, 0x99, 0xd4, 0xf9, 0x3e, 0x17, 0x9d, 0xb8, 0xb0,
+	0xfe, 0x2f, 0x19, 0xd2, 0x7e, 0x6a, 0x7f, 0x02, 0x19, 0xbf, 0x48, 0xb8, 0x60, 0x7e, 0xff, 0x61,
+	0x7c, 0x46, 0x5f, 0xd5, 0x38, 0xb0, 0x2d, 0x8f, 0x5a, 0xde, 0xc4, 0xf3, 0xe7, 0x0b, 0x6c, 0xc8,
+	0x77, 0x21, 0x3b, 0x3c, 0xd7, 0x0c, 0x4b, 0x35, 0x74, 0xf4, 0x28, 0xd7, 0xca, 0xbf, 0xfc, 0x6a,
+	0x3b, 0x73, 0xc0, 0x65, 0x9d, 0x43, 0x25, 0x83, 0xca, 0x8e, 0x4e, 0xee, 0x42, 0xfa, 0x9c, 0x1a,
+	0xa3, 0x73, 0x86, 0x61, 0x27, 0x15, 0x7f, 0x44, 0x7e, 0x08, 0x32, 0xa7, 0x57, 0x59, 0xc6, 0xb5,
+	0x2b, 0x0d, 0xc1, 0xbd, 0x46, 0xc0, 0xbd, 0x46, 0x3f, 0xe0, 0x5e, 0x2b, 0xcb, 0x17, 0x7e, 0xf1,
+	0xb7, 0x6d, 0x49, 0x41, 0x0b, 0x72, 0x00, 0x45, 0x53, 0xf3, 0x98, 0x3a, 0xe0, 0x69, 0xe3, 0xcb,
+	0xa7, 0x70, 0x8a, 0xad, 0xf9, 0x84, 0xf8, 0x89, 0xf5, 0x5d, 0xcf, 0x73, 0x2b, 0x21, 0xd2, 0xc9,
+	0x0e, 0x94, 0x70, 0x92, 0xa1, 0x3d, 0x1e, 0x1b, 0x4c, 0xc5, 0xbc, 0xa7, 0x31, 0xef, 0xab, 0x5c,
+	0x7e, 0x80, 0xe2, 0xc7, 0xbc, 0x02, 0xf7, 0x21, 0xa7, 0x6b, 0x4c, 0x13, 0x90, 0x0c, 0x42, 0xb2,
+	0x5c, 0x80, 0xca, 0xb7, 0x61, 0x2d, 0xe4, 0xb0, 0x27, 0x20, 0x59, 0x31, 0x4b, 0x24, 0x46, 0xe0,
+	0x1e, 0x6c, 0x58, 0x74, 0xca, 0xd4, 0x9b, 0xe8, 0x1c, 0xa2, 0x09, 0xd7, 0x3d, 0x9d, 0xb5, 0xf8,
+	0x0e, 0xac, 0x0e, 0x83, 0xe4, 0x0b, 0x2c, 0x20, 0xb6, 0x18, 0x4a, 0x11, 0xb6, 0x05, 0x59, 0xcd,
+	0x71, 0x04, 0x20, 0x8f, 0x80, 0x8c, 0xe6, 0x38, 0xa8, 0x7a, 0x04, 0xeb, 0x18, 0xa3, 0x4b, 0xbd,
+	0x89, 0xc9, 0xfc, 0x49, 0x0a, 0x88, 0x59, 0xe3, 0x0a, 0x45, 0xc8, 0x11, 0xfb, 0x16, 0x14, 0xe9,
+	0x85, 0xa1, 0x53, 0x6b, 0x48, 0x05, 0xae, 0x88, 0xb8, 0x42, 0x20, 0x44, 0xd0, 0x3b, 0x50, 0x72,
+	0x5c, 0xdb, 0xb1, 0x3d, 0xea, 0xaa, 0x9a, 0xae, 0xbb, 0xd4, 0xf3, 0xca, 0xab, 0x62, 0xbe, 0x40,
+	0xde, 0x14, 0xe2, 0x7a, 0x19, 0xe4, 0x43, 0x8d, 0x69, 0xa4, 0x04, 0x49, 0x36, 0xf5, 0xca, 0x52,
+	0x2d, 0xb9, 0x53, 0x50, 0xf8, 0x67, 0xbd, 0x0a, 0xa9, 0xfe, 0xf4, 0x09, 0xbd, 0x24, 0x9b, 0x90,
+	0x66, 0x53, 0xf5, 0x39, 0xbd, 0xf4, 0x09, 0x9f, 0x62, 0x5c, 0x5c, 0xff, 0x7d, 0x12, 0xe4, 0xa7,
+	0x36, 0xa3, 0xe4, 0x03, 0x90, 0x79, 0x19, 0x51, 0xbb, 0xba, 0x88, 0xef, 0x3d, 0x63, 0x64, 0x51,
+	0xfd, 0xc4, 0x1b, 0xf5, 0x2f, 0x1d, 0xaa, 0x20, 0x38, 0x46, 0xb7, 0xc4, 0x0c, 0xdd, 0x36, 0x20,
+	0xe5, 0xda, 0x13, 0x4b, 0x47, 0x16, 0xa6, 0x14, 0x31, 0x20, 0x6d, 0xc8, 0x86, 0x2c, 0x92, 0x5f,
+	0xc7, 0xa2, 0x35, 0xce, 0x22, 0xce, 0x71, 0x5f, 0xa0, 0x64, 0x06, 0x3e, 0x99, 0x5a, 0x90, 0x0b,
+	0x8f, 0xca, 0x72, 0xea, 0x5b, 0x10, 0x3a, 0x32, 0x23, 0xef, 0xc2, 0x7a, 0xc8, 0x8d, 0x30, 0xb9,
+	0x82, 0x91, 0xa5, 0x50, 0xe1, 0x67, 0x77, 0x86, 0x76, 0xaa, 0x38, 0x7e, 0x32, 0x18, 0x57, 0x44,
+	0xbb, 0x0e, 0x97, 0x92, 0x07, 0x90, 0xf3, 0x8c, 0x91, 0xa5, 0xb1, 0x89, 0x4b, 0x7d, 0x66, 0x46,
+	0x02, 0xae, 0xa5, 0x53, 0x46, 0x2d, 0x3c, 0x04, 0x04, 0x13, 0x23, 0x01, 0xd9, 0x85, 0x3b, 0xe1,
+	0x40, 0x8d, 0x66, 0x11, 0x2c, 0x24, 0xa1, 0xaa, 0x17, 0x68, 0xea, 0xff, 0x96, 0x20, 0x2d, 0x36,
+	0x4e, 0xac, 0x0c, 0xd2, 0xe2, 0x32, 0x24, 0x96, 0x95, 0x21, 0xf9, 0xe6, 0x65, 0x68, 0x02, 0x84,
+	0x6e, 0x7a, 0x65, 0xb9, 0x96, 0xdc, 0xc9, 0xef, 0xdf, 0x9f, 0x9f, 0x48, 0xb8, 0xd8, 0x33, 0x46,
+	0xfe, 0xb9, 0x10, 0x33, 0x22, 0xef, 0xc3, 0x86, 0x36, 0x1a, 0xb9, 0x74, 0xa4, 0x31, 0xaa, 0xc7,
+	0x82, 0x4e, 0x61, 0xd0, 0x77, 0x22, 0x5d, 0x14, 0xf5, 0x5f, 0x25, 0xc8, 0x85, 0x53, 0x92, 0x26,
+	0x14, 0x83, 0x50, 0xd4, 0x67, 0xa6, 0x36, 0xf2, 0xd9, 0xfb, 0x70, 0x69, 0x3c, 0x1f, 0x9b, 0xda,
+	0x48, 0xc9, 0xfb, 0x21, 0xf0, 0xc1, 0x62, 0x26, 0x24, 0x96, 0x30, 0x61, 0x86, 0x7a, 0xc9, 0x37,
+	0xa3, 0xde, 0x0c, 0x49, 0xe4, 0x1b, 0x24, 0xa9, 0xff, 0x43, 0x82, 0xd5, 0xf6, 0x14, 0xdd, 0xd7,
+	0xff, 0x97, 0xd5, 0xfd, 0xd4, 0xa7, 0xa3, 0x1e, 0x2f, 0x4c, 0x50, 0xe6, 0xb7, 0xe6, 0x67, 0x9c,
+	0xf5, 0x39, 0x2a, 0x37, 0x09, 0x66, 0x09, 0x4b, 0xe8, 0xd5, 0x7f, 0x97, 0x80, 0xf5, 0x39, 0xfc,
+	0xff, 0x5f, 0x2d, 0x67, 0x37, 0x7c, 0xea, 0x1b, 0x6e, 0xf8, 0xf4, 0xd2, 0x0d, 0xff, 0x07, 0x19,
+	0xb2, 0x5d, 0x3c, 0xf8, 0x35, 0xf3, 0xbf, 0x71, 0x5c, 0xdf, 0x87, 0x9c, 0x63, 0x9b, 0xaa, 0xd0,
+	0xc8, 0xa8, 0xc9, 0x3a, 0xb6, 0xa9, 0xcc, 0xd1, 0x2c, 0x75, 0x4b, 0x67, 0x79, 0xfa, 0x16, 0x8a,
+	0x90, 0xb9, 0x59, 0x84, 0x3d, 0xc8, 0x88, 0x7b, 0xcf, 0x2b, 0x67, 0x91, 0xbc, 0xf7, 0xe6, 0xfd,
+	0xc4, 0x1b, 0x52, 0x49, 0xe3, 0x8d, 0xe8, 0x91, 0x1f, 0x41, 0x36, 0xb8, 0x87, 0xf1, 0x98, 0xce,
+	0xef, 0x57, 0x17, 0xf0, 0xdd, 0x47, 0x1c, 0x1b, 0x1e, 0x53, 0x42, 0x3c, 0xf9, 0x08, 0xf2, 0xb1,
+	0x46, 0xa7, 0x0c, 0xf3, 0x9d, 0x6e, 0xfc, 0x54, 0x54, 0x20, 0xea, 0x7e, 0xc8, 0x0f, 0x78, 0x71,
+	0xb0, 0xe5, 0xcc, 0x2f, 0xb3, 0x9a, 0xe9, 0x35, 0x7d, 0xf4, 0xc2, 0x36, 0xa1, 0xb0, 0xb8, 0x4d,
+	0x70, 0xa1, 0x20, 0x68, 0xe1, 0x37, 0xa5, 0x7b, 0xe1, 0x92, 0xd2, 0xd7, 0x2f, 0x19, 0x2e, 0xb6,
+	0x07, 0x69, 0x3f, 0xb4, 0xc4, 0x6b, 0x42, 0xf3, 0x71, 0xf5, 0x5f, 0x49, 0x00, 0xc7, 0x9c, 0x65,
+	0x58, 0x7b, 0xde, 0x4e, 0x7a, 0xe8, 0x82, 0x3a, 0xb3, 0x72, 0x75, 0x19, 0x81, 0xfd, 0xf5, 0x0b,
+	0x5e, 0xdc, 0xef, 0x03, 0x28, 0x46, 0xfb, 0xdc, 0xa3, 0x81, 0x33, 0x0b, 0x26, 0x09, 0xbb, 0xbc,
+	0x1e, 0x65, 0x4a, 0xe1, 0x22, 0x36, 0xaa, 0xff, 0x51, 0x82, 0x1c, 0xfa, 0x74, 0x42, 0x99, 0x36,
+	0xc3, 0x67, 0xe9, 0xcd, 0xf9, 0xfc, 0x10, 0x40, 0x4c, 0x13, 0xbe, 0x47, 0x92, 0x4a, 0x0e, 0x25,
+	0x3d, 0xfe, 0x28, 0x89, 0x6a, 0x9c, 0xfc, 0x56, 0x35, 0xbe, 0x07, 0x19, 0x6b, 0x32, 0x56, 0x79,
+	0x6f, 0x27, 0x8b, 0x9d, 0x6b, 0x4d, 0xc6, 0xfd, 0xa9, 0x57, 0xff, 0x05, 0x64, 0xfa, 0x53, 0x7c,
+	0x35, 0xf1, 0xed, 0xea, 0xda, 0xb6, 0xdf, 0x5c, 0x8b, 0x1e, 0x2f, 0xcb, 0x05, 0xd8, 0x4b, 0x12,
+	0x90, 0x79, 0x17, 0x1d, 0xbc, 0xe1, 0xf8, 0x37, 0x69, 0x7c, 0xc3, 0xf7, 0x58, 0xf0, 0x12, 0xfb,
+	0xa7, 0x04, 0xd9, 0x80, 0xf6, 0x44, 0x83, 0x7b, 0xfa, 0xc4, 0x31, 0x8d, 0xa1, 0xc6, 0xa8, 0x7a,
+	0x61, 0x33, 0xaa, 0x86, 0x7b, 0x46, 0xa4, 0xef, 0xed, 0xf9, 0xd0, 0x0e, 0x03, 0x03, 0xde, 0x70,
+	0x06, 0x33, 0x3d, 0x5e, 0x51, 0x36, 0xf5, 0x45, 0x0a, 0x62, 0xc1, 0x03, 0x93, 0x13, 0x47, 0x1d,
+	0x9a, 0x06, 0xb5, 0x98, 0xaa, 0x31, 0xa6, 0x0d, 0x9f, 0x47, 0xeb, 0x88, 0xa2, 0xbf, 0x3b, 0xbf,
+	0x0e, 0xd2, 0xed, 0x00, 0x8d, 0x9a, 0x68, 0x13, 0x5b, 0x6b, 0xcb, 0x5c, 0xa6, 0x6c, 0xa5, 0x20,
+	0xe9, 0x4d, 0xc6, 0xf5, 0x17, 0x09, 0xd8, 0x5c, 0xe8, 0x29, 0xf9, 0x1e, 0xa4, 0x31, 0x52, 0xcd,
+	0x0f, 0xf1, 0xee, 0x02, 0xbe, 0xd9, 0x8c, 0x2a, 0x29, 0x8e, 0x6a, 0x86, 0xf0, 0x41, 0x39, 0xf1,
+	0x7a, 0x78, 0x8b, 0xbc, 0x07, 0x04, 0xdf, 0xdb, 0x3c, 0x9b, 0x86, 0x35, 0x52, 0x1d, 0xfb, 0x33,
+	0x9f, 0x27, 0x49, 0xa5, 0x84, 0x9a, 0xa7, 0xa8, 0xe8, 0x72, 0xf9, 0x6c, 0x4f, 0x2a, 0xa0, 0x82,
+	0x19, 0x51, 0x4f, 0x2a, 0x80, 0xb7, 0xd0, 0x2d, 0xd7, 0xff, 0x94, 0x80, 0xad, 0xa5, 0x49, 0x25,
+	0x1d, 0x58, 0x1f, 0xda, 0xd6, 0x33, 0xd3, 0x18, 0xa2, 0xdf, 0xc8, 0x76, 0x3f, 0x43, 0x0f, 0x96,
+	0x14, 0x07, 0xf7, 0x8d, 0x52, 0x8a, 0x99, 0xa1, 0x84, 0xbf, 0x8b, 0xf8, 0xb1, 0x61, 0x5b, 0xea,
+	0xcc, 0x3d, 0x55, 0x10, 0xc2, 0xc7, 0x28, 0x23, 0xa7, 0xb0, 0x31, 0xb8, 0xfc, 0x5c, 0xb3, 0x98,
+	0x61, 0xd1, 0xd8, 0x0b, 0xaf, 0x9c, 0x5c, 0xd6, 0x82, 0x86, 0x87, 0x80, 0x72, 0x27, 0x34, 0x0c,
+	0x65, 0xde, 0x92, 0xc4, 0xcb, 0x4b, 0x12, 0x7f, 0x1b, 0xf9, 0x3c, 0x86, 0x42, 0xfc, 0xfe, 0x20,
+	0x3f, 0x8e, 0xdd, 0x38, 0x12, 0x46, 0x51, 0x59, 0x7e, 0xe3, 0xf8, 0x47, 0x43, 0x68, 0xf1, 0xe8,
+	0x45, 0x02, 0xf2, 0xb1, 0x96, 0x88, 0xbc, 0x0f, 0x9b, 0xad, 0xe3, 0xb3, 0x83, 0x27, 0x6a, 0xe7,
+	0x50, 0xfd, 0xf8, 0xb8, 0x79, 0xa4, 0x7e, 0x72, 0xfa, 0xe4, 0xf4, 0xec, 0x67, 0xa7, 0xa5, 0x95,
+	0xca, 0xdd, 0xab, 0xeb, 0x1a, 0x89, 0x61, 0x3f, 0xb1, 0x9e, 0x5b, 0xf6, 0x67, 0xbc, 0x17, 0xd9,
+	0x98, 0x35, 0x69, 0xb6, 0x7a, 0xed, 0xd3, 0x7e, 0x49, 0xaa, 0x6c, 0x5e, 0x5d, 0xd7, 0xd6, 0x63,
+	0x16, 0xcd, 0x81, 0x47, 0x2d, 0x36, 0x6f, 0x70, 0x70, 0x76, 0x72, 0xd2, 0xe9, 0x97, 0x12, 0x73,
+	0x06, 0xfe, 0xed, 0xf6, 0x0e, 0xac, 0xcf, 0x1a, 0x9c, 0x76, 0x8e, 0x4b, 0xc9, 0x0a, 0xb9, 0xba,
+	0xae, 0xad, 0xc6, 0xd0, 0xa7, 0x86, 0x49, 0x3e, 0x84, 0xf2, 0x0d, 0x67, 0x8e, 0x8e, 0x94, 0xf6,
+	0x51, 0xb3, 0xdf, 0x3e, 0x2c, 0xc9, 0x95, 0xad, 0xab, 0xeb, 0xda, 0x66, 0xdc, 0xa1, 0xf0, 0x91,
+	0x50, 0xc9, 0xfe, 0xf2, 0xd7, 0xd5, 0x95, 0xdf, 0xfe, 0xa6, 0x2a, 0x3d, 0xfa, 0xb3, 0x04, 0xc5,
+	0x99, 0x06, 0x88, 0xbc, 0x07, 0xf7, 0x7a, 0x9d, 0xa3, 0xd3, 0xf6, 0xa1, 0x7a, 0xd2, 0x3b, 0x52,
+	0xfb, 0x3f, 0xef, 0xb6, 0x63, 0x69, 0x59, 0xbb, 0xba, 0xae, 0xe5, 0xfd, 0x5c, 0x2c, 0x43, 0x77,
+	0x95, 0xf6, 0xd3, 0xb3, 0x7e, 0xbb, 0x24, 0x09, 0x74, 0xd7, 0xa5, 0x7c, 0xdb, 0x22, 0x7a, 0x0f,
+	0xb6, 0x16, 0xa0, 0xc3, 0x8c, 0xac, 0x5f, 0x5d, 0xd7, 0x8a, 0x5d, 0x97, 0x8a, 0x0b, 0x11, 0x2d,
+	0x1a, 0x50, 0x9e, 0xb7, 0x38, 0xeb, 0x9e, 0xf5, 0x9a, 0xc7, 0xa5, 0x5a, 0xa5, 0x74, 0x75, 0x5d,
+	0x2b, 0x04, 0x9d, 0x1e, 0xc7, 0x47, 0x91, 0xb5, 0x7e, 0xfa, 0xc5, 0xcb, 0xaa, 0xf4, 0xe5, 0xcb,
+	0xaa, 0xf4, 0xf7, 0x97, 0x55, 0xe9, 0xc5, 0xab, 0xea, 0xca, 0x97, 0xaf, 0xaa, 0x2b, 0x7f, 0x79,
+	0x55, 0x5d, 0xf9, 0xf4, 0xc3, 0x91, 0xc1, 0xce, 0x27, 0x83, 0xc6, 0xd0, 0x1e, 0xef, 0xc6, 0xff,
+	0xfa, 0x8b, 0x3e, 0xc5, 0x5f, 0x90, 0x37, 0xff, 0x16, 0x1c, 0xa4, 0x51, 0xfe, 0xc1, 0x7f, 0x06,
+	0x00, 0xec, 0x87, 0x71, 0x76, 0xd7, 0x14, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1799,6 +1880,46 @@ func (m *Part) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ParityPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ParityPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ParityPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bytes) > 0 {
+		i -= len(m.Bytes)
+		copy(dAtA[i:], m.Bytes)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Bytes)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *BlockID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2136,6 +2257,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2951,6 +3079,25 @@ func (m *Part) Size() (n int) {
 	return n
 }
 
+func (m *ParityPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovTypes(uint64(m.Size_))
+	}
+	l = len(m.Bytes)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *BlockID) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3113,6 +3260,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3662,6 +3813,128 @@ func (m *Part) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ParityPart) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ParityPart: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ParityPart: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bytes = append(m.Bytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bytes == nil {
+				m.Bytes = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *BlockID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4913,6 +5186,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -53,6 +53,87 @@ func WaitForHeight(ctx context.Context, c StatusClient, h int64, waiter Waiter)
 	return nil
 }
 
+// HeightClient is the subset of the RPC client used by AwaitHeight.
+type HeightClient interface {
+	StatusClient
+	EventsClient
+}
+
+// awaitHeightPollInterval is the interval at which AwaitHeight polls the
+// status of the node when the event log is not available.
+const awaitHeightPollInterval = 500 * time.Millisecond
+
+// AwaitHeight blocks until the latest block height of the node reaches target,
+// and returns immediately if it already has. It waits for NewBlockHeader
+// events from the event log of the node, and falls back to polling the status
+// of the node if the event log is not enabled. It returns ctx.Err() if ctx ends
+// first.
+//
+// Unlike WaitForHeight, AwaitHeight does not give up on targets far ahead of
+// the current height: use the deadline of ctx to bound the wait.
+func AwaitHeight(ctx context.Context, c HeightClient, target int64) error {
+	status, err := c.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.SyncInfo.LatestBlockHeight >= target {
+		return nil
+	}
+
+	// Blocks committed before the first request are still in the event log,
+	// so starting from its beginning ensures none are missed.
+	req := &coretypes.RequestEvents{
+		Filter:   &coretypes.EventFilter{Query: types.EventQueryNewBlockHeader.String()},
+		MaxItems: 1,
+		WaitTime: 10 * time.Second,
+	}
+	for {
+		rsp, err := c.Events(ctx, req)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			return pollHeight(ctx, c, target)
+		}
+
+		// Items are ordered from newest to oldest.
+		if len(rsp.Items) != 0 {
+			var data types.EventData
+			if err := jsontypes.Unmarshal(rsp.Items[0].Data, &data); err != nil {
+				return err
+			}
+			if header, ok := data.(types.EventDataNewBlockHeader); ok && header.Header.Height >= target {
+				return nil
+			}
+		}
+		if rsp.Newest != "" {
+			req.After = rsp.Newest
+		}
+	}
+}
+
+// pollHeight polls the status of the node until its latest block height
+// reaches target or ctx ends.
+func pollHeight(ctx context.Context, c StatusClient, target int64) error {
+	ticker := time.NewTicker(awaitHeightPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if status.SyncInfo.LatestBlockHeight >= target {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // WaitForOneEvent waits for the first event matching the given query on c, or
 // until ctx ends. It reports an error if ctx ends before a matching event is
 // received.
@@ -75,3 +156,79 @@ func WaitForOneEvent(ctx context.Context, c EventsClient, query string) (types.E
 		return result, nil
 	}
 }
+
+// SubscribeNewBlock subscribes to NewBlock events on c and returns a channel
+// that delivers the data of each event. See subscribeTyped for the lifetime
+// of the returned channel.
+func SubscribeNewBlock(ctx context.Context, c SubscriptionClient, subscriber string,
+	outCapacity ...int) (<-chan types.EventDataNewBlock, error) {
+	return subscribeTyped[types.EventDataNewBlock](ctx, c, subscriber, types.EventQueryNewBlock.String(), outCapacity...)
+}
+
+// SubscribeNewBlockHeader subscribes to NewBlockHeader events on c and returns
+// a channel that delivers the data of each event. See subscribeTyped for the
+// lifetime of the returned channel.
+func SubscribeNewBlockHeader(ctx context.Context, c SubscriptionClient, subscriber string,
+	outCapacity ...int) (<-chan types.EventDataNewBlockHeader, error) {
+	return subscribeTyped[types.EventDataNewBlockHeader](ctx, c, subscriber, types.EventQueryNewBlockHeader.String(), outCapacity...)
+}
+
+// SubscribeTx subscribes to Tx events on c and returns a channel that delivers
+// the data of each event. See subscribeTyped for the lifetime of the returned
+// channel.
+func SubscribeTx(ctx context.Context, c SubscriptionClient, subscriber string,
+	outCapacity ...int) (<-chan types.EventDataTx, error) {
+	return subscribeTyped[types.EventDataTx](ctx, c, subscriber, types.EventQueryTx.String(), outCapacity...)
+}
+
+// SubscribeValidatorSetUpdates subscribes to ValidatorSetUpdates events on c
+// and returns a channel that delivers the data of each event. See
+// subscribeTyped for the lifetime of the returned channel.
+func SubscribeValidatorSetUpdates(ctx context.Context, c SubscriptionClient, subscriber string,
+	outCapacity ...int) (<-chan types.EventDataValidatorSetUpdates, error) {
+	return subscribeTyped[types.EventDataValidatorSetUpdates](ctx, c, subscriber,
+		types.EventQueryValidatorSetUpdates.String(), outCapacity...)
+}
+
+// subscribeTyped subscribes to query on c and forwards the data of each
+// matching event to the returned channel. Events whose data are not of type T
+// are dropped.
+//
+// Unlike c.Subscribe, ctx governs the lifetime of the returned channel as well
+// as the initial subscription: the channel is closed once ctx ends or the
+// underlying subscription is closed. Callers
+// should still call Unsubscribe or UnsubscribeAll to stop the subscription on
+// the server.
+func subscribeTyped[T types.EventData](ctx context.Context, c SubscriptionClient, subscriber, query string,
+	outCapacity ...int) (<-chan T, error) {
+	in, err := c.Subscribe(ctx, subscriber, query, outCapacity...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan T, cap(in))
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-in:
+				if !ok {
+					return
+				}
+				data, ok := evt.Data.(T)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -3,15 +3,20 @@ package client_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/internal/jsontypes"
 	"github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/mock"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestWaitForHeight(t *testing.T) {
@@ -81,3 +86,151 @@ func TestWaitForHeight(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, int64(15), postr.SyncInfo.LatestBlockHeight)
 }
+
+// subscriptionClient is a client.SubscriptionClient that delivers the events
+// sent on its channel to every subscription.
+type subscriptionClient struct {
+	events chan coretypes.ResultEvent
+	query  string
+}
+
+func (c *subscriptionClient) Subscribe(ctx context.Context, subscriber, query string,
+	outCapacity ...int) (<-chan coretypes.ResultEvent, error) {
+	c.query = query
+	return c.events, nil
+}
+
+func (c *subscriptionClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	return nil
+}
+
+func (c *subscriptionClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	return nil
+}
+
+func TestSubscribeTyped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &subscriptionClient{events: make(chan coretypes.ResultEvent, 2)}
+	blocks, err := client.SubscribeNewBlock(ctx, c, "test")
+	require.NoError(t, err)
+	require.Equal(t, types.EventQueryNewBlock.String(), c.query)
+
+	// Events with data of another type are dropped.
+	c.events <- coretypes.ResultEvent{Data: types.EventDataTx{}}
+	c.events <- coretypes.ResultEvent{Data: types.EventDataNewBlock{
+		BlockID: types.BlockID{Hash: []byte("hash")},
+	}}
+
+	select {
+	case block := <-blocks:
+		assert.Equal(t, []byte("hash"), []byte(block.BlockID.Hash))
+	case <-time.After(time.Second):
+		t.Fatal("did not receive a new block after 1 sec.")
+	}
+
+	// The channel is closed once the context ends.
+	cancel()
+	select {
+	case _, ok := <-blocks:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after 1 sec.")
+	}
+
+	// The channel is also closed once the underlying subscription is closed.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	c = &subscriptionClient{events: make(chan coretypes.ResultEvent)}
+	txs, err := client.SubscribeTx(ctx, c, "test")
+	require.NoError(t, err)
+	close(c.events)
+	select {
+	case _, ok := <-txs:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after 1 sec.")
+	}
+}
+
+// heightClient is a client.HeightClient serving the height of a node that
+// advances by one block every time it is queried.
+type heightClient struct {
+	mtx       sync.Mutex
+	height    int64
+	eventLog  bool
+	statusReq int
+	eventsReq int
+}
+
+func (c *heightClient) advance() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.height++
+	return c.height
+}
+
+func (c *heightClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	c.mtx.Lock()
+	c.statusReq++
+	c.mtx.Unlock()
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.advance()}}, nil
+}
+
+func (c *heightClient) LagStatus(context.Context) (*coretypes.ResultLagStatus, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *heightClient) Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error) {
+	c.mtx.Lock()
+	c.eventsReq++
+	c.mtx.Unlock()
+	if !c.eventLog {
+		return nil, errors.New("the event log is not enabled")
+	}
+
+	height := c.advance()
+	data, err := jsontypes.Marshal(types.EventDataNewBlockHeader{Header: types.Header{Height: height}})
+	if err != nil {
+		return nil, err
+	}
+	cursor := fmt.Sprint(height)
+	return &coretypes.ResultEvents{
+		Items:  []*coretypes.EventItem{{Cursor: cursor, Event: types.EventNewBlockHeaderValue, Data: data}},
+		Newest: cursor,
+	}, nil
+}
+
+func TestAwaitHeight(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("AlreadyReached", func(t *testing.T) {
+		c := &heightClient{height: 9, eventLog: true}
+		require.NoError(t, client.AwaitHeight(ctx, c, 5))
+		assert.Equal(t, 1, c.statusReq)
+		assert.Equal(t, 0, c.eventsReq)
+	})
+
+	t.Run("EventLog", func(t *testing.T) {
+		c := &heightClient{eventLog: true}
+		require.NoError(t, client.AwaitHeight(ctx, c, 5))
+		assert.Equal(t, 1, c.statusReq)
+		assert.Equal(t, 4, c.eventsReq)
+	})
+
+	t.Run("PollsWithoutEventLog", func(t *testing.T) {
+		c := &heightClient{}
+		require.NoError(t, client.AwaitHeight(ctx, c, 3))
+		assert.Equal(t, 3, c.statusReq)
+		assert.Equal(t, 1, c.eventsReq)
+	})
+
+	t.Run("ContextExpires", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		c := &heightClient{}
+		err := client.AwaitHeight(ctx, c, 1000)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
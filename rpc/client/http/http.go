@@ -101,30 +101,51 @@ var (
 //-----------------------------------------------------------------------------
 // HTTP
 
-// New takes a remote endpoint in the form <protocol>://<host>:<port>. An error
-// is returned on invalid remote.
-func New(remote string) (*HTTP, error) {
+// Option sets an optional parameter on the HTTP client.
+type Option func(*options)
+
+type options struct {
+	retryPolicy *RetryPolicy
+	metrics     *Metrics
+}
+
+// WithRetryPolicy makes the client retry requests that fail with a transient
+// error according to policy. Requests sent in a batch are not retried. By
+// default, requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) { o.retryPolicy = &policy }
+}
+
+// WithMetrics sets the metrics of the client.
+func WithMetrics(metrics *Metrics) Option {
+	return func(o *options) { o.metrics = metrics }
+}
+
+// New takes a remote endpoint in the form <protocol>://<host>:<port>, or
+// unix://<path> for a unix socket. An error is returned on invalid remote.
+func New(remote string, opts ...Option) (*HTTP, error) {
 	c, err := jsonrpcclient.DefaultHTTPClient(remote)
 	if err != nil {
 		return nil, err
 	}
-	return NewWithClient(remote, c)
+	return NewWithClient(remote, c, opts...)
 }
 
 // NewWithTimeout does the same thing as New, except you can set a Timeout for
-// http.Client. A Timeout of zero means no timeout.
-func NewWithTimeout(remote string, t time.Duration) (*HTTP, error) {
+// http.Client. A Timeout of zero means no timeout. With a retry policy, the
+// timeout applies to each attempt.
+func NewWithTimeout(remote string, t time.Duration, opts ...Option) (*HTTP, error) {
 	c, err := jsonrpcclient.DefaultHTTPClient(remote)
 	if err != nil {
 		return nil, err
 	}
 	c.Timeout = t
-	return NewWithClient(remote, c)
+	return NewWithClient(remote, c, opts...)
 }
 
 // NewWithClient constructs an RPC client using a custom HTTP client.
 // An error is reported if c == nil or remote is an invalid address.
-func NewWithClient(remote string, c *http.Client) (*HTTP, error) {
+func NewWithClient(remote string, c *http.Client, opts ...Option) (*HTTP, error) {
 	if c == nil {
 		return nil, errors.New("nil client")
 	}
@@ -138,10 +159,19 @@ func NewWithClient(remote string, c *http.Client) (*HTTP, error) {
 		return nil, err
 	}
 
+	o := options{metrics: NopMetrics()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var caller jsonrpcclient.Caller = rpc
+	if o.retryPolicy != nil {
+		caller = &retryCaller{caller: rpc, policy: *o.retryPolicy, metrics: o.metrics}
+	}
+
 	httpClient := &HTTP{
 		rpc:           rpc,
 		remote:        remote,
-		baseRPCClient: &baseRPCClient{caller: rpc},
+		baseRPCClient: &baseRPCClient{caller: caller},
 		wsEvents:      wsEvents,
 	}
 
@@ -155,6 +185,12 @@ func (c *HTTP) Remote() string {
 	return c.remote
 }
 
+// WaitForHeight blocks until the node reaches height target or ctx ends. See
+// rpcclient.AwaitHeight.
+func (c *HTTP) WaitForHeight(ctx context.Context, target int64) error {
+	return rpcclient.AwaitHeight(ctx, c, target)
+}
+
 // NewBatch creates a new batch client for this HTTP client.
 func (c *HTTP) NewBatch() *BatchHTTP {
 	rpcBatch := c.rpc.NewRequestBatch()
@@ -282,6 +318,18 @@ func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*coretypes.Resul
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolEntries(ctx context.Context, page *int, perPage *int) (*coretypes.ResultMempoolEntries, error) {
+	result := new(coretypes.ResultMempoolEntries)
+
+	if err := c.caller.Call(ctx, "mempool_entries", &coretypes.RequestMempoolEntries{
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultCheckTx, error) {
 	result := new(coretypes.ResultCheckTx)
 	if err := c.caller.Call(ctx, "check_tx", &coretypes.RequestCheckTx{Tx: tx}, result); err != nil {
@@ -321,6 +369,14 @@ func (c *baseRPCClient) ConsensusState(ctx context.Context) (*coretypes.ResultCo
 	return result, nil
 }
 
+func (c *baseRPCClient) ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error) {
+	result := new(coretypes.ResultConsensusVotes)
+	if err := c.caller.Call(ctx, "consensus_votes", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	result := new(coretypes.ResultConsensusParams)
 	if err := c.caller.Call(ctx, "consensus_params", &coretypes.RequestConsensusParams{
@@ -358,6 +414,16 @@ func (c *baseRPCClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight
 	return result, nil
 }
 
+func (c *baseRPCClient) HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error) {
+	result := new(coretypes.ResultHeightETA)
+	if err := c.caller.Call(ctx, "height_eta", &coretypes.RequestHeightETA{
+		Height: coretypes.Int64(height),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	result := new(coretypes.ResultGenesis)
 	if err := c.caller.Call(ctx, "genesis", nil, result); err != nil {
@@ -394,6 +460,14 @@ func (c *baseRPCClient) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockByAppHash(ctx context.Context, appHash bytes.HexBytes) (*coretypes.ResultBlock, error) {
+	result := new(coretypes.ResultBlock)
+	if err := c.caller.Call(ctx, "block_by_app_hash", &coretypes.RequestBlockByAppHash{AppHash: appHash}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
 	result := new(coretypes.ResultBlockResults)
 	if err := c.caller.Call(ctx, "block_results", &coretypes.RequestBlockInfo{
@@ -442,6 +516,22 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash bytes.HexBytes, prove bool)
 	return result, nil
 }
 
+func (c *baseRPCClient) TxEvents(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxEvents, error) {
+	result := new(coretypes.ResultTxEvents)
+	if err := c.caller.Call(ctx, "tx_events", &coretypes.RequestTxEvents{Hash: hash}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) TxProof(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxProof, error) {
+	result := new(coretypes.ResultTxProof)
+	if err := c.caller.Call(ctx, "tx_proof", &coretypes.RequestTxProof{Hash: hash}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	result := new(coretypes.ResultTxSearch)
 	if err := c.caller.Call(ctx, "tx_search", &coretypes.RequestTxSearch{
@@ -471,6 +561,16 @@ func (c *baseRPCClient) BlockSearch(ctx context.Context, query string, page, per
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error) {
+	result := new(coretypes.ResultValidatorUptime)
+	if err := c.caller.Call(ctx, "validator_uptime", &coretypes.RequestValidatorUptime{
+		Window: coretypes.Int64Ptr(window),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
 	result := new(coretypes.ResultValidators)
 	if err := c.caller.Call(ctx, "validators", &coretypes.RequestValidators{
@@ -492,3 +592,23 @@ func (c *baseRPCClient) BroadcastEvidence(ctx context.Context, ev types.Evidence
 	}
 	return result, nil
 }
+
+func (c *baseRPCClient) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	result := new(coretypes.ResultExpiredEvidence)
+	if err := c.caller.Call(ctx, "expired_evidence", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) PendingEvidence(ctx context.Context, evidenceType string, page, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	result := new(coretypes.ResultPendingEvidence)
+	if err := c.caller.Call(ctx, "pending_evidence", &coretypes.RequestPendingEvidence{
+		Type:    evidenceType,
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
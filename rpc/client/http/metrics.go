@@ -0,0 +1,17 @@
+package http
+
+import "github.com/go-kit/kit/metrics"
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc_client"
+)
+
+//go:generate go run ../../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of requests retried by the client, labeled by RPC method.
+	RetriedRequests metrics.Counter `metrics_labels:"method"`
+}
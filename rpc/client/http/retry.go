@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	mrand "math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+// RetryPolicy configures how the client retries requests that fail with a
+// transient error: a network error, or an HTTP status listed in
+// RetryableStatusCodes. Errors returned by the RPC methods themselves are
+// never retried.
+//
+// Between attempts the client waits BaseBackoff, doubled after every attempt
+// and capped at MaxBackoff. Retries stop early if the request context is done
+// or its deadline would expire before the next attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the first retry.
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction, in [0, 1], of each delay that is randomized, so
+	// that clients failing together don't retry in lockstep.
+	Jitter float64
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+
+	// RetryNonIdempotent enables retrying the methods not known to be
+	// idempotent, such as broadcast_tx_*. A retry of such a method may apply
+	// it twice if the failed attempt reached the node.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy retrying idempotent requests up to three
+// times on network errors and on the HTTP statuses typically returned by
+// overloaded nodes and proxies in front of them.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// idempotentMethods are the RPC methods that don't change the state of the
+// node, and are therefore always retried. Any other method, including those
+// added after this list, is only retried if the policy sets
+// RetryNonIdempotent.
+var idempotentMethods = map[string]bool{
+	"abci_info":            true,
+	"abci_query":           true,
+	"block":                true,
+	"block_by_app_hash":    true,
+	"block_by_hash":        true,
+	"block_results":        true,
+	"block_search":         true,
+	"blockchain":           true,
+	"check_tx":             true,
+	"commit":               true,
+	"consensus_params":     true,
+	"consensus_state":      true,
+	"consensus_votes":      true,
+	"dump_consensus_state": true,
+	"events":               true,
+	"expired_evidence":     true,
+	"genesis":              true,
+	"genesis_chunked":      true,
+	"header":               true,
+	"header_by_hash":       true,
+	"health":               true,
+	"height_eta":           true,
+	"lag_status":           true,
+	"mempool_entries":      true,
+	"net_info":             true,
+	"num_unconfirmed_txs":  true,
+	"pending_evidence":     true,
+	"status":               true,
+	"tx":                   true,
+	"tx_events":            true,
+	"tx_proof":             true,
+	"tx_search":            true,
+	"unconfirmed_txs":      true,
+	"validator_uptime":     true,
+	"validators":           true,
+}
+
+// retryableMethod reports whether requests for method may be retried.
+func (p RetryPolicy) retryableMethod(method string) bool {
+	return p.MaxAttempts > 1 && (p.RetryNonIdempotent || idempotentMethods[method])
+}
+
+// retryableError reports whether a request that failed with err may be retried.
+func (p RetryPolicy) retryableError(err error) bool {
+	var statusErr *jsonrpcclient.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		for _, code := range p.RetryableStatusCodes {
+			if statusErr.Code == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	// The HTTP client wraps all transport errors in a *url.Error, including
+	// those that are not transient, such as an unsupported scheme.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		// nolint:gosec // G404: Use of weak random number generator
+		d -= time.Duration(mrand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// retryCaller is a jsonrpcclient.Caller retrying failed requests according to
+// a RetryPolicy.
+type retryCaller struct {
+	caller  jsonrpcclient.Caller
+	policy  RetryPolicy
+	metrics *Metrics
+}
+
+var _ jsonrpcclient.Caller = (*retryCaller)(nil)
+
+func (c *retryCaller) Call(ctx context.Context, method string, params, result interface{}) error {
+	if !c.policy.retryableMethod(method) {
+		return c.caller.Call(ctx, method, params, result)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.caller.Call(ctx, method, params, result)
+		if err == nil || attempt >= c.policy.MaxAttempts || ctx.Err() != nil || !c.policy.retryableError(err) {
+			return err
+		}
+
+		backoff := c.policy.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		c.metrics.RetriedRequests.With("method", method).Add(1)
+	}
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// newFlakyServer returns a server failing the first failures requests with
+// status, and replying with an empty result to the following ones.
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{}}`, req.ID())
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	return policy
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RetriesTransientStatus", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+		c, err := New(srv.URL, WithRetryPolicy(testRetryPolicy()))
+		require.NoError(t, err)
+
+		_, err = c.Health(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 5, http.StatusServiceUnavailable)
+		c, err := New(srv.URL, WithRetryPolicy(testRetryPolicy()))
+		require.NoError(t, err)
+
+		_, err = c.Health(ctx)
+		var statusErr *jsonrpcclient.HTTPStatusError
+		require.True(t, errors.As(err, &statusErr), err)
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.Code)
+		assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+	})
+
+	t.Run("DoesNotRetryOtherStatus", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 1, http.StatusInternalServerError)
+		c, err := New(srv.URL, WithRetryPolicy(testRetryPolicy()))
+		require.NoError(t, err)
+
+		_, err = c.Health(ctx)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+	})
+
+	t.Run("DoesNotRetryNonIdempotent", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+		c, err := New(srv.URL, WithRetryPolicy(testRetryPolicy()))
+		require.NoError(t, err)
+
+		_, err = c.BroadcastTxSync(ctx, types.Tx("tx"))
+		require.Error(t, err)
+		assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+	})
+
+	t.Run("OnlyRetriesKnownIdempotentMethods", func(t *testing.T) {
+		policy := testRetryPolicy()
+		assert.True(t, policy.retryableMethod("block"))
+		assert.False(t, policy.retryableMethod("broadcast_evidence"))
+		assert.False(t, policy.retryableMethod("unsafe_flush_mempool"))
+		assert.False(t, policy.retryableMethod("some_new_method"))
+
+		policy.RetryNonIdempotent = true
+		assert.True(t, policy.retryableMethod("some_new_method"))
+	})
+
+	t.Run("RetriesNonIdempotentIfEnabled", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+		policy := testRetryPolicy()
+		policy.RetryNonIdempotent = true
+		c, err := New(srv.URL, WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		_, err = c.BroadcastTxSync(ctx, types.Tx("tx"))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+	})
+
+	t.Run("HonorsContextDeadline", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 5, http.StatusServiceUnavailable)
+		policy := testRetryPolicy()
+		policy.BaseBackoff = time.Minute
+		c, err := New(srv.URL, WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		start := time.Now()
+		_, err = c.Health(ctx)
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		srv, requests := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+
+		_, err = c.Health(ctx)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 4*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(4))
+	assert.Equal(t, 5*time.Second, policy.backoff(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := policy.backoff(1)
+		assert.True(t, d > time.Second/2 && d <= time.Second, d)
+	}
+}
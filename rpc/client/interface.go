@@ -74,13 +74,27 @@ type ABCIClient interface {
 type SignClient interface {
 	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultBlock, error)
+	BlockByAppHash(ctx context.Context, appHash bytes.HexBytes) (*coretypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
 	Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error)
+
+	// ValidatorUptime reports how many of the latest blocks (100 by default)
+	// were committed without the vote of the node's validator.
+	ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error)
+
 	Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error)
 
+	// TxEvents returns the events emitted by a transaction, in emission order,
+	// along with their block-relative index and indexed composite keys.
+	TxEvents(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxEvents, error)
+
+	// TxProof returns a transaction along with the Merkle proof of its
+	// inclusion in the DataHash of its block.
+	TxProof(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxProof, error)
+
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// DeliverTx event search criteria.
 	TxSearch(
@@ -92,7 +106,9 @@ type SignClient interface {
 	) (*coretypes.ResultTxSearch, error)
 
 	// BlockSearch defines a method to search for a paginated set of blocks by
-	// FinalizeBlock event search criteria.
+	// FinalizeBlock event search criteria. orderBy is of the form
+	// "[<key>] [asc|desc]", where the key is block.height, block.time or a
+	// numeric FinalizeBlock event attribute.
 	BlockSearch(
 		ctx context.Context,
 		query string,
@@ -106,6 +122,10 @@ type HistoryClient interface {
 	Genesis(context.Context) (*coretypes.ResultGenesis, error)
 	GenesisChunked(context.Context, uint) (*coretypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error)
+
+	// HeightETA estimates when the given height will be committed, from the
+	// recent average block interval.
+	HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error)
 }
 
 // StatusClient provides access to general chain info.
@@ -120,6 +140,7 @@ type NetworkClient interface {
 	NetInfo(context.Context) (*coretypes.ResultNetInfo, error)
 	DumpConsensusState(context.Context) (*coretypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*coretypes.ResultConsensusState, error)
+	ConsensusVotes(context.Context) (*coretypes.ResultConsensusVotes, error)
 	ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error)
 	Health(context.Context) (*coretypes.ResultHealth, error)
 }
@@ -145,6 +166,9 @@ type SubscriptionClient interface {
 	// the lifetime of the channel. To cancel a subscription call Unsubscribe or
 	// UnsubscribeAll.
 	//
+	// Helpers such as SubscribeNewBlock and SubscribeTx wrap this method to
+	// deliver the concrete event data types for common events.
+	//
 	// Deprecated: This method will be removed in Tendermint v0.37, use Events
 	// instead.
 	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan coretypes.ResultEvent, err error)
@@ -166,14 +190,17 @@ type SubscriptionClient interface {
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, page, perPage *int) (*coretypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*coretypes.ResultUnconfirmedTxs, error)
+	MempoolEntries(ctx context.Context, page, perPage *int) (*coretypes.ResultMempoolEntries, error)
 	CheckTx(context.Context, types.Tx) (*coretypes.ResultCheckTx, error)
 	RemoveTx(context.Context, types.TxKey) error
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
-// behavior.
+// behavior and for listing expired evidence the node still retains.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*coretypes.ResultBroadcastEvidence, error)
+	ExpiredEvidence(context.Context) (*coretypes.ResultExpiredEvidence, error)
+	PendingEvidence(ctx context.Context, evidenceType string, page, perPage *int) (*coretypes.ResultPendingEvidence, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
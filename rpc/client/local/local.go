@@ -73,6 +73,12 @@ func (c *Local) LagStatus(ctx context.Context) (*coretypes.ResultLagStatus, erro
 	return c.env.LagStatus(ctx)
 }
 
+// WaitForHeight blocks until the node reaches height target or ctx ends. See
+// rpcclient.AwaitHeight.
+func (c *Local) WaitForHeight(ctx context.Context, target int64) error {
+	return rpcclient.AwaitHeight(ctx, c, target)
+}
+
 func (c *Local) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
 	return c.env.ABCIInfo(ctx)
 }
@@ -114,6 +120,13 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfi
 	return c.env.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Local) MempoolEntries(ctx context.Context, page, perPage *int) (*coretypes.ResultMempoolEntries, error) {
+	return c.env.MempoolEntries(ctx, &coretypes.RequestMempoolEntries{
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	})
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultCheckTx, error) {
 	return c.env.CheckTx(ctx, &coretypes.RequestCheckTx{Tx: tx})
 }
@@ -134,6 +147,10 @@ func (c *Local) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusS
 	return c.env.GetConsensusState(ctx)
 }
 
+func (c *Local) ConsensusVotes(ctx context.Context) (*coretypes.ResultConsensusVotes, error) {
+	return c.env.ConsensusVotes(ctx)
+}
+
 func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	return c.env.ConsensusParams(ctx, &coretypes.RequestConsensusParams{Height: (*coretypes.Int64)(height)})
 }
@@ -153,6 +170,10 @@ func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	})
 }
 
+func (c *Local) HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error) {
+	return c.env.HeightETA(ctx, &coretypes.RequestHeightETA{Height: coretypes.Int64(height)})
+}
+
 func (c *Local) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.env.Genesis(ctx)
 }
@@ -169,6 +190,10 @@ func (c *Local) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretype
 	return c.env.BlockByHash(ctx, &coretypes.RequestBlockByHash{Hash: hash})
 }
 
+func (c *Local) BlockByAppHash(ctx context.Context, appHash bytes.HexBytes) (*coretypes.ResultBlock, error) {
+	return c.env.BlockByAppHash(ctx, &coretypes.RequestBlockByAppHash{AppHash: appHash})
+}
+
 func (c *Local) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
 	return c.env.BlockResults(ctx, &coretypes.RequestBlockInfo{Height: (*coretypes.Int64)(height)})
 }
@@ -185,6 +210,10 @@ func (c *Local) Commit(ctx context.Context, height *int64) (*coretypes.ResultCom
 	return c.env.Commit(ctx, &coretypes.RequestBlockInfo{Height: (*coretypes.Int64)(height)})
 }
 
+func (c *Local) ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error) {
+	return c.env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{Window: coretypes.Int64Ptr(window)})
+}
+
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
 	return c.env.Validators(ctx, &coretypes.RequestValidators{
 		Height:  (*coretypes.Int64)(height),
@@ -197,6 +226,14 @@ func (c *Local) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coret
 	return c.env.Tx(ctx, &coretypes.RequestTx{Hash: hash, Prove: prove})
 }
 
+func (c *Local) TxEvents(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxEvents, error) {
+	return c.env.TxEvents(ctx, &coretypes.RequestTxEvents{Hash: hash})
+}
+
+func (c *Local) TxProof(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxProof, error) {
+	return c.env.TxProof(ctx, &coretypes.RequestTxProof{Hash: hash})
+}
+
 func (c *Local) TxSearch(ctx context.Context, queryString string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	return c.env.TxSearch(ctx, &coretypes.RequestTxSearch{
 		Query:   queryString,
@@ -220,6 +257,18 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*core
 	return c.env.BroadcastEvidence(ctx, &coretypes.RequestBroadcastEvidence{Evidence: ev})
 }
 
+func (c *Local) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	return c.env.ExpiredEvidence(ctx)
+}
+
+func (c *Local) PendingEvidence(ctx context.Context, evidenceType string, page, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	return c.env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{
+		Type:    evidenceType,
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	})
+}
+
 func (c *Local) Subscribe(ctx context.Context, subscriber, queryString string, capacity ...int) (<-chan coretypes.ResultEvent, error) {
 	q, err := query.New(queryString)
 	if err != nil {
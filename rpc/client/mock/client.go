@@ -142,6 +142,10 @@ func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	})
 }
 
+func (c Client) HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error) {
+	return c.env.HeightETA(ctx, &coretypes.RequestHeightETA{Height: coretypes.Int64(height)})
+}
+
 func (c Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return c.env.Genesis(ctx)
 }
@@ -154,10 +158,18 @@ func (c Client) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretype
 	return c.env.BlockByHash(ctx, &coretypes.RequestBlockByHash{Hash: hash})
 }
 
+func (c Client) BlockByAppHash(ctx context.Context, appHash bytes.HexBytes) (*coretypes.ResultBlock, error) {
+	return c.env.BlockByAppHash(ctx, &coretypes.RequestBlockByAppHash{AppHash: appHash})
+}
+
 func (c Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error) {
 	return c.env.Commit(ctx, &coretypes.RequestBlockInfo{Height: (*coretypes.Int64)(height)})
 }
 
+func (c Client) ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error) {
+	return c.env.ValidatorUptime(ctx, &coretypes.RequestValidatorUptime{Window: coretypes.Int64Ptr(window)})
+}
+
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
 	return c.env.Validators(ctx, &coretypes.RequestValidators{
 		Height:  (*coretypes.Int64)(height),
@@ -169,3 +181,15 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(ctx, &coretypes.RequestBroadcastEvidence{Evidence: ev})
 }
+
+func (c Client) ExpiredEvidence(ctx context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	return c.env.ExpiredEvidence(ctx)
+}
+
+func (c Client) PendingEvidence(ctx context.Context, evidenceType string, page, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	return c.env.PendingEvidence(ctx, &coretypes.RequestPendingEvidence{
+		Type:    evidenceType,
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	})
+}
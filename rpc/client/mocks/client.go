@@ -112,6 +112,29 @@ func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBl
 	return r0, r1
 }
 
+// BlockByAppHash provides a mock function with given fields: ctx, appHash
+func (_m *Client) BlockByAppHash(ctx context.Context, appHash bytes.HexBytes) (*coretypes.ResultBlock, error) {
+	ret := _m.Called(ctx, appHash)
+
+	var r0 *coretypes.ResultBlock
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultBlock); ok {
+		r0 = rf(ctx, appHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlock)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, appHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockByHash provides a mock function with given fields: ctx, hash
 func (_m *Client) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, hash)
@@ -411,6 +434,29 @@ func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensu
 	return r0, r1
 }
 
+// ConsensusVotes provides a mock function with given fields: _a0
+func (_m *Client) ConsensusVotes(_a0 context.Context) (*coretypes.ResultConsensusVotes, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultConsensusVotes
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultConsensusVotes); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultConsensusVotes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DumpConsensusState provides a mock function with given fields: _a0
 func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -457,6 +503,29 @@ func (_m *Client) Events(ctx context.Context, req *coretypes.RequestEvents) (*co
 	return r0, r1
 }
 
+// ExpiredEvidence provides a mock function with given fields: _a0
+func (_m *Client) ExpiredEvidence(_a0 context.Context) (*coretypes.ResultExpiredEvidence, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultExpiredEvidence
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultExpiredEvidence); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultExpiredEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -572,6 +641,52 @@ func (_m *Client) Health(_a0 context.Context) (*coretypes.ResultHealth, error) {
 	return r0, r1
 }
 
+// HeightETA provides a mock function with given fields: ctx, height
+func (_m *Client) HeightETA(ctx context.Context, height int64) (*coretypes.ResultHeightETA, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultHeightETA
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *coretypes.ResultHeightETA); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultHeightETA)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MempoolEntries provides a mock function with given fields: ctx, page, perPage
+func (_m *Client) MempoolEntries(ctx context.Context, page *int, perPage *int) (*coretypes.ResultMempoolEntries, error) {
+	ret := _m.Called(ctx, page, perPage)
+
+	var r0 *coretypes.ResultMempoolEntries
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *int) *coretypes.ResultMempoolEntries); ok {
+		r0 = rf(ctx, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultMempoolEntries)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *int) error); ok {
+		r1 = rf(ctx, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetInfo provides a mock function with given fields: _a0
 func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error) {
 	ret := _m.Called(_a0)
@@ -618,6 +733,29 @@ func (_m *Client) NumUnconfirmedTxs(_a0 context.Context) (*coretypes.ResultUncon
 	return r0, r1
 }
 
+// PendingEvidence provides a mock function with given fields: ctx, evidenceType, page, perPage
+func (_m *Client) PendingEvidence(ctx context.Context, evidenceType string, page *int, perPage *int) (*coretypes.ResultPendingEvidence, error) {
+	ret := _m.Called(ctx, evidenceType, page, perPage)
+
+	var r0 *coretypes.ResultPendingEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *int) *coretypes.ResultPendingEvidence); ok {
+		r0 = rf(ctx, evidenceType, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultPendingEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *int) error); ok {
+		r1 = rf(ctx, evidenceType, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveTx provides a mock function with given fields: _a0, _a1
 func (_m *Client) RemoveTx(_a0 context.Context, _a1 types.TxKey) error {
 	ret := _m.Called(_a0, _a1)
@@ -722,6 +860,52 @@ func (_m *Client) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*cor
 	return r0, r1
 }
 
+// TxEvents provides a mock function with given fields: ctx, hash
+func (_m *Client) TxEvents(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxEvents, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxEvents
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultTxEvents); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TxProof provides a mock function with given fields: ctx, hash
+func (_m *Client) TxProof(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxProof, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxProof
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultTxProof); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TxSearch provides a mock function with given fields: ctx, query, prove, page, perPage, orderBy
 func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
 	ret := _m.Called(ctx, query, prove, page, perPage, orderBy)
@@ -819,6 +1003,29 @@ func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perP
 	return r0, r1
 }
 
+// ValidatorUptime provides a mock function with given fields: ctx, window
+func (_m *Client) ValidatorUptime(ctx context.Context, window *int) (*coretypes.ResultValidatorUptime, error) {
+	ret := _m.Called(ctx, window)
+
+	var r0 *coretypes.ResultValidatorUptime
+	if rf, ok := ret.Get(0).(func(context.Context, *int) *coretypes.ResultValidatorUptime); ok {
+		r0 = rf(ctx, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorUptime)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int) error); ok {
+		r1 = rf(ctx, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
@@ -246,12 +246,43 @@ func TestClientMethodCalls(t *testing.T) {
 				require.NoError(t, err, "%d: %+v", i, err)
 				assert.NotEmpty(t, cons.RoundState)
 			})
+			t.Run("ConsensusVotes", func(t *testing.T) {
+				nc, ok := c.(client.NetworkClient)
+				require.True(t, ok, "%d", i)
+				votes, err := nc.ConsensusVotes(ctx)
+				require.NoError(t, err, "%d: %+v", i, err)
+				assert.Positive(t, votes.Height)
+				require.Len(t, votes.Validators, 1)
+				assert.Equal(t, 1, votes.Prevotes.Size())
+				assert.Equal(t, 1, votes.Precommits.Size())
+			})
 			t.Run("Health", func(t *testing.T) {
 				nc, ok := c.(client.NetworkClient)
 				require.True(t, ok, "%d", i)
 				_, err := nc.Health(ctx)
 				require.NoError(t, err, "%d: %+v", i, err)
 			})
+			t.Run("WaitForHeight", func(t *testing.T) {
+				hw, ok := c.(interface {
+					WaitForHeight(context.Context, int64) error
+				})
+				require.True(t, ok, "%d", i)
+
+				status, err := c.Status(ctx)
+				require.NoError(t, err)
+				target := status.SyncInfo.LatestBlockHeight + 2
+
+				wctx, wcancel := context.WithTimeout(ctx, 30*time.Second)
+				defer wcancel()
+				require.NoError(t, hw.WaitForHeight(wctx, target))
+
+				status, err = c.Status(ctx)
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, status.SyncInfo.LatestBlockHeight, target)
+
+				// Heights that were already reached return immediately.
+				require.NoError(t, hw.WaitForHeight(wctx, 1))
+			})
 			t.Run("GenesisAndValidators", func(t *testing.T) {
 				// make sure this is the right genesis file
 				gen, err := c.Genesis(ctx)
@@ -348,6 +379,27 @@ func TestClientMethodCalls(t *testing.T) {
 				assert.EqualValues(t, txh, ptx.Height)
 				assert.EqualValues(t, tx, ptx.Tx)
 
+				// the proof of inclusion verifies against the block's data hash
+				tp, err := c.TxProof(ctx, bres.Hash)
+				require.NoError(t, err)
+				assert.EqualValues(t, txh, tp.Height)
+				assert.EqualValues(t, tx, tp.Tx)
+				txBlock, err := c.Block(ctx, &txh)
+				require.NoError(t, err)
+				require.NoError(t, tp.Validate(txBlock.Block.DataHash))
+				require.Error(t, tp.Validate(txBlock.Block.LastResultsHash))
+
+				// the tx events are returned in emission order
+				evs, err := c.TxEvents(ctx, bres.Hash)
+				require.NoError(t, err)
+				assert.EqualValues(t, txh, evs.Height)
+				require.Len(t, evs.Events, len(ptx.TxResult.Events))
+				for j, ev := range evs.Events {
+					assert.Equal(t, ptx.TxResult.Events[j].Type, ev.Type)
+				}
+				require.NotEmpty(t, evs.Events)
+				assert.Equal(t, []string{"app.creator", "app.key", "app.index_key"}, evs.Events[0].CompositeKeys)
+
 				// and we can even check the block is added
 				block, err := c.Block(ctx, &apph)
 				require.NoError(t, err)
@@ -359,6 +411,16 @@ func TestClientMethodCalls(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, block, blockByHash)
 
+				// the app hash in the header was produced by an earlier block
+				blockByAppHash, err := c.BlockByAppHash(ctx, appHash)
+				require.NoError(t, err)
+				require.NotNil(t, blockByAppHash.Block)
+				producedHeight := blockByAppHash.Block.Height + 1
+				assert.True(t, producedHeight <= apph)
+				nextHeader, err := c.Header(ctx, &producedHeight)
+				require.NoError(t, err)
+				assert.EqualValues(t, appHash, nextHeader.Header.AppHash)
+
 				// check that the header matches the block hash
 				header, err := c.Header(ctx, &apph)
 				require.NoError(t, err)
@@ -516,6 +578,47 @@ func TestClientMethodCalls(t *testing.T) {
 						require.Equal(t, firstBlockHeight+i, block.Header.Height)
 					}
 				})
+				t.Run("HeaderResults", func(t *testing.T) {
+					const subscriber = "TestHeaderResults"
+
+					// The header event carries the same results as the
+					// block_results method.
+					eventCh, err := c.Subscribe(ctx, subscriber, types.QueryForEvent(types.EventNewBlockHeaderValue).String())
+					require.NoError(t, err)
+					t.Cleanup(func() {
+						if err := c.UnsubscribeAll(ctx, subscriber); err != nil {
+							t.Error(err)
+						}
+					})
+
+					_, _, tx := MakeTxKV()
+					_, err = c.BroadcastTxSync(ctx, tx)
+					require.NoError(t, err)
+
+					timeout := time.After(10 * waitForEventTimeout)
+					for {
+						var event coretypes.ResultEvent
+						select {
+						case event = <-eventCh:
+						case <-timeout:
+							t.Fatal("did not receive the results of a block with txs")
+						}
+
+						headerEvent, ok := event.Data.(types.EventDataNewBlockHeader)
+						require.True(t, ok, "%d: %#v", i, event.Data)
+						results := headerEvent.ResultFinalizeBlock
+						if len(results.TxResults) == 0 {
+							continue
+						}
+
+						height := headerEvent.Header.Height
+						blockResults, err := c.BlockResults(ctx, &height)
+						require.NoError(t, err)
+						require.Equal(t, blockResults.TxsResults, results.TxResults)
+						require.Equal(t, blockResults.FinalizeBlockEvents, results.Events)
+						return
+					}
+				})
 				t.Run("BroadcastTxAsync", func(t *testing.T) {
 					testTxEventsSent(ctx, t, "async", c)
 				})
@@ -642,6 +745,56 @@ func TestClientMethodCallsAdvanced(t *testing.T) {
 
 		pool.Flush()
 	})
+	t.Run("MempoolEntries", func(t *testing.T) {
+		// populate mempool with 5 tx
+		txs := make([]types.Tx, 5)
+		ch := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			_, _, tx := MakeTxKV()
+
+			txs[i] = tx
+			err := pool.CheckTx(ctx, tx, func(_ *abci.ResponseCheckTx) { ch <- nil }, mempool.TxInfo{})
+
+			require.NoError(t, err)
+		}
+		// wait for tx to arrive in mempoool.
+		for i := 0; i < 5; i++ {
+			select {
+			case <-ch:
+			case <-time.After(5 * time.Second):
+				t.Error("Timed out waiting for CheckTx callback")
+			}
+		}
+		close(ch)
+
+		hashes := make([]string, len(txs))
+		for i, tx := range txs {
+			hashes[i] = fmt.Sprintf("%X", tx.Hash())
+		}
+
+		for _, c := range GetClients(t, n, conf) {
+			for i := 1; i <= 2; i++ {
+				mc := c.(client.MempoolClient)
+				page, perPage := i, 3
+				res, err := mc.MempoolEntries(ctx, &page, &perPage)
+				require.NoError(t, err)
+
+				if i == 2 {
+					perPage = 2
+				}
+				assert.Equal(t, perPage, res.Count)
+				assert.Equal(t, 5, res.Total)
+				assert.Equal(t, pool.SizeBytes(), res.TotalBytes)
+				require.Len(t, res.Entries, perPage)
+				for _, entry := range res.Entries {
+					assert.Contains(t, hashes, entry.Hash.String())
+					assert.False(t, entry.Time.IsZero())
+				}
+			}
+		}
+
+		pool.Flush()
+	})
 	t.Run("NumUnconfirmedTxs", func(t *testing.T) {
 		ch := make(chan struct{})
 
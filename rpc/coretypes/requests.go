@@ -23,6 +23,14 @@ type RequestBlockchainInfo struct {
 	MaxHeight Int64 `json:"maxHeight"`
 }
 
+type RequestHeightETA struct {
+	Height Int64 `json:"height"`
+}
+
+type RequestValidatorUptime struct {
+	Window *Int64 `json:"window"`
+}
+
 type RequestGenesisChunked struct {
 	Chunk Int64 `json:"chunk"`
 }
@@ -35,6 +43,10 @@ type RequestBlockByHash struct {
 	Hash bytes.HexBytes `json:"hash"`
 }
 
+type RequestBlockByAppHash struct {
+	AppHash bytes.HexBytes `json:"app_hash"`
+}
+
 type RequestCheckTx struct {
 	Tx types.Tx `json:"tx"`
 }
@@ -48,6 +60,14 @@ type RequestTx struct {
 	Prove bool           `json:"prove"`
 }
 
+type RequestTxProof struct {
+	Hash bytes.HexBytes `json:"hash"`
+}
+
+type RequestTxEvents struct {
+	Hash bytes.HexBytes `json:"hash"`
+}
+
 type RequestTxSearch struct {
 	Query   string `json:"query"`
 	Prove   bool   `json:"prove"`
@@ -64,9 +84,10 @@ type RequestBlockSearch struct {
 }
 
 type RequestValidators struct {
-	Height  *Int64 `json:"height"`
-	Page    *Int64 `json:"page"`
-	PerPage *Int64 `json:"per_page"`
+	Height        *Int64         `json:"height"`
+	Page          *Int64         `json:"page"`
+	PerPage       *Int64         `json:"per_page"`
+	AddressPrefix bytes.HexBytes `json:"address_prefix"`
 }
 
 type RequestConsensusParams struct {
@@ -78,6 +99,11 @@ type RequestUnconfirmedTxs struct {
 	PerPage *Int64 `json:"per_page"`
 }
 
+type RequestMempoolEntries struct {
+	Page    *Int64 `json:"page"`
+	PerPage *Int64 `json:"per_page"`
+}
+
 type RequestBroadcastTx struct {
 	Tx types.Tx `json:"tx"`
 }
@@ -89,6 +115,16 @@ type RequestABCIQuery struct {
 	Prove  bool           `json:"prove"`
 }
 
+type RequestUnsafeSetChannelPriority struct {
+	Channel  Int64 `json:"channel"`
+	Priority Int64 `json:"priority"`
+}
+
+type RequestUnsafeRemoveTx struct {
+	Hash      bytes.HexBytes `json:"hash"`
+	Blacklist bool           `json:"blacklist"`
+}
+
 type RequestBroadcastEvidence struct {
 	Evidence types.Evidence
 }
@@ -118,6 +154,18 @@ func (r *RequestBroadcastEvidence) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Evidence types, as listed by the pending_evidence endpoint.
+const (
+	EvidenceTypeDuplicateVote     = "duplicate_vote"
+	EvidenceTypeLightClientAttack = "light_client_attack"
+)
+
+type RequestPendingEvidence struct {
+	Type    string `json:"type"`
+	Page    *Int64 `json:"page"`
+	PerPage *Int64 `json:"per_page"`
+}
+
 // RequestEvents is the argument for the "/events" RPC endpoint.
 type RequestEvents struct {
 	// Optional filter spec. If nil or empty, all items are eligible.
@@ -9,7 +9,9 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/jsontypes"
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -23,6 +25,7 @@ var (
 	ErrHeightExceedsChainHead = errors.New("height must be less than or equal to the head of the node's blockchain")
 	ErrHeightNotAvailable     = errors.New("height is not available")
 	ErrLagIsTooHigh           = errors.New("lag is too high")
+	ErrNotEnoughBlocks        = errors.New("not enough blocks to estimate the block interval")
 	// ErrInvalidRequest is used as a wrapper to cover more specific cases where the user has
 	// made an invalid request
 	ErrInvalidRequest = errors.New("invalid request")
@@ -34,6 +37,28 @@ type ResultBlockchainInfo struct {
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
 }
 
+// Estimated time until a height is reached
+type ResultHeightETA struct {
+	Height          int64     `json:"height,string"`
+	LatestHeight    int64     `json:"latest_height,string"`
+	LatestBlockTime time.Time `json:"latest_block_time"`
+
+	// Reached is set if the height was already committed, in which case
+	// Elapsed is the time since its block, if it is still in the block store.
+	Reached bool          `json:"reached"`
+	Elapsed time.Duration `json:"elapsed,string"`
+
+	// ETA is the estimated time left until the height is committed, which is
+	// zero if the height is reached or overdue.
+	ETA           time.Duration `json:"eta,string"`
+	EstimatedTime time.Time     `json:"estimated_time"`
+
+	// The average block interval, over the blocks of the sample window.
+	AvgBlockInterval  time.Duration `json:"avg_block_interval,string"`
+	WindowStartHeight int64         `json:"window_start_height,string"`
+	WindowEndHeight   int64         `json:"window_end_height,string"`
+}
+
 // Genesis file
 type ResultGenesis struct {
 	Genesis *types.GenesisDoc `json:"genesis"`
@@ -74,6 +99,15 @@ type ResultBlockResults struct {
 	FinalizeBlockEvents   []abci.Event             `json:"finalize_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate   `json:"validator_updates"`
 	ConsensusParamUpdates *tmproto.ConsensusParams `json:"consensus_param_updates"`
+
+	// TotalGasWanted is the sum of the gas wanted by the transactions.
+	TotalGasWanted int64 `json:"total_gas_wanted,string"`
+	// MaxBlockGas is the consensus max gas of the block, -1 if unlimited, or 0
+	// if the consensus params of the height are not available.
+	MaxBlockGas int64 `json:"max_block_gas,string"`
+	// BlockGasPercent is the percentage of MaxBlockGas used by the
+	// transactions, or 0 if MaxBlockGas is not positive.
+	BlockGasPercent float64 `json:"block_gas_percent"`
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -127,12 +161,15 @@ type ValidatorInfo struct {
 	Address     bytes.HexBytes
 	PubKey      crypto.PubKey
 	VotingPower int64
+	// ConsensusPaused is true while the node doesn't sign votes and proposals.
+	ConsensusPaused bool
 }
 
 type validatorInfoJSON struct {
-	Address     bytes.HexBytes  `json:"address"`
-	PubKey      json.RawMessage `json:"pub_key"`
-	VotingPower int64           `json:"voting_power,string"`
+	Address         bytes.HexBytes  `json:"address"`
+	PubKey          json.RawMessage `json:"pub_key"`
+	VotingPower     int64           `json:"voting_power,string"`
+	ConsensusPaused bool            `json:"consensus_paused"`
 }
 
 func (v ValidatorInfo) MarshalJSON() ([]byte, error) {
@@ -141,7 +178,7 @@ func (v ValidatorInfo) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 	return json.Marshal(validatorInfoJSON{
-		Address: v.Address, PubKey: pk, VotingPower: v.VotingPower,
+		Address: v.Address, PubKey: pk, VotingPower: v.VotingPower, ConsensusPaused: v.ConsensusPaused,
 	})
 }
 
@@ -155,6 +192,7 @@ func (v *ValidatorInfo) UnmarshalJSON(data []byte) error {
 	}
 	v.Address = val.Address
 	v.VotingPower = val.VotingPower
+	v.ConsensusPaused = val.ConsensusPaused
 	return nil
 }
 
@@ -167,6 +205,25 @@ type ResultStatus struct {
 	LightClientInfo types.LightClientInfo `json:"light_client_info,omitempty"`
 }
 
+// How often the local validator missed signing the recent blocks
+type ResultValidatorUptime struct {
+	// Applicable is false if the node was not a validator for any block of
+	// the window, in which case no block is counted.
+	Applicable bool           `json:"applicable"`
+	Address    bytes.HexBytes `json:"address"`
+
+	WindowStartHeight int64 `json:"window_start_height,string"`
+	WindowEndHeight   int64 `json:"window_end_height,string"`
+
+	// ValidatorBlocks is the number of blocks of the window committed while
+	// the node was a validator, whose commit it either signed or missed.
+	ValidatorBlocks  int64   `json:"validator_blocks,string"`
+	SignedBlocks     int64   `json:"signed_blocks,string"`
+	MissedBlocks     int64   `json:"missed_blocks,string"`
+	MissedRate       float64 `json:"missed_rate"`
+	LastMissedHeight int64   `json:"last_missed_height,string"`
+}
+
 // Node lag status
 type ResultLagStatus struct {
 	CurrentHeight int64 `json:"current_height"`
@@ -247,6 +304,26 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
+// ResultConsensusVotes is a compact summary of the votes received by the node
+// in its current round.
+// UNSTABLE
+type ResultConsensusVotes struct {
+	Height int64  `json:"height,string"`
+	Round  int32  `json:"round"`
+	Step   string `json:"step"`
+
+	// Validators are the addresses of the validators of the height, in the
+	// order of the vote bit arrays.
+	Validators []bytes.HexBytes `json:"validators"`
+	Prevotes   *bits.BitArray   `json:"prevotes"`
+	Precommits *bits.BitArray   `json:"precommits"`
+
+	// ProposalBlockHash is the hash of the proposal block, empty until the
+	// node received all of its parts.
+	ProposalBlockHash  bytes.HexBytes `json:"proposal_block_hash"`
+	ProposalBlockParts *bits.BitArray `json:"proposal_block_parts"`
+}
+
 // CheckTx result
 type ResultBroadcastTx struct {
 	Code      uint32         `json:"code"`
@@ -279,10 +356,49 @@ type ResultTx struct {
 	Proof    types.TxProof     `json:"proof,omitempty"`
 }
 
+// Result of querying for the proof of inclusion of a tx in its block. The proof
+// is a path from the hash of Tx to DataHash, the root of the Merkle tree of the
+// block's txs.
+type ResultTxProof struct {
+	Hash     bytes.HexBytes `json:"hash"`
+	Height   int64          `json:"height,string"`
+	Index    uint32         `json:"index"`
+	Tx       types.Tx       `json:"tx"`
+	DataHash bytes.HexBytes `json:"data_hash"`
+	Proof    merkle.Proof   `json:"proof"`
+}
+
+// Validate checks that the proof includes the tx in the txs with the given
+// data hash, usually the DataHash of a trusted header at Height.
+func (r *ResultTxProof) Validate(dataHash []byte) error {
+	return types.TxProof{RootHash: r.DataHash, Data: r.Tx, Proof: r.Proof}.Validate(dataHash)
+}
+
+// Result of querying for the events emitted by a tx
+type ResultTxEvents struct {
+	Hash   bytes.HexBytes `json:"hash"`
+	Height int64          `json:"height,string"`
+	Index  uint32         `json:"index"`
+	Events []TxEvent      `json:"events"`
+}
+
+// TxEvent is an event emitted by a tx, along with its index among the events
+// emitted by all the txs of the block and the composite keys ("type.key") its
+// attributes were indexed under.
+type TxEvent struct {
+	Index         uint64                `json:"index,string"`
+	Type          string                `json:"type"`
+	Attributes    []abci.EventAttribute `json:"attributes"`
+	CompositeKeys []string              `json:"composite_keys"`
+}
+
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count,string"`
+	// Truncated is true if the search was cut short by a limit of the node,
+	// so that Txs and TotalCount only cover the transactions found so far.
+	Truncated bool `json:"truncated"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
@@ -299,6 +415,29 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// MempoolEntry describes a transaction in the mempool along with the metadata
+// the mempool tracks for it.
+type MempoolEntry struct {
+	Hash      bytes.HexBytes `json:"hash"`
+	Size      int            `json:"size,string"`
+	Priority  int64          `json:"priority,string"`
+	Sender    string         `json:"sender"`
+	GasWanted int64          `json:"gas_wanted,string"`
+	Time      time.Time      `json:"time"`
+
+	// PendingRecheck is set if the transaction hasn't been rechecked since
+	// the last block yet.
+	PendingRecheck bool `json:"pending_recheck"`
+}
+
+// List of mempool entries
+type ResultMempoolEntries struct {
+	Count      int            `json:"n_txs,string"`
+	Total      int            `json:"total,string"`
+	TotalBytes int64          `json:"total_bytes,string"`
+	Entries    []MempoolEntry `json:"entries"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -314,6 +453,71 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of listing expired evidence that is still retained
+type ResultExpiredEvidence struct {
+	Count    int                `json:"n_evidence,string"`
+	Evidence types.EvidenceList `json:"evidence"`
+}
+
+// EvidenceInfo summarizes a piece of evidence.
+type EvidenceInfo struct {
+	Hash   bytes.HexBytes `json:"hash"`
+	Type   string         `json:"type"`
+	Height int64          `json:"height,string"`
+	Time   time.Time      `json:"time"`
+	// Validators are the addresses of the validators implicated.
+	Validators []bytes.HexBytes `json:"validators"`
+}
+
+// Result of listing the evidence pending inclusion in a block. Info[i]
+// summarizes Evidence[i].
+type ResultPendingEvidence struct {
+	Count    int                `json:"n_evidence,string"`
+	Total    int                `json:"total,string"`
+	Info     []EvidenceInfo     `json:"info"`
+	Evidence types.EvidenceList `json:"evidence"`
+}
+
+// PeerScore describes how a peer is currently scored by the peer manager.
+type PeerScore struct {
+	ID           types.NodeID `json:"node_id"`
+	Score        int          `json:"score,string"`
+	MutableScore int64        `json:"mutable_score,string"`
+	NumBans      uint32       `json:"num_bans,string"`
+	BannedUntil  time.Time    `json:"banned_until"`
+}
+
+// Scores of all known peers, best scored first
+type ResultUnsafePeerScores struct {
+	Peers []PeerScore `json:"peers"`
+}
+
+// Whether consensus participation is paused
+type ResultUnsafeConsensusPause struct {
+	Paused bool `json:"paused"`
+}
+
+// The send priority set for a p2p channel
+type ResultUnsafeSetChannelPriority struct {
+	Channel  int64 `json:"channel"`
+	Priority int64 `json:"priority"`
+}
+
+// The transaction removed from the mempool
+type ResultUnsafeRemoveTx struct {
+	Hash        bytes.HexBytes `json:"hash"`
+	Blacklisted bool           `json:"blacklisted"`
+}
+
+// Time spent in each ABCI call made for the last committed block
+type ResultUnsafeABCITimings struct {
+	Height          int64         `json:"height,string"`
+	PrepareProposal time.Duration `json:"prepare_proposal,string"`
+	ProcessProposal time.Duration `json:"process_proposal,string"`
+	FinalizeBlock   time.Duration `json:"finalize_block,string"`
+	Commit          time.Duration `json:"commit,string"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
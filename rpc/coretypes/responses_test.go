@@ -72,7 +72,10 @@ func TestResultBlockResults_regression8583(t *testing.T) {
       "power": "400"
     }
   ],
-  "consensus_param_updates": null
+  "consensus_param_updates": null,
+  "total_gas_wanted": "0",
+  "max_block_gas": "0",
+  "block_gas_percent": 0
 }`, wantKey))))
 
 	bits, err := json.Marshal(rsp)
@@ -119,6 +119,25 @@ type Caller interface {
 
 //-------------------------------------------------------------
 
+// HTTPStatusError is returned by Client.Call when the server replies with a
+// non-2xx HTTP status and the request fails. Err is the error decoding the
+// response body, which is an *rpctypes.RPCError if the server replied with a
+// JSON-RPC error.
+type HTTPStatusError struct {
+	Code int
+	Err  error
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP status %d: %v", e.Code, e.Err)
+}
+
+func (e *HTTPStatusError) Unwrap() error { return e.Err }
+
+func isSuccessStatus(code int) bool { return code >= 200 && code < 300 }
+
+//-------------------------------------------------------------
+
 // Client is a JSON-RPC client, which sends POST HTTP requests to the
 // remote server.
 //
@@ -216,7 +235,11 @@ func (c *Client) Call(ctx context.Context, method string, params, result interfa
 		return fmt.Errorf("reading response body: %w", err)
 	}
 
-	return unmarshalResponseBytes(responseBytes, request.ID(), result)
+	err = unmarshalResponseBytes(responseBytes, request.ID(), result)
+	if err != nil && !isSuccessStatus(httpResponse.StatusCode) {
+		return &HTTPStatusError{Code: httpResponse.StatusCode, Err: err}
+	}
+	return err
 }
 
 // NewRequestBatch starts a batch of requests for this client.
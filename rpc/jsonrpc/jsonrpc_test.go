@@ -112,7 +112,7 @@ func setup(ctx context.Context, t *testing.T, logger log.Logger) error {
 	wm := server.NewWebsocketManager(tcpLogger, Routes, server.ReadWait(5*time.Second), server.PingPeriod(1*time.Second))
 	mux.HandleFunc(websocketEndpoint, wm.WebsocketHandler)
 	config := server.DefaultConfig()
-	listener1, err := server.Listen(tcpAddr, config.MaxOpenConnections)
+	listener1, err := server.Listen(tcpAddr, config)
 	if err != nil {
 		return err
 	}
@@ -129,7 +129,7 @@ func setup(ctx context.Context, t *testing.T, logger log.Logger) error {
 	server.RegisterRPCFuncs(mux2, Routes, unixLogger)
 	wm = server.NewWebsocketManager(unixLogger, Routes)
 	mux2.HandleFunc(websocketEndpoint, wm.WebsocketHandler)
-	listener2, err := server.Listen(unixAddr, config.MaxOpenConnections)
+	listener2, err := server.Listen(unixAddr, config)
 	if err != nil {
 		return err
 	}
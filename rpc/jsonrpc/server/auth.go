@@ -0,0 +1,33 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// AuthTokenHandler wraps h in a handler that requires requests to carry an
+// "Authorization: Bearer <token>" header. Other requests are rejected with a
+// 401 Unauthorized status. If token is empty, h is returned as is.
+func AuthTokenHandler(h http.Handler, token string) http.Handler {
+	if token == "" {
+		return h
+	}
+	return authTokenHandler{handler: h, token: []byte(token)}
+}
+
+type authTokenHandler struct {
+	handler http.Handler
+	token   []byte
+}
+
+func (h authTokenHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	scheme, token, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(token), h.token) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="tendermint"`)
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid auth token"))
+		return
+	}
+	h.handler.ServeHTTP(w, req)
+}
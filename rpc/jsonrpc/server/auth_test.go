@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/cors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestAuthTokenHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		token  string
+		header string
+		status int
+	}{
+		{"", "", http.StatusOK},
+		{"secret", "", http.StatusUnauthorized},
+		{"secret", "Bearer wrong", http.StatusUnauthorized},
+		{"secret", "Basic secret", http.StatusUnauthorized},
+		{"secret", "Bearer secret", http.StatusOK},
+		{"secret", "bearer secret", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rec := httptest.NewRecorder()
+		AuthTokenHandler(ok, tc.token).ServeHTTP(rec, req)
+		assert.Equal(t, tc.status, rec.Code, "token %q, header %q", tc.token, tc.header)
+		if tc.status == http.StatusUnauthorized {
+			assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
+
+func TestAuthTokenCORS(t *testing.T) {
+	var calls int
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	})
+	cfg := DefaultConfig()
+	cfg.AuthToken = "secret"
+	cfg.CORS = &cors.Options{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedHeaders: []string{"Authorization"},
+	}
+	srv := httptest.NewServer(wrapHandler(ok, log.NewNopLogger(), cfg))
+	t.Cleanup(srv.Close)
+
+	do := func(method, auth string, header map[string]string) *http.Response {
+		req, err := http.NewRequest(method, srv.URL+"/status", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://example.com")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rsp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		rsp.Body.Close()
+		return rsp
+	}
+
+	// preflight requests don't carry credentials, nor reach the handler
+	rsp := do(http.MethodOptions, "", map[string]string{
+		"Access-Control-Request-Method":  http.MethodGet,
+		"Access-Control-Request-Headers": "Authorization",
+	})
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	assert.Equal(t, "https://example.com", rsp.Header.Get("Access-Control-Allow-Origin"))
+
+	// other requests must be authenticated, and carry CORS headers either way
+	rsp = do(http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rsp.StatusCode)
+	assert.Equal(t, "https://example.com", rsp.Header.Get("Access-Control-Allow-Origin"))
+	rsp = do(http.MethodGet, "Bearer secret", nil)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, "https://example.com", rsp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, 1, calls)
+}
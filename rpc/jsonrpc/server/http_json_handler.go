@@ -123,6 +123,9 @@ func writeListOfEndpoints(w http.ResponseWriter, r *http.Request, funcMap map[st
 	hasArgs := make(map[string]string)
 	noArgs := make(map[string]string)
 	for name, rf := range funcMap {
+		if rf.denied {
+			continue
+		}
 		base := fmt.Sprintf("//%s/%s", r.Host, name)
 		if len(rf.args) == 0 {
 			noArgs[name] = base
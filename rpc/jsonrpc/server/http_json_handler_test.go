@@ -264,3 +264,68 @@ func TestRPCResponseCache(t *testing.T) {
 	res.Body.Close()
 	require.NoError(t, err, "reading from the body should not give back an error")
 }
+
+func TestRestrictRPCFuncs(t *testing.T) {
+	echo := func(s string) *RPCFunc {
+		return NewRPCFunc(func(ctx context.Context) (string, error) { return s, nil })
+	}
+	funcMap := map[string]*RPCFunc{"a": echo("a"), "b": echo("b"), "c": echo("c")}
+
+	serve := func(t *testing.T, mux *http.ServeMux, req *http.Request) []byte {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.Bytes()
+	}
+
+	testCases := []struct {
+		allowed, denied []string
+		enabled         map[string]bool
+	}{
+		{nil, nil, map[string]bool{"a": true, "b": true, "c": true}},
+		{[]string{"a", "b", "unknown"}, nil, map[string]bool{"a": true, "b": true}},
+		{nil, []string{"b"}, map[string]bool{"a": true, "c": true}},
+		{[]string{"a", "b"}, []string{"b"}, map[string]bool{"a": true}},
+	}
+	for _, tc := range testCases {
+		mux := http.NewServeMux()
+		RegisterRPCFuncs(mux, RestrictRPCFuncs(funcMap, tc.allowed, tc.denied), log.NewNopLogger())
+
+		for _, name := range []string{"a", "b", "c"} {
+			// URI requests get the bare result or error
+			body := serve(t, mux, httptest.NewRequest(http.MethodGet, "/"+name, nil))
+			if tc.enabled[name] {
+				assert.Equal(t, `"`+name+`"`, string(body), "%s: %+v", name, tc)
+			} else {
+				rpcErr := new(rpctypes.RPCError)
+				require.NoError(t, json.Unmarshal(body, rpcErr))
+				assert.Equal(t, int(rpctypes.CodeMethodNotAllowed), rpcErr.Code, "%s: %+v", name, tc)
+			}
+
+			body = serve(t, mux, httptest.NewRequest(http.MethodPost, "/",
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+name+`"}`)))
+			res := new(rpctypes.RPCResponse)
+			require.NoError(t, json.Unmarshal(body, res))
+			if tc.enabled[name] {
+				assert.Nil(t, res.Error, "%s: %+v", name, tc)
+				assert.Equal(t, `"`+name+`"`, string(res.Result))
+			} else {
+				require.NotNil(t, res.Error, "%s: %+v", name, tc)
+				assert.Equal(t, int(rpctypes.CodeMethodNotAllowed), res.Error.Code)
+			}
+		}
+
+		// the disabled methods are not listed
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		for _, name := range []string{"a", "b", "c"} {
+			assert.Equal(t, tc.enabled[name], strings.Contains(rec.Body.String(), "/"+name+"<"), "%s: %+v", name, tc)
+		}
+	}
+
+	// the original functions are not disabled
+	for name, fn := range funcMap {
+		assert.False(t, fn.denied, name)
+	}
+}
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"runtime/debug"
 	"strings"
 	"time"
 
+	"github.com/rs/cors"
 	"golang.org/x/net/netutil"
 
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -35,6 +38,11 @@ type Config struct {
 	// See https://godoc.org/net/http#Server.WriteTimeout
 	WriteTimeout time.Duration
 
+	// Used to set the HTTP server's keep-alive idle timeout. If zero, the
+	// ReadTimeout is used.
+	// See https://godoc.org/net/http#Server.IdleTimeout
+	IdleTimeout time.Duration
+
 	// Controls the maximum number of bytes the server will read parsing the
 	// request body.
 	MaxBodyBytes int64
@@ -42,6 +50,35 @@ type Config struct {
 	// Controls the maximum size of a request header.
 	// See https://godoc.org/net/http#Server.MaxHeaderBytes
 	MaxHeaderBytes int
+
+	// The rate limits of RPC methods, by method name.
+	// See RateLimitHandler.
+	RateLimits map[string]config.RateLimit
+
+	// The rate limit shared by the methods missing from RateLimits. A zero
+	// QPS means no limit.
+	DefaultRateLimit config.RateLimit
+
+	// If true, each client IP address is rate limited separately.
+	RateLimitPerClient bool
+
+	// If not nil, the rate limiter used instead of one made from the settings
+	// above, so that several servers can share the same limits.
+	// See NewRateLimiter.
+	RateLimiter *RateLimiter
+
+	// The permissions of the socket file when listening on a unix socket. If
+	// zero, the permissions are left to the umask of the process.
+	UnixSocketPermissions os.FileMode
+
+	// If not empty, requests must carry this bearer token.
+	// See AuthTokenHandler.
+	AuthToken string
+
+	// If not nil, the cross-origin requests allowed. CORS preflight requests
+	// are answered before authentication, since browsers send them without
+	// credentials.
+	CORS *cors.Options
 }
 
 // DefaultConfig returns a default configuration.
@@ -50,20 +87,23 @@ func DefaultConfig() *Config {
 		MaxOpenConnections: 0, // unlimited
 		ReadTimeout:        10 * time.Second,
 		WriteTimeout:       0,       // no default timeout
+		IdleTimeout:        0,       // same as ReadTimeout
 		MaxBodyBytes:       1000000, // 1MB
 		MaxHeaderBytes:     1 << 20, // same as the net/http default
 	}
 }
 
 // Serve creates a http.Server and calls Serve with the given listener. It
-// wraps handler to recover panics and limit the request body size.
+// wraps handler to recover panics, authenticate the requests, limit the
+// request body size and rate limit the RPC methods.
 func Serve(ctx context.Context, listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
-	h := recoverAndLogHandler(MaxBytesHandler(handler, config.MaxBodyBytes), logger)
+	h := wrapHandler(handler, logger, config)
 	s := &http.Server{
 		Handler:        h,
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
+		IdleTimeout:    config.IdleTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
 	sig := make(chan struct{})
@@ -86,8 +126,8 @@ func Serve(ctx context.Context, listener net.Listener, handler http.Handler, log
 }
 
 // Serve creates a http.Server and calls ServeTLS with the given listener,
-// certFile and keyFile. It wraps handler to recover panics and limit the
-// request body size.
+// certFile and keyFile. It wraps handler to recover panics, authenticate the
+// requests, limit the request body size and rate limit the RPC methods.
 func ServeTLS(ctx context.Context, listener net.Listener, handler http.Handler, certFile, keyFile string, logger log.Logger, config *Config) error {
 	logger.Info("Starting RPC HTTPS server",
 		"listenterAddr", listener.Addr(),
@@ -95,9 +135,10 @@ func ServeTLS(ctx context.Context, listener net.Listener, handler http.Handler,
 		"keyFile", keyFile)
 
 	s := &http.Server{
-		Handler:        recoverAndLogHandler(MaxBytesHandler(handler, config.MaxBodyBytes), logger),
+		Handler:        wrapHandler(handler, logger, config),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
+		IdleTimeout:    config.IdleTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
 	sig := make(chan struct{})
@@ -119,6 +160,19 @@ func ServeTLS(ctx context.Context, listener net.Listener, handler http.Handler,
 	return nil
 }
 
+// wrapHandler wraps handler with the middlewares used by Serve and ServeTLS.
+// Unauthenticated requests are rejected before they count towards the rate
+// limits, and CORS headers are set before authentication, so that browsers can
+// read the responses to unauthenticated requests too.
+func wrapHandler(handler http.Handler, logger log.Logger, config *Config) http.Handler {
+	h := MaxBytesHandler(RateLimitHandler(handler, config, logger), config.MaxBodyBytes)
+	h = AuthTokenHandler(h, config.AuthToken)
+	if config.CORS != nil {
+		h = cors.New(*config.CORS).Handler(h)
+	}
+	return recoverAndLogHandler(h, logger)
+}
+
 // writeError writes an internal server error (500) to w with the text
 // of err in the body. This is a fallback used when a handler is unable to
 // write the expected response.
@@ -284,9 +338,15 @@ func (w statusWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
-// Listen starts a new net.Listener on the given address.
+// Listen starts a new net.Listener on the given address, accepting up to
+// config.MaxOpenConnections simultaneous connections if positive.
 // It returns an error if the address is invalid or the call to Listen() fails.
-func Listen(addr string, maxOpenConnections int) (listener net.Listener, err error) {
+//
+// A unix socket address, e.g. unix:///path/to/rpc.sock, replaces the socket
+// file left by a process that is no longer listening on it. The permissions of
+// the socket file are set to config.UnixSocketPermissions if non-zero, and the
+// file is removed when the listener is closed.
+func Listen(addr string, config *Config) (listener net.Listener, err error) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf(
@@ -295,13 +355,44 @@ func Listen(addr string, maxOpenConnections int) (listener net.Listener, err err
 		)
 	}
 	proto, addr := parts[0], parts[1]
+	if proto == "unix" {
+		if err := removeStaleSocket(addr); err != nil {
+			return nil, err
+		}
+	}
 	listener, err = net.Listen(proto, addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %v: %v", addr, err)
 	}
-	if maxOpenConnections > 0 {
-		listener = netutil.LimitListener(listener, maxOpenConnections)
+	if proto == "unix" && config.UnixSocketPermissions != 0 {
+		if err := os.Chmod(addr, config.UnixSocketPermissions); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to set the permissions of %v: %w", addr, err)
+		}
+	}
+	if config.MaxOpenConnections > 0 {
+		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
 	}
 
 	return listener, nil
 }
+
+// removeStaleSocket removes the unix socket file at path if no process is
+// listening on it anymore. It fails if the file isn't a socket, or if it is
+// still in use.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("cannot listen on %v: the file exists and is not a unix socket", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("cannot listen on %v: the unix socket is in use", path)
+	}
+	return os.Remove(path)
+}
@@ -8,6 +8,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -47,7 +49,8 @@ func TestMaxOpenConnections(t *testing.T) {
 		fmt.Fprint(w, "some body")
 	})
 	config := DefaultConfig()
-	l, err := Listen("tcp://127.0.0.1:0", max)
+	config.MaxOpenConnections = max
+	l, err := Listen("tcp://127.0.0.1:0", config)
 	require.NoError(t, err)
 	defer l.Close()
 
@@ -124,6 +127,44 @@ func TestServeTLS(t *testing.T) {
 	assert.Equal(t, []byte("some body"), body)
 }
 
+func TestListenUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rpc.sock")
+	addr := "unix://" + path
+	config := DefaultConfig()
+	config.UnixSocketPermissions = 0o660
+
+	l, err := Listen(addr, config)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+
+	// the socket is in use
+	_, err = Listen(addr, config)
+	require.Error(t, err)
+
+	// the socket file is removed on close
+	require.NoError(t, l.Close())
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	// a stale socket file is replaced
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+	l, err = Listen(addr, config)
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	// other files are left alone
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+	_, err = Listen(addr, config)
+	require.Error(t, err)
+}
+
 func TestWriteRPCResponse(t *testing.T) {
 	req := rpctypes.NewRequest(-1)
 
@@ -23,6 +23,11 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		ctx := rpctypes.WithCallInfo(req.Context(), &rpctypes.CallInfo{
 			HTTPRequest: req,
 		})
+		jreq := rpctypes.NewRequest(uriReqID)
+		if rpcFunc.denied {
+			writeHTTPResponse(w, logger, jreq.MakeError(nil, methodNotAllowedError()))
+			return
+		}
 		args, err := parseURLParams(rpcFunc.args, req)
 		if err != nil {
 			w.Header().Set("Content-Type", "text/plain")
@@ -30,7 +35,6 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 			fmt.Fprintln(w, err.Error())
 			return
 		}
-		jreq := rpctypes.NewRequest(uriReqID)
 		result, err := rpcFunc.Call(ctx, args)
 		if err == nil {
 			writeHTTPResponse(w, logger, jreq.MakeResponse(result))
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// rateLimitSweepInterval is how often the buckets that have refilled are
+// dropped, so that per-client buckets don't accumulate.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitHandler wraps h in a handler that rate limits the calls to each RPC
+// method, using the RateLimiter of config, or one made with NewRateLimiter if
+// it is nil. Requests over the limit are rejected with a 429 Too Many Requests
+// status and a Retry-After header. A JSON-RPC batch is rejected if any of its
+// calls is over the limit, without taking tokens for the others. Calls made
+// over a websocket connection share the same limits and are rejected with a
+// CodeRateLimitExceeded error. If no rate limit is configured, h is returned
+// as is.
+func RateLimitHandler(h http.Handler, config *Config, logger log.Logger) http.Handler {
+	limiter := config.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(config)
+	}
+	if limiter == nil {
+		return h
+	}
+	return rateLimitHandler{
+		handler: h,
+		limiter: limiter,
+		logger:  logger,
+	}
+}
+
+type rateLimitHandler struct {
+	handler http.Handler
+	limiter *RateLimiter
+	logger  log.Logger
+}
+
+func (h rateLimitHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var methods []string
+	if req.URL.Path == "/" {
+		// JSON-RPC calls. The body is restored for the wrapped handler, which
+		// also reports the requests that can't be parsed.
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			writeRPCResponse(w, h.logger, rpctypes.RPCRequest{}.MakeErrorf(
+				rpctypes.CodeInvalidRequest, "reading request body: %v", err))
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		if len(b) > 0 {
+			requests, _ := parseRequests(b)
+			for _, r := range requests {
+				if !r.IsNotification() {
+					methods = append(methods, r.Method)
+				}
+			}
+		}
+	} else {
+		methods = []string{strings.TrimPrefix(req.URL.Path, "/")}
+	}
+
+	client, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		client = req.RemoteAddr
+	}
+	if method, wait, ok := h.limiter.allowAll(methods, client, time.Now()); !ok {
+		h.logger.Debug("rate limited RPC request", "method", method, "remoteAddr", req.RemoteAddr)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded for method %q", method))
+		return
+	}
+
+	// The calls made over a websocket connection are checked by the
+	// connection itself, see rateLimitFromContext.
+	ctx := context.WithValue(req.Context(), rateLimitKey{}, callRateLimit{limiter: h.limiter, client: client})
+	h.handler.ServeHTTP(w, req.WithContext(ctx))
+}
+
+type rateLimitKey struct{}
+
+// callRateLimit is the rate limiter of the calls made by client.
+type callRateLimit struct {
+	limiter *RateLimiter
+	client  string
+}
+
+// rateLimitFromContext returns the rate limiter that RateLimitHandler
+// attached to the context of a request, if any.
+func rateLimitFromContext(ctx context.Context) (callRateLimit, bool) {
+	rl, ok := ctx.Value(rateLimitKey{}).(callRateLimit)
+	return rl, ok
+}
+
+// allow reports whether n calls to method are within the rate limit.
+func (rl callRateLimit) allow(method string, n int) bool {
+	methods := make([]string, n)
+	for i := range methods {
+		methods[i] = method
+	}
+	_, _, ok := rl.limiter.allowAll(methods, rl.client, time.Now())
+	return ok
+}
+
+// TakeRateLimitTokens counts a call to method made with the context ctx as n
+// more calls against its rate limit, for methods whose cost depends on their
+// parameters. It returns a CodeRateLimitExceeded error, and takes no tokens,
+// if that is over the limit. Calls that aren't rate limited are always
+// allowed.
+func TakeRateLimitTokens(ctx context.Context, method string, n int) error {
+	rl, ok := rateLimitFromContext(ctx)
+	if !ok || n <= 0 || rl.allow(method, n) {
+		return nil
+	}
+	return &rpctypes.RPCError{
+		Code:    int(rpctypes.CodeRateLimitExceeded),
+		Message: rpctypes.CodeRateLimitExceeded.String(),
+		Data:    method,
+	}
+}
+
+// NewRateLimiter returns a rate limiter for the RateLimits, DefaultRateLimit
+// and RateLimitPerClient of config, or nil if no rate limit is configured.
+// Servers sharing a rate limiter through their Config share the same limits.
+func NewRateLimiter(config *Config) *RateLimiter {
+	enabled := config.DefaultRateLimit.QPS > 0
+	for _, limit := range config.RateLimits {
+		enabled = enabled || limit.QPS > 0
+	}
+	if !enabled {
+		return nil
+	}
+	return newRateLimiter(config.RateLimits, config.DefaultRateLimit, config.RateLimitPerClient)
+}
+
+// RateLimiter holds the token buckets of the rate limited methods. Methods
+// without their own rate limit share the bucket of the default one. If
+// perClient is true, each client has its own buckets.
+type RateLimiter struct {
+	limits       map[string]config.RateLimit
+	defaultLimit config.RateLimit
+	perClient    bool
+
+	mtx       sync.Mutex
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	method string // empty for the default bucket
+	client string // empty unless rate limiting per client
+}
+
+type tokenBucket struct {
+	limit  config.RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limits map[string]config.RateLimit, defaultLimit config.RateLimit, perClient bool) *RateLimiter {
+	return &RateLimiter{
+		limits:       limits,
+		defaultLimit: defaultLimit,
+		perClient:    perClient,
+		buckets:      make(map[bucketKey]*tokenBucket),
+	}
+}
+
+// allow takes a token from the bucket of method for client at time now. If
+// there is none left, it returns false and how long until one is available.
+func (rl *RateLimiter) allow(method, client string, now time.Time) (time.Duration, bool) {
+	_, wait, ok := rl.allowAll([]string{method}, client, now)
+	return wait, ok
+}
+
+// allowAll takes a token for each of methods for client at time now, either
+// all of them or none. If a bucket doesn't have enough tokens left, it returns
+// false along with the first method over the limit and how long until enough
+// tokens are available for it.
+func (rl *RateLimiter) allowAll(methods []string, client string, now time.Time) (string, time.Duration, bool) {
+	if !rl.perClient {
+		client = ""
+	}
+
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	if now.Sub(rl.lastSweep) >= rateLimitSweepInterval {
+		for key, b := range rl.buckets {
+			if b.refill(now) >= b.capacity() {
+				delete(rl.buckets, key)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	// Count the tokens needed from each bucket before taking any, so that a
+	// rejected batch doesn't use up the tokens of its other calls.
+	needed := make(map[*tokenBucket]float64, len(methods))
+	for _, method := range methods {
+		limit, ok := rl.limits[method]
+		if !ok {
+			limit = rl.defaultLimit
+		}
+		if limit.QPS <= 0 {
+			continue
+		}
+		b := rl.bucket(method, client, limit, now)
+		needed[b]++
+		if b.tokens < needed[b] {
+			return method, time.Duration((needed[b] - b.tokens) / limit.QPS * float64(time.Second)), false
+		}
+	}
+	for b, n := range needed {
+		b.tokens -= n
+	}
+	return "", 0, true
+}
+
+// bucket returns the refilled bucket of method for client, creating it if
+// needed. Methods without their own rate limit share the default bucket. It
+// must be called with rl.mtx held.
+func (rl *RateLimiter) bucket(method, client string, limit config.RateLimit, now time.Time) *tokenBucket {
+	if _, ok := rl.limits[method]; !ok {
+		method = ""
+	}
+	key := bucketKey{method: method, client: client}
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &tokenBucket{limit: limit, last: now}
+		b.tokens = b.capacity()
+		rl.buckets[key] = b
+	}
+	b.tokens, b.last = b.refill(now), now
+	return b
+}
+
+func (b *tokenBucket) capacity() float64 {
+	if b.limit.Burst < 1 {
+		return 1
+	}
+	return float64(b.limit.Burst)
+}
+
+// refill returns the tokens in the bucket at time now.
+func (b *tokenBucket) refill(now time.Time) float64 {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return b.tokens
+	}
+	return math.Min(b.capacity(), b.tokens+elapsed*b.limit.QPS)
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := newRateLimiter(map[string]config.RateLimit{
+		"block_search": {QPS: 2, Burst: 3},
+		"status":       {},
+	}, config.RateLimit{QPS: 1, Burst: 1}, true)
+	now := time.Now()
+
+	// the burst is allowed, then calls wait for the bucket to refill
+	for i := 0; i < 3; i++ {
+		_, ok := rl.allow("block_search", "client1", now)
+		require.True(t, ok)
+	}
+	wait, ok := rl.allow("block_search", "client1", now)
+	require.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	_, ok = rl.allow("block_search", "client1", now.Add(wait))
+	assert.True(t, ok)
+
+	// each client has its own buckets
+	_, ok = rl.allow("block_search", "client2", now)
+	assert.True(t, ok)
+
+	// a batch is rejected as a whole, without taking tokens for its other calls
+	method, _, ok := rl.allowAll([]string{"block_search", "validators", "blockchain"}, "client3", now)
+	assert.False(t, ok)
+	assert.Equal(t, "blockchain", method)
+	for i := 0; i < 3; i++ {
+		_, ok := rl.allow("block_search", "client3", now)
+		require.True(t, ok)
+	}
+	_, ok = rl.allow("validators", "client3", now)
+	assert.True(t, ok)
+
+	// the methods without a rate limit share the default one
+	_, ok = rl.allow("blockchain", "client1", now)
+	assert.True(t, ok)
+	_, ok = rl.allow("validators", "client1", now)
+	assert.False(t, ok)
+
+	// a zero QPS means no limit
+	for i := 0; i < 10; i++ {
+		_, ok := rl.allow("status", "client1", now)
+		require.True(t, ok)
+	}
+
+	// refilled buckets are eventually dropped
+	require.Len(t, rl.buckets, 5)
+	_, ok = rl.allow("block_search", "client1", now.Add(rateLimitSweepInterval))
+	assert.True(t, ok)
+	assert.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body of JSON-RPC requests is passed on
+		if r.URL.Path == "/" {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NotEmpty(t, b)
+		}
+		calls++
+	})
+
+	// without rate limits, the handler isn't wrapped
+	cfg := DefaultConfig()
+	assert.IsType(t, handler, RateLimitHandler(handler, cfg, log.NewNopLogger()))
+
+	cfg.RateLimits = map[string]config.RateLimit{"block_search": {QPS: 1, Burst: 2}}
+	h := RateLimitHandler(handler, cfg, log.NewNopLogger())
+
+	serve := func(req *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Result()
+	}
+	get := func(path string) *http.Response {
+		return serve(httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	post := func(body string) *http.Response {
+		return serve(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	}
+
+	assert.Equal(t, http.StatusOK, get("/block_search?query=%22%22").StatusCode)
+	assert.Equal(t, http.StatusOK, post(`{"jsonrpc":"2.0","id":1,"method":"block_search"}`).StatusCode)
+
+	rsp := get("/block_search")
+	assert.Equal(t, http.StatusTooManyRequests, rsp.StatusCode)
+	assert.Equal(t, "1", rsp.Header.Get("Retry-After"))
+	rsp = post(`[{"jsonrpc":"2.0","id":1,"method":"status"},{"jsonrpc":"2.0","id":2,"method":"block_search"}]`)
+	assert.Equal(t, http.StatusTooManyRequests, rsp.StatusCode)
+	assert.Equal(t, 2, calls)
+
+	// other methods aren't limited
+	assert.Equal(t, http.StatusOK, get("/status").StatusCode)
+	assert.Equal(t, http.StatusOK, post(`{"jsonrpc":"2.0","id":1,"method":"status"}`).StatusCode)
+	assert.Equal(t, 4, calls)
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	cfg := DefaultConfig()
+	cfg.RateLimits = map[string]config.RateLimit{"block_search": {QPS: 0.001, Burst: 1}}
+	assert.Nil(t, NewRateLimiter(DefaultConfig()))
+	cfg.RateLimiter = NewRateLimiter(cfg)
+	require.NotNil(t, cfg.RateLimiter)
+
+	// handlers made from copies of the config, as for several listeners,
+	// share the same limits
+	cfg2 := *cfg
+	for i, h := range []http.Handler{
+		RateLimitHandler(handler, cfg, log.NewNopLogger()),
+		RateLimitHandler(handler, &cfg2, log.NewNopLogger()),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/block_search", nil))
+		if i == 0 {
+			assert.Equal(t, http.StatusOK, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		}
+	}
+}
+
+func TestRateLimitWebsocket(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c": NewWSRPCFunc(func(context.Context) (string, error) { return "foo", nil }),
+	}
+	wm := NewWebsocketManager(log.NewNopLogger(), funcMap)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+
+	cfg := DefaultConfig()
+	cfg.RateLimits = map[string]config.RateLimit{"c": {QPS: 0.001, Burst: 1}}
+	srv := httptest.NewServer(RateLimitHandler(mux, cfg, log.NewNopLogger()))
+	t.Cleanup(srv.Close)
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+srv.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	call := func(id int) rpctypes.RPCResponse {
+		req := rpctypes.NewRequest(id)
+		require.NoError(t, req.SetMethodAndParams("c", map[string]interface{}{}))
+		require.NoError(t, c.WriteJSON(req))
+		var rsp rpctypes.RPCResponse
+		require.NoError(t, c.ReadJSON(&rsp))
+		return rsp
+	}
+
+	require.Nil(t, call(1).Error)
+	rsp := call(2)
+	require.NotNil(t, rsp.Error)
+	assert.Equal(t, int(rpctypes.CodeRateLimitExceeded), rsp.Error.Code)
+}
+
+func TestTakeRateLimitTokens(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RateLimits = map[string]config.RateLimit{"c": {QPS: 0.001, Burst: 5}}
+	var errs []error
+	h := RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errs = append(errs, TakeRateLimitTokens(r.Context(), "c", 3))
+	}), cfg, log.NewNopLogger())
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/c", nil))
+	}
+
+	// the first call takes 1+3 tokens, the second only the one of the call
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	var rpcErr *rpctypes.RPCError
+	require.ErrorAs(t, errs[1], &rpcErr)
+	assert.Equal(t, int(rpctypes.CodeRateLimitExceeded), rpcErr.Code)
+	rsp := httptest.NewRecorder()
+	h.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/c", nil))
+	assert.Equal(t, http.StatusTooManyRequests, rsp.Code)
+
+	// calls that aren't rate limited are allowed
+	assert.NoError(t, TakeRateLimitTokens(context.Background(), "c", 100))
+}
@@ -33,6 +33,33 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger lo
 	mux.HandleFunc("/", ensureBodyClose(handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger))))
 }
 
+// RestrictRPCFuncs returns a copy of funcMap in which the methods not in
+// allowed, if it isn't empty, and the methods in denied are disabled: calling
+// them over HTTP or a websocket reports a CodeMethodNotAllowed error instead of
+// calling the function. The names of allowed and denied which are not in
+// funcMap are ignored. funcMap is not modified.
+func RestrictRPCFuncs(funcMap map[string]*RPCFunc, allowed, denied []string) map[string]*RPCFunc {
+	allow := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allow[name] = true
+	}
+	deny := make(map[string]bool, len(denied))
+	for _, name := range denied {
+		deny[name] = true
+	}
+
+	out := make(map[string]*RPCFunc, len(funcMap))
+	for name, fn := range funcMap {
+		if deny[name] || (len(allow) != 0 && !allow[name]) {
+			disabled := *fn
+			disabled.denied = true
+			fn = &disabled
+		}
+		out[name] = fn
+	}
+	return out
+}
+
 // Function introspection
 
 // RPCFunc contains the introspected type information for a function.
@@ -43,6 +70,7 @@ type RPCFunc struct {
 	args    []argInfo     // names and type information (for URL decoding)
 	timeout time.Duration // default request timeout, 0 means none
 	ws      bool          // websocket only
+	denied  bool          // disabled by RestrictRPCFuncs
 }
 
 // argInfo records the name of a field, along with a bit to tell whether the
@@ -56,8 +84,12 @@ type argInfo struct {
 
 // Call parses the given JSON parameters and calls the function wrapped by rf
 // with the resulting argument value. It reports an error if parameter parsing
-// fails, otherwise it returns the result from the wrapped function.
+// fails or rf is disabled, otherwise it returns the result from the wrapped
+// function.
 func (rf *RPCFunc) Call(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if rf.denied {
+		return nil, methodNotAllowedError()
+	}
 	// If ctx has its own deadline we will respect it; otherwise use rf.timeout.
 	if _, ok := ctx.Deadline(); !ok && rf.timeout > 0 {
 		var cancel context.CancelFunc
@@ -255,6 +287,14 @@ func invalidParamsError(msg string, args ...interface{}) error {
 	}
 }
 
+func methodNotAllowedError() error {
+	return &rpctypes.RPCError{
+		Code:    int(rpctypes.CodeMethodNotAllowed),
+		Message: rpctypes.CodeMethodNotAllowed.String(),
+		Data:    "method is disabled on this server",
+	}
+}
+
 // isNullOrEmpty reports whether params is either itself empty or represents an
 // empty parameter (null, empty object, or empty array).
 func isNullOrEmpty(params json.RawMessage) bool {
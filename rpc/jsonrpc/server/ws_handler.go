@@ -317,6 +317,15 @@ func (wsc *wsConnection) readRoutine(ctx context.Context) {
 				continue
 			}
 
+			rl, limited := rateLimitFromContext(ctx)
+			if limited && !rl.allow(request.Method, 1) {
+				if err := wsc.WriteRPCResponse(writeCtx,
+					request.MakeErrorf(rpctypes.CodeRateLimitExceeded, request.Method)); err != nil {
+					wsc.Logger.Error("error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			// Now, fetch the RPCFunc and execute it.
 			rpcFunc := wsc.funcMap[request.Method]
 			if rpcFunc == nil {
@@ -331,6 +340,9 @@ func (wsc *wsConnection) readRoutine(ctx context.Context) {
 				RPCRequest: &request,
 				WSConn:     wsc,
 			})
+			if limited {
+				fctx = context.WithValue(fctx, rateLimitKey{}, rl)
+			}
 			var resp rpctypes.RPCResponse
 			result, err := rpcFunc.Call(fctx, request.Params)
 			if err == nil {
@@ -38,7 +38,7 @@ func main() {
 
 	rpcserver.RegisterRPCFuncs(mux, routes, logger)
 	config := rpcserver.DefaultConfig()
-	listener, err := rpcserver.Listen("tcp://127.0.0.1:8008", config.MaxOpenConnections)
+	listener, err := rpcserver.Listen("tcp://127.0.0.1:8008", config)
 	if err != nil {
 		stdlog.Fatalf("rpc listening: %v", err)
 	}
@@ -33,6 +33,9 @@ const (
 	CodeInvalidParams  ErrorCode = -32602 // Invalid method parameters
 	CodeInternalError  ErrorCode = -32603 // Internal JSON-RPC error
 	CodeLagIsHighError ErrorCode = -32604 // Lag is too high error
+
+	CodeMethodNotAllowed  ErrorCode = -32605 // The method is disabled on this server
+	CodeRateLimitExceeded ErrorCode = -32606 // Too many calls to the method
 )
 
 var errorCodeString = map[ErrorCode]string{
@@ -42,6 +45,9 @@ var errorCodeString = map[ErrorCode]string{
 	CodeInvalidParams:  "Invalid params",
 	CodeInternalError:  "Internal error",
 	CodeLagIsHighError: "Lag is too high",
+
+	CodeMethodNotAllowed:  "Method not allowed",
+	CodeRateLimitExceeded: "Rate limit exceeded",
 }
 
 //----------------------------------------
@@ -26,12 +26,14 @@ var plan = transform.Plan{
 	},
 	{
 		// [fastsync]  renamed in https://github.com/tendermint/tendermint/pull/6896.
-		// [blocksync] removed in https://github.com/tendermint/tendermint/pull/7159.
-		Desc: "Remove [fastsync] and [blocksync] sections",
+		// [blocksync] removed in https://github.com/tendermint/tendermint/pull/7159,
+		// and reintroduced with the request limit settings below.
+		Desc: "Remove [fastsync] section and vestigial [blocksync] settings",
 		T: transform.Func(func(_ context.Context, doc *tomledit.Document) error {
 			doc.First("fast-sync").Remove()
 			transform.FindTable(doc, "fastsync").Remove()
-			transform.FindTable(doc, "blocksync").Remove()
+			doc.First("blocksync", "enable").Remove()
+			doc.First("blocksync", "version").Remove()
 			return nil
 		}),
 		ErrorOK: true,
@@ -234,4 +236,79 @@ var plan = transform.Plan{
 		T:       transform.Remove(parser.Key{"p2p", "seeds"}),
 		ErrorOK: true,
 	},
+	{
+		Desc: "Add [evidence] section with the retention setting",
+		T: transform.Func(func(_ context.Context, doc *tomledit.Document) error {
+			sec := ensureTable(doc, "evidence", "###         Evidence Configuration Options          ###")
+			transform.InsertMapping(sec, &parser.KeyValue{
+				Block: parser.Comments{"How long evidence is kept by the local evidence pool."},
+				Name:  parser.Key{"retention"},
+				Value: parser.MustValue(`"0s"`),
+			}, false)
+			return nil
+		}),
+	},
+	{
+		Desc: "Add consensus WAL flush policy settings",
+		T: transform.Func(func(ctx context.Context, doc *tomledit.Document) error {
+			for _, kv := range []*parser.KeyValue{{
+				Block: parser.Comments{"When the messages written to the WAL are flushed and synced to disk."},
+				Name:  parser.Key{"wal-flush-policy"},
+				Value: parser.MustValue(`"interval"`),
+			}, {
+				Name:  parser.Key{"wal-flush-interval"},
+				Value: parser.MustValue(`"2s"`),
+			}, {
+				Name:  parser.Key{"wal-flush-messages"},
+				Value: parser.MustValue("100"),
+			}} {
+				if err := transform.EnsureKey(parser.Key{"consensus"}, kv)(ctx, doc); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	},
+	{
+		Desc: "Add [blocksync] request limit settings",
+		T: transform.Func(func(_ context.Context, doc *tomledit.Document) error {
+			sec := ensureTable(doc, "blocksync", "###         Block Sync Configuration Options        ###")
+			transform.InsertMapping(sec, &parser.KeyValue{
+				Block: parser.Comments{"The maximum number of block requests in flight to a single peer."},
+				Name:  parser.Key{"max-requests-per-peer"},
+				Value: parser.MustValue("20"),
+			}, false)
+			transform.InsertMapping(sec, &parser.KeyValue{
+				Block: parser.Comments{"The maximum number of blocks requested ahead of the latest synced block."},
+				Name:  parser.Key{"max-pending-requests"},
+				Value: parser.MustValue("600"),
+			}, false)
+			transform.InsertMapping(sec, &parser.KeyValue{
+				Block: parser.Comments{"Adjust the number of requests in flight to each peer to its latency."},
+				Name:  parser.Key{"adaptive-requests"},
+				Value: parser.MustValue("false"),
+			}, false)
+			return nil
+		}),
+	},
+}
+
+// ensureTable returns the section of the named table, appending it with the
+// given title to the document if it doesn't exist.
+func ensureTable(doc *tomledit.Document, name, title string) *tomledit.Section {
+	if tab := transform.FindTable(doc, name); tab != nil {
+		return tab.Section
+	}
+	sec := &tomledit.Section{
+		Heading: &parser.Heading{
+			Block: parser.Comments{
+				"#######################################################",
+				title,
+				"#######################################################",
+			},
+			Name: parser.Key{name},
+		},
+	}
+	doc.Sections = append(doc.Sections, sec)
+	return sec
 }
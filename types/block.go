@@ -12,6 +12,7 @@ import (
 	gogotypes "github.com/gogo/protobuf/types"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -602,14 +603,19 @@ const (
 	BlockIDFlagCommit
 	// BlockIDFlagNil - voted for nil.
 	BlockIDFlagNil
+	// BlockIDFlagAggregated - voted for the Commit.BlockID, with the signature
+	// aggregated into the Commit.AggregatedSignature.
+	BlockIDFlagAggregated
 )
 
 const (
 	// Max size of commit without any commitSigs -> 82 for BlockID, 8 for Height, 4 for Round.
+	// An aggregated signature takes no more space than the individual signatures
+	// it replaces.
 	MaxCommitOverheadBytes int64 = 94
-	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
+	// Commit sig size is made up of 96 bytes for the signature, 20 bytes for the address,
 	// 1 byte for the flag and 14 bytes for the timestamp
-	MaxCommitSigBytes int64 = 109
+	MaxCommitSigBytes int64 = 141
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -621,8 +627,8 @@ type CommitSig struct {
 }
 
 func MaxCommitBytes(valCount int) int64 {
-	// From the repeated commit sig field
-	var protoEncodingOverhead int64 = 2
+	// From the repeated commit sig field: the tag and the two byte length
+	var protoEncodingOverhead int64 = 3
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
@@ -648,6 +654,12 @@ func (cs CommitSig) String() string {
 		CanonicalTime(cs.Timestamp))
 }
 
+// ForBlock returns true if CommitSig is for the Commit's BlockID, whether its
+// signature is individual or aggregated.
+func (cs CommitSig) ForBlock() bool {
+	return cs.BlockIDFlag == BlockIDFlagCommit || cs.BlockIDFlag == BlockIDFlagAggregated
+}
+
 // BlockID returns the Commit's BlockID if CommitSig indicates signing,
 // otherwise - empty BlockID.
 func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
@@ -655,7 +667,7 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 		blockID = BlockID{}
-	case BlockIDFlagCommit:
+	case BlockIDFlagCommit, BlockIDFlagAggregated:
 		blockID = commitBlockID
 	case BlockIDFlagNil:
 		blockID = BlockID{}
@@ -671,6 +683,7 @@ func (cs CommitSig) ValidateBasic() error {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
 	case BlockIDFlagNil:
+	case BlockIDFlagAggregated:
 	default:
 		return fmt.Errorf("unknown BlockIDFlag: %v", cs.BlockIDFlag)
 	}
@@ -686,6 +699,17 @@ func (cs CommitSig) ValidateBasic() error {
 		if len(cs.Signature) != 0 {
 			return errors.New("signature is present")
 		}
+	case BlockIDFlagAggregated:
+		if len(cs.ValidatorAddress) != crypto.AddressSize {
+			return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+				crypto.AddressSize,
+				len(cs.ValidatorAddress),
+			)
+		}
+		// The signature is part of the Commit's AggregatedSignature.
+		if len(cs.Signature) != 0 {
+			return errors.New("signature is present")
+		}
 	default:
 		if len(cs.ValidatorAddress) != crypto.AddressSize {
 			return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
@@ -764,6 +788,10 @@ func (ecs ExtendedCommitSig) ValidateBasic() error {
 	if err := ecs.CommitSig.ValidateBasic(); err != nil {
 		return err
 	}
+	// Extended commits always keep the individual signatures.
+	if ecs.BlockIDFlag == BlockIDFlagAggregated {
+		return errors.New("extended commit signature cannot be aggregated")
+	}
 
 	if ecs.BlockIDFlag == BlockIDFlagCommit {
 		if len(ecs.Extension) > MaxVoteExtensionSize {
@@ -833,6 +861,9 @@ type Commit struct {
 	Round      int32       `json:"round"`
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
+	// AggregatedSignature is the aggregate of the signatures of the CommitSigs
+	// with BlockIDFlagAggregated.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
 
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
@@ -905,9 +936,29 @@ func (commit *Commit) ValidateBasic() error {
 			}
 		}
 	}
+
+	aggregated := commit.isAggregated()
+	switch {
+	case aggregated && len(commit.AggregatedSignature) == 0:
+		return errors.New("aggregated signature is missing")
+	case !aggregated && len(commit.AggregatedSignature) != 0:
+		return errors.New("aggregated signature is present without aggregated CommitSigs")
+	case len(commit.AggregatedSignature) > MaxSignatureSize:
+		return fmt.Errorf("aggregated signature is too big (max: %d)", MaxSignatureSize)
+	}
 	return nil
 }
 
+// isAggregated returns true if any signature of the commit is aggregated.
+func (commit *Commit) isAggregated() bool {
+	for _, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag == BlockIDFlagAggregated {
+			return true
+		}
+	}
+	return false
+}
+
 // Hash returns the hash of the commit
 func (commit *Commit) Hash() tmbytes.HexBytes {
 	if commit == nil {
@@ -924,6 +975,9 @@ func (commit *Commit) Hash() tmbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		if len(commit.AggregatedSignature) != 0 {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -949,6 +1003,50 @@ func (commit *Commit) WrappedExtendedCommit() *ExtendedCommit {
 	}
 }
 
+// Aggregate returns a copy of the commit in which the signatures of the
+// validators with BLS12-381 keys that voted for the block are replaced by
+// their aggregate. Votes for nil keep their individual signatures.
+// vals must be the validator set that signed the commit.
+func (commit *Commit) Aggregate(vals *ValidatorSet) (*Commit, error) {
+	if vals.Size() != len(commit.Signatures) {
+		return nil, NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+	if commit.isAggregated() {
+		return nil, errors.New("commit is already aggregated")
+	}
+
+	var sigs [][]byte
+	cs := make([]CommitSig, len(commit.Signatures))
+	for idx, commitSig := range commit.Signatures {
+		cs[idx] = commitSig
+		if commitSig.BlockIDFlag != BlockIDFlagCommit {
+			continue
+		}
+		if _, ok := vals.Validators[idx].PubKey.(bls12381.PubKey); !ok {
+			continue
+		}
+		sigs = append(sigs, commitSig.Signature)
+		cs[idx].BlockIDFlag = BlockIDFlagAggregated
+		cs[idx].Signature = nil
+	}
+
+	aggregated := &Commit{
+		Height:     commit.Height,
+		Round:      commit.Round,
+		BlockID:    commit.BlockID,
+		Signatures: cs,
+	}
+	if len(sigs) == 0 {
+		return aggregated, nil
+	}
+	sig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating commit signatures: %w", err)
+	}
+	aggregated.AggregatedSignature = sig
+	return aggregated, nil
+}
+
 // StringIndented returns a string representation of the commit.
 func (commit *Commit) StringIndented(indent string) string {
 	if commit == nil {
@@ -985,6 +1083,7 @@ func (commit *Commit) ToProto() *tmproto.Commit {
 		sigs[i] = *commit.Signatures[i].ToProto()
 	}
 	c.Signatures = sigs
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	c.Height = commit.Height
 	c.Round = commit.Round
@@ -1016,6 +1115,7 @@ func CommitFromProto(cp *tmproto.Commit) (*Commit, error) {
 		}
 	}
 	commit.Signatures = sigs
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
@@ -505,11 +505,11 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}{
 		0: {-10, 1, 0, true, 0},
 		1: {10, 1, 0, true, 0},
-		2: {841, 1, 0, true, 0},
-		3: {842, 1, 0, false, 0},
-		4: {843, 1, 0, false, 1},
-		5: {954, 2, 0, false, 1},
-		6: {1053, 2, 100, false, 0},
+		2: {874, 1, 0, true, 0},
+		3: {875, 1, 0, false, 0},
+		4: {876, 1, 0, false, 1},
+		5: {1020, 2, 0, false, 1},
+		6: {1119, 2, 100, false, 0},
 	}
 
 	for i, tc := range testCases {
@@ -536,9 +536,9 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}{
 		0: {-10, 1, true, 0},
 		1: {10, 1, true, 0},
-		2: {841, 1, true, 0},
-		3: {842, 1, false, 0},
-		4: {843, 1, false, 1},
+		2: {874, 1, true, 0},
+		3: {875, 1, false, 0},
+		4: {876, 1, false, 1},
 	}
 
 	for i, tc := range testCases {
@@ -1057,6 +1057,23 @@ func TestCommitSig_ValidateBasic(t *testing.T) {
 			},
 			false, "",
 		},
+		{
+			"BlockIDFlagAggregated signature present",
+			CommitSig{
+				BlockIDFlag:      BlockIDFlagAggregated,
+				ValidatorAddress: make([]byte, crypto.AddressSize),
+				Signature:        make([]byte, MaxSignatureSize),
+			},
+			true, "signature is present",
+		},
+		{
+			"BlockIDFlagAggregated valid",
+			CommitSig{
+				BlockIDFlag:      BlockIDFlagAggregated,
+				ValidatorAddress: make([]byte, crypto.AddressSize),
+			},
+			false, "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1396,6 +1413,48 @@ func TestCommit_ValidateBasic(t *testing.T) {
 			},
 			true, "wrong CommitSig",
 		},
+		{
+			"missing aggregated signature",
+			&Commit{
+				Height: 1,
+				Round:  1,
+				BlockID: BlockID{
+					Hash: make([]byte, crypto.HashSize),
+					PartSetHeader: PartSetHeader{
+						Hash: make([]byte, crypto.HashSize),
+					},
+				},
+				Signatures: []CommitSig{
+					{
+						BlockIDFlag:      BlockIDFlagAggregated,
+						ValidatorAddress: make([]byte, crypto.AddressSize),
+					},
+				},
+			},
+			true, "aggregated signature is missing",
+		},
+		{
+			"aggregated signature without aggregated signatures",
+			&Commit{
+				Height: 1,
+				Round:  1,
+				BlockID: BlockID{
+					Hash: make([]byte, crypto.HashSize),
+					PartSetHeader: PartSetHeader{
+						Hash: make([]byte, crypto.HashSize),
+					},
+				},
+				Signatures: []CommitSig{
+					{
+						BlockIDFlag:      BlockIDFlagCommit,
+						ValidatorAddress: make([]byte, crypto.AddressSize),
+						Signature:        make([]byte, MaxSignatureSize),
+					},
+				},
+				AggregatedSignature: make([]byte, MaxSignatureSize),
+			},
+			true, "aggregated signature is present",
+		},
 		{
 			"valid commit",
 			&Commit{
@@ -16,6 +16,28 @@ type (
 		Expected int
 		Actual   int
 	}
+
+	// ErrInvalidCommitSignature is returned when a signature of a commit
+	// doesn't verify against the public key of its validator.
+	ErrInvalidCommitSignature struct {
+		Index     int
+		Signature []byte
+	}
+
+	// ErrInvalidCommitAggregatedSignature is returned when the aggregated
+	// signature of a commit doesn't verify against the public keys of the
+	// validators whose signatures it aggregates.
+	ErrInvalidCommitAggregatedSignature struct {
+		Signature []byte
+	}
+
+	// ErrDuplicateCommitSignature is returned when a commit has more than one
+	// signature from the same validator.
+	ErrDuplicateCommitSignature struct {
+		Validator   *Validator
+		FirstIndex  int
+		SecondIndex int
+	}
 )
 
 func NewErrInvalidCommitHeight(expected, actual int64) ErrInvalidCommitHeight {
@@ -39,3 +61,15 @@ func NewErrInvalidCommitSignatures(expected, actual int) ErrInvalidCommitSignatu
 func (e ErrInvalidCommitSignatures) Error() string {
 	return fmt.Sprintf("Invalid commit -- wrong set size: %v vs %v", e.Expected, e.Actual)
 }
+
+func (e ErrInvalidCommitSignature) Error() string {
+	return fmt.Sprintf("wrong signature (#%d): %X", e.Index, e.Signature)
+}
+
+func (e ErrInvalidCommitAggregatedSignature) Error() string {
+	return fmt.Sprintf("wrong aggregated signature: %X", e.Signature)
+}
+
+func (e ErrDuplicateCommitSignature) Error() string {
+	return fmt.Sprintf("double vote from %v (%d and %d)", e.Validator, e.FirstIndex, e.SecondIndex)
+}
@@ -37,6 +37,7 @@ const (
 	EventPolkaValue           = "Polka"
 	EventRelockValue          = "Relock"
 	EventStateSyncStatusValue = "StateSyncStatus"
+	EventStepTransitionValue  = "StepTransition"
 	EventTimeoutProposeValue  = "TimeoutPropose"
 	EventTimeoutWaitValue     = "TimeoutWait"
 	EventValidBlockValue      = "ValidBlock"
@@ -79,6 +80,16 @@ var (
 		},
 	}
 
+	EventStepTransition = abci.Event{
+		Type: strings.Split(EventTypeKey, ".")[0],
+		Attributes: []abci.EventAttribute{
+			{
+				Key:   []byte(strings.Split(EventTypeKey, ".")[1]),
+				Value: []byte(EventStepTransitionValue),
+			},
+		},
+	}
+
 	EventTx = abci.Event{
 		Type: strings.Split(EventTypeKey, ".")[0],
 		Attributes: []abci.EventAttribute{
@@ -116,6 +127,7 @@ func init() {
 	jsontypes.MustRegister(EventDataNewEvidence{})
 	jsontypes.MustRegister(EventDataNewRound{})
 	jsontypes.MustRegister(EventDataRoundState{})
+	jsontypes.MustRegister(EventDataStepTransition{})
 	jsontypes.MustRegister(EventDataStateSyncStatus{})
 	jsontypes.MustRegister(EventDataTx{})
 	jsontypes.MustRegister(EventDataValidatorSetUpdates{})
@@ -341,6 +353,39 @@ func (e EventDataRoundState) ToLegacy() LegacyEventData {
 	return e
 }
 
+// Triggers of a consensus step transition.
+const (
+	StepTriggerTimeout      = "timeout"
+	StepTriggerMessage      = "message"
+	StepTriggerTxsAvailable = "txs-available"
+)
+
+// EventDataStepTransition is published each time the consensus state machine
+// enters a new round step. Trigger is what woke the state machine up and
+// Reason is the label of the transition, e.g. "precommit-two-thirds".
+type EventDataStepTransition struct {
+	Height  int64  `json:"height,string"`
+	Round   int32  `json:"round"`
+	Step    string `json:"step"`
+	Trigger string `json:"trigger"`
+	Reason  string `json:"reason"`
+}
+
+// TypeTag implements the required method of jsontypes.Tagged.
+func (EventDataStepTransition) TypeTag() string { return "tendermint/event/StepTransition" }
+
+// ABCIEvents implements the eventlog.ABCIEventer interface.
+func (e EventDataStepTransition) ABCIEvents() []abci.Event {
+	return []abci.Event{
+		eventWithAttr(StepHeightKey, fmt.Sprint(e.Height)),
+		eventWithAttr(StepTriggerKey, e.Trigger),
+	}
+}
+
+func (e EventDataStepTransition) ToLegacy() LegacyEventData {
+	return e
+}
+
 type ValidatorInfo struct {
 	Address Address `json:"address"`
 	Index   int32   `json:"index"`
@@ -464,6 +509,20 @@ const (
 
 	// BlockHeightKey is a reserved key used for indexing FinalizeBlock events.
 	BlockHeightKey = "block.height"
+
+	// BlockProposerKey is a reserved key used for indexing blocks by the
+	// (uppercase hex) address of their proposer.
+	BlockProposerKey = "block.proposer"
+
+	// StepHeightKey is a reserved key, used to specify the height of a
+	// consensus step transition.
+	// see EventBus#PublishEventStepTransition
+	StepHeightKey = "step.height"
+
+	// StepTriggerKey is a reserved key, used to specify what triggered a
+	// consensus step transition.
+	// see EventBus#PublishEventStepTransition
+	StepTriggerKey = "step.trigger"
 )
 
 var (
@@ -484,6 +543,7 @@ var (
 	EventQueryVote                = QueryForEvent(EventVoteValue)
 	EventQueryBlockSyncStatus     = QueryForEvent(EventBlockSyncStatusValue)
 	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatusValue)
+	EventQueryStepTransition      = QueryForEvent(EventStepTransitionValue)
 	EventQueryEvidenceValidated   = QueryForEvent(EventEvidenceValidatedValue)
 )
 
@@ -491,6 +551,14 @@ func EventQueryTxFor(tx Tx) *tmquery.Query {
 	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTxValue, TxHashKey, tx.Hash()))
 }
 
+// EventQueryStepTransitionForHeights returns a query matching the consensus
+// step transitions of the heights in [minHeight, maxHeight].
+func EventQueryStepTransitionForHeights(minHeight, maxHeight int64) *tmquery.Query {
+	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s>=%d AND %s<=%d",
+		EventTypeKey, EventStepTransitionValue,
+		StepHeightKey, minHeight, StepHeightKey, maxHeight))
+}
+
 func QueryForEvent(eventValue string) *tmquery.Query {
 	return tmquery.MustCompile(fmt.Sprintf("%s='%s'", EventTypeKey, eventValue))
 }
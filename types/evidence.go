@@ -309,7 +309,7 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 	// validators who are in the commonVals and voted for the lunatic header
 	if l.ConflictingHeaderIsInvalid(trusted.Header) {
 		for _, commitSig := range l.ConflictingBlock.Commit.Signatures {
-			if commitSig.BlockIDFlag != BlockIDFlagCommit {
+			if !commitSig.ForBlock() {
 				continue
 			}
 
@@ -329,12 +329,12 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 		// only need a single loop to find the validators that voted twice.
 		for i := 0; i < len(l.ConflictingBlock.Commit.Signatures); i++ {
 			sigA := l.ConflictingBlock.Commit.Signatures[i]
-			if sigA.BlockIDFlag != BlockIDFlagCommit {
+			if !sigA.ForBlock() {
 				continue
 			}
 
 			sigB := trusted.Commit.Signatures[i]
-			if sigB.BlockIDFlag != BlockIDFlagCommit {
+			if !sigB.ForBlock() {
 				continue
 			}
 
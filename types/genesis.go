@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/jsontypes"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmtime "github.com/tendermint/tendermint/libs/time"
@@ -73,6 +74,12 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes   `json:"app_hash"`
 	AppState        json.RawMessage    `json:"app_state,omitempty"`
+
+	// MerkleHashFunction is the hash function of the Merkle trees of the
+	// chain, including the ones of the block hashes, data hashes and block
+	// parts. Empty means merkle.HashFunctionSHA256. It can't be changed once
+	// the chain started.
+	MerkleHashFunction string `json:"merkle_hash_function,omitempty"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -110,6 +117,9 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	if genDoc.InitialHeight == 0 {
 		genDoc.InitialHeight = 1
 	}
+	if err := merkle.ValidateHashFunction(genDoc.MerkleHashFunction); err != nil {
+		return err
+	}
 
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
@@ -18,8 +18,9 @@ func TestGenesisBad(t *testing.T) {
 		{},              // empty
 		{1, 1, 1, 1, 1}, // junk
 		[]byte(`{}`),    // empty
-		[]byte(`{"chain_id":"mychain","validators":[{}]}`),   // invalid validator
-		[]byte(`{"chain_id":"chain","initial_height":"-1"}`), // negative initial height
+		[]byte(`{"chain_id":"mychain","validators":[{}]}`),          // invalid validator
+		[]byte(`{"chain_id":"chain","initial_height":"-1"}`),        // negative initial height
+		[]byte(`{"chain_id":"chain","merkle_hash_function":"md5"}`), // unknown merkle hash function
 		// missing pub_key type
 		[]byte(
 			`{"validators":[{"pub_key":{"value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="},"power":"10","name":""}]}`,
@@ -125,6 +126,7 @@ func TestBasicGenesisDoc(t *testing.T) {
 		[]byte(`{"chain_id":"mychain","validators":[]}`),   // missing validators
 		[]byte(`{"chain_id":"mychain","validators":null}`), // nil validator
 		[]byte(`{"chain_id":"mychain"}`),                   // missing validators
+		[]byte(`{"chain_id":"mychain","merkle_hash_function":"blake2b-256"}`),
 	}
 
 	for _, tc := range missingValidatorsTestCases {
@@ -127,6 +127,100 @@ func LightBlockFromProto(pb *tmproto.LightBlock) (*LightBlock, error) {
 	return lb, nil
 }
 
+const (
+	// DefaultMaxLightBlockHeaderBytes is the default maximum size of the
+	// header of a light block or block meta received from an untrusted
+	// source. It leaves room for app hashes longer than the ones
+	// MaxHeaderBytes accounts for.
+	DefaultMaxLightBlockHeaderBytes int64 = 2048
+
+	// DefaultMaxLightBlockBytes is the default maximum size of a light block
+	// received from an untrusted source. It leaves room for a commit and a
+	// validator set of MaxVotesCount validators.
+	DefaultMaxLightBlockBytes int64 = 4 << 20 // 4MB
+)
+
+// ErrLightBlockTooLarge is returned for light blocks and block metas which
+// exceed their LightBlockSizeLimits.
+var ErrLightBlockTooLarge = errors.New("light block too large")
+
+// LightBlockSizeLimits bounds the encoded size of the light blocks and block
+// metas received from untrusted sources, so that they can be rejected before
+// being converted from their protobuf representation. A zero maximum disables
+// the corresponding check.
+type LightBlockSizeLimits struct {
+	// MaxHeaderBytes is the maximum size of a header.
+	MaxHeaderBytes int64
+	// MaxBytes is the maximum size of a light block, i.e. of its header,
+	// commit and validator set.
+	MaxBytes int64
+}
+
+// DefaultLightBlockSizeLimits returns the default limits, which admit all
+// valid light blocks.
+func DefaultLightBlockSizeLimits() LightBlockSizeLimits {
+	return LightBlockSizeLimits{
+		MaxHeaderBytes: DefaultMaxLightBlockHeaderBytes,
+		MaxBytes:       DefaultMaxLightBlockBytes,
+	}
+}
+
+// ValidateBasic checks the limits are not negative.
+func (l LightBlockSizeLimits) ValidateBasic() error {
+	if l.MaxHeaderBytes < 0 {
+		return fmt.Errorf("negative max header bytes: %d", l.MaxHeaderBytes)
+	}
+	if l.MaxBytes < 0 {
+		return fmt.Errorf("negative max light block bytes: %d", l.MaxBytes)
+	}
+	return nil
+}
+
+// CheckProto checks the size of a light block in its protobuf representation,
+// before it is converted with LightBlockFromProto.
+func (l LightBlockSizeLimits) CheckProto(pb *tmproto.LightBlock) error {
+	if pb == nil {
+		return nil
+	}
+	if l.MaxBytes > 0 {
+		if size := int64(pb.Size()); size > l.MaxBytes {
+			return fmt.Errorf("%w: %d bytes, max %d", ErrLightBlockTooLarge, size, l.MaxBytes)
+		}
+	}
+	if pb.SignedHeader != nil && pb.SignedHeader.Header != nil {
+		return l.checkHeader(pb.SignedHeader.Header)
+	}
+	return nil
+}
+
+// Check checks the size of a light block that was decoded from another
+// representation, e.g. JSON.
+func (l LightBlockSizeLimits) Check(lb *LightBlock) error {
+	pb, err := lb.ToProto()
+	if err != nil {
+		return err
+	}
+	return l.CheckProto(pb)
+}
+
+// CheckBlockMeta checks the size of the header of a block meta. The size of
+// the block itself is not bounded.
+func (l LightBlockSizeLimits) CheckBlockMeta(bm *BlockMeta) error {
+	if bm == nil {
+		return nil
+	}
+	return l.checkHeader(bm.Header.ToProto())
+}
+
+func (l LightBlockSizeLimits) checkHeader(pb *tmproto.Header) error {
+	if l.MaxHeaderBytes > 0 {
+		if size := int64(pb.Size()); size > l.MaxHeaderBytes {
+			return fmt.Errorf("%w: header is %d bytes, max %d", ErrLightBlockTooLarge, size, l.MaxHeaderBytes)
+		}
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 
 // SignedHeader is a header along with the commits that prove it.
@@ -3,12 +3,15 @@ package types
 import (
 	"context"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/version"
 )
 
@@ -118,6 +121,80 @@ func TestLightBlockProtobuf(t *testing.T) {
 
 }
 
+func TestLightBlockSizeLimits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	header := MakeRandHeader()
+	commit := randCommit(ctx, t, time.Now())
+	vals, _ := randValidatorPrivValSet(ctx, t, 5, 1)
+	lb := &LightBlock{
+		SignedHeader: &SignedHeader{Header: &header, Commit: commit},
+		ValidatorSet: vals,
+	}
+	limits := DefaultLightBlockSizeLimits()
+	require.NoError(t, limits.Check(lb))
+	require.NoError(t, limits.CheckBlockMeta(&BlockMeta{Header: header}))
+
+	// a header with an oversized app hash
+	bigHeader := header
+	bigHeader.AppHash = make([]byte, DefaultMaxLightBlockHeaderBytes)
+	err := limits.Check(&LightBlock{
+		SignedHeader: &SignedHeader{Header: &bigHeader, Commit: commit},
+		ValidatorSet: vals,
+	})
+	require.ErrorIs(t, err, ErrLightBlockTooLarge)
+	require.ErrorIs(t, limits.CheckBlockMeta(&BlockMeta{Header: bigHeader}), ErrLightBlockTooLarge)
+
+	// a commit padded with signatures
+	bigCommit := *commit
+	bigCommit.Signatures = append([]CommitSig{}, commit.Signatures...)
+	for i := 0; i < 100000; i++ {
+		bigCommit.Signatures = append(bigCommit.Signatures, CommitSig{
+			BlockIDFlag:      BlockIDFlagCommit,
+			ValidatorAddress: make([]byte, crypto.AddressSize),
+			Timestamp:        time.Now(),
+			Signature:        make([]byte, MaxSignatureSize),
+		})
+	}
+	pb, err := (&LightBlock{
+		SignedHeader: &SignedHeader{Header: &header, Commit: &bigCommit},
+		ValidatorSet: vals,
+	}).ToProto()
+	require.NoError(t, err)
+	require.ErrorIs(t, limits.CheckProto(pb), ErrLightBlockTooLarge)
+
+	// zero limits disable the checks
+	require.NoError(t, LightBlockSizeLimits{}.CheckProto(pb))
+	require.Error(t, LightBlockSizeLimits{MaxBytes: -1}.ValidateBasic())
+}
+
+func TestDefaultLightBlockSizeLimitsAdmitMaxValidators(t *testing.T) {
+	header := MakeRandHeader()
+	header.ChainID = strings.Repeat("c", MaxChainIDLen)
+
+	validators := make([]*Validator, MaxVotesCount)
+	sigs := make([]CommitSig, MaxVotesCount)
+	for i := range validators {
+		validators[i] = NewValidator(ed25519.GenPrivKey().PubKey(), MaxTotalVotingPower/MaxVotesCount)
+		validators[i].ProposerPriority = math.MinInt64
+		sigs[i] = CommitSig{
+			BlockIDFlag:      BlockIDFlagCommit,
+			ValidatorAddress: validators[i].Address,
+			Timestamp:        time.Now(),
+			Signature:        make([]byte, MaxSignatureSize),
+		}
+	}
+	lb := &LightBlock{
+		SignedHeader: &SignedHeader{
+			Header: &header,
+			Commit: &Commit{Height: header.Height, Round: math.MaxInt32, Signatures: sigs},
+		},
+		ValidatorSet: &ValidatorSet{Validators: validators, Proposer: validators[0]},
+	}
+	require.NoError(t, DefaultLightBlockSizeLimits().Check(lb))
+}
+
 func TestSignedHeaderValidateBasic(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -10,6 +10,10 @@ import (
 // ErrTxInCache is returned to the client if we saw tx earlier
 var ErrTxInCache = errors.New("tx already exists in cache")
 
+// ErrTxBlacklisted is returned to the client if tx was removed from the
+// mempool by the operator, and blacklisted from re-entering it.
+var ErrTxBlacklisted = errors.New("tx was removed from the mempool and is blacklisted")
+
 // TxKey is the fixed length array key used as an index.
 type TxKey [sha256.Size]byte
 
@@ -84,6 +88,17 @@ func (e ErrMempoolIsFull) Error() string {
 	)
 }
 
+// ErrTxBelowMinGasPrice defines an error where the gas price of a transaction
+// is below the minimum gas price of the mempool.
+type ErrTxBelowMinGasPrice struct {
+	GasPrice    float64
+	MinGasPrice float64
+}
+
+func (e ErrTxBelowMinGasPrice) Error() string {
+	return fmt.Sprintf("tx gas price %g is below the minimum gas price %g", e.GasPrice, e.MinGasPrice)
+}
+
 // ErrPreCheck defines an error where a transaction fails a pre-check.
 type ErrPreCheck struct {
 	Reason error
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -44,12 +45,18 @@ type NodeInfo struct {
 	// Channels are HexBytes so easier to read as JSON
 	Network string `json:"network"` // network/chain ID
 	Version string `json:"version"` // major.minor.revision
+	// hash function of the Merkle trees, empty for the default
+	MerkleHashFunction string `json:"merkle_hash_function,omitempty"`
 	// FIXME: This should be changed to uint16 to be consistent with the updated channel type
 	Channels bytes.HexBytes `json:"channels"` // channels this node knows about
 
 	// ASCIIText fields
 	Moniker string        `json:"moniker"` // arbitrary moniker
 	Other   NodeInfoOther `json:"other"`   // other application specific data
+
+	// Set during the grace period after a rotation of the node key, to let
+	// peers dialing the previous node ID connect.
+	KeyRotation *NodeKeyRotation `json:"key_rotation,omitempty"`
 }
 
 // NodeInfoOther is the misc. applcation specific data
@@ -101,6 +108,10 @@ func (info NodeInfo) Validate() error {
 		channels[ch] = struct{}{}
 	}
 
+	if err := merkle.ValidateHashFunction(info.MerkleHashFunction); err != nil {
+		return fmt.Errorf("info.MerkleHashFunction: %w", err)
+	}
+
 	if m, err := tmstrings.ASCIITrim(info.Moniker); err != nil || m == "" {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
 	}
@@ -121,6 +132,15 @@ func (info NodeInfo) Validate() error {
 		}
 	}
 
+	if info.KeyRotation != nil {
+		if err := info.KeyRotation.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid info.KeyRotation: %w", err)
+		}
+		if info.KeyRotation.NodeID != info.NodeID {
+			return fmt.Errorf("info.KeyRotation is for node %v, not %v", info.KeyRotation.NodeID, info.NodeID)
+		}
+	}
+
 	return nil
 }
 
@@ -138,6 +158,11 @@ func (info NodeInfo) CompatibleWith(other NodeInfo) error {
 		return fmt.Errorf("peer is on a different network. Got %v, expected %v", other.Network, info.Network)
 	}
 
+	// nodes must hash the Merkle trees of blocks the same way
+	if mine, theirs := merkleHashFunction(info), merkleHashFunction(other); mine != theirs {
+		return fmt.Errorf("peer uses a different merkle hash function. Got %v, expected %v", theirs, mine)
+	}
+
 	// if we have no channels, we're just testing
 	if len(info.Channels) == 0 {
 		return nil
@@ -174,15 +199,26 @@ func (info *NodeInfo) AddChannel(channel uint16) {
 
 func (info NodeInfo) Copy() NodeInfo {
 	return NodeInfo{
-		ProtocolVersion: info.ProtocolVersion,
-		NodeID:          info.NodeID,
-		ListenAddr:      info.ListenAddr,
-		Network:         info.Network,
-		Version:         info.Version,
-		Channels:        info.Channels,
-		Moniker:         info.Moniker,
-		Other:           info.Other,
+		ProtocolVersion:    info.ProtocolVersion,
+		NodeID:             info.NodeID,
+		ListenAddr:         info.ListenAddr,
+		Network:            info.Network,
+		Version:            info.Version,
+		Channels:           info.Channels,
+		Moniker:            info.Moniker,
+		Other:              info.Other,
+		KeyRotation:        info.KeyRotation,
+		MerkleHashFunction: info.MerkleHashFunction,
+	}
+}
+
+// merkleHashFunction returns the name of the Merkle tree hash function of info,
+// which defaults to merkle.HashFunctionSHA256.
+func merkleHashFunction(info NodeInfo) string {
+	if info.MerkleHashFunction == "" {
+		return merkle.HashFunctionSHA256
 	}
+	return info.MerkleHashFunction
 }
 
 func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
@@ -198,12 +234,18 @@ func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
 	dni.ListenAddr = info.ListenAddr
 	dni.Network = info.Network
 	dni.Version = info.Version
+	dni.MerkleHashFunction = info.MerkleHashFunction
 	dni.Channels = info.Channels
 	dni.Moniker = info.Moniker
 	dni.Other = tmp2p.NodeInfoOther{
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	if info.KeyRotation != nil {
+		// Validate() checks that the rotation can be encoded, by checking its
+		// signature.
+		dni.KeyRotation, _ = info.KeyRotation.ToProto()
+	}
 
 	return dni
 }
@@ -228,6 +270,14 @@ func NodeInfoFromProto(pb *tmp2p.NodeInfo) (NodeInfo, error) {
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
 		},
+		MerkleHashFunction: pb.MerkleHashFunction,
+	}
+	if pb.KeyRotation != nil {
+		rotation, err := NodeKeyRotationFromProto(pb.KeyRotation)
+		if err != nil {
+			return NodeInfo{}, fmt.Errorf("invalid key rotation: %w", err)
+		}
+		dni.KeyRotation = rotation
 	}
 
 	return dni, nil
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/version"
 )
@@ -59,6 +60,9 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty Moniker", func(ni *NodeInfo) { ni.Moniker = "" }, true},
 		{"Good Moniker", func(ni *NodeInfo) { ni.Moniker = "hey its me" }, false},
 
+		{"Unknown MerkleHashFunction", func(ni *NodeInfo) { ni.MerkleHashFunction = "md5" }, true},
+		{"Good MerkleHashFunction", func(ni *NodeInfo) { ni.MerkleHashFunction = merkle.HashFunctionBlake2b }, false},
+
 		{"Non-ASCII TxIndex", func(ni *NodeInfo) { ni.Other.TxIndex = nonASCII }, true},
 		{"Empty tab TxIndex", func(ni *NodeInfo) { ni.Other.TxIndex = emptyTab }, true},
 		{"Empty space TxIndex", func(ni *NodeInfo) { ni.Other.TxIndex = emptySpace }, true},
@@ -156,6 +160,7 @@ func TestNodeInfoCompatible(t *testing.T) {
 		{"Wrong block version", func(ni *NodeInfo) { ni.ProtocolVersion.Block++ }},
 		{"Wrong network", func(ni *NodeInfo) { ni.Network += "-wrong" }},
 		{"No common channels", func(ni *NodeInfo) { ni.Channels = []byte{newTestChannel} }},
+		{"Wrong merkle hash function", func(ni *NodeInfo) { ni.MerkleHashFunction = merkle.HashFunctionBlake2b }},
 	}
 
 	for _, tc := range testCases {
@@ -163,6 +168,11 @@ func TestNodeInfoCompatible(t *testing.T) {
 		tc.malleateNodeInfo(&ni)
 		assert.Error(t, ni1.CompatibleWith(ni))
 	}
+
+	// the default merkle hash function may be left empty
+	ni2 = testNodeInfo(t, nodeKey2ID, name)
+	ni2.MerkleHashFunction = merkle.HashFunctionSHA256
+	assert.NoError(t, ni1.CompatibleWith(ni2))
 }
 
 func TestNodeInfoAddChannel(t *testing.T) {
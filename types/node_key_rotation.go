@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// NodeKeyRotation records that a node rotated its node key, from
+// PreviousPubKey to the key of NodeID. It is signed by the previous key, and
+// sent by the node in its NodeInfo so that peers still dialing its previous
+// node ID can connect to it, and learn its new node ID, until it expires.
+type NodeKeyRotation struct {
+	NodeID         NodeID
+	PreviousPubKey crypto.PubKey
+	Expires        time.Time
+	Signature      []byte
+}
+
+type nodeKeyRotationJSON struct {
+	NodeID         NodeID          `json:"node_id"`
+	PreviousPubKey json.RawMessage `json:"previous_pub_key"`
+	Expires        time.Time       `json:"expires"`
+	Signature      []byte          `json:"signature"`
+}
+
+// NewNodeKeyRotation returns the rotation from the previous node key to the
+// key of nodeID, valid until expires.
+func NewNodeKeyRotation(previous NodeKey, nodeID NodeID, expires time.Time) (*NodeKeyRotation, error) {
+	rotation := &NodeKeyRotation{
+		NodeID:         nodeID,
+		PreviousPubKey: previous.PubKey(),
+		Expires:        expires.UTC(),
+	}
+	signBytes, err := rotation.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if rotation.Signature, err = previous.PrivKey.Sign(signBytes); err != nil {
+		return nil, fmt.Errorf("signing node key rotation: %w", err)
+	}
+	return rotation, nil
+}
+
+// PreviousNodeID returns the node ID of the previous node key.
+func (r *NodeKeyRotation) PreviousNodeID() NodeID {
+	return NodeIDFromPubKey(r.PreviousPubKey)
+}
+
+// IsExpired returns true if the rotation is no longer valid at the given time.
+func (r *NodeKeyRotation) IsExpired(now time.Time) bool {
+	return !now.Before(r.Expires)
+}
+
+// SignBytes returns the bytes signed by the previous node key: the protobuf
+// encoding of the rotation without its signature.
+func (r *NodeKeyRotation) SignBytes() ([]byte, error) {
+	pb, err := r.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pb.Signature = nil
+	return pb.Marshal()
+}
+
+// ValidateBasic checks that the rotation is signed by the previous node key.
+func (r *NodeKeyRotation) ValidateBasic() error {
+	if err := r.NodeID.Validate(); err != nil {
+		return fmt.Errorf("invalid node ID: %w", err)
+	}
+	if r.PreviousPubKey == nil {
+		return errors.New("missing previous public key")
+	}
+	if r.PreviousNodeID() == r.NodeID {
+		return errors.New("previous node ID is the node ID")
+	}
+	signBytes, err := r.SignBytes()
+	if err != nil {
+		return err
+	}
+	if !r.PreviousPubKey.VerifySignature(signBytes, r.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (r *NodeKeyRotation) ToProto() (*tmp2p.NodeKeyRotation, error) {
+	pk, err := encoding.PubKeyToProto(r.PreviousPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tmp2p.NodeKeyRotation{
+		NodeID:         string(r.NodeID),
+		PreviousPubKey: pk,
+		Expires:        r.Expires,
+		Signature:      r.Signature,
+	}, nil
+}
+
+func NodeKeyRotationFromProto(pb *tmp2p.NodeKeyRotation) (*NodeKeyRotation, error) {
+	if pb == nil {
+		return nil, errors.New("nil node key rotation")
+	}
+	pk, err := encoding.PubKeyFromProto(pb.PreviousPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeKeyRotation{
+		NodeID:         NodeID(pb.NodeID),
+		PreviousPubKey: pk,
+		Expires:        pb.Expires,
+		Signature:      pb.Signature,
+	}, nil
+}
+
+func (r NodeKeyRotation) MarshalJSON() ([]byte, error) {
+	pk, err := jsontypes.Marshal(r.PreviousPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(nodeKeyRotationJSON{
+		NodeID:         r.NodeID,
+		PreviousPubKey: pk,
+		Expires:        r.Expires,
+		Signature:      r.Signature,
+	})
+}
+
+func (r *NodeKeyRotation) UnmarshalJSON(data []byte) error {
+	var rjson nodeKeyRotationJSON
+	if err := json.Unmarshal(data, &rjson); err != nil {
+		return err
+	}
+	var pk crypto.PubKey
+	if err := jsontypes.Unmarshal(rjson.PreviousPubKey, &pk); err != nil {
+		return err
+	}
+	*r = NodeKeyRotation{
+		NodeID:         rjson.NodeID,
+		PreviousPubKey: pk,
+		Expires:        rjson.Expires,
+		Signature:      rjson.Signature,
+	}
+	return nil
+}
+
+// SaveAs persists the NodeKeyRotation to filePath.
+func (r NodeKeyRotation) SaveAs(filePath string) error {
+	jsonBytes, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, jsonBytes, 0600)
+}
+
+// LoadNodeKeyRotation loads the NodeKeyRotation located in filePath.
+func LoadNodeKeyRotation(filePath string) (*NodeKeyRotation, error) {
+	jsonBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	rotation := new(NodeKeyRotation)
+	if err := json.Unmarshal(jsonBytes, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
@@ -0,0 +1,94 @@
+package types_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNodeKeyRotationValidateBasic(t *testing.T) {
+	previous := types.GenNodeKey()
+	nodeKey := types.GenNodeKey()
+
+	rotation, err := types.NewNodeKeyRotation(previous, nodeKey.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, rotation.ValidateBasic())
+	require.Equal(t, previous.ID, rotation.PreviousNodeID())
+
+	testCases := map[string]func(r *types.NodeKeyRotation){
+		"other node ID":        func(r *types.NodeKeyRotation) { r.NodeID = types.GenNodeKey().ID },
+		"invalid node ID":      func(r *types.NodeKeyRotation) { r.NodeID = "foo" },
+		"other expiry":         func(r *types.NodeKeyRotation) { r.Expires = r.Expires.Add(time.Hour) },
+		"other previous key":   func(r *types.NodeKeyRotation) { r.PreviousPubKey = types.GenNodeKey().PubKey() },
+		"missing previous key": func(r *types.NodeKeyRotation) { r.PreviousPubKey = nil },
+		"missing signature":    func(r *types.NodeKeyRotation) { r.Signature = nil },
+		"same node ID":         func(r *types.NodeKeyRotation) { r.NodeID = previous.ID },
+	}
+	for name, malleate := range testCases {
+		malleate := malleate
+		t.Run(name, func(t *testing.T) {
+			r := *rotation
+			malleate(&r)
+			require.Error(t, r.ValidateBasic())
+		})
+	}
+}
+
+func TestNodeKeyRotationSaveAs(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "node_key.json.rotation")
+
+	_, err := types.LoadNodeKeyRotation(filePath)
+	require.Error(t, err)
+
+	rotation, err := types.NewNodeKeyRotation(types.GenNodeKey(), types.GenNodeKey().ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, rotation.SaveAs(filePath))
+
+	loaded, err := types.LoadNodeKeyRotation(filePath)
+	require.NoError(t, err)
+	require.Equal(t, rotation, loaded)
+	require.NoError(t, loaded.ValidateBasic())
+}
+
+func TestNodeKeyRotationProto(t *testing.T) {
+	rotation, err := types.NewNodeKeyRotation(types.GenNodeKey(), types.GenNodeKey().ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	pb, err := rotation.ToProto()
+	require.NoError(t, err)
+	decoded, err := types.NodeKeyRotationFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, rotation, decoded)
+
+	_, err = types.NodeKeyRotationFromProto(nil)
+	require.Error(t, err)
+}
+
+func TestNodeInfoKeyRotation(t *testing.T) {
+	nodeKey := types.GenNodeKey()
+	rotation, err := types.NewNodeKeyRotation(types.GenNodeKey(), nodeKey.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	ni := types.NodeInfo{
+		NodeID:      nodeKey.ID,
+		Network:     "network",
+		ListenAddr:  "0.0.0.0:26656",
+		Moniker:     "moniker",
+		Channels:    []byte{0x01},
+		KeyRotation: rotation,
+	}
+	require.NoError(t, ni.Validate())
+
+	pb := ni.ToProto()
+	decoded, err := types.NodeInfoFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, rotation, decoded.KeyRotation)
+
+	// the rotation must be to the node's own ID
+	ni.NodeID = types.GenNodeKey().ID
+	require.Error(t, ni.Validate())
+}
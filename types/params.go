@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/sr25519"
@@ -17,21 +18,26 @@ const (
 	// MaxBlockSizeBytes is the maximum permitted size of the blocks.
 	MaxBlockSizeBytes = 104857600 // 100MB
 
-	// BlockPartSizeBytes is the size of one block part.
+	// BlockPartSizeBytes is the default, and maximum, size of one block part.
 	BlockPartSizeBytes uint32 = 1048576 // 1MB
 
+	// MinBlockPartSizeBytes is the minimum size of one block part.
+	MinBlockPartSizeBytes uint32 = 16384 // 16KB
+
 	// MaxBlockPartsCount is the maximum number of block parts.
 	MaxBlockPartsCount = (MaxBlockSizeBytes / BlockPartSizeBytes) + 1
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
 	ABCIPubKeyTypeSr25519   = sr25519.KeyType
+	ABCIPubKeyTypeBls12381  = bls12381.KeyType
 )
 
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
 	ABCIPubKeyTypeSr25519:   sr25519.PubKeyName,
+	ABCIPubKeyTypeBls12381:  bls12381.PubKeyName,
 }
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -50,8 +56,9 @@ type ConsensusParams struct {
 // It is amino encoded and hashed into
 // the Header.ConsensusHash.
 type HashedParams struct {
-	BlockMaxBytes int64
-	BlockMaxGas   int64
+	BlockMaxBytes      int64
+	BlockMaxGas        int64
+	BlockPartSizeBytes uint32
 }
 
 // BlockParams define limits on the block size and gas plus minimum time
@@ -59,6 +66,17 @@ type HashedParams struct {
 type BlockParams struct {
 	MaxBytes int64 `json:"max_bytes,string"`
 	MaxGas   int64 `json:"max_gas,string"`
+	// PartSizeBytes is the size of the parts blocks are split into. 0 means
+	// BlockPartSizeBytes.
+	PartSizeBytes uint32 `json:"part_size_bytes"`
+}
+
+// PartSize returns the size of the parts blocks are split into.
+func (b BlockParams) PartSize() uint32 {
+	if b.PartSizeBytes == 0 {
+		return BlockPartSizeBytes
+	}
+	return b.PartSizeBytes
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
@@ -72,6 +90,19 @@ type EvidenceParams struct {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
+	// AggregateCommitsEnableHeight is the first height whose commit aggregates
+	// the signatures of the BLS12-381 validators that voted for the block into
+	// a single signature. 0 disables aggregation.
+	AggregateCommitsEnableHeight int64 `json:"aggregate_commits_enable_height"`
+}
+
+// AggregateCommitsEnabled returns true if the commit for the block at height h
+// aggregates the signatures of BLS12-381 validators and false otherwise.
+func (v ValidatorParams) AggregateCommitsEnabled(h int64) bool {
+	if v.AggregateCommitsEnableHeight == 0 {
+		return false
+	}
+	return v.AggregateCommitsEnableHeight <= h
 }
 
 type VersionParams struct {
@@ -85,6 +116,19 @@ type VersionParams struct {
 type SynchronyParams struct {
 	Precision    time.Duration `json:"precision,string"`
 	MessageDelay time.Duration `json:"message_delay,string"`
+	// MedianTimeEnableHeight is the first height at which the time of a block
+	// must not be before the median time of its last commit by more than the
+	// precision. 0 disables the check.
+	MedianTimeEnableHeight int64 `json:"median_time_enable_height"`
+}
+
+// MedianTimeEnabled returns true if the time of blocks is checked against the
+// median time of their last commit at height h and false otherwise.
+func (s SynchronyParams) MedianTimeEnabled(h int64) bool {
+	if s.MedianTimeEnableHeight == 0 {
+		return false
+	}
+	return s.MedianTimeEnableHeight <= h
 }
 
 // TimeoutParams configure the timings of the steps of the Tendermint consensus algorithm.
@@ -113,6 +157,13 @@ func (a ABCIParams) VoteExtensionsEnabled(h int64) bool {
 	return a.VoteExtensionsEnableHeight <= h
 }
 
+// ExtendedCommitsRequired returns true if the extended commit for the block at
+// height h must be stored: either to provide its vote extensions to the
+// application or to keep the individual signatures of an aggregated commit.
+func (params ConsensusParams) ExtendedCommitsRequired(h int64) bool {
+	return params.ABCI.VoteExtensionsEnabled(h) || params.Validator.AggregateCommitsEnabled(h)
+}
+
 // DefaultConsensusParams returns a default ConsensusParams.
 func DefaultConsensusParams() *ConsensusParams {
 	return &ConsensusParams{
@@ -286,6 +337,19 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Block.MaxGas)
 	}
 
+	if params.Block.PartSizeBytes != 0 {
+		if err := ValidateBlockPartSize(params.Block.PartSizeBytes); err != nil {
+			return fmt.Errorf("block.PartSizeBytes: %w", err)
+		}
+	}
+
+	// A block of MaxBytes must not be split into more parts than peers accept.
+	partSize := int64(params.Block.PartSize())
+	if parts := (params.Block.MaxBytes + partSize - 1) / partSize; parts > int64(MaxBlockPartsCount) {
+		return fmt.Errorf("block.MaxBytes %d would be split into %d parts of %d bytes, max: %d",
+			params.Block.MaxBytes, parts, partSize, MaxBlockPartsCount)
+	}
+
 	if params.Evidence.MaxAgeNumBlocks <= 0 {
 		return fmt.Errorf("evidence.MaxAgeNumBlocks must be greater than 0. Got %d",
 			params.Evidence.MaxAgeNumBlocks)
@@ -316,6 +380,11 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Synchrony.Precision)
 	}
 
+	if params.Synchrony.MedianTimeEnableHeight < 0 {
+		return fmt.Errorf("synchrony.MedianTimeEnableHeight cannot be negative. Got: %d",
+			params.Synchrony.MedianTimeEnableHeight)
+	}
+
 	if params.Timeout.Propose <= 0 {
 		return fmt.Errorf("timeout.ProposeDelta must be greater than 0. Got: %d", params.Timeout.Propose)
 	}
@@ -339,6 +408,11 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 		return fmt.Errorf("ABCI.VoteExtensionsEnableHeight cannot be negative. Got: %d", params.ABCI.VoteExtensionsEnableHeight)
 	}
 
+	if params.Validator.AggregateCommitsEnableHeight < 0 {
+		return fmt.Errorf("validator.AggregateCommitsEnableHeight cannot be negative. Got: %d",
+			params.Validator.AggregateCommitsEnableHeight)
+	}
+
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
@@ -356,6 +430,34 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 }
 
 func (params ConsensusParams) ValidateUpdate(updated *tmproto.ConsensusParams, h int64) error {
+	if updated.Synchrony != nil &&
+		params.Synchrony.MedianTimeEnableHeight != updated.Synchrony.MedianTimeEnableHeight {
+		if updated.Synchrony.MedianTimeEnableHeight != 0 && updated.Synchrony.MedianTimeEnableHeight <= h {
+			return fmt.Errorf("MedianTimeEnableHeight cannot be updated to a past height, "+
+				"updated height: %d, current height %d",
+				updated.Synchrony.MedianTimeEnableHeight, h)
+		}
+		if params.Synchrony.MedianTimeEnableHeight != 0 && params.Synchrony.MedianTimeEnableHeight <= h {
+			return fmt.Errorf("MedianTimeEnableHeight cannot be modified once "+
+				"the enable height has occurred, "+
+				"enable height: %d, current height %d",
+				params.Synchrony.MedianTimeEnableHeight, h)
+		}
+	}
+	if updated.Validator != nil &&
+		params.Validator.AggregateCommitsEnableHeight != updated.Validator.AggregateCommitsEnableHeight {
+		if updated.Validator.AggregateCommitsEnableHeight != 0 && updated.Validator.AggregateCommitsEnableHeight <= h {
+			return fmt.Errorf("AggregateCommitsEnableHeight cannot be updated to a past height, "+
+				"updated height: %d, current height %d",
+				updated.Validator.AggregateCommitsEnableHeight, h)
+		}
+		if params.Validator.AggregateCommitsEnableHeight != 0 && params.Validator.AggregateCommitsEnableHeight <= h {
+			return fmt.Errorf("AggregateCommitsEnableHeight cannot be modified once "+
+				"the enable height has occurred, "+
+				"enable height: %d, current height %d",
+				params.Validator.AggregateCommitsEnableHeight, h)
+		}
+	}
 	if updated.Abci == nil {
 		return nil
 	}
@@ -380,14 +482,16 @@ func (params ConsensusParams) ValidateUpdate(updated *tmproto.ConsensusParams, h
 }
 
 // Hash returns a hash of a subset of the parameters to store in the block header.
-// Only the Block.MaxBytes and Block.MaxGas are included in the hash.
+// Only the Block.MaxBytes, Block.MaxGas and Block.PartSizeBytes are included in
+// the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
 // protocol. No need for a Merkle tree here, just a small struct to hash.
 // TODO: We should hash the other parameters as well
 func (params ConsensusParams) HashConsensusParams() []byte {
 	hp := tmproto.HashedParams{
-		BlockMaxBytes: params.Block.MaxBytes,
-		BlockMaxGas:   params.Block.MaxGas,
+		BlockMaxBytes:      params.Block.MaxBytes,
+		BlockMaxGas:        params.Block.MaxGas,
+		BlockPartSizeBytes: params.Block.PartSizeBytes,
 	}
 
 	bz, err := hp.Marshal()
@@ -407,6 +511,7 @@ func (params *ConsensusParams) Equals(params2 *ConsensusParams) bool {
 		params.Synchrony == params2.Synchrony &&
 		params.Timeout == params2.Timeout &&
 		params.ABCI == params2.ABCI &&
+		params.Validator.AggregateCommitsEnableHeight == params2.Validator.AggregateCommitsEnableHeight &&
 		tmstrings.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes)
 }
 
@@ -423,6 +528,7 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		res.Block.PartSizeBytes = params2.Block.PartSizeBytes
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
@@ -433,6 +539,7 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
 		// This avoids having to initialize the slice to 0 values, and then write to it again.
 		res.Validator.PubKeyTypes = append([]string{}, params2.Validator.PubKeyTypes...)
+		res.Validator.AggregateCommitsEnableHeight = params2.Validator.GetAggregateCommitsEnableHeight()
 	}
 	if params2.Version != nil {
 		res.Version.AppVersion = params2.Version.AppVersion
@@ -444,6 +551,7 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 		if params2.Synchrony.Precision != nil {
 			res.Synchrony.Precision = *params2.Synchrony.GetPrecision()
 		}
+		res.Synchrony.MedianTimeEnableHeight = params2.Synchrony.GetMedianTimeEnableHeight()
 	}
 	if params2.Timeout != nil {
 		if params2.Timeout.Propose != nil {
@@ -473,8 +581,9 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 	return tmproto.ConsensusParams{
 		Block: &tmproto.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:      params.Block.MaxBytes,
+			MaxGas:        params.Block.MaxGas,
+			PartSizeBytes: params.Block.PartSizeBytes,
 		},
 		Evidence: &tmproto.EvidenceParams{
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
@@ -482,14 +591,16 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 			MaxBytes:        params.Evidence.MaxBytes,
 		},
 		Validator: &tmproto.ValidatorParams{
-			PubKeyTypes: params.Validator.PubKeyTypes,
+			PubKeyTypes:                  params.Validator.PubKeyTypes,
+			AggregateCommitsEnableHeight: params.Validator.AggregateCommitsEnableHeight,
 		},
 		Version: &tmproto.VersionParams{
 			AppVersion: params.Version.AppVersion,
 		},
 		Synchrony: &tmproto.SynchronyParams{
-			MessageDelay: &params.Synchrony.MessageDelay,
-			Precision:    &params.Synchrony.Precision,
+			MessageDelay:           &params.Synchrony.MessageDelay,
+			Precision:              &params.Synchrony.Precision,
+			MedianTimeEnableHeight: params.Synchrony.MedianTimeEnableHeight,
 		},
 		Timeout: &tmproto.TimeoutParams{
 			Propose:             &params.Timeout.Propose,
@@ -509,8 +620,9 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams {
 	c := ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: pbParams.Block.MaxBytes,
-			MaxGas:   pbParams.Block.MaxGas,
+			MaxBytes:      pbParams.Block.MaxBytes,
+			MaxGas:        pbParams.Block.MaxGas,
+			PartSizeBytes: pbParams.Block.PartSizeBytes,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
@@ -518,7 +630,8 @@ func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams
 			MaxBytes:        pbParams.Evidence.MaxBytes,
 		},
 		Validator: ValidatorParams{
-			PubKeyTypes: pbParams.Validator.PubKeyTypes,
+			PubKeyTypes:                  pbParams.Validator.PubKeyTypes,
+			AggregateCommitsEnableHeight: pbParams.Validator.AggregateCommitsEnableHeight,
 		},
 		Version: VersionParams{
 			AppVersion: pbParams.Version.AppVersion,
@@ -531,6 +644,7 @@ func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams
 		if pbParams.Synchrony.Precision != nil {
 			c.Synchrony.Precision = *pbParams.Synchrony.GetPrecision()
 		}
+		c.Synchrony.MedianTimeEnableHeight = pbParams.Synchrony.GetMedianTimeEnableHeight()
 	}
 	if pbParams.Timeout != nil {
 		if pbParams.Timeout.Propose != nil {
@@ -52,6 +52,46 @@ func TestConsensusParamsValidation(t *testing.T) {
 				messageDelay: 1}),
 			valid: true,
 		},
+		{
+			name: "block params valid PartSizeBytes",
+			params: makeParams(makeParamsArgs{
+				blockBytes:   1024 * 1024,
+				partSize:     65536,
+				evidenceAge:  2,
+				precision:    1,
+				messageDelay: 1}),
+			valid: true,
+		},
+		{
+			name: "block params PartSizeBytes not power of two",
+			params: makeParams(makeParamsArgs{
+				blockBytes:   1,
+				partSize:     65537,
+				evidenceAge:  2,
+				precision:    1,
+				messageDelay: 1}),
+			valid: false,
+		},
+		{
+			name: "block params PartSizeBytes too big",
+			params: makeParams(makeParamsArgs{
+				blockBytes:   1,
+				partSize:     2 * BlockPartSizeBytes,
+				evidenceAge:  2,
+				precision:    1,
+				messageDelay: 1}),
+			valid: false,
+		},
+		{
+			name: "block params too many parts",
+			params: makeParams(makeParamsArgs{
+				blockBytes:   47 * 1024 * 1024,
+				partSize:     MinBlockPartSizeBytes,
+				evidenceAge:  2,
+				precision:    1,
+				messageDelay: 1}),
+			valid: false,
+		},
 		{
 			name: "block params small MaxBytes",
 			params: makeParams(makeParamsArgs{
@@ -178,6 +218,7 @@ func TestConsensusParamsValidation(t *testing.T) {
 type makeParamsArgs struct {
 	blockBytes          int64
 	blockGas            int64
+	partSize            uint32
 	recheck             bool
 	evidenceAge         int64
 	maxEvidenceBytes    int64
@@ -192,7 +233,8 @@ type makeParamsArgs struct {
 	voteDelta    *time.Duration
 	commit       *time.Duration
 
-	abciExtensionHeight int64
+	abciExtensionHeight    int64
+	aggregateCommitsHeight int64
 }
 
 func makeParams(args makeParamsArgs) ConsensusParams {
@@ -216,8 +258,9 @@ func makeParams(args makeParamsArgs) ConsensusParams {
 	}
 	return ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: args.blockBytes,
-			MaxGas:   args.blockGas,
+			MaxBytes:      args.blockBytes,
+			MaxGas:        args.blockGas,
+			PartSizeBytes: args.partSize,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: args.evidenceAge,
@@ -225,7 +268,8 @@ func makeParams(args makeParamsArgs) ConsensusParams {
 			MaxBytes:        args.maxEvidenceBytes,
 		},
 		Validator: ValidatorParams{
-			PubKeyTypes: args.pubkeyTypes,
+			PubKeyTypes:                  args.pubkeyTypes,
+			AggregateCommitsEnableHeight: args.aggregateCommitsHeight,
 		},
 		Synchrony: SynchronyParams{
 			Precision:    args.precision,
@@ -256,6 +300,7 @@ func TestConsensusParamsHash(t *testing.T) {
 		makeParams(makeParamsArgs{blockBytes: 9, blockGas: 5, evidenceAge: 4, maxEvidenceBytes: 1}),
 		makeParams(makeParamsArgs{blockBytes: 7, blockGas: 8, evidenceAge: 9, maxEvidenceBytes: 1}),
 		makeParams(makeParamsArgs{blockBytes: 4, blockGas: 6, evidenceAge: 5, maxEvidenceBytes: 1}),
+		makeParams(makeParamsArgs{blockBytes: 4, blockGas: 6, partSize: 65536, evidenceAge: 5, maxEvidenceBytes: 1}),
 	}
 
 	hashes := make([][]byte, len(params))
@@ -392,6 +437,11 @@ func TestConsensusParamsUpdate(t *testing.T) {
 	}
 }
 
+func TestBlockParamsPartSize(t *testing.T) {
+	assert.Equal(t, BlockPartSizeBytes, BlockParams{}.PartSize())
+	assert.EqualValues(t, 65536, BlockParams{PartSizeBytes: 65536}.PartSize())
+}
+
 func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	params := makeParams(makeParamsArgs{blockBytes: 1, blockGas: 2, evidenceAge: 3})
 
@@ -475,6 +525,59 @@ func TestConsensusParamsUpdate_VoteExtensionsEnableHeight(t *testing.T) {
 	})
 }
 
+func TestConsensusParamsUpdate_MedianTimeEnableHeight(t *testing.T) {
+	params := DefaultConsensusParams()
+	update := &tmproto.ConsensusParams{
+		Synchrony: &tmproto.SynchronyParams{MedianTimeEnableHeight: 10},
+	}
+	require.NoError(t, params.ValidateUpdate(update, 5))
+	require.Error(t, params.ValidateUpdate(update, 10))
+	updated := params.UpdateConsensusParams(update)
+	assert.EqualValues(t, 10, updated.Synchrony.MedianTimeEnableHeight)
+	assert.False(t, updated.Synchrony.MedianTimeEnabled(9))
+	assert.True(t, updated.Synchrony.MedianTimeEnabled(10))
+
+	// It can be changed until it is reached.
+	update.Synchrony.MedianTimeEnableHeight = 0
+	require.NoError(t, updated.ValidateUpdate(update, 5))
+	require.Error(t, updated.ValidateUpdate(update, 10))
+	update.Synchrony.MedianTimeEnableHeight = 10
+	require.NoError(t, updated.ValidateUpdate(update, 20))
+
+	params.Synchrony.MedianTimeEnableHeight = -1
+	require.Error(t, params.ValidateConsensusParams())
+}
+
+func TestConsensusParamsUpdate_AggregateCommitsEnableHeight(t *testing.T) {
+	params := DefaultConsensusParams()
+	update := &tmproto.ConsensusParams{
+		Validator: &tmproto.ValidatorParams{
+			PubKeyTypes:                  []string{ABCIPubKeyTypeBls12381},
+			AggregateCommitsEnableHeight: 10,
+		},
+	}
+	require.NoError(t, params.ValidateUpdate(update, 5))
+	require.Error(t, params.ValidateUpdate(update, 10))
+	updated := params.UpdateConsensusParams(update)
+	require.NoError(t, updated.ValidateConsensusParams())
+	assert.EqualValues(t, 10, updated.Validator.AggregateCommitsEnableHeight)
+	assert.False(t, updated.Validator.AggregateCommitsEnabled(9))
+	assert.True(t, updated.Validator.AggregateCommitsEnabled(10))
+	assert.False(t, updated.ExtendedCommitsRequired(9))
+	assert.True(t, updated.ExtendedCommitsRequired(10))
+	assert.False(t, params.Equals(&updated))
+
+	// It can be changed until it is reached.
+	update.Validator.AggregateCommitsEnableHeight = 0
+	require.NoError(t, updated.ValidateUpdate(update, 5))
+	require.Error(t, updated.ValidateUpdate(update, 10))
+	update.Validator.AggregateCommitsEnableHeight = 10
+	require.NoError(t, updated.ValidateUpdate(update, 20))
+
+	params.Validator.AggregateCommitsEnableHeight = -1
+	require.Error(t, params.ValidateConsensusParams())
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(makeParamsArgs{blockBytes: 4, blockGas: 2, evidenceAge: 3, maxEvidenceBytes: 1}),
@@ -485,10 +588,12 @@ func TestProto(t *testing.T) {
 		makeParams(makeParamsArgs{blockBytes: 9, blockGas: 5, evidenceAge: 4, maxEvidenceBytes: 1}),
 		makeParams(makeParamsArgs{blockBytes: 7, blockGas: 8, evidenceAge: 9, maxEvidenceBytes: 1}),
 		makeParams(makeParamsArgs{blockBytes: 4, blockGas: 6, evidenceAge: 5, maxEvidenceBytes: 1}),
+		makeParams(makeParamsArgs{blockBytes: 4, blockGas: 6, partSize: 65536, evidenceAge: 5, maxEvidenceBytes: 1}),
 		makeParams(makeParamsArgs{precision: time.Second, messageDelay: time.Minute}),
 		makeParams(makeParamsArgs{precision: time.Nanosecond, messageDelay: time.Millisecond}),
 		makeParams(makeParamsArgs{abciExtensionHeight: 100}),
 		makeParams(makeParamsArgs{abciExtensionHeight: 100}),
+		makeParams(makeParamsArgs{aggregateCommitsHeight: 100}),
 		makeParams(makeParamsArgs{
 			propose:             durationPtr(2 * time.Second),
 			proposeDelta:        durationPtr(400 * time.Millisecond),
@@ -0,0 +1,323 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/libs/reedsolomon"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// parityPartRatio is the number of block parts per parity part.
+const parityPartRatio = 2
+
+// maxParityReconstructions bounds the reconstructions ReconstructParts attempts
+// with different subsets of the parity parts, each of which costs about as
+// much as encoding the block.
+const maxParityReconstructions = 64
+
+// ErrPartSetInvalidParity is returned when the parts reconstructed from parity
+// parts don't match the part set hash.
+var ErrPartSetInvalidParity = errors.New("error part set invalid parity parts")
+
+// ParityPartsTotal returns the number of parity parts of a part set of total
+// parts, or 0 if part sets of that size aren't erasure-coded.
+func ParityPartsTotal(total uint32) uint32 {
+	if total < 2 {
+		return 0
+	}
+	parity := (total + parityPartRatio - 1) / parityPartRatio
+	if total+parity > reedsolomon.MaxShards {
+		return 0
+	}
+	return parity
+}
+
+// ParityPart is an erasure-coded part of a block, computed from the parts of
+// its part set with a Reed-Solomon code. The missing parts of a part set can
+// be reconstructed from any combination of its parts and parity parts as
+// large as its total. Parity parts have no proofs: the reconstructed parts are
+// verified against the part set hash instead.
+type ParityPart struct {
+	Index uint32 `json:"index"`
+	// Size is the byte size of the block, from which the size of the last
+	// part, which is padded with zeros in the code, is derived.
+	Size  uint32           `json:"size"`
+	Bytes tmbytes.HexBytes `json:"bytes"`
+}
+
+// ValidateBasic performs basic validation.
+func (part *ParityPart) ValidateBasic() error {
+	if len(part.Bytes) == 0 {
+		return errors.New("empty parity part")
+	}
+	if len(part.Bytes) > int(BlockPartSizeBytes) {
+		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), BlockPartSizeBytes)
+	}
+	if part.Size == 0 {
+		return errors.New("zero block size")
+	}
+	return nil
+}
+
+// String returns a string representation of ParityPart.
+func (part *ParityPart) String() string {
+	return fmt.Sprintf("ParityPart{#%v %X... size %v}", part.Index, tmbytes.Fingerprint(part.Bytes), part.Size)
+}
+
+func (part *ParityPart) ToProto() (*tmproto.ParityPart, error) {
+	if part == nil {
+		return nil, errors.New("nil parity part")
+	}
+	return &tmproto.ParityPart{
+		Index: part.Index,
+		Size_: part.Size,
+		Bytes: part.Bytes,
+	}, nil
+}
+
+func ParityPartFromProto(pb *tmproto.ParityPart) (*ParityPart, error) {
+	if pb == nil {
+		return nil, errors.New("nil parity part")
+	}
+	part := &ParityPart{
+		Index: pb.Index,
+		Size:  pb.Size_,
+		Bytes: pb.Bytes,
+	}
+	return part, part.ValidateBasic()
+}
+
+// ParityParts returns the parity parts of the complete part set, which are
+// computed on first use. It returns nil if the part set isn't erasure-coded.
+func (ps *PartSet) ParityParts() ([]*ParityPart, error) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.count != ps.total {
+		return nil, errors.New("cannot compute the parity parts of an incomplete part set")
+	}
+	parityTotal := ParityPartsTotal(ps.total)
+	if ps.parityParts != nil || parityTotal == 0 {
+		return ps.parityParts, nil
+	}
+
+	enc, err := reedsolomon.New(int(ps.total), int(parityTotal))
+	if err != nil {
+		return nil, err
+	}
+	partSize := len(ps.parts[0].Bytes)
+	shards := make([][]byte, ps.total)
+	for i, part := range ps.parts {
+		if len(part.Bytes) > partSize {
+			return nil, fmt.Errorf("part %d is larger than the first part", i)
+		}
+		shards[i] = padPart(part.Bytes, partSize)
+	}
+	parity, err := enc.Encode(shards)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.parityParts = make([]*ParityPart, len(parity))
+	for i, bz := range parity {
+		ps.parityParts[i] = &ParityPart{
+			Index: uint32(i),
+			Size:  uint32(ps.byteSize),
+			Bytes: bz,
+		}
+	}
+	return ps.parityParts, nil
+}
+
+// ReconstructParts rebuilds the parts missing from the part set, from the
+// parts it has and the given parity parts, and verifies them against the part
+// set hash. If that fails and there are more parity parts than needed, it is
+// retried without some of the parity parts, fewest first, so that as many
+// invalid parity parts as there are extra ones are tolerated, within
+// maxParityReconstructions attempts. It also returns the given parity parts
+// that don't match the reconstructed block. The part set itself is left
+// unchanged: the returned parts carry their proofs, and are to be added with
+// AddPart.
+func (ps *PartSet) ReconstructParts(parity []*ParityPart) ([]*Part, []*ParityPart, error) {
+	parts, data, size, err := ps.reconstructParts(parity)
+	if err != nil && !errors.Is(err, reedsolomon.ErrTooFewShards) {
+		extra := int(ps.Count()) + len(parity) - int(ps.total)
+		attempts := 1
+	retry:
+		for leftOut := 1; leftOut <= extra; leftOut++ {
+			subsets := newSubsets(len(parity), len(parity)-leftOut)
+			for subsets.next() {
+				if attempts == maxParityReconstructions {
+					break retry
+				}
+				attempts++
+				others := make([]*ParityPart, 0, len(parity)-leftOut)
+				for _, i := range subsets.indexes {
+					others = append(others, parity[i])
+				}
+				var otherErr error
+				if parts, data, size, otherErr = ps.reconstructParts(others); otherErr == nil {
+					err = nil
+					break retry
+				}
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enc, err := reedsolomon.New(int(ps.total), int(ParityPartsTotal(ps.total)))
+	if err != nil {
+		return nil, nil, err
+	}
+	expected, err := enc.Encode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var invalid []*ParityPart
+	for _, part := range parity {
+		if part.Index >= uint32(len(expected)) || part.Size != size || !bytes.Equal(part.Bytes, expected[part.Index]) {
+			invalid = append(invalid, part)
+		}
+	}
+	return parts, invalid, nil
+}
+
+// reconstructParts rebuilds the parts missing from the part set from the given
+// parity parts, and returns them along with all the data shards, padded to the
+// part size, and the block size.
+func (ps *PartSet) reconstructParts(parity []*ParityPart) ([]*Part, [][]byte, uint32, error) {
+	parityTotal := ParityPartsTotal(ps.total)
+	if parityTotal == 0 {
+		return nil, nil, 0, errors.New("part set isn't erasure-coded")
+	}
+	if len(parity) == 0 {
+		return nil, nil, 0, reedsolomon.ErrTooFewShards
+	}
+
+	partSize, size := len(parity[0].Bytes), parity[0].Size
+	if uint64(size) <= uint64(ps.total-1)*uint64(partSize) || uint64(size) > uint64(ps.total)*uint64(partSize) {
+		return nil, nil, 0, fmt.Errorf("%w: block size %d doesn't match %d parts of %d bytes",
+			ErrPartSetInvalidParity, size, ps.total, partSize)
+	}
+	lastSize := int(size) - int(ps.total-1)*partSize
+
+	shards := make([][]byte, ps.total+parityTotal)
+	for _, part := range parity {
+		if part.Index >= parityTotal {
+			return nil, nil, 0, ErrPartSetUnexpectedIndex
+		}
+		if len(part.Bytes) != partSize || part.Size != size {
+			return nil, nil, 0, fmt.Errorf("%w: parity parts have different sizes", ErrPartSetInvalidParity)
+		}
+		shards[ps.total+part.Index] = part.Bytes
+	}
+
+	ps.mtx.Lock()
+	for i, part := range ps.parts {
+		if part == nil {
+			continue
+		}
+		expected := partSize
+		if uint32(i) == ps.total-1 {
+			expected = lastSize
+		}
+		if len(part.Bytes) != expected {
+			ps.mtx.Unlock()
+			return nil, nil, 0, fmt.Errorf("%w: part %d has %d bytes, expected %d",
+				ErrPartSetInvalidParity, i, len(part.Bytes), expected)
+		}
+		shards[i] = padPart(part.Bytes, partSize)
+	}
+	ps.mtx.Unlock()
+
+	enc, err := reedsolomon.New(int(ps.total), int(parityTotal))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	// Remember which parts were missing before Reconstruct fills them in.
+	var missing []uint32
+	for i := uint32(0); i < ps.total; i++ {
+		if shards[i] == nil {
+			missing = append(missing, i)
+		}
+	}
+	if err := enc.Reconstruct(shards); err != nil {
+		return nil, nil, 0, err
+	}
+
+	data := shards[:ps.total]
+	last := data[ps.total-1]
+	data[ps.total-1] = last[:lastSize]
+	root, proofs := merkle.ProofsFromByteSlices(data)
+	data[ps.total-1] = last
+	if !bytes.Equal(root, ps.hash) {
+		return nil, nil, 0, ErrPartSetInvalidParity
+	}
+
+	parts := make([]*Part, len(missing))
+	for i, index := range missing {
+		bz := data[index]
+		if index == ps.total-1 {
+			bz = bz[:lastSize]
+		}
+		parts[i] = &Part{
+			Index: index,
+			Bytes: bz,
+			Proof: *proofs[index],
+		}
+	}
+	return parts, data, size, nil
+}
+
+// subsets iterates over the subsets of k of the indexes 0 to n-1, in
+// lexicographic order.
+type subsets struct {
+	n       int
+	indexes []int
+	started bool
+}
+
+func newSubsets(n, k int) *subsets {
+	return &subsets{n: n, indexes: make([]int, k)}
+}
+
+// next moves to the next subset, and returns false once there are none left.
+func (s *subsets) next() bool {
+	k := len(s.indexes)
+	if !s.started {
+		s.started = true
+		for i := range s.indexes {
+			s.indexes[i] = i
+		}
+		return k <= s.n
+	}
+	// Increment the last index that can be, and reset the following ones.
+	i := k - 1
+	for i >= 0 && s.indexes[i] == s.n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	s.indexes[i]++
+	for j := i + 1; j < k; j++ {
+		s.indexes[j] = s.indexes[j-1] + 1
+	}
+	return true
+}
+
+// padPart returns bz padded with zeros to size bytes.
+func padPart(bz []byte, size int) []byte {
+	if len(bz) == size {
+		return bz
+	}
+	padded := make([]byte, size)
+	copy(padded, bz)
+	return padded
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestParityPartsTotal(t *testing.T) {
+	assert.EqualValues(t, 0, ParityPartsTotal(1))
+	assert.EqualValues(t, 1, ParityPartsTotal(2))
+	assert.EqualValues(t, 5, ParityPartsTotal(9))
+	assert.EqualValues(t, 85, ParityPartsTotal(170))
+	assert.EqualValues(t, 0, ParityPartsTotal(171))
+}
+
+func TestPartSetReconstructParts(t *testing.T) {
+	const partSize = 1024
+	// The last part is shorter than the others.
+	data := tmrand.Bytes(partSize*9 + 100)
+	partSet := NewPartSetFromData(data, partSize)
+	require.EqualValues(t, 10, partSet.Total())
+
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 5)
+
+	// Keep 5 parts, including the last one, and drop the rest.
+	partial := NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 3, 4, 7, 9} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	_, _, err = partial.ReconstructParts(parity[:4])
+	require.Error(t, err)
+
+	parts, invalid, err := partial.ReconstructParts(parity)
+	require.NoError(t, err)
+	require.Empty(t, invalid)
+	require.Len(t, parts, 5)
+	for _, part := range parts {
+		added, err := partial.AddPart(part)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	require.True(t, partial.IsComplete())
+	bz, err := io.ReadAll(partial.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, bz)
+
+	// Parts are reconstructed from parity parts alone, without the last part.
+	partial = NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{1, 2, 5, 6, 8} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+	parts, _, err = partial.ReconstructParts(parity)
+	require.NoError(t, err)
+	assert.Len(t, parts, 5)
+}
+
+func TestPartSetReconstructPartsInvalid(t *testing.T) {
+	const partSize = 1024
+	partSet := NewPartSetFromData(tmrand.Bytes(partSize*4), partSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 2)
+
+	partial := NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 1} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	corrupted := *parity[0]
+	corrupted.Bytes = tmrand.Bytes(partSize)
+	_, _, err = partial.ReconstructParts([]*ParityPart{&corrupted, parity[1]})
+	assert.True(t, errors.Is(err, ErrPartSetInvalidParity))
+
+	resized := *parity[0]
+	resized.Size = partSize * 5
+	_, _, err = partial.ReconstructParts([]*ParityPart{&resized, parity[1]})
+	assert.True(t, errors.Is(err, ErrPartSetInvalidParity))
+}
+
+func TestPartSetReconstructPartsLeavesOutInvalid(t *testing.T) {
+	const partSize = 1024
+	partSet := NewPartSetFromData(tmrand.Bytes(partSize*6), partSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 3)
+
+	partial := NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 1, 2, 3} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	corrupted := *parity[1]
+	corrupted.Bytes = tmrand.Bytes(partSize)
+	resized := *parity[1]
+	resized.Size = partSize*6 - 1
+
+	for _, invalidPart := range []*ParityPart{&corrupted, &resized} {
+		// With the invalid parity part first, it is always used.
+		parts, invalid, err := partial.ReconstructParts([]*ParityPart{invalidPart, parity[0], parity[2]})
+		require.NoError(t, err)
+		require.Len(t, parts, 2)
+		assert.Equal(t, partSet.GetPart(4).Bytes, parts[0].Bytes)
+		assert.Equal(t, partSet.GetPart(5).Bytes, parts[1].Bytes)
+		assert.Equal(t, []*ParityPart{invalidPart}, invalid)
+	}
+}
+
+func TestPartSetReconstructPartsLeavesOutSeveralInvalid(t *testing.T) {
+	const partSize = 1024
+	partSet := NewPartSetFromData(tmrand.Bytes(partSize*10), partSize)
+	parity, err := partSet.ParityParts()
+	require.NoError(t, err)
+	require.Len(t, parity, 5)
+
+	// 3 parts are missing, and 2 of the 5 parity parts are invalid.
+	partial := NewPartSetFromHeader(partSet.Header())
+	for _, i := range []int{0, 1, 2, 4, 5, 7, 9} {
+		_, err := partial.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+	corrupted := *parity[0]
+	corrupted.Bytes = tmrand.Bytes(partSize)
+	resized := *parity[3]
+	resized.Size = partSize*10 - 1
+	received := []*ParityPart{&corrupted, parity[1], parity[2], &resized, parity[4]}
+
+	parts, invalid, err := partial.ReconstructParts(received)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	for i, index := range []int{3, 6, 8} {
+		assert.Equal(t, partSet.GetPart(index).Bytes, parts[i].Bytes)
+	}
+	assert.Equal(t, []*ParityPart{&corrupted, &resized}, invalid)
+
+	// Without enough valid parity parts, the reconstruction fails.
+	_, _, err = partial.ReconstructParts(received[:4])
+	assert.True(t, errors.Is(err, ErrPartSetInvalidParity))
+}
+
+func TestSubsets(t *testing.T) {
+	var got [][]int
+	s := newSubsets(4, 2)
+	for s.next() {
+		got = append(got, append([]int{}, s.indexes...))
+	}
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}, got)
+
+	s = newSubsets(2, 3)
+	assert.False(t, s.next())
+}
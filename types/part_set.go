@@ -20,6 +20,22 @@ var (
 	ErrPartSetInvalidProof    = errors.New("error part set invalid proof")
 )
 
+// ValidateBlockPartSize checks that size is a valid block part size, i.e. a
+// power of two between MinBlockPartSizeBytes and BlockPartSizeBytes.
+//
+// NOTE: the part size determines the PartSetHeader, and therefore the BlockID,
+// of every block, which is why it is a consensus parameter.
+func ValidateBlockPartSize(size uint32) error {
+	if size < MinBlockPartSizeBytes || size > BlockPartSizeBytes {
+		return fmt.Errorf("block part size %d must be between %d and %d",
+			size, MinBlockPartSizeBytes, BlockPartSizeBytes)
+	}
+	if size&(size-1) != 0 {
+		return fmt.Errorf("block part size %d must be a power of two", size)
+	}
+	return nil
+}
+
 type Part struct {
 	Index uint32           `json:"index"`
 	Bytes tmbytes.HexBytes `json:"bytes"`
@@ -164,6 +180,8 @@ type PartSet struct {
 	// a count of the total size (in bytes). Used to ensure that the
 	// part set doesn't exceed the maximum block bytes
 	byteSize int64
+	// parity parts of the complete part set, computed on first use
+	parityParts []*ParityPart
 }
 
 // Returns an immutable, full PartSet from the data bytes.
@@ -161,6 +161,15 @@ func TestParSetHeaderProtoBuf(t *testing.T) {
 	}
 }
 
+func TestValidateBlockPartSize(t *testing.T) {
+	for _, size := range []uint32{MinBlockPartSizeBytes, BlockPartSizeBytes, 65536} {
+		assert.NoError(t, ValidateBlockPartSize(size), size)
+	}
+	for _, size := range []uint32{0, MinBlockPartSizeBytes / 2, BlockPartSizeBytes * 2, BlockPartSizeBytes + 1, 100000} {
+		assert.Error(t, ValidateBlockPartSize(size), size)
+	}
+}
+
 func TestPartProtoBuf(t *testing.T) {
 
 	proof := merkle.Proof{
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 )
@@ -9,5 +10,5 @@ var (
 	// MaxSignatureSize is a maximum allowed signature size for the Proposal
 	// and Vote.
 	// XXX: secp256k1 does not have Size nor MaxSize defined.
-	MaxSignatureSize = tmmath.MaxInt(ed25519.SignatureSize, 64)
+	MaxSignatureSize = tmmath.MaxInt(tmmath.MaxInt(ed25519.SignatureSize, 64), bls12381.SignatureSize)
 )
@@ -1,11 +1,13 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/batch"
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 )
 
@@ -39,7 +41,7 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	ignore := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagAbsent }
 
 	// only count the signatures that are for the block
-	count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
+	count := func(c CommitSig) bool { return c.ForBlock() }
 
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
@@ -52,6 +54,39 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 		ignore, count, true, true)
 }
 
+// VerifyCommitStructure checks that the given commit is for the block and
+// height, has one signature per validator of the set, and that the signatures
+// for the block claim +2/3 of the voting power of the set.
+//
+// It does NOT verify any signature, so anyone can build a commit which passes
+// it: it must only be used for commits from a trusted source.
+func VerifyCommitStructure(vals *ValidatorSet, blockID BlockID, height int64, commit *Commit) error {
+	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
+		return err
+	}
+	if err := commit.ValidateBasic(); err != nil {
+		return err
+	}
+
+	var tallied int64
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.ForBlock() {
+			continue
+		}
+		val := vals.Validators[idx]
+		if !bytes.Equal(val.Address, commitSig.ValidatorAddress) {
+			return fmt.Errorf("wrong validator address in commit sig #%d: expected %X, got %X",
+				idx, val.Address, commitSig.ValidatorAddress)
+		}
+		tallied += val.VotingPower
+	}
+
+	if votingPowerNeeded := vals.TotalVotingPower() * 2 / 3; tallied <= votingPowerNeeded {
+		return ErrNotEnoughVotingPowerSigned{Got: tallied, Needed: votingPowerNeeded}
+	}
+	return nil
+}
+
 // LIGHT CLIENT VERIFICATION METHODS
 
 // VerifyCommitLight verifies +2/3 of the set had signed the given commit.
@@ -85,6 +120,47 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 		ignore, count, false, true)
 }
 
+// VerifyCommitLightSubset verifies +2/3 of the set had signed the given commit,
+// like VerifyCommitLight, but accepts a commit carrying only a subset of the
+// signatures of the set, in any order. Signatures are matched to validators by
+// address rather than by index, signatures of validators outside of the set
+// are skipped, and so are absent and nil signatures.
+//
+// Verification stops as soon as more than 2/3 of the voting power of the set
+// has been verified, so a commit of the signatures of the largest validators
+// first is the cheapest to verify.
+func VerifyCommitLightSubset(chainID string, vals *ValidatorSet, blockID BlockID,
+	height int64, commit *Commit) error {
+	if vals == nil {
+		return errors.New("nil validator set")
+	}
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+	if err := verifyCommitHeightAndBlockID(commit, height, blockID); err != nil {
+		return err
+	}
+
+	// calculate voting power needed
+	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+
+	// ignore all commit signatures that are not for the block
+	ignore := func(c CommitSig) bool { return c.BlockIDFlag != BlockIDFlagCommit }
+
+	// count all the remaining signatures
+	count := func(c CommitSig) bool { return true }
+
+	// the signatures don't correspond to the validator set by index, so look
+	// them up by address
+	if shouldBatchVerify(vals, commit) {
+		return verifyCommitBatch(chainID, vals, commit,
+			votingPowerNeeded, ignore, count, false, false)
+	}
+
+	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+		ignore, count, false, false)
+}
+
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
 // this commit.
 //
@@ -162,13 +238,13 @@ func verifyCommitBatch(
 	lookUpByIndex bool,
 ) error {
 	var (
-		val                *Validator
-		valIdx             int32
-		talliedVotingPower int64
-		seenVals           = make(map[int32]int, len(commit.Signatures))
-		batchSigIdxs       = make([]int, 0, len(commit.Signatures))
+		val          *Validator
+		valIdx       int32
+		seenVals     = make(map[int32]int, len(commit.Signatures))
+		batchSigIdxs = make([]int, 0, len(commit.Signatures))
 	)
 	// attempt to create a batch verifier
+	batchKeyType := vals.GetProposer().PubKey.Type()
 	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
 	// re-check if batch verification is supported
 	if !ok || len(commit.Signatures) < batchVerifyThreshold {
@@ -176,9 +252,15 @@ func verifyCommitBatch(
 		return fmt.Errorf("unsupported signature algorithm or insufficient signatures for batch verification")
 	}
 
+	talliedVotingPower, err := verifyCommitAggregate(chainID, vals, commit, lookUpByIndex, seenVals)
+	if err != nil {
+		return err
+	}
+
 	for idx, commitSig := range commit.Signatures {
-		// skip over signatures that should be ignored
-		if ignoreSig(commitSig) {
+		// skip over signatures that should be ignored, and the aggregated ones
+		// which have already been verified
+		if ignoreSig(commitSig) || commitSig.BlockIDFlag == BlockIDFlagAggregated {
 			continue
 		}
 
@@ -198,8 +280,7 @@ func verifyCommitBatch(
 			// because we are getting validators by address we need to make sure
 			// that the same validator doesn't commit twice
 			if firstIndex, ok := seenVals[valIdx]; ok {
-				secondIndex := idx
-				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+				return ErrDuplicateCommitSignature{Validator: val, FirstIndex: firstIndex, SecondIndex: idx}
 			}
 			seenVals[valIdx] = idx
 		}
@@ -207,11 +288,18 @@ func verifyCommitBatch(
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 
-		// add the key, sig and message to the verifier
-		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-			return err
+		// add the key, sig and message to the verifier, unless the key is of
+		// another type than the batch verifier's and is verified on its own
+		if val.PubKey.Type() != batchKeyType {
+			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+				return ErrInvalidCommitSignature{Index: idx, Signature: commitSig.Signature}
+			}
+		} else {
+			if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
+				return err
+			}
+			batchSigIdxs = append(batchSigIdxs, idx)
 		}
-		batchSigIdxs = append(batchSigIdxs, idx)
 
 		// If this signature counts then add the voting power of the validator
 		// to the tally
@@ -231,6 +319,9 @@ func verifyCommitBatch(
 	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
 		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
 	}
+	if len(batchSigIdxs) == 0 {
+		return nil
+	}
 
 	// attempt to verify the batch.
 	ok, validSigs := bv.Verify()
@@ -245,8 +336,7 @@ func verifyCommitBatch(
 		if !ok {
 			// go back from the batch index to the commit.Signatures index
 			idx := batchSigIdxs[i]
-			sig := commit.Signatures[idx]
-			return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
+			return ErrInvalidCommitSignature{Index: idx, Signature: commit.Signatures[idx].Signature}
 		}
 	}
 
@@ -275,14 +365,23 @@ func verifyCommitSingle(
 	lookUpByIndex bool,
 ) error {
 	var (
-		val                *Validator
-		valIdx             int32
-		talliedVotingPower int64
-		voteSignBytes      []byte
-		seenVals           = make(map[int32]int, len(commit.Signatures))
+		val           *Validator
+		valIdx        int32
+		voteSignBytes []byte
+		seenVals      = make(map[int32]int, len(commit.Signatures))
 	)
+	talliedVotingPower, err := verifyCommitAggregate(chainID, vals, commit, lookUpByIndex, seenVals)
+	if err != nil {
+		return err
+	}
+	if !countAllSignatures && talliedVotingPower > votingPowerNeeded {
+		return nil
+	}
+
 	for idx, commitSig := range commit.Signatures {
-		if ignoreSig(commitSig) {
+		// skip over signatures that should be ignored, and the aggregated ones
+		// which have already been verified
+		if ignoreSig(commitSig) || commitSig.BlockIDFlag == BlockIDFlagAggregated {
 			continue
 		}
 
@@ -302,8 +401,7 @@ func verifyCommitSingle(
 			// because we are getting validators by address we need to make sure
 			// that the same validator doesn't commit twice
 			if firstIndex, ok := seenVals[valIdx]; ok {
-				secondIndex := idx
-				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+				return ErrDuplicateCommitSignature{Validator: val, FirstIndex: firstIndex, SecondIndex: idx}
 			}
 			seenVals[valIdx] = idx
 		}
@@ -311,7 +409,7 @@ func verifyCommitSingle(
 		voteSignBytes = commit.VoteSignBytes(chainID, int32(idx))
 
 		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+			return ErrInvalidCommitSignature{Index: idx, Signature: commitSig.Signature}
 		}
 
 		// If this signature counts then add the voting power of the validator
@@ -333,6 +431,62 @@ func verifyCommitSingle(
 	return nil
 }
 
+// verifyCommitAggregate verifies the aggregated signature of the commit, if
+// any, and returns the voting power of the validators whose signatures it
+// aggregates. All of them must be known to verify it: if validators are looked
+// up by address and one is not in the set, the aggregated signature is not
+// verified and counts for no voting power.
+func verifyCommitAggregate(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	lookUpByIndex bool,
+	seenVals map[int32]int,
+) (int64, error) {
+	if !commit.isAggregated() && len(commit.AggregatedSignature) == 0 {
+		return 0, nil
+	}
+
+	var (
+		val                *Validator
+		valIdx             int32
+		talliedVotingPower int64
+		pubKeys            []bls12381.PubKey
+		msgs               [][]byte
+	)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag != BlockIDFlagAggregated {
+			continue
+		}
+
+		if lookUpByIndex {
+			val = vals.Validators[idx]
+		} else {
+			valIdx, val = vals.GetByAddress(commitSig.ValidatorAddress)
+			if val == nil {
+				return 0, nil
+			}
+			if firstIndex, ok := seenVals[valIdx]; ok {
+				return 0, ErrDuplicateCommitSignature{Validator: val, FirstIndex: firstIndex, SecondIndex: idx}
+			}
+			seenVals[valIdx] = idx
+		}
+
+		pubKey, ok := val.PubKey.(bls12381.PubKey)
+		if !ok {
+			return 0, fmt.Errorf("aggregated signature #%d of a validator with a %s key", idx, val.PubKey.Type())
+		}
+		pubKeys = append(pubKeys, pubKey)
+		msgs = append(msgs, commit.VoteSignBytes(chainID, int32(idx)))
+		talliedVotingPower += val.VotingPower
+	}
+
+	if !bls12381.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return 0, ErrInvalidCommitAggregatedSignature{Signature: commit.AggregatedSignature}
+	}
+	return talliedVotingPower, nil
+}
+
 func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64, blockID BlockID) error {
 	if vals == nil {
 		return errors.New("nil validator set")
@@ -346,7 +500,10 @@ func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64,
 		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
 	}
 
-	// Validate Height and BlockID.
+	return verifyCommitHeightAndBlockID(commit, height, blockID)
+}
+
+func verifyCommitHeightAndBlockID(commit *Commit, height int64, blockID BlockID) error {
 	if height != commit.Height {
 		return NewErrInvalidCommitHeight(height, commit.Height)
 	}
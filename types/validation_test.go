@@ -2,12 +2,14 @@ package types
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto/bls12381"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -171,6 +173,114 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+func TestVerifyCommitStructure(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	voteSet, valSet, vals := randVoteSet(ctx, t, h, 0, tmproto.PrecommitType, 4, 10)
+	extCommit, err := makeExtCommit(ctx, blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	require.NoError(t, VerifyCommitStructure(valSet, blockID, h, commit))
+	require.Error(t, VerifyCommitStructure(valSet, blockID, h+1, commit))
+	require.Error(t, VerifyCommitStructure(valSet, makeBlockIDRandom(), h, commit))
+
+	// the signatures are not verified
+	vote := voteSet.GetByIndex(3)
+	v := vote.ToProto()
+	require.NoError(t, vals[3].SignVote(ctx, "CentaurusA", v))
+	vote.Signature = v.Signature
+	commit.Signatures[3] = vote.CommitSig()
+	require.NoError(t, VerifyCommitStructure(valSet, blockID, h, commit))
+	require.Error(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+
+	// but the signatures for the block must claim +2/3 of the voting power
+	commit.Signatures[2] = NewCommitSigAbsent()
+	commit.Signatures[3] = NewCommitSigAbsent()
+	err = VerifyCommitStructure(valSet, blockID, h, commit)
+	require.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+}
+
+func TestValidatorSet_VerifyCommit_Aggregated(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// three validators with BLS12-381 keys and one with an ed25519 key
+	privVals := make([]PrivValidator, 4)
+	vals := make([]*Validator, 4)
+	for i := range privVals {
+		pv := NewMockPV()
+		if i < 3 {
+			pv = NewMockPVWithParams(bls12381.GenPrivKey(), false, false)
+		}
+		privVals[i] = pv
+		vals[i] = pv.ExtractIntoValidator(ctx, 10)
+	}
+	sort.Sort(PrivValidatorsByAddress(privVals))
+	valSet := NewValidatorSet(vals)
+
+	voteSet := NewExtendedVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	extCommit, err := makeExtCommit(ctx, blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	commit, err := extCommit.ToCommit().Aggregate(valSet)
+	require.NoError(t, err)
+	require.NoError(t, commit.ValidateBasic())
+	require.NotEqual(t, extCommit.ToCommit().Hash(), commit.Hash())
+
+	var (
+		blsVals []*Validator
+		blsSigs [][]byte
+	)
+	for idx, cs := range commit.Signatures {
+		val := valSet.Validators[idx]
+		if _, ok := val.PubKey.(bls12381.PubKey); ok {
+			require.Equal(t, BlockIDFlagAggregated, cs.BlockIDFlag)
+			blsVals = append(blsVals, val.Copy())
+			blsSigs = append(blsSigs, extCommit.ExtendedSignatures[idx].Signature)
+		} else {
+			require.Equal(t, BlockIDFlagCommit, cs.BlockIDFlag)
+		}
+	}
+	require.Len(t, blsVals, 3)
+
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3}))
+	require.NoError(t, VerifyCommitStructure(valSet, blockID, h, commit))
+
+	// the aggregated signature must be the aggregate of all the signatures
+	// of the validators flagged as aggregated
+	wrongSig, err := bls12381.AggregateSignatures(blsSigs[1:])
+	require.NoError(t, err)
+	wrong := *commit
+	wrong.AggregatedSignature = wrongSig
+	err = valSet.VerifyCommit(chainID, blockID, h, &wrong)
+	require.ErrorAs(t, err, &ErrInvalidCommitAggregatedSignature{})
+	err = valSet.VerifyCommitLight(chainID, blockID, h, &wrong)
+	require.ErrorAs(t, err, &ErrInvalidCommitAggregatedSignature{})
+
+	// a validator set which doesn't have all of the aggregated signers can't
+	// verify the aggregated signature, which counts for no voting power
+	err = NewValidatorSet(blsVals[:2]).VerifyCommitLightTrusting(chainID, commit,
+		tmmath.Fraction{Numerator: 1, Denominator: 3})
+	require.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+	require.NoError(t, NewValidatorSet(blsVals).VerifyCommitLightTrusting(chainID, commit,
+		tmmath.Fraction{Numerator: 1, Denominator: 3}))
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
@@ -290,3 +400,55 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+func TestValidatorSet_VerifyCommitLightSubset(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	voteSet, valSet, vals := randVoteSet(ctx, t, h, 0, tmproto.PrecommitType, 10, 10)
+	extCommit, err := makeExtCommit(ctx, blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	subset := func(indexes ...int) *Commit {
+		c := &Commit{Height: commit.Height, Round: commit.Round, BlockID: commit.BlockID}
+		for _, idx := range indexes {
+			c.Signatures = append(c.Signatures, commit.Signatures[idx])
+		}
+		return c
+	}
+
+	require.NoError(t, valSet.VerifyCommitLightSubset(chainID, blockID, h, commit))
+
+	// 7 out of 10 validators of equal power are enough, in any order
+	require.NoError(t, valSet.VerifyCommitLightSubset(chainID, blockID, h, subset(9, 2, 4, 0, 7, 1, 5)))
+
+	// 6 aren't, and absent signatures don't count
+	c := subset(9, 2, 4, 0, 7, 1, 5)
+	c.Signatures[3] = NewCommitSigAbsent()
+	err = valSet.VerifyCommitLightSubset(chainID, blockID, h, c)
+	assert.True(t, IsErrNotEnoughVotingPowerSigned(err), "unexpected error: %v", err)
+
+	err = valSet.VerifyCommitLightSubset(chainID, blockID, h, subset(9, 2, 9, 0, 7, 1, 5, 3))
+	var duplicate ErrDuplicateCommitSignature
+	if assert.ErrorAs(t, err, &duplicate) {
+		assert.Equal(t, 0, duplicate.FirstIndex)
+		assert.Equal(t, 2, duplicate.SecondIndex)
+	}
+
+	c = subset(9, 2, 4, 0, 7, 1, 5)
+	c.Signatures[1].Signature = c.Signatures[0].Signature
+	err = valSet.VerifyCommitLightSubset(chainID, blockID, h, c)
+	var invalid ErrInvalidCommitSignature
+	if assert.ErrorAs(t, err, &invalid) {
+		assert.Equal(t, 1, invalid.Index)
+	}
+
+	err = valSet.VerifyCommitLightSubset(chainID, blockID, h+1, subset(9, 2, 4, 0, 7, 1, 5))
+	assert.ErrorAs(t, err, &ErrInvalidCommitHeight{})
+}
@@ -664,6 +664,13 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 	return VerifyCommitLight(chainID, vals, blockID, height, commit)
 }
 
+// VerifyCommitLightSubset verifies +2/3 of the set had signed the given commit,
+// which may carry only a subset of the signatures of the set.
+func (vals *ValidatorSet) VerifyCommitLightSubset(chainID string, blockID BlockID,
+	height int64, commit *Commit) error {
+	return VerifyCommitLightSubset(chainID, vals, blockID, height, commit)
+}
+
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
 // this commit.
 func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Commit, trustLevel tmmath.Fraction) error {
@@ -0,0 +1,27 @@
+//go:build testhooks
+// +build testhooks
+
+package types
+
+import "fmt"
+
+// PinProposer makes the validator with the given address the proposer of the
+// set, overriding the one selected by the proposer priority accumulator.
+//
+// It is meant for test harnesses that need a specific proposer at a given
+// height: pinning the proposer of a state's NextValidators makes that
+// validator propose round 0 of the next height. The proposer priorities are
+// left untouched, so later rounds and heights go back to the accumulator's
+// choice. Every node of the network must pin the same proposer, otherwise
+// they disagree on the expected proposer and reject each other's proposals.
+//
+// It is only compiled with the testhooks build tag, so production binaries
+// cannot call it.
+func (vals *ValidatorSet) PinProposer(address Address) error {
+	_, val := vals.GetByAddress(address)
+	if val == nil {
+		return fmt.Errorf("validator %v is not in the set", address)
+	}
+	vals.Proposer = val
+	return nil
+}
@@ -0,0 +1,36 @@
+//go:build testhooks
+// +build testhooks
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorSetPinProposer(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 300),
+		newValidator([]byte("baz"), 330),
+	})
+	unpinned := vset.Copy()
+	require.Equal(t, Address("foo"), vset.GetProposer().Address)
+
+	require.Error(t, vset.PinProposer([]byte("qux")))
+	require.Equal(t, Address("foo"), vset.GetProposer().Address)
+
+	require.NoError(t, vset.PinProposer([]byte("bar")))
+	require.Equal(t, Address("bar"), vset.GetProposer().Address)
+
+	// the pinned proposer survives a copy, e.g. when the next validators of a
+	// state become its validators
+	require.Equal(t, Address("bar"), vset.Copy().GetProposer().Address)
+
+	// priorities are untouched, so the accumulator takes over afterwards
+	vset.IncrementProposerPriority(1)
+	unpinned.IncrementProposerPriority(1)
+	require.Equal(t, unpinned.GetProposer().Address, vset.GetProposer().Address)
+	require.Equal(t, unpinned.Validators, vset.Validators)
+}
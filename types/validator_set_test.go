@@ -1608,6 +1608,48 @@ func BenchmarkValidatorSet_VerifyCommitLightTrusting_Ed25519(b *testing.B) {
 	}
 }
 
+// BenchmarkValidatorSet_VerifyCommitLightEarlyExit compares checking all the
+// signatures of a commit with light verification, which stops once more than
+// 2/3 of the voting power signed, on commits of large validator sets.
+func BenchmarkValidatorSet_VerifyCommitLightEarlyExit(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, n := range []int{128, 1024} {
+		var (
+			chainID = "test_chain_id"
+			h       = int64(3)
+			blockID = makeBlockIDRandom()
+		)
+		voteSet, valSet, vals := randVoteSet(ctx, b, h, 0, tmproto.PrecommitType, n, 10)
+		extCommit, err := makeExtCommit(ctx, blockID, h, 0, voteSet, vals, time.Now())
+		require.NoError(b, err)
+		commit := extCommit.ToCommit()
+
+		// a commit of just enough signatures
+		subset := &Commit{Height: commit.Height, Round: commit.Round, BlockID: commit.BlockID}
+		subset.Signatures = commit.Signatures[:n*2/3+1]
+
+		for _, bc := range []struct {
+			name   string
+			verify func() error
+		}{
+			{"all signatures", func() error { return valSet.VerifyCommit(chainID, blockID, h, commit) }},
+			{"light", func() error { return valSet.VerifyCommitLight(chainID, blockID, h, commit) }},
+			{"light subset", func() error { return valSet.VerifyCommitLightSubset(chainID, blockID, h, subset) }},
+		} {
+			b.Run(fmt.Sprintf("%s/valset size %d", bc.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := bc.verify(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
 // Testing Utils
 
 // deterministicValidatorSet returns a deterministic validator set (size: +numValidators+),